@@ -3,8 +3,8 @@ package add
 import (
 	"strconv"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
-	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -93,7 +93,11 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 	deploymentName := args[0]
 
 	// Get base config and check if deployment already exists
-	config := configutil.GetBaseConfig()
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, generatedConfig, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 	if config.Deployments != nil {
 		for _, deployConfig := range *config.Deployments {
 			if *deployConfig.Name == deploymentName {
@@ -113,11 +117,6 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 	} else if cmd.Chart != "" {
 		newDeployment, err = configure.GetHelmDeployment(deploymentName, cmd.Chart, cmd.ChartRepo, cmd.ChartVersion)
 	} else if cmd.Dockerfile != "" {
-		generatedConfig, err := generated.LoadConfig()
-		if err != nil {
-			log.Fatal(err)
-		}
-
 		newImage, newDeployment, err = configure.GetDockerfileComponentDeployment(config, generatedConfig, deploymentName, cmd.Image, cmd.Dockerfile, cmd.Context)
 	} else if cmd.Image != "" {
 		newImage, newDeployment, err = configure.GetImageComponentDeployment(deploymentName, cmd.Image)
@@ -136,7 +135,7 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 	}
 
 	// Restore vars in config
-	clonedConfig, err := configutil.RestoreVars(config)
+	clonedConfig, err := loader.RestoreVars(config)
 	if err != nil {
 		log.Fatalf("Error restoring vars: %v", err)
 	}
@@ -191,7 +190,7 @@ func (cmd *deploymentCmd) RunAddDeployment(cobraCmd *cobra.Command, args []strin
 	(*clonedConfig.Deployments) = append([]*latest.DeploymentConfig{newDeployment}, (*clonedConfig.Deployments)...)
 
 	// Save config
-	err = configutil.SaveConfig(clonedConfig)
+	err = loader.Save(clonedConfig)
 	if err != nil {
 		log.Fatalf("Couldn't save config file: %s", err.Error())
 	}
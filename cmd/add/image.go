@@ -1,6 +1,7 @@
 package add
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -61,7 +62,14 @@ func (cmd *imageCmd) RunAddImage(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
 	}
 
-	err = configure.AddImage(args[0], cmd.Name, cmd.Tag, cmd.ContextPath, cmd.DockerfilePath, cmd.BuildEngine)
+	// Load base config
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = configure.AddImage(config, loader, args[0], cmd.Name, cmd.Tag, cmd.ContextPath, cmd.DockerfilePath, cmd.BuildEngine)
 	if err != nil {
 		log.Fatal(err)
 	}
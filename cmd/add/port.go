@@ -1,6 +1,7 @@
 package add
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -11,6 +12,7 @@ type portCmd struct {
 	LabelSelector string
 	Namespace     string
 	Service       string
+	BindAddress   string
 }
 
 func newPortCmd() *cobra.Command {
@@ -26,6 +28,7 @@ func newPortCmd() *cobra.Command {
 Add a new port mapping to your DevSpace configuration
 (format is local:remote comma separated):
 devspace add port 8080:80,3000
+devspace add port 8080 --bind 0.0.0.0
 #######################################################
 	`,
 		Args: cobra.ExactArgs(1),
@@ -35,6 +38,7 @@ devspace add port 8080:80,3000
 	addPortCmd.Flags().StringVar(&cmd.Namespace, "namespace", "", "Namespace to use")
 	addPortCmd.Flags().StringVar(&cmd.LabelSelector, "label-selector", "", "Comma separated key=value label-selector list (e.g. release=test)")
 	addPortCmd.Flags().StringVar(&cmd.Service, "selector", "", "Name of a selector defined in your DevSpace config")
+	addPortCmd.Flags().StringVar(&cmd.BindAddress, "bind", "", "Local address to bind the ports to (e.g. 0.0.0.0, default: 127.0.0.1)")
 
 	return addPortCmd
 }
@@ -50,7 +54,14 @@ func (cmd *portCmd) RunAddPort(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
 	}
 
-	err = configure.AddPort(cmd.Namespace, cmd.LabelSelector, cmd.Service, args)
+	// Load base config
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = configure.AddPort(config, loader, cmd.Namespace, cmd.LabelSelector, cmd.Service, cmd.BindAddress, args)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -1,15 +1,22 @@
 package add
 
 import (
+	"net/url"
+	"strings"
+
 	cloudpkg "github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud/config"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud/config/versions/latest"
 
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
-type providerCmd struct{}
+type providerCmd struct {
+	Host string
+	Key  string
+}
 
 func newProviderCmd() *cobra.Command {
 	cmd := &providerCmd{}
@@ -21,16 +28,21 @@ func newProviderCmd() *cobra.Command {
 #######################################################
 ############## devspace add provider ##################
 #######################################################
-Add a new cloud provider.
+Add a new cloud provider. If no --key is supplied the
+browser will be opened to login
 
 Example:
 devspace add provider app.devspace.cloud
+devspace add provider my-cloud --host http://10.0.0.5:8080 --key myaccesskey
 #######################################################
 	`,
 		Args: cobra.ExactArgs(1),
 		Run:  cmd.RunAddProvider,
 	}
 
+	addProviderCmd.Flags().StringVar(&cmd.Host, "host", "", "Host of the cloud provider (Default: https://[NAME])")
+	addProviderCmd.Flags().StringVar(&cmd.Key, "key", "", "Access key to login into the cloud provider")
+
 	return addProviderCmd
 }
 
@@ -38,6 +50,16 @@ devspace add provider app.devspace.cloud
 func (cmd *providerCmd) RunAddProvider(cobraCmd *cobra.Command, args []string) {
 	providerName := args[0]
 
+	host := "https://" + providerName
+	if cmd.Host != "" {
+		var err error
+
+		host, err = parseProviderHost(cmd.Host)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// Get provider configuration
 	providerConfig, err := config.ParseProviderConfig()
 	if err != nil {
@@ -51,16 +73,27 @@ func (cmd *providerCmd) RunAddProvider(cobraCmd *cobra.Command, args []string) {
 	if provider == nil {
 		providerConfig.Providers = append(providerConfig.Providers, &latest.Provider{
 			Name: providerName,
-			Host: "https://" + providerName,
+			Host: host,
 		})
-	} else {
-		provider.Host = "https://" + providerName
+	} else if provider.Host != host {
+		// The credentials belong to the old host
+		provider.Host = host
+		provider.Key = ""
+		provider.Token = ""
 	}
 
-	// Ensure user is logged in
-	err = cloudpkg.EnsureLoggedIn(providerConfig, providerName, log.GetInstance())
-	if err != nil {
-		log.Fatalf("Couldn't login to provider: %v", err)
+	if cmd.Key != "" {
+		// Login with the key, this validates the key against the provider api
+		err = cloudpkg.ReLogin(providerConfig, providerName, &cmd.Key, log.GetInstance())
+		if err != nil {
+			log.Fatalf("Couldn't login to provider: %v", err)
+		}
+	} else {
+		// Ensure user is logged in
+		err = cloudpkg.EnsureLoggedIn(providerConfig, providerName, log.GetInstance())
+		if err != nil {
+			log.Fatalf("Couldn't login to provider: %v", err)
+		}
 	}
 
 	err = config.SaveProviderConfig(providerConfig)
@@ -70,3 +103,19 @@ func (cmd *providerCmd) RunAddProvider(cobraCmd *cobra.Command, args []string) {
 
 	log.Donef("Successfully added cloud provider %s", providerName)
 }
+
+// parseProviderHost validates the host and removes trailing slashes
+func parseProviderHost(host string) (string, error) {
+	parsedURL, err := url.Parse(host)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse host %s", host)
+	}
+	if parsedURL.Scheme != "http" && parsedURL.Scheme != "https" {
+		return "", errors.Errorf("Host %s has to start with http:// or https://", host)
+	}
+	if parsedURL.Host == "" {
+		return "", errors.Errorf("Host %s is missing a hostname", host)
+	}
+
+	return strings.TrimRight(host, "/"), nil
+}
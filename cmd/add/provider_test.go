@@ -0,0 +1,23 @@
+package add
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseProviderHost(t *testing.T) {
+	host, err := parseProviderHost("http://10.0.0.5:8080/")
+	assert.NilError(t, err)
+	assert.Equal(t, "http://10.0.0.5:8080", host)
+
+	host, err = parseProviderHost("https://app.devspace.cloud")
+	assert.NilError(t, err)
+	assert.Equal(t, "https://app.devspace.cloud", host)
+
+	_, err = parseProviderHost("app.devspace.cloud")
+	assert.Error(t, err, "Host app.devspace.cloud has to start with http:// or https://")
+
+	_, err = parseProviderHost("https://")
+	assert.Error(t, err, "Host https:// is missing a hostname")
+}
@@ -1,6 +1,7 @@
 package add
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -50,7 +51,14 @@ func (cmd *selectorCmd) RunAddSelector(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
 	}
 
-	err = configure.AddSelector(args[0], cmd.LabelSelector, cmd.Namespace, true)
+	// Load base config
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = configure.AddSelector(config, loader, args[0], cmd.LabelSelector, cmd.Namespace, true)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -1,6 +1,7 @@
 package add
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -60,7 +61,14 @@ func (cmd *syncCmd) RunAddSync(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
 	}
 
-	err = configure.AddSyncPath(cmd.LocalPath, cmd.ContainerPath, cmd.Namespace, cmd.LabelSelector, cmd.ExcludedPaths, cmd.Service)
+	// Load base config
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = configure.AddSyncPath(config, loader, cmd.LocalPath, cmd.ContainerPath, cmd.Namespace, cmd.LabelSelector, cmd.ExcludedPaths, cmd.Service)
 	if err != nil {
 		log.Fatalf("Error adding sync path: %v", err)
 	}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/analyze"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -28,8 +29,11 @@ func NewAnalyzeCmd() *cobra.Command {
 #######################################################
 ################## devspace analyze ###################
 #######################################################
-Analyze checks a namespaces events, replicasets, services
-and pods for potential problems
+Analyze checks a namespaces events, replicasets,
+statefulsets, persistent volume claims and pods for
+potential problems (e.g. CrashLoopBackOff,
+ImagePullBackOff, pending pods or unbound volumes) and
+prints the logs of crashed containers
 
 Example:
 devspace analyze
@@ -56,9 +60,9 @@ func (cmd *AnalyzeCmd) RunAnalyze(cobraCmd *cobra.Command, args []string) {
 
 	var devSpaceConfig *latest.Config
 	if configExists {
-		devSpaceConfig = configutil.GetConfig()
+		var generatedConfig *generated.Config
 
-		generatedConfig, err := generated.LoadConfig()
+		devSpaceConfig, generatedConfig, err = configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -76,19 +80,9 @@ func (cmd *AnalyzeCmd) RunAnalyze(cobraCmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
-	namespace := ""
-	if configExists == true {
-		config := configutil.GetConfig()
-
-		namespace, err = configutil.GetDefaultNamespace(config)
-		if err != nil {
-			log.Fatal(err)
-		}
-	} else {
-		namespace, err = configutil.GetDefaultNamespace(nil)
-		if err != nil {
-			log.Fatal(err)
-		}
+	namespace, err := configutil.GetDefaultNamespace(devSpaceConfig)
+	if err != nil {
+		log.Fatal(err)
 	}
 
 	// Override namespace
@@ -3,6 +3,7 @@ package cmd
 import (
 	"strings"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/build"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
 	"github.com/mgutz/ansi"
@@ -10,6 +11,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/spf13/cobra"
 )
@@ -21,7 +23,11 @@ type BuildCmd struct {
 
 	ForceBuild        bool
 	BuildSequential   bool
+	BuildLogDir       string
 	ForceDependencies bool
+
+	// commandVars are the config variables passed with --var
+	commandVars map[string]string
 }
 
 // NewBuildCmd creates a new devspace build command
@@ -44,6 +50,7 @@ Builds all defined images and pushes them
 
 	buildCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to build every image")
 	buildCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
+	buildCmd.Flags().StringVar(&cmd.BuildLogDir, "build-log-dir", "", "Writes the build output of each image to [dir]/[image].log (e.g. .devspace/logs)")
 	buildCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", false, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 
 	buildCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
@@ -53,6 +60,8 @@ Builds all defined images and pushes them
 
 // Run executes the command logic
 func (cmd *BuildCmd) Run(cobraCmd *cobra.Command, args []string) {
+	cmd.commandVars = flags.CommandVars(cobraCmd)
+
 	// Set config root
 	configExists, err := configutil.SetDevSpaceRoot()
 	if err != nil {
@@ -63,17 +72,17 @@ func (cmd *BuildCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Start file logging
-	log.StartFileLogging()
+	log.StartFileLogging("build")
+
+	// Get the config
+	config, generatedConfig := cmd.loadConfig()
 
-	// Load config
-	generatedConfig, err := generated.LoadConfig()
+	// Execute the before:build hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.Before, "build", log.GetInstance())
 	if err != nil {
-		log.Fatalf("Error loading generated.yaml: %v", err)
+		log.Fatal(err)
 	}
 
-	// Get the config
-	config := cmd.loadConfig(generatedConfig)
-
 	// Dependencies
 	err = dependency.BuildAll(config, generatedConfig, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.ForceBuild, log.GetInstance())
 	if err != nil {
@@ -81,7 +90,7 @@ func (cmd *BuildCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Build images if necessary
-	builtImages, err := build.All(config, generatedConfig.GetActive(), nil, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, log.GetInstance())
+	builtImages, err := build.All(config, generatedConfig.GetActive(), nil, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, cmd.BuildLogDir, log.GetInstance())
 	if err != nil {
 		if strings.Index(err.Error(), "no space left on device") != -1 {
 			log.Fatalf("Error building image: %v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
@@ -101,20 +110,20 @@ func (cmd *BuildCmd) Run(cobraCmd *cobra.Command, args []string) {
 	} else {
 		log.Info("No images to rebuild. Run with -b to force rebuilding")
 	}
-}
 
-func (cmd *BuildCmd) loadConfig(generatedConfig *generated.Config) *latest.Config {
-	// Load Config and modify it
-	config, err := configutil.GetConfigFromPath(".", generatedConfig.ActiveConfig, true, generatedConfig, log.GetInstance())
+	// Execute the after:build hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.After, "build", log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
+}
 
-	// Save generated config
-	err = generated.SaveConfig(generatedConfig)
+func (cmd *BuildCmd) loadConfig() (*latest.Config, *generated.Config) {
+	// Load Config and modify it
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: cmd.commandVars}).Load()
 	if err != nil {
-		log.Fatalf("Couldn't save generated config: %v", err)
+		log.Fatal(err)
 	}
 
-	return config
+	return config, generatedConfig
 }
@@ -3,15 +3,23 @@ package cleanup
 import (
 	"context"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/filters"
+	dockerclient "github.com/docker/docker/client"
 	"github.com/spf13/cobra"
 )
 
 type imagesCmd struct {
+	All      bool
+	Registry bool
 }
 
 func newImagesCmd() *cobra.Command {
@@ -19,18 +27,27 @@ func newImagesCmd() *cobra.Command {
 
 	imagesCmd := &cobra.Command{
 		Use:   "images",
-		Short: "Deletes all locally created images from docker",
+		Short: "Deletes old images created by devspace from docker",
 		Long: ` 
 #######################################################
 ############# devspace cleanup images #################
 #######################################################
-Deletes all locally created docker images from docker
+Deletes the old tags of the images in the config that
+were created by devspace and all dangling images from
+docker. Tags that are still in use are kept:
+
+devspace cleanup images
+devspace cleanup images --registry
+devspace cleanup images --all
 #######################################################
 	`,
 		Args: cobra.NoArgs,
 		Run:  cmd.RunCleanupImages,
 	}
 
+	imagesCmd.Flags().BoolVar(&cmd.All, "all", false, "Deletes all local tags of the images, including the tags that are in use")
+	imagesCmd.Flags().BoolVar(&cmd.Registry, "registry", false, "Deletes the old tags from the registry as well")
+
 	return imagesCmd
 }
 
@@ -46,13 +63,15 @@ func (cmd *imagesCmd) RunCleanupImages(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Load config
-	config := configutil.GetConfig()
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 	if config.Images == nil || len(*config.Images) == 0 {
 		log.Done("No images found in config to delete")
 		return
 	}
 
-	// Create docker client
 	client, err := docker.NewClient(config, true, log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
@@ -65,20 +84,46 @@ func (cmd *imagesCmd) RunCleanupImages(cobraCmd *cobra.Command, args []string) {
 
 	defer log.StopWait()
 
-	// Delete all images
+	// The tags of the image caches are still in use. Only tags generated by devspace are deleted
+	usedTags := generatedConfig.GetUsedImageTags()
+	generatedTags := generatedConfig.GetGeneratedImageTags()
+
+	// Delete the images
 	for _, imageConfig := range *config.Images {
-		log.StartWait("Deleting local image " + *imageConfig.Image)
+		imageName := registry.GetAliasedImageName(config, *imageConfig.Image)
+		keepTags := getKeepTags(imageConfig, usedTags[imageName])
+
+		if cmd.All {
+			log.StartWait("Deleting local image " + imageName)
+			response, err := docker.DeleteImageByName(client, imageName, log.GetInstance())
+			if err != nil {
+				log.Fatal(err)
+			}
 
-		response, err := docker.DeleteImageByName(client, *imageConfig.Image, log.GetInstance())
-		if err != nil {
-			log.Fatal(err)
+			printDeleteResponse(response)
+		} else {
+			log.StartWait("Deleting old tags of local image " + imageName)
+			tags, err := docker.GetImageTags(client, imageName)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			for _, tag := range getOldTags(tags, generatedTags[imageName], keepTags) {
+				response, err := docker.RemoveImageTag(client, imageName, tag)
+				if err != nil {
+					log.Warnf("%v", err)
+					continue
+				}
+
+				printDeleteResponse(response)
+			}
 		}
 
-		for _, t := range response {
-			if t.Deleted != "" {
-				log.Donef("Deleted %s", t.Deleted)
-			} else if t.Untagged != "" {
-				log.Donef("Untagged %s", t.Untagged)
+		if cmd.Registry {
+			log.StartWait("Deleting old tags of image " + imageName + " in registry")
+			err = deleteOldRegistryTags(client, generatedConfig, imageName, generatedTags[imageName], keepTags)
+			if err != nil {
+				log.Warnf("Unable to delete old tags of image %s in registry: %v", imageName, err)
 			}
 		}
 	}
@@ -92,13 +137,7 @@ func (cmd *imagesCmd) RunCleanupImages(cobraCmd *cobra.Command, args []string) {
 			log.Fatal(err)
 		}
 
-		for _, t := range response {
-			if t.Deleted != "" {
-				log.Donef("Deleted %s", t.Deleted)
-			} else if t.Untagged != "" {
-				log.Donef("Untagged %s", t.Untagged)
-			}
-		}
+		printDeleteResponse(response)
 
 		if len(response) == 0 {
 			break
@@ -106,5 +145,95 @@ func (cmd *imagesCmd) RunCleanupImages(cobraCmd *cobra.Command, args []string) {
 	}
 
 	log.StopWait()
+
+	// Save the generated tags that were deleted from the registry
+	if cmd.Registry {
+		err = generated.SaveConfig(generatedConfig)
+		if err != nil {
+			log.Errorf("Error saving generated.yaml: %v", err)
+		}
+	}
+
 	log.Donef("Successfully cleaned up images")
 }
+
+// getKeepTags returns the tags of the image that are in use or set in the config
+func getKeepTags(imageConfig *latest.ImageConfig, usedTags []string) []string {
+	keepTags := append([]string{}, usedTags...)
+	if imageConfig.Tag != nil {
+		keepTags = append(keepTags, *imageConfig.Tag)
+	}
+	if imageConfig.Tags != nil {
+		keepTags = append(keepTags, *imageConfig.Tags...)
+	}
+
+	return keepTags
+}
+
+// getOldTags returns the tags that were generated by devspace and are not kept
+func getOldTags(tags []string, generatedTags []string, keepTags []string) []string {
+	old := map[string]bool{}
+	for _, tag := range generatedTags {
+		old[tag] = true
+	}
+	for _, tag := range keepTags {
+		delete(old, tag)
+	}
+
+	oldTags := []string{}
+	for _, tag := range tags {
+		if old[tag] {
+			oldTags = append(oldTags, tag)
+		}
+	}
+
+	return oldTags
+}
+
+func deleteOldRegistryTags(client dockerclient.CommonAPIClient, generatedConfig *generated.Config, imageName string, generatedTags []string, keepTags []string) error {
+	repository, err := registry.NewRepository(client, imageName)
+	if err != nil {
+		return err
+	}
+
+	tags, err := repository.Tags()
+	if err != nil {
+		return err
+	}
+
+	// Tags that were not generated by devspace are kept as well, so their manifests are not deleted with an old tag
+	oldTags := getOldTags(tags, generatedTags, keepTags)
+	for _, tag := range tags {
+		if contains(oldTags, tag) == false {
+			keepTags = append(keepTags, tag)
+		}
+	}
+
+	deletedTags, err := repository.DeleteTags(oldTags, keepTags)
+	for _, tag := range deletedTags {
+		log.Donef("Deleted %s:%s from registry", imageName, tag)
+	}
+
+	generatedConfig.RemoveGeneratedImageTags(imageName, deletedTags)
+	return err
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printDeleteResponse(response []types.ImageDeleteResponseItem) {
+	for _, t := range response {
+		if t.Deleted != "" {
+			log.Donef("Deleted %s", t.Deleted)
+		} else if t.Untagged != "" {
+			log.Donef("Untagged %s", t.Untagged)
+		}
+	}
+}
@@ -4,18 +4,22 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/build"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
-	v1 "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
 	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/notification"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/mgutz/ansi"
 	"github.com/spf13/cobra"
 )
@@ -28,14 +32,26 @@ type DeployCmd struct {
 
 	ForceBuild        bool
 	BuildSequential   bool
+	BuildLogDir       string
 	ForceDeploy       bool
 	Deployments       string
 	ForceDependencies bool
 
 	SwitchContext bool
 	SkipPush      bool
+	RunTests      bool
+	DebugChart    bool
+	Events        bool
+	Wait          bool
 
 	AllowCyclicDependencies bool
+
+	Image  string
+	Ports  []int
+	Expose bool
+
+	// commandVars are the config variables passed with --var
+	commandVars map[string]string
 }
 
 // NewDeployCmd creates a new deploy command
@@ -55,6 +71,7 @@ devspace deploy
 devspace deploy --namespace=deploy
 devspace deploy --namespace=deploy
 devspace deploy --kube-context=deploy-context
+devspace deploy --image nginx:latest --port 80 --expose
 #######################################################`,
 		Args: cobra.NoArgs,
 		Run:  cmd.Run,
@@ -67,18 +84,34 @@ devspace deploy --kube-context=deploy-context
 
 	deployCmd.Flags().BoolVar(&cmd.SwitchContext, "switch-context", false, "Switches the kube context to the deploy context")
 	deployCmd.Flags().BoolVar(&cmd.SkipPush, "skip-push", false, "Skips image pushing, useful for minikube deployment")
+	deployCmd.Flags().BoolVar(&cmd.Events, "events", true, "Print warning events of the devspace resources (e.g. FailedScheduling or Unhealthy) while deploying")
+	deployCmd.Flags().BoolVar(&cmd.RunTests, "test", false, "Runs the helm tests of all helm deployments after they were deployed")
+	deployCmd.Flags().BoolVar(&cmd.DebugChart, "debug-chart", false, "Writes the rendered templates of all helm deployments to a temp dir and shows the complete manifests of rejected objects")
+	deployCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Waits until the Deployments, StatefulSets and Jobs of all deployments are ready")
 
 	deployCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to (re-)build every image")
 	deployCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
+	deployCmd.Flags().StringVar(&cmd.BuildLogDir, "build-log-dir", "", "Writes the build output of each image to [dir]/[image].log (e.g. .devspace/logs)")
 	deployCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
 	deployCmd.Flags().BoolVar(&cmd.ForceDependencies, "force-dependencies", false, "Forces to re-evaluate dependencies (use with --force-build --force-deploy to actually force building & deployment of dependencies)")
 	deployCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
 
+	deployCmd.Flags().StringVar(&cmd.Image, "image", "", "Deploys the given image without a devspace.yaml (quick deploy)")
+	deployCmd.Flags().IntSliceVar(&cmd.Ports, "port", []int{}, "Container ports to create a service for (only with --image)")
+	deployCmd.Flags().BoolVar(&cmd.Expose, "expose", false, "Creates a service of type LoadBalancer for the ports (only with --image)")
+
 	return deployCmd
 }
 
 // Run executes the down command logic
 func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
+	cmd.commandVars = flags.CommandVars(cobraCmd)
+
+	if cmd.Image != "" {
+		cmd.quickDeploy()
+		return
+	}
+
 	// Set config root
 	configExists, err := configutil.SetDevSpaceRoot()
 	if err != nil {
@@ -89,17 +122,20 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Start file logging
-	log.StartFileLogging()
+	log.StartFileLogging("deploy")
+
+	// Prepare the config
+	config, generatedConfig := cmd.loadConfig()
+
+	// Notify about the result of the deployment
+	notifier := notification.Start(config, generatedConfig, "deploy", log.GetInstance())
 
-	// Load generated config
-	generatedConfig, err := generated.LoadConfig()
+	// Execute the before:deploy hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.Before, "deploy", log.GetInstance())
 	if err != nil {
-		log.Fatalf("Error loading generated.yaml: %v", err)
+		log.Fatal(err)
 	}
 
-	// Prepare the config
-	config := cmd.loadConfig(generatedConfig)
-
 	// Signal that we are working on the space if there is any
 	err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
 	if err != nil {
@@ -118,6 +154,14 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 		log.Fatalf("Unable to create namespace: %v", err)
 	}
 
+	// Print warning events of the devspace resources while deploying
+	if cmd.Events {
+		eventLogger := startEventLogging(config, client)
+		if eventLogger != nil {
+			defer eventLogger.Close()
+		}
+	}
+
 	// Create cluster binding if necessary
 	err = kubectl.EnsureGoogleCloudClusterRoleBinding(config, client, log.GetInstance())
 	if err != nil {
@@ -126,9 +170,17 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 
 	// Create docker client
 	dockerClient, err := docker.NewClient(config, false, log.GetInstance())
+	if err != nil {
+		dockerClient = nil
+	}
 
 	// Create pull secrets and private registry if necessary
-	err = registry.CreatePullSecrets(config, dockerClient, client, log.GetInstance())
+	err = registry.CreatePullSecrets(config, generatedConfig, dockerClient, client, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = registry.CreateDeploymentPullSecrets(config, generatedConfig, dockerClient, log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -140,7 +192,7 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Build images
-	builtImages, err := build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, false, cmd.ForceBuild, cmd.BuildSequential, log.GetInstance())
+	builtImages, err := build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, false, cmd.ForceBuild, cmd.BuildSequential, cmd.BuildLogDir, log.GetInstance())
 	if err != nil {
 		if strings.Index(err.Error(), "no space left on device") != -1 {
 			err = fmt.Errorf("%v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
@@ -178,6 +230,14 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 		log.Fatalf("Error saving generated config: %v", err)
 	}
 
+	// Execute the after:deploy hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.After, "deploy", log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	notifier.Success()
+
 	if generatedConfig.CloudSpace != nil {
 		log.Donef("Successfully deployed!")
 		log.Infof("\r          \nRun: \n- `%s` to create an ingress for the app and open it in the browser \n- `%s` to open a shell into the container \n- `%s` to show the container logs\n- `%s` to open the management ui\n- `%s` to analyze the space for potential issues\n", ansi.Color("devspace open", "white+b"), ansi.Color("devspace enter", "white+b"), ansi.Color("devspace logs", "white+b"), ansi.Color("devspace ui", "white+b"), ansi.Color("devspace analyze", "white+b"))
@@ -187,36 +247,98 @@ func (cmd *DeployCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 }
 
-func (cmd *DeployCmd) loadConfig(generatedConfig *generated.Config) *latest.Config {
+// quickDeploy deploys the image with a component deployment that is created on the fly, so no devspace.yaml is required
+func (cmd *DeployCmd) quickDeploy() {
+	deploymentConfig := configure.GetQuickDeployment("", cmd.Image, cmd.Ports, cmd.Expose)
+	config := &latest.Config{
+		Version:     ptr.String(latest.Version),
+		Deployments: &[]*latest.DeploymentConfig{deploymentConfig},
+		Cluster:     &latest.Cluster{},
+	}
+	if cmd.Namespace != "" {
+		config.Cluster.Namespace = &cmd.Namespace
+	}
+	if cmd.KubeContext != "" {
+		config.Cluster.KubeContext = &cmd.KubeContext
+	}
+
+	// Create kubectl client
+	client, err := kubectl.NewClientWithContextSwitch(config, cmd.SwitchContext)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %v", err)
+	}
+
+	// Create namespace if necessary
+	err = kubectl.EnsureDefaultNamespace(config, client, log.GetInstance())
+	if err != nil {
+		log.Fatalf("Unable to create namespace: %v", err)
+	}
+
+	namespace, err := configutil.GetDefaultNamespace(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The cache is not saved, because there is no project to save it to
+	err = deploy.All(config, generated.NewCache(), client, false, true, nil, nil, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Donef("Successfully deployed %s as %s in namespace %s", cmd.Image, *deploymentConfig.Name, namespace)
+	if cmd.Expose && len(cmd.Ports) > 0 {
+		log.Infof("Run `%s` to get the external ip of the service", ansi.Color(fmt.Sprintf("kubectl get services -n %s", namespace), "white+b"))
+	}
+
+	// Tiller runs in the deployment namespace unless the component specifies another one
+	tillerNamespace := namespace
+	if deploymentConfig.Component.Options != nil && deploymentConfig.Component.Options.TillerNamespace != nil && *deploymentConfig.Component.Options.TillerNamespace != "" {
+		tillerNamespace = *deploymentConfig.Component.Options.TillerNamespace
+	}
+
+	log.Infof("Run `%s` to remove the deployment again", ansi.Color(fmt.Sprintf("helm delete --purge %s --tiller-namespace %s", *deploymentConfig.Name, tillerNamespace), "white+b"))
+}
+
+func (cmd *DeployCmd) loadConfig() (*latest.Config, *generated.Config) {
 	// Load Config and modify it
-	config, err := configutil.GetConfigFromPath(".", generatedConfig.ActiveConfig, true, generatedConfig, log.GetInstance())
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{
+		Namespace:   cmd.Namespace,
+		KubeContext: cmd.KubeContext,
+		CommandVars: cmd.commandVars,
+	}).Load()
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	if cmd.Namespace != "" {
-		config.Cluster = &v1.Cluster{
-			Namespace:   &cmd.Namespace,
-			KubeContext: config.Cluster.KubeContext,
-		}
-
 		log.Infof("Using %s namespace for deploying", cmd.Namespace)
 	}
 
 	if cmd.KubeContext != "" {
-		config.Cluster = &v1.Cluster{
-			Namespace:   config.Cluster.Namespace,
-			KubeContext: &cmd.KubeContext,
+		log.Infof("Using %s kube context for deploying", cmd.KubeContext)
+	}
+
+	if cmd.RunTests && config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			if deployConfig.Helm != nil {
+				deployConfig.Helm.RunTests = &cmd.RunTests
+			}
 		}
+	}
 
-		log.Infof("Using %s kube context for deploying", cmd.KubeContext)
+	if cmd.DebugChart && config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			if deployConfig.Helm != nil {
+				deployConfig.Helm.DebugChart = &cmd.DebugChart
+			}
+		}
 	}
 
-	// Save generated config
-	err = generated.SaveConfig(generatedConfig)
-	if err != nil {
-		log.Fatalf("Couldn't save generated config: %v", err)
+	if cmd.Wait && config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			deployConfig.Wait = &cmd.Wait
+		}
 	}
 
-	return config
+	return config, generatedConfig
 }
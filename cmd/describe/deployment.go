@@ -0,0 +1,315 @@
+package describe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	deployComponent "github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
+	deployHelm "github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
+	deployKubectl "github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+	yaml "gopkg.in/yaml.v2"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+type deploymentCmd struct{}
+
+func newDeploymentCmd() *cobra.Command {
+	cmd := &deploymentCmd{}
+
+	return &cobra.Command{
+		Use:   "deployment [name]",
+		Short: "Shows everything devspace knows about a deployment",
+		Long: `
+#######################################################
+############ devspace describe deployment #############
+#######################################################
+Shows the resolved type, chart or manifests, the final
+values, the injected images, the target namespace, the
+last deployment and the live resources of a deployment
+
+Example:
+devspace describe deployment my-deployment
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		Run:  cmd.RunDescribeDeployment,
+	}
+}
+
+// RunDescribeDeployment executes the devspace describe deployment command logic
+func (cmd *deploymentCmd) RunDescribeDeployment(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find any devspace configuration. Please run `devspace init`")
+	}
+
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var deployConfig *latest.DeploymentConfig
+	if config.Deployments != nil {
+		for _, deployment := range *config.Deployments {
+			if *deployment.Name == args[0] {
+				deployConfig = deployment
+				break
+			}
+		}
+	}
+	if deployConfig == nil {
+		log.Fatalf("Couldn't find deployment %s", args[0])
+	}
+
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatalf("Error loading generated.yaml: %v", err)
+	}
+	cache := generatedConfig.GetActive()
+
+	client, err := kubectl.NewClient(config)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %v", err)
+	}
+
+	var (
+		deployClient deploy.Interface
+		values       map[interface{}]interface{}
+		release      *hapi_release5.Release
+		manifests    string
+		namespace    string
+	)
+
+	if deployConfig.Kubectl != nil {
+		kubectlClient, err := deployKubectl.New(config, client, deployConfig, log.GetInstance())
+		if err != nil {
+			log.Fatalf("Unable to create kubectl deploy config for %s: %v", *deployConfig.Name, err)
+		}
+
+		namespace = kubectlClient.Namespace
+		manifests, err = kubectlClient.GetManifests(cache)
+		if err != nil {
+			log.Warnf("Error rendering manifests: %v", err)
+		}
+
+		deployClient = kubectlClient
+	} else if deployConfig.Component != nil && deployConfig.Component.RenderOnly != nil && *deployConfig.Component.RenderOnly == true {
+		componentClient, err := deployComponent.New(config, client, deployConfig, log.GetInstance())
+		if err != nil {
+			log.Fatalf("Unable to create component deploy config for %s: %v", *deployConfig.Name, err)
+		}
+
+		namespace = componentClient.KubectlConfig.Namespace
+		values, _, err = componentClient.GetValues(cache, nil)
+		if err != nil {
+			log.Warnf("Error retrieving values: %v", err)
+		}
+
+		manifests, err = componentClient.GetManifests(cache)
+		if err != nil {
+			log.Warnf("Error rendering manifests: %v", err)
+		}
+
+		deployClient = componentClient
+	} else if deployConfig.Helm != nil || deployConfig.Component != nil {
+		var helmClient *deployHelm.DeployConfig
+		if deployConfig.Helm != nil {
+			helmClient, err = deployHelm.New(config, client, deployConfig, log.GetInstance())
+			if err != nil {
+				log.Fatalf("Unable to create helm deploy config for %s: %v", *deployConfig.Name, err)
+			}
+
+			deployClient = helmClient
+		} else {
+			componentClient, err := deployComponent.New(config, client, deployConfig, log.GetInstance())
+			if err != nil {
+				log.Fatalf("Unable to create component deploy config for %s: %v", *deployConfig.Name, err)
+			}
+
+			helmClient = componentClient.HelmConfig
+			deployClient = componentClient
+		}
+
+		namespace, err = configutil.GetDefaultNamespace(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if deployConfig.Namespace != nil && *deployConfig.Namespace != "" {
+			namespace = *deployConfig.Namespace
+		}
+
+		values, _, err = helmClient.GetValues(cache, nil)
+		if err != nil {
+			log.Warnf("Error retrieving values: %v", err)
+		}
+
+		release, err = helmClient.GetRelease()
+		if err != nil {
+			log.Warnf("Error retrieving helm release: %v", err)
+		}
+		if release != nil {
+			manifests = release.Manifest
+		}
+	} else {
+		log.Fatalf("Deployment %s has no deployment method configured", *deployConfig.Name)
+	}
+
+	status, err := deployClient.Status()
+	if err != nil {
+		log.Fatalf("Error retrieving status for deployment %s: %v", *deployConfig.Name, err)
+	}
+
+	// General information
+	log.PrintTable(log.GetInstance(), []string{"NAME", "TYPE", "NAMESPACE", "DEPLOY", "STATUS"}, [][]string{
+		{status.Name, status.Type, namespace, status.Target, status.Status},
+	})
+
+	// Last deployment
+	deployCache := cache.Deployments[*deployConfig.Name]
+	lastDeployed := "N/A"
+	if release != nil && release.Info != nil && release.Info.LastDeployed != nil {
+		lastDeployed = formatTime(release.Info.LastDeployed.Seconds) + " (Revision " + strconv.Itoa(int(release.Version)) + ")"
+	} else if deployCache != nil && deployCache.LastDeployed > 0 {
+		lastDeployed = formatTime(deployCache.LastDeployed)
+	}
+
+	log.WriteString("\nLast deployed: " + lastDeployed + "\n")
+	if deployCache != nil {
+		log.WriteString(getHashes(deployCache))
+	}
+
+	// Final values
+	valuesOut := []byte{}
+	if values != nil {
+		valuesOut, err = yaml.Marshal(values)
+		if err != nil {
+			log.Fatalf("Error marshalling values: %v", err)
+		}
+	}
+
+	// Injected images are the images that appear in the rendered manifests or in the values
+	images := [][]string{}
+	for imageConfigName, imageCache := range cache.Images {
+		if imageCache.ImageName == "" || imageCache.Tag == "" {
+			continue
+		}
+		if strings.Index(manifests, imageCache.ImageName) == -1 && strings.Index(string(valuesOut), imageCache.ImageName) == -1 {
+			continue
+		}
+
+		images = append(images, []string{imageConfigName, imageCache.ImageName + ":" + imageCache.Tag})
+	}
+	if len(images) > 0 {
+		log.WriteString("\nImages:\n")
+		log.PrintTable(log.GetInstance(), []string{"IMAGE", "INJECTED"}, images)
+	}
+
+	if values != nil {
+		log.WriteString("\nValues:\n" + string(valuesOut))
+	}
+
+	// Live resources
+	resources, err := deploy.GetResources(manifests, namespace)
+	if err != nil {
+		log.Fatalf("Error parsing manifests: %v", err)
+	}
+	if len(resources) > 0 {
+		resourceValues := [][]string{}
+		for _, resource := range resources {
+			resourceValues = append(resourceValues, []string{resource.Kind, resource.Name, resource.Namespace, getResourceStatus(client, resource)})
+		}
+
+		log.WriteString("\nResources:\n")
+		log.PrintTable(log.GetInstance(), []string{"KIND", "NAME", "NAMESPACE", "STATUS"}, resourceValues)
+	}
+}
+
+func formatTime(seconds int64) string {
+	deployed := time.Unix(seconds, 0)
+	return deployed.Format(time.RFC1123) + " (" + time.Since(deployed).Round(time.Second).String() + " ago)"
+}
+
+func getHashes(deployCache *generated.DeploymentCache) string {
+	hashes := ""
+	if deployCache.DeploymentConfigHash != "" {
+		hashes += "Deployment config hash: " + deployCache.DeploymentConfigHash + "\n"
+	}
+	if deployCache.HelmChartHash != "" {
+		hashes += "Chart hash: " + deployCache.HelmChartHash + "\n"
+	}
+	if deployCache.HelmOverridesHash != "" {
+		hashes += "Values files hash: " + deployCache.HelmOverridesHash + "\n"
+	}
+	if deployCache.KubectlManifestsHash != "" {
+		hashes += "Manifests hash: " + deployCache.KubectlManifestsHash + "\n"
+	}
+
+	return hashes
+}
+
+// getResourceStatus returns a short status of the live resource in the cluster
+func getResourceStatus(client kubernetes.Interface, resource *deploy.Resource) string {
+	var err error
+	status := "Exists"
+
+	switch resource.Kind {
+	case "Pod":
+		pod, getErr := client.CoreV1().Pods(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status = kubectl.GetPodStatus(pod)
+		}
+	case "Deployment":
+		deployment, getErr := kubectl.GetDeployment(client, resource.Namespace, resource.Name)
+		if err = getErr; err == nil {
+			status = fmt.Sprintf("%d/%d ready", deployment.Status.ReadyReplicas, deployment.Status.Replicas)
+		}
+	case "StatefulSet":
+		statefulSet, getErr := client.AppsV1().StatefulSets(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status = fmt.Sprintf("%d/%d ready", statefulSet.Status.ReadyReplicas, statefulSet.Status.Replicas)
+		}
+	case "Service":
+		service, getErr := client.CoreV1().Services(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status = string(service.Spec.Type) + " " + service.Spec.ClusterIP
+		}
+	case "ConfigMap":
+		_, err = client.CoreV1().ConfigMaps(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	case "Secret":
+		_, err = client.CoreV1().Secrets(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+	case "PersistentVolumeClaim":
+		pvc, getErr := client.CoreV1().PersistentVolumeClaims(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err = getErr; err == nil {
+			status = string(pvc.Status.Phase)
+		}
+	default:
+		return "N/A"
+	}
+
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return "Not found"
+		}
+
+		return "Error: " + err.Error()
+	}
+
+	return status
+}
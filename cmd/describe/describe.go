@@ -0,0 +1,23 @@
+package describe
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewDescribeCmd creates a new cobra command for the describe sub command
+func NewDescribeCmd() *cobra.Command {
+	describeCmd := &cobra.Command{
+		Use:   "describe",
+		Short: "Shows detailed information about a resource",
+		Long: `
+#######################################################
+################# devspace describe ###################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	describeCmd.AddCommand(newDeploymentCmd())
+
+	return describeCmd
+}
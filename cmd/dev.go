@@ -7,23 +7,32 @@ import (
 	"sync"
 	"time"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/build"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/debug"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/remote"
 	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
+	"github.com/devspace-cloud/devspace/pkg/devspace/health"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
 	"github.com/devspace-cloud/devspace/pkg/devspace/watch"
 	"github.com/mgutz/ansi"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
-	v1 "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/notification"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"k8s.io/client-go/kubernetes"
 )
@@ -35,6 +44,7 @@ type DevCmd struct {
 
 	ForceBuild        bool
 	BuildSequential   bool
+	BuildLogDir       string
 	ForceDeploy       bool
 	Deployments       string
 	ForceDependencies bool
@@ -45,13 +55,32 @@ type DevCmd struct {
 	SkipPipeline    bool
 	SwitchContext   bool
 	Portforwarding  bool
+	Debug           bool
 	VerboseSync     bool
 	Selector        string
 	Container       string
 	LabelSelector   string
+	Pod             string
 	Namespace       string
+	Events          bool
+	Wait            bool
+
+	HealthPort    int
+	HealthTimeout time.Duration
+
+	notifier *notification.Notifier
+	health   *health.Reporter
+
+	// watchedConfig is a copy of the config as it was loaded, changed configs are compared against it
+	watchedConfig *latest.Config
+
+	// commandVars are the config variables passed with --var
+	commandVars map[string]string
 }
 
+// healthFailedGracePeriod is the time the health endpoint keeps reporting the failed phase before devspace dev exits
+const healthFailedGracePeriod = time.Second * 5
+
 // NewDevCmd creates a new devspace dev command
 func NewDevCmd() *cobra.Command {
 	cmd := &DevCmd{}
@@ -77,6 +106,7 @@ Starts your project in development mode:
 
 	devCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to build every image")
 	devCmd.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", false, "Builds the images one after another instead of in parallel")
+	devCmd.Flags().StringVar(&cmd.BuildLogDir, "build-log-dir", "", "Writes the build output of each image to [dir]/[image].log (e.g. .devspace/logs)")
 
 	devCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to deploy every deployment")
 	devCmd.Flags().StringVar(&cmd.Deployments, "deployments", "", "Only deploy a specifc deployment (You can specify multiple deployments comma-separated")
@@ -89,22 +119,31 @@ Starts your project in development mode:
 	devCmd.Flags().BoolVar(&cmd.VerboseSync, "verbose-sync", false, "When enabled the sync will log every file change")
 
 	devCmd.Flags().BoolVar(&cmd.Portforwarding, "portforwarding", true, "Enable port forwarding")
+	devCmd.Flags().BoolVar(&cmd.Debug, "debug", false, "Starts the images in dev.debug under a debugger and forwards the debug ports")
 
+	devCmd.Flags().BoolVar(&cmd.Events, "events", true, "Print warning events of the devspace resources (e.g. FailedScheduling or Unhealthy)")
 	devCmd.Flags().BoolVar(&cmd.Terminal, "terminal", true, "Enable terminal (true or false)")
 	devCmd.Flags().StringVarP(&cmd.Selector, "selector", "s", "", "Selector name (in config) to select pods/container for terminal")
 	devCmd.Flags().StringVarP(&cmd.Container, "container", "c", "", "Container name where to open the shell")
 	devCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list to use for terminal (e.g. release=test)")
+	devCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to open the terminal to")
 
 	devCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "The namespace to deploy to")
 
 	devCmd.Flags().BoolVar(&cmd.SwitchContext, "switch-context", false, "Switch kubectl context to the DevSpace context")
 	devCmd.Flags().BoolVar(&cmd.ExitAfterDeploy, "exit-after-deploy", false, "Exits the command after building the images and deploying the project")
+	devCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Waits until the Deployments, StatefulSets and Jobs of all deployments are ready before starting the services")
+
+	devCmd.Flags().IntVar(&cmd.HealthPort, "health-port", 0, "Serves the state of the session on http://127.0.0.1:[port]/healthz (e.g. for supervisors in CI)")
+	devCmd.Flags().DurationVar(&cmd.HealthTimeout, "health-timeout", 0, "Reports the session as unhealthy if building, deploying or starting the services takes longer (e.g. 30m)")
 
 	return devCmd
 }
 
 // Run executes the command logic
 func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) {
+	cmd.commandVars = flags.CommandVars(cobraCmd)
+
 	// Set config root
 	configExists, err := configutil.SetDevSpaceRoot()
 	if err != nil {
@@ -115,16 +154,24 @@ func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Start file logging
-	log.StartFileLogging()
+	log.StartFileLogging("dev")
 
-	// Load config
-	generatedConfig, err := generated.LoadConfig()
-	if err != nil {
-		log.Fatalf("Error loading generated.yaml: %v", err)
+	// Get the config
+	config, generatedConfig := cmd.loadConfig()
+
+	// Notify about the result of the pipeline
+	cmd.notifier = notification.Start(config, generatedConfig, "dev", log.GetInstance())
+
+	// Serve the state of the session
+	if cmd.HealthPort > 0 {
+		cmd.startHealthEndpoint()
 	}
 
-	// Get the config
-	config := cmd.loadConfig(generatedConfig)
+	// Execute the before:dev hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.Before, "dev", log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	// Signal that we are working on the space if there is any
 	err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
@@ -138,12 +185,26 @@ func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) {
 		log.Fatalf("Unable to create new kubectl client: %v", err)
 	}
 
+	// Delete the build pods that were kept running for rebuilds when the session ends
+	defer helper.DeleteReusableBuildPods(log.GetInstance())
+	log.OnFatal(func(message string) {
+		helper.DeleteReusableBuildPods(log.Discard)
+	})
+
 	// Create namespace if necessary
 	err = kubectl.EnsureDefaultNamespace(config, client, log.GetInstance())
 	if err != nil {
 		log.Fatalf("Unable to create namespace: %v", err)
 	}
 
+	// Print warning events of the devspace resources
+	if cmd.Events {
+		eventLogger := startEventLogging(config, client)
+		if eventLogger != nil {
+			defer eventLogger.Close()
+		}
+	}
+
 	// Create cluster role binding if necessary
 	err = kubectl.EnsureGoogleCloudClusterRoleBinding(config, client, log.GetInstance())
 	if err != nil {
@@ -156,20 +217,60 @@ func (cmd *DevCmd) Run(cobraCmd *cobra.Command, args []string) {
 		dockerClient = nil
 	}
 
-	err = registry.CreatePullSecrets(config, dockerClient, client, log.GetInstance())
+	err = registry.CreatePullSecrets(config, generatedConfig, dockerClient, client, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = registry.CreateDeploymentPullSecrets(config, generatedConfig, dockerClient, log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	// Make sure that the port forwardings will not fail because of duplicate local ports
+	if cmd.Portforwarding && cmd.ExitAfterDeploy == false {
+		err = cmd.checkPortConflicts(config, generatedConfig)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	// Build and deploy images
 	err = cmd.buildAndDeploy(config, generatedConfig, client, args)
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	// Execute the after:dev hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.After, "dev", log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func (cmd *DevCmd) startHealthEndpoint() {
+	cmd.health = health.NewReporter(cmd.HealthTimeout)
+
+	_, err := cmd.health.Serve(cmd.HealthPort)
+	if err != nil {
+		log.Fatalf("Unable to start health endpoint: %v", err)
+	}
+
+	log.OnFatal(func(message string) {
+		cmd.health.SetPhase(health.PhaseFailed)
+		cmd.health.Error(errors.New(message))
+
+		// Give supervisors polling the endpoint the chance to see the failure before the process exits
+		time.Sleep(healthFailedGracePeriod)
+	})
+
+	log.Infof("Health endpoint available at http://127.0.0.1:%d%s", cmd.HealthPort, health.Path)
 }
 
 func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, args []string) error {
 	if cmd.SkipPipeline == false {
+		cmd.health.SetPhase(health.PhaseBuilding)
+
 		// Dependencies
 		err := dependency.DeployAll(config, generatedConfig, cmd.AllowCyclicDependencies, false, cmd.SkipPush, cmd.ForceDependencies, cmd.ForceBuild, cmd.ForceDeploy, log.GetInstance())
 		if err != nil {
@@ -177,7 +278,7 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 		}
 
 		// Build image if necessary
-		builtImages, err := build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, log.GetInstance())
+		builtImages, err := build.All(config, generatedConfig.GetActive(), client, cmd.SkipPush, true, cmd.ForceBuild, cmd.BuildSequential, cmd.BuildLogDir, log.GetInstance())
 		if err != nil {
 			if strings.Index(err.Error(), "no space left on device") != -1 {
 				return fmt.Errorf("Error building image: %v\n\n Try running `%s` to free docker daemon space and retry", err, ansi.Color("devspace cleanup images", "white+b"))
@@ -196,17 +297,9 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 
 		// Deploy all defined deployments
 		if config.Deployments != nil {
-			// What deployments should be deployed
-			deployments := []string{}
-			if cmd.Deployments != "" {
-				deployments = strings.Split(cmd.Deployments, ",")
-				for index := range deployments {
-					deployments[index] = strings.TrimSpace(deployments[index])
-				}
-			}
-
 			// Deploy all
-			err = deploy.All(config, generatedConfig.GetActive(), client, true, cmd.ForceDeploy, builtImages, deployments, log.GetInstance())
+			cmd.health.SetPhase(health.PhaseDeploying)
+			err = deploy.All(config, generatedConfig.GetActive(), client, true, cmd.ForceDeploy, builtImages, cmd.getDeployments(), log.GetInstance())
 			if err != nil {
 				return fmt.Errorf("Error deploying: %v", err)
 			}
@@ -217,23 +310,181 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 				return fmt.Errorf("Error saving generated config: %v", err)
 			}
 		}
+
+		if cmd.notifier != nil {
+			cmd.notifier.Success()
+		}
+	}
+
+	return cmd.runServices(config, generatedConfig, client, args)
+}
+
+// runServices starts the services and reloads the pipeline if a change was detected
+func (cmd *DevCmd) runServices(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, args []string) error {
+	if cmd.ExitAfterDeploy {
+		return nil
 	}
 
 	// Start services
-	if cmd.ExitAfterDeploy == false {
-		// Start services
-		err := cmd.startServices(config, client, args, log.GetInstance())
-		if err != nil {
-			// Check if we should reload
-			if _, ok := err.(*reloadError); ok {
-				// Get the config
-				config := cmd.loadConfig(generatedConfig)
+	cmd.health.SetPhase(health.PhaseServices)
+	err := cmd.startServices(config, client, args, log.GetInstance())
+	if err != nil {
+		// Check if we should reload
+		reloadErr, ok := err.(*reloadError)
+		if ok == false {
+			return err
+		}
 
-				// Trigger rebuild & redeploy
-				return cmd.buildAndDeploy(config, generatedConfig, client, args)
+		cmd.health.SetPhase(health.PhaseReloading)
+		cmd.health.RemoveServices()
+
+		// Only rebuild and redeploy what changed if the config itself changed
+		if reloadErr.diff != nil {
+			cmd.rediffConfig(reloadErr)
+			if reloadErr.diff.Other == false {
+				return cmd.reloadChanges(reloadErr.config, reloadErr.generatedConfig, client, reloadErr.diff, args)
 			}
 
-			return err
+			return cmd.buildAndDeploy(reloadErr.config, reloadErr.generatedConfig, client, args)
+		}
+
+		// Get the config
+		config, generatedConfig := cmd.loadConfig()
+
+		// Trigger rebuild & redeploy
+		return cmd.buildAndDeploy(config, generatedConfig, client, args)
+	}
+
+	return nil
+}
+
+// reloadChanges rebuilds the changed images, redeploys the changed deployments and the deployments that use a
+// rebuilt image and restarts the services
+func (cmd *DevCmd) reloadChanges(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, diff *configutil.ConfigDiff, args []string) error {
+	for _, deploymentName := range diff.RemovedDeployments {
+		log.Warnf("Deployment %s was removed from the config. Run `%s` to delete it", deploymentName, ansi.Color("devspace purge -d "+deploymentName, "white+b"))
+	}
+
+	if cmd.SkipPipeline == false && (len(diff.Images) > 0 || len(diff.Deployments) > 0) {
+		cmd.health.SetPhase(health.PhaseBuilding)
+
+		// Rebuild the changed images
+		builtImages := make(map[string]string)
+		if len(diff.Images) > 0 {
+			images := map[string]*latest.ImageConfig{}
+			for _, imageName := range diff.Images {
+				images[imageName] = (*config.Images)[imageName]
+			}
+
+			imagesConfig := *config
+			imagesConfig.Images = &images
+
+			var err error
+			builtImages, err = build.All(&imagesConfig, generatedConfig.GetActive(), client, cmd.SkipPush, true, true, cmd.BuildSequential, cmd.BuildLogDir, log.GetInstance())
+			if err != nil {
+				return fmt.Errorf("Error building image: %v", err)
+			}
+		}
+
+		// Redeploy the changed deployments and the deployments that might use a rebuilt image
+		changedDeployments := []string{}
+		unchangedDeployments := []string{}
+		if config.Deployments != nil {
+			for _, deployConfig := range *config.Deployments {
+				if cmd.shouldDeploy(*deployConfig.Name) == false {
+					continue
+				}
+
+				if contains(diff.Deployments, *deployConfig.Name) {
+					changedDeployments = append(changedDeployments, *deployConfig.Name)
+				} else {
+					unchangedDeployments = append(unchangedDeployments, *deployConfig.Name)
+				}
+			}
+		}
+
+		cmd.health.SetPhase(health.PhaseDeploying)
+		if len(changedDeployments) > 0 {
+			err := deploy.All(config, generatedConfig.GetActive(), client, true, true, builtImages, changedDeployments, log.GetInstance())
+			if err != nil {
+				return fmt.Errorf("Error deploying: %v", err)
+			}
+		}
+		if len(builtImages) > 0 && len(unchangedDeployments) > 0 {
+			err := deploy.All(config, generatedConfig.GetActive(), client, true, false, builtImages, unchangedDeployments, log.GetInstance())
+			if err != nil {
+				return fmt.Errorf("Error deploying: %v", err)
+			}
+		}
+
+		err := generated.SaveConfig(generatedConfig)
+		if err != nil {
+			return fmt.Errorf("Error saving generated config: %v", err)
+		}
+
+		if cmd.notifier != nil {
+			cmd.notifier.Success()
+		}
+	}
+
+	return cmd.runServices(config, generatedConfig, client, args)
+}
+
+// getDeployments returns the deployments selected with --deployments or an empty slice if all deployments should be deployed
+func (cmd *DevCmd) getDeployments() []string {
+	deployments := []string{}
+	if cmd.Deployments != "" {
+		deployments = strings.Split(cmd.Deployments, ",")
+		for index := range deployments {
+			deployments[index] = strings.TrimSpace(deployments[index])
+		}
+	}
+
+	return deployments
+}
+
+func (cmd *DevCmd) shouldDeploy(deploymentName string) bool {
+	deployments := cmd.getDeployments()
+	return len(deployments) == 0 || contains(deployments, deploymentName)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkPortConflicts checks that no two port mappings of the config use the same local port. Port mappings
+// of dependencies are not forwarded, so conflicts with them are only reported as a warning
+func (cmd *DevCmd) checkPortConflicts(config *latest.Config, generatedConfig *generated.Config) error {
+	claims := services.GetPortClaims(constants.DefaultConfigPath, config)
+
+	conflicts := services.FindPortConflicts(claims)
+	if len(conflicts) > 0 {
+		messages := make([]string, 0, len(conflicts))
+		for _, conflict := range conflicts {
+			messages = append(messages, conflict.String())
+		}
+
+		return fmt.Errorf("Duplicate local ports in port forwarding config:\n%s", strings.Join(messages, "\n"))
+	}
+
+	if cmd.SkipPipeline == false {
+		dependencies, err := dependency.GetAll(config, generatedConfig, cmd.AllowCyclicDependencies, log.GetInstance())
+		if err != nil {
+			return fmt.Errorf("Error resolving dependencies: %v", err)
+		}
+
+		for _, dep := range dependencies {
+			claims = append(claims, services.GetPortClaims("dependency "+dep.ID, dep.Config)...)
+		}
+
+		for _, conflict := range services.FindPortConflicts(claims) {
+			log.Warnf("%s. Running devspace dev for these configs at the same time will fail", conflict.String())
 		}
 	}
 
@@ -242,7 +493,7 @@ func (cmd *DevCmd) buildAndDeploy(config *latest.Config, generatedConfig *genera
 
 func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interface, args []string, log log.Logger) error {
 	if cmd.Portforwarding {
-		portForwarder, err := services.StartPortForwarding(config, client, log)
+		portForwarder, err := services.StartPortForwarding(config, client, cmd.health, log)
 		if err != nil {
 			return fmt.Errorf("Unable to start portforwarding: %v", err)
 		}
@@ -259,6 +510,9 @@ func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interf
 		if err != nil {
 			return fmt.Errorf("Unable to start sync: %v", err)
 		}
+		if len(syncConfigs) > 0 {
+			cmd.health.SetService("sync", health.StateRunning, nil)
+		}
 
 		defer func() {
 			for _, v := range syncConfigs {
@@ -270,27 +524,39 @@ func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interf
 	exitChan := make(chan error)
 	autoReloadPaths := GetPaths(config)
 
+	// Only the first detected change triggers a reload
+	var once sync.Once
+	reload := func(err *reloadError) {
+		once.Do(func() {
+			log.Info("Change detected, will reload in 2 seconds")
+			time.Sleep(time.Second * 2)
+
+			exitChan <- err
+		})
+	}
+
 	// Start watcher if we have at least one auto reload path and if we should not skip the pipeline
 	if cmd.SkipPipeline == false && len(autoReloadPaths) > 0 {
-		var once sync.Once
 		watcher, err := watch.New(autoReloadPaths, func(changed []string, deleted []string) error {
-			once.Do(func() {
-				log.Info("Change detected, will reload in 2 seconds")
-				time.Sleep(time.Second * 2)
-
-				exitChan <- &reloadError{}
-			})
-
+			reload(&reloadError{})
 			return nil
 		}, log)
 		if err != nil {
 			return err
 		}
 
+		watcher.PollInterval = settings.Milliseconds(settings.GetLimits().WatchPollInterval, watcher.PollInterval)
 		watcher.Start()
 		defer watcher.Stop()
 	}
 
+	// Reload the config if it changes
+	configWatcher, err := cmd.watchConfig(reload, log)
+	if err != nil {
+		return err
+	}
+	defer configWatcher.Stop()
+
 	// Build params
 	params := targetselector.CmdParameter{}
 	if cmd.Selector != "" {
@@ -302,18 +568,27 @@ func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interf
 	if cmd.LabelSelector != "" {
 		params.LabelSelector = &cmd.LabelSelector
 	}
+	if cmd.Pod != "" {
+		params.PodName = &cmd.Pod
+	}
 	if cmd.Namespace != "" {
 		params.Namespace = &cmd.Namespace
 	}
 
+	cmd.health.SetPhase(health.PhaseRunning)
 	if cmd.Terminal && (config.Dev == nil || config.Dev.Terminal == nil || config.Dev.Terminal.Disabled == nil || *config.Dev.Terminal.Disabled == false) {
+		// Open all configured terminals if the terminal is not selected by flags or args
+		if config.Dev != nil && config.Dev.Terminals != nil && len(args) == 0 && cmd.Selector == "" && cmd.LabelSelector == "" && cmd.Pod == "" && cmd.Container == "" {
+			return services.StartTerminals(config, client, exitChan, log)
+		}
+
 		return services.StartTerminal(config, client, params, args, exitChan, log)
 	}
 
 	log.Info("Will now try to print the logs of a running pod...")
 
 	// Start attaching to a running pod
-	err := services.StartAttach(config, client, params, exitChan, log)
+	err = services.StartAttach(config, client, params, exitChan, log)
 	if err != nil {
 		// If it's a reload error we return that so we can rebuild & redeploy
 		if _, ok := err.(*reloadError); ok {
@@ -321,6 +596,7 @@ func (cmd *DevCmd) startServices(config *latest.Config, client kubernetes.Interf
 		}
 
 		log.Infof("Couldn't print logs of running pod: %v", err)
+		cmd.health.Error(err)
 	}
 
 	log.Done("Services started (Press Ctrl+C to abort port-forwarding and sync)")
@@ -349,6 +625,11 @@ func GetPaths(config *latest.Config) []string {
 							}
 						} else if deployConf.Kubectl != nil && deployConf.Kubectl.Manifests != nil {
 							for _, manifestPath := range *deployConf.Kubectl.Manifests {
+								// Manifests from urls cannot be watched
+								if remote.IsURL(*manifestPath) {
+									continue
+								}
+
 								paths = append(paths, *manifestPath)
 							}
 						}
@@ -384,34 +665,117 @@ func GetPaths(config *latest.Config) []string {
 	return paths
 }
 
+// watchConfig watches the config files and requests a reload with the changes between the current and the changed
+// config. Changes that neither affect the pipeline nor the services are ignored
+func (cmd *DevCmd) watchConfig(reload func(err *reloadError), log log.Logger) (*watch.Watcher, error) {
+	watcher, err := watch.New([]string{constants.DefaultConfigPath, constants.DefaultConfigsPath}, func(changed []string, deleted []string) error {
+		newConfig, newGeneratedConfig, err := cmd.tryLoadConfig()
+		if err != nil {
+			log.Warnf("Unable to reload config, will keep the current config: %v", err)
+			return nil
+		}
+
+		// Compare against the copy, because the deployed config was modified by the pipeline
+		newWatchedConfig, err := configutil.CopyConfig(newConfig)
+		if err != nil {
+			log.Warnf("Unable to reload config, will keep the current config: %v", err)
+			return nil
+		}
+
+		// The snapshot is only replaced when the reload is executed, so that changes after the first one are not lost
+		diff := configutil.Diff(cmd.watchedConfig, newWatchedConfig)
+		if diff.Empty() {
+			return nil
+		}
+
+		reload(&reloadError{
+			config:          newConfig,
+			generatedConfig: newGeneratedConfig,
+			diff:            diff,
+		})
+		return nil
+	}, log)
+	if err != nil {
+		return nil, err
+	}
+
+	watcher.PollInterval = settings.Milliseconds(settings.GetLimits().WatchPollInterval, watcher.PollInterval)
+	watcher.Start()
+	return watcher, nil
+}
+
+// rediffConfig loads the config again and diffs it against the watched config, because the config might have been
+// changed again while the reload was pending. The loaded config becomes the new watched config
+func (cmd *DevCmd) rediffConfig(reloadErr *reloadError) {
+	config, generatedConfig, err := cmd.tryLoadConfig()
+	if err != nil {
+		log.Warnf("Unable to reload config, will use the first changed config: %v", err)
+		config, generatedConfig = reloadErr.config, reloadErr.generatedConfig
+	}
+
+	watchedConfig, err := configutil.CopyConfig(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	reloadErr.config = config
+	reloadErr.generatedConfig = generatedConfig
+	reloadErr.diff = configutil.Diff(cmd.watchedConfig, watchedConfig)
+	cmd.watchedConfig = watchedConfig
+}
+
+// reloadError is returned by startServices if a change was detected. If the config changed, the error contains the
+// changed config and the diff to the current config
 type reloadError struct {
+	config          *latest.Config
+	generatedConfig *generated.Config
+	diff            *configutil.ConfigDiff
 }
 
 func (r *reloadError) Error() string {
 	return ""
 }
 
-func (cmd *DevCmd) loadConfig(generatedConfig *generated.Config) *latest.Config {
-	// Load Config and modify it
-	config, err := configutil.GetConfigFromPath(".", generatedConfig.ActiveConfig, true, generatedConfig, log.GetInstance())
+func (cmd *DevCmd) loadConfig() (*latest.Config, *generated.Config) {
+	config, generatedConfig, err := cmd.tryLoadConfig()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if cmd.Namespace != "" {
-		config.Cluster = &v1.Cluster{
-			Namespace:   &cmd.Namespace,
-			KubeContext: config.Cluster.KubeContext,
-		}
+	cmd.watchedConfig, err = configutil.CopyConfig(config)
+	if err != nil {
+		log.Fatal(err)
+	}
 
+	if cmd.Namespace != "" {
 		log.Infof("Using %s namespace", cmd.Namespace)
 	}
 
-	// Save generated config
-	err = generated.SaveConfig(generatedConfig)
+	return config, generatedConfig
+}
+
+func (cmd *DevCmd) tryLoadConfig() (*latest.Config, *generated.Config, error) {
+	// Load Config and modify it
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{
+		Namespace:   cmd.Namespace,
+		CommandVars: cmd.commandVars,
+	}).Load()
 	if err != nil {
-		log.Fatalf("Couldn't save generated config: %v", err)
+		return nil, nil, err
+	}
+
+	if cmd.Debug {
+		err = debug.Apply(config, log.GetInstance())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if cmd.Wait && config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			deployConfig.Wait = &cmd.Wait
+		}
 	}
 
-	return config
+	return config, generatedConfig, nil
 }
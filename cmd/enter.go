@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
@@ -55,7 +56,7 @@ devspace enter bash -l release=test
 	enterCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 	enterCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to select pods")
 	enterCmd.Flags().BoolVar(&cmd.SwitchContext, "switch-context", false, "Switch kubectl context to the DevSpace context")
-	enterCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod")
+	enterCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod (use --pick=false to use the newest pod if multiple pods match)")
 
 	return enterCmd
 }
@@ -71,9 +72,9 @@ func (cmd *EnterCmd) Run(cobraCmd *cobra.Command, args []string) {
 	// Get config
 	var config *latest.Config
 	if configutil.ConfigExists() {
-		config = configutil.GetConfig()
+		var generatedConfig *generated.Config
 
-		generatedConfig, err := generated.LoadConfig()
+		config, generatedConfig, err = configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -107,7 +108,7 @@ func (cmd *EnterCmd) Run(cobraCmd *cobra.Command, args []string) {
 	if cmd.Pod != "" {
 		params.PodName = &cmd.Pod
 	}
-	if cmd.Pick != false {
+	if cobraCmd.Flags().Changed("pick") {
 		params.Pick = &cmd.Pick
 	}
 
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EventsCmd holds the events cmd flags
+type EventsCmd struct {
+	Namespace    string
+	Follow       bool
+	WarningsOnly bool
+}
+
+// NewEventsCmd creates a new events command
+func NewEventsCmd() *cobra.Command {
+	cmd := &EventsCmd{}
+
+	eventsCmd := &cobra.Command{
+		Use:   "events",
+		Short: "Prints the kubernetes events of the devspace resources",
+		Long: `
+#######################################################
+################### devspace events ###################
+#######################################################
+Events prints the kubernetes events of all resources
+that belong to a deployment or match a selector of the
+devspace and optionally follows them
+
+Example:
+devspace events
+devspace events --follow --warnings-only
+devspace events --namespace=mynamespace
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunEvents,
+	}
+
+	eventsCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to watch the events")
+	eventsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Print new events as they occur")
+	eventsCmd.Flags().BoolVarP(&cmd.WarningsOnly, "warnings-only", "w", false, "Only print events of type Warning")
+
+	return eventsCmd
+}
+
+// RunEvents executes the functionality devspace events
+func (cmd *EventsCmd) RunEvents(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Signal that we are working on the space if there is any
+	err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Get kubectl client
+	client, err := kubectl.NewClient(config)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %v", err)
+	}
+
+	namespace := cmd.Namespace
+	if namespace == "" {
+		namespace, err = configutil.GetDefaultNamespace(config)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Print the current events
+	events, err := services.GetEvents(client, services.NewEventFilter(config, client), namespace, cmd.WarningsOnly)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, event := range events {
+		services.PrintEvent(event, log.GetInstance())
+	}
+
+	if cmd.Follow == false {
+		if len(events) == 0 {
+			log.Infof("No events found in namespace %s", namespace)
+		}
+
+		return
+	}
+
+	// Follow the events
+	eventLogger, err := services.StartEventLogging(config, client, namespace, cmd.WarningsOnly, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer eventLogger.Close()
+
+	log.Infof("Waiting for new events in namespace %s (Press Ctrl+C to abort)", namespace)
+	select {
+	case err := <-eventLogger.Errors():
+		log.Fatalf("Error watching events: %v", err)
+	case <-interrupt.Context().Done():
+	}
+}
+
+// startEventLogging prints warning events of the devspace resources while the command is running. Errors
+// are only logged, because the events are not essential for the command
+func startEventLogging(config *latest.Config, client kubernetes.Interface) *services.EventLogger {
+	namespace, err := configutil.GetDefaultNamespace(config)
+	if err != nil {
+		log.Warnf("Unable to print events: %v", err)
+		return nil
+	}
+
+	eventLogger, err := services.StartEventLogging(config, client, namespace, true, log.GetInstance())
+	if err != nil {
+		log.Warnf("Unable to print events: %v", err)
+		return nil
+	}
+
+	return eventLogger
+}
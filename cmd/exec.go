@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+// ExecCmd is a struct that defines a command call for "exec"
+type ExecCmd struct {
+	Selector      string
+	Namespace     string
+	LabelSelector string
+	Container     string
+	Pod           string
+	SwitchContext bool
+	Pick          bool
+	All           bool
+}
+
+// NewExecCmd creates a new exec command
+func NewExecCmd() *cobra.Command {
+	cmd := &ExecCmd{}
+
+	execCmd := &cobra.Command{
+		Use:   "exec",
+		Short: "Executes a command in one or all selected containers",
+		Long: `
+#######################################################
+################### devspace exec #####################
+#######################################################
+Executes a command without a terminal in a container.
+With --all, the command is executed concurrently in all
+running pods that match the selection and the output
+of every pod is prefixed with its name:
+
+devspace exec -- ls -la
+devspace exec -p -- env # Select pod
+devspace exec -s my-selector -- rm -rf /tmp/cache
+devspace exec --all -l app=worker -- redis-cli flushall
+#######################################################`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  cmd.Run,
+	}
+
+	execCmd.Flags().StringVarP(&cmd.Selector, "selector", "s", "", "Selector name (in config) to select pods/containers")
+	execCmd.Flags().StringVarP(&cmd.Container, "container", "c", "", "Container name within pod where to execute command")
+	execCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to execute the command in")
+	execCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
+	execCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to select pods")
+	execCmd.Flags().BoolVar(&cmd.SwitchContext, "switch-context", false, "Switch kubectl context to the DevSpace context")
+	execCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod (use --pick=false to use the newest pod if multiple pods match)")
+	execCmd.Flags().BoolVar(&cmd.All, "all", false, "Execute the command in all running pods that match the selection")
+
+	return execCmd
+}
+
+// Run executes the command logic
+func (cmd *ExecCmd) Run(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	_, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Get config
+	var config *latest.Config
+	if configutil.ConfigExists() {
+		var generatedConfig *generated.Config
+
+		config, generatedConfig, err = configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Get kubectl client
+	client, err := kubectl.NewClientWithContextSwitch(config, cmd.SwitchContext)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %v", err)
+	}
+
+	// Build params
+	selectorParameter := &targetselector.SelectorParameter{}
+	if cmd.Selector != "" {
+		selectorParameter.ConfigParameter.Selector = &cmd.Selector
+	}
+	if cmd.Container != "" {
+		selectorParameter.CmdParameter.ContainerName = &cmd.Container
+	}
+	if cmd.LabelSelector != "" {
+		selectorParameter.CmdParameter.LabelSelector = &cmd.LabelSelector
+	}
+	if cmd.Namespace != "" {
+		selectorParameter.CmdParameter.Namespace = &cmd.Namespace
+	}
+	if cmd.Pod != "" {
+		selectorParameter.CmdParameter.PodName = &cmd.Pod
+	}
+	if cobraCmd.Flags().Changed("pick") {
+		selectorParameter.CmdParameter.Pick = &cmd.Pick
+	}
+
+	if cmd.All {
+		if cmd.Pod != "" || cmd.Pick {
+			log.Fatal("--all cannot be used together with --pod or --pick")
+		}
+
+		cmd.execInAllContainers(config, client, selectorParameter, args)
+		return
+	}
+
+	targetSelector, err := targetselector.NewTargetSelector(config, selectorParameter, true)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	pod, container, err := targetSelector.GetContainer(client)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	restConfig, err := kubectl.GetRestConfig(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = kubectl.ExecStream(restConfig, pod, container.Name, args, false, nil, os.Stdout, os.Stderr)
+	if err != nil {
+		if exitError, ok := err.(kubectlExec.CodeExitError); ok {
+			os.Exit(exitError.Code)
+		}
+
+		log.Fatalf("Error executing command in %s:%s: %v", pod.Name, container.Name, err)
+	}
+}
+
+func (cmd *ExecCmd) execInAllContainers(config *latest.Config, client kubernetes.Interface, selectorParameter *targetselector.SelectorParameter, args []string) {
+	results, err := services.ExecInAllContainers(config, client, selectorParameter, args, os.Stdout, os.Stderr, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	values := make([][]string, 0, len(results))
+	for _, result := range results {
+		status := strconv.Itoa(result.ExitCode)
+		if result.Err != nil {
+			status = result.Err.Error()
+		}
+		if result.ExitCode != 0 {
+			failed++
+		}
+
+		values = append(values, []string{
+			result.Pod,
+			result.Container,
+			status,
+		})
+	}
+
+	log.WriteString("\n")
+	log.PrintTable(log.GetInstance(), []string{"Pod", "Container", "Exit Code"}, values)
+
+	if failed > 0 {
+		log.Fatalf("Command failed in %d of %d containers", failed, len(results))
+	}
+
+	log.Donef("Command succeeded in all %d containers", len(results))
+}
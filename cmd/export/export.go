@@ -0,0 +1,24 @@
+package export
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewExportCmd creates a new cobra command for the export sub command
+func NewExportCmd() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export configuration for other machines",
+		Long: `
+#######################################################
+################### devspace export ###################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	exportCmd.AddCommand(newKubeContextCmd())
+	exportCmd.AddCommand(newTokenCmd())
+
+	return exportCmd
+}
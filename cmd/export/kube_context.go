@@ -0,0 +1,100 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type kubeContextCmd struct {
+	provider   string
+	context    string
+	embedToken bool
+}
+
+func newKubeContextCmd() *cobra.Command {
+	cmd := &kubeContextCmd{}
+
+	exportKubeContext := &cobra.Command{
+		Use:   "kube-context",
+		Short: "Prints a standalone kube config for a space",
+		Long: `
+#######################################################
+########### devspace export kube-context ##############
+#######################################################
+Prints a kube config that only contains the context of 
+the space. By default, the credentials are not stored 
+in the kube config but retrieved with the devspace exec 
+plugin, which uses the access key from the environment 
+variable DEVSPACE_ACCESS_KEY if the machine is not 
+logged in. Use --embed-token to store the service 
+account token of the space in the kube config instead.
+
+Example:
+devspace export kube-context my-space > space.kubeconfig
+devspace export kube-context my-space --embed-token
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		Run:  cmd.RunExportKubeContext,
+	}
+
+	exportKubeContext.Flags().StringVar(&cmd.provider, "provider", "", "The cloud provider to use")
+	exportKubeContext.Flags().StringVar(&cmd.context, "context", "", "The name of the kube context (Default: the name devspace use space would use)")
+	exportKubeContext.Flags().BoolVar(&cmd.embedToken, "embed-token", false, "Store the service account token in the kube config instead of using the exec plugin")
+
+	return exportKubeContext
+}
+
+// RunExportKubeContext executes the functionality "devspace export kube-context"
+func (cmd *kubeContextCmd) RunExportKubeContext(cobraCmd *cobra.Command, args []string) {
+	// Only the kube config is printed to stdout
+	log.SetInstance(log.NewStreamLogger(os.Stderr, logrus.InfoLevel))
+
+	// Check if user has specified a certain provider
+	var cloudProvider *string
+	if cmd.provider != "" {
+		cloudProvider = &cmd.provider
+	}
+
+	// Get cloud provider from config
+	provider, err := cloud.GetProvider(cloudProvider, log.GetInstance())
+	if err != nil {
+		log.Fatalf("Error getting cloud context: %v", err)
+	}
+	if provider == nil {
+		log.Fatal("No cloud provider specified")
+	}
+
+	space, err := provider.GetSpaceByName(args[0])
+	if err != nil {
+		log.Fatalf("Error retrieving Spaces details: %v", err)
+	}
+
+	serviceAccount, err := provider.GetServiceAccount(space)
+	if err != nil {
+		log.Fatalf("Error retrieving space service account: %v", err)
+	}
+
+	contextName := cmd.context
+	if contextName == "" {
+		contextName = cloud.GetKubeContextNameFromSpace(space.Name, space.ProviderName)
+	}
+
+	kubeConfig, err := cloud.GetSpaceKubeConfig(contextName, space, serviceAccount, cmd.embedToken)
+	if err != nil {
+		log.Fatalf("Error creating kube config: %v", err)
+	}
+
+	out, err := clientcmd.Write(*kubeConfig)
+	if err != nil {
+		log.Fatalf("Error creating kube config: %v", err)
+	}
+
+	fmt.Print(string(out))
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud/config"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type tokenCmd struct {
+	provider string
+	spaceID  int
+}
+
+func newTokenCmd() *cobra.Command {
+	cmd := &tokenCmd{}
+
+	exportToken := &cobra.Command{
+		Use:   "token",
+		Short: "Prints the credentials for a space as kubectl exec credential",
+		Long: `
+#######################################################
+############### devspace export token #################
+#######################################################
+Prints the service account token of a space as exec 
+credential. This command is called by kubectl for kube 
+configs created with devspace export kube-context. If 
+the environment variable DEVSPACE_ACCESS_KEY is set, 
+its access key is used instead of the one from 
+devspace login.
+#######################################################
+	`,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		Run:    cmd.RunExportToken,
+	}
+
+	exportToken.Flags().StringVar(&cmd.provider, "provider", config.DevSpaceCloudProviderName, "The cloud provider to use")
+	exportToken.Flags().IntVar(&cmd.spaceID, "space-id", 0, "The id of the space")
+
+	return exportToken
+}
+
+// RunExportToken executes the functionality "devspace export token"
+func (cmd *tokenCmd) RunExportToken(cobraCmd *cobra.Command, args []string) {
+	// kubectl reads the credential from stdout
+	log.SetInstance(log.NewStreamLogger(os.Stderr, logrus.InfoLevel))
+
+	if cmd.spaceID == 0 {
+		log.Fatal("Please specify the space with --space-id")
+	}
+
+	provider, err := cloud.GetProviderWithAccessKey(cmd.provider)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	space, err := provider.GetSpace(cmd.spaceID)
+	if err != nil {
+		log.Fatalf("Error retrieving space %d: %v", cmd.spaceID, err)
+	}
+
+	serviceAccount, err := provider.GetServiceAccount(space)
+	if err != nil {
+		log.Fatalf("Error retrieving space service account: %v", err)
+	}
+
+	credential, err := cloud.GetExecCredential(serviceAccount)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(string(credential))
+}
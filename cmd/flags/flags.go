@@ -0,0 +1,25 @@
+package flags
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/spf13/cobra"
+)
+
+// Var is the name of the global flag that sets the value of a config variable
+const Var = "var"
+
+// CommandVars returns the config variables passed with --var. The variables are validated by the root command before
+// a command runs, hence invalid variables are ignored here
+func CommandVars(cobraCmd *cobra.Command) map[string]string {
+	vars, err := cobraCmd.Flags().GetStringArray(Var)
+	if err != nil {
+		return nil
+	}
+
+	commandVars, err := configutil.ParseCommandVars(vars)
+	if err != nil {
+		return nil
+	}
+
+	return commandVars
+}
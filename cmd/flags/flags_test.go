@@ -0,0 +1,28 @@
+package flags
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"gotest.tools/assert"
+)
+
+func TestCommandVars(t *testing.T) {
+	var commandVars map[string]string
+
+	rootCmd := &cobra.Command{Use: "devspace"}
+	rootCmd.PersistentFlags().StringArray(Var, []string{}, "")
+	rootCmd.AddCommand(&cobra.Command{
+		Use: "deploy",
+		Run: func(cobraCmd *cobra.Command, args []string) {
+			commandVars = CommandVars(cobraCmd)
+		},
+	})
+
+	rootCmd.SetArgs([]string{"deploy", "--var", "HOSTS=a,b", "--var", "URL=http://host?a=b"})
+	err := rootCmd.Execute()
+	assert.NilError(t, err, "Error executing command")
+	assert.DeepEqual(t, commandVars, map[string]string{"HOSTS": "a,b", "URL": "http://host?a=b"})
+
+	assert.Equal(t, len(CommandVars(&cobra.Command{})), 0, "Command without var flag has vars")
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+// ForwardCmd is a struct that defines a command call for "forward"
+type ForwardCmd struct {
+	Selector      string
+	Namespace     string
+	LabelSelector string
+	Pod           string
+	Pick          bool
+
+	Address string
+}
+
+// NewForwardCmd creates a new forward command
+func NewForwardCmd() *cobra.Command {
+	cmd := &ForwardCmd{}
+
+	forwardCmd := &cobra.Command{
+		Use:   "forward",
+		Short: "Forwards ports of pods to the local computer",
+		Long: `
+#######################################################
+################## devspace forward ###################
+#######################################################
+Only starts the port forwarding without building,
+deploying, syncing or opening a terminal. Without
+arguments the ports in dev.ports are forwarded:
+
+devspace forward
+devspace forward 8080:80 -l app=api
+devspace forward 8080 9229:9230 --pod=my-pod
+devspace forward 3000 --address=0.0.0.0 -n my-namespace
+#######################################################`,
+		Run: cmd.Run,
+	}
+
+	forwardCmd.Flags().StringVarP(&cmd.Selector, "selector", "s", "", "Selector name (in config) to select the pod to forward ports to")
+	forwardCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to forward ports to")
+	forwardCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
+	forwardCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to select pods")
+	forwardCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod (use --pick=false to use the newest pod if multiple pods match)")
+	forwardCmd.Flags().StringVar(&cmd.Address, "address", "", "Local address to bind the forwarded ports to (Default: 127.0.0.1)")
+
+	return forwardCmd
+}
+
+// Run executes the command logic
+func (cmd *ForwardCmd) Run(cobraCmd *cobra.Command, args []string) {
+	_, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var config *latest.Config
+	if configutil.ConfigExists() {
+		loadedConfig, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{
+			Namespace:   cmd.Namespace,
+			CommandVars: flags.CommandVars(cobraCmd),
+		}).Load()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		config = loadedConfig
+
+		// Signal that we are working on the space if there is any
+		err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else if len(args) == 0 {
+		log.Fatal("Couldn't find a DevSpace configuration. Please specify the ports to forward, e.g. `devspace forward 8080:80 -l app=api`")
+	}
+
+	client, err := kubectl.NewClient(config)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %v", err)
+	}
+
+	// Forward the ports of the config
+	if len(args) == 0 {
+		if config.Dev == nil || config.Dev.Ports == nil || len(*config.Dev.Ports) == 0 {
+			log.Fatal("No ports to forward defined in dev.ports. Please specify the ports to forward, e.g. `devspace forward 8080:80 -l app=api`")
+		}
+
+		portForwarder, err := services.StartPortForwarding(config, client, nil, log.GetInstance())
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(portForwarder) == 0 {
+			log.Fatal("No pods found to forward ports to")
+		}
+
+		log.Info("Forwarding ports (Press Ctrl+C to abort)")
+		<-interrupt.Context().Done()
+		for _, pf := range portForwarder {
+			pf.Close()
+		}
+
+		return
+	}
+
+	// Forward the ports of the args
+	portMappings, err := services.ParsePortMappings(args, cmd.Address)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	params := targetselector.CmdParameter{}
+	if cmd.Selector != "" {
+		params.Selector = &cmd.Selector
+	}
+	if cmd.LabelSelector != "" {
+		params.LabelSelector = &cmd.LabelSelector
+	}
+	if cmd.Namespace != "" {
+		params.Namespace = &cmd.Namespace
+	}
+	if cmd.Pod != "" {
+		params.PodName = &cmd.Pod
+	}
+	if cobraCmd.Flags().Changed("pick") {
+		params.Pick = &cmd.Pick
+	}
+
+	portForwarder, err := services.StartPortForwardingFromCmd(config, client, params, portMappings, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Info("Forwarding ports (Press Ctrl+C to abort)")
+	<-interrupt.Context().Done()
+	portForwarder.Close()
+}
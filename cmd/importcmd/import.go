@@ -0,0 +1,23 @@
+package importcmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewImportCmd creates a new cobra command for the import sub command
+func NewImportCmd() *cobra.Command {
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import configuration exported on another machine",
+		Long: `
+#######################################################
+################### devspace import ###################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	importCmd.AddCommand(newKubeContextCmd())
+
+	return importCmd
+}
@@ -0,0 +1,83 @@
+package importcmd
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+type kubeContextCmd struct {
+	setActive bool
+}
+
+func newKubeContextCmd() *cobra.Command {
+	cmd := &kubeContextCmd{}
+
+	importKubeContext := &cobra.Command{
+		Use:   "kube-context",
+		Short: "Adds the contexts of a kube config file to the local kube config",
+		Long: `
+#######################################################
+########### devspace import kube-context ##############
+#######################################################
+Adds the contexts of a kube config file (e.g. created 
+with devspace export kube-context) together with their 
+clusters and users to the local kube config. Existing 
+entries with the same name are replaced.
+
+Example:
+devspace import kube-context space.kubeconfig
+devspace import kube-context space.kubeconfig --use
+#######################################################
+	`,
+		Args: cobra.ExactArgs(1),
+		Run:  cmd.RunImportKubeContext,
+	}
+
+	importKubeContext.Flags().BoolVar(&cmd.setActive, "use", false, "Use the imported context as current kube context")
+
+	return importKubeContext
+}
+
+// RunImportKubeContext executes the functionality "devspace import kube-context"
+func (cmd *kubeContextCmd) RunImportKubeContext(cobraCmd *cobra.Command, args []string) {
+	imported, err := clientcmd.LoadFromFile(args[0])
+	if err != nil {
+		log.Fatalf("Error loading %s: %v", args[0], err)
+	}
+	if len(imported.Contexts) == 0 {
+		log.Fatalf("%s does not contain any kube context", args[0])
+	}
+
+	config, err := kubeconfig.LoadRawConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	contextNames, err := kubeconfig.MergeConfig(config, imported)
+	if err != nil {
+		log.Fatalf("Error importing %s: %v", args[0], err)
+	}
+
+	if cmd.setActive {
+		currentContext := imported.CurrentContext
+		if _, ok := imported.Contexts[currentContext]; !ok {
+			currentContext = contextNames[0]
+		}
+
+		config.CurrentContext = currentContext
+	}
+
+	err = kubeconfig.SaveConfig(config)
+	if err != nil {
+		log.Fatalf("Error saving kube config: %v", err)
+	}
+
+	for _, contextName := range contextNames {
+		log.Donef("Successfully imported kube context %s", contextName)
+	}
+	if cmd.setActive {
+		log.Infof("Current kube context is now %s", config.CurrentContext)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	cloudconfig "github.com/devspace-cloud/devspace/pkg/devspace/cloud/config"
@@ -53,6 +54,7 @@ type InitCmd struct {
 	Dockerfile  string
 	Context     string
 
+	config              *latest.Config
 	providerName        *string
 	useCloud            bool
 	dockerfileGenerator *generator.DockerfileGenerator
@@ -107,7 +109,9 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 	os.Remove(constants.DefaultVarsPath)
 
 	// Create config
-	config := configutil.InitConfig()
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)})
+	config := latest.New().(*latest.Config)
+	cmd.config = config
 
 	// Print DevSpace logo
 	log.PrintLogo()
@@ -226,7 +230,12 @@ func (cmd *InitCmd) Run(cobraCmd *cobra.Command, args []string) {
 	cmd.addDevConfig()
 
 	// Save config
-	err = configutil.SaveLoadedConfig()
+	clonedConfig, err := loader.RestoreVars(config)
+	if err != nil {
+		log.Fatalf("Config error: %v", err)
+	}
+
+	err = loader.Save(clonedConfig)
 	if err != nil {
 		log.Fatalf("Config error: %v", err)
 	}
@@ -424,12 +433,11 @@ func (cmd *InitCmd) configureCluster() {
 		DefaultValue: "default",
 	})
 
-	config := configutil.GetConfig()
-	config.Cluster.Namespace = &namespace
+	cmd.config.Cluster.Namespace = &namespace
 }
 
 func (cmd *InitCmd) addDevConfig() {
-	config := configutil.GetConfig()
+	config := cmd.config
 
 	// Forward ports
 	if len(*config.Deployments) > 0 && (*config.Deployments)[0].Component != nil && (*config.Deployments)[0].Component.Service != nil && (*config.Deployments)[0].Component.Service.Ports != nil && len(*(*config.Deployments)[0].Component.Service.Ports) > 0 {
@@ -96,5 +96,6 @@ func (cmd *clustersCmd) RunListClusters(cobraCmd *cobra.Command, args []string)
 		log.PrintTable(log.GetInstance(), headerColumnNames, values)
 	} else {
 		log.Infof("No clusters found. You can connect a cluster with `%s`", ansi.Color("devspace connect cluster", "white+b"))
+		log.PrintNoEntries()
 	}
 }
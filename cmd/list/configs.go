@@ -50,6 +50,7 @@ func (cmd *configsCmd) RunListConfigs(cobraCmd *cobra.Command, args []string) {
 	_, err = os.Stat(constants.DefaultConfigsPath)
 	if err != nil {
 		log.Infof("Please create a '%s' to specify multiple configurations", constants.DefaultConfigsPath)
+		log.PrintNoEntries()
 		return
 	}
 
@@ -0,0 +1,98 @@
+package list
+
+import (
+	"strconv"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/spf13/cobra"
+)
+
+type contextsCmd struct{}
+
+func newContextsCmd() *cobra.Command {
+	cmd := &contextsCmd{}
+
+	contextsCmd := &cobra.Command{
+		Use:   "contexts",
+		Short: "Lists all kube contexts",
+		Long: `
+#######################################################
+############# devspace list contexts ##################
+#######################################################
+Lists all kube contexts and shows which context is used
+by this project (see devspace use context)
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunListContexts,
+	}
+
+	return contextsCmd
+}
+
+// RunListContexts runs the list contexts command logic
+func (cmd *contextsCmd) RunListContexts(cobraCmd *cobra.Command, args []string) {
+	kubeConfig, err := kubeconfig.LoadRawConfig()
+	if err != nil {
+		log.Fatalf("Unable to load kube config: %v", err)
+	}
+
+	// The context of the project is only available within a project
+	projectContext := ""
+	projectNamespace := ""
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if configExists {
+		generatedConfig, err := generated.LoadConfig()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		projectContext = generatedConfig.KubeContext
+		projectNamespace = generatedConfig.Namespace
+		if projectContext == "" && generatedConfig.CloudSpace != nil {
+			projectContext = generatedConfig.CloudSpace.KubeContext
+		}
+	}
+
+	// Without a project context the current kubectl context is used
+	activeContext := projectContext
+	if activeContext == "" {
+		activeContext = kubeConfig.CurrentContext
+	}
+
+	// Specify the table column names
+	headerColumnNames := []string{
+		"Name",
+		"Cluster",
+		"Namespace",
+		"Active",
+		"Kubectl Current",
+	}
+
+	contextRows := [][]string{}
+	for _, contextName := range kubeconfig.GetContextNames(kubeConfig) {
+		context := kubeConfig.Contexts[contextName]
+
+		namespace := context.Namespace
+		if contextName == projectContext && projectNamespace != "" {
+			namespace = projectNamespace
+		}
+
+		contextRows = append(contextRows, []string{
+			contextName,
+			context.Cluster,
+			namespace,
+			strconv.FormatBool(contextName == activeContext),
+			strconv.FormatBool(contextName == kubeConfig.CurrentContext),
+		})
+	}
+
+	log.PrintTable(log.GetInstance(), headerColumnNames, contextRows)
+}
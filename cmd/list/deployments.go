@@ -1,6 +1,7 @@
 package list
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	deployComponent "github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
@@ -50,7 +51,10 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(cobraCmd *cobra.Command, args []
 		"STATUS",
 	}
 
-	config := configutil.GetConfig()
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 	kubectl, err := kubectl.NewClient(config)
 	if err != nil {
 		log.Fatalf("Unable to create new kubectl client: %s", err.Error())
@@ -0,0 +1,196 @@
+package list
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/build"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/custom"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/docker"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/kaniko"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/pod"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+type imagesCmd struct {
+	Resolved bool
+	Dev      bool
+}
+
+func newImagesCmd() *cobra.Command {
+	cmd := &imagesCmd{}
+
+	imagesCmd := &cobra.Command{
+		Use:   "images",
+		Short: "Lists the images in the active config",
+		Long: `
+#######################################################
+############### devspace list images ##################
+#######################################################
+Lists the configured images. With --resolved, the last
+built tag, digest, build time and builder are shown from
+the cache together with the information if the next
+build would rebuild the image
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunListImages,
+	}
+
+	imagesCmd.Flags().BoolVar(&cmd.Resolved, "resolved", false, "Show the last built tags and digests and whether a rebuild is needed")
+	imagesCmd.Flags().BoolVar(&cmd.Dev, "dev", false, "Check if devspace dev instead of devspace deploy would rebuild the images")
+
+	return imagesCmd
+}
+
+// RunListImages runs the list images command logic
+func (cmd *imagesCmd) RunListImages(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if config.Images == nil || len(*config.Images) == 0 {
+		log.Info("No images are defined in the config")
+		log.PrintNoEntries()
+		return
+	}
+
+	imageConfigNames := make([]string, 0, len(*config.Images))
+	for imageConfigName := range *config.Images {
+		imageConfigNames = append(imageConfigNames, imageConfigName)
+	}
+	sort.Strings(imageConfigNames)
+
+	if cmd.Resolved == false {
+		headerColumnNames := []string{
+			"Name",
+			"Image",
+			"Tags",
+			"Builder",
+		}
+
+		imageRows := make([][]string, 0, len(imageConfigNames))
+		for _, imageConfigName := range imageConfigNames {
+			imageConf := (*config.Images)[imageConfigName]
+			imageRows = append(imageRows, []string{
+				imageConfigName,
+				registry.GetAliasedImageName(config, *imageConf.Image),
+				getConfiguredTags(imageConf),
+				getConfiguredBuilder(imageConf),
+			})
+		}
+
+		log.PrintTable(log.GetInstance(), headerColumnNames, imageRows)
+		return
+	}
+
+	// Load generated config
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cache := generatedConfig.GetActive()
+	headerColumnNames := []string{
+		"Name",
+		"Image",
+		"Tag",
+		"Digest",
+		"Built",
+		"Builder",
+		"Rebuild",
+	}
+
+	imageRows := make([][]string, 0, len(imageConfigNames))
+	for _, imageConfigName := range imageConfigNames {
+		imageConf := (*config.Images)[imageConfigName]
+		row := []string{
+			imageConfigName,
+			registry.GetAliasedImageName(config, *imageConf.Image),
+			"-",
+			"-",
+			"never",
+			"-",
+		}
+
+		if imageCache, ok := cache.Images[imageConfigName]; ok && imageCache.Tag != "" {
+			row[1] = imageCache.GetResolvedImageName()
+			row[2] = imageCache.Tag
+			if imageCache.Digest != "" {
+				row[3] = imageCache.Digest
+			}
+			if imageCache.LastBuilt > 0 {
+				row[4] = time.Since(time.Unix(imageCache.LastBuilt, 0)).Round(time.Second).String() + " ago"
+			} else {
+				row[4] = "unknown"
+			}
+			if imageCache.Builder != "" {
+				row[5] = imageCache.Builder
+			}
+		}
+
+		needRebuild, err := build.NeedsRebuild(config, cache, imageConfigName, cmd.Dev)
+		if err != nil {
+			log.Warnf("Error checking if image %s needs a rebuild: %v", imageConfigName, err)
+			row = append(row, "unknown")
+		} else if needRebuild {
+			row = append(row, "yes")
+		} else {
+			row = append(row, "no")
+		}
+
+		imageRows = append(imageRows, row)
+	}
+
+	log.PrintTable(log.GetInstance(), headerColumnNames, imageRows)
+}
+
+func getConfiguredTags(imageConf *latest.ImageConfig) string {
+	if imageConf.Tag != nil {
+		return *imageConf.Tag
+	}
+	if imageConf.Tags != nil && len(*imageConf.Tags) > 0 {
+		return strings.Join(*imageConf.Tags, ", ")
+	}
+
+	return "random"
+}
+
+func getConfiguredBuilder(imageConf *latest.ImageConfig) string {
+	if imageConf.Build != nil {
+		if imageConf.Build.Disabled != nil && *imageConf.Build.Disabled == true {
+			return "disabled"
+		}
+		if imageConf.Build.Custom != nil {
+			return custom.EngineName
+		}
+		if imageConf.Build.Kaniko != nil {
+			return kaniko.EngineName
+		}
+		if imageConf.Build.Pod != nil {
+			if imageConf.Build.Pod.Tool != nil && *imageConf.Build.Pod.Tool != "" {
+				return *imageConf.Build.Pod.Tool
+			}
+
+			return pod.ToolBuildah
+		}
+	}
+
+	return docker.EngineName
+}
@@ -23,8 +23,10 @@ func NewListCmd() *cobra.Command {
 	listCmd.AddCommand(newSelectorsCmd())
 	listCmd.AddCommand(newPortsCmd())
 	listCmd.AddCommand(newConfigsCmd())
+	listCmd.AddCommand(newContextsCmd())
 	listCmd.AddCommand(newVarsCmd())
 	listCmd.AddCommand(newDeploymentsCmd())
+	listCmd.AddCommand(newImagesCmd())
 	listCmd.AddCommand(newProvidersCmd())
 	listCmd.AddCommand(newAvailableComponentsCmd())
 
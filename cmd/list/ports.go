@@ -1,9 +1,12 @@
 package list
 
 import (
+	"fmt"
 	"strconv"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/spf13/cobra"
 )
@@ -20,7 +23,8 @@ func newPortsCmd() *cobra.Command {
 #######################################################
 ############### devspace list ports ###################
 #######################################################
-Lists the port forwarding configurations
+Lists the port forwarding configurations and whether
+their local ports are currently forwarded
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -41,10 +45,14 @@ func (cmd *portsCmd) RunListPort(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
 	}
 
-	config := configutil.GetConfig()
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	if config.Dev.Ports == nil || len(*config.Dev.Ports) == 0 {
 		log.Info("No ports are forwarded. Run `devspace add port` to add a port that should be forwarded\n")
+		log.PrintNoEntries()
 		return
 	}
 
@@ -52,6 +60,7 @@ func (cmd *portsCmd) RunListPort(cobraCmd *cobra.Command, args []string) {
 		"Selector",
 		"LabelSelector",
 		"Ports (Local:Remote)",
+		"Status",
 	}
 
 	portForwards := make([][]string, 0, len(*config.Dev.Ports))
@@ -74,20 +83,41 @@ func (cmd *portsCmd) RunListPort(cobraCmd *cobra.Command, args []string) {
 		}
 
 		portMappings := ""
+		activePorts := 0
+		totalPorts := 0
 		if value.PortMappings != nil {
 			for _, v := range *value.PortMappings {
 				if len(portMappings) > 0 {
 					portMappings += ", "
 				}
 
-				portMappings += strconv.Itoa(*v.LocalPort) + ":" + strconv.Itoa(*v.RemotePort)
+				remotePort := *v.LocalPort
+				if v.RemotePort != nil {
+					remotePort = *v.RemotePort
+				}
+
+				portMappings += strconv.Itoa(*v.LocalPort) + ":" + strconv.Itoa(remotePort)
+
+				totalPorts++
+				if services.IsLocalPortInUse(*v.LocalPort, v.BindAddress) {
+					activePorts++
+				}
 			}
 		}
 
+		// The local ports accept connections while devspace dev forwards them
+		status := "Inactive"
+		if totalPorts > 0 && activePorts == totalPorts {
+			status = "Active"
+		} else if activePorts > 0 {
+			status = fmt.Sprintf("%d/%d ports active", activePorts, totalPorts)
+		}
+
 		portForwards = append(portForwards, []string{
 			service,
 			selector,
 			portMappings,
+			status,
 		})
 	}
 
@@ -1,7 +1,13 @@
 package list
 
 import (
+	"os"
+	"path/filepath"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/sync"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/spf13/cobra"
 )
@@ -18,7 +24,8 @@ func newSyncCmd() *cobra.Command {
 #######################################################
 ################# devspace list sync ##################
 #######################################################
-Lists the sync configuration
+Lists the sync configuration and the latest activity
+of the syncs started by devspace dev
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -39,10 +46,14 @@ func (cmd *syncCmd) RunListSync(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find any devspace configuration. Please run `devspace init`")
 	}
 
-	config := configutil.GetConfig()
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	if config.Dev.Sync == nil || len(*config.Dev.Sync) == 0 {
 		log.Info("No sync paths are configured. Run `devspace add sync` to add new sync path\n")
+		log.PrintNoEntries()
 		return
 	}
 
@@ -52,6 +63,14 @@ func (cmd *syncCmd) RunListSync(cobraCmd *cobra.Command, args []string) {
 		"Local Path",
 		"Container Path",
 		"Excluded Paths",
+		"Status",
+		"Latest Activity",
+	}
+
+	// The sync log contains the activity of the syncs of the last devspace dev
+	statusList, err := sync.ReadStatus(filepath.Join(log.Logdir, "sync.log"))
+	if err != nil && os.IsNotExist(err) == false {
+		log.Warnf("Error reading sync log: %v", err)
 	}
 
 	syncPaths := make([][]string, 0, len(*config.Dev.Sync))
@@ -84,14 +103,53 @@ func (cmd *syncCmd) RunListSync(cobraCmd *cobra.Command, args []string) {
 			}
 		}
 
+		status, latestActivity := "Not started", ""
+		if syncStatus := getSyncStatus(statusList, value); syncStatus != nil {
+			status = syncStatus.GetStatus()
+			latestActivity = syncStatus.GetLatestActivity()
+		}
+
 		syncPaths = append(syncPaths, []string{
 			service,
 			selector,
 			*value.LocalSubPath,
 			*value.ContainerPath,
 			excludedPaths,
+			status,
+			latestActivity,
 		})
 	}
 
 	log.PrintTable(log.GetInstance(), headerColumnNames, syncPaths)
 }
+
+// getSyncStatus returns the status of the last sync in the sync log that synced the local and container path
+// of the sync config or nil
+func getSyncStatus(statusList []*sync.Status, syncConfig *latest.SyncConfig) *sync.Status {
+	localPath := "."
+	if syncConfig.LocalSubPath != nil {
+		localPath = *syncConfig.LocalSubPath
+	}
+	if realLocalPath, err := filepath.EvalSymlinks(localPath); err == nil {
+		localPath = realLocalPath
+	}
+
+	absoluteLocalPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return nil
+	}
+
+	containerPath := "."
+	if syncConfig.ContainerPath != nil {
+		containerPath = *syncConfig.ContainerPath
+	}
+
+	var syncStatus *sync.Status
+	for _, status := range statusList {
+		if status.Local == absoluteLocalPath && status.Container == containerPath {
+			syncStatus = status
+		}
+	}
+
+	return syncStatus
+}
@@ -3,8 +3,8 @@ package list
 import (
 	"fmt"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
-	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/spf13/cobra"
 )
@@ -43,11 +43,8 @@ func (cmd *configsCmd) RunListVars(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find any devspace configuration. Please run `devspace init`")
 	}
 
-	// Fill variables config
-	configutil.GetConfig()
-
-	// Load generated config
-	generatedConfig, err := generated.LoadConfig()
+	// Load the config to fill the variables of the generated config
+	_, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -55,6 +52,7 @@ func (cmd *configsCmd) RunListVars(cobraCmd *cobra.Command, args []string) {
 	// No variable found
 	if generatedConfig.GetActive().Vars == nil || len(generatedConfig.GetActive().Vars) == 0 {
 		log.Infof("No variable found for config %s", generatedConfig.ActiveConfig)
+		log.PrintNoEntries()
 		return
 	}
 
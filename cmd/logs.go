@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
@@ -52,7 +53,7 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to print the logs of")
 	logsCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 	logsCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to select pods")
-	logsCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod")
+	logsCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod (use --pick=false to use the newest pod if multiple pods match)")
 	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
 
@@ -69,9 +70,9 @@ func (cmd *LogsCmd) RunLogs(cobraCmd *cobra.Command, args []string) {
 
 	var config *latest.Config
 	if configutil.ConfigExists() {
-		config = configutil.GetConfig()
+		var generatedConfig *generated.Config
 
-		generatedConfig, err := generated.LoadConfig()
+		config, generatedConfig, err = configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -106,7 +107,7 @@ func (cmd *LogsCmd) RunLogs(cobraCmd *cobra.Command, args []string) {
 	if cmd.Pod != "" {
 		params.PodName = &cmd.Pod
 	}
-	if cmd.Pick != false {
+	if cobraCmd.Flags().Changed("pick") {
 		params.Pick = &cmd.Pick
 	}
 
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/analyze"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -19,13 +21,15 @@ import (
 	"github.com/mgutz/ansi"
 	"github.com/skratchdot/open-golang/open"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
 // OpenCmd holds the open cmd flags
 type OpenCmd struct {
 	Provider string
+
+	// commandVars are the config variables passed with --var
+	commandVars map[string]string
 }
 
 // NewOpenCmd creates a new open command
@@ -34,12 +38,14 @@ func NewOpenCmd() *cobra.Command {
 
 	openCmd := &cobra.Command{
 		Use:   "open",
-		Short: "Opens the space in the browser",
+		Short: "Opens the application in the browser",
 		Long: `
 #######################################################
 #################### devspace open ####################
 #######################################################
-Opens the space domain in the browser
+Opens the space domain or, if no space is used, one of
+the ingress hosts in the namespace in the browser as
+soon as the application responds
 
 Example:
 devspace open
@@ -57,6 +63,8 @@ devspace open myspace
 
 // RunOpen executes the functionality "devspace open"
 func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
+	cmd.commandVars = flags.CommandVars(cobraCmd)
+
 	// Set config root
 	configExists, err := configutil.SetDevSpaceRoot()
 	if err != nil {
@@ -80,7 +88,8 @@ func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
 			log.Fatal(err)
 		}
 		if generatedConfig.CloudSpace == nil || generatedConfig.CloudSpace.Name == "" {
-			log.Fatalf("No space configured in project, please specify a space name or run: \n- `%s` to create a new space\n- `%s` to use an existing space", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"))
+			cmd.openIngressHost()
+			return
 		}
 
 		spaceName = generatedConfig.CloudSpace.Name
@@ -141,9 +150,9 @@ func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
 	// Get default namespace
 	var devspaceConfig *latest.Config
 	if configExists {
-		devspaceConfig = configutil.GetConfig()
+		var generatedConfig *generated.Config
 
-		generatedConfig, err := generated.LoadConfig()
+		devspaceConfig, generatedConfig, err = configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: cmd.commandVars}).Load()
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -184,6 +193,59 @@ func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
 		}
 	}
 
+	openWhenReady(client, namespace, domain, tls)
+}
+
+// openIngressHost opens one of the ingress hosts in the namespace if the project does not use a space
+func (cmd *OpenCmd) openIngressHost() {
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: cmd.commandVars}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Signal that we are working on the space if there is any
+	err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	namespace, err := configutil.GetDefaultNamespace(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := kubectl.NewClient(config)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	hosts, err := findIngressHosts(client, namespace)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(hosts) == 0 {
+		log.Fatalf("Couldn't find an ingress with a host in namespace %s. Please create an ingress for your application or run: \n- `%s` to create a new space\n- `%s` to use an existing space", namespace, ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"))
+	}
+
+	hostNames := make([]string, 0, len(hosts))
+	for host := range hosts {
+		hostNames = append(hostNames, host)
+	}
+	sort.Strings(hostNames)
+
+	host := hostNames[0]
+	if len(hostNames) > 1 {
+		host = survey.Question(&survey.QuestionOptions{
+			Question: "Please select a host to open",
+			Options:  hostNames,
+		})
+	}
+
+	openWhenReady(client, namespace, host, hosts[host])
+}
+
+// openWhenReady waits until the domain returns a status code that is not a server error and opens it in the browser
+func openWhenReady(client kubernetes.Interface, namespace, domain string, tls bool) {
 	// Add schema
 	if tls {
 		domain = "https://" + domain
@@ -191,24 +253,38 @@ func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
 		domain = "http://" + domain
 	}
 
-	// Loop and check if http code is != 502
-	log.StartWait("Waiting for ingress")
+	// Loop and check if http code is < 500
+	log.StartWait("Waiting for " + domain)
 	defer log.StopWait()
 
 	// Make sure the ingress has some time to take effect
 	time.Sleep(time.Second * 2)
 
+	lastState := ""
 	now := time.Now()
 	for time.Since(now) < time.Minute*4 {
 		// Check if domain is ready
+		state := ""
 		resp, err := http.Get(domain)
 		if err != nil {
-			log.Fatalf("Error making request to %s: %v", domain, err)
-		} else if resp.StatusCode != http.StatusBadGateway && resp.StatusCode != http.StatusServiceUnavailable {
+			state = "not reachable yet"
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode < http.StatusInternalServerError {
+				log.StopWait()
+				open.Start(domain)
+				log.Donef("Successfully opened %s", domain)
+				os.Exit(0)
+			}
+
+			state = fmt.Sprintf("returns %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+		}
+
+		if state != lastState {
 			log.StopWait()
-			open.Start(domain)
-			log.Donef("Successfully opened %s", domain)
-			os.Exit(0)
+			log.Infof("%s %s", domain, state)
+			log.StartWait("Waiting for " + domain)
+			lastState = state
 		}
 
 		// Analyze space for issues
@@ -226,7 +302,42 @@ func (cmd *OpenCmd) RunOpen(cobraCmd *cobra.Command, args []string) {
 	}
 
 	log.StopWait()
-	log.Fatalf("Timeout: domain %s still returns 502 code, even after several minutes. Either the app has no valid '/' route or it is listening on the wrong port", domain)
+	log.Fatalf("Timeout: %s still %s, even after several minutes. Either the app has no valid '/' route or it is listening on the wrong port", domain, lastState)
+}
+
+// findIngressHosts returns all ingress hosts in the namespace and whether tls is enabled for them
+func findIngressHosts(client kubernetes.Interface, namespace string) (map[string]bool, error) {
+	log.StartWait("Retrieve ingresses")
+	defer log.StopWait()
+
+	ingressList, err := kubectl.ListIngresses(client, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("Error listing ingresses: %v", err)
+	}
+
+	hosts := map[string]bool{}
+	for _, ingress := range ingressList.Items {
+		for _, rule := range ingress.Spec.Rules {
+			host := strings.TrimSpace(rule.Host)
+			if host == "" {
+				continue
+			}
+
+			if _, ok := hosts[host]; ok == false {
+				hosts[host] = false
+			}
+		}
+
+		for _, tlsEntry := range ingress.Spec.TLS {
+			for _, tlsHost := range tlsEntry.Hosts {
+				if _, ok := hosts[strings.TrimSpace(tlsHost)]; ok {
+					hosts[strings.TrimSpace(tlsHost)] = true
+				}
+			}
+		}
+	}
+
+	return hosts, nil
 }
 
 func findDomain(client kubernetes.Interface, namespace, host string) (string, bool, error) {
@@ -234,7 +345,7 @@ func findDomain(client kubernetes.Interface, namespace, host string) (string, bo
 	defer log.StopWait()
 
 	// List all ingresses and only create one if there is none already
-	ingressList, err := client.ExtensionsV1beta1().Ingresses(namespace).List(metav1.ListOptions{})
+	ingressList, err := kubectl.ListIngresses(client, namespace)
 	if err != nil {
 		return "", false, fmt.Errorf("Error listing ingresses: %v", err)
 	}
@@ -252,8 +363,8 @@ func findDomain(client kubernetes.Interface, namespace, host string) (string, bo
 		// Check if tls is enabled
 		if domain != "" {
 			for _, tlsEntry := range ingress.Spec.TLS {
-				for _, host := range tlsEntry.Hosts {
-					if strings.TrimSpace(host) == host {
+				for _, tlsHost := range tlsEntry.Hosts {
+					if strings.TrimSpace(tlsHost) == host {
 						tls = true
 					}
 				}
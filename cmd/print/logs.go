@@ -0,0 +1,105 @@
+package print
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/spf13/cobra"
+)
+
+type logsCmd struct {
+	Command string
+	Lines   int
+	All     bool
+}
+
+func newLogsCmd() *cobra.Command {
+	cmd := &logsCmd{}
+
+	logsCmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Prints the log file of a command",
+		Long: `
+#######################################################
+################ devspace print logs ##################
+#######################################################
+Prints the log file devspace wrote during the last runs
+of a command, e.g. dev, deploy, build, purge or sync.
+Log files are rotated when they grow larger than 10 MB
+or are older than 7 days, the last 3 rotated files are
+kept.
+
+Examples:
+devspace print logs --command dev
+devspace print logs --command sync --lines 50
+devspace print logs --command deploy --all
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunPrintLogs,
+	}
+
+	logsCmd.Flags().StringVar(&cmd.Command, "command", "dev", "The command to print the log file of (e.g. dev, deploy, build, purge, sync)")
+	logsCmd.Flags().IntVar(&cmd.Lines, "lines", 0, "Only print the last lines (0 prints all lines)")
+	logsCmd.Flags().BoolVar(&cmd.All, "all", false, "Print the rotated log files as well")
+
+	return logsCmd
+}
+
+// RunPrintLogs executes the print logs command logic
+func (cmd *logsCmd) RunPrintLogs(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	files := log.GetLogFiles(cmd.Command)
+	if len(files) == 0 {
+		log.Fatalf("No log file found for command %s. Log files are written by dev, deploy, build, purge, upgrade and sync", cmd.Command)
+	}
+	if cmd.All == false {
+		files = files[len(files)-1:]
+	}
+
+	lines := []string{}
+	for _, file := range files {
+		fileLines, err := readLines(file)
+		if err != nil {
+			log.Fatalf("Error reading %s: %v", file, err)
+		}
+
+		lines = append(lines, fileLines...)
+	}
+	if cmd.Lines > 0 && len(lines) > cmd.Lines {
+		lines = lines[len(lines)-cmd.Lines:]
+	}
+
+	if len(lines) > 0 {
+		os.Stdout.WriteString(strings.Join(lines, "\n") + "\n")
+	}
+}
+
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	return lines, scanner.Err()
+}
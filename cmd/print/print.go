@@ -0,0 +1,21 @@
+package print
+
+import "github.com/spf13/cobra"
+
+// NewPrintCmd creates a new cobra command
+func NewPrintCmd() *cobra.Command {
+	printCmd := &cobra.Command{
+		Use:   "print",
+		Short: "Prints information about the project",
+		Long: `
+#######################################################
+################## devspace print #####################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	printCmd.AddCommand(newLogsCmd())
+
+	return printCmd
+}
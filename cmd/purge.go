@@ -1,19 +1,24 @@
 package cmd
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
-	v1 "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/dependency"
 	deploy "github.com/devspace-cloud/devspace/pkg/devspace/deploy/util"
+	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/notification"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
 
 	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
 )
 
 // PurgeCmd holds the required data for the purge cmd
@@ -22,6 +27,12 @@ type PurgeCmd struct {
 	Namespace               string
 	AllowCyclicDependencies bool
 	PurgeDependencies       bool
+	ForceProtected          bool
+	UnpatchServiceAccount   bool
+	Yes                     bool
+
+	// commandVars are the config variables passed with --var
+	commandVars map[string]string
 }
 
 // NewPurgeCmd creates a new purge command
@@ -40,6 +51,8 @@ Deletes the deployed kuberenetes resources:
 devspace purge
 devspace purge --dependencies
 devspace purge -d my-deployment
+devspace purge --yes
+devspace purge --unpatch-service-account
 #######################################################`,
 		Args: cobra.NoArgs,
 		Run:  cmd.Run,
@@ -49,12 +62,17 @@ devspace purge -d my-deployment
 	purgeCmd.Flags().StringVarP(&cmd.Deployments, "deployments", "d", "", "The deployment to delete (You can specify multiple deployments comma-separated, e.g. devspace-default,devspace-database etc.)")
 	purgeCmd.Flags().BoolVar(&cmd.AllowCyclicDependencies, "allow-cyclic", false, "When enabled allows cyclic dependencies")
 	purgeCmd.Flags().BoolVar(&cmd.PurgeDependencies, "dependencies", false, "When enabled purges the dependencies as well")
+	purgeCmd.Flags().BoolVar(&cmd.ForceProtected, "force-protected", false, "Deletes protected deployments as well")
+	purgeCmd.Flags().BoolVar(&cmd.UnpatchServiceAccount, "unpatch-service-account", false, "Removes the deleted image pull secrets from the default service account as well")
+	purgeCmd.Flags().BoolVarP(&cmd.Yes, "yes", "y", false, "Deletes the resources without asking for confirmation")
 
 	return purgeCmd
 }
 
 // Run executes the purge command logic
 func (cmd *PurgeCmd) Run(cobraCmd *cobra.Command, args []string) {
+	cmd.commandVars = flags.CommandVars(cobraCmd)
+
 	// Set config root
 	configExists, err := configutil.SetDevSpaceRoot()
 	if err != nil {
@@ -64,17 +82,20 @@ func (cmd *PurgeCmd) Run(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find any devspace configuration. Please run `devspace init`")
 	}
 
-	log.StartFileLogging()
+	log.StartFileLogging("purge")
+
+	// Get the config
+	config, generatedConfig := cmd.loadConfig()
+
+	// Notify about the result of the purge
+	notifier := notification.Start(config, generatedConfig, "purge", log.GetInstance())
 
-	generatedConfig, err := generated.LoadConfig()
+	// Execute the before:purge hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.Before, "purge", log.GetInstance())
 	if err != nil {
-		log.Errorf("Error loading generated.yaml: %v", err)
-		return
+		log.Fatal(err)
 	}
 
-	// Get the config
-	config := cmd.loadConfig(generatedConfig)
-
 	// Signal that we are working on the space if there is any
 	err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
 	if err != nil {
@@ -94,14 +115,40 @@ func (cmd *PurgeCmd) Run(cobraCmd *cobra.Command, args []string) {
 		}
 	}
 
+	// Show what will be deleted and ask for confirmation
+	purgeDeployments, protectedDeployments := deploy.GetPurgeDeployments(config, deployments, cmd.ForceProtected)
+	for _, name := range protectedDeployments {
+		log.Warnf("Skip deleting protected deployment %s (use --force-protected to delete it)", name)
+	}
+	if len(purgeDeployments) == 0 && cmd.PurgeDependencies == false {
+		log.Info("No deployments to purge")
+		return
+	}
+	if cmd.Yes == false && cmd.confirm(config, generatedConfig, kubectl, purgeDeployments) == false {
+		return
+	}
+
 	// Purge deployments
-	deploy.PurgeDeployments(config, generatedConfig.GetActive(), kubectl, deployments, log.GetInstance())
+	purgeDeploymentNames := []string{}
+	for _, deployConfig := range purgeDeployments {
+		purgeDeploymentNames = append(purgeDeploymentNames, *deployConfig.Name)
+	}
+	var purgeErr error
+	if len(purgeDeploymentNames) > 0 {
+		purgeErr = deploy.PurgeDeploymentsWithProtected(config, generatedConfig.GetActive(), kubectl, purgeDeploymentNames, cmd.ForceProtected, log.GetInstance())
+
+		// Delete the image pull secrets that are not needed anymore
+		deploy.PurgePullSecrets(config, generatedConfig, kubectl, purgeDeployments, cmd.UnpatchServiceAccount, log.GetInstance())
+	}
 
 	// Purge dependencies
 	if cmd.PurgeDependencies {
 		err = dependency.PurgeAll(config, generatedConfig, cmd.AllowCyclicDependencies, log.GetInstance())
 		if err != nil {
 			log.Errorf("Error purging dependencies: %v", err)
+			if purgeErr == nil {
+				purgeErr = fmt.Errorf("Error purging dependencies: %v", err)
+			}
 		}
 	}
 
@@ -109,29 +156,62 @@ func (cmd *PurgeCmd) Run(cobraCmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Errorf("Error saving generated.yaml: %v", err)
 	}
-}
 
-func (cmd *PurgeCmd) loadConfig(generatedConfig *generated.Config) *latest.Config {
-	// Load Config and modify it
-	config, err := configutil.GetConfigFromPath(".", generatedConfig.ActiveConfig, true, generatedConfig, log.GetInstance())
+	if purgeErr != nil {
+		notifier.Failure(purgeErr)
+		return
+	}
+
+	// Execute the after:purge hooks
+	err = hook.ExecuteEvent(config, generatedConfig, hook.After, "purge", log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	if cmd.Namespace != "" {
-		config.Cluster = &v1.Cluster{
-			Namespace:   &cmd.Namespace,
-			KubeContext: config.Cluster.KubeContext,
+	notifier.Success()
+}
+
+// confirm prints the releases and resources that will be deleted and asks the user if they should be deleted
+func (cmd *PurgeCmd) confirm(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, purgeDeployments []*latest.DeploymentConfig) bool {
+	resources := []string{}
+	for _, deployConfig := range purgeDeployments {
+		deploymentResources, err := deploy.GetPurgeResources(config, generatedConfig.GetActive(), client, deployConfig, log.GetInstance())
+		if err != nil {
+			log.Warnf("Unable to determine resources of deployment %s: %v", *deployConfig.Name, err)
+			resources = append(resources, "all resources of deployment "+*deployConfig.Name)
+			continue
 		}
 
-		log.Infof("Using %s namespace", cmd.Namespace)
+		resources = append(resources, deploymentResources...)
+	}
+	if cmd.PurgeDependencies {
+		resources = append(resources, "all deployments of the dependencies")
 	}
 
-	// Save generated config
-	err = generated.SaveConfig(generatedConfig)
+	log.Infof("The following releases and resources will be deleted:\n- %s", strings.Join(resources, "\n- "))
+	return survey.Question(&survey.QuestionOptions{
+		Question:     "Are you sure you want to delete them?",
+		DefaultValue: "No",
+		Options: []string{
+			"No",
+			"Yes",
+		},
+	}) == "Yes"
+}
+
+func (cmd *PurgeCmd) loadConfig() (*latest.Config, *generated.Config) {
+	// Load Config and modify it
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{
+		Namespace:   cmd.Namespace,
+		CommandVars: cmd.commandVars,
+	}).Load()
 	if err != nil {
-		log.Fatalf("Couldn't save generated config: %v", err)
+		log.Fatal(err)
+	}
+
+	if cmd.Namespace != "" {
+		log.Infof("Using %s namespace", cmd.Namespace)
 	}
 
-	return config
+	return config, generatedConfig
 }
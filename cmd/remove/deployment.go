@@ -1,6 +1,7 @@
 package remove
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
@@ -59,7 +60,11 @@ func (cmd *deploymentCmd) RunRemoveDeployment(cobraCmd *cobra.Command, args []st
 	}
 
 	// Load base config
-	config := configutil.GetBaseConfig()
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, generatedConfig, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	shouldPurgeDeployment := survey.Question(&survey.QuestionOptions{
 		Question:     "Do you want to delete all deployment resources deployed?",
@@ -80,12 +85,6 @@ func (cmd *deploymentCmd) RunRemoveDeployment(cobraCmd *cobra.Command, args []st
 			deployments = []string{args[0]}
 		}
 
-		generatedConfig, err := generated.LoadConfig()
-		if err != nil {
-			log.Errorf("Error loading generated.yaml: %v", err)
-			return
-		}
-
 		deployUtil.PurgeDeployments(config, generatedConfig.GetActive(), kubectl, deployments, log.GetInstance())
 
 		err = generated.SaveConfig(generatedConfig)
@@ -94,7 +93,7 @@ func (cmd *deploymentCmd) RunRemoveDeployment(cobraCmd *cobra.Command, args []st
 		}
 	}
 
-	found, err := configure.RemoveDeployment(cmd.RemoveAll, name)
+	found, err := configure.RemoveDeployment(config, loader, cmd.RemoveAll, name)
 	if err != nil {
 		log.Fatal(err)
 	}
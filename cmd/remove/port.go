@@ -1,6 +1,7 @@
 package remove
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -49,7 +50,14 @@ func (cmd *portCmd) RunRemovePort(cobraCmd *cobra.Command, args []string) {
 		log.Fatal("Couldn't find any devspace configuration. Please run `devspace init`")
 	}
 
-	err = configure.RemovePort(cmd.RemoveAll, cmd.LabelSelector, args)
+	// Load base config
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = configure.RemovePort(config, loader, cmd.RemoveAll, cmd.LabelSelector, args)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -46,7 +46,7 @@ func (cmd *providerCmd) RunRemoveCloudProvider(cobraCmd *cobra.Command, args []s
 		log.Fatalf("Error loading provider config: %v", err)
 	}
 	if config.GetProvider(providerConfig, providerName) == nil {
-		log.Failf("Couldn't find cloud provider %s", providerName)
+		log.Fatalf("Couldn't find cloud provider %s", providerName)
 	}
 
 	newProviders := make([]*latest.Provider, 0, len(providerConfig.Providers)-1)
@@ -1,6 +1,7 @@
 package remove
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/configure"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
@@ -60,7 +61,14 @@ func (cmd *selectorCmd) RunRemoveSelector(cobraCmd *cobra.Command, args []string
 		serviceName = args[0]
 	}
 
-	err = configure.RemoveSelector(cmd.RemoveAll, serviceName, cmd.LabelSelector, cmd.Namespace)
+	// Load base config
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{BaseConfig: true, CommandVars: flags.CommandVars(cobraCmd)})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = configure.RemoveSelector(config, loader, cmd.RemoveAll, serviceName, cmd.LabelSelector, cmd.Namespace)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -86,6 +86,23 @@ func (cmd *spaceCmd) RunRemoveCloudDevSpace(cobraCmd *cobra.Command, args []stri
 			log.Donef("Deleted space %s", space.Name)
 		}
 
+		if configExists {
+			generatedConfig, err := generated.LoadConfig()
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			// The image pull secrets were deleted together with the spaces
+			for _, space := range spaces {
+				delete(generatedConfig.PullSecrets, cloudpkg.GetKubeContextNameFromSpace(space.Name, space.ProviderName))
+			}
+
+			err = generated.SaveConfig(generatedConfig)
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+
 		log.Done("All spaces removed")
 		return
 	}
@@ -137,6 +154,9 @@ func (cmd *spaceCmd) RunRemoveCloudDevSpace(cobraCmd *cobra.Command, args []stri
 		// Remove space from generated config
 		generatedConfig.CloudSpace = nil
 
+		// The image pull secrets were deleted together with the space
+		delete(generatedConfig.PullSecrets, cloudpkg.GetKubeContextNameFromSpace(space.Name, space.ProviderName))
+
 		err = generated.SaveConfig(generatedConfig)
 		if err != nil {
 			log.Fatal(err)
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+// RenderCmd holds the render cmd flags
+type RenderCmd struct {
+	Deployments string
+}
+
+// NewRenderCmd creates a new render command
+func NewRenderCmd() *cobra.Command {
+	cmd := &RenderCmd{}
+
+	renderCmd := &cobra.Command{
+		Use:   "render",
+		Short: "Prints the kubernetes manifests of the deployments",
+		Long: `
+#######################################################
+################## devspace render ####################
+#######################################################
+Renders the component and helm charts of the deployments
+locally with the values they would be deployed with and
+prints the resulting manifests. Kubectl deployments print
+their manifests with the image tags replaced.
+
+Example:
+devspace render
+devspace render --deployment=app
+devspace render --deployment=app,backend > manifests.yaml
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunRender,
+	}
+
+	renderCmd.Flags().StringVarP(&cmd.Deployments, "deployment", "d", "", "Only render a specific deployment (you can specify multiple deployments comma-separated)")
+
+	return renderCmd
+}
+
+// RunRender executes the functionality "devspace render"
+func (cmd *RenderCmd) RunRender(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if config.Deployments == nil || len(*config.Deployments) == 0 {
+		log.Fatal("No deployments found in the config")
+	}
+
+	deployments := []string{}
+	if cmd.Deployments != "" {
+		for _, deployment := range strings.Split(cmd.Deployments, ",") {
+			deployments = append(deployments, strings.TrimSpace(deployment))
+		}
+	}
+
+	cache := generatedConfig.GetActive()
+	rendered := 0
+	for _, deployConfig := range *config.Deployments {
+		if len(deployments) > 0 {
+			found := false
+			for _, deployment := range deployments {
+				if deployment == *deployConfig.Name {
+					found = true
+					break
+				}
+			}
+
+			if found == false {
+				continue
+			}
+		}
+
+		manifests := ""
+		if deployConfig.Component != nil {
+			deployClient, err := component.New(config, nil, deployConfig, log.GetInstance())
+			if err != nil {
+				log.Fatalf("Error rendering deployment %s: %v", *deployConfig.Name, err)
+			}
+
+			manifests, err = deployClient.Render(cache)
+			if err != nil {
+				log.Fatalf("Error rendering deployment %s: %v", *deployConfig.Name, err)
+			}
+		} else if deployConfig.Helm != nil {
+			deployClient, err := helm.New(config, nil, deployConfig, log.GetInstance())
+			if err != nil {
+				log.Fatalf("Error rendering deployment %s: %v", *deployConfig.Name, err)
+			}
+
+			manifests, err = deployClient.Render(cache)
+			if err != nil {
+				log.Fatalf("Error rendering deployment %s: %v", *deployConfig.Name, err)
+			}
+		} else if deployConfig.Kubectl != nil {
+			deployClient, err := kubectl.New(config, nil, deployConfig, log.GetInstance())
+			if err != nil {
+				log.Fatalf("Error rendering deployment %s: %v", *deployConfig.Name, err)
+			}
+
+			manifests, err = deployClient.GetManifests(cache)
+			if err != nil {
+				log.Fatalf("Error rendering deployment %s: %v", *deployConfig.Name, err)
+			}
+			manifests = "---\n" + strings.TrimSpace(manifests) + "\n"
+		} else {
+			log.Fatalf("Deployment %s has no deployment method", *deployConfig.Name)
+		}
+
+		fmt.Print(manifests)
+		rendered++
+	}
+
+	if rendered == 0 {
+		log.Fatalf("Couldn't find deployment %s in the config", cmd.Deployments)
+	}
+}
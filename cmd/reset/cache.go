@@ -0,0 +1,81 @@
+package reset
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/spf13/cobra"
+)
+
+type cacheCmd struct {
+	KubeContext string
+	Namespace   string
+}
+
+func newCacheCmd() *cobra.Command {
+	cmd := &cacheCmd{}
+
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Resets the image and deployment cache",
+		Long: `
+#######################################################
+############### devspace reset cache ##################
+#######################################################
+Resets the image and deployment cache of the current
+config, so that the next devspace dev or devspace deploy
+rebuilds all images and redeploys all deployments.
+The cache is stored per kube context and namespace. By
+default the cache of all kube contexts and namespaces
+is reset.
+
+Examples:
+devspace reset cache
+devspace reset cache --kube-context=minikube
+devspace reset cache --kube-context=minikube --namespace=test
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunResetCache,
+	}
+
+	cacheCmd.Flags().StringVar(&cmd.KubeContext, "kube-context", "", "Only reset the cache of this kube context")
+	cacheCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Only reset the cache of this namespace")
+
+	return cacheCmd
+}
+
+// RunResetCache executes the reset cache command logic
+func (cmd *cacheCmd) RunResetCache(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	// Load generated config
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatalf("Error loading generated.yaml: %v", err)
+	}
+
+	// Reset the caches
+	deleted := generatedConfig.GetActive().ResetContexts(cmd.KubeContext, cmd.Namespace)
+
+	// Save the config
+	err = generated.SaveConfig(generatedConfig)
+	if err != nil {
+		log.Fatalf("Error saving config: %v", err)
+	}
+
+	if deleted == 0 {
+		log.Info("No cache found to reset")
+		return
+	}
+
+	log.Donef("Successfully reset %d cache(s) in config %s", deleted, generatedConfig.ActiveConfig)
+}
@@ -16,6 +16,7 @@ func NewResetCmd() *cobra.Command {
 	}
 
 	resetCmd.AddCommand(newKeyCmd())
+	resetCmd.AddCommand(newCacheCmd())
 	resetCmd.AddCommand(newVarsCmd())
 
 	return resetCmd
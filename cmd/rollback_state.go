@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/history"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	"github.com/spf13/cobra"
+)
+
+// RollbackStateCmd holds the rollback-state cmd flags
+type RollbackStateCmd struct {
+	Config bool
+	List   bool
+}
+
+// NewRollbackStateCmd creates a new rollback-state command
+func NewRollbackStateCmd() *cobra.Command {
+	cmd := &RollbackStateCmd{}
+
+	rollbackStateCmd := &cobra.Command{
+		Use:   "rollback-state",
+		Short: "Restores a previous version of the generated state",
+		Long: `
+#######################################################
+############### devspace rollback-state ###############
+#######################################################
+Restores a previous version of .devspace/generated.yaml
+(or of devspace.yaml with --config). The previous
+versions are kept in .devspace/history. The current
+version is kept as well, so a rollback can be reverted.
+
+Examples:
+devspace rollback-state
+devspace rollback-state --list
+devspace rollback-state 20191015-120102.123456789
+devspace rollback-state --config
+#######################################################
+	`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  cmd.RunRollbackState,
+	}
+
+	rollbackStateCmd.Flags().BoolVar(&cmd.Config, "config", false, "Restore a previous version of devspace.yaml that was saved by a command (requires history.config in the global settings)")
+	rollbackStateCmd.Flags().BoolVar(&cmd.List, "list", false, "List the previous versions instead of restoring one")
+
+	return rollbackStateCmd
+}
+
+// RunRollbackState executes the functionality "devspace rollback-state"
+func (cmd *RollbackStateCmd) RunRollbackState(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	file := generated.ConfigPath
+	if cmd.Config {
+		file = constants.DefaultConfigPath
+	}
+
+	versions, err := history.List(".", file)
+	if err != nil {
+		log.Fatalf("Error listing versions of %s: %v", file, err)
+	}
+	if len(versions) == 0 {
+		log.PrintNoEntries()
+		log.Infof("No previous versions of %s found", file)
+		return
+	}
+
+	if cmd.List {
+		values := [][]string{}
+		for _, version := range versions {
+			values = append(values, []string{version.ID, version.Created.Format("2006-01-02 15:04:05")})
+		}
+
+		log.PrintTable(log.GetInstance(), []string{"ID", "Saved"}, values)
+		return
+	}
+
+	// Select the version, the newest version is used in non-interactive mode
+	id := versions[0].ID
+	if len(args) == 1 {
+		id = args[0]
+	} else if survey.IsNoInput() == false {
+		options := []string{}
+		for _, version := range versions {
+			options = append(options, version.ID)
+		}
+
+		id = survey.Question(&survey.QuestionOptions{
+			Question:     "Which version of " + file + " do you want to restore?",
+			DefaultValue: options[0],
+			Options:      options,
+		})
+	}
+
+	version, err := history.Rollback(".", file, id)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Donef("Restored %s from %s", file, version.Created.Format("2006-01-02 15:04:05"))
+}
@@ -1,29 +1,43 @@
 package cmd
 
 import (
-	"fmt"
+	"os"
+	"strconv"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/cmd/add"
 	"github.com/devspace-cloud/devspace/cmd/cleanup"
 	"github.com/devspace-cloud/devspace/cmd/connect"
 	"github.com/devspace-cloud/devspace/cmd/create"
+	"github.com/devspace-cloud/devspace/cmd/describe"
+	"github.com/devspace-cloud/devspace/cmd/export"
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/cmd/importcmd"
 	"github.com/devspace-cloud/devspace/cmd/list"
+	"github.com/devspace-cloud/devspace/cmd/print"
 	"github.com/devspace-cloud/devspace/cmd/remove"
 	"github.com/devspace-cloud/devspace/cmd/reset"
 	"github.com/devspace-cloud/devspace/cmd/set"
 	"github.com/devspace-cloud/devspace/cmd/status"
 	"github.com/devspace-cloud/devspace/cmd/update"
 	"github.com/devspace-cloud/devspace/cmd/use"
+	"github.com/devspace-cloud/devspace/cmd/workspace"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/upgrade"
 	"github.com/devspace-cloud/devspace/pkg/util/analytics"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var cfgFile string
+var outputFormat string
+var logFormat string
+var noInput bool
+var vars []string
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
@@ -42,22 +56,17 @@ func Execute() {
 	defer analytics.ReportPanics()
 	
 	if version != "" {
-		rootCmd.Version = upgrade.GetVersion()
-
-		if strings.Contains(upgrade.GetVersion(), "-alpha") == false && strings.Contains(upgrade.GetVersion(), "-beta") == false {
-			newerVersion, err := upgrade.CheckForNewerVersion()
-
-			if err == nil && newerVersion != "" {
-				log.Warnf("There is a newer version of DevSpace CLI v%s. Run `devspace upgrade` to update the CLI.\n", newerVersion)
-			}
-		}
+		rootCmd.Version = version
 	}
 
+	// Cancel long running operations and clean up remote resources on SIGINT and SIGTERM
+	interrupt.Start()
+
 	if err := rootCmd.Execute(); err != nil {
 		if analyticsErr == nil {
 			analytics.SendCommandEvent(err)
 		}
-		fmt.Println(err)
+		log.Error(err)
 	} else {
 		if analyticsErr == nil {
 			analytics.SendCommandEvent(nil)
@@ -71,12 +80,17 @@ func init() {
 	rootCmd.AddCommand(cleanup.NewCleanupCmd())
 	rootCmd.AddCommand(connect.NewConnectCmd())
 	rootCmd.AddCommand(create.NewCreateCmd())
+	rootCmd.AddCommand(describe.NewDescribeCmd())
+	rootCmd.AddCommand(export.NewExportCmd())
+	rootCmd.AddCommand(importcmd.NewImportCmd())
 	rootCmd.AddCommand(list.NewListCmd())
+	rootCmd.AddCommand(print.NewPrintCmd())
 	rootCmd.AddCommand(remove.NewRemoveCmd())
 	rootCmd.AddCommand(reset.NewResetCmd())
 	rootCmd.AddCommand(set.NewSetCmd())
 	rootCmd.AddCommand(status.NewStatusCmd())
 	rootCmd.AddCommand(use.NewUseCmd())
+	rootCmd.AddCommand(workspace.NewWorkspaceCmd())
 	rootCmd.AddCommand(update.NewUpdateCmd())
 
 	// Add main commands
@@ -84,23 +98,86 @@ func init() {
 	rootCmd.AddCommand(NewDevCmd())
 	rootCmd.AddCommand(NewBuildCmd())
 	rootCmd.AddCommand(NewSyncCmd())
+	rootCmd.AddCommand(NewForwardCmd())
 	rootCmd.AddCommand(NewInstallCmd())
 	rootCmd.AddCommand(NewPurgeCmd())
 	rootCmd.AddCommand(NewUpgradeCmd())
 	rootCmd.AddCommand(NewDeployCmd())
 	rootCmd.AddCommand(NewEnterCmd())
+	rootCmd.AddCommand(NewExecCmd())
 	rootCmd.AddCommand(NewLoginCmd())
 	rootCmd.AddCommand(NewAnalyzeCmd())
+	rootCmd.AddCommand(NewRenderCmd())
 	rootCmd.AddCommand(NewLogsCmd())
+	rootCmd.AddCommand(NewEventsCmd())
 	rootCmd.AddCommand(NewOpenCmd())
 	rootCmd.AddCommand(NewUICmd())
 	rootCmd.AddCommand(NewContainerizeCmd())
+	rootCmd.AddCommand(NewRollbackStateCmd())
+
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "output", "", "Print lists and status tables as json or yaml")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "Print log messages as text or json (Can also be set with DEVSPACE_LOG_FORMAT=json)")
+	rootCmd.PersistentFlags().BoolVar(&noInput, "no-input", false, "Fail instead of asking questions, e.g. in CI (Can also be set with DEVSPACE_NO_INPUT=true)")
+	rootCmd.PersistentFlags().StringArrayVar(&vars, flags.Var, []string{}, "Set the value of a config variable (format: NAME=value)")
+
+	// The log format is set before anything is printed, so cobra.OnInitialize is not used for initConfig because it
+	// runs before the flags of the command are available to setLogFormat
+	rootCmd.PersistentPreRun = func(cobraCmd *cobra.Command, args []string) {
+		setLogFormat(cobraCmd, args)
+		initConfig()
+		checkForNewerVersion(cobraCmd)
+	}
+}
 
-	cobra.OnInitialize(initConfig)
+// skipVersionCheck contains the commands that don't check for a newer version, e.g. because kubectl calls them
+var skipVersionCheck = map[string]bool{
+	"export token": true,
+}
+
+// checkForNewerVersion warns on stderr in the log format if there is a newer version of DevSpace CLI, so that the
+// output of commands like devspace export kube-context or devspace list spaces --output json stays parsable
+func checkForNewerVersion(cobraCmd *cobra.Command) {
+	version := upgrade.GetVersion()
+	if version == "" || strings.Contains(version, "-alpha") || strings.Contains(version, "-beta") {
+		return
+	} else if skipVersionCheck[strings.TrimPrefix(cobraCmd.CommandPath(), rootCmd.Name()+" ")] {
+		return
+	}
+
+	newerVersion, err := upgrade.CheckForNewerVersion()
+	if err == nil && newerVersion != "" {
+		log.GetStderrInstance().Warnf("There is a newer version of DevSpace CLI v%s. Run `devspace upgrade` to update the CLI.\n", newerVersion)
+	}
+}
+
+// setLogFormat sets the output format and switches the default logger to json entries that contain the executed
+// command as module
+func setLogFormat(cobraCmd *cobra.Command, args []string) {
+	if err := log.SetOutputFormat(outputFormat); err != nil {
+		log.Fatal(err)
+	}
+
+	if logFormat == "" {
+		logFormat = os.Getenv("DEVSPACE_LOG_FORMAT")
+	}
+
+	module := strings.TrimPrefix(cobraCmd.CommandPath(), rootCmd.Name()+" ")
+	if err := log.SetLogFormat(logFormat, module); err != nil {
+		log.Fatal(err)
+	}
 }
 
 // initConfig reads in config file and ENV variables if set.
 func initConfig() {
+	if noInput == false {
+		noInput, _ = strconv.ParseBool(os.Getenv("DEVSPACE_NO_INPUT"))
+	}
+	survey.SetNoInput(noInput)
+
+	if _, err := configutil.ParseCommandVars(vars); err != nil {
+		log.Fatal(err)
+	}
+
 	if cfgFile != "" {
 		// Use config file from the flag.
 		viper.SetConfigFile(cfgFile)
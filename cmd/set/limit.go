@@ -0,0 +1,58 @@
+package set
+
+import (
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+type limitCmd struct{}
+
+func newLimitCmd() *cobra.Command {
+	cmd := &limitCmd{}
+
+	return &cobra.Command{
+		Use:   "limit",
+		Short: "Limits the resources the devspace cli uses",
+		Long: `
+#######################################################
+################# devspace set limit ##################
+#######################################################
+Limits the resources the devspace cli itself uses in all
+projects. A value of 0 removes the limit.
+
+Supported limits:
+` + strings.Join(settings.LimitNames(), "\n") + `
+
+Example:
+devspace set limit maxConcurrentBuilds 2
+devspace set limit maxSyncWorkers 1
+devspace set limit syncCoalesceInterval 2000
+#######################################################
+	`,
+		Args: cobra.ExactArgs(2),
+		Run:  cmd.RunSetLimit,
+	}
+}
+
+// RunSetLimit executes the "devspace set limit" logic
+func (*limitCmd) RunSetLimit(cobraCmd *cobra.Command, args []string) {
+	globalSettings, err := settings.Get()
+	if err != nil {
+		log.Fatalf("Unable to load settings: %v", err)
+	}
+
+	err = globalSettings.SetLimit(args[0], args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = settings.Save(globalSettings)
+	if err != nil {
+		log.Fatalf("Error saving settings: %v", err)
+	}
+
+	log.Donef("Successfully set limit %s to %s", args[0], args[1])
+}
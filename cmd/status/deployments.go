@@ -0,0 +1,162 @@
+package status
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/devspace-cloud/devspace/cmd/flags"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	deployComponent "github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
+	deployHelm "github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
+	deployKubectl "github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// releaseGetter is implemented by the helm and component deployments
+type releaseGetter interface {
+	GetRelease() (*hapi_release5.Release, error)
+}
+
+type deploymentsCmd struct{}
+
+func newDeploymentsCmd() *cobra.Command {
+	cmd := &deploymentsCmd{}
+
+	return &cobra.Command{
+		Use:   "deployments",
+		Short: "Shows the live status of the deployments",
+		Long: `
+#######################################################
+############# devspace status deployments #############
+#######################################################
+Shows the helm release status and revision of helm and
+component deployments, whether kubectl deployments were
+applied and how many of the deployed Deployments,
+StatefulSets and Jobs are ready
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.RunStatusDeployments,
+	}
+}
+
+// RunStatusDeployments executes the devspace status deployments command logic
+func (cmd *deploymentsCmd) RunStatusDeployments(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	config, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if config.Deployments == nil || len(*config.Deployments) == 0 {
+		log.Info("No deployments are configured. Run `devspace add deployment` to add a deployment\n")
+		log.PrintNoEntries()
+		return
+	}
+
+	client, err := kubectl.NewClient(config)
+	if err != nil {
+		log.Fatalf("Unable to create new kubectl client: %v", err)
+	}
+
+	header := []string{
+		"Name",
+		"Type",
+		"Status",
+		"Revision",
+		"Ready",
+		"Last Deployed",
+	}
+
+	cache := generatedConfig.GetActive()
+	clients := kubectl.NewDeploymentClients(config, client)
+	values := make([][]string, 0, len(*config.Deployments))
+	for _, deployConfig := range *config.Deployments {
+		var (
+			deployClient   deploy.ResourceGetter
+			deploymentType string
+		)
+
+		deploymentConfig, deploymentClient, err := clients.Get(deployConfig)
+		if err != nil {
+			log.Warnf("Unable to get status of %s: %v", *deployConfig.Name, err)
+			continue
+		}
+
+		if deployConfig.Kubectl != nil {
+			deploymentType = "kubectl"
+			deployClient, err = deployKubectl.New(deploymentConfig, deploymentClient, deployConfig, log.GetInstance())
+		} else if deployConfig.Helm != nil {
+			deploymentType = "helm"
+			deployClient, err = deployHelm.New(deploymentConfig, deploymentClient, deployConfig, log.GetInstance())
+		} else if deployConfig.Component != nil {
+			deploymentType = "component"
+			deployClient, err = deployComponent.New(deploymentConfig, deploymentClient, deployConfig, log.GetInstance())
+		} else {
+			continue
+		}
+		if err != nil {
+			log.Warnf("Unable to create %s deploy config for %s: %v", deploymentType, *deployConfig.Name, err)
+			continue
+		}
+
+		values = append(values, append([]string{*deployConfig.Name, deploymentType}, getDeploymentStatus(deploymentClient, cache, *deployConfig.Name, deployClient)...))
+	}
+
+	log.PrintTable(log.GetInstance(), header, values)
+}
+
+// getDeploymentStatus returns the status, revision, ready and last deployed columns of a deployment
+func getDeploymentStatus(client kubernetes.Interface, cache *generated.CacheConfig, name string, deployClient deploy.ResourceGetter) []string {
+	status, revision, lastDeployed := "", "-", "-"
+
+	releaseClient, ok := deployClient.(releaseGetter)
+
+	// Render only components are applied with kubectl and have no release
+	if component, isComponent := deployClient.(*deployComponent.DeployConfig); isComponent && component.RenderOnly() {
+		ok = false
+	}
+
+	if ok {
+		release, err := releaseClient.GetRelease()
+		if err != nil {
+			return []string{fmt.Sprintf("Error: %v", err), revision, "-", lastDeployed}
+		} else if release == nil {
+			return []string{"Not deployed", revision, "-", lastDeployed}
+		}
+
+		status = release.GetInfo().GetStatus().GetCode().String()
+		revision = strconv.Itoa(int(release.GetVersion()))
+		if release.GetInfo().GetLastDeployed() != nil {
+			lastDeployed = time.Since(time.Unix(release.GetInfo().GetLastDeployed().Seconds, 0)).Round(time.Second).String() + " ago"
+		}
+	} else {
+		// Kubectl deployments are not tracked in the cluster, so we only know if they were applied by devspace
+		status = "Not applied"
+		if deployCache, ok := cache.Deployments[name]; ok && deployCache.KubectlManifestsHash != "" {
+			status = "Applied"
+		}
+	}
+
+	resources, err := deployClient.GetResources(cache)
+	if err != nil {
+		return []string{status, revision, fmt.Sprintf("Error: %v", err), lastDeployed}
+	}
+
+	ready, total := deploy.GetReadyCount(client, resources)
+	return []string{status, revision, fmt.Sprintf("%d/%d", ready, total), lastDeployed}
+}
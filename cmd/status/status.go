@@ -17,6 +17,7 @@ func NewStatusCmd() *cobra.Command {
 		Args: cobra.NoArgs,
 	}
 
+	statusCmd.AddCommand(newDeploymentsCmd())
 	statusCmd.AddCommand(newSyncCmd())
 
 	return statusCmd
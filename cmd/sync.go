@@ -1,9 +1,9 @@
 package cmd
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
-	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	latest "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
@@ -24,6 +24,7 @@ type SyncCmd struct {
 	ContainerPath string
 	LocalPath     string
 	Verbose       bool
+	NoWatch       bool
 }
 
 // NewSyncCmd creates a new init command
@@ -38,13 +39,14 @@ func NewSyncCmd() *cobra.Command {
 ################### devspace sync #####################
 #######################################################
 Starts a bi-directionaly sync between the target container
-and the current path:
+and the current path. A devspace.yaml is not required:
 
 devspace sync
 devspace sync --local-path=subfolder --container-path=/app
 devspace sync --exclude=node_modules --exclude=test
 devspace sync --pod=my-pod --container=my-container
 devspace sync --container-path=/my-path
+devspace sync --label-selector=app=web --no-watch
 #######################################################`,
 		Run: cmd.Run,
 	}
@@ -54,12 +56,13 @@ devspace sync --container-path=/my-path
 	syncCmd.Flags().StringVar(&cmd.Pod, "pod", "", "Pod to open a shell to")
 	syncCmd.Flags().StringVarP(&cmd.LabelSelector, "label-selector", "l", "", "Comma separated key=value selector list (e.g. release=test)")
 	syncCmd.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "Namespace where to select pods")
-	syncCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod")
+	syncCmd.Flags().BoolVarP(&cmd.Pick, "pick", "p", false, "Select a pod (use --pick=false to use the newest pod if multiple pods match)")
 
 	syncCmd.Flags().StringSliceVarP(&cmd.Exclude, "exclude", "e", []string{}, "Exclude directory from sync")
 	syncCmd.Flags().StringVar(&cmd.LocalPath, "local-path", ".", "Local path to use (Default is current directory")
 	syncCmd.Flags().StringVar(&cmd.ContainerPath, "container-path", "", "Container path to use (Default is working directory)")
 	syncCmd.Flags().BoolVar(&cmd.Verbose, "verbose", false, "Shows every file that is synced")
+	syncCmd.Flags().BoolVar(&cmd.NoWatch, "no-watch", false, "Stop after the initial sync instead of watching for changes")
 
 	return syncCmd
 }
@@ -68,13 +71,13 @@ devspace sync --container-path=/my-path
 func (cmd *SyncCmd) Run(cobraCmd *cobra.Command, args []string) {
 	var config *latest.Config
 	if configutil.ConfigExists() {
-		config = configutil.GetConfig()
-
-		generatedConfig, err := generated.LoadConfig()
+		loadedConfig, generatedConfig, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		config = loadedConfig
+
 		// Signal that we are working on the space if there is any
 		err = cloud.ResumeSpace(config, generatedConfig, true, log.GetInstance())
 		if err != nil {
@@ -99,12 +102,12 @@ func (cmd *SyncCmd) Run(cobraCmd *cobra.Command, args []string) {
 	if cmd.Pod != "" {
 		params.PodName = &cmd.Pod
 	}
-	if cmd.Pick != false {
+	if cobraCmd.Flags().Changed("pick") {
 		params.Pick = &cmd.Pick
 	}
 
-	// Start terminal
-	err := services.StartSyncFromCmd(config, params, cmd.LocalPath, cmd.ContainerPath, cmd.Exclude, cmd.Verbose, log.GetInstance())
+	// Start sync
+	err := services.StartSyncFromCmd(config, params, cmd.LocalPath, cmd.ContainerPath, cmd.Exclude, cmd.Verbose, cmd.NoWatch, log.GetInstance())
 	if err != nil {
 		log.Fatal(err)
 	}
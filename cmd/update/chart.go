@@ -3,6 +3,7 @@ package update
 import (
 	"os"
 
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/generator"
@@ -58,7 +59,10 @@ func (cmd *chartCmd) RunChart(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Get config
-	config := configutil.GetConfig()
+	config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{CommandVars: flags.CommandVars(cobraCmd)}).Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 	if config.Deployments == nil || len(*config.Deployments) == 0 {
 		log.Fatal("No deployment specified")
 	}
@@ -1,6 +1,7 @@
 package update
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/spf13/cobra"
@@ -45,10 +46,23 @@ func (cmd *configCmd) RunConfig(cobraCmd *cobra.Command, args []string) {
 	}
 
 	// Get config
-	configutil.GetConfigWithoutDefaults(false)
+	loader := configutil.NewConfigLoader(".", &configutil.ConfigOptions{
+		BaseConfig:     true,
+		SkipValidation: true,
+		CommandVars:    flags.CommandVars(cobraCmd),
+	})
+	config, _, err := loader.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Restore the variables and save it
+	config, err = loader.RestoreVars(config)
+	if err != nil {
+		log.Fatalf("Error restoring vars: %v", err)
+	}
 
-	// Save it
-	err = configutil.SaveLoadedConfig()
+	err = loader.Save(config)
 	if err != nil {
 		log.Fatalf("Error saving config: %v", err)
 	}
@@ -31,7 +31,7 @@ Upgrades the DevSpace CLI to the newest version
 
 // Run executes the command logic
 func (cmd *UpgradeCmd) Run(cobraCmd *cobra.Command, args []string) {
-	log.StartFileLogging()
+	log.StartFileLogging("upgrade")
 	err := upgrade.Upgrade()
 
 	if err != nil {
@@ -0,0 +1,130 @@
+package use
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
+
+	"github.com/spf13/cobra"
+)
+
+type contextCmd struct {
+	Namespace string
+	Reset     bool
+}
+
+func newContextCmd() *cobra.Command {
+	cmd := &contextCmd{}
+
+	useContext := &cobra.Command{
+		Use:   "context",
+		Short: "Use a specific kube context for this project",
+		Long: `
+#######################################################
+############### devspace use context ##################
+#######################################################
+Use a specific kube context (and namespace) for this
+project instead of the current kubectl context. The
+context is saved in .devspace/generated.yaml
+
+Example:
+devspace use context
+devspace use context my-context
+devspace use context my-context --namespace my-namespace
+devspace use context --reset
+#######################################################
+	`,
+		Args: cobra.MaximumNArgs(1),
+		Run:  cmd.RunUseContext,
+	}
+
+	useContext.Flags().StringVarP(&cmd.Namespace, "namespace", "n", "", "The namespace to use for this project")
+	useContext.Flags().BoolVar(&cmd.Reset, "reset", false, "Use the current kubectl context again")
+
+	return useContext
+}
+
+// RunUseContext executes the functionality "devspace use context"
+func (cmd *contextCmd) RunUseContext(cobraCmd *cobra.Command, args []string) {
+	// Set config root
+	configExists, err := configutil.SetDevSpaceRoot()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if !configExists {
+		log.Fatal("Couldn't find a DevSpace configuration. Please run `devspace init`")
+	}
+
+	// Load generated config
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		log.Fatalf("Cannot load generated config: %v", err)
+	}
+
+	if cmd.Reset {
+		generatedConfig.KubeContext = ""
+		generatedConfig.Namespace = ""
+		generatedConfig.Configs = map[string]*generated.CacheConfig{}
+
+		err = generated.SaveConfig(generatedConfig)
+		if err != nil {
+			log.Fatalf("Error saving generated config: %v", err)
+		}
+
+		log.Info("Successfully reset kube context, the current kubectl context will be used")
+		return
+	}
+
+	kubeConfig, err := kubeconfig.LoadRawConfig()
+	if err != nil {
+		log.Fatalf("Unable to load kube config: %v", err)
+	}
+
+	contextNames := kubeconfig.GetContextNames(kubeConfig)
+	if len(contextNames) == 0 {
+		log.Fatal("No kube contexts found in kube config")
+	}
+
+	kubeContext := ""
+	if len(args) > 0 {
+		kubeContext = args[0]
+		if kubeConfig.Contexts[kubeContext] == nil {
+			log.Fatalf("Kube context '%s' does not exist. Run `devspace list contexts` to see all contexts", kubeContext)
+		}
+	} else {
+		defaultValue := kubeConfig.CurrentContext
+		if kubeConfig.Contexts[generatedConfig.KubeContext] != nil {
+			defaultValue = generatedConfig.KubeContext
+		} else if kubeConfig.Contexts[defaultValue] == nil {
+			defaultValue = contextNames[0]
+		}
+
+		kubeContext = survey.Question(&survey.QuestionOptions{
+			Question:     "Which kube context do you want to use for this project?",
+			DefaultValue: defaultValue,
+			Options:      contextNames,
+		})
+	}
+
+	// Switching the context invalidates the cache of the deployed resources
+	if kubeContext != generatedConfig.KubeContext {
+		generatedConfig.Configs = map[string]*generated.CacheConfig{}
+	}
+
+	generatedConfig.KubeContext = kubeContext
+	generatedConfig.Namespace = cmd.Namespace
+	generatedConfig.CloudSpace = nil
+
+	err = generated.SaveConfig(generatedConfig)
+	if err != nil {
+		log.Fatalf("Error saving generated config: %v", err)
+	}
+
+	if cmd.Namespace != "" {
+		log.Donef("Successfully switched to kube context '%s' and namespace '%s'", kubeContext, cmd.Namespace)
+	} else {
+		log.Donef("Successfully switched to kube context '%s'", kubeContext)
+	}
+}
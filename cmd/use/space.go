@@ -1,6 +1,7 @@
 package use
 
 import (
+	"github.com/devspace-cloud/devspace/cmd/flags"
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	cloudpkg "github.com/devspace-cloud/devspace/pkg/devspace/cloud"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -138,14 +139,21 @@ func (cmd *spaceCmd) RunUseSpace(cobraCmd *cobra.Command, args []string) {
 			Created:      space.Created,
 		}
 		generatedConfig.Configs = map[string]*generated.CacheConfig{}
+		generatedConfig.KubeContext = ""
+		generatedConfig.Namespace = ""
 
 		err = generated.SaveConfig(generatedConfig)
 		if err != nil {
 			log.Fatal(err)
 		}
 
+		config, _, err := configutil.NewConfigLoader(".", &configutil.ConfigOptions{GeneratedConfig: generatedConfig, CommandVars: flags.CommandVars(cobraCmd)}).Load()
+		if err != nil {
+			log.Fatal(err)
+		}
+
 		// Signal that we are working on the space if there is any
-		err = cloud.ResumeSpace(configutil.GetConfig(), generatedConfig, false, log.GetInstance())
+		err = cloud.ResumeSpace(config, generatedConfig, false, log.GetInstance())
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -20,6 +20,7 @@ func NewUseCmd() *cobra.Command {
 	useCmd.AddCommand(newConfigCmd())
 	useCmd.AddCommand(newSpaceCmd())
 	useCmd.AddCommand(newProviderCmd())
+	useCmd.AddCommand(newContextCmd())
 
 	return useCmd
 }
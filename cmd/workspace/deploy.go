@@ -0,0 +1,51 @@
+package workspace
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type deployCmd struct {
+	ForceBuild  bool
+	ForceDeploy bool
+}
+
+func newDeployCmd() *cobra.Command {
+	cmd := &deployCmd{}
+
+	deployCmd := &cobra.Command{
+		Use:   "deploy",
+		Short: "Deploys all projects",
+		Long: `
+#######################################################
+############# devspace workspace deploy ###############
+#######################################################
+Runs devspace deploy in all projects of the workspace
+concurrently
+
+Example:
+devspace workspace deploy
+devspace workspace deploy --force-build
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.Run,
+	}
+
+	deployCmd.Flags().BoolVarP(&cmd.ForceBuild, "force-build", "b", false, "Forces to (re-)build every image")
+	deployCmd.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", false, "Forces to (re-)deploy every deployment")
+
+	return deployCmd
+}
+
+// Run executes the command logic
+func (cmd *deployCmd) Run(cobraCmd *cobra.Command, args []string) {
+	deployArgs := []string{"deploy"}
+	if cmd.ForceBuild {
+		deployArgs = append(deployArgs, "--force-build")
+	}
+	if cmd.ForceDeploy {
+		deployArgs = append(deployArgs, "--force-deploy")
+	}
+
+	run(deployArgs)
+}
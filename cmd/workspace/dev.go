@@ -0,0 +1,42 @@
+package workspace
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+type devCmd struct{}
+
+func newDevCmd() *cobra.Command {
+	cmd := &devCmd{}
+
+	devCmd := &cobra.Command{
+		Use:   "dev",
+		Short: "Starts the development mode in all projects",
+		Long: `
+#######################################################
+############### devspace workspace dev ################
+#######################################################
+Starts devspace dev in all projects of the workspace
+concurrently. The output of each project is prefixed
+with the project name. Ctrl+C stops all projects.
+
+The terminal of the projects is disabled. Use
+devspace enter in a project to open a terminal.
+
+Example:
+devspace workspace dev
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+		Run:  cmd.Run,
+	}
+
+	return devCmd
+}
+
+// Run executes the command logic
+func (cmd *devCmd) Run(cobraCmd *cobra.Command, args []string) {
+	log.Info("Starting development mode in all projects")
+	run([]string{"dev", "--terminal=false"})
+}
@@ -0,0 +1,48 @@
+package workspace
+
+import (
+	"os"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/workspace"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/spf13/cobra"
+)
+
+// NewWorkspaceCmd creates a new cobra command for the workspace sub command
+func NewWorkspaceCmd() *cobra.Command {
+	workspaceCmd := &cobra.Command{
+		Use:   "workspace",
+		Short: "Runs commands in multiple projects",
+		Long: `
+#######################################################
+################# devspace workspace ##################
+#######################################################
+Runs DevSpace commands in all projects that are listed
+in devspace-workspace.yaml concurrently
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	workspaceCmd.AddCommand(newDevCmd())
+	workspaceCmd.AddCommand(newDeployCmd())
+
+	return workspaceCmd
+}
+
+// run loads the workspace config and runs the devspace command in all projects
+func run(args []string) {
+	config, err := workspace.LoadConfig(workspace.ConfigPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Fatalf("Couldn't find %s in the current directory", workspace.ConfigPath)
+		}
+
+		log.Fatal(err)
+	}
+
+	err = workspace.Run(config, args, os.Stdout, log.GetInstance())
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -55,6 +55,18 @@ func CreateReport(client kubernetes.Interface, namespace string, noWait bool) ([
 		})
 	}
 
+	// Analyze persistent volume claims
+	problems, err = PersistentVolumeClaims(client, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("Error during analyzing persistent volume claims: %v", err)
+	}
+	if len(problems) > 0 {
+		report = append(report, &ReportItem{
+			Name:     "PersistentVolumeClaims",
+			Problems: problems,
+		})
+	}
+
 	// We only check events if we suspect a problem
 	checkEvents := len(report) > 0
 
@@ -136,3 +136,32 @@ func TestReportToString(t *testing.T) {
 `
 	assert.Equal(t, expectedString, ReportToString(report), "Report wrong translated")
 }
+
+func TestPersistentVolumeClaims(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset(
+		&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "bound", Namespace: "testNS", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+			Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimBound},
+		},
+		&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "just-created", Namespace: "testNS", CreationTimestamp: metav1.NewTime(time.Now())},
+			Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimPending},
+		},
+		&k8sv1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "unbound", Namespace: "testNS", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+			Spec:       k8sv1.PersistentVolumeClaimSpec{StorageClassName: ptr.String("fast")},
+			Status:     k8sv1.PersistentVolumeClaimStatus{Phase: k8sv1.ClaimPending},
+		},
+	)
+
+	problems, err := PersistentVolumeClaims(kubeClient, "testNS")
+	assert.NilError(t, err, "Error analyzing persistent volume claims")
+	assert.Equal(t, 1, len(problems), "Wrong number of problems reported")
+	assert.Equal(t, true, strings.Contains(problems[0], "unbound"), "Report does not address the unbound claim")
+	assert.Equal(t, true, strings.Contains(problems[0], "fast"), "Report does not contain the storage class")
+
+	reports, err := CreateReport(kubeClient, "testNS", true)
+	assert.NilError(t, err, "Error while creating a report")
+	assert.Equal(t, 1, len(reports), "Wrong number of report items")
+	assert.Equal(t, "PersistentVolumeClaims", reports[0].Name, "Wrong report item")
+}
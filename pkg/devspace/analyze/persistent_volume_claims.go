@@ -0,0 +1,56 @@
+package analyze
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PersistentVolumeClaims checks for persistent volume claims that are not bound to a volume
+func PersistentVolumeClaims(client kubernetes.Interface, namespace string) ([]string, error) {
+	problems := []string{}
+
+	log.StartWait("Analyzing persistent volume claims")
+	defer log.StopWait()
+
+	claims, err := client.CoreV1().PersistentVolumeClaims(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, claim := range claims.Items {
+		if claim.Status.Phase == v1.ClaimBound {
+			continue
+		}
+
+		// Volumes are usually provisioned within a few seconds
+		age := time.Since(claim.CreationTimestamp.Time)
+		if claim.Status.Phase == v1.ClaimPending && age < MinimumPodAge {
+			continue
+		}
+
+		s := []string{
+			fmt.Sprintf("PersistentVolumeClaim %s:", ansi.Color(claim.Name, "white+b")),
+			fmt.Sprintf("    Status: %s", ansi.Color(string(claim.Status.Phase), "red+b")),
+			fmt.Sprintf("    Created: %s ago", ansi.Color(age.Round(time.Second).String(), "white+b")),
+		}
+		if claim.Spec.StorageClassName != nil {
+			s = append(s, fmt.Sprintf("    StorageClass: %s", ansi.Color(*claim.Spec.StorageClassName, "white+b")))
+		} else {
+			s = append(s, fmt.Sprintf("    StorageClass: %s", ansi.Color("none (default storage class)", "white+b")))
+		}
+		if storage, ok := claim.Spec.Resources.Requests[v1.ResourceStorage]; ok {
+			s = append(s, fmt.Sprintf("    Requested: %s", ansi.Color(storage.String(), "white+b")))
+		}
+
+		problems = append(problems, paddingLeft+strings.Join(s, paddingLeft+"\n")+"\n")
+	}
+
+	return problems, nil
+}
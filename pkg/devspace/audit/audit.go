@@ -0,0 +1,206 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// LogPath is the path of the audit log relative to the home directory
+var LogPath = constants.DefaultHomeDevSpaceFolder + "/audit.log"
+
+// The actions that are recorded in the audit log
+const (
+	ActionCreate    = "create"
+	ActionUpdate    = "update"
+	ActionDelete    = "delete"
+	ActionApply     = "apply"
+	ActionInstall   = "install"
+	ActionUpgrade   = "upgrade"
+	ActionUninstall = "uninstall"
+	ActionRollback  = "rollback"
+)
+
+// webhookTimeout is the maximum time a webhook request may take
+const webhookTimeout = 5 * time.Second
+
+// Entry is a single action in the audit log
+type Entry struct {
+	Time time.Time `json:"time"`
+
+	// Who and from where the action was done
+	User    string `json:"user,omitempty"`
+	Host    string `json:"host,omitempty"`
+	Dir     string `json:"dir,omitempty"`
+	Command string `json:"command,omitempty"`
+
+	// What was changed in the cluster
+	KubeContext string `json:"kubeContext,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Action      string `json:"action"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Error       string `json:"error,omitempty"`
+}
+
+// Recorder appends entries to an audit log file and optionally posts them to a webhook
+type Recorder struct {
+	path    string
+	webhook string
+	client  *http.Client
+
+	mutex sync.Mutex
+}
+
+// NewRecorder creates a new recorder that writes to the given file. The webhook is optional
+func NewRecorder(path, webhook string) *Recorder {
+	return &Recorder{
+		path:    path,
+		webhook: webhook,
+		client:  &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Record appends the entry to the audit log and posts it to the webhook
+func (r *Recorder) Record(entry *Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	err = r.write(data)
+	if err != nil {
+		return err
+	}
+
+	if r.webhook != "" {
+		response, err := r.client.Post(r.webhook, "application/json", bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		response.Body.Close()
+
+		if response.StatusCode >= 300 {
+			return fmt.Errorf("Audit webhook returned status %s", response.Status)
+		}
+	}
+
+	return nil
+}
+
+func (r *Recorder) write(data []byte) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	err := os.MkdirAll(filepath.Dir(r.path), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+var (
+	defaultRecorder     *Recorder
+	defaultRecorderOnce sync.Once
+
+	kubeContext      string
+	kubeContextMutex sync.Mutex
+)
+
+// SetKubeContext sets the kube context that is recorded with every following entry
+func SetKubeContext(context string) {
+	kubeContextMutex.Lock()
+	defer kubeContextMutex.Unlock()
+
+	kubeContext = context
+}
+
+// Record records an action that changed the cluster in the global audit log. The action is recorded
+// regardless of its outcome, a failed action is recorded with its error. Problems writing the audit log
+// are ignored, because they should never break the actual command
+func Record(action, kind, namespace, name string, actionErr error) {
+	recorder := getDefaultRecorder()
+	if recorder == nil {
+		return
+	}
+
+	entry := NewEntry(action, kind, namespace, name, actionErr)
+	recorder.Record(entry)
+}
+
+// NewEntry creates a new entry and fills in the time, user and context of the current process
+func NewEntry(action, kind, namespace, name string, actionErr error) *Entry {
+	entry := &Entry{
+		Time:      time.Now(),
+		Command:   strings.Join(os.Args[1:], " "),
+		Namespace: namespace,
+		Action:    action,
+		Kind:      kind,
+		Name:      name,
+	}
+
+	if currentUser, err := user.Current(); err == nil {
+		entry.User = currentUser.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		entry.Host = host
+	}
+	if dir, err := os.Getwd(); err == nil {
+		entry.Dir = dir
+	}
+	if actionErr != nil {
+		entry.Error = actionErr.Error()
+	}
+
+	kubeContextMutex.Lock()
+	entry.KubeContext = kubeContext
+	kubeContextMutex.Unlock()
+
+	return entry
+}
+
+func getDefaultRecorder() *Recorder {
+	defaultRecorderOnce.Do(func() {
+		globalSettings, err := settings.Get()
+		if err != nil {
+			return
+		}
+
+		webhook := ""
+		if globalSettings.Audit != nil {
+			if globalSettings.Audit.Disabled {
+				return
+			}
+
+			webhook = globalSettings.Audit.Webhook
+		}
+
+		homedir, err := homedir.Dir()
+		if err != nil {
+			return
+		}
+
+		defaultRecorder = NewRecorder(filepath.Join(homedir, LogPath), webhook)
+	})
+
+	return defaultRecorder
+}
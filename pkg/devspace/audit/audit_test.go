@@ -0,0 +1,97 @@
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testAudit")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	received := []*Entry{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		entry := &Entry{}
+		err := json.NewDecoder(r.Body).Decode(entry)
+		if err != nil {
+			t.Errorf("Error decoding webhook request: %v", err)
+		}
+
+		received = append(received, entry)
+	}))
+	defer server.Close()
+
+	SetKubeContext("test-context")
+	defer SetKubeContext("")
+
+	recorder := NewRecorder(filepath.Join(dir, "audit", "audit.log"), server.URL)
+	err = recorder.Record(NewEntry(ActionCreate, "Secret", "test", "devspace-auth-docker", nil))
+	if err != nil {
+		t.Fatalf("Error recording entry: %v", err)
+	}
+	err = recorder.Record(NewEntry(ActionDelete, "Release", "test", "my-app", errors.New("release not found")))
+	if err != nil {
+		t.Fatalf("Error recording entry: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "audit", "audit.log"))
+	if err != nil {
+		t.Fatalf("Error reading audit log: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 audit log entries, got %d: %s", len(lines), string(data))
+	}
+
+	entry := &Entry{}
+	err = json.Unmarshal([]byte(lines[1]), entry)
+	if err != nil {
+		t.Fatalf("Error parsing audit log entry: %v", err)
+	}
+	if entry.Action != ActionDelete || entry.Kind != "Release" || entry.Name != "my-app" || entry.Namespace != "test" {
+		t.Fatalf("Unexpected entry %#v", entry)
+	}
+	if entry.KubeContext != "test-context" || entry.Error != "release not found" || entry.Time.IsZero() {
+		t.Fatalf("Unexpected entry %#v", entry)
+	}
+
+	if len(received) != 2 || received[0].Name != "devspace-auth-docker" {
+		t.Fatalf("Unexpected webhook requests %#v", received)
+	}
+}
+
+func TestRecordWebhookError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testAudit")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	recorder := NewRecorder(filepath.Join(dir, "audit.log"), server.URL)
+	err = recorder.Record(NewEntry(ActionApply, "Manifests", "test", "my-app", nil))
+	if err == nil {
+		t.Fatal("Expected webhook error")
+	}
+
+	// The entry is still written to the local log
+	data, err := ioutil.ReadFile(filepath.Join(dir, "audit.log"))
+	if err != nil || len(data) == 0 {
+		t.Fatalf("Expected entry in audit log: %v", err)
+	}
+}
@@ -1,28 +1,42 @@
 package build
 
 import (
-	"bytes"
 	"fmt"
+	"time"
 
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/custom"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
 	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/randutil"
 	"github.com/pkg/errors"
-	"github.com/sirupsen/logrus"
 )
 
 type imageNameAndTag struct {
-	imageConfigName string
-	imageName       string
-	imageTag        string
+	imageConfigName   string
+	imageName         string
+	resolvedImageName string
+	imageTag          string
+
+	engineName string
+	digest     string
+
+	// generatedTag is true if the tag was generated because the image has no tag in the config
+	generatedTag bool
 }
 
-// All builds all images
-func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, skipPush, isDev, forceRebuild, sequential bool, log logpkg.Logger) (map[string]string, error) {
+// generatedTagLength is the length of the random tags of images that have no tag in the config
+const generatedTagLength = 7
+
+// All builds all images. Images that are built in parallel print their output prefixed with the image name. If buildLogDir
+// is not empty, the output of each image build is additionally written to [buildLogDir]/[image].log
+func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, skipPush, isDev, forceRebuild, sequential bool, buildLogDir string, log logpkg.Logger) (map[string]string, error) {
 	var (
 		builtImages = make(map[string]string)
 
@@ -36,11 +50,18 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 		return builtImages, nil
 	}
 
-	// Build not in parallel when we only have one image to build
-	if sequential == false && len(*config.Images) <= 1 {
+	// Build not in parallel when we only have one image to build or parallel builds are limited to one
+	maxConcurrentBuilds := settings.GetLimits().MaxConcurrentBuilds
+	if sequential == false && (len(*config.Images) <= 1 || maxConcurrentBuilds == 1) {
 		sequential = true
 	}
 
+	// Limit the number of parallel builds
+	var buildSlots chan bool
+	if maxConcurrentBuilds > 1 {
+		buildSlots = make(chan bool, maxConcurrentBuilds)
+	}
+
 	// Execute before images build hook
 	err := hook.Execute(config, hook.Before, hook.StageImages, hook.All, log)
 	if err != nil {
@@ -59,13 +80,22 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 		imageName := *cImageConf.Image
 		imageConfigName := key
 
+		// Push the image to the registry alias if there is one
+		resolvedImageName := registry.GetAliasedImageName(config, imageName)
+		cImageConf.Image = &resolvedImageName
+
 		// Get image tag
-		imageTag, err := randutil.GenerateRandomString(7)
+		imageTag, err := randutil.GenerateRandomString(generatedTagLength)
 		if err != nil {
 			return nil, fmt.Errorf("Image building failed: %v", err)
 		}
+		generatedTag := true
 		if imageConf.Tag != nil {
 			imageTag = *imageConf.Tag
+			generatedTag = false
+		} else if imageConf.Tags != nil && len(*imageConf.Tags) > 0 {
+			imageTag = (*imageConf.Tags)[0]
+			generatedTag = false
 		}
 
 		// Create new builder
@@ -74,11 +104,19 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 			return nil, errors.Wrap(err, "create builder")
 		}
 
+		// Images with a dev variant are cached separately for devspace dev and devspace deploy
+		cache.SwitchImageVariant(imageConfigName, helper.GetImageVariant(imageConf, isDev))
+
 		// Check if rebuild is needed
 		needRebuild, err := builder.ShouldRebuild(cache)
 		if err != nil {
 			return nil, fmt.Errorf("Error during shouldRebuild check: %v", err)
 		}
+
+		// Rebuild if the configured tag has changed (e.g. a new git commit)
+		if (imageConf.Tag != nil || imageConf.Tags != nil) && cache.GetImageCache(imageConfigName).Tag != imageTag {
+			needRebuild = true
+		}
 		if forceRebuild == false && needRebuild == false {
 			log.Infof("Skip building image '%s'", imageConfigName)
 			continue
@@ -86,38 +124,65 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 
 		// Sequential or parallel build?
 		if sequential {
+			output, err := newBuildOutput(imageConfigName, log, false, buildLogDir)
+			if err != nil {
+				return nil, err
+			}
+
 			// Build the image
-			err = builder.Build(log)
+			err = builder.Build(output.log)
+			output.Close()
 			if err != nil {
 				return nil, err
 			}
 
 			// Update cache
-			imageCache := cache.GetImageCache(imageConfigName)
-			imageCache.ImageName = imageName
-			imageCache.Tag = imageTag
+			updateImageCache(cache, imageNameAndTag{
+				imageConfigName:   imageConfigName,
+				imageName:         imageName,
+				resolvedImageName: resolvedImageName,
+				imageTag:          imageTag,
+				engineName:        builder.EngineName(),
+				digest:            builder.Digest(),
+				generatedTag:      generatedTag,
+			})
 
 			// Track built images
 			builtImages[imageName] = imageTag
 		} else {
+			output, err := newBuildOutput(imageConfigName, log, true, buildLogDir)
+			if err != nil {
+				return nil, err
+			}
+
 			imagesToBuild++
 			go func() {
-				// Create a string log
-				buff := &bytes.Buffer{}
-				streamLog := logpkg.NewStreamLogger(buff, logrus.InfoLevel)
+				if buildSlots != nil {
+					buildSlots <- true
+					defer func() { <-buildSlots }()
+				}
 
 				// Build the image
-				err := builder.Build(streamLog)
+				err := builder.Build(output.log)
+				output.Close()
 				if err != nil {
-					errChan <- fmt.Errorf("Error building image %s:%s: %s %v", imageName, imageTag, buff.String(), err)
+					if output.logFile != "" {
+						errChan <- fmt.Errorf("Error building image %s:%s (see %s): %v", resolvedImageName, imageTag, output.logFile, err)
+					} else {
+						errChan <- fmt.Errorf("Error building image %s:%s: %v", resolvedImageName, imageTag, err)
+					}
 					return
 				}
 
 				// Send the reponse
 				cacheChan <- imageNameAndTag{
-					imageConfigName: imageConfigName,
-					imageName:       imageName,
-					imageTag:        imageTag,
+					imageConfigName:   imageConfigName,
+					imageName:         imageName,
+					resolvedImageName: resolvedImageName,
+					imageTag:          imageTag,
+					engineName:        builder.EngineName(),
+					digest:            builder.Digest(),
+					generatedTag:      generatedTag,
 				}
 			}()
 		}
@@ -134,12 +199,10 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 				return nil, err
 			case done := <-cacheChan:
 				imagesToBuild--
-				log.Donef("Done building image %s:%s (%s)", done.imageName, done.imageTag, done.imageConfigName)
+				log.Donef("Done building image %s:%s (%s)", done.resolvedImageName, done.imageTag, done.imageConfigName)
 
 				// Update cache
-				imageCache := cache.GetImageCache(done.imageConfigName)
-				imageCache.ImageName = done.imageName
-				imageCache.Tag = done.imageTag
+				updateImageCache(cache, done)
 
 				// Track built images
 				builtImages[done.imageName] = done.imageTag
@@ -155,3 +218,78 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 
 	return builtImages, nil
 }
+
+// NeedsRebuild checks if the next build would rebuild the image. In contrast to the builders ShouldRebuild, the cache
+// is not changed and neither a docker daemon nor a cluster is needed for the check
+func NeedsRebuild(config *latest.Config, cache *generated.CacheConfig, imageConfigName string, isDev bool) (bool, error) {
+	if config.Images == nil || (*config.Images)[imageConfigName] == nil {
+		return false, errors.Errorf("Image %s is not defined in images", imageConfigName)
+	}
+
+	imageConf := (*config.Images)[imageConfigName]
+	if imageConf.Build != nil && imageConf.Build.Disabled != nil && *imageConf.Build.Disabled == true {
+		return false, nil
+	}
+
+	// Check against a copy, because ShouldRebuild updates the hashes in the cache
+	tmpCache := generated.NewCache()
+	if imageCache, ok := cache.Images[imageConfigName]; ok {
+		cachedImage := *imageCache
+		tmpCache.Images[imageConfigName] = &cachedImage
+	}
+	tmpCache.SwitchImageVariant(imageConfigName, helper.GetImageVariant(imageConf, isDev))
+
+	// The build hashes the image config with the aliased image name
+	cImageConf := *imageConf
+	resolvedImageName := registry.GetAliasedImageName(config, *cImageConf.Image)
+	cImageConf.Image = &resolvedImageName
+
+	var needRebuild bool
+	if imageConf.Build != nil && imageConf.Build.Custom != nil {
+		rebuild, err := custom.NewBuilder(config, imageConfigName, &cImageConf, "", isDev).ShouldRebuild(tmpCache)
+		if err != nil {
+			return false, err
+		}
+
+		needRebuild = rebuild
+	} else {
+		rebuild, err := helper.NewBuildHelper(config, "", imageConfigName, &cImageConf, "", isDev).ShouldRebuild(tmpCache)
+		if err != nil {
+			return false, err
+		}
+
+		needRebuild = rebuild
+	}
+
+	// Rebuild if the configured tag has changed
+	if imageConf.Tag != nil && tmpCache.GetImageCache(imageConfigName).Tag != *imageConf.Tag {
+		needRebuild = true
+	} else if imageConf.Tag == nil && imageConf.Tags != nil && len(*imageConf.Tags) > 0 && tmpCache.GetImageCache(imageConfigName).Tag != (*imageConf.Tags)[0] {
+		needRebuild = true
+	}
+
+	return needRebuild, nil
+}
+
+// updateImageCache saves the result of an image build in the cache
+func updateImageCache(cache *generated.CacheConfig, built imageNameAndTag) {
+	imageCache := cache.GetImageCache(built.imageConfigName)
+	imageCache.ImageName = built.imageName
+	imageCache.ResolvedImageName = getResolvedImageName(built.imageName, built.resolvedImageName)
+	imageCache.Tag = built.imageTag
+	imageCache.Builder = built.engineName
+	imageCache.Digest = built.digest
+	imageCache.LastBuilt = time.Now().Unix()
+	if built.generatedTag {
+		imageCache.AddGeneratedTag(built.imageTag)
+	}
+}
+
+// getResolvedImageName returns the resolved image name if it differs from the configured image name
+func getResolvedImageName(imageName, resolvedImageName string) string {
+	if imageName == resolvedImageName {
+		return ""
+	}
+
+	return resolvedImageName
+}
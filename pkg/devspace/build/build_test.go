@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"time"
 	
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -77,7 +78,7 @@ func TestBuild(t *testing.T) {
 
 	//Test without images
 	go makeAllPodsRunning(t, kubeClient, configutil.TestNamespace)
-	images, err := All(testConfig, cache, kubeClient, true, true, true, true, log.GetInstance())
+	images, err := All(testConfig, cache, kubeClient, true, true, true, true, "", log.GetInstance())
 	if err != nil {
 		t.Fatalf("Error building all 0 images: %v", err)
 	}
@@ -88,7 +89,7 @@ func TestBuild(t *testing.T) {
 	(*testConfig.Images)["firstimg"] = &latest.ImageConfig{
 		Image: ptr.String("firstimg"),
 	}
-	images, err = All(testConfig, cache, kubeClient, true, true, true, false, log.GetInstance())
+	images, err = All(testConfig, cache, kubeClient, true, true, true, false, "", log.GetInstance())
 	if err != nil {
 		t.Fatalf("Error building all 1 images: %v", err)
 	}
@@ -257,3 +258,69 @@ spec:
 
 	return nil
 }
+
+func TestNeedsRebuild(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testNeedsRebuild")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+	defer func() {
+		os.Chdir(wdBackup)
+		os.RemoveAll(dir)
+	}()
+
+	err = ioutil.WriteFile("Dockerfile", []byte("FROM alpine"), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testConfig := &latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"default": &latest.ImageConfig{
+				Image: ptr.String("myuser/myimage"),
+				Tag:   ptr.String("v1"),
+			},
+		},
+	}
+	cache := generated.NewCache()
+
+	needRebuild, err := NeedsRebuild(testConfig, cache, "default", false)
+	assert.NilError(t, err)
+	assert.Equal(t, needRebuild, true, "Image without cache doesn't need a rebuild")
+	assert.Equal(t, len(cache.Images), 0, "NeedsRebuild changed the cache")
+
+	// Simulate a build
+	_, err = helper.NewBuildHelper(testConfig, "docker", "default", (*testConfig.Images)["default"], "v1", false).ShouldRebuild(cache)
+	assert.NilError(t, err)
+	cache.GetImageCache("default").Tag = "v1"
+
+	needRebuild, err = NeedsRebuild(testConfig, cache, "default", false)
+	assert.NilError(t, err)
+	assert.Equal(t, needRebuild, false, "Unchanged image needs a rebuild")
+
+	// Change the configured tag
+	(*testConfig.Images)["default"].Tag = ptr.String("v2")
+	needRebuild, err = NeedsRebuild(testConfig, cache, "default", false)
+	assert.NilError(t, err)
+	assert.Equal(t, needRebuild, true, "Image with changed tag doesn't need a rebuild")
+}
+
+func TestUpdateImageCacheGeneratedTags(t *testing.T) {
+	cache := generated.NewCache()
+	updateImageCache(cache, imageNameAndTag{imageConfigName: "default", imageName: "myimage", imageTag: "aB3dE9x", generatedTag: true})
+	updateImageCache(cache, imageNameAndTag{imageConfigName: "default", imageName: "myimage", imageTag: "release"})
+	updateImageCache(cache, imageNameAndTag{imageConfigName: "default", imageName: "myimage", imageTag: "hIjKlMn", generatedTag: true})
+
+	imageCache := cache.GetImageCache("default")
+	assert.Equal(t, imageCache.Tag, "hIjKlMn")
+	assert.DeepEqual(t, imageCache.GeneratedTags, []string{"aB3dE9x", "hIjKlMn"})
+}
@@ -3,17 +3,22 @@ package build
 import (
 	"context"
 	"fmt"
+	"os"
+	"sync"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/builder"
 	"github.com/devspace-cloud/devspace/pkg/devspace/builder/custom"
 	"github.com/devspace-cloud/devspace/pkg/devspace/builder/docker"
 	"github.com/devspace-cloud/devspace/pkg/devspace/builder/kaniko"
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/pod"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	dockerclient "github.com/devspace-cloud/devspace/pkg/devspace/docker"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
 
+	"github.com/docker/docker/pkg/term"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -22,11 +27,13 @@ func CreateBuilder(config *latest.Config, client kubernetes.Interface, imageConf
 	var imageBuilder builder.Interface
 
 	if imageConf.Build != nil && imageConf.Build.Custom != nil {
-		imageBuilder = custom.NewBuilder(imageConfigName, imageConf, imageTag)
+		imageBuilder = custom.NewBuilder(config, imageConfigName, imageConf, imageTag, isDev)
 	} else if imageConf.Build != nil && imageConf.Build.Kaniko != nil {
+		// The docker client is only used to get the registry credentials, which are also found without a docker daemon
 		dockerClient, err := dockerclient.NewClient(config, false, log)
 		if err != nil {
-			return nil, fmt.Errorf("Error creating docker client: %v", err)
+			log.Debugf("Error creating docker client: %v", err)
+			dockerClient = nil
 		}
 		if client == nil {
 			// Create kubectl client if not specified
@@ -42,27 +49,44 @@ func CreateBuilder(config *latest.Config, client kubernetes.Interface, imageConf
 		if err != nil {
 			return nil, fmt.Errorf("Error creating kaniko builder: %v", err)
 		}
+	} else if imageConf.Build != nil && imageConf.Build.Pod != nil {
+		dockerClient, err := dockerclient.NewClient(config, false, log)
+		if err != nil {
+			log.Debugf("Error creating docker client: %v", err)
+			dockerClient = nil
+		}
+		if client == nil {
+			// Create kubectl client if not specified
+			client, err = kubectl.NewClient(config)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to create new kubectl client: %v", err)
+			}
+		}
+
+		log.StartWait("Creating pod builder")
+		defer log.StopWait()
+		imageBuilder, err = pod.NewBuilder(config, dockerClient, client, imageConfigName, imageConf, imageTag, isDev, log)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating pod builder: %v", err)
+		}
 	} else {
 		preferMinikube := true
 		if imageConf.Build != nil && imageConf.Build.Docker != nil && imageConf.Build.Docker.PreferMinikube != nil {
 			preferMinikube = *imageConf.Build.Docker.PreferMinikube
 		}
 
+		// Check if docker daemon is running
 		dockerClient, err := dockerclient.NewClient(config, preferMinikube, log)
-		if err != nil {
-			return nil, fmt.Errorf("Error creating docker client: %v", err)
+		if err == nil {
+			_, err = dockerClient.Ping(context.Background())
 		}
-
-		// Check if docker daemon is running
-		_, err = dockerClient.Ping(context.Background())
 		if err != nil {
-			if imageConf.Build != nil && imageConf.Build.Docker != nil && imageConf.Build.Docker.DisableFallback != nil && *imageConf.Build.Docker.DisableFallback {
-				return nil, fmt.Errorf("Couldn't reach docker daemon: %v. Is the docker daemon running?", err)
+			inClusterConf, err := getInClusterImageConfig(imageConfigName, imageConf, err, log)
+			if err != nil {
+				return nil, err
 			}
 
-			// Fallback to kaniko
-			log.Infof("Couldn't find a running docker daemon. Will fallback to kaniko")
-			return CreateBuilder(config, client, imageConfigName, convertDockerConfigToKanikoConfig(imageConf), imageTag, skipPush, isDev, log)
+			return CreateBuilder(config, client, imageConfigName, inClusterConf, imageTag, skipPush, isDev, log)
 		}
 
 		imageBuilder, err = docker.NewBuilder(config, dockerClient, imageConfigName, imageConf, imageTag, skipPush, isDev)
@@ -74,13 +98,96 @@ func CreateBuilder(config *latest.Config, client kubernetes.Interface, imageConf
 	return imageBuilder, nil
 }
 
+const (
+	inClusterKaniko = "Build with kaniko in the cluster"
+	inClusterImg    = "Build with img (BuildKit) in the cluster"
+	inClusterAbort  = "Abort"
+)
+
+// inClusterAnswer is the answer to the question how to build images if the docker daemon is not reachable, so the
+// question is only asked once for all images
+var (
+	inClusterAnswer      string
+	inClusterAnswerMutex sync.Mutex
+)
+
+// getInClusterImageConfig returns the image config that builds the image in the cluster if the docker daemon is not
+// reachable. Unless build.preferInCluster is set, the user is asked which builder to use. Returns an error if the
+// image cannot or should not be built in the cluster
+func getInClusterImageConfig(imageConfigName string, imageConf *latest.ImageConfig, dockerErr error, log log.Logger) (*latest.ImageConfig, error) {
+	if imageConf.Build != nil && imageConf.Build.Docker != nil && imageConf.Build.Docker.DisableFallback != nil && *imageConf.Build.Docker.DisableFallback {
+		return nil, fmt.Errorf("Couldn't reach docker daemon: %v. Is the docker daemon running?", dockerErr)
+	}
+	if imageConf.Build != nil && imageConf.Build.PreferInCluster != nil && *imageConf.Build.PreferInCluster == false {
+		return nil, fmt.Errorf("Couldn't reach docker daemon: %v. Is the docker daemon running? Set images.%s.build.preferInCluster to true to build the image in the cluster instead", dockerErr, imageConfigName)
+	}
+	if imageConf.Build != nil && imageConf.Build.Export != nil {
+		return nil, fmt.Errorf("Couldn't reach docker daemon: %v. Exporting images requires a running docker daemon", dockerErr)
+	}
+
+	inClusterAnswerMutex.Lock()
+	defer inClusterAnswerMutex.Unlock()
+
+	answer := inClusterKaniko
+	if imageConf.Build != nil && imageConf.Build.PreferInCluster != nil {
+		log.Infof("Couldn't find a running docker daemon. Will build image %s with kaniko in the cluster", imageConfigName)
+	} else if inClusterAnswer != "" {
+		answer = inClusterAnswer
+	} else if survey.IsNoInput() == false && term.IsTerminal(os.Stdin.Fd()) {
+		log.StopWait()
+		log.Warnf("Couldn't find a running docker daemon: %v", dockerErr)
+		inClusterAnswer = survey.Question(&survey.QuestionOptions{
+			Question:     "How do you want to build image " + imageConfigName + "?",
+			DefaultValue: inClusterKaniko,
+			Options:      []string{inClusterKaniko, inClusterImg, inClusterAbort},
+		})
+		answer = inClusterAnswer
+	} else {
+		log.Infof("Couldn't find a running docker daemon. Will fallback to kaniko")
+	}
+
+	switch answer {
+	case inClusterImg:
+		return convertDockerConfigToPodConfig(imageConf, pod.ToolImg), nil
+	case inClusterAbort:
+		return nil, fmt.Errorf("Couldn't reach docker daemon: %v. Is the docker daemon running?", dockerErr)
+	}
+
+	return convertDockerConfigToKanikoConfig(imageConf), nil
+}
+
+func convertDockerConfigToPodConfig(dockerConfig *latest.ImageConfig, tool string) *latest.ImageConfig {
+	podConfig := &latest.ImageConfig{
+		Image:            dockerConfig.Image,
+		Tag:              dockerConfig.Tag,
+		Tags:             dockerConfig.Tags,
+		Dockerfile:       dockerConfig.Dockerfile,
+		Context:          dockerConfig.Context,
+		CreatePullSecret: dockerConfig.CreatePullSecret,
+		CredentialHelper: dockerConfig.CredentialHelper,
+		Build: &latest.BuildConfig{
+			Pod: &latest.PodBuildConfig{
+				Tool: &tool,
+			},
+		},
+	}
+
+	if dockerConfig.Build != nil && dockerConfig.Build.Docker != nil && dockerConfig.Build.Docker.Options != nil {
+		podConfig.Build.Pod.Options = dockerConfig.Build.Docker.Options
+	}
+
+	return podConfig
+}
+
 func convertDockerConfigToKanikoConfig(dockerConfig *latest.ImageConfig) *latest.ImageConfig {
 	kanikoConfig := &latest.ImageConfig{
 		Image:            dockerConfig.Image,
 		Tag:              dockerConfig.Tag,
+		Tags:             dockerConfig.Tags,
 		Dockerfile:       dockerConfig.Dockerfile,
 		Context:          dockerConfig.Context,
 		CreatePullSecret: dockerConfig.CreatePullSecret,
+		CredentialHelper: dockerConfig.CredentialHelper,
 		Build: &latest.BuildConfig{
 			Kaniko: &latest.KanikoConfig{
 				Cache: ptr.Bool(true),
@@ -0,0 +1,67 @@
+package build
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+func TestGetInClusterImageConfig(t *testing.T) {
+	dockerErr := errors.New("Cannot connect to the Docker daemon")
+
+	imageConf := &latest.ImageConfig{
+		Image: ptr.String("myimage"),
+		Build: &latest.BuildConfig{
+			PreferInCluster: ptr.Bool(true),
+			Docker: &latest.DockerConfig{
+				Options: &latest.BuildOptions{
+					Target: ptr.String("dev"),
+				},
+			},
+		},
+	}
+	inClusterConf, err := getInClusterImageConfig("default", imageConf, dockerErr, log.GetInstance())
+	assert.NilError(t, err)
+	assert.Equal(t, *inClusterConf.Image, "myimage")
+	assert.Assert(t, inClusterConf.Build.Kaniko != nil)
+	assert.Equal(t, *inClusterConf.Build.Kaniko.Options.Target, "dev")
+
+	imageConf.Build.PreferInCluster = ptr.Bool(false)
+	_, err = getInClusterImageConfig("default", imageConf, dockerErr, log.GetInstance())
+	assert.ErrorContains(t, err, "preferInCluster")
+
+	imageConf.Build.PreferInCluster = ptr.Bool(true)
+	imageConf.Build.Docker.DisableFallback = ptr.Bool(true)
+	_, err = getInClusterImageConfig("default", imageConf, dockerErr, log.GetInstance())
+	assert.ErrorContains(t, err, "Is the docker daemon running?")
+
+	imageConf.Build.Docker.DisableFallback = nil
+	imageConf.Build.Export = &latest.ExportConfig{}
+	_, err = getInClusterImageConfig("default", imageConf, dockerErr, log.GetInstance())
+	assert.ErrorContains(t, err, "Exporting images requires a running docker daemon")
+}
+
+func TestConvertDockerConfigToPodConfig(t *testing.T) {
+	imageConf := &latest.ImageConfig{
+		Image:      ptr.String("myimage"),
+		Dockerfile: ptr.String("Dockerfile.dev"),
+		Build: &latest.BuildConfig{
+			Docker: &latest.DockerConfig{
+				Options: &latest.BuildOptions{
+					Target: ptr.String("dev"),
+				},
+			},
+		},
+	}
+
+	podConf := convertDockerConfigToPodConfig(imageConf, "img")
+	assert.Equal(t, *podConf.Image, "myimage")
+	assert.Equal(t, *podConf.Dockerfile, "Dockerfile.dev")
+	assert.Equal(t, *podConf.Build.Pod.Tool, "img")
+	assert.Equal(t, *podConf.Build.Pod.Options.Target, "dev")
+}
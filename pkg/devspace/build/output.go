@@ -0,0 +1,70 @@
+package build
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// buildOutput is the logger a single image build writes to together with the resources that have to be released
+// after the build
+type buildOutput struct {
+	log     logpkg.Logger
+	logFile string
+
+	prefixWriter *logpkg.PrefixWriter
+	file         *os.File
+}
+
+// newBuildOutput creates the logger for a single image build. If prefix is true, every line is prefixed with the image
+// config name and written to log. If buildLogDir is not empty, the output is also written to [buildLogDir]/[image].log
+func newBuildOutput(imageConfigName string, log logpkg.Logger, prefix bool, buildLogDir string) (*buildOutput, error) {
+	output := &buildOutput{
+		log: log,
+	}
+
+	writers := []io.Writer{}
+	if prefix {
+		output.prefixWriter = logpkg.NewPrefixWriter("["+imageConfigName+"] ", log)
+		writers = append(writers, output.prefixWriter)
+	}
+
+	if buildLogDir != "" {
+		err := os.MkdirAll(buildLogDir, 0755)
+		if err != nil {
+			return nil, errors.Wrap(err, "create build log dir")
+		}
+
+		output.logFile = filepath.Join(buildLogDir, imageConfigName+".log")
+		output.file, err = os.Create(output.logFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "create build log file")
+		}
+
+		writers = append(writers, output.file)
+		if prefix == false {
+			// Sequential builds still print their output as usual
+			writers = append(writers, log)
+		}
+	}
+
+	if len(writers) > 0 {
+		output.log = logpkg.NewStreamLogger(io.MultiWriter(writers...), logrus.InfoLevel)
+	}
+
+	return output, nil
+}
+
+// Close flushes the output and closes the log file
+func (b *buildOutput) Close() {
+	if b.prefixWriter != nil {
+		b.prefixWriter.Flush()
+	}
+	if b.file != nil {
+		b.file.Close()
+	}
+}
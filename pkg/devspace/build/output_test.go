@@ -0,0 +1,44 @@
+package build
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+)
+
+func TestBuildOutputLogFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testBuildOutput")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	out := &bytes.Buffer{}
+	output, err := newBuildOutput("api", logpkg.NewStreamLogger(out, logrus.InfoLevel), true, filepath.Join(dir, "logs"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	output.log.WriteString("Building image\n")
+	output.Close()
+
+	if output.logFile != filepath.Join(dir, "logs", "api.log") {
+		t.Fatalf("Unexpected log file %s", output.logFile)
+	}
+
+	content, err := ioutil.ReadFile(output.logFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "Building image\n" {
+		t.Fatalf("Unexpected log file content %q", string(content))
+	}
+	if out.String() != "[api] Building image\n" {
+		t.Fatalf("Unexpected output %q", out.String())
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"sort"
 	"strings"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
+
 	"github.com/bmatcuk/doublestar"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/command"
@@ -22,25 +26,51 @@ var (
 	_, stdout, stderr = dockerterm.StdStreams()
 )
 
+// The environment variables that are passed to the custom build command
+const (
+	ImageEnv           = "DEVSPACE_IMAGE"
+	ImageTagEnv        = "DEVSPACE_IMAGE_TAG"
+	ImageTagsEnv       = "DEVSPACE_IMAGE_TAGS"
+	ImageContextEnv    = "DEVSPACE_IMAGE_CONTEXT"
+	ImageDockerfileEnv = "DEVSPACE_IMAGE_DOCKERFILE"
+)
+
+// EngineName is the name of the building engine
+const EngineName = "custom"
+
 // Builder holds all the relevant information for a custom build
 type Builder struct {
+	config    *latest.Config
 	imageConf *latest.ImageConfig
 
 	imageConfigName string
 	imageTag        string
+	isDev           bool
 
 	cmd command.Interface
 }
 
 // NewBuilder creates a new custom builder
-func NewBuilder(imageConfigName string, imageConf *latest.ImageConfig, imageTag string) *Builder {
+func NewBuilder(config *latest.Config, imageConfigName string, imageConf *latest.ImageConfig, imageTag string, isDev bool) *Builder {
 	return &Builder{
+		config:          config,
 		imageConfigName: imageConfigName,
 		imageConf:       imageConf,
 		imageTag:        imageTag,
+		isDev:           isDev,
 	}
 }
 
+// EngineName implements interface
+func (b *Builder) EngineName() string {
+	return EngineName
+}
+
+// Digest implements interface
+func (b *Builder) Digest() string {
+	return ""
+}
+
 // ShouldRebuild implements interface
 func (b *Builder) ShouldRebuild(cache *generated.CacheConfig) (bool, error) {
 	if b.imageConf.Build.Custom.OnChange == nil || len(*b.imageConf.Build.Custom.OnChange) == 0 {
@@ -86,6 +116,11 @@ func (b *Builder) ShouldRebuild(cache *generated.CacheConfig) (bool, error) {
 
 // Build implements interface
 func (b *Builder) Build(log logpkg.Logger) error {
+	customCommand, customArgs, err := configutil.GetOSCommand(b.imageConf.Build.Custom.Command, b.imageConf.Build.Custom.Args, b.imageConf.Build.Custom.Commands)
+	if err != nil {
+		return err
+	}
+
 	// Build arguments
 	args := []string{}
 
@@ -95,14 +130,19 @@ func (b *Builder) Build(log logpkg.Logger) error {
 		args = append(args, *b.imageConf.Image+":"+b.imageTag)
 	}
 
-	if b.imageConf.Build.Custom.Args != nil {
-		for _, arg := range *b.imageConf.Build.Custom.Args {
-			args = append(args, *arg)
-		}
-	}
+	args = append(args, customArgs...)
 
 	if b.cmd == nil {
-		b.cmd = command.NewStreamCommand(filepath.FromSlash(*b.imageConf.Build.Custom.Command), args)
+		env, err := b.getEnv()
+		if err != nil {
+			return err
+		}
+
+		if command.IsShellScript(customCommand) {
+			b.cmd = command.NewShellCommand(customCommand, args, env)
+		} else {
+			b.cmd = command.NewStreamCommandWithEnv(filepath.FromSlash(customCommand), args, env)
+		}
 	}
 
 	// Determine output writer
@@ -113,9 +153,9 @@ func (b *Builder) Build(log logpkg.Logger) error {
 		writer = log
 	}
 
-	log.Infof("Build %s:%s with custom command %s %s", *b.imageConf.Image, b.imageTag, *b.imageConf.Build.Custom.Command, strings.Join(args, " "))
+	log.Infof("Build %s:%s with custom command %s %s", *b.imageConf.Image, b.imageTag, customCommand, strings.Join(args, " "))
 
-	err := b.cmd.Run(writer, writer, nil)
+	err = b.cmd.Run(writer, writer, nil)
 	if err != nil {
 		return fmt.Errorf("Error building image: %v", err)
 	}
@@ -123,3 +163,32 @@ func (b *Builder) Build(log logpkg.Logger) error {
 	log.Done("Done processing image '" + *b.imageConf.Image + "'")
 	return nil
 }
+
+// getEnv returns the environment variables that describe the image to build. Context and Dockerfile are the same
+// the other builders would use, so in dev mode they include the overrides of dev.overrideImages
+func (b *Builder) getEnv() ([]string, error) {
+	dockerfilePath, contextPath := helper.GetDockerfileAndContext(b.config, b.imageConfigName, b.imageConf, b.isDev)
+
+	absoluteContextPath, err := filepath.Abs(contextPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "get absolute context path")
+	}
+	absoluteDockerfilePath, err := filepath.Abs(dockerfilePath)
+	if err != nil {
+		return nil, errors.Wrap(err, "get absolute dockerfile path")
+	}
+
+	env := []string{}
+	for key, value := range helper.GetBuildEnv(b.imageConf) {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+
+	return append(env,
+		ImageEnv+"="+*b.imageConf.Image,
+		ImageTagEnv+"="+b.imageTag,
+		ImageTagsEnv+"="+strings.Join(helper.GetImageTags(b.imageConf, b.imageTag), " "),
+		ImageContextEnv+"="+absoluteContextPath,
+		ImageDockerfileEnv+"="+absoluteDockerfilePath,
+	), nil
+}
@@ -34,7 +34,7 @@ func TestShouldRebuild(t *testing.T) {
 		},
 	}
 
-	shouldRebuild, err := NewBuilder(imageConfigName, imageConf, imageTag).ShouldRebuild(nil)
+	shouldRebuild, err := NewBuilder(&latest.Config{}, imageConfigName, imageConf, imageTag, false).ShouldRebuild(nil)
 	if shouldRebuild == false {
 		t.Fatal("Expected rebuild true, got false")
 	}
@@ -54,7 +54,7 @@ func TestShouldRebuild(t *testing.T) {
 	imageCache := cache.GetImageCache(imageConfigName)
 	imageCache.Tag = imageTag
 
-	shouldRebuild, err = NewBuilder(imageConfigName, imageConf, imageTag).ShouldRebuild(cache)
+	shouldRebuild, err = NewBuilder(&latest.Config{}, imageConfigName, imageConf, imageTag, false).ShouldRebuild(cache)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -62,7 +62,7 @@ func TestShouldRebuild(t *testing.T) {
 		log.Fatal("1: Expected rebuild true, got false")
 	}
 
-	shouldRebuild, err = NewBuilder(imageConfigName, imageConf, imageTag).ShouldRebuild(cache)
+	shouldRebuild, err = NewBuilder(&latest.Config{}, imageConfigName, imageConf, imageTag, false).ShouldRebuild(cache)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -71,7 +71,7 @@ func TestShouldRebuild(t *testing.T) {
 	}
 
 	imageConf.Image = ptr.String("test-image-new")
-	shouldRebuild, err = NewBuilder(imageConfigName, imageConf, imageTag).ShouldRebuild(cache)
+	shouldRebuild, err = NewBuilder(&latest.Config{}, imageConfigName, imageConf, imageTag, false).ShouldRebuild(cache)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -95,7 +95,7 @@ func TestBuild(t *testing.T) {
 		},
 	}
 
-	builder := NewBuilder(imageConfigName, imageConf, imageTag)
+	builder := NewBuilder(&latest.Config{}, imageConfigName, imageConf, imageTag, false)
 	builder.cmd = &command.FakeCommand{}
 
 	err := builder.Build(log.GetInstance())
@@ -103,3 +103,77 @@ func TestBuild(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetEnv(t *testing.T) {
+	imageConf := &latest.ImageConfig{
+		Image:      ptr.String("test-image"),
+		Tags:       &[]string{"latest", imageTag},
+		Context:    ptr.String("/context"),
+		Dockerfile: ptr.String("/context/Dockerfile"),
+		Build: &latest.BuildConfig{
+			Custom: &latest.CustomConfig{
+				Command: ptr.String("my-command"),
+			},
+		},
+	}
+
+	env, err := NewBuilder(&latest.Config{}, imageConfigName, imageConf, imageTag, false).getEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]bool{
+		ImageEnv + "=test-image":         true,
+		ImageTagEnv + "=" + imageTag:     true,
+		ImageTagsEnv + "=test123 latest": true,
+		ImageContextEnv + "=/context":    true,
+	}
+	for _, e := range env {
+		delete(expected, e)
+	}
+	if len(expected) > 0 {
+		t.Fatalf("Missing environment variables %v in %v", expected, env)
+	}
+}
+
+func TestGetEnvDev(t *testing.T) {
+	imageConf := &latest.ImageConfig{
+		Image:      ptr.String("test-image"),
+		Context:    ptr.String("/context"),
+		Dockerfile: ptr.String("/context/Dockerfile"),
+		Dev: &latest.ImageDevConfig{
+			Dockerfile: ptr.String("/context/Dockerfile.dev"),
+		},
+		Build: &latest.BuildConfig{
+			Custom: &latest.CustomConfig{
+				Command: ptr.String("my-command"),
+			},
+		},
+	}
+	config := &latest.Config{
+		Dev: &latest.DevConfig{
+			OverrideImages: &[]*latest.ImageOverrideConfig{
+				{
+					Name:    ptr.String(imageConfigName),
+					Context: ptr.String("/dev-context"),
+				},
+			},
+		},
+	}
+
+	env, err := NewBuilder(config, imageConfigName, imageConf, imageTag, true).getEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]bool{
+		ImageContextEnv + "=/dev-context":               true,
+		ImageDockerfileEnv + "=/context/Dockerfile.dev": true,
+	}
+	for _, e := range env {
+		delete(expected, e)
+	}
+	if len(expected) > 0 {
+		t.Fatalf("Missing environment variables %v in %v", expected, env)
+	}
+}
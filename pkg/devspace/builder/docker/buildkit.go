@@ -0,0 +1,89 @@
+package docker
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	dockerclient "github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/devspace-cloud/devspace/pkg/util/command"
+	"github.com/docker/docker/api/types"
+	"github.com/pkg/errors"
+)
+
+// needsBuildKit returns true if the build options contain ssh or secret mounts, which are only supported by BuildKit
+func needsBuildKit(buildOptions *latest.BuildOptions) bool {
+	if buildOptions == nil {
+		return false
+	}
+
+	return (buildOptions.SSH != nil && len(*buildOptions.SSH) > 0) || (buildOptions.Secrets != nil && len(*buildOptions.Secrets) > 0)
+}
+
+// buildWithBuildKit builds the image with BuildKit through the docker cli. The docker client cannot open the session
+// that BuildKit uses to forward ssh agents and secrets to the build, so builds with ssh or secret mounts are run
+// with the docker cli of the same docker daemon
+func (b *Builder) buildWithBuildKit(contextPath, dockerfilePath string, entrypoint *[]*string, fullImageNames []string, options *types.ImageBuildOptions, writer io.Writer) error {
+	// The docker cli accepts a Dockerfile outside of the context, so the entrypoint is overwritten in a temporary Dockerfile
+	if entrypoint != nil && len(*entrypoint) > 0 {
+		var err error
+		dockerfilePath, err = helper.CreateTempDockerfile(dockerfilePath, *entrypoint)
+		if err != nil {
+			return err
+		}
+
+		defer os.RemoveAll(filepath.Dir(dockerfilePath))
+	}
+
+	env := append([]string{"DOCKER_BUILDKIT=1"}, dockerclient.GetCLIEnvironment(b.client)...)
+	args := getBuildKitArgs(contextPath, dockerfilePath, fullImageNames, options, b.helper.ImageConf.Build.Docker.Options)
+
+	err := command.NewStreamCommandWithEnv("docker", args, env).Run(writer, writer, nil)
+	if err != nil {
+		return errors.Wrap(err, "docker build")
+	}
+
+	return nil
+}
+
+// getBuildKitArgs returns the arguments of docker build for the build options
+func getBuildKitArgs(contextPath, dockerfilePath string, fullImageNames []string, options *types.ImageBuildOptions, buildOptions *latest.BuildOptions) []string {
+	args := []string{"build", "--file", dockerfilePath}
+	for _, fullImageName := range fullImageNames {
+		args = append(args, "--tag", fullImageName)
+	}
+
+	buildArgKeys := make([]string, 0, len(options.BuildArgs))
+	for key := range options.BuildArgs {
+		buildArgKeys = append(buildArgKeys, key)
+	}
+
+	sort.Strings(buildArgKeys)
+	for _, key := range buildArgKeys {
+		if options.BuildArgs[key] != nil {
+			args = append(args, "--build-arg", key+"="+*options.BuildArgs[key])
+		}
+	}
+
+	if options.Target != "" {
+		args = append(args, "--target", options.Target)
+	}
+	if options.NetworkMode != "" {
+		args = append(args, "--network", options.NetworkMode)
+	}
+	if buildOptions.SSH != nil {
+		for _, ssh := range *buildOptions.SSH {
+			args = append(args, "--ssh", ssh)
+		}
+	}
+	if buildOptions.Secrets != nil {
+		for _, secret := range *buildOptions.Secrets {
+			args = append(args, "--secret", secret)
+		}
+	}
+
+	return append(args, contextPath)
+}
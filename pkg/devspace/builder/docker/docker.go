@@ -1,7 +1,6 @@
 package docker
 
 import (
-	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -16,7 +15,9 @@ import (
 	dockerclient "github.com/devspace-cloud/devspace/pkg/devspace/docker"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl/minikube"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
 	"github.com/docker/distribution/reference"
 
@@ -49,6 +50,8 @@ type Builder struct {
 	authConfig *types.AuthConfig
 	client     client.CommonAPIClient
 	skipPush   bool
+
+	digest string
 }
 
 // NewBuilder creates a new docker Builder instance
@@ -65,20 +68,51 @@ func (b *Builder) Build(log logpkg.Logger) error {
 	return b.helper.Build(b, log)
 }
 
+// EngineName implements the interface
+func (b *Builder) EngineName() string {
+	return EngineName
+}
+
+// Digest implements the interface
+func (b *Builder) Digest() string {
+	return b.digest
+}
+
 // ShouldRebuild determines if an image has to be rebuilt
 func (b *Builder) ShouldRebuild(cache *generated.CacheConfig) (bool, error) {
+	// Rebuild if the exported image was removed
+	if exportConfig := b.getExportConfig(); exportConfig != nil {
+		_, err := os.Stat(*exportConfig.Dest)
+		if os.IsNotExist(err) {
+			return true, nil
+		}
+	}
+
 	return b.helper.ShouldRebuild(cache)
 }
 
+// getExportConfig returns the export config of the image or nil if the image should not be exported
+func (b *Builder) getExportConfig() *latest.ExportConfig {
+	if b.helper.ImageConf == nil || b.helper.ImageConf.Build == nil || b.helper.ImageConf.Build.Export == nil || b.helper.ImageConf.Build.Export.Dest == nil {
+		return nil
+	}
+
+	return b.helper.ImageConf.Build.Export
+}
+
 // BuildImage builds a dockerimage with the docker cli
 // contextPath is the absolute path to the context path
 // dockerfilePath is the absolute path to the dockerfile WITHIN the contextPath
 func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*string, log logpkg.Logger) error {
 	var (
-		fullImageName      = b.helper.ImageName + ":" + b.helper.ImageTag
+		fullImageNames     = []string{}
 		displayRegistryURL = "hub.docker.com"
 	)
 
+	for _, imageTag := range b.helper.ImageTags {
+		fullImageNames = append(fullImageNames, b.helper.ImageName+":"+imageTag)
+	}
+
 	// Display nice registry name
 	registryURL, err := registry.GetRegistryFromImageName(b.helper.ImageName)
 	if err != nil {
@@ -95,6 +129,12 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 		}
 	}
 
+	// Exported images are not pushed
+	exportConfig := b.getExportConfig()
+	if exportConfig != nil {
+		b.skipPush = true
+	}
+
 	// Authenticate
 	if b.skipPush == false && (b.helper.ImageConf.Build == nil || b.helper.ImageConf.Build.Docker == nil || b.helper.ImageConf.Build.Docker.SkipPush == nil || *b.helper.ImageConf.Build.Docker.SkipPush == false) {
 		log.StartWait("Authenticating (" + displayRegistryURL + ")")
@@ -108,11 +148,13 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 	}
 
 	// Buildoptions
-	options := &types.ImageBuildOptions{}
+	options := &types.ImageBuildOptions{
+		BuildArgs: map[string]*string{},
+	}
+	for key, value := range helper.GetBuildArgs(b.helper.ImageConf) {
+		options.BuildArgs[key] = ptr.String(value)
+	}
 	if b.helper.ImageConf.Build != nil && b.helper.ImageConf.Build.Docker != nil && b.helper.ImageConf.Build.Docker.Options != nil {
-		if b.helper.ImageConf.Build.Docker.Options.BuildArgs != nil {
-			options.BuildArgs = *b.helper.ImageConf.Build.Docker.Options.BuildArgs
-		}
 		if b.helper.ImageConf.Build.Docker.Options.Target != nil {
 			options.Target = *b.helper.ImageConf.Build.Docker.Options.Target
 		}
@@ -120,6 +162,9 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 			options.NetworkMode = *b.helper.ImageConf.Build.Docker.Options.Network
 		}
 	}
+	if b.helper.Target != "" {
+		options.Target = b.helper.Target
+	}
 
 	// Determine output writer
 	var writer io.Writer
@@ -129,7 +174,51 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 		writer = log
 	}
 
-	ctx := context.Background()
+	// ssh and secret mounts require BuildKit
+	if b.helper.ImageConf.Build != nil && b.helper.ImageConf.Build.Docker != nil && needsBuildKit(b.helper.ImageConf.Build.Docker.Options) {
+		err = b.buildWithBuildKit(contextPath, dockerfilePath, entrypoint, fullImageNames, options, writer)
+	} else {
+		err = b.buildWithClient(contextPath, dockerfilePath, entrypoint, fullImageNames, options, writer)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Check if we skip push
+	if b.skipPush == false && (b.helper.ImageConf.Build == nil || b.helper.ImageConf.Build.Docker == nil || b.helper.ImageConf.Build.Docker.SkipPush == nil || *b.helper.ImageConf.Build.Docker.SkipPush == false) {
+		for _, fullImageName := range fullImageNames {
+			err = b.PushImage(fullImageName, writer)
+			if err != nil {
+				return fmt.Errorf("Error during image push: %v", err)
+			}
+		}
+
+		log.Info("Image pushed to registry (" + displayRegistryURL + ")")
+	} else if exportConfig != nil {
+		exportType := ExportTypeDocker
+		if exportConfig.Type != nil {
+			exportType = *exportConfig.Type
+		}
+
+		log.StartWait("Exporting image to " + *exportConfig.Dest)
+		err = b.ExportImage(fullImageNames, exportType, *exportConfig.Dest)
+		log.StopWait()
+		if err != nil {
+			return fmt.Errorf("Error during image export: %v", err)
+		}
+
+		log.Donef("Exported image %s to %s (%s)", b.helper.ImageName, *exportConfig.Dest, exportType)
+	} else {
+		log.Infof("Skip image push for %s", b.helper.ImageName)
+	}
+
+	b.digest = b.getDigest(fullImageNames[0])
+	return nil
+}
+
+// buildWithClient builds the image with the docker client
+func (b *Builder) buildWithClient(contextPath, dockerfilePath string, entrypoint *[]*string, fullImageNames []string, options *types.ImageBuildOptions, writer io.Writer) error {
+	ctx := interrupt.Context()
 	outStream := command.NewOutStream(writer)
 	contextDir, relDockerfile, err := build.GetContextFromLocalDir(contextPath, dockerfilePath)
 	if err != nil {
@@ -217,7 +306,7 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 	progressOutput := streamformatter.NewProgressOutput(outStream)
 	body := progress.NewProgressReader(buildCtx, progressOutput, 0, "", "Sending build context to Docker daemon")
 	response, err := b.client.ImageBuild(ctx, body, types.ImageBuildOptions{
-		Tags:        []string{fullImageName},
+		Tags:        fullImageNames,
 		Dockerfile:  relDockerfile,
 		BuildArgs:   options.BuildArgs,
 		Target:      options.Target,
@@ -234,19 +323,31 @@ func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*
 		return err
 	}
 
-	// Check if we skip push
-	if b.skipPush == false && (b.helper.ImageConf.Build == nil || b.helper.ImageConf.Build.Docker == nil || b.helper.ImageConf.Build.Docker.SkipPush == nil || *b.helper.ImageConf.Build.Docker.SkipPush == false) {
-		err = b.PushImage(writer)
-		if err != nil {
-			return fmt.Errorf("Error during image push: %v", err)
-		}
+	return nil
+}
 
-		log.Info("Image pushed to registry (" + displayRegistryURL + ")")
-	} else {
-		log.Infof("Skip image push for %s", b.helper.ImageName)
+// getDigest returns the registry digest of the given image or the local image id if the image was not pushed
+func (b *Builder) getDigest(fullImageName string) string {
+	inspect, _, err := b.client.ImageInspectWithRaw(interrupt.Context(), fullImageName)
+	if err != nil {
+		return ""
 	}
 
-	return nil
+	ref, err := reference.ParseNormalizedNamed(fullImageName)
+	if err == nil {
+		for _, repoDigest := range inspect.RepoDigests {
+			digestRef, err := reference.ParseNormalizedNamed(repoDigest)
+			if err != nil || digestRef.Name() != ref.Name() {
+				continue
+			}
+
+			if canonical, ok := digestRef.(reference.Canonical); ok {
+				return canonical.Digest().String()
+			}
+		}
+	}
+
+	return inspect.ID
 }
 
 // Authenticate authenticates the client with a remote registry
@@ -265,8 +366,8 @@ func (b *Builder) Authenticate() (*types.AuthConfig, error) {
 }
 
 // PushImage pushes an image to the specified registry
-func (b *Builder) PushImage(writer io.Writer) error {
-	ref, err := reference.ParseNormalizedNamed(b.helper.ImageName + ":" + b.helper.ImageTag)
+func (b *Builder) PushImage(fullImageName string, writer io.Writer) error {
+	ref, err := reference.ParseNormalizedNamed(fullImageName)
 	if err != nil {
 		return err
 	}
@@ -276,7 +377,7 @@ func (b *Builder) PushImage(writer io.Writer) error {
 		return err
 	}
 
-	out, err := b.client.ImagePush(context.Background(), reference.FamiliarString(ref), types.ImagePushOptions{
+	out, err := b.client.ImagePush(interrupt.Context(), reference.FamiliarString(ref), types.ImagePushOptions{
 		RegistryAuth: encodedAuth,
 	})
 	if err != nil {
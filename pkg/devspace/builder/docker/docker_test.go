@@ -11,6 +11,8 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/randutil"
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
 )
 
 //@Moretest
@@ -362,3 +364,32 @@ spec:
 
 	return nil
 }
+
+func TestGetBuildKitArgs(t *testing.T) {
+	options := &types.ImageBuildOptions{
+		BuildArgs: map[string]*string{
+			"B": ptr.String("2"),
+			"A": ptr.String("1"),
+		},
+		Target: "dev",
+	}
+	buildOptions := &latest.BuildOptions{
+		SSH:     &[]string{"default"},
+		Secrets: &[]string{"id=npmrc,src=.npmrc"},
+	}
+
+	args := getBuildKitArgs("/context", "/context/Dockerfile", []string{"myimage:latest", "myimage:abc"}, options, buildOptions)
+	assert.DeepEqual(t, args, []string{
+		"build", "--file", "/context/Dockerfile",
+		"--tag", "myimage:latest", "--tag", "myimage:abc",
+		"--build-arg", "A=1", "--build-arg", "B=2",
+		"--target", "dev",
+		"--ssh", "default",
+		"--secret", "id=npmrc,src=.npmrc",
+		"/context",
+	})
+
+	assert.Equal(t, needsBuildKit(buildOptions), true)
+	assert.Equal(t, needsBuildKit(&latest.BuildOptions{}), false)
+	assert.Equal(t, needsBuildKit(nil), false)
+}
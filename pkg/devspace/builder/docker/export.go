@@ -0,0 +1,313 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ExportTypeDocker exports the image as tarball in the format of docker save
+	ExportTypeDocker = "docker"
+	// ExportTypeOCI exports the image as directory in the OCI image layout format
+	ExportTypeOCI = "oci"
+)
+
+// Versions and media types of the OCI image layout
+const (
+	ociLayoutVersion     = "1.0.0"
+	ociManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	ociConfigMediaType   = "application/vnd.oci.image.config.v1+json"
+	ociLayerMediaType    = "application/vnd.oci.image.layer.v1.tar"
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+)
+
+// dockerArchiveManifest is the file within a docker save archive that lists the images
+const dockerArchiveManifest = "manifest.json"
+
+// ExportImage saves the given images from the docker daemon to dest. Depending on the export type dest is
+// a tarball (docker) or a directory in the OCI image layout (oci)
+func (b *Builder) ExportImage(fullImageNames []string, exportType, dest string) error {
+	reader, err := b.client.ImageSave(context.Background(), fullImageNames)
+	if err != nil {
+		return errors.Wrap(err, "save image")
+	}
+	defer reader.Close()
+
+	err = os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		return err
+	}
+
+	if exportType == ExportTypeOCI {
+		return writeOCILayout(reader, dest)
+	}
+
+	return writeFile(reader, dest)
+}
+
+// writeFile writes the reader to a temporary file first and renames it afterwards, so that dest
+// never contains a partially written image
+func writeFile(reader io.Reader, dest string) error {
+	file, err := ioutil.TempFile(filepath.Dir(dest), "."+filepath.Base(dest))
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, reader)
+	file.Close()
+	if err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return os.Rename(file.Name(), dest)
+}
+
+type dockerArchiveManifestEntry struct {
+	Config   string
+	RepoTags []string
+	Layers   []string
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	MediaType     string           `json:"mediaType,omitempty"`
+	Config        *ociDescriptor   `json:"config"`
+	Layers        []*ociDescriptor `json:"layers"`
+}
+
+type ociIndex struct {
+	SchemaVersion int              `json:"schemaVersion"`
+	Manifests     []*ociDescriptor `json:"manifests"`
+}
+
+// writeOCILayout converts the docker save archive into the OCI image layout and writes it to the dest directory
+func writeOCILayout(reader io.Reader, dest string) error {
+	tempDir, err := ioutil.TempDir("", "devspace-export")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	symlinks, err := extractArchive(reader, tempDir)
+	if err != nil {
+		return errors.Wrap(err, "extract docker archive")
+	}
+
+	manifestContent, err := ioutil.ReadFile(filepath.Join(tempDir, dockerArchiveManifest))
+	if err != nil {
+		return errors.Wrap(err, "read docker archive manifest")
+	}
+
+	manifestEntries := []*dockerArchiveManifestEntry{}
+	err = json.Unmarshal(manifestContent, &manifestEntries)
+	if err != nil {
+		return errors.Wrap(err, "parse docker archive manifest")
+	}
+
+	// Write into a temporary directory next to the destination first
+	layoutDir, err := ioutil.TempDir(filepath.Dir(dest), "."+filepath.Base(dest))
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(layoutDir)
+
+	err = os.MkdirAll(filepath.Join(layoutDir, "blobs", "sha256"), 0755)
+	if err != nil {
+		return err
+	}
+
+	index := &ociIndex{
+		SchemaVersion: 2,
+		Manifests:     []*ociDescriptor{},
+	}
+
+	resolve := func(name string) string {
+		name = path.Clean(name)
+		if target, ok := symlinks[name]; ok {
+			name = target
+		}
+
+		return filepath.Join(tempDir, filepath.FromSlash(name))
+	}
+
+	for _, entry := range manifestEntries {
+		manifest := &ociManifest{
+			SchemaVersion: 2,
+			MediaType:     ociManifestMediaType,
+			Layers:        []*ociDescriptor{},
+		}
+
+		manifest.Config, err = writeBlobFromFile(layoutDir, resolve(entry.Config), ociConfigMediaType)
+		if err != nil {
+			return errors.Wrapf(err, "write config %s", entry.Config)
+		}
+
+		for _, layer := range entry.Layers {
+			descriptor, err := writeBlobFromFile(layoutDir, resolve(layer), ociLayerMediaType)
+			if err != nil {
+				return errors.Wrapf(err, "write layer %s", layer)
+			}
+
+			manifest.Layers = append(manifest.Layers, descriptor)
+		}
+
+		manifestContent, err := json.Marshal(manifest)
+		if err != nil {
+			return err
+		}
+
+		manifestDescriptor, err := writeBlob(layoutDir, manifestContent, ociManifestMediaType)
+		if err != nil {
+			return errors.Wrap(err, "write manifest")
+		}
+
+		if len(entry.RepoTags) == 0 {
+			index.Manifests = append(index.Manifests, manifestDescriptor)
+		}
+
+		for _, repoTag := range entry.RepoTags {
+			descriptor := *manifestDescriptor
+			descriptor.Annotations = map[string]string{
+				ociRefNameAnnotation: repoTag,
+			}
+
+			index.Manifests = append(index.Manifests, &descriptor)
+		}
+	}
+
+	indexContent, err := json.Marshal(index)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(layoutDir, "index.json"), indexContent, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = ioutil.WriteFile(filepath.Join(layoutDir, "oci-layout"), []byte(`{"imageLayoutVersion":"`+ociLayoutVersion+`"}`), 0644)
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(layoutDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	err = os.RemoveAll(dest)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(layoutDir, dest)
+}
+
+// extractArchive extracts the regular files of the tar archive into dir and returns the
+// symlinks within the archive (newer docker versions link layers to blobs)
+func extractArchive(reader io.Reader, dir string) (map[string]string, error) {
+	symlinks := map[string]string{}
+	tarReader := tar.NewReader(reader)
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		name := path.Clean(header.Name)
+		if name == ".." || strings.HasPrefix(name, "../") || path.IsAbs(name) {
+			return nil, fmt.Errorf("invalid path %s in archive", header.Name)
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(filepath.Join(dir, filepath.FromSlash(name)), 0755)
+		case tar.TypeReg, tar.TypeRegA:
+			err = os.MkdirAll(filepath.Join(dir, filepath.Dir(filepath.FromSlash(name))), 0755)
+			if err == nil {
+				err = writeFile(tarReader, filepath.Join(dir, filepath.FromSlash(name)))
+			}
+		case tar.TypeSymlink:
+			symlinks[name] = path.Join(path.Dir(name), header.Linkname)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return symlinks, nil
+}
+
+// writeBlobFromFile copies the file into the blobs directory of the OCI layout
+func writeBlobFromFile(layoutDir, filename, mediaType string) (*ociDescriptor, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	tempFile, err := ioutil.TempFile(filepath.Join(layoutDir, "blobs", "sha256"), ".blob")
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tempFile, hash), file)
+	tempFile.Close()
+	if err != nil {
+		os.Remove(tempFile.Name())
+		return nil, err
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	err = os.Rename(tempFile.Name(), filepath.Join(layoutDir, "blobs", "sha256", digest))
+	if err != nil {
+		return nil, err
+	}
+
+	return &ociDescriptor{
+		MediaType: mediaType,
+		Digest:    "sha256:" + digest,
+		Size:      size,
+	}, nil
+}
+
+// writeBlob writes the content into the blobs directory of the OCI layout
+func writeBlob(layoutDir string, content []byte, mediaType string) (*ociDescriptor, error) {
+	hash := sha256.Sum256(content)
+	digest := hex.EncodeToString(hash[:])
+
+	err := ioutil.WriteFile(filepath.Join(layoutDir, "blobs", "sha256", digest), content, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ociDescriptor{
+		MediaType: mediaType,
+		Digest:    "sha256:" + digest,
+		Size:      int64(len(content)),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWriteOCILayout(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testExport")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	layer := []byte("layer content")
+
+	// Newer docker versions link the layer tars to the blobs
+	archive := &bytes.Buffer{}
+	writer := tar.NewWriter(archive)
+	writeTarFile(t, writer, "blobs/sha256/abc", layer)
+	writeTarFile(t, writer, "config.json", config)
+	err = writer.WriteHeader(&tar.Header{Name: "layer1/layer.tar", Typeflag: tar.TypeSymlink, Linkname: "../blobs/sha256/abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeTarFile(t, writer, dockerArchiveManifest, []byte(`[{"Config":"config.json","RepoTags":["user/image:tag1","user/image:tag2"],"Layers":["layer1/layer.tar"]}]`))
+	writer.Close()
+
+	dest := filepath.Join(dir, "export", "image")
+	err = os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = writeOCILayout(archive, dest)
+	if err != nil {
+		t.Fatalf("Error writing oci layout: %v", err)
+	}
+
+	layout, err := ioutil.ReadFile(filepath.Join(dest, "oci-layout"))
+	if err != nil || string(layout) != `{"imageLayoutVersion":"1.0.0"}` {
+		t.Fatalf("Unexpected oci-layout file %s: %v", string(layout), err)
+	}
+
+	index := &ociIndex{}
+	readJSON(t, filepath.Join(dest, "index.json"), index)
+	if len(index.Manifests) != 2 {
+		t.Fatalf("Expected 2 manifests in index, got %d", len(index.Manifests))
+	}
+	if index.Manifests[0].Annotations[ociRefNameAnnotation] != "user/image:tag1" || index.Manifests[1].Annotations[ociRefNameAnnotation] != "user/image:tag2" {
+		t.Fatalf("Unexpected ref names in index: %#v", index.Manifests)
+	}
+	if index.Manifests[0].Digest != index.Manifests[1].Digest {
+		t.Fatalf("Expected both tags to reference the same manifest")
+	}
+
+	manifest := &ociManifest{}
+	readJSON(t, blobPath(dest, index.Manifests[0].Digest), manifest)
+	if manifest.Config.Digest != digest(config) || manifest.Config.MediaType != ociConfigMediaType {
+		t.Fatalf("Unexpected config descriptor: %#v", manifest.Config)
+	}
+	if len(manifest.Layers) != 1 || manifest.Layers[0].Digest != digest(layer) || manifest.Layers[0].Size != int64(len(layer)) {
+		t.Fatalf("Unexpected layer descriptors: %#v", manifest.Layers)
+	}
+
+	content, err := ioutil.ReadFile(blobPath(dest, manifest.Layers[0].Digest))
+	if err != nil || string(content) != string(layer) {
+		t.Fatalf("Unexpected layer blob %s: %v", string(content), err)
+	}
+
+	// No temporary files should be left next to the destination
+	files, err := ioutil.ReadDir(filepath.Dir(dest))
+	if err != nil || len(files) != 1 {
+		t.Fatalf("Expected only the export in the destination directory, got %d files: %v", len(files), err)
+	}
+}
+
+func writeTarFile(t *testing.T, writer *tar.Writer, name string, content []byte) {
+	err := writer.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(content))})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = writer.Write(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readJSON(t *testing.T, filename string, obj interface{}) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = json.Unmarshal(content, obj)
+	if err != nil {
+		t.Fatalf("Error parsing %s: %v", filename, err)
+	}
+}
+
+func blobPath(dir, digest string) string {
+	return filepath.Join(dir, "blobs", "sha256", strings.TrimPrefix(digest, "sha256:"))
+}
+
+func digest(content []byte) string {
+	hash := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,54 @@
+package builder
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+)
+
+// FakeEngineName is the engine name the fake builder reports
+const FakeEngineName = "fake"
+
+// FakeBuilder implements Interface without docker or a cluster and counts the builds
+type FakeBuilder struct {
+	// Rebuild is returned by ShouldRebuild, BuildError by Build
+	Rebuild    bool
+	BuildError error
+
+	// ImageDigest is returned by Digest after a successful build
+	ImageDigest string
+
+	// Builds is the number of times Build was called
+	Builds int
+}
+
+// NewFakeBuilder creates a new fake builder that reports whether the image should be rebuilt with rebuild
+func NewFakeBuilder(rebuild bool) *FakeBuilder {
+	return &FakeBuilder{
+		Rebuild: rebuild,
+	}
+}
+
+// ShouldRebuild implements interface
+func (f *FakeBuilder) ShouldRebuild(cache *generated.CacheConfig) (bool, error) {
+	return f.Rebuild, nil
+}
+
+// Build implements interface
+func (f *FakeBuilder) Build(log log.Logger) error {
+	f.Builds++
+	return f.BuildError
+}
+
+// EngineName implements interface
+func (f *FakeBuilder) EngineName() string {
+	return FakeEngineName
+}
+
+// Digest implements interface
+func (f *FakeBuilder) Digest() string {
+	if f.Builds == 0 || f.BuildError != nil {
+		return ""
+	}
+
+	return f.ImageDigest
+}
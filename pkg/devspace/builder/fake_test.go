@@ -0,0 +1,31 @@
+package builder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"gotest.tools/assert"
+)
+
+func TestFakeBuilder(t *testing.T) {
+	fakeBuilder := NewFakeBuilder(true)
+	fakeBuilder.ImageDigest = "sha256:123"
+
+	var builder Interface = fakeBuilder
+	rebuild, err := builder.ShouldRebuild(nil)
+	assert.NilError(t, err, "Error checking rebuild")
+	assert.Equal(t, rebuild, true)
+	assert.Equal(t, builder.Digest(), "", "Digest reported before build")
+
+	err = builder.Build(log.Discard)
+	assert.NilError(t, err, "Error building")
+	assert.Equal(t, builder.Digest(), "sha256:123")
+	assert.Equal(t, fakeBuilder.Builds, 1)
+
+	fakeBuilder.BuildError = errors.New("build error")
+	err = builder.Build(log.Discard)
+	assert.Error(t, err, "build error")
+	assert.Equal(t, builder.Digest(), "", "Digest reported after failed build")
+}
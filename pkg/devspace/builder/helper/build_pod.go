@@ -0,0 +1,492 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/envutil"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/docker/cli/cli/command/image/build"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// BuildPodContextPath is the path of the build context within a build pod
+const BuildPodContextPath = "/context"
+
+// BuildPodContextContainerName is the name of the container of a build pod that receives the build context
+const BuildPodContextContainerName = "context"
+
+// BuildPodDoneFile is the file the init container of a build pod waits for
+const BuildPodDoneFile = "/tmp/done"
+
+// DefaultBuildPodWaitTimeout is the maximum time to wait for the init and build container of a build pod to get ready
+const DefaultBuildPodWaitTimeout = 2 * time.Minute
+
+// BuildPodWaitTimeoutEnv is the environment variable that overrides the default wait timeout of build pods in seconds
+const BuildPodWaitTimeoutEnv = "DEVSPACE_BUILD_POD_WAIT_TIMEOUT"
+
+// DevspaceQuota is the quota name of the space quota in the devspace cloud
+const devspaceQuota = "devspace-quota"
+
+// DevspaceLimitRange is the limit range name of the space limit range in the devspace cloud
+const devspaceLimitRange = "devspace-limit-range"
+
+// AvailableResources holds the resources a build pod can use
+type AvailableResources struct {
+	CPU              resource.Quantity
+	Memory           resource.Quantity
+	EphemeralStorage resource.Quantity
+}
+
+// DefaultBuildPodResources are the default resource limits to use for a build pod
+var DefaultBuildPodResources = &AvailableResources{
+	CPU:              resource.MustParse("4"),
+	Memory:           resource.MustParse("8Gi"),
+	EphemeralStorage: resource.MustParse("10Gi"),
+}
+
+// NewBuildPodInitContainer returns the init container that receives the build context and waits until the upload is done
+func NewBuildPodInitContainer() k8sv1.Container {
+	return k8sv1.Container{
+		Name:            BuildPodContextContainerName,
+		Image:           "alpine",
+		Command:         []string{"sh"},
+		Args:            []string{"-c", "while [ ! -f " + BuildPodDoneFile + " ]; do sleep 2; done"},
+		ImagePullPolicy: k8sv1.PullIfNotPresent,
+		VolumeMounts: []k8sv1.VolumeMount{
+			{
+				Name:      "context",
+				MountPath: BuildPodContextPath,
+			},
+		},
+	}
+}
+
+// NewBuildPodContextContainer returns the container of a reusable build pod that keeps running and receives the
+// build context for every build
+func NewBuildPodContextContainer() k8sv1.Container {
+	container := NewBuildPodInitContainer()
+	container.Args = []string{"-c", "while true; do sleep 5; done"}
+
+	return container
+}
+
+// NewBuildPodVolumes returns the context volume and the volume that contains the registry credentials of a build pod
+func NewBuildPodVolumes(pullSecretName string) []k8sv1.Volume {
+	return []k8sv1.Volume{
+		{
+			Name: pullSecretName,
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: pullSecretName,
+					Items: []k8sv1.KeyToPath{
+						{
+							Key:  k8sv1.DockerConfigJsonKey,
+							Path: "config.json",
+						},
+					},
+				},
+			},
+		},
+		{
+			Name: "context",
+			VolumeSource: k8sv1.VolumeSource{
+				EmptyDir: &k8sv1.EmptyDirVolumeSource{},
+			},
+		},
+	}
+}
+
+// CreateBuildPullSecret creates the secret that is used by a build pod to push to the registry of the image
+// with the credentials of the local docker daemon
+func CreateBuildPullSecret(kubectlClient kubernetes.Interface, dockerClient client.CommonAPIClient, namespace, fullImageName string, log log.Logger) error {
+	registryURL, err := registry.GetRegistryFromImageName(fullImageName)
+	if err != nil {
+		return err
+	}
+
+	authConfig, err := docker.GetAuthConfig(dockerClient, registryURL, true)
+	if err != nil {
+		return err
+	}
+
+	password := authConfig.Password
+	if password == "" {
+		password = authConfig.IdentityToken
+	}
+
+	return registry.CreatePullSecret(kubectlClient, namespace, registryURL, authConfig.Username, password, authConfig.Email, log)
+}
+
+// RunBuildPod creates the build pod, uploads the build context and the dockerfile to its init container, prints
+// the logs of the build container to out and waits until the build is finished
+func (b *BuildHelper) RunBuildPod(kubectlClient kubernetes.Interface, buildPod *k8sv1.Pod, contextPath, dockerfilePath string, out io.Writer, log log.Logger) error {
+	namespace := buildPod.Namespace
+
+	// Delete the build pod when we are done or get interrupted during build
+	deleteBuildPod := func() {
+		gracePeriod := int64(3)
+		deleteErr := kubectlClient.CoreV1().Pods(namespace).Delete(buildPod.Name, &metav1.DeleteOptions{
+			GracePeriodSeconds: &gracePeriod,
+		})
+		audit.Record(audit.ActionDelete, "Pod", namespace, buildPod.Name, deleteErr)
+
+		if deleteErr != nil {
+			log.Errorf("Failed to delete build pod: %s", deleteErr.Error())
+		}
+	}
+
+	unregister := interrupt.Register(deleteBuildPod)
+	defer func() {
+		unregister()
+		deleteBuildPod()
+	}()
+
+	err := func() error {
+		defer log.StopWait()
+
+		buildPodCreated, err := kubectlClient.CoreV1().Pods(namespace).Create(buildPod)
+		if err != nil {
+			audit.Record(audit.ActionCreate, "Pod", namespace, buildPod.GenerateName, err)
+			return fmt.Errorf("Unable to create build pod: %s", err.Error())
+		}
+
+		audit.Record(audit.ActionCreate, "Pod", namespace, buildPodCreated.Name, nil)
+
+		now := time.Now()
+		waitTimeout := b.getBuildPodWaitTimeout()
+		log.StartWait("Waiting for build init container to start")
+
+		for {
+			pod, err := kubectlClient.CoreV1().Pods(namespace).Get(buildPodCreated.Name, metav1.GetOptions{})
+			if err == nil {
+				buildPod = pod
+				if len(buildPod.Status.InitContainerStatuses) > 0 && buildPod.Status.InitContainerStatuses[0].State.Running != nil {
+					break
+				}
+
+				log.StartWait("Waiting for build init container to start: " + kubectl.GetPodPendingReason(buildPod))
+			}
+
+			err = interrupt.Sleep(5 * time.Second)
+			if err != nil {
+				return err
+			}
+			if time.Since(now) >= waitTimeout {
+				return fmt.Errorf("Timeout waiting for init container after %v, set %s or build.*.waitTimeout to wait longer", waitTimeout, BuildPodWaitTimeoutEnv)
+			}
+		}
+
+		// Get rest config
+		restConfig, err := kubectl.GetRestConfig(b.Config)
+		if err != nil {
+			return errors.Wrap(err, "get rest config")
+		}
+
+		// Get ignore rules from docker ignore
+		ignoreRules, err := build.ReadDockerignore(contextPath)
+		if err != nil {
+			return err
+		}
+
+		ignoreRules = append(ignoreRules, ".devspace/")
+		log.StartWait("Uploading files to build container")
+
+		// Copy complete context
+		err = kubectl.Copy(restConfig, buildPod, buildPod.Spec.InitContainers[0].Name, BuildPodContextPath, contextPath, ignoreRules)
+		if err != nil {
+			return fmt.Errorf("Error uploading files to container: %v", err)
+		}
+
+		// Copy dockerfile
+		err = kubectl.Copy(restConfig, buildPod, buildPod.Spec.InitContainers[0].Name, BuildPodContextPath, dockerfilePath, ignoreRules)
+		if err != nil {
+			return fmt.Errorf("Error uploading files to container: %v", err)
+		}
+
+		// Tell init container we are done
+		_, _, err = kubectl.ExecBuffered(context.Background(), restConfig, buildPod, buildPod.Spec.InitContainers[0].Name, []string{"touch", BuildPodDoneFile}, nil)
+		if err != nil {
+			return fmt.Errorf("Error executing command in init container: %v", err)
+		}
+
+		log.Done("Uploaded files to container")
+		log.StartWait("Waiting for " + b.EngineName + " container to start")
+
+		now = time.Now()
+		for true {
+			pod, err := kubectlClient.CoreV1().Pods(namespace).Get(buildPodCreated.Name, metav1.GetOptions{})
+			if err == nil {
+				buildPod = pod
+				if len(buildPod.Status.ContainerStatuses) > 0 && buildPod.Status.ContainerStatuses[0].Ready {
+					break
+				}
+
+				log.StartWait("Waiting for " + b.EngineName + " container to start: " + kubectl.GetPodPendingReason(buildPod))
+			}
+
+			err = interrupt.Sleep(2 * time.Second)
+			if err != nil {
+				return err
+			}
+			if time.Since(now) >= waitTimeout {
+				return fmt.Errorf("Timeout waiting for %s build pod after %v, set %s or build.*.waitTimeout to wait longer", b.EngineName, waitTimeout, BuildPodWaitTimeoutEnv)
+			}
+		}
+
+		log.StopWait()
+		log.Done("Build pod has started")
+
+		// Stream the logs
+		err = services.StartLogsWithWriter(b.Config, kubectlClient, targetselector.CmdParameter{PodName: &buildPod.Name, ContainerName: &buildPod.Spec.Containers[0].Name, Namespace: &buildPod.Namespace}, true, 100, log, out, out)
+		if err != nil {
+			return fmt.Errorf("Error during printling build logs: %v", err)
+		}
+
+		log.StartWait("Checking build status")
+		for true {
+			err = interrupt.Sleep(time.Second)
+			if err != nil {
+				return err
+			}
+
+			// Check if build was successfull
+			pod, err := kubectlClient.CoreV1().Pods(namespace).Get(buildPodCreated.Name, metav1.GetOptions{})
+			if err != nil {
+				return fmt.Errorf("Error checking if build was successful: %v", err)
+			}
+
+			// Check if terminated
+			if pod.Status.ContainerStatuses[0].State.Terminated != nil {
+				if pod.Status.ContainerStatuses[0].State.Terminated.ExitCode != 0 {
+					return fmt.Errorf("Error building image (Exit Code %d)", pod.Status.ContainerStatuses[0].State.Terminated.ExitCode)
+				}
+
+				break
+			}
+		}
+		log.StopWait()
+
+		log.Done("Done building image")
+		return nil
+	}()
+
+	if err != nil {
+		// Delete all build pods on error
+		pods, getErr := kubectlClient.CoreV1().Pods(namespace).List(metav1.ListOptions{
+			LabelSelector: "devspace-build=true",
+		})
+		if getErr != nil {
+			return err
+		}
+		for _, pod := range pods.Items {
+			deleteErr := kubectlClient.CoreV1().Pods(namespace).Delete(pod.Name, &metav1.DeleteOptions{})
+			audit.Record(audit.ActionDelete, "Pod", namespace, pod.Name, deleteErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// GetAvailableResources determines the resources a build pod can use (This is only necessary in the devspace cloud)
+func GetAvailableResources(kubectlClient kubernetes.Interface, namespace string) (*AvailableResources, error) {
+	quota, err := kubectlClient.CoreV1().ResourceQuotas(namespace).Get(devspaceQuota, metav1.GetOptions{})
+	if err != nil {
+		return DefaultBuildPodResources, nil
+	}
+
+	availableResources := &AvailableResources{}
+
+	// CPU
+	availableResources.CPU, err = getAvailableResourceQuantity(DefaultBuildPodResources.CPU, k8sv1.ResourceLimitsCPU, quota)
+	if err != nil {
+		return nil, errors.Wrap(err, "get available resource quantity")
+	}
+
+	// Memory
+	availableResources.Memory, err = getAvailableResourceQuantity(DefaultBuildPodResources.Memory, k8sv1.ResourceLimitsMemory, quota)
+	if err != nil {
+		return nil, errors.Wrap(err, "get available resource quantity")
+	}
+
+	// Ephemeral Storage
+	availableResources.EphemeralStorage, err = getAvailableResourceQuantity(DefaultBuildPodResources.EphemeralStorage, k8sv1.ResourceLimitsEphemeralStorage, quota)
+	if err != nil {
+		return nil, errors.Wrap(err, "get available resource quantity")
+	}
+
+	// Get limitrange
+	limitrange, err := kubectlClient.CoreV1().LimitRanges(namespace).Get(devspaceLimitRange, metav1.GetOptions{})
+	if err != nil {
+		return availableResources, nil
+	}
+
+	// Check if container limit is smaller than the available resources
+	for _, limit := range limitrange.Spec.Limits {
+		if limit.Type == k8sv1.LimitTypeContainer {
+			if maxCPU, ok := limit.Max[k8sv1.ResourceCPU]; ok {
+				if availableResources.CPU.Cmp(maxCPU) == 1 {
+					availableResources.CPU = maxCPU
+				}
+			}
+			if maxMemory, ok := limit.Max[k8sv1.ResourceMemory]; ok {
+				if availableResources.Memory.Cmp(maxMemory) == 1 {
+					availableResources.Memory = maxMemory
+				}
+			}
+			if maxEphemeralStorage, ok := limit.Max[k8sv1.ResourceEphemeralStorage]; ok {
+				if availableResources.EphemeralStorage.Cmp(maxEphemeralStorage) == 1 {
+					availableResources.EphemeralStorage = maxEphemeralStorage
+				}
+			}
+		}
+	}
+
+	return availableResources, nil
+}
+
+func getAvailableResourceQuantity(defaultQuantity resource.Quantity, resourceName k8sv1.ResourceName, quota *k8sv1.ResourceQuota) (resource.Quantity, error) {
+	retLimit := defaultQuantity
+	if quotaLimit, ok := quota.Status.Hard[resourceName]; ok {
+		retLimit = quotaLimit
+		if quotaUsed, ok := quota.Status.Used[resourceName]; ok {
+			retLimit.Sub(quotaUsed)
+
+			if retLimit.Cmp(defaultQuantity) == 1 {
+				retLimit = defaultQuantity
+			}
+		}
+	}
+
+	// Check if limit == 0 or below zero
+	if retLimit.Sign() != 1 {
+		return resource.MustParse("0"), fmt.Errorf("Available %s resource is zero or below zero: %s", resourceName, retLimit.String())
+	}
+
+	return retLimit, nil
+}
+
+// getBuildPodWaitTimeout returns the waitTimeout of the kaniko or pod build config, the timeout of the environment
+// variable or the default timeout
+func (b *BuildHelper) getBuildPodWaitTimeout() time.Duration {
+	var waitTimeout *int
+	if b.ImageConf.Build != nil && b.ImageConf.Build.Kaniko != nil {
+		waitTimeout = b.ImageConf.Build.Kaniko.WaitTimeout
+	} else if b.ImageConf.Build != nil && b.ImageConf.Build.Pod != nil {
+		waitTimeout = b.ImageConf.Build.Pod.WaitTimeout
+	}
+
+	if waitTimeout != nil && *waitTimeout > 0 {
+		return time.Duration(*waitTimeout) * time.Second
+	}
+
+	return envutil.GetTimeout(BuildPodWaitTimeoutEnv, DefaultBuildPodWaitTimeout)
+}
+
+// GetBuildPodResources converts the configured resources of a build pod. Returns nil if no resources are configured
+func GetBuildPodResources(resources *latest.PodResources) (*k8sv1.ResourceRequirements, error) {
+	if resources == nil {
+		return nil, nil
+	}
+
+	requests, err := toResourceList(resources.Requests)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := toResourceList(resources.Limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return &k8sv1.ResourceRequirements{
+		Requests: requests,
+		Limits:   limits,
+	}, nil
+}
+
+func toResourceList(resources *map[string]*string) (k8sv1.ResourceList, error) {
+	if resources == nil {
+		return nil, nil
+	}
+
+	resourceList := k8sv1.ResourceList{}
+	for name, value := range *resources {
+		if value == nil {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(*value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s quantity", name)
+		}
+
+		resourceList[k8sv1.ResourceName(name)] = quantity
+	}
+
+	return resourceList, nil
+}
+
+// GetBuildPodStringMap converts a configured map like the node selector or the annotations of a build pod and
+// skips empty values
+func GetBuildPodStringMap(values *map[string]*string) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	stringMap := map[string]string{}
+	for key, value := range *values {
+		if value != nil {
+			stringMap[key] = *value
+		}
+	}
+
+	return stringMap
+}
+
+// GetBuildPodTolerations converts the configured tolerations of a build pod
+func GetBuildPodTolerations(tolerations *[]*latest.PodToleration) []k8sv1.Toleration {
+	if tolerations == nil {
+		return nil
+	}
+
+	podTolerations := make([]k8sv1.Toleration, 0, len(*tolerations))
+	for _, toleration := range *tolerations {
+		podToleration := k8sv1.Toleration{
+			TolerationSeconds: toleration.TolerationSeconds,
+		}
+		if toleration.Key != nil {
+			podToleration.Key = *toleration.Key
+		}
+		if toleration.Operator != nil {
+			podToleration.Operator = k8sv1.TolerationOperator(*toleration.Operator)
+		}
+		if toleration.Value != nil {
+			podToleration.Value = *toleration.Value
+		}
+		if toleration.Effect != nil {
+			podToleration.Effect = k8sv1.TaintEffect(*toleration.Effect)
+		}
+
+		podTolerations = append(podTolerations, podToleration)
+	}
+
+	return podTolerations
+}
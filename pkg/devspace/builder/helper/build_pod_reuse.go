@@ -0,0 +1,458 @@
+package helper
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	gosync "sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
+	"github.com/devspace-cloud/devspace/pkg/devspace/sync"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/docker/cli/cli/command/image/build"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// reusableBuildPod is a build pod that keeps running during a session, so subsequent builds of the same image
+// only have to upload the changed files of the build context
+type reusableBuildPod struct {
+	kubectlClient kubernetes.Interface
+	pod           *k8sv1.Pod
+	unregister    func()
+
+	// contextSync mirrors the build context into the context container. If the sync can't be started, the changed
+	// files are uploaded as tar before each build instead. syncMutex guards contextSync, because the pod can be
+	// deleted while a build uploads to it
+	contextSync     *sync.Sync
+	syncDone        chan bool
+	syncUnavailable bool
+	syncMutex       gosync.Mutex
+
+	// uploadedFiles holds the files of the build context that are already in the build pod
+	uploadedFiles map[string]*sync.FileInformation
+}
+
+// reusableBuildPodsMutex only guards the maps, builds of different images run in parallel and builds of the same
+// image are serialized by the lock of the image in reusableBuildPodLocks
+var (
+	reusableBuildPods      = map[string]*reusableBuildPod{}
+	reusableBuildPodLocks  = map[string]*gosync.Mutex{}
+	reusableBuildPodsMutex gosync.Mutex
+)
+
+// RunReusableBuildPod executes the build command in a running build pod of the image. If there is no build pod yet,
+// buildPod is created and kept running until DeleteReusableBuildPods is called. Only files of the build context that
+// changed since the last build are uploaded to the pod
+func (b *BuildHelper) RunReusableBuildPod(kubectlClient kubernetes.Interface, buildPod *k8sv1.Pod, contextPath, dockerfilePath string, command []string, out io.Writer, log log.Logger) error {
+	key := buildPod.Namespace + "/" + b.ImageConfigName
+	buildLock := getReusableBuildPodLock(key)
+	buildLock.Lock()
+	defer buildLock.Unlock()
+
+	reusablePod, err := b.getReusableBuildPod(kubectlClient, key, buildPod, log)
+	if err != nil {
+		return err
+	}
+
+	restConfig, err := kubectl.GetRestConfig(b.Config)
+	if err != nil {
+		return errors.Wrap(err, "get rest config")
+	}
+
+	err = reusablePod.upload(b.Config, restConfig, contextPath, dockerfilePath, b.getBuildPodWaitTimeout(), log)
+	if err != nil {
+		// We don't know what is in the pod now, so we start with a new one next time
+		removeReusableBuildPod(key, reusablePod)
+		reusablePod.delete(log)
+
+		return err
+	}
+
+	log.Done("Uploaded changed files to build pod")
+	log.Infof("Building image in build pod %s", reusablePod.pod.Name)
+
+	err = kubectl.ExecStream(restConfig, reusablePod.pod, reusablePod.pod.Spec.Containers[0].Name, command, false, nil, out, out)
+	if err != nil {
+		return fmt.Errorf("Error building image: %v", err)
+	}
+
+	log.Done("Done building image")
+	return nil
+}
+
+// getReusableBuildPodLock returns the lock that serializes the builds of the image with the key
+func getReusableBuildPodLock(key string) *gosync.Mutex {
+	reusableBuildPodsMutex.Lock()
+	defer reusableBuildPodsMutex.Unlock()
+
+	buildLock, ok := reusableBuildPodLocks[key]
+	if ok == false {
+		buildLock = &gosync.Mutex{}
+		reusableBuildPodLocks[key] = buildLock
+	}
+
+	return buildLock
+}
+
+// removeReusableBuildPod removes the build pod from the running build pods, unless it was already replaced or
+// removed by DeleteReusableBuildPods
+func removeReusableBuildPod(key string, reusablePod *reusableBuildPod) {
+	reusableBuildPodsMutex.Lock()
+	defer reusableBuildPodsMutex.Unlock()
+
+	if reusableBuildPods[key] == reusablePod {
+		delete(reusableBuildPods, key)
+	}
+}
+
+// getReusableBuildPod returns the running build pod for the key or creates a new one. The caller has to hold the
+// lock of the key
+func (b *BuildHelper) getReusableBuildPod(kubectlClient kubernetes.Interface, key string, buildPod *k8sv1.Pod, log log.Logger) (*reusableBuildPod, error) {
+	reusableBuildPodsMutex.Lock()
+	reusablePod, ok := reusableBuildPods[key]
+	reusableBuildPodsMutex.Unlock()
+
+	if ok {
+		pod, err := kubectlClient.CoreV1().Pods(reusablePod.pod.Namespace).Get(reusablePod.pod.Name, metav1.GetOptions{})
+		if err == nil && pod.DeletionTimestamp == nil && isBuildPodReady(pod) {
+			return reusablePod, nil
+		}
+
+		log.Infof("Build pod %s is not running anymore, creating a new one", reusablePod.pod.Name)
+		removeReusableBuildPod(key, reusablePod)
+		reusablePod.delete(log)
+	}
+
+	namespace := buildPod.Namespace
+	buildPodCreated, err := kubectlClient.CoreV1().Pods(namespace).Create(buildPod)
+	if err != nil {
+		audit.Record(audit.ActionCreate, "Pod", namespace, buildPod.GenerateName, err)
+		return nil, fmt.Errorf("Unable to create build pod: %s", err.Error())
+	}
+
+	audit.Record(audit.ActionCreate, "Pod", namespace, buildPodCreated.Name, nil)
+
+	reusablePod = &reusableBuildPod{
+		kubectlClient: kubectlClient,
+		pod:           buildPodCreated,
+		uploadedFiles: map[string]*sync.FileInformation{},
+	}
+	reusablePod.unregister = interrupt.Register(func() {
+		reusablePod.delete(log)
+	})
+
+	err = b.waitForReusableBuildPod(reusablePod, log)
+	if err != nil {
+		reusablePod.delete(log)
+		return nil, err
+	}
+
+	reusableBuildPodsMutex.Lock()
+	reusableBuildPods[key] = reusablePod
+	reusableBuildPodsMutex.Unlock()
+
+	return reusablePod, nil
+}
+
+// waitForReusableBuildPod waits until the build container of the pod is ready
+func (b *BuildHelper) waitForReusableBuildPod(reusablePod *reusableBuildPod, log log.Logger) error {
+	defer log.StopWait()
+
+	now := time.Now()
+	waitTimeout := b.getBuildPodWaitTimeout()
+	log.StartWait("Waiting for " + b.EngineName + " build pod to start")
+
+	for {
+		pod, err := reusablePod.kubectlClient.CoreV1().Pods(reusablePod.pod.Namespace).Get(reusablePod.pod.Name, metav1.GetOptions{})
+		if err == nil {
+			reusablePod.pod = pod
+			if isBuildPodReady(pod) {
+				break
+			}
+
+			log.StartWait("Waiting for " + b.EngineName + " build pod to start: " + kubectl.GetPodPendingReason(pod))
+		}
+
+		err = interrupt.Sleep(2 * time.Second)
+		if err != nil {
+			return err
+		}
+		if time.Since(now) >= waitTimeout {
+			return fmt.Errorf("Timeout waiting for %s build pod after %v, set %s or build.*.waitTimeout to wait longer", b.EngineName, waitTimeout, BuildPodWaitTimeoutEnv)
+		}
+	}
+
+	log.StopWait()
+	log.Done("Build pod has started")
+	return nil
+}
+
+// isBuildPodReady checks if all containers of the build pod are ready
+func isBuildPodReady(pod *k8sv1.Pod) bool {
+	if len(pod.Status.ContainerStatuses) < len(pod.Spec.Containers) {
+		return false
+	}
+
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Ready == false {
+			return false
+		}
+	}
+
+	return true
+}
+
+// upload mirrors the build context into the context container and uploads the dockerfile
+func (r *reusableBuildPod) upload(config *latest.Config, restConfig *rest.Config, contextPath, dockerfilePath string, timeout time.Duration, log log.Logger) error {
+	log.StartWait("Uploading changed files to build pod")
+	defer log.StopWait()
+
+	// Get ignore rules from docker ignore
+	ignoreRules, err := build.ReadDockerignore(contextPath)
+	if err != nil {
+		return err
+	}
+
+	ignoreRules = append(ignoreRules, ".devspace/")
+
+	// The context is synced by the sync engine, so only the changed files are transferred
+	if r.contextSync == nil && r.syncUnavailable == false {
+		contextSync, syncDone, err := services.StartBuildContextSync(config, r.pod, BuildPodContextContainerName, contextPath, BuildPodContextPath, ignoreRules, func(err error) {})
+		if err != nil {
+			log.Warnf("Unable to sync the build context, uploading the changed files instead: %v", err)
+			r.syncUnavailable = true
+		} else {
+			r.syncMutex.Lock()
+			r.contextSync = contextSync
+			r.syncDone = syncDone
+			r.syncMutex.Unlock()
+		}
+	}
+
+	if r.contextSync != nil {
+		err = r.waitForContextSync(timeout)
+	} else {
+		err = r.uploadChangedFiles(restConfig, contextPath, ignoreRules)
+	}
+	if err != nil {
+		return err
+	}
+
+	// The dockerfile is always uploaded, because it may be located outside of the context
+	err = kubectl.Copy(restConfig, r.pod, BuildPodContextContainerName, BuildPodContextPath, dockerfilePath, nil)
+	if err != nil {
+		return fmt.Errorf("Error uploading files to container: %v", err)
+	}
+
+	return nil
+}
+
+// waitForContextSync waits until the context sync has uploaded all local changes
+func (r *reusableBuildPod) waitForContextSync(timeout time.Duration) error {
+	// Give the file watcher of the sync time to report the latest changes
+	err := interrupt.Sleep(r.contextSync.Options.CoalesceInterval)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for {
+		select {
+		case <-r.syncDone:
+			return errors.New("Sync of the build context stopped. For more information check .devspace/logs/sync.log")
+		default:
+		}
+
+		if r.contextSync.UpstreamIdle() {
+			return nil
+		}
+
+		err = interrupt.Sleep(100 * time.Millisecond)
+		if err != nil {
+			return err
+		}
+		if time.Since(now) >= timeout {
+			return fmt.Errorf("Timeout waiting for the build context to sync after %v", timeout)
+		}
+	}
+}
+
+// uploadChangedFiles uploads the changed files of the build context as tar and removes deleted files from the pod
+func (r *reusableBuildPod) uploadChangedFiles(restConfig *rest.Config, contextPath string, ignoreRules []string) error {
+	container := BuildPodContextContainerName
+	contextFiles, err := getContextFiles(contextPath, ignoreRules)
+	if err != nil {
+		return errors.Wrap(err, "get context files")
+	}
+
+	changed, deleted := diffContextFiles(r.uploadedFiles, contextFiles)
+	if len(deleted) > 0 {
+		command := []string{"rm", "-rf", "--"}
+		for _, name := range deleted {
+			command = append(command, BuildPodContextPath+"/"+name)
+		}
+
+		_, stderr, err := kubectl.ExecBuffered(context.Background(), restConfig, r.pod, container, command, nil)
+		if err != nil {
+			return fmt.Errorf("Error removing deleted files from build pod: %s %v", string(stderr), err)
+		}
+	}
+
+	if len(changed) > 0 {
+		reader, writer := io.Pipe()
+		errorChan := make(chan error)
+		go func() {
+			err := kubectl.CopyFromReader(restConfig, r.pod, container, BuildPodContextPath, reader)
+			reader.CloseWithError(err)
+			errorChan <- err
+		}()
+
+		err = writeContextTar(writer, contextPath, changed)
+		writer.CloseWithError(err)
+
+		copyErr := <-errorChan
+		if err != nil {
+			return errors.Wrap(err, "write tar")
+		} else if copyErr != nil {
+			return fmt.Errorf("Error uploading files to container: %v", copyErr)
+		}
+	}
+
+	r.uploadedFiles = contextFiles
+	return nil
+}
+
+// getContextFiles returns the files of the build context that are not ignored by the given rules
+func getContextFiles(contextPath string, ignoreRules []string) (map[string]*sync.FileInformation, error) {
+	ignoreMatcher, err := sync.CompilePaths(ignoreRules)
+	if err != nil {
+		return nil, errors.Wrap(err, "compile exclude paths")
+	}
+
+	files := map[string]*sync.FileInformation{}
+	err = filepath.Walk(contextPath, func(absolutePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relativePath, err := filepath.Rel(contextPath, absolutePath)
+		if err != nil || relativePath == "." {
+			return err
+		}
+
+		relativePath = filepath.ToSlash(relativePath)
+		if ignoreMatcher != nil && ignoreMatcher.MatchesPath(relativePath) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if info.Mode().IsRegular() {
+			files[relativePath] = &sync.FileInformation{
+				Name:      relativePath,
+				Size:      info.Size(),
+				Mtime:     info.ModTime().Unix(),
+				MtimeNano: info.ModTime().UnixNano(),
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// diffContextFiles returns the files that were added or changed and the files that were deleted since the last upload
+func diffContextFiles(uploaded, current map[string]*sync.FileInformation) ([]string, []string) {
+	changed := []string{}
+	for name, file := range current {
+		uploadedFile, ok := uploaded[name]
+		if ok == false || uploadedFile.Size != file.Size || uploadedFile.MtimeNano != file.MtimeNano {
+			changed = append(changed, name)
+		}
+	}
+
+	deleted := []string{}
+	for name := range uploaded {
+		if _, ok := current[name]; ok == false {
+			deleted = append(deleted, name)
+		}
+	}
+
+	sort.Strings(changed)
+	sort.Strings(deleted)
+	return changed, deleted
+}
+
+// writeContextTar writes a gzipped tar of the given files of the context to the writer
+func writeContextTar(writer io.Writer, contextPath string, files []string) error {
+	gw := gzip.NewWriter(writer)
+	defer gw.Close()
+
+	tarWriter := tar.NewWriter(gw)
+	defer tarWriter.Close()
+
+	writtenFiles := map[string]*sync.FileInformation{}
+	for _, name := range files {
+		err := sync.RecursiveTar(path.Clean(filepath.ToSlash(contextPath)), name, writtenFiles, tarWriter, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// delete deletes the build pod
+func (r *reusableBuildPod) delete(log log.Logger) {
+	r.unregister()
+
+	r.syncMutex.Lock()
+	if r.contextSync != nil {
+		r.contextSync.Stop(nil)
+	}
+	r.syncMutex.Unlock()
+
+	gracePeriod := int64(3)
+	err := r.kubectlClient.CoreV1().Pods(r.pod.Namespace).Delete(r.pod.Name, &metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+	})
+	audit.Record(audit.ActionDelete, "Pod", r.pod.Namespace, r.pod.Name, err)
+
+	if err != nil {
+		log.Errorf("Failed to delete build pod: %s", err.Error())
+	}
+}
+
+// DeleteReusableBuildPods deletes all build pods that were kept running for subsequent builds. It doesn't wait for
+// running builds, so it can be called while devspace exits because a build failed
+func DeleteReusableBuildPods(log log.Logger) {
+	reusableBuildPodsMutex.Lock()
+	reusablePods := reusableBuildPods
+	reusableBuildPods = map[string]*reusableBuildPod{}
+	reusableBuildPodsMutex.Unlock()
+
+	for _, reusablePod := range reusablePods {
+		reusablePod.delete(log)
+	}
+}
@@ -0,0 +1,112 @@
+package helper
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+func TestContextFilesDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = fsutil.WriteToFile([]byte("FROM alpine"), filepath.Join(dir, "Dockerfile"))
+	assert.NilError(t, err, "Error writing Dockerfile")
+	err = fsutil.WriteToFile([]byte("a"), filepath.Join(dir, "src", "a.go"))
+	assert.NilError(t, err, "Error writing a.go")
+	err = fsutil.WriteToFile([]byte("b"), filepath.Join(dir, "src", "b.go"))
+	assert.NilError(t, err, "Error writing b.go")
+	err = fsutil.WriteToFile([]byte("ignored"), filepath.Join(dir, "node_modules", "index.js"))
+	assert.NilError(t, err, "Error writing index.js")
+
+	uploaded, err := getContextFiles(dir, []string{"node_modules/"})
+	assert.NilError(t, err, "Error getting context files")
+	assert.Equal(t, len(uploaded), 3, "Wrong number of context files")
+
+	changed, deleted := diffContextFiles(nil, uploaded)
+	assert.DeepEqual(t, changed, []string{"Dockerfile", "src/a.go", "src/b.go"})
+	assert.DeepEqual(t, deleted, []string{})
+
+	// Change a.go and delete b.go
+	err = fsutil.WriteToFile([]byte("changed"), filepath.Join(dir, "src", "a.go"))
+	assert.NilError(t, err, "Error writing a.go")
+	err = os.Chtimes(filepath.Join(dir, "src", "a.go"), time.Now(), time.Now().Add(time.Minute))
+	assert.NilError(t, err, "Error changing mtime of a.go")
+	err = os.Remove(filepath.Join(dir, "src", "b.go"))
+	assert.NilError(t, err, "Error removing b.go")
+
+	current, err := getContextFiles(dir, []string{"node_modules/"})
+	assert.NilError(t, err, "Error getting context files")
+
+	changed, deleted = diffContextFiles(uploaded, current)
+	assert.DeepEqual(t, changed, []string{"src/a.go"})
+	assert.DeepEqual(t, deleted, []string{"src/b.go"})
+}
+
+func TestIsBuildPodReady(t *testing.T) {
+	pod := &k8sv1.Pod{
+		Spec: k8sv1.PodSpec{
+			Containers: []k8sv1.Container{NewBuildPodContextContainer(), {Name: "kaniko"}},
+		},
+	}
+	assert.Equal(t, isBuildPodReady(pod), false, "Pod without container statuses is ready")
+
+	pod.Status.ContainerStatuses = []k8sv1.ContainerStatus{{Name: "kaniko", Ready: true}, {Name: BuildPodContextContainerName, Ready: false}}
+	assert.Equal(t, isBuildPodReady(pod), false, "Pod with a container that is not ready is ready")
+
+	pod.Status.ContainerStatuses[1].Ready = true
+	assert.Equal(t, isBuildPodReady(pod), true, "Pod with ready containers is not ready")
+}
+
+func TestReusableBuildPodLocks(t *testing.T) {
+	assert.Assert(t, getReusableBuildPodLock("test/image-a") == getReusableBuildPodLock("test/image-a"), "Builds of the same image don't share a lock")
+	assert.Assert(t, getReusableBuildPodLock("test/image-a") != getReusableBuildPodLock("test/image-b"), "Builds of different images share a lock")
+
+	pod := &k8sv1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "build-pod", Namespace: "test"}}
+	kubectlClient := fake.NewSimpleClientset(pod)
+	unregistered := false
+	reusableBuildPodsMutex.Lock()
+	reusableBuildPods["test/image-a"] = &reusableBuildPod{
+		kubectlClient: kubectlClient,
+		pod:           pod,
+		unregister:    func() { unregistered = true },
+	}
+	reusableBuildPodsMutex.Unlock()
+
+	// A running build doesn't block deleting the build pods
+	buildLock := getReusableBuildPodLock("test/image-a")
+	buildLock.Lock()
+	defer buildLock.Unlock()
+
+	done := make(chan bool)
+	go func() {
+		DeleteReusableBuildPods(log.Discard)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("Deleting the build pods waits for the running build")
+	}
+
+	assert.Equal(t, unregistered, true, "Build pod was not deleted")
+	assert.Equal(t, len(reusableBuildPods), 0, "Deleted build pod is still running")
+
+	_, err := kubectlClient.CoreV1().Pods("test").Get("build-pod", metav1.GetOptions{})
+	assert.Assert(t, kerrors.IsNotFound(err), "Build pod still exists: %v", err)
+}
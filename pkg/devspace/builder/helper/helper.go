@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -23,10 +24,13 @@ type BuildHelper struct {
 
 	DockerfilePath string
 	ContextPath    string
+	Target         string
+	IsDev          bool
 
 	EngineName string
 	ImageName  string
 	ImageTag   string
+	ImageTags  []string
 	Entrypoint *[]*string
 }
 
@@ -61,9 +65,12 @@ func NewBuildHelper(config *latest.Config, engineName string, imageConfigName st
 
 		DockerfilePath: dockerfilePath,
 		ContextPath:    contextPath,
+		Target:         GetTarget(imageConf, isDev),
+		IsDev:          isDev,
 
 		ImageName:  imageName,
 		ImageTag:   imageTag,
+		ImageTags:  GetImageTags(imageConf, imageTag),
 		EngineName: engineName,
 
 		Entrypoint: entrypoint,
@@ -103,7 +110,7 @@ func (b *BuildHelper) ShouldRebuild(cache *generated.CacheConfig) (bool, error)
 	if err != nil {
 		return false, fmt.Errorf("Dockerfile %s missing: %v", b.DockerfilePath, err)
 	}
-	dockerfileHash, err := hash.Directory(b.DockerfilePath)
+	dockerfileHash, err := hash.File(b.DockerfilePath)
 	if err != nil {
 		return false, errors.Wrap(err, "hash dockerfile")
 	}
@@ -123,7 +130,7 @@ func (b *BuildHelper) ShouldRebuild(cache *generated.CacheConfig) (bool, error)
 	excludes = build.TrimBuildFilesFromExcludes(excludes, relDockerfile, false)
 	excludes = append(excludes, ".devspace/")
 
-	contextHash, err := hash.DirectoryExcludes(contextDir, excludes, false)
+	contextHash, err := hash.DirectoryTree(contextDir, excludes)
 	if err != nil {
 		return false, fmt.Errorf("Error hashing %s: %v", contextDir, err)
 	}
@@ -148,13 +155,30 @@ func (b *BuildHelper) ShouldRebuild(cache *generated.CacheConfig) (bool, error)
 		entrypointHash = hash.String(string(entrypointHash))
 	}
 
-	// only rebuild Docker image when Dockerfile or context has changed since latest build
-	mustRebuild := imageCache.Tag == "" || imageCache.DockerfileHash != dockerfileHash || imageCache.ContextHash != contextHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash
+	// Hash build args, values from the environment are not part of the image config
+	buildArgs := GetBuildArgs(b.ImageConf)
+	buildArgKeys := make([]string, 0, len(buildArgs))
+	for key := range buildArgs {
+		buildArgKeys = append(buildArgKeys, key)
+	}
+
+	sort.Strings(buildArgKeys)
+	buildArgsStr := ""
+	for _, key := range buildArgKeys {
+		buildArgsStr += key + "=" + buildArgs[key] + "\n"
+	}
+
+	buildArgsHash := hash.String(buildArgsStr)
+
+	// only rebuild Docker image when Dockerfile, context, build args or target have changed since latest build
+	mustRebuild := imageCache.Tag == "" || imageCache.DockerfileHash != dockerfileHash || imageCache.ContextHash != contextHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash || imageCache.BuildArgsHash != buildArgsHash || imageCache.Target != b.Target
 
 	imageCache.DockerfileHash = dockerfileHash
 	imageCache.ContextHash = contextHash
 	imageCache.ImageConfigHash = imageConfigHash
 	imageCache.EntrypointHash = entrypointHash
+	imageCache.BuildArgsHash = buildArgsHash
+	imageCache.Target = b.Target
 
 	return mustRebuild, nil
 }
@@ -75,6 +75,22 @@ func TestBuild(t *testing.T) {
 	assert.Error(t, err, "Error during image build: SomeErr", "No or wrong error passed")
 }
 
+func TestImageTags(t *testing.T) {
+	imageConfig := &latest.ImageConfig{
+		Image: ptr.String("SomeImage"),
+	}
+	helper := NewBuildHelper(&latest.Config{}, "engineName", "imageConfigName", imageConfig, "imageTag", false)
+	assert.DeepEqual(t, []string{"imageTag"}, helper.ImageTags)
+
+	imageConfig.Tags = &[]string{"main", "latest", "main"}
+	helper = NewBuildHelper(&latest.Config{}, "engineName", "imageConfigName", imageConfig, "main", false)
+	assert.DeepEqual(t, []string{"main", "latest"}, helper.ImageTags)
+
+	imageConfig.Tags = &[]string{"latest", "v1", "latest", "v1"}
+	helper = NewBuildHelper(&latest.Config{}, "engineName", "imageConfigName", imageConfig, "main", false)
+	assert.DeepEqual(t, []string{"main", "latest", "v1"}, helper.ImageTags)
+}
+
 func TestShouldRebuild(t *testing.T) {
 	//Create tempDir and go into it
 	dir, err := ioutil.TempDir("", "testDir")
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"io"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -19,6 +20,137 @@ const DefaultDockerfilePath = "./Dockerfile"
 // DefaultContextPath is the default context path to use
 const DefaultContextPath = "./"
 
+// DevVariant is the cache variant of images that are built with the images.*.dev options
+const DevVariant = "dev"
+
+// GetImageVariant returns the cache variant the image is built with. Images that define images.*.dev are tracked
+// separately in the cache for devspace dev and devspace deploy
+func GetImageVariant(imageConf *latest.ImageConfig, isDev bool) string {
+	if isDev && imageConf.Dev != nil {
+		return DevVariant
+	}
+
+	return ""
+}
+
+// GetTarget returns the multi-stage target that overrides the target of the build options or an empty string
+func GetTarget(imageConf *latest.ImageConfig, isDev bool) string {
+	if isDev && imageConf.Dev != nil && imageConf.Dev.Target != nil {
+		return *imageConf.Dev.Target
+	}
+
+	return ""
+}
+
+// GetBuildOptions returns the build options of the build engine that is configured for the image or nil
+func GetBuildOptions(imageConf *latest.ImageConfig) *latest.BuildOptions {
+	if imageConf.Build == nil {
+		return nil
+	}
+
+	if imageConf.Build.Kaniko != nil {
+		return imageConf.Build.Kaniko.Options
+	} else if imageConf.Build.Pod != nil {
+		return imageConf.Build.Pod.Options
+	} else if imageConf.Build.Docker != nil {
+		return imageConf.Build.Docker.Options
+	}
+
+	return nil
+}
+
+// ProxyEnvVars are the proxy environment variables that docker accepts as build args without declaring them in the
+// Dockerfile
+var ProxyEnvVars = []string{
+	"HTTP_PROXY",
+	"http_proxy",
+	"HTTPS_PROXY",
+	"https_proxy",
+	"FTP_PROXY",
+	"ftp_proxy",
+	"NO_PROXY",
+	"no_proxy",
+}
+
+// GetBuildEnv returns the environment variables of images.*.build.env. If images.*.build.proxyBuildArgs is true,
+// the proxy environment variables are added
+func GetBuildEnv(imageConf *latest.ImageConfig) map[string]string {
+	buildEnv := map[string]string{}
+	if imageConf.Build == nil {
+		return buildEnv
+	}
+
+	if imageConf.Build.Env != nil {
+		for key, value := range *imageConf.Build.Env {
+			buildEnv[key] = value
+		}
+	}
+
+	if imageConf.Build.ProxyBuildArgs != nil && *imageConf.Build.ProxyBuildArgs {
+		for _, key := range ProxyEnvVars {
+			if _, ok := buildEnv[key]; ok {
+				continue
+			}
+			if envValue, ok := os.LookupEnv(key); ok {
+				buildEnv[key] = envValue
+			}
+		}
+	}
+
+	return buildEnv
+}
+
+// GetBuildArgs returns the build args of the image. Build args without a value are resolved from images.*.build.env
+// and the environment like docker does. If images.*.build.proxyBuildArgs is true, the proxy environment variables
+// are passed as build args unless they are set explicitly
+func GetBuildArgs(imageConf *latest.ImageConfig) map[string]string {
+	buildArgs := map[string]string{}
+	buildEnv := GetBuildEnv(imageConf)
+
+	buildOptions := GetBuildOptions(imageConf)
+	if buildOptions != nil && buildOptions.BuildArgs != nil {
+		for key, value := range *buildOptions.BuildArgs {
+			if value != nil {
+				buildArgs[key] = *value
+			} else if envValue, ok := buildEnv[key]; ok {
+				buildArgs[key] = envValue
+			} else if envValue, ok := os.LookupEnv(key); ok {
+				buildArgs[key] = envValue
+			}
+		}
+	}
+
+	if imageConf.Build != nil && imageConf.Build.ProxyBuildArgs != nil && *imageConf.Build.ProxyBuildArgs {
+		for _, key := range ProxyEnvVars {
+			if _, ok := buildArgs[key]; ok {
+				continue
+			}
+			if envValue, ok := buildEnv[key]; ok {
+				buildArgs[key] = envValue
+			}
+		}
+	}
+
+	return buildArgs
+}
+
+// GetImageTags returns all tags the image should be tagged with. The given image tag is always the first tag,
+// additional tags from images.*.tags are appended. Every tag is only returned once
+func GetImageTags(imageConf *latest.ImageConfig, imageTag string) []string {
+	imageTags := []string{imageTag}
+	if imageConf.Tags != nil {
+		seen := map[string]bool{imageTag: true}
+		for _, tag := range *imageConf.Tags {
+			if seen[tag] == false {
+				seen[tag] = true
+				imageTags = append(imageTags, tag)
+			}
+		}
+	}
+
+	return imageTags
+}
+
 // GetDockerfileAndContext retrieves the dockerfile and context
 func GetDockerfileAndContext(config *latest.Config, imageConfigName string, imageConf *latest.ImageConfig, isDev bool) (string, string) {
 	var (
@@ -34,6 +166,10 @@ func GetDockerfileAndContext(config *latest.Config, imageConfigName string, imag
 		contextPath = *imageConf.Context
 	}
 
+	if isDev && imageConf.Dev != nil && imageConf.Dev.Dockerfile != nil {
+		dockerfilePath = *imageConf.Dev.Dockerfile
+	}
+
 	if isDev && config.Dev != nil && config.Dev.OverrideImages != nil {
 		for _, overrideConfig := range *config.Dev.OverrideImages {
 			if *overrideConfig.Name == imageConfigName {
@@ -6,6 +6,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
@@ -64,3 +65,138 @@ func TestCreateTempDockerfile(t *testing.T) {
 ENTRYPOINT ["echo"]
 CMD [""]`, string(dockerfileContent), "Temporary dockerfile has wrong content")
 }
+
+func TestGetDockerfileAndContextDev(t *testing.T) {
+	config := &latest.Config{}
+	imageConf := &latest.ImageConfig{
+		Dockerfile: ptr.String("Dockerfile.prod"),
+		Dev: &latest.ImageDevConfig{
+			Dockerfile: ptr.String("Dockerfile.dev"),
+			Target:     ptr.String("development"),
+		},
+	}
+
+	dockerfilePath, contextPath := GetDockerfileAndContext(config, "default", imageConf, false)
+	assert.Equal(t, "Dockerfile.prod", dockerfilePath, "Wrong dockerfile for deploy")
+	assert.Equal(t, DefaultContextPath, contextPath, "Wrong context")
+	assert.Equal(t, "", GetTarget(imageConf, false), "Dev target used for deploy")
+	assert.Equal(t, "", GetImageVariant(imageConf, false), "Wrong variant for deploy")
+
+	dockerfilePath, _ = GetDockerfileAndContext(config, "default", imageConf, true)
+	assert.Equal(t, "Dockerfile.dev", dockerfilePath, "Wrong dockerfile for dev")
+	assert.Equal(t, "development", GetTarget(imageConf, true), "Wrong dev target")
+	assert.Equal(t, DevVariant, GetImageVariant(imageConf, true), "Wrong variant for dev")
+
+	// dev.overrideImages takes precedence
+	config.Dev = &latest.DevConfig{
+		OverrideImages: &[]*latest.ImageOverrideConfig{
+			{
+				Name:       ptr.String("default"),
+				Dockerfile: ptr.String("Dockerfile.override"),
+			},
+		},
+	}
+	dockerfilePath, _ = GetDockerfileAndContext(config, "default", imageConf, true)
+	assert.Equal(t, "Dockerfile.override", dockerfilePath, "Override image dockerfile not used")
+}
+
+func TestGetBuildArgs(t *testing.T) {
+	os.Setenv("DEVSPACE_TEST_BUILD_ARG", "fromEnv")
+	defer os.Unsetenv("DEVSPACE_TEST_BUILD_ARG")
+
+	imageConf := &latest.ImageConfig{
+		Build: &latest.BuildConfig{
+			Kaniko: &latest.KanikoConfig{
+				Options: &latest.BuildOptions{
+					BuildArgs: &map[string]*string{
+						"set":                     ptr.String("value"),
+						"DEVSPACE_TEST_BUILD_ARG": nil,
+						"DEVSPACE_TEST_UNSET_ARG": nil,
+					},
+				},
+			},
+		},
+	}
+
+	buildArgs := GetBuildArgs(imageConf)
+	assert.DeepEqual(t, buildArgs, map[string]string{
+		"set":                     "value",
+		"DEVSPACE_TEST_BUILD_ARG": "fromEnv",
+	})
+
+	assert.Equal(t, len(GetBuildArgs(&latest.ImageConfig{})), 0, "Build args returned for image without build config")
+}
+
+func TestGetBuildArgsWithEnvAndProxy(t *testing.T) {
+	os.Setenv("HTTP_PROXY", "http://proxy:3128")
+	os.Setenv("NO_PROXY", "localhost")
+	defer os.Unsetenv("HTTP_PROXY")
+	defer os.Unsetenv("NO_PROXY")
+
+	imageConf := &latest.ImageConfig{
+		Build: &latest.BuildConfig{
+			Env: &map[string]string{
+				"NPM_TOKEN": "secret",
+				"NO_PROXY":  "localhost,.cluster.local",
+			},
+			ProxyBuildArgs: ptr.Bool(true),
+			Docker: &latest.DockerConfig{
+				Options: &latest.BuildOptions{
+					BuildArgs: &map[string]*string{
+						"NPM_TOKEN":   nil,
+						"HTTPS_PROXY": ptr.String("http://other:3128"),
+					},
+				},
+			},
+		},
+	}
+
+	assert.DeepEqual(t, GetBuildEnv(imageConf), map[string]string{
+		"NPM_TOKEN":  "secret",
+		"NO_PROXY":   "localhost,.cluster.local",
+		"HTTP_PROXY": "http://proxy:3128",
+	})
+	assert.DeepEqual(t, GetBuildArgs(imageConf), map[string]string{
+		"NPM_TOKEN":   "secret",
+		"HTTPS_PROXY": "http://other:3128",
+		"NO_PROXY":    "localhost,.cluster.local",
+		"HTTP_PROXY":  "http://proxy:3128",
+	})
+
+	imageConf.Build.ProxyBuildArgs = nil
+	assert.DeepEqual(t, GetBuildArgs(imageConf), map[string]string{
+		"NPM_TOKEN":   "secret",
+		"HTTPS_PROXY": "http://other:3128",
+	})
+}
+
+func TestGetBuildPodSchedulingOptions(t *testing.T) {
+	resources, err := GetBuildPodResources(&latest.PodResources{
+		Limits: &map[string]*string{"memory": ptr.String("2Gi"), "cpu": nil},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources.Limits), 1)
+	assert.Equal(t, resources.Limits.Memory().String(), "2Gi")
+
+	_, err = GetBuildPodResources(&latest.PodResources{
+		Requests: &map[string]*string{"memory": ptr.String("lots")},
+	})
+	assert.ErrorContains(t, err, "parse memory quantity")
+
+	resources, err = GetBuildPodResources(nil)
+	assert.NilError(t, err)
+	assert.Assert(t, resources == nil)
+
+	assert.DeepEqual(t, GetBuildPodStringMap(&map[string]*string{"pool": ptr.String("build"), "empty": nil}), map[string]string{"pool": "build"})
+
+	seconds := int64(60)
+	tolerations := GetBuildPodTolerations(&[]*latest.PodToleration{
+		{Key: ptr.String("dedicated"), Operator: ptr.String("Equal"), Value: ptr.String("build"), Effect: ptr.String("NoSchedule"), TolerationSeconds: &seconds},
+	})
+	assert.Equal(t, len(tolerations), 1)
+	assert.Equal(t, tolerations[0].Key, "dedicated")
+	assert.Equal(t, string(tolerations[0].Operator), "Equal")
+	assert.Equal(t, tolerations[0].Value, "build")
+	assert.Equal(t, string(tolerations[0].Effect), "NoSchedule")
+	assert.Equal(t, *tolerations[0].TolerationSeconds, int64(60))
+}
@@ -9,4 +9,9 @@ import (
 type Interface interface {
 	ShouldRebuild(cache *generated.CacheConfig) (bool, error)
 	Build(log log.Logger) error
+
+	// EngineName returns the name of the engine that builds the image
+	EngineName() string
+	// Digest returns the digest of the built image or an empty string if the engine does not report it
+	Digest() string
 }
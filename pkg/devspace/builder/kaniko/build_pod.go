@@ -2,62 +2,39 @@ package kaniko
 
 import (
 	"path/filepath"
+	"sort"
 
 	"github.com/docker/docker/api/types"
-	"github.com/pkg/errors"
 	k8sv1 "k8s.io/api/core/v1"
 
 	"fmt"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 	"github.com/docker/distribution/reference"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-// The context path within the kaniko pod
-const kanikoContextPath = "/context"
+// kanikoImage is the image of the kaniko build container
+const kanikoImage = "gcr.io/kaniko-project/executor:v0.10.0"
 
-// The file the init container will wait for
-const doneFile = "/tmp/done"
+// kanikoDebugImage is the kaniko image that contains a shell, so the container can be kept running for reuse
+const kanikoDebugImage = "gcr.io/kaniko-project/executor:debug-v0.10.0"
 
-// DevspaceQuota is the quota name of the space quota in the devspace cloud
-const devspaceQuota = "devspace-quota"
+// kanikoExecutor is the path of the kaniko executor within the kaniko images
+const kanikoExecutor = "/kaniko/executor"
 
-// DevspaceLimitRange is the limit range name of the space limit range in the devspace cloud
-const devspaceLimitRange = "devspace-limit-range"
-
-type availableResources struct {
-	CPU              resource.Quantity
-	Memory           resource.Quantity
-	EphemeralStorage resource.Quantity
-}
-
-// The default resource limits to use for the kaniko pod
-var defaultResources = &availableResources{
-	CPU:              resource.MustParse("4"),
-	Memory:           resource.MustParse("8Gi"),
-	EphemeralStorage: resource.MustParse("10Gi"),
-}
-
-func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions, dockerfilePath string) (*k8sv1.Pod, error) {
+func (b *Builder) getBuildArgs(options *types.ImageBuildOptions, dockerfilePath string) ([]string, error) {
 	kanikoOptions := b.helper.ImageConf.Build.Kaniko
 
-	registryURL, err := registry.GetRegistryFromImageName(b.FullImageName)
-	if err != nil {
-		return nil, err
-	}
-
-	pullSecretName := registry.GetRegistryAuthSecretName(registryURL)
-	if b.PullSecretName != "" {
-		pullSecretName = b.PullSecretName
-	}
-
 	// additional options to pass to kaniko
 	kanikoArgs := []string{
-		"--dockerfile=" + kanikoContextPath + "/" + filepath.Base(dockerfilePath),
-		"--context=dir://" + kanikoContextPath,
-		"--destination=" + b.FullImageName,
+		"--dockerfile=" + helper.BuildPodContextPath + "/" + filepath.Base(dockerfilePath),
+		"--context=dir://" + helper.BuildPodContextPath,
+	}
+	for _, imageTag := range b.helper.ImageTags {
+		kanikoArgs = append(kanikoArgs, "--destination="+b.helper.ImageName+":"+imageTag)
 	}
 
 	// Set snapshot mode
@@ -74,6 +51,10 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 
 	// Build args
 	for key, value := range options.BuildArgs {
+		if value == nil {
+			continue
+		}
+
 		newKanikoArg := fmt.Sprintf("%v=%v", key, *value)
 		kanikoArgs = append(kanikoArgs, "--build-arg", newKanikoArg)
 	}
@@ -86,51 +67,68 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 	}
 
 	// Cache
-	if !options.NoCache {
-		ref, err := reference.ParseNormalizedNamed(b.FullImageName)
-		if err != nil {
-			return nil, err
+	if !options.NoCache && (kanikoOptions.Cache == nil || *kanikoOptions.Cache) {
+		cacheRepo := ""
+		if kanikoOptions.CacheRepo != nil && *kanikoOptions.CacheRepo != "" {
+			cacheRepo = *kanikoOptions.CacheRepo
+		} else {
+			ref, err := reference.ParseNormalizedNamed(b.FullImageName)
+			if err != nil {
+				return nil, err
+			}
+
+			cacheRepo = ref.Name()
 		}
 
-		kanikoArgs = append(kanikoArgs, "--cache=true", "--cache-repo="+ref.Name())
+		kanikoArgs = append(kanikoArgs, "--cache=true", "--cache-repo="+cacheRepo)
+		if kanikoOptions.CacheTTL != nil && *kanikoOptions.CacheTTL != "" {
+			kanikoArgs = append(kanikoArgs, "--cache-ttl="+*kanikoOptions.CacheTTL)
+		}
 	}
 
-	// Get available resources
-	availableResources, err := b.getAvailableResources()
+	return kanikoArgs, nil
+}
+
+// getBuildPod returns the build pod that runs kaniko with the given args. A reusable build pod keeps the kaniko and
+// the context container running, kaniko is executed in it for every build
+func (b *Builder) getBuildPod(buildID string, kanikoArgs []string, reuse bool) (*k8sv1.Pod, error) {
+	registryURL, err := registry.GetRegistryFromImageName(b.FullImageName)
 	if err != nil {
 		return nil, err
 	}
 
-	return &k8sv1.Pod{
+	pullSecretName := registry.GetRegistryAuthSecretName(registryURL)
+	if b.PullSecretName != "" {
+		pullSecretName = b.PullSecretName
+	}
+
+	kanikoOptions := b.helper.ImageConf.Build.Kaniko
+	resources, err := b.getResources()
+	if err != nil {
+		return nil, err
+	}
+
+	pod := &k8sv1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: "devspace-build-",
+			Namespace:    b.BuildNamespace,
 			Labels: map[string]string{
 				"devspace-build":    "true",
 				"devspace-build-id": buildID,
 			},
+			Annotations: helper.GetBuildPodStringMap(kanikoOptions.Annotations),
 		},
 		Spec: k8sv1.PodSpec{
 			InitContainers: []k8sv1.Container{
-				{
-					Name:            "context",
-					Image:           "alpine",
-					Command:         []string{"sh"},
-					Args:            []string{"-c", "while [ ! -f " + doneFile + " ]; do sleep 2; done"},
-					ImagePullPolicy: k8sv1.PullIfNotPresent,
-					VolumeMounts: []k8sv1.VolumeMount{
-						{
-							Name:      "context",
-							MountPath: kanikoContextPath,
-						},
-					},
-				},
+				helper.NewBuildPodInitContainer(),
 			},
 			Containers: []k8sv1.Container{
 				{
 					Name:            "kaniko",
-					Image:           "gcr.io/kaniko-project/executor:v0.10.0",
+					Image:           kanikoImage,
 					ImagePullPolicy: k8sv1.PullIfNotPresent,
 					Args:            kanikoArgs,
+					Env:             getBuildPodEnv(helper.GetBuildEnv(b.helper.ImageConf)),
 					VolumeMounts: []k8sv1.VolumeMount{
 						{
 							Name:      pullSecretName,
@@ -138,124 +136,78 @@ func (b *Builder) getBuildPod(buildID string, options *types.ImageBuildOptions,
 						},
 						{
 							Name:      "context",
-							MountPath: kanikoContextPath,
-						},
-					},
-					Resources: k8sv1.ResourceRequirements{
-						Limits: k8sv1.ResourceList{
-							k8sv1.ResourceCPU:              availableResources.CPU,
-							k8sv1.ResourceMemory:           availableResources.Memory,
-							k8sv1.ResourceEphemeralStorage: availableResources.EphemeralStorage,
-						},
-						Requests: k8sv1.ResourceList{
-							k8sv1.ResourceCPU:              resource.MustParse("0"),
-							k8sv1.ResourceMemory:           resource.MustParse("0"),
-							k8sv1.ResourceEphemeralStorage: resource.MustParse("0"),
+							MountPath: helper.BuildPodContextPath,
 						},
 					},
+					Resources: *resources,
 				},
 			},
-			Volumes: []k8sv1.Volume{
-				{
-					Name: pullSecretName,
-					VolumeSource: k8sv1.VolumeSource{
-						Secret: &k8sv1.SecretVolumeSource{
-							SecretName: pullSecretName,
-							Items: []k8sv1.KeyToPath{
-								{
-									Key:  k8sv1.DockerConfigJsonKey,
-									Path: "config.json",
-								},
-							},
-						},
-					},
-				},
-				{
-					Name: "context",
-					VolumeSource: k8sv1.VolumeSource{
-						EmptyDir: &k8sv1.EmptyDirVolumeSource{},
-					},
-				},
-			},
+			NodeSelector:  helper.GetBuildPodStringMap(kanikoOptions.NodeSelector),
+			Tolerations:   helper.GetBuildPodTolerations(kanikoOptions.Tolerations),
+			Volumes:       helper.NewBuildPodVolumes(pullSecretName),
 			RestartPolicy: k8sv1.RestartPolicyNever,
 		},
-	}, nil
-}
-
-// Determine available resources (This is only necessary in the devspace cloud)
-func (b *Builder) getAvailableResources() (*availableResources, error) {
-	quota, err := b.kubectl.CoreV1().ResourceQuotas(b.BuildNamespace).Get(devspaceQuota, metav1.GetOptions{})
-	if err != nil {
-		return defaultResources, nil
 	}
-
-	availableResources := &availableResources{}
-
-	// CPU
-	availableResources.CPU, err = getAvailableResourceQuantity(defaultResources.CPU, k8sv1.ResourceLimitsCPU, quota)
-	if err != nil {
-		return nil, errors.Wrap(err, "get available resource quantity")
+	if kanikoOptions.ServiceAccount != nil {
+		pod.Spec.ServiceAccountName = *kanikoOptions.ServiceAccount
 	}
 
-	// Memory
-	availableResources.Memory, err = getAvailableResourceQuantity(defaultResources.Memory, k8sv1.ResourceLimitsMemory, quota)
-	if err != nil {
-		return nil, errors.Wrap(err, "get available resource quantity")
+	if reuse {
+		pod.Spec.InitContainers = nil
+		pod.Spec.Containers = append(pod.Spec.Containers, helper.NewBuildPodContextContainer())
+		pod.Spec.Containers[0].Image = kanikoDebugImage
+		pod.Spec.Containers[0].Command = []string{"/busybox/sh"}
+		pod.Spec.Containers[0].Args = []string{"-c", "while true; do sleep 5; done"}
 	}
 
-	// Ephemeral Storage
-	availableResources.EphemeralStorage, err = getAvailableResourceQuantity(defaultResources.EphemeralStorage, k8sv1.ResourceLimitsEphemeralStorage, quota)
-	if err != nil {
-		return nil, errors.Wrap(err, "get available resource quantity")
+	return pod, nil
+}
+
+// getBuildPodEnv returns the environment variables of the kaniko container sorted by name
+func getBuildPodEnv(buildEnv map[string]string) []k8sv1.EnvVar {
+	if len(buildEnv) == 0 {
+		return nil
 	}
 
-	// Get limitrange
-	limitrange, err := b.kubectl.CoreV1().LimitRanges(b.BuildNamespace).Get(devspaceLimitRange, metav1.GetOptions{})
-	if err != nil {
-		return availableResources, nil
+	keys := make([]string, 0, len(buildEnv))
+	for key := range buildEnv {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	// Check if container limit is smaller than the available resources
-	for _, limit := range limitrange.Spec.Limits {
-		if limit.Type == k8sv1.LimitTypeContainer {
-			if maxCPU, ok := limit.Max[k8sv1.ResourceCPU]; ok {
-				if availableResources.CPU.Cmp(maxCPU) == 1 {
-					availableResources.CPU = maxCPU
-				}
-			}
-			if maxMemory, ok := limit.Max[k8sv1.ResourceMemory]; ok {
-				if availableResources.Memory.Cmp(maxMemory) == 1 {
-					availableResources.Memory = maxMemory
-				}
-			}
-			if maxEphemeralStorage, ok := limit.Max[k8sv1.ResourceEphemeralStorage]; ok {
-				if availableResources.EphemeralStorage.Cmp(maxEphemeralStorage) == 1 {
-					availableResources.EphemeralStorage = maxEphemeralStorage
-				}
-			}
-		}
+	env := make([]k8sv1.EnvVar, 0, len(keys))
+	for _, key := range keys {
+		env = append(env, k8sv1.EnvVar{
+			Name:  key,
+			Value: buildEnv[key],
+		})
 	}
 
-	return availableResources, nil
+	return env
 }
 
-func getAvailableResourceQuantity(defaultQuantity resource.Quantity, resourceName k8sv1.ResourceName, quota *k8sv1.ResourceQuota) (resource.Quantity, error) {
-	retLimit := defaultQuantity
-	if quotaLimit, ok := quota.Status.Hard[resourceName]; ok {
-		retLimit = quotaLimit
-		if quotaUsed, ok := quota.Status.Used[resourceName]; ok {
-			retLimit.Sub(quotaUsed)
-
-			if retLimit.Cmp(defaultQuantity) == 1 {
-				retLimit = defaultQuantity
-			}
-		}
+// getResources returns the configured resources or the available resources as limits if nothing is configured
+func (b *Builder) getResources() (*k8sv1.ResourceRequirements, error) {
+	resources, err := helper.GetBuildPodResources(b.helper.ImageConf.Build.Kaniko.Resources)
+	if err != nil || resources != nil {
+		return resources, err
 	}
 
-	// Check if limit == 0 or below zero
-	if retLimit.Sign() != 1 {
-		return resource.MustParse("0"), fmt.Errorf("Available %s resource is zero or below zero: %s", resourceName, retLimit.String())
+	availableResources, err := helper.GetAvailableResources(b.kubectl, b.BuildNamespace)
+	if err != nil {
+		return nil, err
 	}
 
-	return retLimit, nil
+	return &k8sv1.ResourceRequirements{
+		Limits: k8sv1.ResourceList{
+			k8sv1.ResourceCPU:              availableResources.CPU,
+			k8sv1.ResourceMemory:           availableResources.Memory,
+			k8sv1.ResourceEphemeralStorage: availableResources.EphemeralStorage,
+		},
+		Requests: k8sv1.ResourceList{
+			k8sv1.ResourceCPU:              resource.MustParse("0"),
+			k8sv1.ResourceMemory:           resource.MustParse("0"),
+			k8sv1.ResourceEphemeralStorage: resource.MustParse("0"),
+		},
+	}, nil
 }
@@ -3,15 +3,19 @@ package kaniko
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/docker/docker/api/types"
+	"gotest.tools/assert"
 
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -20,6 +24,62 @@ import (
 
 const testNamespace = "test-kaniko-build"
 
+func TestGetBuildArgsCache(t *testing.T) {
+	testCases := map[string]struct {
+		kanikoConfig  *latest.KanikoConfig
+		noCache       bool
+		expectedCache []string
+	}{
+		"default cache repo": {
+			kanikoConfig:  &latest.KanikoConfig{},
+			expectedCache: []string{"--cache=true", "--cache-repo=docker.io/myuser/myimage"},
+		},
+		"custom cache repo and ttl": {
+			kanikoConfig: &latest.KanikoConfig{
+				CacheRepo: ptr.String("myregistry.com/cache"),
+				CacheTTL:  ptr.String("6h"),
+			},
+			expectedCache: []string{"--cache=true", "--cache-repo=myregistry.com/cache", "--cache-ttl=6h"},
+		},
+		"cache disabled": {
+			kanikoConfig: &latest.KanikoConfig{
+				Cache:    ptr.Bool(false),
+				CacheTTL: ptr.String("6h"),
+			},
+			expectedCache: []string{},
+		},
+		"no cache": {
+			kanikoConfig:  &latest.KanikoConfig{},
+			noCache:       true,
+			expectedCache: []string{},
+		},
+	}
+
+	for name, testCase := range testCases {
+		imageConf := &latest.ImageConfig{
+			Image: ptr.String("myuser/myimage"),
+			Build: &latest.BuildConfig{
+				Kaniko: testCase.kanikoConfig,
+			},
+		}
+		builder := &Builder{
+			FullImageName: "myuser/myimage:latest",
+			helper:        helper.NewBuildHelper(&latest.Config{}, EngineName, "default", imageConf, "latest", false),
+		}
+
+		kanikoArgs, err := builder.getBuildArgs(&types.ImageBuildOptions{NoCache: testCase.noCache}, "Dockerfile")
+		assert.NilError(t, err, "Test case %s", name)
+
+		cacheArgs := []string{}
+		for _, arg := range kanikoArgs {
+			if strings.HasPrefix(arg, "--cache") {
+				cacheArgs = append(cacheArgs, arg)
+			}
+		}
+		assert.DeepEqual(t, cacheArgs, testCase.expectedCache)
+	}
+}
+
 func TestKanikoBuildWithEntrypointOverride(t *testing.T) {
 	t.Skip("Package is untestable because of kubeClient stream usage")
 
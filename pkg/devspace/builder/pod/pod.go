@@ -0,0 +1,358 @@
+package pod
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/randutil"
+
+	"github.com/docker/docker/client"
+	dockerterm "github.com/docker/docker/pkg/term"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ToolBuildah builds the image with buildah
+	ToolBuildah = "buildah"
+	// ToolImg builds the image with img
+	ToolImg = "img"
+)
+
+// DefaultImages are the images that are used for the build container if no image is specified
+var DefaultImages = map[string]string{
+	ToolBuildah: "quay.io/buildah/stable:v1.11.3",
+	ToolImg:     "r.j3ss.co/img:v0.5.7",
+}
+
+// dockerConfigPath is the path where the registry credentials are mounted in the build container
+const dockerConfigPath = "/devspace/.docker"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// Builder builds and pushes images with buildah or img within a pod in the cluster
+type Builder struct {
+	helper *helper.BuildHelper
+	tool   string
+
+	PullSecretName string
+	FullImageName  string
+	BuildNamespace string
+
+	allowInsecureRegistry bool
+	kubectl               kubernetes.Interface
+	dockerClient          client.CommonAPIClient
+}
+
+// NewBuilder creates a new pod.Builder instance
+func NewBuilder(config *latest.Config, dockerClient client.CommonAPIClient, kubectl kubernetes.Interface, imageConfigName string, imageConf *latest.ImageConfig, imageTag string, isDev bool, log logpkg.Logger) (*Builder, error) {
+	podConfig := imageConf.Build.Pod
+
+	buildNamespace, err := configutil.GetDefaultNamespace(config)
+	if err != nil {
+		return nil, errors.New("Error retrieving default namespace")
+	}
+	if podConfig.Namespace != nil && *podConfig.Namespace != "" {
+		buildNamespace = *podConfig.Namespace
+	}
+
+	tool := ToolBuildah
+	if podConfig.Tool != nil && *podConfig.Tool != "" {
+		tool = *podConfig.Tool
+	}
+	if _, ok := DefaultImages[tool]; !ok {
+		return nil, fmt.Errorf("Unsupported build tool %s, please use either %s or %s", tool, ToolBuildah, ToolImg)
+	}
+
+	allowInsecurePush := false
+	if podConfig.Insecure != nil {
+		allowInsecurePush = *podConfig.Insecure
+	}
+
+	pullSecretName := ""
+	if podConfig.PullSecret != nil {
+		pullSecretName = *podConfig.PullSecret
+	}
+
+	builder := &Builder{
+		helper: helper.NewBuildHelper(config, tool, imageConfigName, imageConf, imageTag, isDev),
+		tool:   tool,
+
+		PullSecretName: pullSecretName,
+		FullImageName:  *imageConf.Image + ":" + imageTag,
+		BuildNamespace: buildNamespace,
+
+		allowInsecureRegistry: allowInsecurePush,
+
+		kubectl:      kubectl,
+		dockerClient: dockerClient,
+	}
+
+	// create pull secret
+	if pullSecretName == "" {
+		err = helper.CreateBuildPullSecret(kubectl, dockerClient, buildNamespace, builder.FullImageName, log)
+		if err != nil {
+			return nil, errors.Wrap(err, "create pull secret")
+		}
+	}
+
+	return builder, nil
+}
+
+// Build implements the interface
+func (b *Builder) Build(log logpkg.Logger) error {
+	return b.helper.Build(b, log)
+}
+
+// EngineName implements the interface
+func (b *Builder) EngineName() string {
+	return b.tool
+}
+
+// Digest implements the interface
+func (b *Builder) Digest() string {
+	return ""
+}
+
+// ShouldRebuild determines if an image has to be rebuilt
+func (b *Builder) ShouldRebuild(cache *generated.CacheConfig) (bool, error) {
+	return b.helper.ShouldRebuild(cache)
+}
+
+// BuildImage builds a dockerimage within a build pod
+func (b *Builder) BuildImage(contextPath, dockerfilePath string, entrypoint *[]*string, log logpkg.Logger) error {
+	// Check if we should overwrite entrypoint
+	if entrypoint != nil && len(*entrypoint) > 0 {
+		tempDockerfilePath, err := helper.CreateTempDockerfile(dockerfilePath, *entrypoint)
+		if err != nil {
+			return err
+		}
+
+		defer os.RemoveAll(filepath.Dir(tempDockerfilePath))
+		dockerfilePath = tempDockerfilePath
+	}
+
+	// Generate the build pod spec
+	randString, _ := randutil.GenerateRandomString(12)
+	buildID := strings.ToLower(randString)
+	buildPod, err := b.getBuildPod(buildID, dockerfilePath)
+	if err != nil {
+		return errors.Wrap(err, "get build pod")
+	}
+
+	// Determine output writer
+	var writer io.Writer
+	if log == logpkg.GetInstance() {
+		writer = stdout
+	} else {
+		writer = log
+	}
+
+	return b.helper.RunBuildPod(b.kubectl, buildPod, contextPath, dockerfilePath, writer, log)
+}
+
+func (b *Builder) getBuildPod(buildID string, dockerfilePath string) (*k8sv1.Pod, error) {
+	podConfig := b.helper.ImageConf.Build.Pod
+
+	registryURL, err := registry.GetRegistryFromImageName(b.FullImageName)
+	if err != nil {
+		return nil, err
+	}
+
+	pullSecretName := registry.GetRegistryAuthSecretName(registryURL)
+	if b.PullSecretName != "" {
+		pullSecretName = b.PullSecretName
+	}
+
+	image := DefaultImages[b.tool]
+	if podConfig.Image != nil && *podConfig.Image != "" {
+		image = *podConfig.Image
+	}
+
+	privileged := true
+	if podConfig.Privileged != nil {
+		privileged = *podConfig.Privileged
+	}
+
+	resources, err := b.getResources()
+	if err != nil {
+		return nil, err
+	}
+
+	nodeSelector := helper.GetBuildPodStringMap(podConfig.NodeSelector)
+
+	return &k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "devspace-build-",
+			Namespace:    b.BuildNamespace,
+			Labels: map[string]string{
+				"devspace-build":    "true",
+				"devspace-build-id": buildID,
+			},
+		},
+		Spec: k8sv1.PodSpec{
+			InitContainers: []k8sv1.Container{
+				helper.NewBuildPodInitContainer(),
+			},
+			Containers: []k8sv1.Container{
+				{
+					Name:            b.tool,
+					Image:           image,
+					ImagePullPolicy: k8sv1.PullIfNotPresent,
+					Command:         []string{"sh"},
+					Args:            []string{"-c", b.getBuildCommand(dockerfilePath)},
+					Env: []k8sv1.EnvVar{
+						{
+							Name:  "DOCKER_CONFIG",
+							Value: dockerConfigPath,
+						},
+						{
+							Name:  "REGISTRY_AUTH_FILE",
+							Value: dockerConfigPath + "/config.json",
+						},
+					},
+					VolumeMounts: []k8sv1.VolumeMount{
+						{
+							Name:      pullSecretName,
+							MountPath: dockerConfigPath,
+						},
+						{
+							Name:      "context",
+							MountPath: helper.BuildPodContextPath,
+						},
+					},
+					Resources: *resources,
+					SecurityContext: &k8sv1.SecurityContext{
+						Privileged: &privileged,
+					},
+				},
+			},
+			NodeSelector:  nodeSelector,
+			Volumes:       helper.NewBuildPodVolumes(pullSecretName),
+			RestartPolicy: k8sv1.RestartPolicyNever,
+		},
+	}, nil
+}
+
+// getBuildCommand returns the shell command that builds and pushes the image with the configured tool
+func (b *Builder) getBuildCommand(dockerfilePath string) string {
+	podConfig := b.helper.ImageConf.Build.Pod
+
+	buildArgs := []string{"bud", "--storage-driver=vfs", "--format=docker"}
+	pushArgs := []string{"push", "--storage-driver=vfs"}
+	if b.tool == ToolImg {
+		buildArgs = []string{"build"}
+		pushArgs = []string{"push"}
+	}
+
+	buildArgs = append(buildArgs, "-f", helper.BuildPodContextPath+"/"+filepath.Base(dockerfilePath))
+	for _, imageTag := range b.helper.ImageTags {
+		buildArgs = append(buildArgs, "-t", b.helper.ImageName+":"+imageTag)
+	}
+
+	if podConfig.Options != nil {
+		if podConfig.Options.BuildArgs != nil {
+			keys := make([]string, 0, len(*podConfig.Options.BuildArgs))
+			for key := range *podConfig.Options.BuildArgs {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+
+			for _, key := range keys {
+				value := ""
+				if (*podConfig.Options.BuildArgs)[key] != nil {
+					value = *(*podConfig.Options.BuildArgs)[key]
+				}
+
+				buildArgs = append(buildArgs, "--build-arg", key+"="+value)
+			}
+		}
+		if podConfig.Options.Target != nil && b.helper.Target == "" {
+			buildArgs = append(buildArgs, "--target", *podConfig.Options.Target)
+		}
+		if podConfig.Options.Network != nil && b.tool == ToolBuildah {
+			buildArgs = append(buildArgs, "--network", *podConfig.Options.Network)
+		}
+	}
+
+	if b.helper.Target != "" {
+		buildArgs = append(buildArgs, "--target", b.helper.Target)
+	}
+
+	// Extra flags
+	if podConfig.Flags != nil {
+		for _, flag := range *podConfig.Flags {
+			buildArgs = append(buildArgs, *flag)
+		}
+	}
+
+	buildArgs = append(buildArgs, helper.BuildPodContextPath)
+
+	// Allow insecure registry
+	if b.allowInsecureRegistry {
+		if b.tool == ToolImg {
+			pushArgs = append(pushArgs, "--insecure-registry")
+		} else {
+			pushArgs = append(pushArgs, "--tls-verify=false")
+		}
+	}
+
+	commands := []string{shellCommand(b.tool, buildArgs)}
+	for _, imageTag := range b.helper.ImageTags {
+		commands = append(commands, shellCommand(b.tool, append(pushArgs, b.helper.ImageName+":"+imageTag)))
+	}
+
+	return strings.Join(commands, " && ")
+}
+
+// getResources returns the configured resources or the available resources as limits if nothing is configured
+func (b *Builder) getResources() (*k8sv1.ResourceRequirements, error) {
+	resources, err := helper.GetBuildPodResources(b.helper.ImageConf.Build.Pod.Resources)
+	if err != nil || resources != nil {
+		return resources, err
+	}
+
+	availableResources, err := helper.GetAvailableResources(b.kubectl, b.BuildNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return &k8sv1.ResourceRequirements{
+		Limits: k8sv1.ResourceList{
+			k8sv1.ResourceCPU:              availableResources.CPU,
+			k8sv1.ResourceMemory:           availableResources.Memory,
+			k8sv1.ResourceEphemeralStorage: availableResources.EphemeralStorage,
+		},
+		Requests: k8sv1.ResourceList{
+			k8sv1.ResourceCPU:              resource.MustParse("0"),
+			k8sv1.ResourceMemory:           resource.MustParse("0"),
+			k8sv1.ResourceEphemeralStorage: resource.MustParse("0"),
+		},
+	}, nil
+}
+
+// shellCommand joins the command and its arguments and quotes them for sh
+func shellCommand(command string, args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, command)
+	for _, arg := range args {
+		quoted = append(quoted, "'"+strings.Replace(arg, "'", `'"'"'`, -1)+"'")
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,93 @@
+package pod
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/builder/helper"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetBuildPod(t *testing.T) {
+	imageConf := &latest.ImageConfig{
+		Image: ptr.String("registry.example.com/user/image"),
+		Tags:  &[]string{"v1", "latest"},
+		Build: &latest.BuildConfig{
+			Pod: &latest.PodBuildConfig{
+				Tool:     ptr.String(ToolImg),
+				Insecure: ptr.Bool(true),
+				Flags:    &[]*string{ptr.String("--no-cache")},
+				Resources: &latest.PodResources{
+					Limits: &map[string]*string{"memory": ptr.String("2Gi")},
+				},
+				NodeSelector: &map[string]*string{"pool": ptr.String("build")},
+				Options: &latest.BuildOptions{
+					Target:    ptr.String("production"),
+					BuildArgs: &map[string]*string{"B": ptr.String("it's"), "A": ptr.String("1")},
+				},
+			},
+		},
+	}
+
+	builder := &Builder{
+		helper:         helper.NewBuildHelper(&latest.Config{}, ToolImg, "default", imageConf, "v1", false),
+		tool:           ToolImg,
+		FullImageName:  "registry.example.com/user/image:v1",
+		BuildNamespace: "build",
+
+		allowInsecureRegistry: true,
+		kubectl:               fake.NewSimpleClientset(),
+	}
+
+	buildPod, err := builder.getBuildPod("abc", "/project/Dockerfile")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buildPod.Namespace != "build" {
+		t.Fatalf("Unexpected namespace %s", buildPod.Namespace)
+	}
+	if buildPod.Spec.NodeSelector["pool"] != "build" {
+		t.Fatalf("Unexpected node selector %v", buildPod.Spec.NodeSelector)
+	}
+
+	container := buildPod.Spec.Containers[0]
+	if container.Image != DefaultImages[ToolImg] {
+		t.Fatalf("Unexpected image %s", container.Image)
+	}
+	if memory := container.Resources.Limits[k8sv1.ResourceMemory]; memory.String() != "2Gi" {
+		t.Fatalf("Unexpected memory limit %s", memory.String())
+	}
+	if container.SecurityContext == nil || *container.SecurityContext.Privileged != true {
+		t.Fatal("Expected privileged build container")
+	}
+
+	expectedCommand := "img 'build' '-f' '/context/Dockerfile' '-t' 'registry.example.com/user/image:v1' '-t' 'registry.example.com/user/image:latest' '--build-arg' 'A=1' '--build-arg' 'B=it'\"'\"'s' '--target' 'production' '--no-cache' '/context'" +
+		" && img 'push' '--insecure-registry' 'registry.example.com/user/image:v1'" +
+		" && img 'push' '--insecure-registry' 'registry.example.com/user/image:latest'"
+	if container.Args[1] != expectedCommand {
+		t.Fatalf("Unexpected build command:\n%s\nexpected:\n%s", container.Args[1], expectedCommand)
+	}
+}
+
+func TestGetBuildCommandBuildah(t *testing.T) {
+	imageConf := &latest.ImageConfig{
+		Image: ptr.String("image"),
+		Build: &latest.BuildConfig{
+			Pod: &latest.PodBuildConfig{},
+		},
+	}
+
+	builder := &Builder{
+		helper: helper.NewBuildHelper(&latest.Config{}, ToolBuildah, "default", imageConf, "v1", false),
+		tool:   ToolBuildah,
+	}
+
+	command := builder.getBuildCommand("/project/Dockerfile")
+	expectedCommand := "buildah 'bud' '--storage-driver=vfs' '--format=docker' '-f' '/context/Dockerfile' '-t' 'image:v1' '/context' && buildah 'push' '--storage-driver=vfs' 'image:v1'"
+	if command != expectedCommand {
+		t.Fatalf("Unexpected build command:\n%s\nexpected:\n%s", command, expectedCommand)
+	}
+}
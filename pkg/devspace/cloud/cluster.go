@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/util/hash"
@@ -673,6 +674,7 @@ func initializeNamespace(client kubernetes.Interface) error {
 				Name: DevSpaceCloudNamespace,
 			},
 		})
+		audit.Record(audit.ActionCreate, "Namespace", "", DevSpaceCloudNamespace, err)
 		if err != nil {
 			return errors.Wrap(err, "create namespace")
 		}
@@ -688,6 +690,7 @@ func initializeNamespace(client kubernetes.Interface) error {
 				Name: DevSpaceServiceAccount,
 			},
 		})
+		audit.Record(audit.ActionCreate, "ServiceAccount", DevSpaceCloudNamespace, DevSpaceServiceAccount, err)
 		if err != nil {
 			return errors.Wrap(err, "create service account")
 		}
@@ -715,6 +718,7 @@ func initializeNamespace(client kubernetes.Interface) error {
 				Name:     "cluster-admin",
 			},
 		})
+		audit.Record(audit.ActionCreate, "ClusterRoleBinding", "", DevSpaceClusterRoleBinding, err)
 		if err != nil {
 			return errors.Wrap(err, "create cluster role binding")
 		}
@@ -29,7 +29,7 @@ func TestConnectCluster(t *testing.T) {
 	options.KubeContext = "invalidContext"
 
 	err = provider.ConnectCluster(options)
-	assert.Error(t, err, "new kubectl client: invalid configuration: [context was not found for specified context: invalidContext, cluster has no server defined]", "Wrong or no error when connecting cluster with invalid context")
+	assert.Error(t, err, "new kubectl client: invalid configuration: [context was not found for specified context: invalidContext, cluster has no server defined]\n\nUnable to load kube context invalidContext from your kube config. List the available contexts with `kubectl config get-contexts` and select one with `devspace use context`", "Wrong or no error when connecting cluster with invalid context")
 }
 
 func TestDefaultClusterSpaceDomain(t *testing.T) {
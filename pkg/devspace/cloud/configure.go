@@ -2,13 +2,19 @@ package cloud
 
 import (
 	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
 	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/cloud/config"
 	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clientauthv1beta1 "k8s.io/client-go/pkg/apis/clientauthentication/v1beta1"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
@@ -80,6 +86,49 @@ func UpdateKubeConfig(contextName string, serviceAccount *ServiceAccount, setAct
 	if err != nil {
 		return err
 	}
+
+	authInfo := api.NewAuthInfo()
+	authInfo.Token = serviceAccount.Token
+
+	err = addSpaceContext(config, contextName, serviceAccount, authInfo)
+	if err != nil {
+		return err
+	}
+
+	if setActive {
+		config.CurrentContext = contextName
+	}
+
+	return kubeconfig.SaveConfig(config)
+}
+
+// GetSpaceKubeConfig returns a standalone kube config that only contains the context of the given space. Unless
+// embedToken is true, the credentials are not stored in the kube config but retrieved with the exec plugin
+// "devspace export token", which uses the access key from DEVSPACE_ACCESS_KEY or the local provider config
+func GetSpaceKubeConfig(contextName string, space *Space, serviceAccount *ServiceAccount, embedToken bool) (*api.Config, error) {
+	config := api.NewConfig()
+
+	authInfo := api.NewAuthInfo()
+	if embedToken {
+		authInfo.Token = serviceAccount.Token
+	} else {
+		authInfo.Exec = &api.ExecConfig{
+			APIVersion: ExecCredentialAPIVersion,
+			Command:    "devspace",
+			Args:       []string{"export", "token", "--provider", space.ProviderName, "--space-id", strconv.Itoa(space.SpaceID)},
+		}
+	}
+
+	err := addSpaceContext(config, contextName, serviceAccount, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	config.CurrentContext = contextName
+	return config, nil
+}
+
+func addSpaceContext(config *api.Config, contextName string, serviceAccount *ServiceAccount, authInfo *api.AuthInfo) error {
 	caCert, err := base64.StdEncoding.DecodeString(serviceAccount.CaCert)
 	if err != nil {
 		return err
@@ -89,9 +138,6 @@ func UpdateKubeConfig(contextName string, serviceAccount *ServiceAccount, setAct
 	cluster.Server = serviceAccount.Server
 	cluster.CertificateAuthorityData = caCert
 
-	authInfo := api.NewAuthInfo()
-	authInfo.Token = serviceAccount.Token
-
 	config.Clusters[contextName] = cluster
 	config.AuthInfos[contextName] = authInfo
 
@@ -102,10 +148,55 @@ func UpdateKubeConfig(contextName string, serviceAccount *ServiceAccount, setAct
 	context.Namespace = serviceAccount.Namespace
 
 	config.Contexts[contextName] = context
+	return nil
+}
 
-	if setActive {
-		config.CurrentContext = contextName
+// ExecCredentialAPIVersion is the api version of the credentials that "devspace export token" prints
+const ExecCredentialAPIVersion = "client.authentication.k8s.io/v1beta1"
+
+// AccessKeyEnv is the environment variable that holds the access key "devspace export token" uses instead of
+// the key from the provider config, so machines such as CI runners do not have to login
+const AccessKeyEnv = "DEVSPACE_ACCESS_KEY"
+
+// GetExecCredential returns the exec credential that authenticates kubectl for the given space
+func GetExecCredential(serviceAccount *ServiceAccount) ([]byte, error) {
+	credential := &clientauthv1beta1.ExecCredential{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: ExecCredentialAPIVersion,
+			Kind:       "ExecCredential",
+		},
+		Status: &clientauthv1beta1.ExecCredentialStatus{
+			Token: serviceAccount.Token,
+		},
 	}
 
-	return kubeconfig.SaveConfig(config)
+	return json.Marshal(credential)
+}
+
+// GetProviderWithAccessKey returns the cloud provider without starting the login flow. The access key is taken from
+// the DEVSPACE_ACCESS_KEY environment variable or the provider config
+func GetProviderWithAccessKey(providerName string) (*Provider, error) {
+	providerConfig, err := config.ParseProviderConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	p := config.GetProvider(providerConfig, providerName)
+	if p == nil {
+		return nil, fmt.Errorf("Cloud provider %s not found! Did you run `devspace add provider [url]`?", providerName)
+	}
+
+	provider := &Provider{*p}
+	if accessKey := os.Getenv(AccessKeyEnv); accessKey != "" && accessKey != provider.Key {
+		provider.Key = accessKey
+		provider.Token = ""
+	}
+	if provider.Key == "" {
+		return nil, fmt.Errorf("Not logged into %s. Please run `devspace login` or set the %s environment variable", providerName, AccessKeyEnv)
+	}
+	if provider.ClusterKey == nil {
+		provider.ClusterKey = make(map[int]string)
+	}
+
+	return provider, nil
 }
@@ -3,6 +3,7 @@ package cloud
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
@@ -74,3 +75,31 @@ func TestUpdateKubeConfig(t *testing.T) {
 	assert.Equal(t, len(config.Clusters["someContext"].CertificateAuthorityData), 0, "KubeConfig badly saved")
 	assert.Equal(t, config.AuthInfos["someContext"].Token, "someToken", "KubeConfig badly saved")
 }
+
+func TestGetSpaceKubeConfig(t *testing.T) {
+	space := &Space{SpaceID: 12, Name: "my-space", ProviderName: "app.devspace.cloud"}
+	serviceAccount := &ServiceAccount{
+		Namespace: "someNamespace",
+		Server:    "someServer",
+		Token:     "someToken",
+	}
+
+	config, err := GetSpaceKubeConfig("someContext", space, serviceAccount, false)
+	assert.NilError(t, err, "Error creating space kube config")
+	assert.Equal(t, config.CurrentContext, "someContext", "Wrong current context")
+	assert.Equal(t, config.Contexts["someContext"].Namespace, "someNamespace", "Wrong namespace")
+	assert.Equal(t, config.Clusters["someContext"].Server, "someServer", "Wrong server")
+	assert.Equal(t, config.AuthInfos["someContext"].Token, "", "Token embedded in kube config with exec auth")
+	assert.Equal(t, strings.Join(config.AuthInfos["someContext"].Exec.Args, " "), "export token --provider app.devspace.cloud --space-id 12", "Wrong exec plugin args")
+
+	config, err = GetSpaceKubeConfig("someContext", space, serviceAccount, true)
+	assert.NilError(t, err, "Error creating space kube config")
+	assert.Equal(t, config.AuthInfos["someContext"].Token, "someToken", "Token not embedded in kube config")
+	assert.Assert(t, config.AuthInfos["someContext"].Exec == nil, "Exec plugin used with embedded token")
+}
+
+func TestGetExecCredential(t *testing.T) {
+	credential, err := GetExecCredential(&ServiceAccount{Token: "someToken"})
+	assert.NilError(t, err, "Error creating exec credential")
+	assert.Equal(t, string(credential), `{"kind":"ExecCredential","apiVersion":"client.authentication.k8s.io/v1beta1","spec":{},"status":{"token":"someToken"}}`, "Wrong exec credential")
+}
@@ -81,17 +81,51 @@ func ResumeSpace(config *latest.Config, generatedConfig *generated.Config, loop
 	}
 
 	if loop {
-		go func() {
-			for {
-				time.Sleep(time.Minute * 3)
-				p.ResumeSpace(space.SpaceID, space.Cluster)
-			}
-		}()
+		go keepAlive(space.Name, func() (bool, error) {
+			return p.ResumeSpace(space.SpaceID, space.Cluster)
+		}, KeepAliveInterval, nil, log)
 	}
 
 	return nil
 }
 
+// KeepAliveInterval is the interval in which the activity on the space is signaled while a command is running
+var KeepAliveInterval = time.Minute * 3
+
+// keepAlive signals the activity on the space in the given interval until stop is closed. It reports if the space
+// was paused in the meantime and had to be resumed and if the activity could not be signaled
+func keepAlive(spaceName string, resume func() (bool, error), interval time.Duration, stop <-chan struct{}, log log.Logger) {
+	failing := false
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		resumed, err := resume()
+		if err != nil {
+			if failing == false {
+				log.Warnf("Unable to signal activity for space %s, the space might be paused due to inactivity: %v", spaceName, err)
+				failing = true
+			}
+
+			continue
+		}
+
+		if failing {
+			log.Infof("Signaling activity for space %s works again", spaceName)
+			failing = false
+		}
+		if resumed {
+			log.Warnf("Space %s was paused and is resuming now. Pods are restarted, so the sync and port forwarding might need a restart", spaceName)
+		}
+	}
+}
+
 // ResumeSpace resumes a space if its sleeping and sets the last activity to the current timestamp
 func (p *Provider) ResumeSpace(spaceID int, cluster *Cluster) (bool, error) {
 	key, err := p.GetClusterKey(cluster)
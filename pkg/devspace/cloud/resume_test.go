@@ -0,0 +1,84 @@
+package cloud
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
+)
+
+type syncBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (s *syncBuffer) Write(data []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buffer.Write(data)
+}
+
+func (s *syncBuffer) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buffer.String()
+}
+
+func TestKeepAlive(t *testing.T) {
+	// The space fails twice, is signaled again and was paused in the meantime
+	results := []struct {
+		resumed bool
+		err     error
+	}{
+		{false, nil},
+		{false, errors.New("connection refused")},
+		{false, errors.New("connection refused")},
+		{true, nil},
+	}
+
+	calls := 0
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	output := &syncBuffer{}
+
+	go func() {
+		keepAlive("my-space", func() (bool, error) {
+			if calls >= len(results) {
+				return false, nil
+			}
+
+			result := results[calls]
+			calls++
+			if calls == len(results) {
+				defer close(stop)
+			}
+
+			return result.resumed, result.err
+		}, time.Millisecond, stop, log.NewStreamLogger(output, logrus.InfoLevel))
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("keepAlive did not stop")
+	}
+
+	logs := output.String()
+	if strings.Count(logs, "Unable to signal activity for space my-space") != 1 {
+		t.Fatalf("Expected the failure to be reported once, got: %s", logs)
+	}
+	if strings.Contains(logs, "Signaling activity for space my-space works again") == false {
+		t.Fatalf("Expected the recovery to be reported, got: %s", logs)
+	}
+	if strings.Contains(logs, "Space my-space was paused and is resuming now") == false {
+		t.Fatalf("Expected the resume to be reported, got: %s", logs)
+	}
+}
@@ -110,6 +110,7 @@ func (p *Provider) PrintSpaces(cluster, name string, all bool) error {
 		log.PrintTable(log.GetInstance(), headerColumnNames, values)
 	} else {
 		log.Info("No spaces found")
+		log.PrintNoEntries()
 	}
 
 	return nil
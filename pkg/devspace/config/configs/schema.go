@@ -30,4 +30,5 @@ type Variable struct {
 	Question          *string   `yaml:"question,omitempty"`
 	ValidationPattern *string   `yaml:"validationPattern,omitempty"`
 	ValidationMessage *string   `yaml:"validationMessage,omitempty"`
+	Source            *string   `yaml:"source,omitempty"`
 }
@@ -38,7 +38,7 @@ func loadVarsFromWrapper(basePath string, varsWrapper *configs.VarsWrapper) ([]*
 	return returnVars, nil
 }
 
-func loadConfigFromWrapper(basePath string, configWrapper *configs.ConfigWrapper) (*latest.Config, error) {
+func loadConfigFromWrapper(basePath string, configWrapper *configs.ConfigWrapper, resolver *varResolver) (*latest.Config, error) {
 	if configWrapper.Path == nil && configWrapper.Data == nil {
 		return nil, fmt.Errorf("path & data key are empty for config %s", LoadedConfig)
 	}
@@ -52,12 +52,12 @@ func loadConfigFromWrapper(basePath string, configWrapper *configs.ConfigWrapper
 
 	// Load from path
 	if configWrapper.Path != nil {
-		returnConfig, err = loadConfigFromPath(filepath.Join(basePath, filepath.FromSlash(*configWrapper.Path)))
+		returnConfig, err = loadConfigFromPath(filepath.Join(basePath, filepath.FromSlash(*configWrapper.Path)), resolver)
 		if err != nil {
 			return nil, fmt.Errorf("Loading config: %v", err)
 		}
 	} else {
-		returnConfig, err = loadConfigFromInterface(configWrapper.Data)
+		returnConfig, err = loadConfigFromInterface(configWrapper.Data, resolver)
 		if err != nil {
 			return nil, fmt.Errorf("Loading config from interface: %v", err)
 		}
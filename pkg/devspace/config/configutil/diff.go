@@ -0,0 +1,118 @@
+package configutil
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ConfigDiff describes which parts of a config changed between two versions of it
+type ConfigDiff struct {
+	// Images contains the names of the images that were added or changed
+	Images []string
+
+	// Deployments contains the names of the deployments that were added or changed
+	Deployments []string
+
+	// RemovedDeployments contains the names of the deployments that were removed
+	RemovedDeployments []string
+
+	// Dev is true if the dev section changed, which only requires the services to be restarted
+	Dev bool
+
+	// Other is true if any other part of the config changed (e.g. dependencies, hooks or the cluster), which
+	// requires the whole pipeline to run again
+	Other bool
+}
+
+// Empty returns true if the configs are equal
+func (c *ConfigDiff) Empty() bool {
+	return len(c.Images) == 0 && len(c.Deployments) == 0 && len(c.RemovedDeployments) == 0 && c.Dev == false && c.Other == false
+}
+
+// Diff compares the old and the new config
+func Diff(oldConfig, newConfig *latest.Config) *ConfigDiff {
+	diff := &ConfigDiff{
+		Images:             []string{},
+		Deployments:        []string{},
+		RemovedDeployments: []string{},
+		Dev:                equal(oldConfig.Dev, newConfig.Dev) == false,
+	}
+
+	// Images
+	oldImages := map[string]*latest.ImageConfig{}
+	if oldConfig.Images != nil {
+		oldImages = *oldConfig.Images
+	}
+	if newConfig.Images != nil {
+		for imageName, imageConfig := range *newConfig.Images {
+			if equal(oldImages[imageName], imageConfig) == false {
+				diff.Images = append(diff.Images, imageName)
+			}
+		}
+	}
+	sort.Strings(diff.Images)
+
+	// Deployments
+	oldDeployments := map[string]*latest.DeploymentConfig{}
+	if oldConfig.Deployments != nil {
+		for _, deployConfig := range *oldConfig.Deployments {
+			oldDeployments[*deployConfig.Name] = deployConfig
+		}
+	}
+	if newConfig.Deployments != nil {
+		for _, deployConfig := range *newConfig.Deployments {
+			if equal(oldDeployments[*deployConfig.Name], deployConfig) == false {
+				diff.Deployments = append(diff.Deployments, *deployConfig.Name)
+			}
+
+			delete(oldDeployments, *deployConfig.Name)
+		}
+	}
+	for deploymentName := range oldDeployments {
+		diff.RemovedDeployments = append(diff.RemovedDeployments, deploymentName)
+	}
+	sort.Strings(diff.RemovedDeployments)
+
+	// Removed images and all other sections require the whole pipeline to run again
+	oldRest, newRest := *oldConfig, *newConfig
+	oldRest.Images, newRest.Images = nil, nil
+	oldRest.Deployments, newRest.Deployments = nil, nil
+	oldRest.Dev, newRest.Dev = nil, nil
+	diff.Other = equal(&oldRest, &newRest) == false
+	for imageName := range oldImages {
+		if newConfig.Images == nil {
+			diff.Other = true
+		} else if _, ok := (*newConfig.Images)[imageName]; ok == false {
+			diff.Other = true
+		}
+	}
+
+	return diff
+}
+
+// CopyConfig returns a deep copy of the config. Configs should be copied right after loading before they are diffed,
+// because deploying a config fills in defaults (e.g. the options of component deployments)
+func CopyConfig(config *latest.Config) (*latest.Config, error) {
+	copied := &latest.Config{}
+	err := util.Convert(config, copied)
+	if err != nil {
+		return nil, err
+	}
+
+	return copied, nil
+}
+
+// equal compares two config objects by their yaml representation
+func equal(a, b interface{}) bool {
+	aYaml, errA := yaml.Marshal(a)
+	bYaml, errB := yaml.Marshal(b)
+	if errA != nil || errB != nil {
+		return reflect.DeepEqual(a, b)
+	}
+
+	return string(aYaml) == string(bYaml)
+}
@@ -0,0 +1,100 @@
+package configutil
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+)
+
+func newDiffTestConfig() *latest.Config {
+	return &latest.Config{
+		Version: ptr.String(latest.Version),
+		Images: &map[string]*latest.ImageConfig{
+			"default": &latest.ImageConfig{Image: ptr.String("myimage")},
+			"other":   &latest.ImageConfig{Image: ptr.String("otherimage")},
+		},
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{Name: ptr.String("app")},
+			&latest.DeploymentConfig{Name: ptr.String("db")},
+		},
+		Dev: &latest.DevConfig{},
+	}
+}
+
+func TestDiff(t *testing.T) {
+	if diff := Diff(newDiffTestConfig(), newDiffTestConfig()); diff.Empty() == false {
+		t.Fatalf("Expected empty diff, got %#v", diff)
+	}
+
+	// Changed image and deployment
+	newConfig := newDiffTestConfig()
+	(*newConfig.Images)["other"].Tag = ptr.String("latest")
+	(*newConfig.Deployments)[1].Namespace = ptr.String("db")
+	diff := Diff(newDiffTestConfig(), newConfig)
+	if reflect.DeepEqual(diff.Images, []string{"other"}) == false || reflect.DeepEqual(diff.Deployments, []string{"db"}) == false || diff.Dev || diff.Other {
+		t.Fatalf("Unexpected diff %#v", diff)
+	}
+
+	// Removed deployment and changed dev config
+	newConfig = newDiffTestConfig()
+	newConfig.Deployments = &[]*latest.DeploymentConfig{(*newConfig.Deployments)[0]}
+	newConfig.Dev.Terminal = &latest.Terminal{Disabled: ptr.Bool(true)}
+	diff = Diff(newDiffTestConfig(), newConfig)
+	if reflect.DeepEqual(diff.RemovedDeployments, []string{"db"}) == false || len(diff.Deployments) != 0 || diff.Dev == false || diff.Other {
+		t.Fatalf("Unexpected diff %#v", diff)
+	}
+
+	// Removed image and changed cluster config
+	for _, change := range []func(config *latest.Config){
+		func(config *latest.Config) { delete(*config.Images, "other") },
+		func(config *latest.Config) { config.Cluster = &latest.Cluster{Namespace: ptr.String("test")} },
+	} {
+		newConfig = newDiffTestConfig()
+		change(newConfig)
+		if diff = Diff(newDiffTestConfig(), newConfig); diff.Other == false {
+			t.Fatalf("Expected diff to require a full reload, got %#v", diff)
+		}
+	}
+}
+
+func TestDiffComponentDeployment(t *testing.T) {
+	newComponentConfig := func() *latest.Config {
+		config := newDiffTestConfig()
+		config.Deployments = &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name: ptr.String("app"),
+				Component: &latest.ComponentConfig{
+					Containers: &[]*latest.ContainerConfig{
+						&latest.ContainerConfig{Image: ptr.String("myimage")},
+					},
+				},
+			},
+		}
+
+		return config
+	}
+
+	config := newComponentConfig()
+	watchedConfig, err := CopyConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Deploying a component fills in its options (see component.New)
+	(*config.Deployments)[0].Component.Options = &latest.ComponentConfigOptions{}
+
+	// A changed dev config must not redeploy the component
+	newConfig := newComponentConfig()
+	newConfig.Dev.Terminal = &latest.Terminal{Disabled: ptr.Bool(true)}
+	diff := Diff(watchedConfig, newConfig)
+	if len(diff.Deployments) != 0 || diff.Dev == false || diff.Other {
+		t.Fatalf("Unexpected diff %#v", diff)
+	}
+
+	// The deployed config itself differs from the new config
+	if diff = Diff(config, newConfig); reflect.DeepEqual(diff.Deployments, []string{"app"}) == false {
+		t.Fatalf("Expected the modified component to differ, got %#v", diff)
+	}
+}
@@ -0,0 +1,84 @@
+package configutil
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EnvFiles are the files in the project root config variables are loaded from. Variables of later files take
+// precedence over the ones of earlier files
+var EnvFiles = []string{".env", "devspace.env"}
+
+// loadEnvFiles loads the variables of all env files in basePath
+func loadEnvFiles(basePath string) (map[string]string, error) {
+	vars := map[string]string{}
+	for _, envFile := range EnvFiles {
+		content, err := ioutil.ReadFile(filepath.Join(basePath, envFile))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+
+			return nil, errors.Wrapf(err, "read %s", envFile)
+		}
+
+		fileVars, err := parseEnvFile(content)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s", envFile)
+		}
+
+		for name, value := range fileVars {
+			vars[name] = value
+		}
+	}
+
+	return vars, nil
+}
+
+// parseEnvFile parses lines in the format NAME=value. Empty lines, comments starting with # and an export prefix
+// are ignored, values can be quoted
+func parseEnvFile(content []byte) (map[string]string, error) {
+	vars := map[string]string{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+		splitted := strings.SplitN(line, "=", 2)
+		name := strings.TrimSpace(splitted[0])
+		if len(splitted) != 2 || name == "" {
+			return nil, fmt.Errorf("line %d: expected format NAME=value", lineNumber)
+		}
+
+		value := strings.TrimSpace(splitted[1])
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			unquoted, err := strconv.Unquote(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+
+			value = unquoted
+		} else if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+			value = value[1 : len(value)-1]
+		}
+
+		vars[name] = value
+	}
+
+	return vars, scanner.Err()
+}
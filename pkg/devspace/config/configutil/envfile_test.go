@@ -0,0 +1,54 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	vars, err := parseEnvFile([]byte(`
+# Comment
+IMAGE=mysql:5.7
+export REPLICAS = 2
+QUOTED="line1\nline2"
+SINGLE='${NOT_RESOLVED}'
+URL=http://host?a=b
+EMPTY=
+`))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, vars, map[string]string{
+		"IMAGE":    "mysql:5.7",
+		"REPLICAS": "2",
+		"QUOTED":   "line1\nline2",
+		"SINGLE":   "${NOT_RESOLVED}",
+		"URL":      "http://host?a=b",
+		"EMPTY":    "",
+	})
+
+	_, err = parseEnvFile([]byte("IMAGE=mysql\nINVALID\n"))
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestLoadEnvFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testEnvFiles")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	vars, err := loadEnvFiles(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, len(vars), 0)
+
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("IMAGE=mysql\nREPLICAS=1\n"), 0644))
+	assert.NilError(t, ioutil.WriteFile(filepath.Join(dir, "devspace.env"), []byte("REPLICAS=2\n"), 0644))
+
+	vars, err = loadEnvFiles(dir)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, vars, map[string]string{
+		"IMAGE":    "mysql",
+		"REPLICAS": "2",
+	})
+}
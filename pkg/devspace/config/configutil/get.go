@@ -5,23 +5,29 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/docker/distribution/reference"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
 
 	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
 
 	configspkg "github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
+	"github.com/devspace-cloud/devspace/pkg/devspace/secrets"
 	"github.com/mgutz/ansi"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // ConfigInterface defines the pattern of every config
@@ -31,49 +37,19 @@ type ConfigInterface interface{}
 var LoadedConfig string
 
 // Global config vars
-var config *latest.Config // merged config
+var config *latest.Config      // merged config
+var configLoader *ConfigLoader // loader of the merged config
 
 // Thread-safety helper
 var getConfigOnce sync.Once
 var validateOnce sync.Once
 
+// signalRegEx matches signal names like HUP, SIGUSR2 or sigterm and signal numbers
+var signalRegEx = regexp.MustCompile(`^(?i)(SIG)?[A-Z0-9]+$`)
+
 // ConfigExists checks whether the yaml file for the config exists or the configs.yaml exists
 func ConfigExists() bool {
-	return configExistsInPath(".")
-}
-
-// configExistsInPath checks wheter a devspace configuration exists at a certain path
-func configExistsInPath(path string) bool {
-	// Needed for testing
-	if config != nil {
-		return true
-	}
-
-	// Check devspace.yaml
-	_, err := os.Stat(filepath.Join(path, constants.DefaultConfigPath))
-	if err == nil {
-		return true
-	}
-
-	// Check devspace-configs.yaml
-	_, err = os.Stat(filepath.Join(path, constants.DefaultConfigsPath))
-	if err == nil {
-		return true
-	}
-
-	// Check old .devspace/config.yaml
-	_, err = os.Stat(filepath.Join(path, ".devspace", "config.yaml"))
-	if err == nil {
-		return true
-	}
-
-	// Check old .devspace/configs.yaml
-	_, err = os.Stat(filepath.Join(path, ".devspace", "configs.yaml"))
-	if err == nil {
-		return true
-	}
-
-	return false // Normal config file found
+	return configFileExistsInPath(".")
 }
 
 // InitConfig initializes the config objects
@@ -86,6 +62,8 @@ func InitConfig() *latest.Config {
 }
 
 // GetBaseConfig returns the config unmerged with potential overwrites
+//
+// Deprecated: Use NewConfigLoader(".", &ConfigOptions{BaseConfig: true}).Load() instead
 func GetBaseConfig() *latest.Config {
 	GetConfigWithoutDefaults(false)
 	ValidateOnce()
@@ -94,6 +72,8 @@ func GetBaseConfig() *latest.Config {
 }
 
 // GetConfig returns the config merged with all potential overwrite files
+//
+// Deprecated: Use NewConfigLoader(".", nil).Load() instead
 func GetConfig() *latest.Config {
 	GetConfigWithoutDefaults(true)
 	ValidateOnce()
@@ -101,7 +81,7 @@ func GetConfig() *latest.Config {
 	return config
 }
 
-func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites bool, generatedConfig *generated.Config, log log.Logger) (*latest.Config, *configspkg.ConfigDefinition, error) {
+func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites bool, generatedConfig *generated.Config, resolver *varResolver, log log.Logger) (*latest.Config, *configspkg.ConfigDefinition, error) {
 	var (
 		config           = latest.New().(*latest.Config)
 		configRaw        = latest.New().(*latest.Config)
@@ -166,14 +146,14 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 				}
 			}
 
-			err = askQuestions(generatedConfig.GetActive(), vars)
+			err = resolver.askQuestions(generatedConfig.GetActive(), vars)
 			if err != nil {
 				return nil, nil, fmt.Errorf("Error filling vars: %v", err)
 			}
 		}
 
 		// Load config
-		configRaw, err = loadConfigFromWrapper(basePath, configDefinition.Config)
+		configRaw, err = loadConfigFromWrapper(basePath, configDefinition.Config, resolver)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -192,13 +172,13 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 			}
 
 			// Ask questions
-			err = askQuestions(generatedConfig.GetActive(), vars)
+			err = resolver.askQuestions(generatedConfig.GetActive(), vars)
 			if err != nil {
 				return nil, nil, fmt.Errorf("Error filling vars: %v", err)
 			}
 		}
 
-		configRaw, err = loadConfigFromPath(configPath)
+		configRaw, err = loadConfigFromPath(configPath, resolver)
 		if err != nil {
 			return nil, nil, fmt.Errorf("Loading config: %v", err)
 		}
@@ -211,7 +191,7 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 		if configDefinition != nil {
 			if configDefinition.Overrides != nil {
 				for index, configWrapper := range *configDefinition.Overrides {
-					overwriteConfig, err := loadConfigFromWrapper(".", configWrapper)
+					overwriteConfig, err := loadConfigFromWrapper(basePath, configWrapper, resolver)
 					if err != nil {
 						return nil, nil, fmt.Errorf("Error loading override config at index %d: %v", index, err)
 					}
@@ -230,7 +210,17 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 		// Exchange kube context if necessary, but only if we don't load the base config
 		// we do this to avoid saving the kube context on commands like
 		// devspace add deployment && devspace add image etc.
-		if generatedConfig.CloudSpace != nil {
+		if generatedConfig.KubeContext != "" {
+			if config.Cluster == nil {
+				config.Cluster = &latest.Cluster{}
+			}
+			if config.Cluster.KubeContext == nil {
+				config.Cluster.KubeContext = &generatedConfig.KubeContext
+			}
+			if config.Cluster.Namespace == nil && generatedConfig.Namespace != "" {
+				config.Cluster.Namespace = &generatedConfig.Namespace
+			}
+		} else if generatedConfig.CloudSpace != nil {
 			if config.Cluster == nil || config.Cluster.KubeContext == nil {
 				if generatedConfig.CloudSpace.KubeContext == "" {
 					return nil, nil, fmt.Errorf("No space configured!\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b"))
@@ -253,53 +243,36 @@ func loadBaseConfigFromPath(basePath string, loadConfig string, loadOverwrites b
 }
 
 // GetConfigFromPath loads the config from a given base path
+//
+// Deprecated: Use NewConfigLoader(basePath, &ConfigOptions{ConfigName: loadConfig, GeneratedConfig: generatedConfig}).Load() instead
 func GetConfigFromPath(basePath string, loadConfig string, loadOverrides bool, generatedConfig *generated.Config, log log.Logger) (*latest.Config, error) {
-	config, _, err := loadBaseConfigFromPath(basePath, loadConfig, loadOverrides, generatedConfig, log)
-	if err != nil {
-		return nil, err
-	}
-
-	err = validate(config)
-	if err != nil {
-		return nil, fmt.Errorf("Error validating config in %s: %v", basePath, err)
-	}
-
-	return config, nil
+	config, _, err := NewConfigLoader(basePath, &ConfigOptions{
+		ConfigName:      loadConfig,
+		BaseConfig:      loadOverrides == false,
+		GeneratedConfig: generatedConfig,
+		Log:             log,
+	}).Load()
+	return config, err
 }
 
 // GetConfigWithoutDefaults returns the config without setting the default values
+//
+// Deprecated: Use NewConfigLoader(".", &ConfigOptions{SkipValidation: true}).Load() instead
 func GetConfigWithoutDefaults(loadOverwrites bool) *latest.Config {
 	getConfigOnce.Do(func() {
-		var (
-			err              error
-			configDefinition *configspkg.ConfigDefinition
-		)
-
-		// Get generated config
-		generatedConfig, err := generated.LoadConfig()
-		if err != nil {
-			log.Panicf("Error loading %s: %v", generated.ConfigPath, err)
-		}
+		loader := NewConfigLoader(".", &ConfigOptions{
+			BaseConfig:     loadOverwrites == false,
+			SkipValidation: true,
+		})
 
-		// Get config to load
-		LoadedConfig = generatedConfig.ActiveConfig
-
-		// Load base config
-		config, configDefinition, err = loadBaseConfigFromPath(".", LoadedConfig, loadOverwrites, generatedConfig, log.GetInstance())
+		loadedConfig, _, err := loader.Load()
 		if err != nil {
 			log.Fatal(err)
 		}
 
-		// Reset loaded config if there was no configs.yaml
-		if configDefinition == nil {
-			LoadedConfig = ""
-		}
-
-		// Save generated config
-		err = generated.SaveConfig(generatedConfig)
-		if err != nil {
-			log.Fatalf("Couldn't save generated config: %v", err)
-		}
+		config = loadedConfig
+		configLoader = loader
+		LoadedConfig = loader.LoadedConfig()
 	})
 
 	return config
@@ -317,6 +290,10 @@ func ValidateOnce() {
 
 func validate(config *latest.Config) error {
 	if config.Dev != nil {
+		if config.Dev.ReadinessTimeout != nil && *config.Dev.ReadinessTimeout < 0 {
+			return errors.New("dev.readinessTimeout must not be negative")
+		}
+
 		if config.Dev.Selectors != nil {
 			for index, selectorConfig := range *config.Dev.Selectors {
 				if selectorConfig.Name == nil {
@@ -327,8 +304,11 @@ func validate(config *latest.Config) error {
 
 		if config.Dev.Ports != nil {
 			for index, port := range *config.Dev.Ports {
-				if port.Selector == nil && port.LabelSelector == nil {
-					return fmt.Errorf("Error in config: selector and label selector are nil in port config at index %d", index)
+				if port.Selector == nil && port.LabelSelector == nil && port.ImageName == nil {
+					return fmt.Errorf("Error in config: selector, label selector and image name are nil in port config at index %d", index)
+				}
+				if port.ImageName != nil && (config.Images == nil || (*config.Images)[*port.ImageName] == nil) {
+					return fmt.Errorf("Error in config: dev.ports[%d].imageName: image %s is not defined in images", index, *port.ImageName)
 				}
 				if port.PortMappings == nil {
 					return fmt.Errorf("Error in config: portMappings is empty in port config at index %d", index)
@@ -338,8 +318,38 @@ func validate(config *latest.Config) error {
 
 		if config.Dev.Sync != nil {
 			for index, sync := range *config.Dev.Sync {
-				if sync.Selector == nil && sync.LabelSelector == nil {
-					return fmt.Errorf("Error in config: selector and label selector are nil in sync config at index %d", index)
+				if sync.Selector == nil && sync.LabelSelector == nil && sync.ImageName == nil {
+					return fmt.Errorf("Error in config: selector, label selector and image name are nil in sync config at index %d", index)
+				}
+				if sync.ImageName != nil && (config.Images == nil || (*config.Images)[*sync.ImageName] == nil) {
+					return fmt.Errorf("Error in config: dev.sync[%d].imageName: image %s is not defined in images", index, *sync.ImageName)
+				}
+				if sync.Transport != nil && *sync.Transport != "exec" && *sync.Transport != "portforward" {
+					return fmt.Errorf("dev.sync[%d].transport must be either exec or portforward", index)
+				}
+				if sync.CompareBy != nil && *sync.CompareBy != "mtime" && *sync.CompareBy != "hash" {
+					return fmt.Errorf("dev.sync[%d].compareBy must be either mtime or hash", index)
+				}
+				if (sync.UID != nil && *sync.UID < 0) || (sync.GID != nil && *sync.GID < 0) {
+					return fmt.Errorf("dev.sync[%d].uid and dev.sync[%d].gid must not be negative", index, index)
+				}
+				if sync.GID != nil && sync.UID == nil {
+					return fmt.Errorf("dev.sync[%d].gid requires dev.sync[%d].uid", index, index)
+				}
+				if sync.OnUpload != nil && sync.OnUpload.RestartContainer != nil {
+					restartConfig := sync.OnUpload.RestartContainer
+					if restartConfig.Signal != nil && restartConfig.Command != nil {
+						return fmt.Errorf("dev.sync[%d].onUpload.restartContainer: signal and command cannot be used together", index)
+					}
+					if restartConfig.Signal != nil && signalRegEx.MatchString(*restartConfig.Signal) == false {
+						return fmt.Errorf("dev.sync[%d].onUpload.restartContainer.signal: invalid signal %s (e.g. HUP or USR2)", index, *restartConfig.Signal)
+					}
+				}
+				if sync.OnUpload != nil && sync.OnUpload.ExecRemote != nil && (sync.OnUpload.ExecRemote.Command == nil || *sync.OnUpload.ExecRemote.Command == "") {
+					return fmt.Errorf("dev.sync[%d].onUpload.execRemote.command is required", index)
+				}
+				if sync.OnDownload != nil && sync.OnDownload.ExecLocal != nil && (sync.OnDownload.ExecLocal.Command == nil || *sync.OnDownload.ExecLocal.Command == "") {
+					return fmt.Errorf("dev.sync[%d].onDownload.execLocal.command is required", index)
 				}
 			}
 		}
@@ -351,20 +361,151 @@ func validate(config *latest.Config) error {
 				}
 			}
 		}
+
+		if config.Dev.Terminals != nil {
+			if config.Dev.Terminal != nil {
+				return fmt.Errorf("dev.terminal and dev.terminals cannot be used together")
+			}
+
+			names := map[string]bool{}
+			for index, terminalConfig := range *config.Dev.Terminals {
+				if terminalConfig.Name != nil {
+					if names[*terminalConfig.Name] {
+						return fmt.Errorf("dev.terminals[%d]: duplicate terminal name %s", index, *terminalConfig.Name)
+					}
+
+					names[*terminalConfig.Name] = true
+				}
+			}
+			if len(*config.Dev.Terminals) > 9 {
+				return fmt.Errorf("dev.terminals: at most 9 terminals are supported")
+			}
+		}
+
+		if config.Dev.Debug != nil {
+			for index, debugConfig := range *config.Dev.Debug {
+				if debugConfig.Image == nil {
+					return fmt.Errorf("dev.debug[%d].image is required", index)
+				}
+				if debugConfig.Runtime == nil || (*debugConfig.Runtime != "go" && *debugConfig.Runtime != "node" && *debugConfig.Runtime != "java") {
+					return fmt.Errorf("dev.debug[%d].runtime must be either go, node or java", index)
+				}
+				if debugConfig.Command == nil || len(*debugConfig.Command) == 0 {
+					return fmt.Errorf("dev.debug[%d].command is required", index)
+				}
+			}
+		}
+	}
+
+	if config.RegistryAliases != nil {
+		for prefix, alias := range *config.RegistryAliases {
+			if strings.TrimSpace(prefix) == "" || strings.TrimSpace(alias) == "" {
+				return fmt.Errorf("registryAliases: prefix and alias must not be empty")
+			}
+		}
 	}
 
 	if config.Hooks != nil {
 		for index, hookConfig := range *config.Hooks {
-			if hookConfig.Command == nil {
+			if hookConfig.Command == nil && hookConfig.Commands == nil {
 				return fmt.Errorf("hooks[%d].command is required", index)
 			}
+			err := validateOSCommands(fmt.Sprintf("hooks[%d].commands", index), hookConfig.Commands)
+			if err != nil {
+				return err
+			}
+			if hookConfig.Events != nil {
+				for _, event := range *hookConfig.Events {
+					if validateHookEvent(*event) == false {
+						return fmt.Errorf("hooks[%d].events: unsupported event %s (supported: before:[command], after:[command] with command build, deploy, dev or purge)", index, *event)
+					}
+				}
+			}
+			if hookConfig.OS != nil {
+				for _, hookOS := range *hookConfig.OS {
+					if isSupportedOS(*hookOS) == false {
+						return fmt.Errorf("hooks[%d].os: unsupported os %s (supported: %s)", index, *hookOS, strings.Join(SupportedOS, ", "))
+					}
+				}
+			}
+		}
+	}
+
+	if config.Notifications != nil {
+		for index, notificationConfig := range *config.Notifications {
+			if notificationConfig.URL == nil || *notificationConfig.URL == "" {
+				return fmt.Errorf("notifications[%d].url is required", index)
+			}
+			if notificationConfig.Commands != nil {
+				for _, command := range *notificationConfig.Commands {
+					if *command != "deploy" && *command != "dev" && *command != "purge" {
+						return fmt.Errorf("notifications[%d].commands: unsupported command %s (supported: deploy, dev, purge)", index, *command)
+					}
+				}
+			}
+			if notificationConfig.On != nil {
+				for _, on := range *notificationConfig.On {
+					if *on != "success" && *on != "failure" {
+						return fmt.Errorf("notifications[%d].on: unsupported value %s (supported: success, failure)", index, *on)
+					}
+				}
+			}
 		}
 	}
 
 	if config.Images != nil {
 		for imageConfigName, imageConf := range *config.Images {
-			if imageConf.Build != nil && imageConf.Build.Custom != nil && imageConf.Build.Custom.Command == nil {
-				return fmt.Errorf("images.%s.build.custom.command is required", imageConfigName)
+			if imageConf.Build != nil && imageConf.Build.Custom != nil {
+				if imageConf.Build.Custom.Command == nil && imageConf.Build.Custom.Commands == nil {
+					return fmt.Errorf("images.%s.build.custom.command is required", imageConfigName)
+				}
+
+				err := validateOSCommands(fmt.Sprintf("images.%s.build.custom.commands", imageConfigName), imageConf.Build.Custom.Commands)
+				if err != nil {
+					return err
+				}
+			}
+			if imageConf.Build != nil && imageConf.Build.Pod != nil {
+				err := validatePodBuildConfig(imageConfigName, imageConf.Build.Pod)
+				if err != nil {
+					return err
+				}
+			}
+			if imageConf.Build != nil && imageConf.Build.Kaniko != nil {
+				err := validateKanikoConfig(imageConfigName, imageConf.Build.Kaniko)
+				if err != nil {
+					return err
+				}
+			}
+			if imageConf.Build != nil {
+				err := validateBuildKitOptions(imageConfigName, imageConf.Build)
+				if err != nil {
+					return err
+				}
+			}
+			if imageConf.Build != nil && imageConf.Build.Export != nil {
+				if imageConf.Build.Export.Dest == nil || *imageConf.Build.Export.Dest == "" {
+					return fmt.Errorf("images.%s.build.export.dest is required", imageConfigName)
+				}
+				if imageConf.Build.Export.Type != nil && *imageConf.Build.Export.Type != "docker" && *imageConf.Build.Export.Type != "oci" {
+					return fmt.Errorf("images.%s.build.export.type: unsupported type %s (supported: docker, oci)", imageConfigName, *imageConf.Build.Export.Type)
+				}
+				if imageConf.Build.Custom != nil || imageConf.Build.Kaniko != nil || imageConf.Build.Pod != nil {
+					return fmt.Errorf("images.%s.build.export can only be used with the docker builder", imageConfigName)
+				}
+			}
+			if imageConf.Tags != nil {
+				if imageConf.Tag != nil {
+					return fmt.Errorf("images.%s.tag and images.%s.tags cannot be used together", imageConfigName, imageConfigName)
+				}
+				if len(*imageConf.Tags) == 0 {
+					return fmt.Errorf("images.%s.tags must contain at least one tag", imageConfigName)
+				}
+				for index, tag := range *imageConf.Tags {
+					if tag == "" {
+						return fmt.Errorf("images.%s.tags[%d] is empty", imageConfigName, index)
+					}
+				}
 			}
 		}
 	}
@@ -380,8 +521,141 @@ func validate(config *latest.Config) error {
 			if deployConfig.Helm != nil && (deployConfig.Helm.Chart == nil || deployConfig.Helm.Chart.Name == nil) {
 				return fmt.Errorf("deployments[%d].helm.chart and deployments[%d].helm.chart.name is required", index, index)
 			}
-			if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil {
-				return fmt.Errorf("deployments[%d].kubectl.manifests is required", index)
+			if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil && deployConfig.Kubectl.InlineManifest == nil {
+				return fmt.Errorf("deployments[%d].kubectl.manifests or deployments[%d].kubectl.inlineManifest is required", index, index)
+			}
+			if deployConfig.Kubectl != nil && deployConfig.Kubectl.InlineManifest != nil && deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true {
+				return fmt.Errorf("deployments[%d].kubectl.inlineManifest cannot be used with kustomize", index)
+			}
+			if deployConfig.Env != nil {
+				if deployConfig.Kubectl != nil {
+					return fmt.Errorf("deployments[%d].env is only supported for component and helm deployments", index)
+				}
+
+				for envIndex, envVar := range *deployConfig.Env {
+					if envVar == nil {
+						return fmt.Errorf("deployments[%d].env[%d] is empty", index, envIndex)
+					}
+					if name, ok := (*envVar)["name"].(string); ok == false || name == "" {
+						return fmt.Errorf("deployments[%d].env[%d].name is required", index, envIndex)
+					}
+				}
+			}
+		}
+	}
+
+	if config.Dependencies != nil {
+		for index, dependency := range *config.Dependencies {
+			if dependency.Source == nil {
+				return fmt.Errorf("dependencies[%d].source is required", index)
+			}
+
+			sources := 0
+			for _, source := range []bool{dependency.Source.Git != nil, dependency.Source.Path != nil, dependency.Source.Chart != nil} {
+				if source {
+					sources++
+				}
+			}
+			if sources != 1 {
+				return fmt.Errorf("dependencies[%d].source: exactly one of git, path or chart is required", index)
+			}
+
+			if dependency.Source.Chart != nil {
+				if dependency.Source.Chart.Name == nil {
+					return fmt.Errorf("dependencies[%d].source.chart.name is required", index)
+				}
+				if dependency.Config != nil {
+					return fmt.Errorf("dependencies[%d].config cannot be used with a chart source", index)
+				}
+				if dependency.Vars != nil {
+					return fmt.Errorf("dependencies[%d].vars cannot be used with a chart source", index)
+				}
+			} else if dependency.Values != nil {
+				return fmt.Errorf("dependencies[%d].values can only be used with a chart source", index)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateHookEvent checks if the event has the format before:[command] or after:[command]
+func validateHookEvent(event string) bool {
+	splitted := strings.Split(strings.TrimSpace(event), ":")
+	if len(splitted) != 2 || (splitted[0] != "before" && splitted[0] != "after") {
+		return false
+	}
+
+	switch splitted[1] {
+	case "build", "deploy", "dev", "purge":
+		return true
+	}
+
+	return false
+}
+
+func validatePodBuildConfig(imageConfigName string, podConfig *latest.PodBuildConfig) error {
+	if podConfig.Tool != nil && *podConfig.Tool != "buildah" && *podConfig.Tool != "img" {
+		return fmt.Errorf("images.%s.build.pod.tool must be either buildah or img", imageConfigName)
+	}
+
+	return validatePodResources(fmt.Sprintf("images.%s.build.pod.resources", imageConfigName), podConfig.Resources)
+}
+
+func validateKanikoConfig(imageConfigName string, kanikoConfig *latest.KanikoConfig) error {
+	if kanikoConfig.CacheTTL != nil && *kanikoConfig.CacheTTL != "" {
+		if _, err := time.ParseDuration(*kanikoConfig.CacheTTL); err != nil {
+			return fmt.Errorf("images.%s.build.kaniko.cacheTTL must be a duration (e.g. 6h): %v", imageConfigName, err)
+		}
+	}
+	if kanikoConfig.CacheRepo != nil && *kanikoConfig.CacheRepo != "" {
+		if _, err := reference.ParseNormalizedNamed(*kanikoConfig.CacheRepo); err != nil {
+			return fmt.Errorf("images.%s.build.kaniko.cacheRepo is not a valid repository: %v", imageConfigName, err)
+		}
+	}
+
+	err := validatePodResources(fmt.Sprintf("images.%s.build.kaniko.resources", imageConfigName), kanikoConfig.Resources)
+	if err != nil {
+		return err
+	}
+
+	if kanikoConfig.Tolerations != nil {
+		for idx, toleration := range *kanikoConfig.Tolerations {
+			path := fmt.Sprintf("images.%s.build.kaniko.tolerations[%d]", imageConfigName, idx)
+			if toleration.Operator != nil && *toleration.Operator != "Exists" && *toleration.Operator != "Equal" {
+				return fmt.Errorf("%s.operator must be either Exists or Equal", path)
+			}
+			if toleration.Operator != nil && *toleration.Operator == "Exists" && toleration.Value != nil && *toleration.Value != "" {
+				return fmt.Errorf("%s.value must be empty if operator is Exists", path)
+			}
+			if toleration.Effect != nil && *toleration.Effect != "NoSchedule" && *toleration.Effect != "PreferNoSchedule" && *toleration.Effect != "NoExecute" {
+				return fmt.Errorf("%s.effect must be one of NoSchedule, PreferNoSchedule or NoExecute", path)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateBuildKitOptions checks that ssh and secret mounts are only used with the docker builder
+func validateBuildKitOptions(imageConfigName string, buildConfig *latest.BuildConfig) error {
+	if buildConfig.Kaniko != nil && buildConfig.Kaniko.Options != nil && (buildConfig.Kaniko.Options.SSH != nil || buildConfig.Kaniko.Options.Secrets != nil) {
+		return fmt.Errorf("images.%s.build.kaniko.options: ssh and secrets are only supported by the docker builder", imageConfigName)
+	}
+	if buildConfig.Pod != nil && buildConfig.Pod.Options != nil && (buildConfig.Pod.Options.SSH != nil || buildConfig.Pod.Options.Secrets != nil) {
+		return fmt.Errorf("images.%s.build.pod.options: ssh and secrets are only supported by the docker builder", imageConfigName)
+	}
+
+	if buildConfig.Docker != nil && buildConfig.Docker.Options != nil && buildConfig.Docker.Options.Secrets != nil {
+		for idx, secret := range *buildConfig.Docker.Options.Secrets {
+			hasID := false
+			for _, field := range strings.Split(secret, ",") {
+				if strings.HasPrefix(field, "id=") && len(field) > len("id=") {
+					hasID = true
+				}
+			}
+			if hasID == false {
+				return fmt.Errorf("images.%s.build.docker.options.secrets[%d] requires an id, e.g. id=mysecret,src=path/to/secret", imageConfigName, idx)
 			}
 		}
 	}
@@ -389,16 +663,65 @@ func validate(config *latest.Config) error {
 	return nil
 }
 
-func askQuestions(cache *generated.CacheConfig, vars []*configspkg.Variable) error {
+func validatePodResources(path string, podResources *latest.PodResources) error {
+	if podResources == nil {
+		return nil
+	}
+
+	for _, resources := range []*map[string]*string{podResources.Requests, podResources.Limits} {
+		if resources == nil {
+			continue
+		}
+
+		for name, quantity := range *resources {
+			if quantity == nil {
+				return fmt.Errorf("%s: quantity of %s is empty", path, name)
+			}
+
+			_, err := resource.ParseQuantity(*quantity)
+			if err != nil {
+				return fmt.Errorf("%s: invalid quantity %s for %s: %v", path, *quantity, name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *varResolver) askQuestions(cache *generated.CacheConfig, vars []*configspkg.Variable) error {
 	for idx, variable := range vars {
 		if variable.Name == nil {
 			return fmt.Errorf("Name required for variable with index %d", idx)
 		}
 
-		if os.Getenv(VarEnvPrefix+strings.ToUpper(*variable.Name)) != "" {
+		if _, ok := r.commandVars[*variable.Name]; ok {
+			continue
+		} else if os.Getenv(VarEnvPrefix+strings.ToUpper(*variable.Name)) != "" {
+			continue
+		} else if variable.Source != nil {
+			// Secrets are resolved when the config is loaded and never saved in the generated config
+			if secrets.IsReference(*variable.Source) == false {
+				return fmt.Errorf("Invalid source %s for variable %s, expected e.g. vault:kv/path#key, awsssm:/param or gcpsm:projects/[project]/secrets/[secret]", *variable.Source, *variable.Name)
+			}
+
+			r.secretVars[*variable.Name] = *variable.Source
+			continue
+		} else if _, ok := r.overrideVars[*variable.Name]; ok {
+			continue
+		} else if _, ok := r.fileVars[*variable.Name]; ok {
 			continue
 		} else if _, ok := cache.Vars[*variable.Name]; ok {
 			continue
+		} else if survey.IsNoInput() {
+			// Without input the default is used like an accepted question
+			if variable.Default != nil {
+				cache.Vars[*variable.Name] = *variable.Default
+				continue
+			}
+
+			// The missing variables are reported together with the ones found while loading the config
+			r.addMissingVar(*variable.Name)
+			continue
 		}
 
 		cache.Vars[*variable.Name] = AskQuestion(variable)
@@ -423,7 +746,7 @@ func SetDevSpaceRoot() (bool, error) {
 	lastLength := 0
 	for len(cwd) != lastLength {
 		if cwd != homedir {
-			configExists := configExistsInPath(cwd)
+			configExists := configFileExistsInPath(cwd)
 			if configExists {
 				// Change working directory
 				err = os.Chdir(cwd)
@@ -455,7 +778,7 @@ func SetDevSpaceRoot() (bool, error) {
 
 // GetSelector returns the service referenced by serviceName
 func GetSelector(config *latest.Config, selectorName string) (*latest.SelectorConfig, error) {
-	if config.Dev.Selectors != nil {
+	if config.Dev != nil && config.Dev.Selectors != nil {
 		for _, selector := range *config.Dev.Selectors {
 			if *selector.Name == selectorName {
 				return selector, nil
@@ -466,6 +789,36 @@ func GetSelector(config *latest.Config, selectorName string) (*latest.SelectorCo
 	return nil, errors.New("Unable to find selector: " + selectorName)
 }
 
+// SetCacheContext scopes the image and deployment caches of the generated config to the kube context and namespace
+// of the config. If there is no kube config, the caches are not scoped
+func SetCacheContext(config *latest.Config, generatedConfig *generated.Config) {
+	activeContext, err := GetKubeContext(config)
+	if err != nil {
+		return
+	}
+
+	namespace, err := GetDefaultNamespace(config)
+	if err != nil {
+		return
+	}
+
+	generatedConfig.SetCacheContext(activeContext, namespace)
+}
+
+// GetKubeContext returns the kube context of the config or the current kube context if the config has none
+func GetKubeContext(config *latest.Config) (string, error) {
+	if config != nil && config.Cluster != nil && config.Cluster.KubeContext != nil {
+		return *config.Cluster.KubeContext, nil
+	}
+
+	kubeConfig, err := kubeconfig.LoadRawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	return kubeConfig.CurrentContext, nil
+}
+
 // GetDefaultNamespace retrieves the default namespace where to operate in, either from devspace config or kube config
 func GetDefaultNamespace(config *latest.Config) (string, error) {
 	if config != nil && config.Cluster != nil && config.Cluster.Namespace != nil {
@@ -4,9 +4,11 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"testing"
 
+	configspkg "github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
@@ -22,10 +24,6 @@ import (
 )
 
 func TestConfigExists(t *testing.T) {
-	configBackup := config
-	SetFakeConfig(&latest.Config{})
-	defer func() { config = configBackup }()
-
 	//Create tempDir and go into it
 	dir, err := ioutil.TempDir("", "testDir")
 	if err != nil {
@@ -53,9 +51,8 @@ func TestConfigExists(t *testing.T) {
 		}
 	}()
 
-	assert.Equal(t, ConfigExists(), true, "Config doesn't exist despite being set directly in cache")
+	assert.Equal(t, ConfigExists(), false, "Config exists in empty directory")
 
-	config = nil
 	fsutil.WriteToFile([]byte(""), constants.DefaultConfigPath)
 	assert.Equal(t, ConfigExists(), true, "Config doesn't exist despite being set in devspace.yaml")
 
@@ -417,7 +414,13 @@ dev:
 	assert.Equal(t, *config.Cluster.Namespace, "someNS", "Initialized config has wrong namespace of cluster")
 	assert.Equal(t, len(*config.Images), 1, "Initialized config has wrong number of images")
 	assert.Equal(t, *(*config.Images)["default"].Image, "defaultImage", "Initialized config has wrong image")
-	
+
+	// The kube context of devspace use context is used if the config doesn't define one
+	fsutil.WriteToFile([]byte("version: v1beta2\n"), filepath.Join("OtherDir", constants.DefaultConfigPath))
+	config, err = GetConfigFromPath("OtherDir", "", true, &generated.Config{KubeContext: "projectContext", Namespace: "projectNS"}, &log.DiscardLogger{})
+	assert.NilError(t, err, "Error from function GetConfigFromPath")
+	assert.Equal(t, *config.Cluster.KubeContext, "projectContext", "Config has wrong kubeContext from generated config")
+	assert.Equal(t, *config.Cluster.Namespace, "projectNS", "Config has wrong namespace from generated config")
 }
 
 func TestSetSetDevspaceRoot(t *testing.T) {
@@ -598,6 +601,37 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("No error in config with nameless selector: %v", err)
 	}
 
+	err = validate(&latest.Config{
+		RegistryAliases: &map[string]string{"docker.io/myorg": ""},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with empty registry alias")
+	}
+
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Terminals: &[]*latest.Terminal{
+				&latest.Terminal{Name: ptr.String("api")},
+				&latest.Terminal{Name: ptr.String("api")},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with duplicate terminal names")
+	}
+
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Terminal: &latest.Terminal{},
+			Terminals: &[]*latest.Terminal{
+				&latest.Terminal{Name: ptr.String("api")},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with terminal and terminals")
+	}
+
 	err = validate(&latest.Config{
 		Dev: &latest.DevConfig{
 			Ports: &[]*latest.PortForwardingConfig{
@@ -633,6 +667,137 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("No error in config with invalid sync: %v", err)
 	}
 
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Sync: &[]*latest.SyncConfig{
+				&latest.SyncConfig{
+					Selector:  ptr.String("default"),
+					Transport: ptr.String("ssh"),
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid sync transport: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Sync: &[]*latest.SyncConfig{
+				&latest.SyncConfig{
+					Selector:  ptr.String("default"),
+					CompareBy: ptr.String("checksum"),
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid sync compareBy: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Sync: &[]*latest.SyncConfig{
+				&latest.SyncConfig{
+					Selector: ptr.String("default"),
+					GID:      ptr.Int64(1000),
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with sync gid without uid")
+	}
+
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Sync: &[]*latest.SyncConfig{
+				&latest.SyncConfig{
+					Selector: ptr.String("default"),
+					OnUpload: &latest.SyncOnUpload{
+						RestartContainer: &latest.RestartContainerConfig{
+							Signal:  ptr.String("HUP"),
+							Command: &[]string{"supervisorctl", "restart", "app"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with restart signal and command")
+	}
+
+	for signal, valid := range map[string]bool{"HUP": true, "SIGUSR2": true, "sigterm": true, "15": true, "USR 2": false, "-HUP": false} {
+		err = validate(&latest.Config{
+			Dev: &latest.DevConfig{
+				Sync: &[]*latest.SyncConfig{
+					&latest.SyncConfig{
+						Selector: ptr.String("default"),
+						OnUpload: &latest.SyncOnUpload{
+							RestartContainer: &latest.RestartContainerConfig{
+								Signal: ptr.String(signal),
+							},
+						},
+					},
+				},
+			},
+		})
+		if valid && err != nil {
+			t.Fatalf("Error in config with restart signal %s: %v", signal, err)
+		} else if valid == false && err == nil {
+			t.Fatalf("No error in config with invalid restart signal %s", signal)
+		}
+	}
+
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Sync: &[]*latest.SyncConfig{
+				&latest.SyncConfig{
+					Selector: ptr.String("default"),
+					OnDownload: &latest.SyncOnDownload{
+						ExecLocal: &latest.SyncExecConfig{},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with sync execLocal without command")
+	}
+
+	err = validate(&latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name: ptr.String("test"),
+				Kubectl: &latest.KubectlConfig{
+					Manifests: &[]*string{ptr.String("kube/*")},
+				},
+				Env: &[]*map[interface{}]interface{}{
+					&map[interface{}]interface{}{"name": "TEST", "value": "test"},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with env in kubectl deployment: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name:      ptr.String("test"),
+				Component: &latest.ComponentConfig{},
+				Env: &[]*map[interface{}]interface{}{
+					&map[interface{}]interface{}{"value": "test"},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with env var without name: %v", err)
+	}
+
 	err = validate(&latest.Config{
 		Dev: &latest.DevConfig{
 			OverrideImages: &[]*latest.ImageOverrideConfig{
@@ -644,6 +809,21 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("No error in config with invalid imageOverrideConfig: %v", err)
 	}
 
+	err = validate(&latest.Config{
+		Dev: &latest.DevConfig{
+			Debug: &[]*latest.DebugConfig{
+				&latest.DebugConfig{
+					Image:   ptr.String("default"),
+					Runtime: ptr.String("python"),
+					Command: &[]*string{ptr.String("main.py")},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid debug runtime: %v", err)
+	}
+
 	err = validate(&latest.Config{
 		Hooks: &[]*latest.HookConfig{
 			&latest.HookConfig{},
@@ -653,6 +833,22 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("No error in config with invalid hook: %v", err)
 	}
 
+	err = validate(&latest.Config{
+		Hooks: &[]*latest.HookConfig{
+			&latest.HookConfig{
+				Commands: &[]*latest.OSCommandConfig{
+					&latest.OSCommandConfig{
+						OS:      &[]*string{ptr.String("win32")},
+						Command: ptr.String("cmd"),
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid hook command os: %v", err)
+	}
+
 	err = validate(&latest.Config{
 		Images: &map[string]*latest.ImageConfig{
 			"invalidImg": &latest.ImageConfig{
@@ -666,6 +862,165 @@ func TestValidate(t *testing.T) {
 		t.Fatalf("No error in config with invalid image config: %v", err)
 	}
 
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Pod: &latest.PodBuildConfig{
+						Tool: ptr.String("docker"),
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid build tool: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Pod: &latest.PodBuildConfig{
+						Resources: &latest.PodResources{
+							Limits: &map[string]*string{"memory": ptr.String("lots")},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid build pod resources: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Kaniko: &latest.KanikoConfig{
+						Resources: &latest.PodResources{
+							Requests: &map[string]*string{"cpu": ptr.String("fast")},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid kaniko resources: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Kaniko: &latest.KanikoConfig{
+						CacheTTL: ptr.String("2 weeks"),
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid kaniko cacheTTL: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Docker: &latest.DockerConfig{
+						Options: &latest.BuildOptions{
+							Secrets: &[]string{"src=.npmrc"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with docker secret without id: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Kaniko: &latest.KanikoConfig{
+						Options: &latest.BuildOptions{
+							SSH: &[]string{"default"},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with kaniko ssh mount: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Kaniko: &latest.KanikoConfig{
+						Tolerations: &[]*latest.PodToleration{
+							{Key: ptr.String("dedicated"), Operator: ptr.String("Exists"), Value: ptr.String("build")},
+						},
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with invalid kaniko toleration: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Tag:  ptr.String("v1"),
+				Tags: &[]string{"v1"},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with tag and tags: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"invalidImg": &latest.ImageConfig{
+				Tags: &[]string{},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with empty tags: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Notifications: &[]*latest.NotificationConfig{
+			&latest.NotificationConfig{},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with notification without url: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Notifications: &[]*latest.NotificationConfig{
+			&latest.NotificationConfig{
+				URL:      ptr.String("https://hooks.slack.com/services/test"),
+				Commands: &[]*string{ptr.String("build")},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with unsupported notification command: %v", err)
+	}
+
 	err = validate(&latest.Config{
 		Deployments: &[]*latest.DeploymentConfig{
 			&latest.DeploymentConfig{},
@@ -738,6 +1093,11 @@ func TestValidate(t *testing.T) {
 			&latest.HookConfig{
 				Command: ptr.String("echo"),
 			},
+			&latest.HookConfig{
+				Command: ptr.String("echo"),
+				Events:  &[]*string{ptr.String("before:dev"), ptr.String("after:purge")},
+				OS:      &[]*string{ptr.String("linux"), ptr.String("darwin")},
+			},
 		},
 		Images: &map[string]*latest.ImageConfig{
 			"validImg": &latest.ImageConfig{
@@ -758,4 +1118,217 @@ func TestValidate(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Error in valid config found: %v", err)
 	}
+
+	err = validate(&latest.Config{
+		Hooks: &[]*latest.HookConfig{
+			&latest.HookConfig{
+				Command: ptr.String("echo"),
+				Events:  &[]*string{ptr.String("before:enter")},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with unsupported hook event")
+	}
+
+	err = validate(&latest.Config{
+		Hooks: &[]*latest.HookConfig{
+			&latest.HookConfig{
+				Command: ptr.String("echo"),
+				OS:      &[]*string{ptr.String("plan9")},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with unsupported hook os")
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"default": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Export: &latest.ExportConfig{
+						Type: ptr.String("zip"),
+						Dest: ptr.String("image.tar"),
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with unsupported export type")
+	}
+
+	err = validate(&latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"default": &latest.ImageConfig{
+				Build: &latest.BuildConfig{
+					Kaniko: &latest.KanikoConfig{},
+					Export: &latest.ExportConfig{
+						Dest: ptr.String("image.tar"),
+					},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in config with export and kaniko builder")
+	}
+
+	err = validate(&latest.Config{
+		Dependencies: &[]*latest.DependencyConfig{
+			&latest.DependencyConfig{
+				Source: &latest.SourceConfig{
+					Path:  ptr.String("../api"),
+					Chart: &latest.ChartConfig{Name: ptr.String("stable/mysql")},
+				},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in dependency with path and chart source")
+	}
+
+	err = validate(&latest.Config{
+		Dependencies: &[]*latest.DependencyConfig{
+			&latest.DependencyConfig{
+				Source: &latest.SourceConfig{Path: ptr.String("../api")},
+				Values: &map[interface{}]interface{}{"replicas": 2},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in path dependency with values")
+	}
+
+	err = validate(&latest.Config{
+		Dependencies: &[]*latest.DependencyConfig{
+			&latest.DependencyConfig{
+				Source: &latest.SourceConfig{Chart: &latest.ChartConfig{Name: ptr.String("stable/mysql")}},
+				Values: &map[interface{}]interface{}{"replicas": 2},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error in chart dependency: %v", err)
+	}
+
+	err = validate(&latest.Config{
+		Dependencies: &[]*latest.DependencyConfig{
+			&latest.DependencyConfig{
+				Source: &latest.SourceConfig{Chart: &latest.ChartConfig{Name: ptr.String("stable/mysql")}},
+				Vars:   &map[string]string{"replicas": "2"},
+			},
+		},
+	})
+	if err == nil {
+		t.Fatalf("No error in chart dependency with vars")
+	}
+}
+
+func TestAskQuestionsWithSource(t *testing.T) {
+	cache := &generated.CacheConfig{Vars: map[string]string{}}
+	resolver := newVarResolver()
+
+	err := resolver.askQuestions(cache, []*configspkg.Variable{
+		{
+			Name:   ptr.String("password"),
+			Source: ptr.String("vault:kv/app#password"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resolver.secretVars["password"] != "vault:kv/app#password" {
+		t.Fatalf("Expected secret var to be registered, got %v", resolver.secretVars)
+	}
+	if _, ok := cache.Vars["password"]; ok {
+		t.Fatal("Secret var must not be saved in the generated config")
+	}
+
+	err = resolver.askQuestions(cache, []*configspkg.Variable{
+		{
+			Name:   ptr.String("password"),
+			Source: ptr.String("unknown:app"),
+		},
+	})
+	if err == nil {
+		t.Fatal("Expected error for invalid source")
+	}
+}
+
+func TestAskQuestionsWithOverrideVars(t *testing.T) {
+	cache := &generated.CacheConfig{Vars: map[string]string{}}
+
+	resolver := newVarResolver()
+	resolver.overrideVars = map[string]string{"environment": "staging"}
+
+	err := resolver.askQuestions(cache, []*configspkg.Variable{
+		{
+			Name: ptr.String("environment"),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Vars["environment"]; ok {
+		t.Fatal("Override var must not be saved in the generated config")
+	}
+
+	value, err := resolver.resolveVar("environment")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "staging" {
+		t.Fatalf("Expected override var value staging, got %s", value)
+	}
+}
+
+func TestAskQuestionsWithNoInput(t *testing.T) {
+	cache := &generated.CacheConfig{Vars: map[string]string{"cached": "value"}}
+	resolver := newVarResolver()
+	resolver.commandVars["fromCommand"] = "value"
+
+	survey.SetNoInput(true)
+	defer survey.SetNoInput(false)
+
+	err := resolver.askQuestions(cache, []*configspkg.Variable{
+		{Name: ptr.String("cached")},
+		{Name: ptr.String("fromCommand")},
+		{Name: ptr.String("first")},
+		{Name: ptr.String("withDefault"), Default: ptr.String("default")},
+		{Name: ptr.String("second")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cache.Vars["fromCommand"]; ok {
+		t.Fatal("Command var must not be saved in the generated config")
+	}
+	if cache.Vars["withDefault"] != "default" {
+		t.Fatalf("Expected default value for withDefault, got %s", cache.Vars["withDefault"])
+	}
+
+	err = resolver.missingVarsError()
+	if err == nil {
+		t.Fatal("Expected error for missing vars")
+	}
+	if strings.Contains(err.Error(), "first, second") == false || strings.Contains(err.Error(), "DEVSPACE_VAR_FIRST, DEVSPACE_VAR_SECOND") == false || strings.Contains(err.Error(), "withDefault") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestParseCommandVars(t *testing.T) {
+	commandVars, err := ParseCommandVars([]string{"name=value", "url=http://host?a=b", "HOSTS=a,b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if commandVars["name"] != "value" || commandVars["url"] != "http://host?a=b" || commandVars["HOSTS"] != "a,b" {
+		t.Fatalf("Unexpected command vars %v", commandVars)
+	}
+
+	_, err = ParseCommandVars([]string{"name"})
+	if err == nil {
+		t.Fatal("Expected error for var without value")
+	}
 }
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/blang/semver"
 	"github.com/devspace-cloud/devspace/pkg/util/git"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/randutil"
@@ -20,20 +21,28 @@ import (
 	cloudtoken "github.com/devspace-cloud/devspace/pkg/devspace/cloud/token"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configs"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/schema"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
+	"github.com/devspace-cloud/devspace/pkg/devspace/secrets"
 	yaml "gopkg.in/yaml.v2"
 )
 
 // VarMatchRegex is the regex to check if a value matches the devspace var format
-var VarMatchRegex = regexp.MustCompile("^(.*)(\\$\\{[^\\}]+\\})(.*)$")
+var VarMatchRegex = regexp.MustCompile("(?s)^(.*)(\\$\\{[^\\}]+\\})(.*)$")
+
+// varRegex matches a single variable within a value
+var varRegex = regexp.MustCompile("\\$\\{[^\\}]+\\}")
 
 // VarEnvPrefix is the prefix environment variables should have in order to use them
 const VarEnvPrefix = "DEVSPACE_VAR_"
 
-// LoadedVars holds all variables that were loaded
-var LoadedVars = make(map[string]string)
+// VersionFile is the file DEVSPACE_VERSION is read from
+const VersionFile = "VERSION"
+
+// invalidTagCharRegex matches characters that are not allowed within an image tag
+var invalidTagCharRegex = regexp.MustCompile(`[^a-zA-Z0-9_.-]`)
 
 // PredefinedVars holds all predefined variables that can be used in the config
 var PredefinedVars = map[string]*predefinedVarDefinition{
@@ -65,6 +74,36 @@ var PredefinedVars = map[string]*predefinedVarDefinition{
 			return ptr.String(hash[:8]), nil
 		},
 	},
+	"DEVSPACE_GIT_BRANCH": &predefinedVarDefinition{
+		ErrorMessage: "No git branch found, but predefined var DEVSPACE_GIT_BRANCH is used",
+		Fill: func(generatedConfig *generated.Config) (*string, error) {
+			gitRepo := git.NewGitRepository(".", "")
+
+			branch, err := gitRepo.GetBranch()
+			if err != nil {
+				return nil, nil
+			}
+
+			// Branch names like feature/xyz are not valid image tags
+			return ptr.String(invalidTagCharRegex.ReplaceAllString(branch, "-")), nil
+		},
+	},
+	"DEVSPACE_VERSION": &predefinedVarDefinition{
+		ErrorMessage: "No semantic version found in file " + VersionFile + ", but predefined var DEVSPACE_VERSION is used",
+		Fill: func(generatedConfig *generated.Config) (*string, error) {
+			data, err := ioutil.ReadFile(VersionFile)
+			if err != nil {
+				return nil, nil
+			}
+
+			version, err := semver.ParseTolerant(strings.TrimSpace(string(data)))
+			if err != nil {
+				return nil, nil
+			}
+
+			return ptr.String(invalidTagCharRegex.ReplaceAllString(version.String(), "-")), nil
+		},
+	},
 	"DEVSPACE_SPACE": &predefinedVarDefinition{
 		ErrorMessage: fmt.Sprintf("No space configured, but predefined var DEVSPACE_SPACE is used.\n\nPlease run: \n- `%s` to create a new space\n- `%s` to use an existing space\n- `%s` to list existing spaces", ansi.Color("devspace create space [NAME]", "white+b"), ansi.Color("devspace use space [NAME]", "white+b"), ansi.Color("devspace list spaces", "white+b")),
 		Fill: func(generatedConfig *generated.Config) (*string, error) {
@@ -111,43 +150,135 @@ var PredefinedVars = map[string]*predefinedVarDefinition{
 }
 
 type predefinedVarDefinition struct {
-	Value        *string
 	ErrorMessage string
 	Fill         func(generatedConfig *generated.Config) (*string, error)
 }
 
-func varReplaceFn(path, value string) (interface{}, error) {
+// varResolver resolves the variables of a config. Every ConfigLoader has its own resolver, so loaders neither share
+// the values of their variables nor need to be serialized
+type varResolver struct {
+	// loadedVars holds the original values of all config fields that contained variables
+	loadedVars map[string]string
+
+	// secretVars maps the names of variables to the secret references they are resolved from
+	secretVars map[string]string
+
+	// commandVars holds the variable values that were passed with --var on the command line. They take precedence
+	// over environment variables and are never saved in the generated config
+	commandVars map[string]string
+
+	// overrideVars holds the variable values that were passed to the config loader (e.g. the vars of a dependency).
+	// They take precedence over the variables saved in the generated config
+	overrideVars map[string]string
+
+	// fileVars holds the variables loaded from the env files of the project
+	fileVars map[string]string
+
+	// predefinedVars holds the values of the predefined variables, nil if a value couldn't be determined
+	predefinedVars map[string]*string
+
+	// generatedConfig and basePath are the generated config and the project path the values of the variables are
+	// saved in. If generatedConfig is nil, the generated config of the working directory is used
+	generatedConfig *generated.Config
+	basePath        string
+
+	// missingVars collects the variables without a value if questions cannot be asked (--no-input), so all of them
+	// are reported at once
+	missingVars []string
+}
+
+func newVarResolver() *varResolver {
+	return &varResolver{
+		loadedVars:     make(map[string]string),
+		secretVars:     make(map[string]string),
+		commandVars:    make(map[string]string),
+		overrideVars:   make(map[string]string),
+		fileVars:       make(map[string]string),
+		predefinedVars: make(map[string]*string),
+	}
+}
+
+func (r *varResolver) varReplaceFn(path, value string) (interface{}, error) {
 	// Save old value
-	LoadedVars[path] = value
+	r.loadedVars[path] = value
+
+	// Replace every variable, multi-line values like inline manifests can contain several
+	var resolveErr error
+	varValue := varRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
 
-	matched := VarMatchRegex.FindStringSubmatch(value)
-	if len(matched) != 4 {
-		return "", nil
+		resolved, err := r.resolveVar(strings.TrimSpace(match[2 : len(match)-1]))
+		if err != nil {
+			resolveErr = err
+			return match
+		}
+
+		return resolved
+	})
+	if resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	// Check if we can convert val
+	if i, err := strconv.Atoi(varValue); err == nil {
+		return i, nil
+	} else if b, err := strconv.ParseBool(varValue); err == nil {
+		return b, nil
 	}
 
-	value = matched[2]
-	varName := strings.TrimSpace(value[2 : len(value)-1])
+	return varValue, nil
+}
 
-	// Find value for variable
+// resolveVar returns the value of a predefined variable, a secret, a command line variable, an env variable, an override variable, a variable from the env files or a variable from the generated config.
+// If the variable is not set yet, the user is asked for a value
+func (r *varResolver) resolveVar(varName string) (string, error) {
 	varValue := ""
 	if variable, ok := PredefinedVars[strings.ToUpper(varName)]; ok {
-		if variable.Value == nil {
-			return nil, errors.New(variable.ErrorMessage)
+		value := r.predefinedVars[strings.ToUpper(varName)]
+		if value == nil {
+			return "", errors.New(variable.ErrorMessage)
+		}
+
+		varValue = *value
+	} else if secrets.IsReference(varName) {
+		secretValue, err := secrets.Resolve(varName)
+		if err != nil {
+			return "", err
 		}
 
-		varValue = *variable.Value
+		varValue = secretValue
+	} else if commandValue, ok := r.commandVars[varName]; ok {
+		varValue = commandValue
 	} else if os.Getenv(VarEnvPrefix+strings.ToUpper(varName)) != "" {
 		envVarValue := os.Getenv(VarEnvPrefix + strings.ToUpper(varName))
 		varValue = envVarValue
+	} else if reference, ok := r.secretVars[varName]; ok {
+		secretValue, err := secrets.Resolve(reference)
+		if err != nil {
+			return "", err
+		}
+
+		varValue = secretValue
+	} else if overrideValue, ok := r.overrideVars[varName]; ok {
+		varValue = overrideValue
+	} else if fileValue, ok := r.fileVars[varName]; ok {
+		varValue = fileValue
 	} else {
-		generatedConfig, err := generated.LoadConfig()
+		generatedConfig, err := r.loadGeneratedConfig()
 		if err != nil {
-			return nil, fmt.Errorf("Error reading generated config: %v", err)
+			return "", fmt.Errorf("Error reading generated config: %v", err)
 		}
 
 		// Get current config
 		currentConfig := generatedConfig.GetActive()
 		if _, ok := currentConfig.Vars[varName]; !ok {
+			if survey.IsNoInput() {
+				r.addMissingVar(varName)
+				return "", nil
+			}
+
 			currentConfig.Vars[varName] = AskQuestion(&configs.Variable{
 				Question: ptr.String("Please enter a value for " + varName),
 			})
@@ -156,23 +287,29 @@ func varReplaceFn(path, value string) (interface{}, error) {
 		varValue = currentConfig.Vars[varName]
 
 		// Save config
-		err = generated.SaveConfig(generatedConfig)
+		err = r.saveGeneratedConfig(generatedConfig)
 		if err != nil {
-			return nil, fmt.Errorf("Error saving generated config: %v", err)
+			return "", fmt.Errorf("Error saving generated config: %v", err)
 		}
 	}
 
-	// Add matched groups again
-	varValue = matched[1] + varValue + matched[3]
+	return varValue, nil
+}
 
-	// Check if we can convert val
-	if i, err := strconv.Atoi(varValue); err == nil {
-		return i, nil
-	} else if b, err := strconv.ParseBool(varValue); err == nil {
-		return b, nil
+func (r *varResolver) loadGeneratedConfig() (*generated.Config, error) {
+	if r.generatedConfig != nil {
+		return r.generatedConfig, nil
 	}
 
-	return varValue, nil
+	return generated.LoadConfig()
+}
+
+func (r *varResolver) saveGeneratedConfig(generatedConfig *generated.Config) error {
+	if r.generatedConfig != nil {
+		return generated.SaveConfigInPath(r.basePath, generatedConfig)
+	}
+
+	return generated.SaveConfig(generatedConfig)
 }
 
 func varMatchFn(path, key, value string) bool {
@@ -210,13 +347,13 @@ func AskQuestion(variable *configs.Variable) string {
 	return survey.Question(params)
 }
 
-func loadConfigFromPath(path string) (*latest.Config, error) {
+func loadConfigFromPath(path string, resolver *varResolver) (*latest.Config, error) {
 	yamlFileContent, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := resolveVars(yamlFileContent)
+	out, err := resolver.resolveVars(yamlFileContent)
 	if err != nil {
 		return nil, err
 	}
@@ -227,6 +364,16 @@ func loadConfigFromPath(path string) (*latest.Config, error) {
 		return nil, err
 	}
 
+	err = validateRequires(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateSchema(oldConfig, yamlFileContent)
+	if err != nil {
+		return nil, errors.Wrapf(err, "validate %s", path)
+	}
+
 	newConfig, err := versions.Parse(oldConfig)
 	if err != nil {
 		return nil, err
@@ -235,13 +382,13 @@ func loadConfigFromPath(path string) (*latest.Config, error) {
 	return newConfig, nil
 }
 
-func loadConfigFromInterface(m interface{}) (*latest.Config, error) {
+func loadConfigFromInterface(m interface{}, resolver *varResolver) (*latest.Config, error) {
 	yamlFileContent, err := yaml.Marshal(m)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := resolveVars(yamlFileContent)
+	out, err := resolver.resolveVars(yamlFileContent)
 	if err != nil {
 		return nil, err
 	}
@@ -252,6 +399,16 @@ func loadConfigFromInterface(m interface{}) (*latest.Config, error) {
 		return nil, err
 	}
 
+	err = validateRequires(oldConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateSchema(oldConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+
 	newConfig, err := versions.Parse(oldConfig)
 	if err != nil {
 		return nil, err
@@ -260,6 +417,28 @@ func loadConfigFromInterface(m interface{}) (*latest.Config, error) {
 	return newConfig, nil
 }
 
+// validateSchema validates the config data against the json schema of the latest config version and returns
+// all unknown fields and fields with a wrong type at once. If the content of the config file is passed,
+// the errors contain the line numbers of the offending fields
+func validateSchema(data map[interface{}]interface{}, content []byte) error {
+	if version, ok := data["version"].(string); ok && version != latest.Version {
+		// Older config versions are validated strictly while they are upgraded
+		return nil
+	}
+
+	errs := schema.Validate(schema.Config(), data, content)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, "  "+err.Error())
+	}
+
+	return fmt.Errorf("Invalid config:\n%s", strings.Join(messages, "\n"))
+}
+
 // LoadConfigs loads all the configs from devspace-configs.yaml
 func LoadConfigs(configs *configs.Configs, path string) error {
 	yamlFileContent, err := ioutil.ReadFile(path)
@@ -292,17 +471,64 @@ func CustomResolveVars(yamlFileContent []byte, matchFn func(string, string, stri
 	return out, nil
 }
 
-func resolveVars(yamlFileContent []byte) ([]byte, error) {
-	err := fillPredefinedVars()
+func (r *varResolver) resolveVars(yamlFileContent []byte) ([]byte, error) {
+	err := r.fillPredefinedVars()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() { r.missingVars = nil }()
+
+	out, err := CustomResolveVars(yamlFileContent, varMatchFn, r.varReplaceFn)
 	if err != nil {
 		return nil, err
 	}
 
-	return CustomResolveVars(yamlFileContent, varMatchFn, varReplaceFn)
+	return out, r.missingVarsError()
+}
+
+// ParseCommandVars parses the NAME=value pairs passed with --var. Everything after the first = is the value, so
+// values may contain = and commas
+func ParseCommandVars(vars []string) (map[string]string, error) {
+	commandVars := make(map[string]string, len(vars))
+	for _, v := range vars {
+		splitted := strings.SplitN(v, "=", 2)
+		if len(splitted) != 2 || splitted[0] == "" {
+			return nil, fmt.Errorf("Invalid variable %s, expected format NAME=value", v)
+		}
+
+		commandVars[splitted[0]] = splitted[1]
+	}
+
+	return commandVars, nil
+}
+
+func (r *varResolver) addMissingVar(varName string) {
+	for _, missingVar := range r.missingVars {
+		if missingVar == varName {
+			return
+		}
+	}
+
+	r.missingVars = append(r.missingVars, varName)
+}
+
+// missingVarsError returns an error that lists all missing variables and how to set them
+func (r *varResolver) missingVarsError() error {
+	if len(r.missingVars) == 0 {
+		return nil
+	}
+
+	envVars := make([]string, 0, len(r.missingVars))
+	for _, varName := range r.missingVars {
+		envVars = append(envVars, VarEnvPrefix+strings.ToUpper(varName))
+	}
+
+	return fmt.Errorf("Missing values for the config variables %s, which cannot be asked for with --no-input. Please set them with `--var NAME=value` or the environment variables %s", strings.Join(r.missingVars, ", "), strings.Join(envVars, ", "))
 }
 
-func fillPredefinedVars() error {
-	generatedConfig, err := generated.LoadConfig()
+func (r *varResolver) fillPredefinedVars() error {
+	generatedConfig, err := r.loadGeneratedConfig()
 	if err != nil {
 		return fmt.Errorf("Error reading generated config: %v", err)
 	}
@@ -313,7 +539,7 @@ func fillPredefinedVars() error {
 			return errors.Wrap(err, "fill predefined var "+varName)
 		}
 
-		predefinedVariable.Value = val
+		r.predefinedVars[varName] = val
 	}
 
 	return nil
@@ -0,0 +1,31 @@
+package configutil
+
+import (
+	"os"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestVarReplaceFn(t *testing.T) {
+	os.Setenv(VarEnvPrefix+"NAME", "api")
+	defer os.Unsetenv(VarEnvPrefix + "NAME")
+	os.Setenv(VarEnvPrefix+"PORT", "8080")
+	defer os.Unsetenv(VarEnvPrefix + "PORT")
+
+	resolver := newVarResolver()
+
+	// Single variables are converted
+	value, err := resolver.varReplaceFn(".port", "${PORT}")
+	assert.NilError(t, err)
+	assert.Equal(t, value, 8080)
+
+	// Multi-line values with several variables, e.g. inline manifests
+	inlineManifest := "kind: ConfigMap\nmetadata:\n  name: ${NAME}-config\ndata:\n  port: \"${PORT}\"\n"
+	assert.Equal(t, VarMatchRegex.MatchString(inlineManifest), true, "Multi-line value not matched")
+
+	value, err = resolver.varReplaceFn(".deployments[0].kubectl.inlineManifest", inlineManifest)
+	assert.NilError(t, err)
+	assert.Equal(t, value, "kind: ConfigMap\nmetadata:\n  name: api-config\ndata:\n  port: \"8080\"\n")
+	assert.Equal(t, resolver.loadedVars[".deployments[0].kubectl.inlineManifest"], inlineManifest, "Original value not saved")
+}
@@ -0,0 +1,251 @@
+package configutil
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// ConfigOptions defines how a ConfigLoader loads the config
+type ConfigOptions struct {
+	// ConfigName is the config of devspace-configs.yaml to load. Defaults to the active config of the generated config
+	ConfigName string
+
+	// BaseConfig loads the config without overrides and without exchanging the kube context and namespace
+	BaseConfig bool
+
+	// Namespace and KubeContext override the namespace and kube context of the loaded config
+	Namespace   string
+	KubeContext string
+
+	// SkipValidation returns the config without validating it
+	SkipValidation bool
+
+	// GeneratedConfig is used instead of loading the generated config. If it is set, the loader neither scopes its
+	// caches to the kube context and namespace of the config nor saves it
+	GeneratedConfig *generated.Config
+
+	// Vars are used for the variables of the config instead of asking for them or using the values saved in the
+	// generated config. Env variables still take precedence
+	Vars map[string]string
+
+	// CommandVars are the variables passed with --var. They take precedence over env variables and are not saved in
+	// the generated config
+	CommandVars map[string]string
+
+	// Log is the logger used while loading, defaults to the global logger
+	Log log.Logger
+}
+
+// ConfigLoader loads a devspace config together with its generated config. In contrast to GetConfig a loader keeps
+// its state for itself, hence several loaders can be used concurrently e.g. when devspace is embedded as a library
+type ConfigLoader struct {
+	basePath string
+	options  ConfigOptions
+
+	loadOnce        sync.Once
+	config          *latest.Config
+	generatedConfig *generated.Config
+	loadedConfig    string
+	vars            *varResolver
+	err             error
+}
+
+// NewConfigLoader creates a new config loader for the devspace project in basePath
+func NewConfigLoader(basePath string, options *ConfigOptions) *ConfigLoader {
+	loader := &ConfigLoader{
+		basePath: basePath,
+		vars:     newVarResolver(),
+	}
+
+	if options != nil {
+		loader.options = *options
+	}
+	if loader.options.Log == nil {
+		loader.options.Log = log.GetInstance()
+	}
+
+	return loader
+}
+
+// Exists checks whether a devspace configuration exists in the base path
+func (l *ConfigLoader) Exists() bool {
+	return configFileExistsInPath(l.basePath)
+}
+
+// Load loads, validates and returns the config and the generated config. The config is only loaded once, subsequent
+// calls return the same objects
+func (l *ConfigLoader) Load() (*latest.Config, *generated.Config, error) {
+	l.loadOnce.Do(func() {
+		l.config, l.generatedConfig, l.err = l.load()
+	})
+
+	return l.config, l.generatedConfig, l.err
+}
+
+// LoadedConfig returns the name of the loaded config of devspace-configs.yaml or an empty string if the config was
+// loaded from devspace.yaml
+func (l *ConfigLoader) LoadedConfig() string {
+	return l.loadedConfig
+}
+
+func (l *ConfigLoader) load() (*latest.Config, *generated.Config, error) {
+	generatedConfig := l.options.GeneratedConfig
+	if generatedConfig == nil {
+		var err error
+
+		generatedConfig, err = l.loadGeneratedConfig()
+		if err != nil {
+			return nil, nil, errors.Errorf("Error loading %s: %v", generated.ConfigPath, err)
+		}
+	}
+
+	configName := l.options.ConfigName
+	if configName == "" {
+		configName = generatedConfig.ActiveConfig
+	}
+
+	config, err := l.loadConfig(configName, generatedConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if l.options.Namespace != "" || l.options.KubeContext != "" {
+		cluster := &latest.Cluster{}
+		if config.Cluster != nil {
+			cluster.Namespace = config.Cluster.Namespace
+			cluster.KubeContext = config.Cluster.KubeContext
+		}
+		if l.options.Namespace != "" {
+			cluster.Namespace = &l.options.Namespace
+		}
+		if l.options.KubeContext != "" {
+			cluster.KubeContext = &l.options.KubeContext
+		}
+
+		config.Cluster = cluster
+	}
+
+	if l.options.SkipValidation == false {
+		err = validate(config)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if l.options.GeneratedConfig == nil {
+		// Scope the image and deployment caches to the kube context and namespace we deploy to
+		if l.options.BaseConfig == false {
+			SetCacheContext(config, generatedConfig)
+		}
+
+		err = l.saveGeneratedConfig(generatedConfig)
+		if err != nil {
+			return nil, nil, errors.Errorf("Couldn't save generated config: %v", err)
+		}
+	}
+
+	return config, generatedConfig, nil
+}
+
+// loadGeneratedConfig loads the generated config of the project in the base path. For the project in the current
+// working directory the config is shared with generated.LoadConfig, so commands and the loader use the same object
+func (l *ConfigLoader) loadGeneratedConfig() (*generated.Config, error) {
+	if l.inWorkingDir() {
+		return generated.LoadConfig()
+	}
+
+	return generated.LoadConfigFromPath(filepath.Join(l.basePath, filepath.FromSlash(generated.ConfigPath)))
+}
+
+func (l *ConfigLoader) saveGeneratedConfig(generatedConfig *generated.Config) error {
+	if l.inWorkingDir() {
+		return generated.SaveConfig(generatedConfig)
+	}
+
+	return generated.SaveConfigInPath(l.basePath, generatedConfig)
+}
+
+// inWorkingDir checks whether the base path is the current working directory
+func (l *ConfigLoader) inWorkingDir() bool {
+	workdir, err := os.Getwd()
+	if err != nil {
+		return false
+	}
+
+	basePath, err := filepath.Abs(l.basePath)
+	if err != nil {
+		return false
+	}
+
+	return basePath == workdir
+}
+
+func (l *ConfigLoader) loadConfig(configName string, generatedConfig *generated.Config) (*latest.Config, error) {
+	for name, value := range l.options.Vars {
+		l.vars.overrideVars[name] = value
+	}
+	for name, value := range l.options.CommandVars {
+		l.vars.commandVars[name] = value
+	}
+
+	// Save the values of the variables in the generated config of the project instead of the working directory
+	if l.options.GeneratedConfig == nil && l.inWorkingDir() == false {
+		l.vars.generatedConfig = generatedConfig
+		l.vars.basePath = l.basePath
+	}
+
+	fileVars, err := loadEnvFiles(l.basePath)
+	if err != nil {
+		return nil, err
+	}
+	l.vars.fileVars = fileVars
+
+	config, configDefinition, err := loadBaseConfigFromPath(l.basePath, configName, l.options.BaseConfig == false, generatedConfig, l.vars, l.options.Log)
+	if err != nil {
+		return nil, err
+	}
+
+	if configDefinition != nil {
+		l.loadedConfig = configName
+	}
+
+	return config, nil
+}
+
+// RestoreVars returns a copy of the config with the variables restored that were replaced while loading it
+func (l *ConfigLoader) RestoreVars(config *latest.Config) (*latest.Config, error) {
+	return restoreVars(config, l.vars.loadedVars)
+}
+
+// Save writes the config to the file it was loaded from. Use RestoreVars before to keep the variables in the file
+func (l *ConfigLoader) Save(config *latest.Config) error {
+	return saveConfig(l.basePath, l.loadedConfig, config)
+}
+
+// configFileExistsInPath checks whether one of the config files exists in the given path
+func configFileExistsInPath(path string) bool {
+	configPaths := []string{
+		constants.DefaultConfigPath,
+		constants.DefaultConfigsPath,
+
+		// Old config paths
+		filepath.Join(".devspace", "config.yaml"),
+		filepath.Join(".devspace", "configs.yaml"),
+	}
+
+	for _, configPath := range configPaths {
+		_, err := os.Stat(filepath.Join(path, configPath))
+		if err == nil {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,137 @@
+package configutil
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"gotest.tools/assert"
+)
+
+func TestConfigLoader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	projects := []string{"project-a", "project-b"}
+	for _, project := range projects {
+		err = fsutil.WriteToFile([]byte("version: v1beta2\nimages:\n  default:\n    image: ${IMAGE}-"+project+"\n"), filepath.Join(dir, project, constants.DefaultConfigPath))
+		if err != nil {
+			t.Fatalf("Error writing config: %v", err)
+		}
+	}
+
+	os.Setenv(VarEnvPrefix+"IMAGE", "myimage")
+	defer os.Unsetenv(VarEnvPrefix + "IMAGE")
+
+	// Load both projects concurrently
+	loaders := make([]*ConfigLoader, len(projects))
+	waitGroup := sync.WaitGroup{}
+	for i, project := range projects {
+		loaders[i] = NewConfigLoader(filepath.Join(dir, project), &ConfigOptions{
+			GeneratedConfig: &generated.Config{},
+			Log:             log.Discard,
+		})
+
+		waitGroup.Add(1)
+		go func(loader *ConfigLoader) {
+			defer waitGroup.Done()
+			loader.Load()
+		}(loaders[i])
+	}
+	waitGroup.Wait()
+
+	for i, project := range projects {
+		assert.Equal(t, true, loaders[i].Exists(), "Config doesn't exist")
+
+		config, _, err := loaders[i].Load()
+		assert.NilError(t, err, "Error loading config of %s", project)
+		assert.Equal(t, "myimage-"+project, *(*config.Images)["default"].Image, "Variable not resolved")
+		assert.Equal(t, "", loaders[i].LoadedConfig(), "Wrong loaded config")
+
+		restoredConfig, err := loaders[i].RestoreVars(config)
+		assert.NilError(t, err, "Error restoring vars")
+		assert.Equal(t, "${IMAGE}-"+project, *(*restoredConfig.Images)["default"].Image, "Variable not restored")
+
+		err = loaders[i].Save(restoredConfig)
+		assert.NilError(t, err, "Error saving config")
+
+		content, err := fsutil.ReadFile(filepath.Join(dir, project, constants.DefaultConfigPath), -1)
+		assert.NilError(t, err, "Error reading saved config")
+		assert.Equal(t, "version: v1beta2\nimages:\n  default:\n    image: ${IMAGE}-"+project+"\n", string(content), "Config saved wrongly")
+	}
+
+	// The loaders keep the variables for themselves
+	assert.Equal(t, "${IMAGE}-project-a", loaders[0].vars.loadedVars[".images.default.image"])
+	assert.Equal(t, "${IMAGE}-project-b", loaders[1].vars.loadedVars[".images.default.image"])
+
+	assert.Equal(t, false, NewConfigLoader(dir, nil).Exists(), "Config exists in directory without config")
+
+	// Command vars take precedence over env variables and are only used by the loader they are passed to
+	config, _, err := NewConfigLoader(filepath.Join(dir, projects[0]), &ConfigOptions{
+		GeneratedConfig: &generated.Config{},
+		CommandVars:     map[string]string{"IMAGE": "a,b"},
+		Log:             log.Discard,
+	}).Load()
+	assert.NilError(t, err, "Error loading config with command vars")
+	assert.Equal(t, "a,b-project-a", *(*config.Images)["default"].Image, "Command var not used")
+
+	config, _, err = NewConfigLoader(filepath.Join(dir, projects[0]), &ConfigOptions{
+		GeneratedConfig: &generated.Config{},
+		Log:             log.Discard,
+	}).Load()
+	assert.NilError(t, err, "Error loading config without command vars")
+	assert.Equal(t, "myimage-project-a", *(*config.Images)["default"].Image, "Command var used by another loader")
+}
+
+func TestConfigLoaderGeneratedConfigInBasePath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	defer os.Chdir(wdBackup)
+
+	// The loader must not touch the generated config of the working directory
+	workdir := filepath.Join(dir, "workdir")
+	err = os.MkdirAll(workdir, 0755)
+	if err != nil {
+		t.Fatalf("Error creating working directory: %v", err)
+	}
+	err = os.Chdir(workdir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+
+	project := filepath.Join(dir, "project")
+	err = fsutil.WriteToFile([]byte("version: v1beta2\nimages:\n  default:\n    image: ${IMAGE}\n"), filepath.Join(project, constants.DefaultConfigPath))
+	if err != nil {
+		t.Fatalf("Error writing config: %v", err)
+	}
+	err = fsutil.WriteToFile([]byte("configs:\n  default:\n    vars:\n      IMAGE: myimage\n"), filepath.Join(project, generated.ConfigPath))
+	if err != nil {
+		t.Fatalf("Error writing generated config: %v", err)
+	}
+
+	config, generatedConfig, err := NewConfigLoader(project, &ConfigOptions{Log: log.Discard}).Load()
+	assert.NilError(t, err, "Error loading config")
+	assert.Equal(t, "myimage", *(*config.Images)["default"].Image, "Variable not loaded from the generated config of the project")
+	assert.Equal(t, "myimage", generatedConfig.GetActive().Vars["IMAGE"], "Wrong generated config")
+
+	_, err = os.Stat(filepath.Join(workdir, generated.ConfigPath))
+	assert.Equal(t, true, os.IsNotExist(err), "Generated config saved in working directory")
+}
@@ -0,0 +1,87 @@
+package configutil
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+)
+
+// SupportedOS holds the operating systems that can be used in os filters and command variants
+var SupportedOS = []string{"darwin", "linux", "windows"}
+
+// GetOSCommand returns the command and args that should be executed on the current operating system. The first
+// command variant that matches the operating system is used, otherwise the default command and args are returned
+func GetOSCommand(command *string, args *[]*string, variants *[]*latest.OSCommandConfig) (string, []string, error) {
+	return getOSCommand(runtime.GOOS, command, args, variants)
+}
+
+func getOSCommand(goos string, command *string, args *[]*string, variants *[]*latest.OSCommandConfig) (string, []string, error) {
+	if variants != nil {
+		for _, variant := range *variants {
+			if variant.OS == nil || variant.Command == nil {
+				continue
+			}
+
+			for _, variantOS := range *variant.OS {
+				if variantOS != nil && strings.TrimSpace(*variantOS) == goos {
+					return *variant.Command, stringSlice(variant.Args), nil
+				}
+			}
+		}
+	}
+
+	if command == nil {
+		return "", nil, fmt.Errorf("No command specified for os %s", goos)
+	}
+
+	return *command, stringSlice(args), nil
+}
+
+// validateOSCommands validates the command variants at the given config path
+func validateOSCommands(path string, variants *[]*latest.OSCommandConfig) error {
+	if variants == nil {
+		return nil
+	}
+
+	for index, variant := range *variants {
+		if variant.Command == nil {
+			return fmt.Errorf("%s[%d].command is required", path, index)
+		}
+		if variant.OS == nil || len(*variant.OS) == 0 {
+			return fmt.Errorf("%s[%d].os is required", path, index)
+		}
+
+		for _, variantOS := range *variant.OS {
+			if isSupportedOS(*variantOS) == false {
+				return fmt.Errorf("%s[%d].os: unsupported os %s (supported: %s)", path, index, *variantOS, strings.Join(SupportedOS, ", "))
+			}
+		}
+	}
+
+	return nil
+}
+
+func isSupportedOS(goos string) bool {
+	for _, supportedOS := range SupportedOS {
+		if goos == supportedOS {
+			return true
+		}
+	}
+
+	return false
+}
+
+func stringSlice(values *[]*string) []string {
+	retSlice := []string{}
+	if values != nil {
+		for _, value := range *values {
+			if value != nil {
+				retSlice = append(retSlice, *value)
+			}
+		}
+	}
+
+	return retSlice
+}
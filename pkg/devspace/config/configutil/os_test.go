@@ -0,0 +1,44 @@
+package configutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+)
+
+func TestGetOSCommand(t *testing.T) {
+	variants := &[]*latest.OSCommandConfig{
+		&latest.OSCommandConfig{
+			OS:      &[]*string{ptr.String("windows")},
+			Command: ptr.String("powershell"),
+			Args:    &[]*string{ptr.String("-File"), ptr.String("build.ps1")},
+		},
+		&latest.OSCommandConfig{
+			OS:      &[]*string{ptr.String("darwin"), ptr.String("linux")},
+			Command: ptr.String("bash"),
+		},
+	}
+
+	tests := map[string]string{
+		"windows": "powershell -File build.ps1",
+		"darwin":  "bash",
+		"freebsd": "sh build.sh",
+	}
+
+	for goos, expected := range tests {
+		command, args, err := getOSCommand(goos, ptr.String("sh"), &[]*string{ptr.String("build.sh")}, variants)
+		if err != nil {
+			t.Fatalf("Error getting command for %s: %v", goos, err)
+		}
+		if strings.Join(append([]string{command}, args...), " ") != expected {
+			t.Fatalf("Expected command %s for %s, got %s %v", expected, goos, command, args)
+		}
+	}
+
+	_, _, err := getOSCommand("freebsd", nil, nil, variants)
+	if err == nil {
+		t.Fatalf("Expected error if no command matches the os")
+	}
+}
@@ -0,0 +1,84 @@
+package configutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/devspace-cloud/devspace/pkg/devspace/upgrade"
+	"github.com/pkg/errors"
+)
+
+// SupportedFeatures are the features that configs can require in requires.features
+var SupportedFeatures = []string{
+	"dependencyVars",
+	"imageExport",
+	"kubeContexts",
+	"preferInCluster",
+	"registryAliases",
+}
+
+// validateRequires checks the requires section of the raw config data. It runs before the rest of the config is
+// validated, because configs for newer DevSpace CLI versions usually contain fields this version doesn't know
+func validateRequires(data map[interface{}]interface{}) error {
+	requires, ok := data["requires"].(map[interface{}]interface{})
+	if ok == false {
+		return nil
+	}
+
+	if versionRange, ok := requires["devspace"].(string); ok {
+		err := validateVersion(versionRange, upgrade.GetVersion())
+		if err != nil {
+			return err
+		}
+	}
+
+	if features, ok := requires["features"].([]interface{}); ok {
+		unsupported := []string{}
+		for _, feature := range features {
+			featureName := fmt.Sprintf("%v", feature)
+			if isSupportedFeature(featureName) == false {
+				unsupported = append(unsupported, featureName)
+			}
+		}
+
+		if len(unsupported) > 0 {
+			return fmt.Errorf("The config requires the features %s, which are not supported by this version of DevSpace CLI. Please run `devspace upgrade` to update the CLI", strings.Join(unsupported, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateVersion checks if the version is in the version range. Development builds have no version and are
+// always accepted
+func validateVersion(versionRange, version string) error {
+	expectedRange, err := semver.ParseRange(versionRange)
+	if err != nil {
+		return errors.Wrapf(err, "parse requires.devspace %s", versionRange)
+	}
+	if version == "" {
+		return nil
+	}
+
+	currentVersion, err := semver.Parse(version)
+	if err != nil {
+		return errors.Wrapf(err, "parse version %s", version)
+	}
+
+	if expectedRange(currentVersion) == false {
+		return fmt.Errorf("The config requires DevSpace CLI version %s, but you are using version %s. Please run `devspace upgrade` to update the CLI", versionRange, version)
+	}
+
+	return nil
+}
+
+func isSupportedFeature(feature string) bool {
+	for _, supportedFeature := range SupportedFeatures {
+		if supportedFeature == feature {
+			return true
+		}
+	}
+
+	return false
+}
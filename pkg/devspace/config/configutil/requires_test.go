@@ -0,0 +1,43 @@
+package configutil
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestValidateRequires(t *testing.T) {
+	err := validateRequires(map[interface{}]interface{}{
+		"version": "v1beta3",
+	})
+	assert.NilError(t, err)
+
+	err = validateRequires(map[interface{}]interface{}{
+		"requires": map[interface{}]interface{}{
+			"features": []interface{}{"kubeContexts", "registryAliases"},
+		},
+	})
+	assert.NilError(t, err)
+
+	err = validateRequires(map[interface{}]interface{}{
+		"requires": map[interface{}]interface{}{
+			"features": []interface{}{"kubeContexts", "helmV3"},
+		},
+	})
+	assert.ErrorContains(t, err, "requires the features helmV3")
+
+	err = validateRequires(map[interface{}]interface{}{
+		"requires": map[interface{}]interface{}{
+			"devspace": "not a range",
+		},
+	})
+	assert.ErrorContains(t, err, "parse requires.devspace")
+}
+
+func TestValidateVersion(t *testing.T) {
+	assert.NilError(t, validateVersion(">=3.5.0", "3.5.0"))
+	assert.NilError(t, validateVersion(">=3.5.0 <4.0.0", "3.6.1"))
+	assert.NilError(t, validateVersion(">=3.5.0", ""))
+	assert.ErrorContains(t, validateVersion(">=3.5.0", "3.4.2"), "requires DevSpace CLI version >=3.5.0, but you are using version 3.4.2")
+	assert.ErrorContains(t, validateVersion(">=3.5.0 <4.0.0", "4.0.0"), "devspace upgrade")
+}
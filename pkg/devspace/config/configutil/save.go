@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/history"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
 	"github.com/pkg/errors"
@@ -15,18 +17,18 @@ import (
 	yaml "gopkg.in/yaml.v2"
 )
 
-func replaceVar(path, value string) (interface{}, error) {
-	oldValue, _ := LoadedVars[path]
-	return oldValue, nil
-}
+// RestoreVars restores the variables in the config
+//
+// Deprecated: Use ConfigLoader.RestoreVars instead
+func RestoreVars(config *latest.Config) (*latest.Config, error) {
+	if configLoader == nil {
+		return restoreVars(config, nil)
+	}
 
-func matchVar(path, key, value string) bool {
-	_, ok := LoadedVars[path+"."+key]
-	return ok
+	return configLoader.RestoreVars(config)
 }
 
-// RestoreVars restores the variables in the config
-func RestoreVars(config *latest.Config) (*latest.Config, error) {
+func restoreVars(config *latest.Config, loadedVars map[string]string) (*latest.Config, error) {
 	configMap := make(map[interface{}]interface{})
 
 	// Copy config
@@ -36,8 +38,13 @@ func RestoreVars(config *latest.Config) (*latest.Config, error) {
 	}
 
 	// Restore old vars values
-	if len(LoadedVars) > 0 {
-		walk.Walk(configMap, matchVar, replaceVar)
+	if len(loadedVars) > 0 {
+		walk.Walk(configMap, func(path, key, value string) bool {
+			_, ok := loadedVars[path+"."+key]
+			return ok
+		}, func(path, value string) (interface{}, error) {
+			return loadedVars[path], nil
+		})
 	}
 
 	// Cloned config
@@ -67,6 +74,8 @@ func RestoreVars(config *latest.Config) (*latest.Config, error) {
 }
 
 // SaveLoadedConfig writes the data of a config to its yaml file
+//
+// Deprecated: Use ConfigLoader.Save instead
 func SaveLoadedConfig() error {
 	// RestoreVars restores the variables in the config
 	clonedConfig, err := RestoreVars(config)
@@ -79,6 +88,10 @@ func SaveLoadedConfig() error {
 
 // SaveConfig saves the config to file
 func SaveConfig(config *latest.Config) error {
+	return saveConfig(".", LoadedConfig, config)
+}
+
+func saveConfig(basePath string, loadedConfig string, config *latest.Config) error {
 	// Convert to string
 	configYaml, err := yaml.Marshal(config)
 	if err != nil {
@@ -86,19 +99,20 @@ func SaveConfig(config *latest.Config) error {
 	}
 
 	// Path to save the configuration to
-	savePath := constants.DefaultConfigPath
+	savePath := filepath.Join(basePath, constants.DefaultConfigPath)
 
 	// Check if we have to save to configs.yaml
-	if LoadedConfig != "" {
+	if loadedConfig != "" {
 		configs := configs.Configs{}
+		configsPath := filepath.Join(basePath, constants.DefaultConfigsPath)
 
 		// Load configs
-		err = LoadConfigs(&configs, constants.DefaultConfigsPath)
+		err = LoadConfigs(&configs, configsPath)
 		if err != nil {
 			return fmt.Errorf("Error loading %s: %v", constants.DefaultConfigsPath, err)
 		}
 
-		configDefinition := configs[LoadedConfig]
+		configDefinition := configs[loadedConfig]
 
 		// We have to save the config in the configs.yaml
 		if configDefinition.Config.Data != nil {
@@ -108,7 +122,11 @@ func SaveConfig(config *latest.Config) error {
 				return err
 			}
 
-			err = ioutil.WriteFile(constants.DefaultConfigsPath, configYaml, os.ModePerm)
+			if history.IsConfigEnabled() {
+				_ = history.Record(basePath, constants.DefaultConfigsPath, configYaml)
+			}
+
+			err = ioutil.WriteFile(configsPath, configYaml, os.ModePerm)
 			if err != nil {
 				return err
 			}
@@ -117,7 +135,15 @@ func SaveConfig(config *latest.Config) error {
 		}
 
 		// Save config in save path
-		savePath = *configDefinition.Config.Path
+		savePath = filepath.Join(basePath, filepath.FromSlash(*configDefinition.Config.Path))
+	}
+
+	// Keep the previous version for devspace rollback-state
+	if history.IsConfigEnabled() {
+		relPath, err := filepath.Rel(basePath, savePath)
+		if err == nil {
+			_ = history.Record(basePath, relPath, configYaml)
+		}
 	}
 
 	err = ioutil.WriteFile(savePath, configYaml, os.ModePerm)
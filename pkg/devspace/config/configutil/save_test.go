@@ -21,7 +21,10 @@ func TestRestoreVars(t *testing.T) {
 		Namespace: ptr.String("UnloadedNS"),
 	}
 
-	LoadedVars[".cluster.namespace"] = "LoadedNS"
+	configLoaderBackup := configLoader
+	defer func() { configLoader = configLoaderBackup }()
+	configLoader = NewConfigLoader(".", nil)
+	configLoader.vars.loadedVars[".cluster.namespace"] = "LoadedNS"
 
 	resultConfig, err := RestoreVars(testConfig)
 
@@ -203,7 +206,7 @@ hooks:
 - command: echo
 cluster:
   kubeContext: someKubeContext
-  namespace: LoadedNS
+  namespace: someNS
 `
 	assert.Equal(t, expectedContent, string(configContent), "Config differently saved than loaded")
 }
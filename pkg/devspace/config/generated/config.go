@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/history"
 	yaml "gopkg.in/yaml.v2"
 )
 
@@ -17,6 +18,35 @@ type Config struct {
 	ActiveConfig string                  `yaml:"activeConfig,omitempty"`
 	Configs      map[string]*CacheConfig `yaml:"configs,omitempty"`
 	CloudSpace   *CloudSpaceConfig       `yaml:"space,omitempty"`
+
+	// KubeContext and Namespace are set by devspace use context and used instead of the current kubectl context
+	KubeContext string `yaml:"kubeContext,omitempty"`
+	Namespace   string `yaml:"namespace,omitempty"`
+
+	// Terminals holds the state of the dev terminals by terminal name, so it can be restored when they are reopened
+	Terminals map[string]*TerminalState `yaml:"terminals,omitempty"`
+
+	// PullSecrets holds the image pull secrets devspace created by kube context and namespace, so they can be
+	// deleted when the deployments are purged
+	PullSecrets map[string]map[string]*PullSecretCache `yaml:"pullSecrets,omitempty"`
+
+	// The kube context and namespace the image and deployment caches are currently scoped to
+	cacheKubeContext string
+	cacheNamespace   string
+}
+
+// TerminalState holds the working directory and the last command of a terminal
+type TerminalState struct {
+	WorkDir     string `yaml:"workDir,omitempty"`
+	LastCommand string `yaml:"lastCommand,omitempty"`
+}
+
+// PullSecretCache holds the image pull secrets devspace created in a namespace
+type PullSecretCache struct {
+	Secrets []string `yaml:"secrets,omitempty"`
+
+	// ServiceAccount holds the pull secrets devspace added to the image pull secrets of the default service account
+	ServiceAccount []string `yaml:"serviceAccount,omitempty"`
 }
 
 // CloudSpaceConfig holds all the informations about a certain cloud space
@@ -32,10 +62,24 @@ type CloudSpaceConfig struct {
 
 // CacheConfig holds all the information specific to a certain config
 type CacheConfig struct {
+	// Deployments and Images are the caches of the kube context and namespace set with SetCacheContext.
+	// They are only saved here if no kube context was set, which is how they were saved in older versions
 	Deployments  map[string]*DeploymentCache `yaml:"deployments,omitempty"`
 	Images       map[string]*ImageCache      `yaml:"images,omitempty"`
 	Dependencies map[string]string           `yaml:"dependencies,omitempty"`
 	Vars         map[string]string           `yaml:"vars,omitempty"`
+
+	// Contexts holds the deployment and image caches by kube context and namespace
+	Contexts map[string]map[string]*ContextCache `yaml:"contexts,omitempty"`
+
+	// scoped is true if Deployments and Images belong to an entry in Contexts
+	scoped bool
+}
+
+// ContextCache holds the deployment and image cache of a single kube context and namespace
+type ContextCache struct {
+	Deployments map[string]*DeploymentCache `yaml:"deployments,omitempty"`
+	Images      map[string]*ImageCache      `yaml:"images,omitempty"`
 }
 
 // ImageCache holds the cache related information about a certain image
@@ -45,11 +89,31 @@ type ImageCache struct {
 	DockerfileHash string `yaml:"dockerfileHash,omitempty"`
 	ContextHash    string `yaml:"contextHash,omitempty"`
 	EntrypointHash string `yaml:"entrypointHash,omitempty"`
+	BuildArgsHash  string `yaml:"buildArgsHash,omitempty"`
+
+	// Target is the multi-stage target the image was built with if it was overridden by images.*.dev.target
+	Target string `yaml:"target,omitempty"`
 
 	CustomFilesHash string `yaml:"customFilesHash,omitempty"`
 
 	ImageName string `yaml:"imageName,omitempty"`
 	Tag       string `yaml:"tag,omitempty"`
+
+	// ResolvedImageName is the image name the image was pushed to if a registry alias was applied to ImageName
+	ResolvedImageName string `yaml:"resolvedImageName,omitempty"`
+
+	// Builder is the engine that built the image, Digest the image digest if the engine reports one
+	Builder   string `yaml:"builder,omitempty"`
+	Digest    string `yaml:"digest,omitempty"`
+	LastBuilt int64  `yaml:"lastBuilt,omitempty"`
+
+	// GeneratedTags are the random tags devspace created for images without a configured tag. Only these tags
+	// are deleted by devspace cleanup images
+	GeneratedTags []string `yaml:"generatedTags,omitempty"`
+
+	// Variant is the build variant (e.g. dev) the fields above belong to, Variants holds the cache of the other variants
+	Variant  string                 `yaml:"variant,omitempty"`
+	Variants map[string]*ImageCache `yaml:"variants,omitempty"`
 }
 
 // DeploymentCache holds the information about a specific deployment
@@ -59,6 +123,8 @@ type DeploymentCache struct {
 	HelmOverridesHash    string `yaml:"helmOverridesHash,omitempty"`
 	HelmChartHash        string `yaml:"helmChartHash,omitempty"`
 	KubectlManifestsHash string `yaml:"kubectlManifestsHash,omitempty"`
+
+	LastDeployed int64 `yaml:"lastDeployed,omitempty"`
 }
 
 // ConfigPath is the relative generated config path
@@ -132,6 +198,202 @@ func (config *Config) GetActive() *CacheConfig {
 	return config.Configs[config.ActiveConfig]
 }
 
+// SetCacheContext scopes the deployment and image caches of all configs to the given kube context and namespace.
+// Caches that are not scoped yet, e.g. caches saved by older versions, are moved to the kube context and namespace
+// if there is no cache for it yet
+func (config *Config) SetCacheContext(kubeContext, namespace string) {
+	if kubeContext == "" {
+		return
+	}
+
+	config.cacheKubeContext = kubeContext
+	config.cacheNamespace = namespace
+
+	for _, cache := range config.Configs {
+		cache.useContext(kubeContext, namespace)
+	}
+}
+
+// GetCacheContext returns the kube context and namespace the caches are scoped to
+func (config *Config) GetCacheContext() (string, string) {
+	return config.cacheKubeContext, config.cacheNamespace
+}
+
+func (cache *CacheConfig) useContext(kubeContext, namespace string) {
+	if cache.Contexts == nil {
+		cache.Contexts = make(map[string]map[string]*ContextCache)
+	}
+	if cache.Contexts[kubeContext] == nil {
+		cache.Contexts[kubeContext] = make(map[string]*ContextCache)
+	}
+
+	contextCache, ok := cache.Contexts[kubeContext][namespace]
+	if !ok {
+		contextCache = &ContextCache{}
+		if cache.scoped == false {
+			contextCache.Deployments = cache.Deployments
+			contextCache.Images = cache.Images
+		}
+
+		cache.Contexts[kubeContext][namespace] = contextCache
+	}
+
+	if contextCache.Deployments == nil {
+		contextCache.Deployments = make(map[string]*DeploymentCache)
+	}
+	if contextCache.Images == nil {
+		contextCache.Images = make(map[string]*ImageCache)
+	}
+
+	cache.Deployments = contextCache.Deployments
+	cache.Images = contextCache.Images
+	cache.scoped = true
+}
+
+// ResetContexts deletes the deployment and image caches of the given kube context and namespace. An empty kube context
+// or namespace matches all kube contexts or namespaces. Returns the number of deleted caches
+func (cache *CacheConfig) ResetContexts(kubeContext, namespace string) int {
+	deleted := 0
+	for contextName, namespaces := range cache.Contexts {
+		if kubeContext != "" && kubeContext != contextName {
+			continue
+		}
+
+		for namespaceName, contextCache := range namespaces {
+			if namespace != "" && namespace != namespaceName {
+				continue
+			}
+
+			// Clear the maps instead of replacing them, because they might be the currently used caches
+			for name := range contextCache.Deployments {
+				delete(contextCache.Deployments, name)
+			}
+			for name := range contextCache.Images {
+				delete(contextCache.Images, name)
+			}
+
+			delete(namespaces, namespaceName)
+			deleted++
+		}
+
+		if len(namespaces) == 0 {
+			delete(cache.Contexts, contextName)
+		}
+	}
+
+	// Caches that are not scoped belong to every kube context and namespace
+	if cache.scoped == false && (len(cache.Deployments) > 0 || len(cache.Images) > 0) {
+		cache.Deployments = make(map[string]*DeploymentCache)
+		cache.Images = make(map[string]*ImageCache)
+		deleted++
+	}
+
+	return deleted
+}
+
+// GetResolvedImageName returns the image name the image was pushed to
+func (imageCache *ImageCache) GetResolvedImageName() string {
+	if imageCache.ResolvedImageName != "" {
+		return imageCache.ResolvedImageName
+	}
+
+	return imageCache.ImageName
+}
+
+// GetUsedImageTags returns the tags of all image caches of all configs, kube contexts, namespaces and variants by
+// image name. The tags are listed under the image name and the resolved image name
+func (config *Config) GetUsedImageTags() map[string][]string {
+	usedTags := map[string][]string{}
+	config.forEachImageCache(func(imageCache *ImageCache) {
+		if imageCache.Tag != "" {
+			addImageTags(usedTags, imageCache, []string{imageCache.Tag})
+		}
+	})
+
+	return usedTags
+}
+
+// GetGeneratedImageTags returns the random tags devspace generated for the images of all configs, kube contexts,
+// namespaces and variants by image name. The tags are listed under the image name and the resolved image name
+func (config *Config) GetGeneratedImageTags() map[string][]string {
+	generatedTags := map[string][]string{}
+	config.forEachImageCache(func(imageCache *ImageCache) {
+		addImageTags(generatedTags, imageCache, imageCache.GeneratedTags)
+	})
+
+	return generatedTags
+}
+
+// RemoveGeneratedImageTags forgets the given generated tags of the image, e.g. after they were deleted
+func (config *Config) RemoveGeneratedImageTags(imageName string, tags []string) {
+	config.forEachImageCache(func(imageCache *ImageCache) {
+		if imageCache.ImageName != imageName && imageCache.GetResolvedImageName() != imageName {
+			return
+		}
+
+		generatedTags := []string{}
+		for _, tag := range imageCache.GeneratedTags {
+			if containsString(tags, tag) == false {
+				generatedTags = append(generatedTags, tag)
+			}
+		}
+
+		imageCache.GeneratedTags = generatedTags
+	})
+}
+
+// AddGeneratedTag records a random tag devspace generated for the image
+func (imageCache *ImageCache) AddGeneratedTag(tag string) {
+	if containsString(imageCache.GeneratedTags, tag) == false {
+		imageCache.GeneratedTags = append(imageCache.GeneratedTags, tag)
+	}
+}
+
+func addImageTags(tagsByImage map[string][]string, imageCache *ImageCache, tags []string) {
+	for _, imageName := range []string{imageCache.ImageName, imageCache.GetResolvedImageName()} {
+		if imageName == "" {
+			continue
+		}
+
+		for _, tag := range tags {
+			if containsString(tagsByImage[imageName], tag) == false {
+				tagsByImage[imageName] = append(tagsByImage[imageName], tag)
+			}
+		}
+	}
+}
+
+// forEachImageCache calls fn for every image cache of all configs, kube contexts, namespaces and variants
+func (config *Config) forEachImageCache(fn func(imageCache *ImageCache)) {
+	forEach := func(imageCaches map[string]*ImageCache) {
+		for _, imageCache := range imageCaches {
+			fn(imageCache)
+			for _, variantCache := range imageCache.Variants {
+				fn(variantCache)
+			}
+		}
+	}
+
+	for _, cache := range config.Configs {
+		forEach(cache.Images)
+		for _, namespaces := range cache.Contexts {
+			for _, contextCache := range namespaces {
+				forEach(contextCache.Images)
+			}
+		}
+	}
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetImageCache returns the image cache if it exists and creates one if not
 func (cache *CacheConfig) GetImageCache(imageConfigName string) *ImageCache {
 	if _, ok := cache.Images[imageConfigName]; !ok {
@@ -141,6 +403,89 @@ func (cache *CacheConfig) GetImageCache(imageConfigName string) *ImageCache {
 	return cache.Images[imageConfigName]
 }
 
+// SwitchImageVariant makes the cache of the given variant the active image cache and stores the cache of the
+// previously active variant, so switching between variants does not lose the hashes and tag of the other variant
+func (cache *CacheConfig) SwitchImageVariant(imageConfigName, variant string) *ImageCache {
+	imageCache := cache.GetImageCache(imageConfigName)
+	if imageCache.Variant == variant {
+		return imageCache
+	}
+
+	// Copy the variants, because the map could be shared with a copy of this image cache
+	variants := make(map[string]*ImageCache, len(imageCache.Variants))
+	for name, variantCache := range imageCache.Variants {
+		if name != variant {
+			variants[name] = variantCache
+		}
+	}
+
+	previous := *imageCache
+	previous.Variants = nil
+	variants[previous.Variant] = &previous
+
+	newImageCache := &ImageCache{}
+	if variantCache, ok := imageCache.Variants[variant]; ok {
+		copied := *variantCache
+		newImageCache = &copied
+	}
+
+	newImageCache.Variant = variant
+	newImageCache.Variants = variants
+	cache.Images[imageConfigName] = newImageCache
+	return newImageCache
+}
+
+// AddPullSecret records an image pull secret devspace created in the namespace
+func (config *Config) AddPullSecret(kubeContext, namespace, name string) {
+	pullSecrets := config.usePullSecrets(kubeContext, namespace)
+	if containsString(pullSecrets.Secrets, name) == false {
+		pullSecrets.Secrets = append(pullSecrets.Secrets, name)
+	}
+}
+
+// AddServiceAccountPullSecret records an image pull secret devspace added to the default service account of the namespace
+func (config *Config) AddServiceAccountPullSecret(kubeContext, namespace, name string) {
+	pullSecrets := config.usePullSecrets(kubeContext, namespace)
+	if containsString(pullSecrets.ServiceAccount, name) == false {
+		pullSecrets.ServiceAccount = append(pullSecrets.ServiceAccount, name)
+	}
+}
+
+func (config *Config) usePullSecrets(kubeContext, namespace string) *PullSecretCache {
+	if config.PullSecrets == nil {
+		config.PullSecrets = make(map[string]map[string]*PullSecretCache)
+	}
+	if config.PullSecrets[kubeContext] == nil {
+		config.PullSecrets[kubeContext] = make(map[string]*PullSecretCache)
+	}
+	if config.PullSecrets[kubeContext][namespace] == nil {
+		config.PullSecrets[kubeContext][namespace] = &PullSecretCache{}
+	}
+
+	return config.PullSecrets[kubeContext][namespace]
+}
+
+// GetPullSecrets returns the image pull secrets devspace created in the namespace or nil if there are none
+func (config *Config) GetPullSecrets(kubeContext, namespace string) *PullSecretCache {
+	if config.PullSecrets == nil || config.PullSecrets[kubeContext] == nil {
+		return nil
+	}
+
+	return config.PullSecrets[kubeContext][namespace]
+}
+
+// RemovePullSecrets forgets the image pull secrets of the namespace
+func (config *Config) RemovePullSecrets(kubeContext, namespace string) {
+	if config.PullSecrets == nil || config.PullSecrets[kubeContext] == nil {
+		return
+	}
+
+	delete(config.PullSecrets[kubeContext], namespace)
+	if len(config.PullSecrets[kubeContext]) == 0 {
+		delete(config.PullSecrets, kubeContext)
+	}
+}
+
 // GetDeploymentCache returns the deployment cache if it exists and creates one if not
 func (cache *CacheConfig) GetDeploymentCache(deploymentName string) *DeploymentCache {
 	if _, ok := cache.Deployments[deploymentName]; !ok {
@@ -154,6 +499,10 @@ func (cache *CacheConfig) GetDeploymentCache(deploymentName string) *DeploymentC
 func InitDevSpaceConfig(config *Config, configName string) {
 	if _, ok := config.Configs[configName]; ok == false {
 		config.Configs[configName] = NewCache()
+		if config.cacheKubeContext != "" {
+			config.Configs[configName].useContext(config.cacheKubeContext, config.cacheNamespace)
+		}
+
 		return
 	}
 
@@ -169,16 +518,24 @@ func InitDevSpaceConfig(config *Config, configName string) {
 	if config.Configs[configName].Vars == nil {
 		config.Configs[configName].Vars = make(map[string]string)
 	}
+	if config.cacheKubeContext != "" {
+		config.Configs[configName].useContext(config.cacheKubeContext, config.cacheNamespace)
+	}
 }
 
 // SaveConfig saves the config to the filesystem
 func SaveConfig(config *Config) error {
+	workdir, _ := os.Getwd()
+	return SaveConfigInPath(workdir, config)
+}
+
+// SaveConfigInPath saves the config as generated config of the project in the given path
+func SaveConfigInPath(workdir string, config *Config) error {
 	if testDontSaveConfig {
 		return nil
 	}
 
-	workdir, _ := os.Getwd()
-	data, err := yaml.Marshal(config)
+	data, err := yaml.Marshal(getSaveConfig(config))
 	if err != nil {
 		return err
 	}
@@ -191,5 +548,28 @@ func SaveConfig(config *Config) error {
 		return err
 	}
 
+	// Keep the previous version for devspace rollback-state. Saving the config never fails because of the history
+	_ = history.Record(workdir, ConfigPath, data)
+
 	return ioutil.WriteFile(configPath, data, 0666)
 }
+
+// getSaveConfig returns a copy of the config without the deployment and image caches that are already saved
+// within the contexts
+func getSaveConfig(config *Config) *Config {
+	saveConfig := *config
+	saveConfig.Configs = make(map[string]*CacheConfig, len(config.Configs))
+
+	for name, cache := range config.Configs {
+		if cache != nil && cache.scoped {
+			saveCache := *cache
+			saveCache.Deployments = nil
+			saveCache.Images = nil
+			cache = &saveCache
+		}
+
+		saveConfig.Configs[name] = cache
+	}
+
+	return &saveConfig
+}
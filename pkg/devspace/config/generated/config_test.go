@@ -3,11 +3,13 @@ package generated
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
 
+	yaml "gopkg.in/yaml.v2"
 	"gotest.tools/assert"
 )
 
@@ -163,3 +165,177 @@ func TestGetCaches(t *testing.T) {
 	assert.Equal(t, "", deploymentCache.HelmChartHash, "DeploymentCache wrong initialized")
 	assert.Equal(t, "", deploymentCache.KubectlManifestsHash, "DeploymentCache wrong initialized")
 }
+
+func TestSetCacheContext(t *testing.T) {
+	//Create tempDir and go into it
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Config saved by an older version without contexts
+	path := filepath.Join(dir, "generated.yaml")
+	err = fsutil.WriteToFile([]byte(`activeConfig: default
+configs:
+  default:
+    images:
+      backend:
+        tag: old
+`), path)
+	if err != nil {
+		t.Fatalf("Error writing config: %v", err)
+	}
+
+	config, err := LoadConfigFromPath(path)
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	assert.Equal(t, "old", config.GetActive().GetImageCache("backend").Tag, "Old image cache not loaded")
+
+	// The old cache is moved to the first context
+	config.SetCacheContext("minikube", "default")
+	assert.Equal(t, "old", config.GetActive().GetImageCache("backend").Tag, "Old image cache not migrated")
+	assert.Equal(t, "old", config.GetActive().Contexts["minikube"]["default"].Images["backend"].Tag, "Old image cache not migrated")
+
+	// Other contexts and namespaces have their own cache
+	config.SetCacheContext("minikube", "test")
+	assert.Equal(t, "", config.GetActive().GetImageCache("backend").Tag, "Image cache of other namespace not empty")
+	config.GetActive().GetImageCache("backend").Tag = "test"
+
+	config.SetCacheContext("production", "default")
+	assert.Equal(t, "", config.GetActive().GetImageCache("backend").Tag, "Image cache of other context not empty")
+	InitDevSpaceConfig(config, "other")
+	assert.Equal(t, true, config.Configs["other"].Contexts["production"]["default"] != nil, "New config not scoped")
+
+	// Save and load again
+	data, err := yaml.Marshal(getSaveConfig(config))
+	if err != nil {
+		t.Fatalf("Error marshalling config: %v", err)
+	}
+	err = fsutil.WriteToFile(data, path)
+	if err != nil {
+		t.Fatalf("Error writing config: %v", err)
+	}
+
+	config, err = LoadConfigFromPath(path)
+	if err != nil {
+		t.Fatalf("Error loading config: %v", err)
+	}
+	assert.Equal(t, 0, len(config.GetActive().Images), "Scoped images saved without context")
+
+	config.SetCacheContext("minikube", "test")
+	assert.Equal(t, "test", config.GetActive().GetImageCache("backend").Tag, "Image cache of context not saved")
+	config.SetCacheContext("minikube", "default")
+	assert.Equal(t, "old", config.GetActive().GetImageCache("backend").Tag, "Image cache of context not saved")
+
+	// Reset the cache of a single namespace and then all caches
+	assert.Equal(t, 1, config.GetActive().ResetContexts("minikube", "test"), "Wrong number of caches reset")
+	assert.Equal(t, "old", config.GetActive().GetImageCache("backend").Tag, "Wrong cache reset")
+	assert.Equal(t, 2, config.GetActive().ResetContexts("", ""), "Wrong number of caches reset")
+	assert.Equal(t, 0, len(config.GetActive().Images), "Current cache not reset")
+	assert.Equal(t, 0, len(config.GetActive().Contexts), "Contexts not reset")
+}
+
+func TestSwitchImageVariant(t *testing.T) {
+	cache := NewCache()
+	cache.GetImageCache("backend").Tag = "prod"
+	cache.GetImageCache("backend").DockerfileHash = "prodHash"
+
+	// Switching to the dev variant starts with an empty cache
+	imageCache := cache.SwitchImageVariant("backend", "dev")
+	assert.Equal(t, "dev", imageCache.Variant, "Wrong variant")
+	assert.Equal(t, "", imageCache.Tag, "Dev variant not empty")
+	imageCache.Tag = "dev"
+
+	// Switching back restores the cache of the other variant
+	imageCache = cache.SwitchImageVariant("backend", "")
+	assert.Equal(t, "prod", imageCache.Tag, "Prod variant not restored")
+	assert.Equal(t, "prodHash", imageCache.DockerfileHash, "Prod variant not restored")
+	assert.Equal(t, imageCache, cache.GetImageCache("backend"), "Active image cache not replaced")
+
+	imageCache = cache.SwitchImageVariant("backend", "dev")
+	assert.Equal(t, "dev", imageCache.Tag, "Dev variant not restored")
+	assert.Equal(t, 1, len(imageCache.Variants), "Wrong number of stored variants")
+
+	// Switching to the active variant doesn't change anything
+	assert.Equal(t, imageCache, cache.SwitchImageVariant("backend", "dev"), "Image cache changed")
+}
+
+func TestGetUsedImageTags(t *testing.T) {
+	config := &Config{
+		Configs: map[string]*CacheConfig{
+			"default": &CacheConfig{
+				Images: map[string]*ImageCache{
+					"default": &ImageCache{
+						ImageName: "myimage",
+						Tag:       "abcdefg",
+						Variants: map[string]*ImageCache{
+							"dev": &ImageCache{ImageName: "myimage", Tag: "hijklmn"},
+						},
+					},
+				},
+				Contexts: map[string]map[string]*ContextCache{
+					"minikube": map[string]*ContextCache{
+						"default": &ContextCache{
+							Images: map[string]*ImageCache{
+								"default": &ImageCache{ImageName: "myimage", ResolvedImageName: "localhost:5000/myimage", Tag: "opqrstu"},
+							},
+						},
+					},
+				},
+			},
+			"production": &CacheConfig{
+				Images: map[string]*ImageCache{
+					"default": &ImageCache{ImageName: "myimage", Tag: "abcdefg"},
+					"other":   &ImageCache{ImageName: "other"},
+				},
+			},
+		},
+	}
+
+	usedTags := config.GetUsedImageTags()
+	assert.Equal(t, len(usedTags), 2)
+	assert.Equal(t, len(usedTags["myimage"]), 3)
+	for _, tag := range []string{"abcdefg", "hijklmn", "opqrstu"} {
+		assert.Assert(t, containsString(usedTags["myimage"], tag), "Missing tag %s", tag)
+	}
+	assert.DeepEqual(t, usedTags["localhost:5000/myimage"], []string{"opqrstu"})
+}
+
+func TestGeneratedImageTags(t *testing.T) {
+	config := &Config{
+		Configs: map[string]*CacheConfig{
+			"default": &CacheConfig{
+				Images: map[string]*ImageCache{
+					"default": &ImageCache{
+						ImageName:     "myimage",
+						GeneratedTags: []string{"abcdefg"},
+						Variants: map[string]*ImageCache{
+							"dev": &ImageCache{ImageName: "myimage", GeneratedTags: []string{"hijklmn"}},
+						},
+					},
+				},
+				Contexts: map[string]map[string]*ContextCache{
+					"minikube": map[string]*ContextCache{
+						"default": &ContextCache{
+							Images: map[string]*ImageCache{
+								"default": &ImageCache{ImageName: "myimage", ResolvedImageName: "localhost:5000/myimage", GeneratedTags: []string{"opqrstu"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	generatedTags := config.GetGeneratedImageTags()
+	assert.Equal(t, len(generatedTags["myimage"]), 3)
+	assert.DeepEqual(t, generatedTags["localhost:5000/myimage"], []string{"opqrstu"})
+
+	config.RemoveGeneratedImageTags("localhost:5000/myimage", []string{"opqrstu"})
+	config.RemoveGeneratedImageTags("myimage", []string{"abcdefg"})
+	generatedTags = config.GetGeneratedImageTags()
+	assert.DeepEqual(t, generatedTags["myimage"], []string{"hijklmn"})
+	assert.Equal(t, len(generatedTags["localhost:5000/myimage"]), 0)
+}
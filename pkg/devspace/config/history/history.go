@@ -0,0 +1,172 @@
+package history
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
+	"github.com/pkg/errors"
+)
+
+// Path is the folder the previous versions of the saved files are kept in, relative to the project root
+const Path = ".devspace/history"
+
+// DefaultMaxVersions is the number of versions that are kept per file if history.maxVersions is not set
+const DefaultMaxVersions = 10
+
+// idFormat is the time format of the version ids, which sorts the versions by the time they were saved
+const idFormat = "20060102-150405.000000000"
+
+// Version is a previous version of a file
+type Version struct {
+	ID      string
+	File    string
+	Path    string
+	Created time.Time
+}
+
+// Record keeps the current content of the file (relative to basePath) as a new version before it is overwritten with
+// data. Nothing is recorded if the file does not exist yet or the content does not change
+func Record(basePath, file string, data []byte) error {
+	current, err := ioutil.ReadFile(filepath.Join(basePath, file))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if bytes.Equal(current, data) {
+		return nil
+	}
+
+	return record(basePath, file, current)
+}
+
+// IsConfigEnabled returns true if the versions of devspace.yaml that are saved by commands should be kept as well
+func IsConfigEnabled() bool {
+	globalSettings, err := settings.Get()
+	return err == nil && globalSettings.History != nil && globalSettings.History.Config
+}
+
+// List returns the versions of the file (relative to basePath), the newest first
+func List(basePath, file string) ([]*Version, error) {
+	versionsDir := getVersionsDir(basePath, file)
+	files, err := ioutil.ReadDir(versionsDir)
+	if os.IsNotExist(err) {
+		return []*Version{}, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	versions := []*Version{}
+	for _, fileInfo := range files {
+		id := strings.TrimSuffix(fileInfo.Name(), filepath.Ext(fileInfo.Name()))
+		created, err := time.ParseInLocation(idFormat, id, time.Local)
+		if fileInfo.IsDir() || err != nil {
+			continue
+		}
+
+		versions = append(versions, &Version{
+			ID:      id,
+			File:    file,
+			Path:    filepath.Join(versionsDir, fileInfo.Name()),
+			Created: created,
+		})
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].ID > versions[j].ID
+	})
+
+	return versions, nil
+}
+
+// Rollback restores the version of the file (relative to basePath) with the given id. The current content of the file
+// is recorded as a new version before, so the rollback itself can be reverted
+func Rollback(basePath, file, id string) (*Version, error) {
+	versions, err := List(basePath, file)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, version := range versions {
+		if version.ID != id {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(version.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		err = Record(basePath, file, data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "record current version of %s", file)
+		}
+
+		err = ioutil.WriteFile(filepath.Join(basePath, file), data, 0666)
+		if err != nil {
+			return nil, err
+		}
+
+		return version, nil
+	}
+
+	return nil, fmt.Errorf("Couldn't find version %s of %s", id, file)
+}
+
+func record(basePath, file string, data []byte) error {
+	versions, err := List(basePath, file)
+	if err != nil {
+		return err
+	}
+
+	// Don't record the same version twice in a row
+	if len(versions) > 0 {
+		latest, err := ioutil.ReadFile(versions[0].Path)
+		if err == nil && bytes.Equal(latest, data) {
+			return nil
+		}
+	}
+
+	versionsDir := getVersionsDir(basePath, file)
+	err = os.MkdirAll(versionsDir, 0755)
+	if err != nil {
+		return err
+	}
+
+	id := time.Now().Format(idFormat)
+	err = ioutil.WriteFile(filepath.Join(versionsDir, id+filepath.Ext(file)), data, 0666)
+	if err != nil {
+		return err
+	}
+
+	// Remove the oldest versions
+	maxVersions := getMaxVersions()
+	for index, version := range versions {
+		if index+1 >= maxVersions {
+			os.Remove(version.Path)
+		}
+	}
+
+	return nil
+}
+
+func getMaxVersions() int {
+	globalSettings, err := settings.Get()
+	if err != nil || globalSettings.History == nil || globalSettings.History.MaxVersions <= 0 {
+		return DefaultMaxVersions
+	}
+
+	return globalSettings.History.MaxVersions
+}
+
+// getVersionsDir returns the folder the versions of the file are stored in, e.g. .devspace/history/generated.yaml
+func getVersionsDir(basePath, file string) string {
+	name := strings.Replace(filepath.ToSlash(filepath.Clean(file)), "/", "_", -1)
+	return filepath.Join(basePath, Path, strings.TrimPrefix(name, ".devspace_"))
+}
@@ -0,0 +1,74 @@
+package history
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestRecordAndRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testHistory")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	file := ".devspace/generated.yaml"
+	filePath := filepath.Join(dir, file)
+	save := func(data string) {
+		err := Record(dir, file, []byte(data))
+		assert.NilError(t, err)
+
+		err = os.MkdirAll(filepath.Dir(filePath), 0755)
+		assert.NilError(t, err)
+		err = ioutil.WriteFile(filePath, []byte(data), 0666)
+		assert.NilError(t, err)
+	}
+
+	// The first save and saves without changes are not recorded
+	save("v1")
+	save("v1")
+	versions, err := List(dir, file)
+	assert.NilError(t, err)
+	assert.Equal(t, len(versions), 0)
+
+	save("v2")
+	save("v3")
+	versions, err = List(dir, file)
+	assert.NilError(t, err)
+	assert.Equal(t, len(versions), 2)
+	assert.Equal(t, versions[0].Path, filepath.Join(dir, Path, "generated.yaml", versions[0].ID+".yaml"))
+
+	// Restore v1, the current version v3 is recorded
+	version, err := Rollback(dir, file, versions[1].ID)
+	assert.NilError(t, err)
+	assert.Equal(t, version.ID, versions[1].ID)
+
+	data, err := ioutil.ReadFile(filePath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "v1")
+
+	versions, err = List(dir, file)
+	assert.NilError(t, err)
+	assert.Equal(t, len(versions), 3)
+
+	data, err = ioutil.ReadFile(versions[0].Path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(data), "v3")
+
+	_, err = Rollback(dir, file, "does-not-exist")
+	assert.ErrorContains(t, err, "Couldn't find version")
+
+	// Only the newest versions are kept
+	for i := 0; i < DefaultMaxVersions+5; i++ {
+		save("v" + strconv.Itoa(i+10))
+	}
+
+	versions, err = List(dir, file)
+	assert.NilError(t, err)
+	assert.Equal(t, len(versions), DefaultMaxVersions)
+}
@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+)
+
+// Draft is the json schema draft the generated schemas are compatible with
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Types used in the generated schemas
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema is a (very small) subset of a json schema that is sufficient to describe the devspace config
+type Schema struct {
+	SchemaDraft string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+
+	// Type is empty if any value is allowed
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties interface{}        `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+}
+
+var (
+	configSchema     *Schema
+	configSchemaOnce sync.Once
+)
+
+// Config returns the json schema of the latest config version
+func Config() *Schema {
+	configSchemaOnce.Do(func() {
+		configSchema = Generate(reflect.TypeOf(latest.Config{}))
+		configSchema.SchemaDraft = Draft
+		configSchema.Title = "DevSpace config " + latest.Version
+	})
+
+	return configSchema
+}
+
+// Generate generates a json schema from the given type. Struct fields are named by their yaml tags
+// and unknown fields are not allowed in structs
+func Generate(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		schema := &Schema{
+			Type:                 TypeObject,
+			Properties:           map[string]*Schema{},
+			AdditionalProperties: false,
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if field.PkgPath != "" {
+				continue
+			}
+
+			name := getFieldName(field)
+			if name == "-" {
+				continue
+			}
+
+			schema.Properties[name] = Generate(field.Type)
+		}
+
+		return schema
+	case reflect.Map:
+		schema := &Schema{
+			Type: TypeObject,
+		}
+
+		valueSchema := Generate(t.Elem())
+		if valueSchema.Type != "" {
+			schema.AdditionalProperties = valueSchema
+		}
+
+		return schema
+	case reflect.Slice, reflect.Array:
+		return &Schema{
+			Type:  TypeArray,
+			Items: Generate(t.Elem()),
+		}
+	case reflect.String:
+		return &Schema{Type: TypeString}
+	case reflect.Bool:
+		return &Schema{Type: TypeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: TypeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: TypeNumber}
+	}
+
+	// interface{} and all other types can hold any value
+	return &Schema{}
+}
+
+func getFieldName(field reflect.StructField) string {
+	tag := field.Tag.Get("yaml")
+	if tag == "" {
+		return strings.ToLower(field.Name)
+	}
+
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+
+	return name
+}
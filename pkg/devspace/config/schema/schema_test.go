@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestConfigSchema(t *testing.T) {
+	configSchema := Config()
+	if configSchema.Type != TypeObject || configSchema.AdditionalProperties != false {
+		t.Fatalf("Expected config schema to be a strict object, got %#v", configSchema)
+	}
+
+	images := configSchema.Properties["images"]
+	if images == nil || images.Type != TypeObject {
+		t.Fatalf("Expected images to be an object, got %#v", images)
+	}
+
+	imageSchema, ok := images.AdditionalProperties.(*Schema)
+	if !ok {
+		t.Fatalf("Expected images to have an additional properties schema, got %#v", images.AdditionalProperties)
+	}
+	if imageSchema.Properties["tags"] == nil || imageSchema.Properties["tags"].Type != TypeArray || imageSchema.Properties["tags"].Items.Type != TypeString {
+		t.Fatalf("Unexpected schema for images.*.tags: %#v", imageSchema.Properties["tags"])
+	}
+
+	_, err := json.Marshal(configSchema)
+	if err != nil {
+		t.Fatalf("Error marshalling schema: %v", err)
+	}
+}
+
+const testConfig = `version: v1beta2
+images:
+  default:
+    image: my-user/my-image
+    tagz: latest
+    createPullSecret: "yes"
+deployments:
+- name: my-deployment
+  helm:
+    chart:
+      name: ./chart
+    wait: true
+- name: other-deployment
+  kubectl:
+    manifests: kube/deployment.yaml
+hooks:
+  - command: echo
+    args: ["hello"]
+    events: before:dev
+dev:
+  ports:
+  - labelSelector:
+      app: test
+    forward:
+    - port: "abc"
+`
+
+func TestValidate(t *testing.T) {
+	data := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(testConfig), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := Validate(Config(), data, []byte(testConfig))
+	expected := []string{
+		"line 15: deployments[1].kubectl.manifests: expected array, but got string",
+		"line 25: dev.ports[0].forward[0].port: expected integer, but got string",
+		"line 19: hooks[0].events: expected array, but got string",
+		"line 6: images.default.createPullSecret: expected boolean, but got string",
+		"line 5: images.default.tagz: unknown field",
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(expected), len(errs), errs)
+	}
+
+	for index, err := range errs {
+		if err.Error() != expected[index] {
+			t.Fatalf("Expected error %q, got %q", expected[index], err.Error())
+		}
+	}
+}
+
+func TestValidateValidConfig(t *testing.T) {
+	data := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(`version: v1beta2
+images:
+  default:
+    image: my-user/my-image
+    tag: 123
+deployments:
+- name: my-deployment
+  helm:
+    timeout: 180
+    values:
+      any:
+        value: true
+`), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := Validate(Config(), data, nil)
+	if len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+}
+
+func TestFindLine(t *testing.T) {
+	tests := map[string]int{
+		"images.default.tagz":             5,
+		"deployments.0.name":              8,
+		"deployments.1.kubectl.manifests": 15,
+		"hooks.0.args":                    18,
+		"dev.ports.0.labelSelector.app":   23,
+		"dev.ports.0.forward.0.port":      25,
+		"deployments.1.helm":              13,
+		"notexisting":                     0,
+	}
+
+	for path, expectedLine := range tests {
+		line := FindLine([]byte(testConfig), strings.Split(path, "."))
+		if line != expectedLine {
+			t.Fatalf("Expected line %d for %s, got %d", expectedLine, path, line)
+		}
+	}
+}
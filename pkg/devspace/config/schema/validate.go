@@ -0,0 +1,284 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Error is a single schema violation within the config
+type Error struct {
+	// Path are the keys and list indices that lead to the offending field
+	Path    []string
+	Message string
+
+	// Line is the line of the field within the config file or 0 if it couldn't be found
+	Line int
+}
+
+// Field returns the path of the field in the format images.default.build or hooks[0].command
+func (e *Error) Field() string {
+	field := ""
+	for _, element := range e.Path {
+		if _, err := strconv.Atoi(element); err == nil {
+			field += "[" + element + "]"
+		} else if field == "" {
+			field = element
+		} else {
+			field += "." + element
+		}
+	}
+
+	return field
+}
+
+func (e *Error) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("line %d: %s: %s", e.Line, e.Field(), e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Field(), e.Message)
+}
+
+// Validate validates the parsed yaml data against the schema and returns all violations. If the yaml content
+// the data was parsed from is passed, the line numbers of the offending fields are filled in
+func Validate(schema *Schema, data interface{}, content []byte) []*Error {
+	errs := validateValue(schema, data, []string{})
+	if content != nil {
+		for _, err := range errs {
+			err.Line = FindLine(content, err.Path)
+		}
+	}
+
+	return errs
+}
+
+func validateValue(schema *Schema, value interface{}, path []string) []*Error {
+	if schema == nil || schema.Type == "" || value == nil {
+		return nil
+	}
+
+	switch schema.Type {
+	case TypeObject:
+		object, ok := value.(map[interface{}]interface{})
+		if !ok {
+			return []*Error{newTypeError(schema.Type, value, path)}
+		}
+
+		return validateObject(schema, object, path)
+	case TypeArray:
+		array, ok := value.([]interface{})
+		if !ok {
+			return []*Error{newTypeError(schema.Type, value, path)}
+		}
+
+		errs := []*Error{}
+		for index, item := range array {
+			errs = append(errs, validateValue(schema.Items, item, appendPath(path, strconv.Itoa(index)))...)
+		}
+
+		return errs
+	case TypeString:
+		// Yaml allows every scalar to be used as a string
+		switch value.(type) {
+		case map[interface{}]interface{}, []interface{}:
+			return []*Error{newTypeError(schema.Type, value, path)}
+		}
+	case TypeInteger:
+		switch v := value.(type) {
+		case int, int64, uint64:
+		case float64:
+			if v != math.Trunc(v) {
+				return []*Error{newTypeError(schema.Type, value, path)}
+			}
+		default:
+			return []*Error{newTypeError(schema.Type, value, path)}
+		}
+	case TypeNumber:
+		switch value.(type) {
+		case int, int64, uint64, float64:
+		default:
+			return []*Error{newTypeError(schema.Type, value, path)}
+		}
+	case TypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return []*Error{newTypeError(schema.Type, value, path)}
+		}
+	}
+
+	return nil
+}
+
+func validateObject(schema *Schema, object map[interface{}]interface{}, path []string) []*Error {
+	// Sort the keys to return the errors in a stable order
+	keys := make([]string, 0, len(object))
+	values := make(map[string]interface{}, len(object))
+	for key, value := range object {
+		keyString := fmt.Sprintf("%v", key)
+		keys = append(keys, keyString)
+		values[keyString] = value
+	}
+	sort.Strings(keys)
+
+	errs := []*Error{}
+	for _, key := range keys {
+		fieldPath := appendPath(path, key)
+		if propertySchema, ok := schema.Properties[key]; ok {
+			errs = append(errs, validateValue(propertySchema, values[key], fieldPath)...)
+			continue
+		}
+
+		switch additionalProperties := schema.AdditionalProperties.(type) {
+		case bool:
+			if additionalProperties == false {
+				errs = append(errs, &Error{
+					Path:    fieldPath,
+					Message: "unknown field" + getSuggestion(schema, key),
+				})
+			}
+		case *Schema:
+			errs = append(errs, validateValue(additionalProperties, values[key], fieldPath)...)
+		}
+	}
+
+	return errs
+}
+
+// getSuggestion returns a hint for an unknown field that only differs in case from a known field
+func getSuggestion(schema *Schema, key string) string {
+	for property := range schema.Properties {
+		if strings.EqualFold(property, key) {
+			return fmt.Sprintf(" (did you mean %s?)", property)
+		}
+	}
+
+	return ""
+}
+
+func newTypeError(expected string, value interface{}, path []string) *Error {
+	return &Error{
+		Path:    path,
+		Message: fmt.Sprintf("expected %s, but got %s", expected, getType(value)),
+	}
+}
+
+func getType(value interface{}) string {
+	switch value.(type) {
+	case map[interface{}]interface{}:
+		return TypeObject
+	case []interface{}:
+		return TypeArray
+	case string:
+		return TypeString
+	case bool:
+		return TypeBoolean
+	case int, int64, uint64:
+		return TypeInteger
+	case float64:
+		return TypeNumber
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+func appendPath(path []string, element string) []string {
+	newPath := make([]string, len(path), len(path)+1)
+	copy(newPath, path)
+	return append(newPath, element)
+}
+
+var keyRegEx = regexp.MustCompile(`^("[^"]*"|'[^']*'|[^\s"'#:\-\[{][^:#]*?|-[^\s:#][^:#]*?)\s*:(\s|$)`)
+
+type lineElement struct {
+	indent int
+	name   string
+	item   bool
+	items  int
+}
+
+// FindLine returns the line of the field with the given path within the yaml content. If the field itself
+// cannot be found, the line of the closest parent is returned. FindLine returns 0 if not even the first
+// element of the path can be found
+func FindLine(content []byte, path []string) int {
+	var (
+		stack     = []*lineElement{}
+		bestMatch = 0
+		bestLine  = 0
+	)
+
+	for lineIndex, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "---") {
+			continue
+		}
+
+		indent := len(line) - len(trimmed)
+		for {
+			if trimmed == "-" || strings.HasPrefix(trimmed, "- ") {
+				// List item: remove all deeper elements and the previous item on the same level
+				for len(stack) > 0 && (stack[len(stack)-1].indent > indent || (stack[len(stack)-1].indent == indent && stack[len(stack)-1].item)) {
+					stack = stack[:len(stack)-1]
+				}
+
+				index := 0
+				if len(stack) > 0 {
+					index = stack[len(stack)-1].items
+					stack[len(stack)-1].items++
+				}
+
+				stack = append(stack, &lineElement{indent: indent, name: strconv.Itoa(index), item: true})
+				if match := matchPath(stack, path); match > bestMatch {
+					bestMatch, bestLine = match, lineIndex+1
+				}
+
+				// The content after the dash is handled as if it would start on a new line
+				rest := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+				indent += len(trimmed) - len(rest)
+				trimmed = rest
+				if trimmed == "" {
+					break
+				}
+
+				continue
+			}
+
+			matches := keyRegEx.FindStringSubmatch(trimmed)
+			if matches != nil {
+				for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+					stack = stack[:len(stack)-1]
+				}
+
+				stack = append(stack, &lineElement{indent: indent, name: strings.Trim(strings.TrimSpace(matches[1]), "\"'")})
+				if match := matchPath(stack, path); match > bestMatch {
+					bestMatch, bestLine = match, lineIndex+1
+				}
+			}
+
+			break
+		}
+
+		if bestMatch == len(path) {
+			break
+		}
+	}
+
+	return bestLine
+}
+
+// matchPath returns the length of the path if the stack is equal to the (beginning of the) path and 0 otherwise
+func matchPath(stack []*lineElement, path []string) int {
+	if len(stack) > len(path) {
+		return 0
+	}
+
+	for index, element := range stack {
+		if element.name != path[index] {
+			return 0
+		}
+	}
+
+	return len(stack)
+}
@@ -30,31 +30,79 @@ func NewRaw() *Config {
 
 // Config defines the configuration
 type Config struct {
-	Version      *string                  `yaml:"version"`
-	Images       *map[string]*ImageConfig `yaml:"images,omitempty"`
-	Deployments  *[]*DeploymentConfig     `yaml:"deployments,omitempty"`
-	Dev          *DevConfig               `yaml:"dev,omitempty"`
-	Dependencies *[]*DependencyConfig     `yaml:"dependencies,omitempty"`
-	Hooks        *[]*HookConfig           `yaml:"hooks,omitempty"`
-	Cluster      *Cluster                 `yaml:"cluster,omitempty"`
+	Version       *string                  `yaml:"version"`
+	Images        *map[string]*ImageConfig `yaml:"images,omitempty"`
+	Deployments   *[]*DeploymentConfig     `yaml:"deployments,omitempty"`
+	Dev           *DevConfig               `yaml:"dev,omitempty"`
+	Dependencies  *[]*DependencyConfig     `yaml:"dependencies,omitempty"`
+	Hooks         *[]*HookConfig           `yaml:"hooks,omitempty"`
+	Notifications *[]*NotificationConfig   `yaml:"notifications,omitempty"`
+	Cluster       *Cluster                 `yaml:"cluster,omitempty"`
+
+	// RegistryAliases maps image name prefixes (e.g. docker.io/myorg) to the prefixes images are pushed to and
+	// pulled from instead (e.g. localhost:5000/myorg)
+	RegistryAliases *map[string]string `yaml:"registryAliases,omitempty"`
+
+	// Requires defines the DevSpace CLI version and features the config needs
+	Requires *RequiresConfig `yaml:"requires,omitempty"`
+}
+
+// RequiresConfig defines the requirements of a config to the DevSpace CLI
+type RequiresConfig struct {
+	// DevSpace is the version range of the DevSpace CLI, e.g. >=3.5.0
+	DevSpace *string `yaml:"devspace,omitempty"`
+
+	// Features are the features the DevSpace CLI has to support
+	Features *[]string `yaml:"features,omitempty"`
 }
 
 // ImageConfig defines the image specification
 type ImageConfig struct {
-	Image            *string      `yaml:"image"`
-	Tag              *string      `yaml:"tag,omitempty"`
-	Dockerfile       *string      `yaml:"dockerfile,omitempty"`
-	Context          *string      `yaml:"context,omitempty"`
-	CreatePullSecret *bool        `yaml:"createPullSecret,omitempty"`
-	Build            *BuildConfig `yaml:"build,omitempty"`
+	Image            *string         `yaml:"image"`
+	Tag              *string         `yaml:"tag,omitempty"`
+	Tags             *[]string       `yaml:"tags,omitempty"`
+	Dockerfile       *string         `yaml:"dockerfile,omitempty"`
+	Context          *string         `yaml:"context,omitempty"`
+	CreatePullSecret *bool           `yaml:"createPullSecret,omitempty"`
+	InjectPullSecret *bool           `yaml:"injectPullSecret,omitempty"`
+	CredentialHelper *string         `yaml:"credentialHelper,omitempty"`
+	PullPolicy       *string         `yaml:"pullPolicy,omitempty"`
+	InjectEnv        *bool           `yaml:"injectEnv,omitempty"`
+	Dev              *ImageDevConfig `yaml:"dev,omitempty"`
+	Build            *BuildConfig    `yaml:"build,omitempty"`
+}
+
+// ImageDevConfig defines the dockerfile, target and pull policy that are only used when the image is built during devspace dev
+type ImageDevConfig struct {
+	Dockerfile *string `yaml:"dockerfile,omitempty"`
+	Target     *string `yaml:"target,omitempty"`
+	PullPolicy *string `yaml:"pullPolicy,omitempty"`
 }
 
 // BuildConfig defines the build process for an image
 type BuildConfig struct {
-	Disabled *bool         `yaml:"disabled,omitempty"`
-	Docker   *DockerConfig `yaml:"docker,omitempty"`
-	Kaniko   *KanikoConfig `yaml:"kaniko,omitempty"`
-	Custom   *CustomConfig `yaml:"custom,omitempty"`
+	Disabled *bool           `yaml:"disabled,omitempty"`
+	Docker   *DockerConfig   `yaml:"docker,omitempty"`
+	Kaniko   *KanikoConfig   `yaml:"kaniko,omitempty"`
+	Pod      *PodBuildConfig `yaml:"pod,omitempty"`
+	Custom   *CustomConfig   `yaml:"custom,omitempty"`
+	Export   *ExportConfig   `yaml:"export,omitempty"`
+
+	// PreferInCluster builds the image in the cluster without asking if the docker daemon is not reachable. If false,
+	// the build fails instead
+	PreferInCluster *bool `yaml:"preferInCluster,omitempty"`
+
+	// Env sets environment variables for the build. Build args without a value are resolved from them first
+	Env *map[string]string `yaml:"env,omitempty"`
+
+	// ProxyBuildArgs passes the proxy environment variables (HTTP_PROXY, HTTPS_PROXY, NO_PROXY, ...) as build args
+	ProxyBuildArgs *bool `yaml:"proxyBuildArgs,omitempty"`
+}
+
+// ExportConfig tells the docker builder to save the image to a local path instead of pushing it
+type ExportConfig struct {
+	Type *string `yaml:"type,omitempty"`
+	Dest *string `yaml:"dest"`
 }
 
 // DockerConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost
@@ -68,12 +116,53 @@ type DockerConfig struct {
 // KanikoConfig tells the DevSpace CLI to build with Docker on Minikube or on localhost
 type KanikoConfig struct {
 	Cache        *bool         `yaml:"cache,omitempty"`
+	CacheRepo    *string       `yaml:"cacheRepo,omitempty"`
+	CacheTTL     *string       `yaml:"cacheTTL,omitempty"`
 	SnapshotMode *string       `yaml:"snapshotMode,omitempty"`
 	Flags        *[]*string    `yaml:"flags,omitempty"`
 	Namespace    *string       `yaml:"namespace,omitempty"`
 	Insecure     *bool         `yaml:"insecure,omitempty"`
 	PullSecret   *string       `yaml:"pullSecret,omitempty"`
+	WaitTimeout  *int          `yaml:"waitTimeout,omitempty"`
+	Reuse        *bool         `yaml:"reuse,omitempty"`
 	Options      *BuildOptions `yaml:"options,omitempty"`
+
+	// Scheduling and metadata of the kaniko build pod
+	Resources      *PodResources       `yaml:"resources,omitempty"`
+	NodeSelector   *map[string]*string `yaml:"nodeSelector,omitempty"`
+	Tolerations    *[]*PodToleration   `yaml:"tolerations,omitempty"`
+	ServiceAccount *string             `yaml:"serviceAccount,omitempty"`
+	Annotations    *map[string]*string `yaml:"annotations,omitempty"`
+}
+
+// PodBuildConfig tells the DevSpace CLI to build with buildah or img within a pod in the cluster
+type PodBuildConfig struct {
+	Tool         *string             `yaml:"tool,omitempty"`
+	Image        *string             `yaml:"image,omitempty"`
+	Flags        *[]*string          `yaml:"flags,omitempty"`
+	Namespace    *string             `yaml:"namespace,omitempty"`
+	Insecure     *bool               `yaml:"insecure,omitempty"`
+	PullSecret   *string             `yaml:"pullSecret,omitempty"`
+	Privileged   *bool               `yaml:"privileged,omitempty"`
+	Resources    *PodResources       `yaml:"resources,omitempty"`
+	NodeSelector *map[string]*string `yaml:"nodeSelector,omitempty"`
+	WaitTimeout  *int                `yaml:"waitTimeout,omitempty"`
+	Options      *BuildOptions       `yaml:"options,omitempty"`
+}
+
+// PodResources defines the resource requests and limits of a pod that is created by the DevSpace CLI
+type PodResources struct {
+	Requests *map[string]*string `yaml:"requests,omitempty"`
+	Limits   *map[string]*string `yaml:"limits,omitempty"`
+}
+
+// PodToleration defines a toleration of a pod that is created by the DevSpace CLI
+type PodToleration struct {
+	Key               *string `yaml:"key,omitempty"`
+	Operator          *string `yaml:"operator,omitempty"`
+	Value             *string `yaml:"value,omitempty"`
+	Effect            *string `yaml:"effect,omitempty"`
+	TolerationSeconds *int64  `yaml:"tolerationSeconds,omitempty"`
 }
 
 // CustomConfig tells the DevSpace CLI to build with a custom build script
@@ -82,6 +171,15 @@ type CustomConfig struct {
 	Args      *[]*string `yaml:"flags,omitempty"`
 	ImageFlag *string    `yaml:"imageFlag,omitempty"`
 	OnChange  *[]*string `yaml:"onChange,omitempty"`
+
+	Commands *[]*OSCommandConfig `yaml:"commands,omitempty"`
+}
+
+// OSCommandConfig defines a command that is used instead of the default command on the specified operating systems
+type OSCommandConfig struct {
+	OS      *[]*string `yaml:"os"`
+	Command *string    `yaml:"command"`
+	Args    *[]*string `yaml:"args,omitempty"`
 }
 
 // BuildOptions defines options for building Docker images
@@ -89,15 +187,22 @@ type BuildOptions struct {
 	Target    *string             `yaml:"target,omitempty"`
 	Network   *string             `yaml:"network,omitempty"`
 	BuildArgs *map[string]*string `yaml:"buildArgs,omitempty"`
+	SSH       *[]string           `yaml:"ssh,omitempty"`
+	Secrets   *[]string           `yaml:"secrets,omitempty"`
 }
 
 // DeploymentConfig defines the configuration how the devspace should be deployed
 type DeploymentConfig struct {
-	Name      *string          `yaml:"name"`
-	Namespace *string          `yaml:"namespace,omitempty"`
-	Component *ComponentConfig `yaml:"component,omitempty"`
-	Helm      *HelmConfig      `yaml:"helm,omitempty"`
-	Kubectl   *KubectlConfig   `yaml:"kubectl,omitempty"`
+	Name        *string                         `yaml:"name"`
+	Namespace   *string                         `yaml:"namespace,omitempty"`
+	KubeContext *string                         `yaml:"kubeContext,omitempty"`
+	Component   *ComponentConfig                `yaml:"component,omitempty"`
+	Helm        *HelmConfig                     `yaml:"helm,omitempty"`
+	Kubectl     *KubectlConfig                  `yaml:"kubectl,omitempty"`
+	Env         *[]*map[interface{}]interface{} `yaml:"env,omitempty"`
+	Protected   *bool                           `yaml:"protected,omitempty"`
+	Wait        *bool                           `yaml:"wait,omitempty"`
+	WaitTimeout *int                            `yaml:"waitTimeout,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -112,6 +217,8 @@ type ComponentConfig struct {
 	PodManagementPolicy *string                 `yaml:"podManagementPolicy,omitempty"`
 	PullSecrets         *[]*string              `yaml:"pullSecrets,omitempty"`
 	Options             *ComponentConfigOptions `yaml:"options,omitempty"`
+
+	RenderOnly *bool `yaml:"renderOnly,omitempty"`
 }
 
 // ContainerConfig holds the configurations of a container
@@ -190,6 +297,7 @@ type ComponentConfigOptions struct {
 	Force           *bool   `yaml:"force,omitempty"`
 	Timeout         *int64  `yaml:"timeout,omitempty"`
 	TillerNamespace *string `yaml:"tillerNamespace,omitempty"`
+	TillerTimeout   *int64  `yaml:"tillerTimeout,omitempty"`
 }
 
 // HelmConfig defines the specific helm options used during deployment
@@ -200,9 +308,14 @@ type HelmConfig struct {
 	Force           *bool                        `yaml:"force,omitempty"`
 	Timeout         *int64                       `yaml:"timeout,omitempty"`
 	TillerNamespace *string                      `yaml:"tillerNamespace,omitempty"`
+	TillerTimeout   *int64                       `yaml:"tillerTimeout,omitempty"`
 	DevSpaceValues  *bool                        `yaml:"devSpaceValues,omitempty"`
 	ValuesFiles     *[]*string                   `yaml:"valuesFiles,omitempty"`
+	InlineValues    *string                      `yaml:"inlineValues,omitempty"`
 	Values          *map[interface{}]interface{} `yaml:"values,omitempty"`
+	Remote          *RemoteConfig                `yaml:"remote,omitempty"`
+	RunTests        *bool                        `yaml:"runTests,omitempty"`
+	DebugChart      *bool                        `yaml:"debugChart,omitempty"`
 }
 
 // ChartConfig defines the helm chart options
@@ -216,20 +329,45 @@ type ChartConfig struct {
 
 // KubectlConfig defines the specific kubectl options used during deployment
 type KubectlConfig struct {
-	CmdPath   *string    `yaml:"cmdPath,omitempty"`
-	Manifests *[]*string `yaml:"manifests,omitempty"`
-	Kustomize *bool      `yaml:"kustomize,omitempty"`
-	Flags     *[]*string `yaml:"flags,omitempty"`
+	CmdPath        *string       `yaml:"cmdPath,omitempty"`
+	Manifests      *[]*string    `yaml:"manifests,omitempty"`
+	InlineManifest *string       `yaml:"inlineManifest,omitempty"`
+	Kustomize      *bool         `yaml:"kustomize,omitempty"`
+	Flags          *[]*string    `yaml:"flags,omitempty"`
+	Remote         *RemoteConfig `yaml:"remote,omitempty"`
+	ForceNamespace *bool         `yaml:"forceNamespace,omitempty"`
+}
+
+// RemoteConfig defines how manifests and values files are fetched from http(s) urls
+type RemoteConfig struct {
+	Headers  *map[string]string `yaml:"headers,omitempty"`
+	TokenVar *string            `yaml:"tokenVar,omitempty"`
 }
 
 // DevConfig defines the devspace deployment
 type DevConfig struct {
 	OverrideImages *[]*ImageOverrideConfig  `yaml:"overrideImages,omitempty"`
 	Terminal       *Terminal                `yaml:"terminal,omitempty"`
+	Terminals      *[]*Terminal             `yaml:"terminals,omitempty"`
 	Ports          *[]*PortForwardingConfig `yaml:"ports,omitempty"`
 	Sync           *[]*SyncConfig           `yaml:"sync,omitempty"`
 	AutoReload     *AutoReloadConfig        `yaml:"autoReload,omitempty"`
 	Selectors      *[]*SelectorConfig       `yaml:"selectors,omitempty"`
+	Debug          *[]*DebugConfig          `yaml:"debug,omitempty"`
+
+	ReadinessTimeout *int `yaml:"readinessTimeout,omitempty"`
+}
+
+// DebugConfig defines how an image is started under a debugger during devspace dev --debug
+type DebugConfig struct {
+	Image         *string             `yaml:"image"`
+	Runtime       *string             `yaml:"runtime"`
+	Command       *[]*string          `yaml:"command"`
+	Port          *int                `yaml:"port,omitempty"`
+	LocalPort     *int                `yaml:"localPort,omitempty"`
+	Selector      *string             `yaml:"selector,omitempty"`
+	Namespace     *string             `yaml:"namespace,omitempty"`
+	LabelSelector *map[string]*string `yaml:"labelSelector,omitempty"`
 }
 
 // ImageOverrideConfig holds information about what parts of the image config are overwritten during devspace dev
@@ -242,12 +380,23 @@ type ImageOverrideConfig struct {
 
 // Terminal describes the terminal options
 type Terminal struct {
+	Name          *string             `yaml:"name,omitempty"`
 	Disabled      *bool               `yaml:"disabled,omitempty"`
 	Selector      *string             `yaml:"selector,omitempty"`
 	LabelSelector *map[string]*string `yaml:"labelSelector,omitempty"`
 	Namespace     *string             `yaml:"namespace,omitempty"`
 	ContainerName *string             `yaml:"containerName,omitempty"`
 	Command       *[]*string          `yaml:"command,omitempty"`
+	Reconnect     *TerminalReconnect  `yaml:"reconnect,omitempty"`
+
+	RestoreState *bool `yaml:"restoreState,omitempty"`
+}
+
+// TerminalReconnect defines if and how a terminal is reopened after its pod or container was restarted
+type TerminalReconnect struct {
+	Disabled   *bool `yaml:"disabled,omitempty"`
+	MaxRetries *int  `yaml:"maxRetries,omitempty"`
+	RetryDelay *int  `yaml:"retryDelay,omitempty"`
 }
 
 // PortForwardingConfig defines the ports for a port forwarding to a DevSpace
@@ -255,6 +404,7 @@ type PortForwardingConfig struct {
 	Selector      *string             `yaml:"selector,omitempty"`
 	Namespace     *string             `yaml:"namespace,omitempty"`
 	LabelSelector *map[string]*string `yaml:"labelSelector,omitempty"`
+	ImageName     *string             `yaml:"imageName,omitempty"`
 	PortMappings  *[]*PortMapping     `yaml:"forward"`
 }
 
@@ -270,6 +420,7 @@ type SyncConfig struct {
 	Selector             *string             `yaml:"selector,omitempty"`
 	Namespace            *string             `yaml:"namespace,omitempty"`
 	LabelSelector        *map[string]*string `yaml:"labelSelector,omitempty"`
+	ImageName            *string             `yaml:"imageName,omitempty"`
 	ContainerName        *string             `yaml:"containerName,omitempty"`
 	LocalSubPath         *string             `yaml:"localSubPath,omitempty"`
 	ContainerPath        *string             `yaml:"containerPath,omitempty"`
@@ -277,7 +428,43 @@ type SyncConfig struct {
 	ExcludePaths         *[]string           `yaml:"excludePaths,omitempty"`
 	DownloadExcludePaths *[]string           `yaml:"downloadExcludePaths,omitempty"`
 	UploadExcludePaths   *[]string           `yaml:"uploadExcludePaths,omitempty"`
+	ExcludeFromGitignore *bool               `yaml:"excludeFromGitignore,omitempty"`
 	BandwidthLimits      *BandwidthLimits    `yaml:"bandwidthLimits,omitempty"`
+	PollingInterval      *int64              `yaml:"pollingInterval,omitempty"`
+	DebounceInterval     *int64              `yaml:"debounceInterval,omitempty"`
+	Transport            *string             `yaml:"transport,omitempty"`
+	CompareBy            *string             `yaml:"compareBy,omitempty"`
+	ConvertLineEndings   *bool               `yaml:"convertLineEndings,omitempty"`
+
+	UID *int64 `yaml:"uid,omitempty"`
+	GID *int64 `yaml:"gid,omitempty"`
+
+	OnUpload   *SyncOnUpload   `yaml:"onUpload,omitempty"`
+	OnDownload *SyncOnDownload `yaml:"onDownload,omitempty"`
+}
+
+// SyncOnUpload defines what is done in the container after local changes were uploaded
+type SyncOnUpload struct {
+	RestartContainer *RestartContainerConfig `yaml:"restartContainer,omitempty"`
+	ExecRemote       *SyncExecConfig         `yaml:"execRemote,omitempty"`
+}
+
+// SyncOnDownload defines what is done locally after remote changes were downloaded
+type SyncOnDownload struct {
+	ExecLocal *SyncExecConfig `yaml:"execLocal,omitempty"`
+}
+
+// SyncExecConfig defines a command that is executed once for every batch of synchronized changes
+type SyncExecConfig struct {
+	Command *string    `yaml:"command"`
+	Args    *[]*string `yaml:"args,omitempty"`
+}
+
+// RestartContainerConfig defines how the process in the container is restarted, either by sending a signal to it
+// or by running a command in the container
+type RestartContainerConfig struct {
+	Signal  *string   `yaml:"signal,omitempty"`
+	Command *[]string `yaml:"command,omitempty"`
 }
 
 // BandwidthLimits defines the struct for specifying the sync bandwidth limits
@@ -299,6 +486,8 @@ type SelectorConfig struct {
 	Namespace     *string             `yaml:"namespace,omitempty"`
 	LabelSelector *map[string]*string `yaml:"labelSelector"`
 	ContainerName *string             `yaml:"containerName,omitempty"`
+
+	PodName *string `yaml:"podName,omitempty"`
 }
 
 // DependencyConfig defines the devspace dependency
@@ -308,6 +497,12 @@ type DependencyConfig struct {
 	SkipBuild          *bool         `yaml:"skipBuild,omitempty"`
 	IgnoreDependencies *bool         `yaml:"ignoreDependencies,omitempty"`
 	Namespace          *string       `yaml:"namespace,omitempty"`
+
+	// Vars are the values of the variables of the dependency config
+	Vars *map[string]string `yaml:"vars,omitempty"`
+
+	// Values are the helm values of a chart dependency
+	Values *map[interface{}]interface{} `yaml:"values,omitempty"`
 }
 
 // SourceConfig defines the dependency source
@@ -318,14 +513,20 @@ type SourceConfig struct {
 	Revision *string `yaml:"revision,omitempty"`
 
 	Path *string `yaml:"path,omitempty"`
+
+	Chart *ChartConfig `yaml:"chart,omitempty"`
 }
 
 // HookConfig defines a hook
 type HookConfig struct {
-	Command *string    `yaml:"command"`
-	Args    *[]*string `yaml:"args,omitempty"`
+	Command  *string             `yaml:"command"`
+	Args     *[]*string          `yaml:"args,omitempty"`
+	Commands *[]*OSCommandConfig `yaml:"commands,omitempty"`
 
-	When *HookWhenConfig `yaml:"when,omitempty"`
+	When       *HookWhenConfig `yaml:"when,omitempty"`
+	Events     *[]*string      `yaml:"events,omitempty"`
+	OS         *[]*string      `yaml:"os,omitempty"`
+	Background *bool           `yaml:"background,omitempty"`
 }
 
 // HookWhenConfig defines when the hook should be executed
@@ -340,6 +541,14 @@ type HookWhenAtConfig struct {
 	Deployments *string `yaml:"deployments,omitempty"`
 }
 
+// NotificationConfig defines a webhook that is called when a command succeeded or failed
+type NotificationConfig struct {
+	URL      *string    `yaml:"url"`
+	Commands *[]*string `yaml:"commands,omitempty"`
+	On       *[]*string `yaml:"on,omitempty"`
+	Message  *string    `yaml:"message,omitempty"`
+}
+
 // Cluster is a struct that contains data for a Kubernetes-Cluster
 type Cluster struct {
 	KubeContext *string `yaml:"kubeContext,omitempty"`
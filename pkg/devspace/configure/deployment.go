@@ -2,6 +2,7 @@ package configure
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -11,10 +12,14 @@ import (
 	v1 "github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/generator"
 	dockerfileutil "github.com/devspace-cloud/devspace/pkg/util/dockerfile"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
 	"github.com/pkg/errors"
 )
 
+// invalidNameCharRegEx matches characters that are not allowed within a deployment name
+var invalidNameCharRegEx = regexp.MustCompile("[^a-z0-9-]+")
+
 // GetDockerfileComponentDeployment returns a new deployment that deploys an image built from a local dockerfile via a component
 func GetDockerfileComponentDeployment(config *latest.Config, generatedConfig *generated.Config, name, imageName, dockerfile, context string) (*latest.ImageConfig, *latest.DeploymentConfig, error) {
 	var imageConfig *latest.ImageConfig
@@ -125,6 +130,65 @@ func GetImageComponentDeployment(name, imageName string) (*latest.ImageConfig, *
 	return retImageConfig, retDeploymentConfig, nil
 }
 
+// GetQuickDeployment returns a component deployment that deploys the given image without asking any questions.
+// If ports are given, a service is created for them that is of type LoadBalancer if expose is true
+func GetQuickDeployment(name, imageName string, ports []int, expose bool) *latest.DeploymentConfig {
+	if name == "" {
+		name = GetDeploymentNameFromImage(imageName)
+	}
+
+	deploymentConfig := &latest.DeploymentConfig{
+		Name: &name,
+		Component: &latest.ComponentConfig{
+			Containers: &[]*latest.ContainerConfig{
+				{
+					Image: &imageName,
+				},
+			},
+		},
+	}
+
+	if len(ports) > 0 {
+		servicePorts := []*latest.ServicePortConfig{}
+		for _, port := range ports {
+			servicePort := port
+			servicePorts = append(servicePorts, &latest.ServicePortConfig{
+				Port: &servicePort,
+			})
+		}
+
+		deploymentConfig.Component.Service = &latest.ServiceConfig{
+			Ports: &servicePorts,
+		}
+		if expose {
+			deploymentConfig.Component.Service.Type = ptr.String("LoadBalancer")
+		}
+	}
+
+	return deploymentConfig
+}
+
+// GetDeploymentNameFromImage returns a valid deployment name for the image, e.g. app for registry.com/user/app:1.0
+func GetDeploymentNameFromImage(imageName string) string {
+	name := imageName
+	if index := strings.Index(name, "@"); index != -1 {
+		name = name[:index]
+	}
+	if index := strings.LastIndex(name, "/"); index != -1 {
+		name = name[index+1:]
+	}
+	if index := strings.Index(name, ":"); index != -1 {
+		name = name[:index]
+	}
+
+	name = strings.Trim(invalidNameCharRegEx.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if name == "" {
+		return "quickstart"
+	}
+
+	return name
+}
+
 // GetPredefinedComponentDeployment returns deployment that uses a predefined component
 func GetPredefinedComponentDeployment(name, component string) (*latest.DeploymentConfig, error) {
 	// Create component generator
@@ -185,12 +249,11 @@ func GetHelmDeployment(name, chartName, chartRepo, chartVersion string) (*latest
 }
 
 // RemoveDeployment removes one or all deployments from the config
-func RemoveDeployment(removeAll bool, name string) (bool, error) {
+func RemoveDeployment(config *latest.Config, loader *configutil.ConfigLoader, removeAll bool, name string) (bool, error) {
 	if name == "" && removeAll == false {
 		return false, errors.New("You have to specify either a deployment name or the --all flag")
 	}
 
-	config := configutil.GetBaseConfig()
 	found := false
 
 	if config.Deployments != nil {
@@ -207,7 +270,7 @@ func RemoveDeployment(removeAll bool, name string) (bool, error) {
 		config.Deployments = &newDeployments
 	}
 
-	err := configutil.SaveLoadedConfig()
+	err := saveConfig(config, loader)
 	if err != nil {
 		return false, fmt.Errorf("Couldn't save config file: %s", err.Error())
 	}
@@ -7,3 +7,41 @@ import (
 func TestGetDockerfileComponentDeployment(t *testing.T) {
 
 }
+
+func TestGetQuickDeployment(t *testing.T) {
+	deploymentConfig := GetQuickDeployment("", "nginx:latest", []int{80, 443}, true)
+	if *deploymentConfig.Name != "nginx" {
+		t.Fatalf("Expected deployment name nginx, got %s", *deploymentConfig.Name)
+	}
+	if *(*deploymentConfig.Component.Containers)[0].Image != "nginx:latest" {
+		t.Fatalf("Unexpected container image %s", *(*deploymentConfig.Component.Containers)[0].Image)
+	}
+	if deploymentConfig.Component.Service == nil || len(*deploymentConfig.Component.Service.Ports) != 2 || *(*deploymentConfig.Component.Service.Ports)[1].Port != 443 {
+		t.Fatalf("Unexpected service %#v", deploymentConfig.Component.Service)
+	}
+	if deploymentConfig.Component.Service.Type == nil || *deploymentConfig.Component.Service.Type != "LoadBalancer" {
+		t.Fatalf("Expected exposed service to be of type LoadBalancer")
+	}
+
+	deploymentConfig = GetQuickDeployment("my-app", "nginx", nil, true)
+	if *deploymentConfig.Name != "my-app" || deploymentConfig.Component.Service != nil {
+		t.Fatalf("Unexpected deployment without ports %#v", deploymentConfig)
+	}
+}
+
+func TestGetDeploymentNameFromImage(t *testing.T) {
+	tests := map[string]string{
+		"nginx":                         "nginx",
+		"nginx:1.17":                    "nginx",
+		"localhost:5000/user/my_app:v1": "my-app",
+		"gcr.io/project/Backend@sha256:abcdef12345": "backend",
+		"__": "quickstart",
+	}
+
+	for imageName, expected := range tests {
+		name := GetDeploymentNameFromImage(imageName)
+		if name != expected {
+			t.Fatalf("Expected name %s for image %s, got %s", expected, imageName, name)
+		}
+	}
+}
@@ -248,9 +248,7 @@ func GetImageConfigFromDockerfile(config *latest.Config, dockerfile, context str
 }
 
 // AddImage adds an image to the devspace
-func AddImage(nameInConfig, name, tag, contextPath, dockerfilePath, buildEngine string) error {
-	config := configutil.GetBaseConfig()
-
+func AddImage(config *latest.Config, loader *configutil.ConfigLoader, nameInConfig, name, tag, contextPath, dockerfilePath, buildEngine string) error {
 	imageConfig := &v1.ImageConfig{
 		Image: &name,
 	}
@@ -288,7 +286,7 @@ func AddImage(nameInConfig, name, tag, contextPath, dockerfilePath, buildEngine
 
 	(*config.Images)[nameInConfig] = imageConfig
 
-	err := configutil.SaveLoadedConfig()
+	err := saveConfig(config, loader)
 	if err != nil {
 		return fmt.Errorf("Couldn't save config file: %s", err.Error())
 	}
@@ -297,9 +295,7 @@ func AddImage(nameInConfig, name, tag, contextPath, dockerfilePath, buildEngine
 }
 
 //RemoveImage removes an image from the devspace
-func RemoveImage(removeAll bool, names []string) error {
-	config := configutil.GetBaseConfig()
-
+func RemoveImage(config *latest.Config, loader *configutil.ConfigLoader, removeAll bool, names []string) error {
 	if len(names) == 0 && removeAll == false {
 		return fmt.Errorf("You have to specify at least one image")
 	}
@@ -322,7 +318,7 @@ func RemoveImage(removeAll bool, names []string) error {
 
 	config.Images = &newImageList
 
-	err := configutil.SaveLoadedConfig()
+	err := saveConfig(config, loader)
 	if err != nil {
 		return fmt.Errorf("Couldn't save config file: %v", err)
 	}
@@ -3,6 +3,7 @@ package configure
 import (
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
@@ -48,33 +49,36 @@ func TestAddAndRemoveImage(t *testing.T) { //Create tempDir and go into it
 		}
 	}()
 
-	configutil.SetFakeConfig(&latest.Config{})
-	config := configutil.GetBaseConfig()
-	config.Images = nil
+	config := &latest.Config{}
+	loader := configutil.NewConfigLoader(".", nil)
 	fsutil.WriteToFile([]byte(""), "devspace.yaml")
-	AddImage("NewTestImage", "TestImageName", "vTest", "mycontext", "Dockerfile", "docker")
+	AddImage(config, loader, "NewTestImage", "TestImageName", "vTest", "mycontext", "Dockerfile", "docker")
 	assert.Equal(t, 1, len(*config.Images), "New image not added: Wrong number of images")
 	assert.Equal(t, "TestImageName", *(*config.Images)["NewTestImage"].Image, "New image not correctly added")
 	assert.Equal(t, "vTest", *(*config.Images)["NewTestImage"].Tag, "New image not correctly added")
 
-	AddImage("SecoundTestImage", "SecoundImageName", "v2", "mycontext", "Dockerfile", "kaniko")
+	content, err := fsutil.ReadFile("devspace.yaml", -1)
+	assert.NilError(t, err, "Error reading saved config")
+	assert.Assert(t, strings.Contains(string(content), "image: TestImageName"), "New image not saved")
+
+	AddImage(config, loader, "SecoundTestImage", "SecoundImageName", "v2", "mycontext", "Dockerfile", "kaniko")
 	assert.Equal(t, 2, len(*config.Images), "New image not added: Wrong number of images")
 	assert.Equal(t, "SecoundImageName", *(*config.Images)["SecoundTestImage"].Image, "New image not correctly added")
 	assert.Equal(t, "v2", *(*config.Images)["SecoundTestImage"].Tag, "New image not correctly added")
 
-	AddImage("ThirdTestImage", "ThirdImageName", "v3", "mycontext", "Dockerfile", "wrongBuildEngine")
+	AddImage(config, loader, "ThirdTestImage", "ThirdImageName", "v3", "mycontext", "Dockerfile", "wrongBuildEngine")
 	assert.Equal(t, 3, len(*config.Images), "New image not added: Wrong number of images")
 	assert.Equal(t, "ThirdImageName", *(*config.Images)["ThirdTestImage"].Image, "New image not correctly added")
 	assert.Equal(t, "v3", *(*config.Images)["ThirdTestImage"].Tag, "New image not correctly added")
 
-	err = RemoveImage(false, []string{})
+	err = RemoveImage(config, loader, false, []string{})
 	assert.Error(t, err, "You have to specify at least one image")
 
-	err = RemoveImage(false, []string{"Doesn'tExist"})
+	err = RemoveImage(config, loader, false, []string{"Doesn'tExist"})
 	assert.NilError(t, err, "Error removing non existent image: %v")
 	assert.Equal(t, 3, len(*config.Images), "RemoveImage removed an image that wasn't specified")
 
-	err = RemoveImage(false, []string{"SecoundTestImage"})
+	err = RemoveImage(config, loader, false, []string{"SecoundTestImage"})
 	assert.NilError(t, err, "Error removing existent image: %v")
 	assert.Equal(t, 2, len(*config.Images), "RemoveImage doesn't remove a specified image")
 	assert.Equal(t, true, (*config.Images)["SecoundTestImage"] == nil, "RemoveImage removed wrong image")
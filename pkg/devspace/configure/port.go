@@ -2,11 +2,13 @@ package configure
 
 import (
 	"fmt"
+	"net"
 	"strconv"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services"
 )
 
 // GetNameOfFirstDeployment retrieves the first deployment name
@@ -22,20 +24,36 @@ func GetNameOfFirstDeployment(config *latest.Config) string {
 	return "devspace"
 }
 
-// AddPort adds a port to the config
-func AddPort(namespace, labelSelector, serviceName string, args []string) error {
+// AddPort adds a port to the config. If bindAddress is not empty, the local ports are bound to this address
+func AddPort(config *latest.Config, loader *configutil.ConfigLoader, namespace, labelSelector, serviceName, bindAddress string, args []string) error {
 	var labelSelectorMap map[string]*string
 	var err error
 
-	config := configutil.GetBaseConfig()
 	if labelSelector != "" && serviceName != "" {
 		return fmt.Errorf("both service and label-selector specified. This is illegal because the label-selector is already specified in the referenced service. Therefore defining both is redundant")
 	}
+	if bindAddress != "" && bindAddress != "localhost" && net.ParseIP(bindAddress) == nil {
+		return fmt.Errorf("Invalid bind address %s: expected an ip address or localhost", bindAddress)
+	}
 
 	portMappings, err := parsePortMappings(args[0])
 	if err != nil {
 		return fmt.Errorf("Error parsing port mappings: %s", err.Error())
 	}
+	if bindAddress != "" {
+		for _, portMapping := range portMappings {
+			portMapping.BindAddress = &bindAddress
+		}
+	}
+
+	if config.Dev == nil {
+		config.Dev = &latest.DevConfig{}
+	}
+
+	err = checkDuplicateLocalPorts(config, portMappings)
+	if err != nil {
+		return err
+	}
 
 	// Add to first existing port mapping if labelselector and service name are empty
 	if labelSelector == "" && serviceName == "" && config.Dev != nil && config.Dev.Ports != nil && len(*config.Dev.Ports) > 0 {
@@ -48,7 +66,7 @@ func AddPort(namespace, labelSelector, serviceName string, args []string) error
 			*configMappings = append(*configMappings, portMapping)
 		}
 
-		return configutil.SaveLoadedConfig()
+		return saveConfig(config, loader)
 	} else if labelSelector == "" {
 		if config.Dev != nil && config.Dev.Selectors != nil && len(*config.Dev.Selectors) > 0 {
 			services := *config.Dev.Selectors
@@ -76,7 +94,7 @@ func AddPort(namespace, labelSelector, serviceName string, args []string) error
 	}
 
 	insertOrReplacePortMapping(config, namespace, labelSelectorMap, serviceName, portMappings)
-	err = configutil.SaveLoadedConfig()
+	err = saveConfig(config, loader)
 	if err != nil {
 		return fmt.Errorf("Couldn't save config file: %s", err.Error())
 	}
@@ -85,9 +103,7 @@ func AddPort(namespace, labelSelector, serviceName string, args []string) error
 }
 
 // RemovePort removes a port from the config
-func RemovePort(removeAll bool, labelSelector string, args []string) error {
-	config := configutil.GetBaseConfig()
-
+func RemovePort(config *latest.Config, loader *configutil.ConfigLoader, removeAll bool, labelSelector string, args []string) error {
 	labelSelectorMap, err := parseSelectors(labelSelector)
 	if err != nil {
 		return fmt.Errorf("Error parsing selectors: %s", err.Error())
@@ -131,7 +147,7 @@ func RemovePort(removeAll bool, labelSelector string, args []string) error {
 
 		config.Dev.Ports = &newPortForwards
 
-		err = configutil.SaveLoadedConfig()
+		err = saveConfig(config, loader)
 		if err != nil {
 			return fmt.Errorf("Couldn't save config file: %s", err.Error())
 		}
@@ -140,6 +156,35 @@ func RemovePort(removeAll bool, labelSelector string, args []string) error {
 	return nil
 }
 
+// checkDuplicateLocalPorts returns an error if a local port of the new port mappings is already used by another
+// port mapping with an overlapping bind address
+func checkDuplicateLocalPorts(config *latest.Config, portMappings []*latest.PortMapping) error {
+	existing := []*latest.PortMapping{}
+	if config.Dev != nil && config.Dev.Ports != nil {
+		for _, portForwarding := range *config.Dev.Ports {
+			if portForwarding.PortMappings != nil {
+				existing = append(existing, *portForwarding.PortMappings...)
+			}
+		}
+	}
+
+	for _, portMapping := range portMappings {
+		for _, existingMapping := range existing {
+			if existingMapping.LocalPort == nil || *existingMapping.LocalPort != *portMapping.LocalPort {
+				continue
+			}
+
+			if services.BindAddressesOverlap(existingMapping.BindAddress, portMapping.BindAddress) {
+				return fmt.Errorf("Local port %d is already forwarded, please choose another local port (e.g. %d:%d)", *portMapping.LocalPort, *portMapping.LocalPort+1, *portMapping.RemotePort)
+			}
+		}
+
+		existing = append(existing, portMapping)
+	}
+
+	return nil
+}
+
 func containsPort(port string, ports []string) bool {
 	for _, v := range ports {
 		if strings.TrimSpace(v) == port {
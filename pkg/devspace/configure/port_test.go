@@ -0,0 +1,139 @@
+package configure
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
+
+type addPortTestCase struct {
+	name string
+
+	fakeConfig       *latest.Config
+	bindAddressParam string
+	portsParam       string
+
+	expectedErr          string
+	expectedLocalPorts   []int
+	expectedBindAddress  string
+	expectedPortMappings int
+}
+
+func TestAddPort(t *testing.T) {
+	existingPorts := func() *latest.Config {
+		return &latest.Config{
+			Dev: &latest.DevConfig{
+				Ports: &[]*latest.PortForwardingConfig{
+					&latest.PortForwardingConfig{
+						LabelSelector: &map[string]*string{"app": ptr.String("test")},
+						PortMappings: &[]*latest.PortMapping{
+							&latest.PortMapping{LocalPort: ptr.Int(8080)},
+							&latest.PortMapping{LocalPort: ptr.Int(9090), BindAddress: ptr.String("192.168.0.10")},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []addPortTestCase{
+		addPortTestCase{
+			name:             "Invalid bind address",
+			fakeConfig:       existingPorts(),
+			portsParam:       "3000",
+			bindAddressParam: "not-an-ip",
+			expectedErr:      "Invalid bind address not-an-ip: expected an ip address or localhost",
+		},
+		addPortTestCase{
+			name:        "Duplicate local port",
+			fakeConfig:  existingPorts(),
+			portsParam:  "8080:80",
+			expectedErr: "Local port 8080 is already forwarded, please choose another local port (e.g. 8081:80)",
+		},
+		addPortTestCase{
+			name:        "Duplicate local port in args",
+			fakeConfig:  existingPorts(),
+			portsParam:  "3000,3000:80",
+			expectedErr: "Local port 3000 is already forwarded, please choose another local port (e.g. 3001:80)",
+		},
+		addPortTestCase{
+			name:             "Duplicate local port on all interfaces",
+			fakeConfig:       existingPorts(),
+			portsParam:       "9090",
+			bindAddressParam: "0.0.0.0",
+			expectedErr:      "Local port 9090 is already forwarded, please choose another local port (e.g. 9091:9090)",
+		},
+		addPortTestCase{
+			name:                 "Same local port on another address",
+			fakeConfig:           existingPorts(),
+			portsParam:           "9090",
+			expectedLocalPorts:   []int{8080, 9090, 9090},
+			expectedPortMappings: 3,
+		},
+		addPortTestCase{
+			name:                 "Add port with bind address",
+			fakeConfig:           existingPorts(),
+			portsParam:           "3000:80",
+			bindAddressParam:     "0.0.0.0",
+			expectedLocalPorts:   []int{8080, 9090, 3000},
+			expectedBindAddress:  "0.0.0.0",
+			expectedPortMappings: 3,
+		},
+	}
+
+	//Make temporary test dir
+	dir, err := ioutil.TempDir("", "testDir")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+
+	defer func() {
+		err = os.Chdir(wdBackup)
+		if err != nil {
+			t.Fatalf("Error changing dir back: %v", err)
+		}
+		err = os.RemoveAll(dir)
+		if err != nil {
+			t.Fatalf("Error removing dir: %v", err)
+		}
+	}()
+
+	for _, testCase := range testCases {
+		err := AddPort(testCase.fakeConfig, configutil.NewConfigLoader(".", nil), "", "", "", testCase.bindAddressParam, []string{testCase.portsParam})
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error from AddPort in testCase %s", testCase.name)
+			assert.Equal(t, len(*(*testCase.fakeConfig.Dev.Ports)[0].PortMappings), 2, "Port mappings changed after error in testCase %s", testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, "Error adding port in testCase %s", testCase.name)
+
+		portMappings := *(*testCase.fakeConfig.Dev.Ports)[0].PortMappings
+		assert.Equal(t, len(portMappings), testCase.expectedPortMappings, "Wrong number of port mappings in testCase %s", testCase.name)
+		for index, localPort := range testCase.expectedLocalPorts {
+			assert.Equal(t, *portMappings[index].LocalPort, localPort, "Wrong local port in testCase %s", testCase.name)
+		}
+
+		lastMapping := portMappings[len(portMappings)-1]
+		if testCase.expectedBindAddress == "" {
+			assert.Assert(t, lastMapping.BindAddress == nil, "Unexpected bind address in testCase %s", testCase.name)
+		} else {
+			assert.Equal(t, *lastMapping.BindAddress, testCase.expectedBindAddress, "Wrong bind address in testCase %s", testCase.name)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package configure
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// saveConfig restores the variables of the config and writes it to the file the loader loaded it from
+func saveConfig(config *latest.Config, loader *configutil.ConfigLoader) error {
+	clonedConfig, err := loader.RestoreVars(config)
+	if err != nil {
+		return errors.Wrap(err, "restore vars")
+	}
+
+	return loader.Save(clonedConfig)
+}
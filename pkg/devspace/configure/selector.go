@@ -8,9 +8,7 @@ import (
 )
 
 // AddSelector adds a selector
-func AddSelector(name string, labelSelector string, namespace string, save bool) error {
-	config := configutil.GetBaseConfig()
-
+func AddSelector(config *v1.Config, loader *configutil.ConfigLoader, name string, labelSelector string, namespace string, save bool) error {
 	var labelSelectorMap map[string]*string
 	var err error
 
@@ -47,7 +45,7 @@ func AddSelector(name string, labelSelector string, namespace string, save bool)
 	config.Dev.Selectors = &servicesConfig
 
 	if save {
-		err = configutil.SaveLoadedConfig()
+		err = saveConfig(config, loader)
 		if err != nil {
 			return fmt.Errorf("Couldn't save config file: %s", err.Error())
 		}
@@ -57,8 +55,7 @@ func AddSelector(name string, labelSelector string, namespace string, save bool)
 }
 
 // RemoveSelector removes a service from the devspace
-func RemoveSelector(removeAll bool, name string, labelSelector string, namespace string) error {
-	config := configutil.GetBaseConfig()
+func RemoveSelector(config *v1.Config, loader *configutil.ConfigLoader, removeAll bool, name string, labelSelector string, namespace string) error {
 	labelSelectorMap, err := parseSelectors(labelSelector)
 
 	if err != nil {
@@ -85,7 +82,7 @@ func RemoveSelector(removeAll bool, name string, labelSelector string, namespace
 
 		config.Dev.Selectors = &newServicesPaths
 
-		err = configutil.SaveLoadedConfig()
+		err = saveConfig(config, loader)
 		if err != nil {
 			return fmt.Errorf("Couldn't save config file: %v", err)
 		}
@@ -12,9 +12,7 @@ import (
 )
 
 // AddSyncPath adds a new sync path to the config
-func AddSyncPath(localPath, containerPath, namespace, labelSelector, excludedPathsString, serviceName string) error {
-	config := configutil.GetBaseConfig()
-
+func AddSyncPath(config *latest.Config, loader *configutil.ConfigLoader, localPath, containerPath, namespace, labelSelector, excludedPathsString, serviceName string) error {
 	if config.Dev == nil {
 		config.Dev = &latest.DevConfig{}
 	}
@@ -92,7 +90,7 @@ func AddSyncPath(localPath, containerPath, namespace, labelSelector, excludedPat
 
 	config.Dev.Sync = &Sync
 
-	err = configutil.SaveLoadedConfig()
+	err = saveConfig(config, loader)
 	if err != nil {
 		return fmt.Errorf("Couldn't save config file: %s", err.Error())
 	}
@@ -101,8 +99,7 @@ func AddSyncPath(localPath, containerPath, namespace, labelSelector, excludedPat
 }
 
 // RemoveSyncPath removes a sync path from the config
-func RemoveSyncPath(removeAll bool, localPath, containerPath, labelSelector string) error {
-	config := configutil.GetBaseConfig()
+func RemoveSyncPath(config *latest.Config, loader *configutil.ConfigLoader, removeAll bool, localPath, containerPath, labelSelector string) error {
 	labelSelectorMap, err := parseSelectors(labelSelector)
 
 	if err != nil {
@@ -129,7 +126,7 @@ func RemoveSyncPath(removeAll bool, localPath, containerPath, labelSelector stri
 
 		config.Dev.Sync = &newSyncPaths
 
-		err = configutil.SaveLoadedConfig()
+		err = saveConfig(config, loader)
 		if err != nil {
 			return fmt.Errorf("Couldn't save config file: %v", err)
 		}
@@ -115,9 +115,7 @@ func TestAddSyncPath(t *testing.T) {
 		if testCase.fakeConfig == nil {
 			testCase.fakeConfig = &latest.Config{}
 		}
-		configutil.SetFakeConfig(testCase.fakeConfig)
-
-		err := AddSyncPath(testCase.localPathParam, testCase.containerPathParam, testCase.namespace, testCase.labelSelectorParam, testCase.excludedPathsStringParam, testCase.serviceNameParam)
+		err := AddSyncPath(testCase.fakeConfig, configutil.NewConfigLoader(".", nil), testCase.localPathParam, testCase.containerPathParam, testCase.namespace, testCase.labelSelectorParam, testCase.excludedPathsStringParam, testCase.serviceNameParam)
 
 		if testCase.expectedErr == "" {
 			assert.NilError(t, err, "Error adding sync path in testCase %s", testCase.name)
@@ -238,9 +236,7 @@ func TestRemoveSyncPath(t *testing.T) {
 				},
 			} //default config
 		}
-		configutil.SetFakeConfig(testCase.fakeConfig)
-
-		err := RemoveSyncPath(testCase.removeAllParam, testCase.localPathParam, testCase.containerPathParam, testCase.labelSelectorParam)
+		err := RemoveSyncPath(testCase.fakeConfig, configutil.NewConfigLoader(".", nil), testCase.removeAllParam, testCase.localPathParam, testCase.containerPathParam, testCase.labelSelectorParam)
 		if testCase.expectedErr == "" {
 			assert.NilError(t, err, "Error initializing namespace in testCase %s", testCase.name)
 		} else {
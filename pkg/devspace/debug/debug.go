@@ -0,0 +1,156 @@
+package debug
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+)
+
+// Supported debug runtimes
+const (
+	RuntimeGo   = "go"
+	RuntimeNode = "node"
+	RuntimeJava = "java"
+)
+
+// DefaultPorts holds the port each debugger listens on if no port is configured
+var DefaultPorts = map[string]int{
+	RuntimeGo:   2345,
+	RuntimeNode: 9229,
+	RuntimeJava: 5005,
+}
+
+// GetPort returns the port the debugger listens on in the container
+func GetPort(debugConfig *latest.DebugConfig) int {
+	if debugConfig.Port != nil {
+		return *debugConfig.Port
+	}
+
+	return DefaultPorts[*debugConfig.Runtime]
+}
+
+// GetEntrypoint returns the entrypoint that starts the configured command under the debugger of the runtime
+func GetEntrypoint(debugConfig *latest.DebugConfig) ([]string, error) {
+	if debugConfig.Runtime == nil {
+		return nil, fmt.Errorf("No runtime specified")
+	}
+
+	command := []string{}
+	if debugConfig.Command != nil {
+		for _, str := range *debugConfig.Command {
+			if str != nil {
+				command = append(command, *str)
+			}
+		}
+	}
+	if len(command) == 0 {
+		return nil, fmt.Errorf("Command is empty")
+	}
+
+	port := strconv.Itoa(GetPort(debugConfig))
+
+	switch *debugConfig.Runtime {
+	case RuntimeGo:
+		entrypoint := []string{"dlv", "--listen=:" + port, "--headless=true", "--api-version=2", "--accept-multiclient", "exec", command[0]}
+		if len(command) > 1 {
+			entrypoint = append(entrypoint, "--")
+			entrypoint = append(entrypoint, command[1:]...)
+		}
+
+		return entrypoint, nil
+	case RuntimeNode:
+		return append([]string{command[0], "--inspect=0.0.0.0:" + port}, command[1:]...), nil
+	case RuntimeJava:
+		return append([]string{command[0], "-agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:" + port}, command[1:]...), nil
+	}
+
+	return nil, fmt.Errorf("Unsupported runtime %s (supported: go, node, java)", *debugConfig.Runtime)
+}
+
+// Apply rewrites the dev config, so that the images in dev.debug are started under a debugger and the debug ports
+// are forwarded during devspace dev
+func Apply(config *latest.Config, log log.Logger) error {
+	if config.Dev == nil || config.Dev.Debug == nil || len(*config.Dev.Debug) == 0 {
+		return fmt.Errorf("No debug configuration found. Please add a dev.debug section to your devspace.yaml")
+	}
+
+	if config.Dev.OverrideImages == nil {
+		config.Dev.OverrideImages = &[]*latest.ImageOverrideConfig{}
+	}
+	if config.Dev.Ports == nil {
+		config.Dev.Ports = &[]*latest.PortForwardingConfig{}
+	}
+
+	// Debug ports are added to the port forwarding of the first pod if no selector is specified
+	var defaultPorts *latest.PortForwardingConfig
+	if len(*config.Dev.Ports) > 0 {
+		defaultPorts = (*config.Dev.Ports)[0]
+	}
+
+	for index, debugConfig := range *config.Dev.Debug {
+		entrypoint, err := GetEntrypoint(debugConfig)
+		if err != nil {
+			return fmt.Errorf("dev.debug[%d]: %v", index, err)
+		}
+
+		setEntrypoint(config, *debugConfig.Image, entrypoint)
+
+		portForwarding := &latest.PortForwardingConfig{
+			Selector:      debugConfig.Selector,
+			Namespace:     debugConfig.Namespace,
+			LabelSelector: debugConfig.LabelSelector,
+		}
+		if debugConfig.Selector == nil && debugConfig.LabelSelector == nil {
+			if defaultPorts == nil {
+				return fmt.Errorf("dev.debug[%d]: Please specify a selector or labelSelector to select the pod to debug", index)
+			}
+
+			portForwarding.Selector = defaultPorts.Selector
+			portForwarding.LabelSelector = defaultPorts.LabelSelector
+			if portForwarding.Namespace == nil {
+				portForwarding.Namespace = defaultPorts.Namespace
+			}
+		}
+
+		port := GetPort(debugConfig)
+		localPort := port
+		if debugConfig.LocalPort != nil {
+			localPort = *debugConfig.LocalPort
+		}
+
+		portForwarding.PortMappings = &[]*latest.PortMapping{
+			&latest.PortMapping{
+				LocalPort:  ptr.Int(localPort),
+				RemotePort: ptr.Int(port),
+			},
+		}
+
+		*config.Dev.Ports = append(*config.Dev.Ports, portForwarding)
+		log.Infof("Debugger (%s) of image %s will be available on localhost:%d", *debugConfig.Runtime, *debugConfig.Image, localPort)
+	}
+
+	return nil
+}
+
+// setEntrypoint sets the entrypoint of the image override config for the image or creates a new one
+func setEntrypoint(config *latest.Config, imageName string, entrypoint []string) {
+	entrypointPtrs := []*string{}
+	for _, str := range entrypoint {
+		entrypointPtrs = append(entrypointPtrs, ptr.String(str))
+	}
+
+	for _, overrideImageConfig := range *config.Dev.OverrideImages {
+		if overrideImageConfig.Name != nil && *overrideImageConfig.Name == imageName {
+			overrideImageConfig.Entrypoint = &entrypointPtrs
+			return
+		}
+	}
+
+	*config.Dev.OverrideImages = append(*config.Dev.OverrideImages, &latest.ImageOverrideConfig{
+		Name:       ptr.String(imageName),
+		Entrypoint: &entrypointPtrs,
+	})
+}
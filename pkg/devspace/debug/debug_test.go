@@ -0,0 +1,114 @@
+package debug
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+)
+
+func TestGetEntrypoint(t *testing.T) {
+	tests := map[string]*latest.DebugConfig{
+		"dlv --listen=:2345 --headless=true --api-version=2 --accept-multiclient exec ./main -- --verbose": &latest.DebugConfig{
+			Runtime: ptr.String(RuntimeGo),
+			Command: &[]*string{ptr.String("./main"), ptr.String("--verbose")},
+		},
+		"node --inspect=0.0.0.0:9230 index.js": &latest.DebugConfig{
+			Runtime: ptr.String(RuntimeNode),
+			Command: &[]*string{ptr.String("node"), ptr.String("index.js")},
+			Port:    ptr.Int(9230),
+		},
+		"java -agentlib:jdwp=transport=dt_socket,server=y,suspend=n,address=*:5005 -jar app.jar": &latest.DebugConfig{
+			Runtime: ptr.String(RuntimeJava),
+			Command: &[]*string{ptr.String("java"), ptr.String("-jar"), ptr.String("app.jar")},
+		},
+	}
+
+	for expected, debugConfig := range tests {
+		entrypoint, err := GetEntrypoint(debugConfig)
+		if err != nil {
+			t.Fatalf("Error getting entrypoint for %s: %v", *debugConfig.Runtime, err)
+		}
+		if strings.Join(entrypoint, " ") != expected {
+			t.Fatalf("Expected entrypoint %s, got %s", expected, strings.Join(entrypoint, " "))
+		}
+	}
+
+	_, err := GetEntrypoint(&latest.DebugConfig{Runtime: ptr.String("python"), Command: &[]*string{ptr.String("main.py")}})
+	if err == nil {
+		t.Fatalf("Expected error for unsupported runtime")
+	}
+}
+
+func TestApply(t *testing.T) {
+	config := &latest.Config{
+		Dev: &latest.DevConfig{
+			OverrideImages: &[]*latest.ImageOverrideConfig{
+				&latest.ImageOverrideConfig{
+					Name:       ptr.String("default"),
+					Dockerfile: ptr.String("Dockerfile.dev"),
+				},
+			},
+			Ports: &[]*latest.PortForwardingConfig{
+				&latest.PortForwardingConfig{
+					LabelSelector: &map[string]*string{"app": ptr.String("test")},
+				},
+			},
+			Debug: &[]*latest.DebugConfig{
+				&latest.DebugConfig{
+					Image:     ptr.String("default"),
+					Runtime:   ptr.String(RuntimeNode),
+					Command:   &[]*string{ptr.String("node"), ptr.String("index.js")},
+					LocalPort: ptr.Int(9000),
+				},
+				&latest.DebugConfig{
+					Image:    ptr.String("backend"),
+					Runtime:  ptr.String(RuntimeGo),
+					Command:  &[]*string{ptr.String("./main")},
+					Selector: ptr.String("backend"),
+				},
+			},
+		},
+	}
+
+	err := Apply(config, &log.DiscardLogger{})
+	if err != nil {
+		t.Fatalf("Error applying debug config: %v", err)
+	}
+
+	overrideImages := *config.Dev.OverrideImages
+	if len(overrideImages) != 2 || *overrideImages[0].Dockerfile != "Dockerfile.dev" || *(*overrideImages[0].Entrypoint)[1] != "--inspect=0.0.0.0:9229" {
+		t.Fatalf("Unexpected override images: %#v", overrideImages)
+	}
+	if *overrideImages[1].Name != "backend" || *(*overrideImages[1].Entrypoint)[0] != "dlv" {
+		t.Fatalf("Unexpected override image for backend: %#v", overrideImages[1])
+	}
+
+	ports := *config.Dev.Ports
+	if len(ports) != 3 {
+		t.Fatalf("Expected 3 port forwardings, got %d", len(ports))
+	}
+	if *(*ports[1].LabelSelector)["app"] != "test" || *(*ports[1].PortMappings)[0].LocalPort != 9000 || *(*ports[1].PortMappings)[0].RemotePort != 9229 {
+		t.Fatalf("Unexpected port forwarding for node debugger: %#v", ports[1])
+	}
+	if *ports[2].Selector != "backend" || *(*ports[2].PortMappings)[0].LocalPort != 2345 {
+		t.Fatalf("Unexpected port forwarding for go debugger: %#v", ports[2])
+	}
+
+	err = Apply(&latest.Config{
+		Dev: &latest.DevConfig{
+			Debug: &[]*latest.DebugConfig{
+				&latest.DebugConfig{
+					Image:   ptr.String("default"),
+					Runtime: ptr.String(RuntimeJava),
+					Command: &[]*string{ptr.String("java"), ptr.String("Main")},
+				},
+			},
+		},
+	}, &log.DiscardLogger{})
+	if err == nil {
+		t.Fatalf("Expected error for debug config without selector and port forwarding")
+	}
+}
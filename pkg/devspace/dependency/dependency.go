@@ -19,6 +19,7 @@ import (
 	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
 )
 
 // UpdateAll will update all dependencies if there are any
@@ -49,6 +50,31 @@ func UpdateAll(config *latest.Config, cache *generated.Config, allowCyclic bool,
 	return nil
 }
 
+// GetAll resolves all dependencies without updating them and returns them in deploy order
+func GetAll(config *latest.Config, cache *generated.Config, allowCyclic bool, log log.Logger) ([]*Dependency, error) {
+	if config == nil || config.Dependencies == nil || len(*config.Dependencies) == 0 {
+		return []*Dependency{}, nil
+	}
+
+	// Create a new dependency resolver
+	resolver, err := NewResolver(config, cache, allowCyclic, log)
+	if err != nil {
+		return nil, errors.Wrap(err, "new resolver")
+	}
+
+	// Resolve all dependencies
+	dependencies, err := resolver.Resolve(*config.Dependencies, false)
+	if err != nil {
+		if _, ok := err.(*CyclicError); ok {
+			return nil, fmt.Errorf("%v.\n To allow cyclic dependencies run with the '%s' flag", err, ansi.Color("--allow-cyclic", "white+b"))
+		}
+
+		return nil, err
+	}
+
+	return dependencies, nil
+}
+
 // BuildAll will build all dependencies if there are any
 func BuildAll(config *latest.Config, cache *generated.Config, allowCyclic, updateDependencies, skipPush, forceDeployDependencies, forceBuild bool, logger log.Logger) error {
 	if config == nil || config.Dependencies == nil || len(*config.Dependencies) == 0 {
@@ -213,12 +239,32 @@ type Dependency struct {
 	DependencyCache  *generated.Config
 }
 
+// hash returns the hash of the dependency, which changes if the dependency has to be redeployed
+func (d *Dependency) hash() (string, error) {
+	directoryHash, err := hash.DirectoryExcludes(d.LocalPath, []string{".git", ".devspace"}, true)
+	if err != nil {
+		return "", errors.Wrap(err, "hash directory")
+	}
+
+	// Chart dependencies have no files, so they are redeployed if the chart or the values change
+	if d.DependencyConfig.Source.Chart != nil {
+		out, err := yaml.Marshal(d.DependencyConfig)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal dependency config")
+		}
+
+		directoryHash = hash.String(directoryHash + string(out))
+	}
+
+	return directoryHash, nil
+}
+
 // Build builds and pushes all defined images
 func (d *Dependency) Build(skipPush, forceDependencies, forceBuild bool, log log.Logger) error {
 	// Check if we should redeploy
-	directoryHash, err := hash.DirectoryExcludes(d.LocalPath, []string{".git", ".devspace"}, true)
+	directoryHash, err := d.hash()
 	if err != nil {
-		return errors.Wrap(err, "hash directory")
+		return err
 	}
 
 	// Check if we skip the dependency deploy
@@ -246,7 +292,7 @@ func (d *Dependency) Build(skipPush, forceDependencies, forceBuild bool, log log
 	builtImages := make(map[string]string)
 	if d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false {
 		// Build images
-		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), nil, skipPush, false, forceBuild, false, log)
+		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), nil, skipPush, false, forceBuild, false, "", log)
 		if err != nil {
 			return err
 		}
@@ -267,9 +313,9 @@ func (d *Dependency) Build(skipPush, forceDependencies, forceBuild bool, log log
 // Deploy deploys the dependency if necessary
 func (d *Dependency) Deploy(skipPush bool, forceDependencies, forceBuild, forceDeploy bool, log log.Logger) error {
 	// Check if we should redeploy
-	directoryHash, err := hash.DirectoryExcludes(d.LocalPath, []string{".git", ".devspace"}, true)
+	directoryHash, err := d.hash()
 	if err != nil {
-		return errors.Wrap(err, "hash directory")
+		return err
 	}
 
 	// Check if we skip the dependency deploy
@@ -308,11 +354,16 @@ func (d *Dependency) Deploy(skipPush bool, forceDependencies, forceBuild, forceD
 	// Create docker client
 	dockerClient, err := docker.NewClient(d.Config, false, log)
 	if err != nil {
-		return errors.Wrap(err, "create docker client")
+		dockerClient = nil
 	}
 
 	// Create pull secrets and private registry if necessary
-	err = registry.CreatePullSecrets(d.Config, dockerClient, client, log)
+	err = registry.CreatePullSecrets(d.Config, d.GeneratedConfig, dockerClient, client, log)
+	if err != nil {
+		return err
+	}
+
+	err = registry.CreateDeploymentPullSecrets(d.Config, d.GeneratedConfig, dockerClient, log)
 	if err != nil {
 		return err
 	}
@@ -321,7 +372,7 @@ func (d *Dependency) Deploy(skipPush bool, forceDependencies, forceBuild, forceD
 	builtImages := make(map[string]string)
 	if d.DependencyConfig.SkipBuild == nil || *d.DependencyConfig.SkipBuild == false {
 		// Build images
-		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), client, skipPush, false, forceBuild, false, log)
+		builtImages, err = build.All(d.Config, d.GeneratedConfig.GetActive(), client, skipPush, false, forceBuild, false, "", log)
 		if err != nil {
 			return err
 		}
@@ -377,6 +428,10 @@ func (d *Dependency) Purge(log log.Logger) error {
 	// Purge the deployments
 	deploy.PurgeDeployments(d.Config, d.GeneratedConfig.GetActive(), kubectl, nil, log)
 
+	// Delete the image pull secrets of the dependency
+	purgeDeployments, _ := deploy.GetPurgeDeployments(d.Config, nil, false)
+	deploy.PurgePullSecrets(d.Config, d.GeneratedConfig, kubectl, purgeDeployments, false, log)
+
 	err = generated.SaveConfig(d.GeneratedConfig)
 	if err != nil {
 		log.Errorf("Error saving generated.yaml: %v", err)
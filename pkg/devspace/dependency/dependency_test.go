@@ -206,7 +206,7 @@ func TestDeployAll(t *testing.T) {
 			allowCyclicParam: true,
 			expectedLog: `
 Done Resolved 1 dependencies`,
-			expectedErr: fmt.Sprintf("Error deploying dependency %s:  Unable to create new kubectl client: invalid configuration: no configuration has been provided", dir + string(os.PathSeparator) + "someDir"),
+			expectedErr: fmt.Sprintf("Error deploying dependency %s:  Unable to create new kubectl client: invalid configuration: no configuration has been provided\n\nUnable to load the current kube context from your kube config. List the available contexts with `kubectl config get-contexts` and select one with `devspace use context`", dir + string(os.PathSeparator) + "someDir"),
 		},
 	}
 
@@ -312,7 +312,7 @@ func TestPurgeAll(t *testing.T) {
 			allowCyclicParam: true,
 			expectedLog: `
 Done Resolved 1 dependencies`,
-			expectedErr: fmt.Sprintf("Error deploying dependency %s:  Unable to create new kubectl client: invalid configuration: no configuration has been provided", dir + string(os.PathSeparator) + "someDir"),
+			expectedErr: fmt.Sprintf("Error deploying dependency %s:  Unable to create new kubectl client: invalid configuration: no configuration has been provided\n\nUnable to load the current kube context from your kube config. List the available contexts with `kubectl config get-contexts` and select one with `devspace use context`", dir + string(os.PathSeparator) + "someDir"),
 		},
 	}
 
@@ -3,6 +3,7 @@ package dependency
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -13,6 +14,7 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/git"
 	"github.com/devspace-cloud/devspace/pkg/util/hash"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -222,6 +224,13 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 		if err != nil {
 			return nil, errors.Wrap(err, "filepath absolute")
 		}
+	} else if dependency.Source.Chart != nil {
+		// Chart dependencies only need a folder for their generated config
+		localPath = filepath.Join(DependencyFolderPath, hash.String(ID))
+		err = os.MkdirAll(localPath, 0755)
+		if err != nil {
+			return nil, errors.Wrap(err, "create dependency folder")
+		}
 	}
 
 	if dependency.Config != nil {
@@ -229,9 +238,27 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 	}
 
 	// Load config
-	dConfig, err := configutil.GetConfigFromPath(localPath, loadConfig, true, r.BaseCache, log.Discard)
-	if err != nil {
-		return nil, fmt.Errorf("Error loading config for dependency %s: %v", ID, err)
+	var dConfig *latest.Config
+	if dependency.Source.Chart != nil {
+		dConfig, err = newChartConfig(basePath, dependency)
+		if err != nil {
+			return nil, fmt.Errorf("Error creating config for dependency %s: %v", ID, err)
+		}
+	} else {
+		var vars map[string]string
+		if dependency.Vars != nil {
+			vars = *dependency.Vars
+		}
+
+		dConfig, _, err = configutil.NewConfigLoader(localPath, &configutil.ConfigOptions{
+			ConfigName:      loadConfig,
+			GeneratedConfig: r.BaseCache,
+			Vars:            vars,
+			Log:             log.Discard,
+		}).Load()
+		if err != nil {
+			return nil, fmt.Errorf("Error loading config for dependency %s: %v", ID, err)
+		}
 	}
 
 	// Exchange cluster config
@@ -256,6 +283,7 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 		return nil, fmt.Errorf("Error loading generated config for dependency %s: %v", ID, err)
 	}
 	dGeneratedConfig.ActiveConfig = loadConfig
+	configutil.SetCacheContext(dConfig, dGeneratedConfig)
 
 	return &Dependency{
 		ID:        ID,
@@ -269,6 +297,36 @@ func (r *Resolver) resolveDependency(basePath string, dependency *latest.Depende
 	}, nil
 }
 
+// newChartConfig creates the config of a chart dependency, which consists of a single helm deployment that deploys
+// the chart with the values of the dependency. Local charts are resolved against the parent config
+func newChartConfig(basePath string, dependency *latest.DependencyConfig) (*latest.Config, error) {
+	chart := &latest.ChartConfig{}
+	err := util.Convert(dependency.Source.Chart, chart)
+	if err != nil {
+		return nil, errors.Wrap(err, "convert chart config")
+	}
+
+	if chart.RepoURL == nil {
+		chartPath := filepath.Join(basePath, filepath.FromSlash(*chart.Name))
+		if _, err := os.Stat(chartPath); err == nil {
+			chart.Name = &chartPath
+		}
+	}
+
+	return &latest.Config{
+		Version: ptr.String(latest.Version),
+		Deployments: &[]*latest.DeploymentConfig{
+			{
+				Name: ptr.String(path.Base(*dependency.Source.Chart.Name)),
+				Helm: &latest.HelmConfig{
+					Chart:  chart,
+					Values: dependency.Values,
+				},
+			},
+		},
+	}, nil
+}
+
 func (r *Resolver) getDependencyID(basePath string, dependency *latest.DependencyConfig) string {
 	if dependency.Source.Git != nil {
 		return strings.TrimSpace(*dependency.Source.Git)
@@ -283,6 +341,16 @@ func (r *Resolver) getDependencyID(basePath string, dependency *latest.Dependenc
 		}
 
 		return filePath
+	} else if dependency.Source.Chart != nil {
+		ID := *dependency.Source.Chart.Name
+		if dependency.Source.Chart.RepoURL != nil {
+			ID = strings.TrimSuffix(*dependency.Source.Chart.RepoURL, "/") + "/" + ID
+		}
+		if dependency.Source.Chart.Version != nil {
+			ID += "@" + *dependency.Source.Chart.Version
+		}
+
+		return ID
 	}
 
 	return ""
@@ -96,3 +96,48 @@ func TestResolver(t *testing.T) {
 	assert.Equal(t, gitPath, dependencies[2].ID, "Third dependency has wrong id")
 	assert.Equal(t, gitDepPath, dependencies[2].LocalPath, "Third dependency has wrong local path")
 }
+
+func TestChartDependency(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testFolder")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = fsutil.WriteToFile([]byte("name: local"), filepath.Join(dir, "charts", "local", "Chart.yaml"))
+	if err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+
+	values := map[interface{}]interface{}{"replicas": 2}
+	dependency := &latest.DependencyConfig{
+		Source: &latest.SourceConfig{
+			Chart: &latest.ChartConfig{
+				Name:    ptr.String("mysql"),
+				RepoURL: ptr.String("https://kubernetes-charts.storage.googleapis.com/"),
+				Version: ptr.String("1.3.1"),
+			},
+		},
+		Values: &values,
+	}
+
+	resolver := &Resolver{}
+	assert.Equal(t, resolver.getDependencyID(dir, dependency), "https://kubernetes-charts.storage.googleapis.com/mysql@1.3.1")
+
+	config, err := newChartConfig(dir, dependency)
+	assert.NilError(t, err)
+	assert.Equal(t, len(*config.Deployments), 1)
+	assert.Equal(t, *(*config.Deployments)[0].Name, "mysql")
+	assert.Equal(t, *(*config.Deployments)[0].Helm.Chart.Name, "mysql")
+	assert.DeepEqual(t, *(*config.Deployments)[0].Helm.Values, values)
+
+	dependency = &latest.DependencyConfig{
+		Source: &latest.SourceConfig{
+			Chart: &latest.ChartConfig{Name: ptr.String("charts/local")},
+		},
+	}
+	config, err = newChartConfig(dir, dependency)
+	assert.NilError(t, err)
+	assert.Equal(t, *(*config.Deployments)[0].Name, "local")
+	assert.Equal(t, *(*config.Deployments)[0].Helm.Chart.Name, filepath.Join(dir, "charts", "local"))
+}
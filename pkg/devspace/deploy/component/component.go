@@ -6,14 +6,23 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/util"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	helmclient "github.com/devspace-cloud/devspace/pkg/devspace/helm"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/pkg/errors"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
 )
 
 // DeployConfig holds the informations for deploying a component
 type DeployConfig struct {
 	HelmConfig *helm.DeployConfig
+
+	// KubectlConfig applies the locally rendered component chart if the component is render only. Tiller is not
+	// used in this case
+	KubectlConfig *kubectl.DeployConfig
 }
 
 // DevSpaceChartConfig is the config that holds the devspace chart information
@@ -24,23 +33,21 @@ var DevSpaceChartConfig = &latest.ChartConfig{
 }
 
 // New creates a new helm deployment client
-func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *latest.DeploymentConfig, log log.Logger) (*DeployConfig, error) {
-	// Convert the values
-	values := map[interface{}]interface{}{}
-	err := util.Convert(deployConfig.Component, &values)
+func New(config *latest.Config, client kubernetes.Interface, deployConfig *latest.DeploymentConfig, log log.Logger) (*DeployConfig, error) {
+	values, err := GetValues(deployConfig.Component)
 	if err != nil {
 		return nil, err
 	}
 
-	delete(values, "options")
 	if deployConfig.Component.Options == nil {
 		deployConfig.Component.Options = &latest.ComponentConfigOptions{}
 	}
 
 	// Create a helm config out of the deployment config
-	helmConfig, err := helm.New(config, kubectl, &latest.DeploymentConfig{
+	helmConfig, err := helm.New(config, client, &latest.DeploymentConfig{
 		Name:      deployConfig.Name,
 		Namespace: deployConfig.Namespace,
+		Env:       deployConfig.Env,
 		Helm: &latest.HelmConfig{
 			Chart:           DevSpaceChartConfig,
 			Values:          &values,
@@ -49,6 +56,26 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 			Force:           deployConfig.Component.Options.Force,
 			Timeout:         deployConfig.Component.Options.Timeout,
 			TillerNamespace: deployConfig.Component.Options.TillerNamespace,
+			TillerTimeout:   deployConfig.Component.Options.TillerTimeout,
+		},
+	}, log)
+	if err != nil {
+		return nil, err
+	}
+
+	helmConfig.ComponentChart = true
+	if deployConfig.Component.RenderOnly == nil || *deployConfig.Component.RenderOnly == false {
+		return &DeployConfig{
+			HelmConfig: helmConfig,
+		}, nil
+	}
+
+	// The inline manifest is set to the rendered chart before the manifests are applied or deleted
+	kubectlConfig, err := kubectl.New(config, client, &latest.DeploymentConfig{
+		Name:      deployConfig.Name,
+		Namespace: deployConfig.Namespace,
+		Kubectl: &latest.KubectlConfig{
+			InlineManifest: ptr.String(""),
 		},
 	}, log)
 	if err != nil {
@@ -56,17 +83,104 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 	}
 
 	return &DeployConfig{
-		HelmConfig: helmConfig,
+		HelmConfig:    helmConfig,
+		KubectlConfig: kubectlConfig,
 	}, nil
 }
 
-// Deploy deploys the given deployment with helm
+// GetValues converts the component config into the values of the component chart
+func GetValues(component *latest.ComponentConfig) (map[interface{}]interface{}, error) {
+	values := map[interface{}]interface{}{}
+	err := util.Convert(component, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(values, "options")
+	delete(values, "renderOnly")
+	return values, nil
+}
+
+// LoadChart loads the component chart and downloads it if necessary
+func LoadChart() (*chart.Chart, error) {
+	return helmclient.LoadChart(DevSpaceChartConfig)
+}
+
+// Render renders the component chart with the values of the component config and returns the resulting manifests.
+// Render neither accesses the cluster nor the file system, so the same chart and component always produce the
+// same manifests. Images are not replaced and templates only see the default capabilities of helm template, use
+// (*DeployConfig).Render for the manifests that would be deployed
+func Render(componentChart *chart.Chart, releaseName, releaseNamespace string, component *latest.ComponentConfig) (string, error) {
+	values, err := GetValues(component)
+	if err != nil {
+		return "", err
+	}
+
+	return helmclient.RenderChart(componentChart, releaseName, releaseNamespace, values, nil)
+}
+
+// Render renders the component chart locally with the values the component would be deployed with
+func (d *DeployConfig) Render(cache *generated.CacheConfig) (string, error) {
+	return d.HelmConfig.Render(cache)
+}
+
+// RenderOnly returns true if the component chart is rendered locally and applied with kubectl instead of helm
+func (d *DeployConfig) RenderOnly() bool {
+	return d.KubectlConfig != nil
+}
+
+// GetManifests renders the component chart and returns the manifests with the image tags from the cache injected,
+// the way they are applied if the component is render only
+func (d *DeployConfig) GetManifests(cache *generated.CacheConfig) (string, error) {
+	err := d.renderInlineManifest(cache)
+	if err != nil {
+		return "", err
+	}
+
+	return d.KubectlConfig.GetManifests(cache)
+}
+
+// renderInlineManifest renders the component chart into the inline manifest of the kubectl deployment
+func (d *DeployConfig) renderInlineManifest(cache *generated.CacheConfig) error {
+	if d.KubectlConfig == nil {
+		return errors.New("Component is not render only")
+	}
+
+	manifests, err := d.Render(cache)
+	if err != nil {
+		return errors.Wrap(err, "render component chart")
+	}
+
+	d.KubectlConfig.DeploymentConfig.Kubectl.InlineManifest = &manifests
+	return nil
+}
+
+// Deploy deploys the given deployment with helm or applies the rendered chart with kubectl if the component is
+// render only
 func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error) {
+	if d.RenderOnly() {
+		err := d.renderInlineManifest(cache)
+		if err != nil {
+			return false, err
+		}
+
+		return d.KubectlConfig.Deploy(cache, forceDeploy, builtImages)
+	}
+
 	return d.HelmConfig.Deploy(cache, forceDeploy, builtImages)
 }
 
 // Status gets the status of the deployment
 func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
+	if d.RenderOnly() {
+		return &deploy.StatusResult{
+			Name:   *d.HelmConfig.DeploymentConfig.Name,
+			Type:   "Component",
+			Target: *DevSpaceChartConfig.Name + " (render only)",
+			Status: "N/A",
+		}, nil
+	}
+
 	status, err := d.HelmConfig.Status()
 	if err != nil {
 		return nil, err
@@ -76,7 +190,45 @@ func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
 	return status, nil
 }
 
-// Delete deletes the release
+// GetValues returns the final values the component chart is deployed with
+func (d *DeployConfig) GetValues(cache *generated.CacheConfig, builtImages map[string]string) (map[interface{}]interface{}, bool, error) {
+	return d.HelmConfig.GetValues(cache, builtImages)
+}
+
+// GetRelease returns the deployed helm release of the component or nil if the release was not found. Render only
+// components have no release
+func (d *DeployConfig) GetRelease() (*hapi_release5.Release, error) {
+	if d.RenderOnly() {
+		return nil, nil
+	}
+
+	return d.HelmConfig.GetRelease()
+}
+
+// GetResources returns the kubernetes resources of the component
+func (d *DeployConfig) GetResources(cache *generated.CacheConfig) ([]*deploy.Resource, error) {
+	if d.RenderOnly() {
+		err := d.renderInlineManifest(cache)
+		if err != nil {
+			return nil, err
+		}
+
+		return d.KubectlConfig.GetResources(cache)
+	}
+
+	return d.HelmConfig.GetResources(cache)
+}
+
+// Delete deletes the release or the applied manifests if the component is render only
 func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
+	if d.RenderOnly() {
+		err := d.renderInlineManifest(cache)
+		if err != nil {
+			return err
+		}
+
+		return d.KubectlConfig.Delete(cache)
+	}
+
 	return d.HelmConfig.Delete(cache)
 }
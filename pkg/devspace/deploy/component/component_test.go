@@ -1,6 +1,10 @@
 package component
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -10,8 +14,10 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
 
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/helm/pkg/proto/hapi/chart"
 )
 
 func TestComponentDeployment(t *testing.T) {
@@ -95,3 +101,179 @@ func TestComponentDeployment(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestRender(t *testing.T) {
+	componentChart := &chart.Chart{
+		Metadata: &chart.Metadata{
+			Name:    "component-chart",
+			Version: "v0.0.2",
+		},
+		Templates: []*chart.Template{
+			{
+				Name: "templates/service.yaml",
+				Data: []byte("{{- if .Values.service }}\nkind: Service\nmetadata:\n  name: {{ .Release.Name }}\n  namespace: {{ .Release.Namespace }}\n{{- end }}\n"),
+			},
+			{
+				Name: "templates/deployment.yaml",
+				Data: []byte("kind: Deployment\nmetadata:\n  name: {{ .Release.Name }}\nspec:\n  replicas: {{ .Values.replicas }}\n  containers:\n  {{- range .Values.containers }}\n  - image: {{ .image }}\n  {{- end }}\n"),
+			},
+			{
+				Name: "templates/NOTES.txt",
+				Data: []byte("Deployed {{ .Release.Name }}"),
+			},
+		},
+		Values: &chart.Config{Raw: "replicas: 1\n"},
+	}
+
+	component := &latest.ComponentConfig{
+		Containers: &[]*latest.ContainerConfig{
+			{
+				Image: ptr.String("nginx"),
+			},
+		},
+		Replicas: ptr.Int(2),
+		Options: &latest.ComponentConfigOptions{
+			Wait: ptr.Bool(true),
+		},
+	}
+
+	expected := "---\n# Source: component-chart/templates/deployment.yaml\nkind: Deployment\nmetadata:\n  name: app\nspec:\n  replicas: 2\n  containers:\n  - image: nginx\n"
+	for i := 0; i < 3; i++ {
+		manifests, err := Render(componentChart, "app", "test", component)
+		if err != nil {
+			t.Fatalf("Error rendering component: %v", err)
+		}
+		if manifests != expected {
+			t.Fatalf("Unexpected manifests:\n%s\nExpected:\n%s", manifests, expected)
+		}
+	}
+
+	component.Service = &latest.ServiceConfig{
+		Ports: &[]*latest.ServicePortConfig{
+			{
+				Port: ptr.Int(3000),
+			},
+		},
+	}
+	manifests, err := Render(componentChart, "app", "test", component)
+	if err != nil {
+		t.Fatalf("Error rendering component: %v", err)
+	}
+	if strings.HasSuffix(manifests, "# Source: component-chart/templates/service.yaml\nkind: Service\nmetadata:\n  name: app\n  namespace: test\n") == false {
+		t.Fatalf("Service missing or not sorted after deployment:\n%s", manifests)
+	}
+}
+
+func TestRenderOnly(t *testing.T) {
+	config := &latest.Config{}
+	configutil.SetFakeConfig(config)
+
+	component := &latest.ComponentConfig{
+		Replicas:   ptr.Int(2),
+		RenderOnly: ptr.Bool(true),
+		Options: &latest.ComponentConfigOptions{
+			Wait: ptr.Bool(true),
+		},
+	}
+
+	// Render only and options are no chart values
+	values, err := GetValues(component)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, values, map[interface{}]interface{}{"replicas": 2})
+
+	deployConfig, err := New(config, nil, &latest.DeploymentConfig{
+		Name:      ptr.String("backend"),
+		Namespace: ptr.String("test"),
+		Component: component,
+	}, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, deployConfig.RenderOnly())
+	assert.Equal(t, deployConfig.KubectlConfig.Namespace, "test")
+
+	release, err := deployConfig.GetRelease()
+	assert.NilError(t, err)
+	assert.Assert(t, release == nil)
+
+	status, err := deployConfig.Status()
+	assert.NilError(t, err)
+	assert.Equal(t, status.Type, "Component")
+
+	component.RenderOnly = nil
+	deployConfig, err = New(config, nil, &latest.DeploymentConfig{
+		Name:      ptr.String("backend"),
+		Component: component,
+	}, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, deployConfig.RenderOnly() == false)
+}
+
+// fakeKubectl prints the resources of the manifest like kubectl create --dry-run and records all other calls in kubectl.log
+const fakeKubectl = `#!/bin/sh
+for last; do true; done
+case "$*" in
+  *--dry-run*) grep -v -e '^---' -e '^#' "$last" ;;
+  *) echo "$*" >> "$(dirname "$0")/kubectl.log"; cat > /dev/null ;;
+esac
+`
+
+func TestRenderOnlyKubectl(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("The fake kubectl is a shell script")
+	}
+
+	dir, err := ioutil.TempDir("", "test-render-only")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	assert.NilError(t, err)
+	err = os.Chdir(dir)
+	assert.NilError(t, err)
+	defer os.Chdir(wdBackup)
+
+	// Local component chart, so the chart is not downloaded
+	err = os.MkdirAll(filepath.Join(dir, "chart", "templates"), 0755)
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "chart", "Chart.yaml"), []byte("name: component-chart\nversion: v0.0.2\n"), 0644)
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "chart", "templates", "deployment.yaml"), []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: {{ .Release.Name }}\nspec:\n  replicas: {{ .Values.replicas }}\n"), 0644)
+	assert.NilError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "kubectl"), []byte(fakeKubectl), 0755)
+	assert.NilError(t, err)
+
+	config := &latest.Config{}
+	configutil.SetFakeConfig(config)
+	cache := &generated.CacheConfig{
+		Deployments: map[string]*generated.DeploymentCache{},
+	}
+
+	deployConfig, err := New(config, fake.NewSimpleClientset(), &latest.DeploymentConfig{
+		Name:      ptr.String("backend"),
+		Namespace: ptr.String("test"),
+		Component: &latest.ComponentConfig{
+			Replicas:   ptr.Int(2),
+			RenderOnly: ptr.Bool(true),
+		},
+	}, log.Discard)
+	assert.NilError(t, err)
+	deployConfig.HelmConfig.DeploymentConfig.Helm.Chart = &latest.ChartConfig{Name: ptr.String(filepath.Join(dir, "chart"))}
+	deployConfig.KubectlConfig.CmdPath = filepath.Join(dir, "kubectl")
+
+	wasDeployed, err := deployConfig.Deploy(cache, false, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, wasDeployed)
+
+	resources, err := deployConfig.GetResources(cache)
+	assert.NilError(t, err)
+	assert.Equal(t, len(resources), 1)
+	assert.Equal(t, resources[0].Kind, "Deployment")
+	assert.Equal(t, resources[0].Name, "backend")
+	assert.Equal(t, resources[0].Namespace, "test")
+
+	err = deployConfig.Delete(cache)
+	assert.NilError(t, err)
+
+	calls, err := ioutil.ReadFile(filepath.Join(dir, "kubectl.log"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(calls), "--namespace test apply --force -f -\n--namespace test delete --ignore-not-found=true -f -\n")
+}
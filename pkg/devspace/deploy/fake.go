@@ -0,0 +1,65 @@
+package deploy
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+)
+
+// FakeDeployer implements Interface without a cluster and records the deployments and deletions
+type FakeDeployer struct {
+	// Result is returned by Status
+	Result *StatusResult
+
+	// DeployError is returned by Deploy, DeleteError by Delete
+	DeployError error
+	DeleteError error
+
+	// Deployed is true after a successful deployment and false after a successful deletion
+	Deployed bool
+
+	// BuiltImages holds the built images of the last deployment
+	BuiltImages map[string]string
+}
+
+// NewFakeDeployer creates a new fake deployer for the deployment with the given name
+func NewFakeDeployer(name string) *FakeDeployer {
+	return &FakeDeployer{
+		Result: &StatusResult{
+			Name:   name,
+			Type:   "Fake",
+			Target: "N/A",
+			Status: "N/A",
+		},
+	}
+}
+
+// Status implements interface
+func (f *FakeDeployer) Status() (*StatusResult, error) {
+	return f.Result, nil
+}
+
+// Deploy implements interface. Like the other deployers it only deploys if forced, not deployed yet or images
+// were built
+func (f *FakeDeployer) Deploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error) {
+	if f.DeployError != nil {
+		return false, f.DeployError
+	}
+	if f.Deployed && forceDeploy == false && len(builtImages) == 0 {
+		return false, nil
+	}
+
+	f.Deployed = true
+	f.BuiltImages = builtImages
+	f.Result.Status = "Deployed"
+	return true, nil
+}
+
+// Delete implements interface
+func (f *FakeDeployer) Delete(cache *generated.CacheConfig) error {
+	if f.DeleteError != nil {
+		return f.DeleteError
+	}
+
+	f.Deployed = false
+	f.Result.Status = "N/A"
+	return nil
+}
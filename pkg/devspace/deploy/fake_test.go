@@ -0,0 +1,37 @@
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestFakeDeployer(t *testing.T) {
+	var deployer Interface = NewFakeDeployer("test")
+
+	deployed, err := deployer.Deploy(nil, false, nil)
+	assert.NilError(t, err, "Error deploying")
+	assert.Equal(t, deployed, true, "Not deployed initially")
+
+	deployed, err = deployer.Deploy(nil, false, nil)
+	assert.NilError(t, err, "Error deploying")
+	assert.Equal(t, deployed, false, "Redeployed without changes")
+
+	deployed, err = deployer.Deploy(nil, false, map[string]string{"image": "tag"})
+	assert.NilError(t, err, "Error deploying")
+	assert.Equal(t, deployed, true, "Not redeployed after images were built")
+
+	status, err := deployer.Status()
+	assert.NilError(t, err, "Error getting status")
+	assert.Equal(t, status.Name, "test")
+	assert.Equal(t, status.Status, "Deployed")
+
+	err = deployer.Delete(nil)
+	assert.NilError(t, err, "Error deleting")
+	assert.Equal(t, deployer.(*FakeDeployer).Deployed, false, "Still deployed after delete")
+
+	deployer.(*FakeDeployer).DeployError = errors.New("deploy error")
+	_, err = deployer.Deploy(nil, true, nil)
+	assert.Error(t, err, "deploy error")
+}
@@ -20,6 +20,10 @@ type DeployConfig struct {
 	DeploymentConfig *latest.DeploymentConfig
 	Log              log.Logger
 
+	// ComponentChart is true if the values are the values of the component chart. The deployment env vars
+	// are injected into the containers in this case
+	ComponentChart bool
+
 	config *latest.Config
 }
 
@@ -0,0 +1,120 @@
+package helm
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+)
+
+// maxManifestLines is the amount of lines of a rejected manifest that are printed if the chart is not debugged
+const maxManifestLines = 30
+
+func (d *DeployConfig) debugChart() bool {
+	return d.DeploymentConfig.Helm.DebugChart != nil && *d.DeploymentConfig.Helm.DebugChart
+}
+
+// renderTemplates renders the chart templates locally with the values the chart is deployed with
+func (d *DeployConfig) renderTemplates(releaseNamespace string, values map[interface{}]interface{}) (map[string]string, error) {
+	if releaseNamespace == "" {
+		defaultNamespace, err := configutil.GetDefaultNamespace(d.config)
+		if err != nil {
+			return nil, err
+		}
+
+		releaseNamespace = defaultNamespace
+	}
+
+	renderChart, err := helm.LoadChart(d.DeploymentConfig.Helm.Chart)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities, err := d.getCapabilities()
+	if err != nil {
+		return nil, err
+	}
+
+	return helm.RenderTemplates(renderChart, *d.DeploymentConfig.Name, releaseNamespace, values, capabilities)
+}
+
+// dumpTemplates writes the rendered templates into a new temp dir and returns its path
+func (d *DeployConfig) dumpTemplates(templates map[string]string) (string, error) {
+	dir, err := ioutil.TempDir("", "devspace-chart-"+*d.DeploymentConfig.Name+"-")
+	if err != nil {
+		return "", errors.Wrap(err, "create temp dir")
+	}
+
+	for templateName, content := range templates {
+		templatePath := filepath.Join(dir, filepath.FromSlash(templateName))
+		err = os.MkdirAll(filepath.Dir(templatePath), 0755)
+		if err != nil {
+			return "", err
+		}
+
+		err = ioutil.WriteFile(templatePath, []byte(content+"\n"), 0644)
+		if err != nil {
+			return "", errors.Wrapf(err, "write template %s", templateName)
+		}
+	}
+
+	return dir, nil
+}
+
+// deployError creates the error that is shown if the chart could not be deployed. If the api server rejected an
+// object of the chart, the error contains the rendered manifest of the object. templates may be nil, in which case
+// the chart is rendered again
+func (d *DeployConfig) deployError(deployErr error, releaseNamespace string, values map[interface{}]interface{}, templates map[string]string) error {
+	message := "Unable to deploy helm chart: " + helm.CleanError(deployErr.Error())
+
+	invalidObject := helm.ParseInvalidObject(deployErr.Error())
+	if invalidObject != nil {
+		if templates == nil {
+			var err error
+			templates, err = d.renderTemplates(releaseNamespace, values)
+			if err != nil {
+				d.Log.Debugf("Error rendering chart %s: %v", *d.DeploymentConfig.Helm.Chart.Name, err)
+			}
+		}
+
+		templateName, manifest := helm.FindManifest(templates, invalidObject)
+		if manifest != "" {
+			object := invalidObject.Kind
+			if invalidObject.Name != "" {
+				object += " " + invalidObject.Name
+			}
+
+			message += fmt.Sprintf("\n\n%s from %s was rejected: %s\n%s", object, templateName, ansi.Color(invalidObject.Message, "red+b"), formatManifest(manifest, d.debugChart()))
+		}
+
+		if d.debugChart() == false {
+			message += fmt.Sprintf("\nRun `%s` to write the rendered templates to a temp dir", ansi.Color("devspace deploy --debug-chart", "white+b"))
+		}
+	}
+
+	return fmt.Errorf("%s\nRun `%s` and `%s` to recreate the chart", message, ansi.Color("devspace purge -d "+*d.DeploymentConfig.Name, "white+b"), ansi.Color("devspace deploy", "white+b"))
+}
+
+// formatManifest indents the manifest and shortens it to maxManifestLines, unless all lines should be shown
+func formatManifest(manifest string, allLines bool) string {
+	lines := strings.Split(manifest, "\n")
+
+	omitted := 0
+	if allLines == false && len(lines) > maxManifestLines {
+		omitted = len(lines) - maxManifestLines
+		lines = lines[:maxManifestLines]
+	}
+
+	formatted := "    " + strings.Join(lines, "\n    ")
+	if omitted > 0 {
+		formatted += fmt.Sprintf("\n    ... (%d more lines)", omitted)
+	}
+
+	return formatted
+}
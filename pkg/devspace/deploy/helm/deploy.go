@@ -9,11 +9,11 @@ import (
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/remote"
 	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 	hashpkg "github.com/devspace-cloud/devspace/pkg/util/hash"
 	"github.com/devspace-cloud/devspace/pkg/util/yamlutil"
-	"github.com/mgutz/ansi"
 	"github.com/pkg/errors"
 )
 
@@ -42,7 +42,12 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 	helmOverridesHash := ""
 	if d.DeploymentConfig.Helm.ValuesFiles != nil {
 		for _, override := range *d.DeploymentConfig.Helm.ValuesFiles {
-			hash, err := hashpkg.Directory(*override)
+			valuesFile, err := d.getLocalValuesFile(chartPath, *override)
+			if err != nil {
+				return false, err
+			}
+
+			hash, err := hashpkg.Directory(valuesFile)
 			if err != nil {
 				return false, fmt.Errorf("Error stating override file %s: %v", *override, err)
 			}
@@ -105,12 +110,7 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 }
 
 func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error) {
-	var (
-		releaseName     = *d.DeploymentConfig.Name
-		chartPath       = *d.DeploymentConfig.Helm.Chart.Name
-		chartValuesPath = filepath.Join(chartPath, "values.yaml")
-		overwriteValues = map[interface{}]interface{}{}
-	)
+	releaseName := *d.DeploymentConfig.Name
 
 	// Get release namespace
 	releaseNamespace := ""
@@ -118,25 +118,130 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 		releaseNamespace = *d.DeploymentConfig.Namespace
 	}
 
-	// Check if its a local chart
-	_, err := os.Stat(chartValuesPath)
-	if err == nil {
-		// Get values yaml when chart is locally
-		_, err := os.Stat(chartValuesPath)
-		if err == nil {
-			err := yamlutil.ReadYamlFromFile(chartValuesPath, overwriteValues)
+	overwriteValues, shouldRedeploy, err := d.GetValues(cache, builtImages)
+	if err != nil {
+		return false, err
+	}
+	if forceDeploy == false && shouldRedeploy {
+		forceDeploy = true
+	}
+
+	// Deployment is not necessary, but the tests of the existing release still run if they were requested
+	if forceDeploy == false {
+		if d.shouldRunTests() {
+			appRelease, err := d.getRelease(releaseName)
 			if err != nil {
-				return false, fmt.Errorf("Couldn't deploy chart, error reading from chart values %s: %v", chartValuesPath, err)
+				return false, err
 			}
+
+			return false, d.runTests(appRelease)
+		}
+
+		return false, nil
+	}
+
+	d.Log.StartWait(fmt.Sprintf("Deploying chart %s (%s) with helm", *d.DeploymentConfig.Helm.Chart.Name, *d.DeploymentConfig.Name))
+	defer d.Log.StopWait()
+
+	// Write the rendered templates to a temp dir
+	var templates map[string]string
+	if d.debugChart() {
+		templates, err = d.renderTemplates(releaseNamespace, overwriteValues)
+		if err != nil {
+			return false, errors.Wrap(err, "render chart")
+		}
+
+		dir, err := d.dumpTemplates(templates)
+		if err != nil {
+			return false, err
+		}
+
+		d.Log.Infof("Wrote rendered templates of chart %s to %s", *d.DeploymentConfig.Helm.Chart.Name, dir)
+	}
+
+	// Deploy chart
+	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, &overwriteValues, d.DeploymentConfig.Helm)
+	if err != nil {
+		return false, d.deployError(err, releaseNamespace, overwriteValues, templates)
+	}
+
+	// Print revision
+	if appRelease != nil {
+		releaseRevision := int(appRelease.Version)
+		d.Log.Donef("Deployed helm chart (Release revision: %d)", releaseRevision)
+	} else {
+		d.Log.Done("Deployed helm chart")
+	}
+
+	// Run the chart tests
+	if d.shouldRunTests() {
+		err = d.runTests(appRelease)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// getValuesFilePath returns the path of a values file. Relative paths are resolved against the project and, if the
+// file does not exist there, against the local chart, so that e.g. values-dev.yaml next to values.yaml is found
+func getValuesFilePath(chartPath, valuesFile string) string {
+	if filepath.IsAbs(valuesFile) {
+		return valuesFile
+	}
+
+	_, err := os.Stat(valuesFile)
+	if err != nil {
+		chartValuesFile := filepath.Join(chartPath, valuesFile)
+		if _, err := os.Stat(chartValuesFile); err == nil {
+			return chartValuesFile
+		}
+	}
+
+	return valuesFile
+}
+
+// getLocalValuesFile returns the local path of a values file. Values files from urls are fetched into the remote cache folder
+func (d *DeployConfig) getLocalValuesFile(chartPath, valuesFile string) (string, error) {
+	if remote.IsURL(valuesFile) {
+		return remote.GetLocalPath(valuesFile, d.DeploymentConfig.Helm.Remote, d.Log)
+	}
+
+	return getValuesFilePath(chartPath, valuesFile), nil
+}
+
+// GetValues returns the final values the chart is deployed with. The values are merged in the following order:
+// chart values.yaml, helm.valuesFiles, helm.inlineValues, helm.values and finally the image tags from the cache are injected.
+// The returned bool indicates if one of the injected images was built in this run
+func (d *DeployConfig) GetValues(cache *generated.CacheConfig, builtImages map[string]string) (map[interface{}]interface{}, bool, error) {
+	var (
+		chartPath       = *d.DeploymentConfig.Helm.Chart.Name
+		chartValuesPath = filepath.Join(chartPath, "values.yaml")
+		overwriteValues = map[interface{}]interface{}{}
+		shouldRedeploy  = false
+	)
+
+	// Get values yaml when chart is locally
+	_, err := os.Stat(chartValuesPath)
+	if err == nil {
+		err := yamlutil.ReadYamlFromFile(chartValuesPath, overwriteValues)
+		if err != nil {
+			return nil, false, fmt.Errorf("Couldn't deploy chart, error reading from chart values %s: %v", chartValuesPath, err)
 		}
 	}
 
 	// Load override values from path
 	if d.DeploymentConfig.Helm.ValuesFiles != nil {
 		for _, overridePath := range *d.DeploymentConfig.Helm.ValuesFiles {
-			overwriteValuesPath, err := filepath.Abs(*overridePath)
+			valuesFile, err := d.getLocalValuesFile(chartPath, *overridePath)
+			if err != nil {
+				return nil, false, err
+			}
+
+			overwriteValuesPath, err := filepath.Abs(valuesFile)
 			if err != nil {
-				return false, fmt.Errorf("Error retrieving absolute path from %s: %v", *overridePath, err)
+				return nil, false, fmt.Errorf("Error retrieving absolute path from %s: %v", *overridePath, err)
 			}
 
 			overwriteValuesFromPath := map[interface{}]interface{}{}
@@ -149,43 +254,64 @@ func (d *DeployConfig) internalDeploy(cache *generated.CacheConfig, forceDeploy
 		}
 	}
 
+	// Load inline values and merge them
+	if d.DeploymentConfig.Helm.InlineValues != nil {
+		inlineValues := map[interface{}]interface{}{}
+		err := yaml.Unmarshal([]byte(*d.DeploymentConfig.Helm.InlineValues), &inlineValues)
+		if err != nil {
+			return nil, false, fmt.Errorf("Error parsing inline values of deployment %s: %v", *d.DeploymentConfig.Name, err)
+		}
+
+		Values(overwriteValues).MergeInto(inlineValues)
+	}
+
 	// Load override values from data and merge them
 	if d.DeploymentConfig.Helm.Values != nil {
 		Values(overwriteValues).MergeInto(*d.DeploymentConfig.Helm.Values)
 	}
 
+	// Add the deployment env vars
+	env, injectedImageBuilt := d.getInjectedEnv(cache, builtImages)
+	d.injectEnv(overwriteValues, env)
+
 	// Add devspace specific values
 	if d.DeploymentConfig.Helm.DevSpaceValues == nil || *d.DeploymentConfig.Helm.DevSpaceValues == true {
 		// Replace image names
-		shouldRedeploy := replaceContainerNames(overwriteValues, cache, builtImages)
-		if forceDeploy == false && shouldRedeploy {
-			forceDeploy = true
-		}
+		shouldRedeploy = replaceContainerNames(overwriteValues, cache, builtImages)
 	}
-
-	// Deployment is not necessary
-	if forceDeploy == false {
-		return false, nil
+	if injectedImageBuilt {
+		shouldRedeploy = true
 	}
 
-	d.Log.StartWait(fmt.Sprintf("Deploying chart %s (%s) with helm", *d.DeploymentConfig.Helm.Chart.Name, *d.DeploymentConfig.Name))
-	defer d.Log.StopWait()
+	// Set the pull policy and inject the pull secrets of images with images.*.pullPolicy or images.*.injectPullSecret
+	d.injectPullOptions(overwriteValues, cache)
 
-	// Deploy chart
-	appRelease, err := d.Helm.InstallChart(releaseName, releaseNamespace, &overwriteValues, d.DeploymentConfig.Helm)
-	if err != nil {
-		return false, fmt.Errorf("Unable to deploy helm chart: %v\nRun `%s` and `%s` to recreate the chart", err, ansi.Color("devspace purge -d "+*d.DeploymentConfig.Name, "white+b"), ansi.Color("devspace deploy", "white+b"))
+	return overwriteValues, shouldRedeploy, nil
+}
+
+// injectPullOptions sets the pull policy of the containers that use an image with pull options and adds the pull
+// secrets of these images to the pullSecrets of the component chart or, for other charts, to imagePullSecrets
+func (d *DeployConfig) injectPullOptions(values map[interface{}]interface{}, cache *generated.CacheConfig) {
+	pullOptions := registry.GetPullOptions(d.config, cache)
+	if len(pullOptions) == 0 {
+		return
 	}
 
-	// Print revision
-	if appRelease != nil {
-		releaseRevision := int(appRelease.Version)
-		d.Log.Donef("Deployed helm chart (Release revision: %d)", releaseRevision)
-	} else {
-		d.Log.Done("Deployed helm chart")
+	if d.ComponentChart {
+		pullSecrets := registry.InjectPullOptions(values, pullOptions, false)
+		if len(pullSecrets) > 0 {
+			values["pullSecrets"] = registry.MergePullSecrets(values["pullSecrets"], pullSecrets, false)
+		}
+
+		return
 	}
 
-	return true, nil
+	registry.InjectPullOptions(values, pullOptions, true)
+
+	pullSecrets := registry.GetReferencedPullSecrets(values, pullOptions)
+	if len(pullSecrets) > 0 {
+		values["imagePullSecrets"] = registry.MergePullSecrets(values["imagePullSecrets"], pullSecrets, true)
+	}
 }
 
 func replaceContainerNames(overwriteValues map[interface{}]interface{}, cache *generated.CacheConfig, builtImages map[string]string) bool {
@@ -199,9 +325,9 @@ func replaceContainerNames(overwriteValues map[interface{}]interface{}, cache *g
 
 		// Search for image name
 		for _, imageCache := range cache.Images {
-			if imageCache.ImageName == image && imageCache.Tag != "" {
+			if imageCache.Tag != "" && registry.IsSameImage(imageCache.ImageName, image) {
 				if builtImages != nil {
-					if _, ok := builtImages[image]; ok {
+					if _, ok := builtImages[imageCache.ImageName]; ok {
 						shouldRedeploy = true
 					}
 				}
@@ -221,8 +347,8 @@ func replaceContainerNames(overwriteValues map[interface{}]interface{}, cache *g
 
 		// Search for image name
 		for _, imageCache := range cache.Images {
-			if imageCache.ImageName == image {
-				return image + ":" + imageCache.Tag, nil
+			if imageCache.Tag != "" && registry.IsSameImage(imageCache.ImageName, image) {
+				return imageCache.GetResolvedImageName() + ":" + imageCache.Tag, nil
 			}
 		}
 
@@ -473,3 +473,109 @@ func TestReplaceImageNames(t *testing.T) {
 		t.Fatalf("Replace failed: Got\n %s\n, but expected\n %s", gotYaml, expectedYaml)
 	}
 }
+
+func TestReplaceContainerNamesWithAlias(t *testing.T) {
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"app": &generated.ImageCache{
+				ImageName:         "docker.io/myorg/app",
+				ResolvedImageName: "localhost:5000/myorg/app",
+				Tag:               "abc",
+			},
+		},
+	}
+	builtImages := map[string]string{
+		"docker.io/myorg/app": "abc",
+	}
+
+	input := map[interface{}]interface{}{
+		"short": "myorg/app",
+		"full":  "index.docker.io/myorg/app:latest",
+		"other": "myorg/other",
+	}
+	output := map[interface{}]interface{}{
+		"short": "localhost:5000/myorg/app:abc",
+		"full":  "localhost:5000/myorg/app:abc",
+		"other": "myorg/other",
+	}
+
+	shouldRedeploy := replaceContainerNames(input, cache, builtImages)
+	if shouldRedeploy == false {
+		t.Fatal("Expected to redeploy")
+	}
+
+	if !reflect.DeepEqual(input, output) {
+		gotYaml, _ := yaml.Marshal(input)
+		expectedYaml, _ := yaml.Marshal(output)
+
+		t.Fatalf("Replace failed: Got\n %s\n, but expected\n %s", gotYaml, expectedYaml)
+	}
+}
+
+func TestGetValuesMergeOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testValues")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+	defer os.Chdir(wdBackup)
+
+	files := map[string]string{
+		"chart/values.yaml":     "a: chart\nb: chart\nc: chart\nd: chart\ne: chart\n",
+		"chart/values-dev.yaml": "b: dev\nc: dev\nd: dev\ne: dev\n",
+		"values-local.yaml":     "c: local\nd: local\ne: local\n",
+	}
+	err = os.MkdirAll("chart", 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range files {
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deployConfig := &latest.DeploymentConfig{
+		Name: ptr.String("test-deployment"),
+		Helm: &latest.HelmConfig{
+			Chart: &latest.ChartConfig{
+				Name: ptr.String("chart"),
+			},
+			ValuesFiles:  &[]*string{ptr.String("values-dev.yaml"), ptr.String("values-local.yaml")},
+			InlineValues: ptr.String("d: block\ne: block\n"),
+			Values: &map[interface{}]interface{}{
+				"d": "inline",
+			},
+		},
+	}
+
+	helm := &DeployConfig{
+		DeploymentConfig: deployConfig,
+		Log:              &log.DiscardLogger{},
+	}
+	values, _, err := helm.GetValues(&generated.CacheConfig{}, nil)
+	if err != nil {
+		t.Fatalf("Error getting values: %v", err)
+	}
+
+	expected := map[interface{}]interface{}{
+		"a": "chart",
+		"b": "dev",
+		"c": "local",
+		"d": "inline",
+		"e": "block",
+	}
+	if reflect.DeepEqual(values, expected) == false {
+		t.Fatalf("Unexpected values: %v != %v", values, expected)
+	}
+}
@@ -0,0 +1,135 @@
+package helm
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+)
+
+// NamespaceEnvVar is the env var that holds the namespace of the deployment
+const NamespaceEnvVar = "DEVSPACE_NAMESPACE"
+
+// ImageEnvVarPrefix is the prefix of the env vars that hold the built image of images with injectEnv
+const ImageEnvVarPrefix = "DEVSPACE_IMAGE_"
+
+var envVarNameRegEx = regexp.MustCompile("[^A-Z0-9_]")
+
+// getInjectedEnv returns the env vars of the deployment config together with the env vars devspace provides and
+// whether one of the injected images was built
+func (d *DeployConfig) getInjectedEnv(cache *generated.CacheConfig, builtImages map[string]string) ([]interface{}, bool) {
+	env := []interface{}{}
+	imageBuilt := false
+	if d.DeploymentConfig.Env != nil {
+		for _, envVar := range *d.DeploymentConfig.Env {
+			if envVar != nil {
+				env = append(env, *envVar)
+			}
+		}
+	}
+
+	// Add the built images of images with injectEnv
+	if d.config != nil && d.config.Images != nil {
+		imageConfigNames := []string{}
+		for imageConfigName, imageConf := range *d.config.Images {
+			if imageConf.InjectEnv != nil && *imageConf.InjectEnv == true {
+				imageConfigNames = append(imageConfigNames, imageConfigName)
+			}
+		}
+		sort.Strings(imageConfigNames)
+
+		for _, imageConfigName := range imageConfigNames {
+			imageCache, ok := cache.Images[imageConfigName]
+			if ok == false || imageCache.Tag == "" {
+				continue
+			}
+			if _, ok := builtImages[imageCache.ImageName]; ok {
+				imageBuilt = true
+			}
+
+			env = append(env, map[interface{}]interface{}{
+				"name":  ImageEnvVarPrefix + envVarNameRegEx.ReplaceAllString(strings.ToUpper(imageConfigName), "_"),
+				"value": imageCache.GetResolvedImageName() + ":" + imageCache.Tag,
+			})
+		}
+	}
+
+	if len(env) == 0 {
+		return env, false
+	}
+
+	namespace := ""
+	if d.DeploymentConfig.Namespace != nil && *d.DeploymentConfig.Namespace != "" {
+		namespace = *d.DeploymentConfig.Namespace
+	} else {
+		defaultNamespace, err := configutil.GetDefaultNamespace(d.config)
+		if err == nil {
+			namespace = defaultNamespace
+		}
+	}
+	if namespace != "" {
+		env = append(env, map[interface{}]interface{}{
+			"name":  NamespaceEnvVar,
+			"value": namespace,
+		})
+	}
+
+	return env, imageBuilt
+}
+
+// injectEnv adds the env vars to the env list in the values or, for the component chart, to the env list
+// of every container. Env vars that are already defined in the values are not overridden
+func (d *DeployConfig) injectEnv(values map[interface{}]interface{}, env []interface{}) {
+	if len(env) == 0 {
+		return
+	}
+
+	if d.ComponentChart == false {
+		values["env"] = mergeEnv(values["env"], env)
+		return
+	}
+
+	containers, ok := values["containers"].([]interface{})
+	if ok == false {
+		return
+	}
+
+	for _, container := range containers {
+		if containerValues, ok := container.(map[interface{}]interface{}); ok {
+			containerValues["env"] = mergeEnv(containerValues["env"], env)
+		}
+	}
+}
+
+func mergeEnv(existing interface{}, env []interface{}) interface{} {
+	existingEnv, ok := existing.([]interface{})
+	if existing != nil && ok == false {
+		// We don't know how to merge this, so we leave it as it is
+		return existing
+	}
+
+	defined := map[string]bool{}
+	for _, envVar := range existingEnv {
+		if envVarMap, ok := envVar.(map[interface{}]interface{}); ok {
+			if name, ok := envVarMap["name"].(string); ok {
+				defined[name] = true
+			}
+		}
+	}
+
+	merged := make([]interface{}, 0, len(existingEnv)+len(env))
+	merged = append(merged, existingEnv...)
+	for _, envVar := range env {
+		if envVarMap, ok := envVar.(map[interface{}]interface{}); ok {
+			if name, ok := envVarMap["name"].(string); ok && defined[name] {
+				continue
+			}
+		}
+
+		merged = append(merged, envVar)
+	}
+
+	return merged
+}
@@ -0,0 +1,107 @@
+package helm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+)
+
+func TestInjectEnv(t *testing.T) {
+	config := &latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"backend": &latest.ImageConfig{
+				Image:     ptr.String("myuser/backend"),
+				InjectEnv: ptr.Bool(true),
+			},
+			"frontend": &latest.ImageConfig{
+				Image: ptr.String("myuser/frontend"),
+			},
+		},
+	}
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"backend": &generated.ImageCache{
+				ImageName: "myuser/backend",
+				Tag:       "abc",
+			},
+			"frontend": &generated.ImageCache{
+				ImageName: "myuser/frontend",
+				Tag:       "def",
+			},
+		},
+	}
+	env := &[]*map[interface{}]interface{}{
+		&map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		&map[interface{}]interface{}{"name": "OVERRIDDEN", "value": "injected"},
+	}
+
+	// Helm chart
+	deployConfig := &DeployConfig{
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name:      ptr.String("test"),
+			Namespace: ptr.String("test"),
+			Env:       env,
+			Helm: &latest.HelmConfig{
+				Chart: &latest.ChartConfig{
+					Name: ptr.String("notexisting"),
+				},
+				Values: &map[interface{}]interface{}{
+					"env": []interface{}{
+						map[interface{}]interface{}{"name": "OVERRIDDEN", "value": "values"},
+					},
+				},
+			},
+		},
+		Log:    &log.DiscardLogger{},
+		config: config,
+	}
+
+	values, shouldRedeploy, err := deployConfig.GetValues(cache, map[string]string{"myuser/backend": "abc"})
+	if err != nil {
+		t.Fatalf("Error getting values: %v", err)
+	}
+	if shouldRedeploy == false {
+		t.Fatal("Expected redeploy after injected image was built")
+	}
+
+	expected := []interface{}{
+		map[interface{}]interface{}{"name": "OVERRIDDEN", "value": "values"},
+		map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		map[interface{}]interface{}{"name": "DEVSPACE_IMAGE_BACKEND", "value": "myuser/backend:abc"},
+		map[interface{}]interface{}{"name": "DEVSPACE_NAMESPACE", "value": "test"},
+	}
+	if reflect.DeepEqual(values["env"], expected) == false {
+		t.Fatalf("Unexpected env: %v != %v", values["env"], expected)
+	}
+
+	// Component chart
+	deployConfig.ComponentChart = true
+	deployConfig.DeploymentConfig.Helm.Values = &map[interface{}]interface{}{
+		"containers": []interface{}{
+			map[interface{}]interface{}{"image": "myuser/frontend"},
+		},
+	}
+
+	values, _, err = deployConfig.GetValues(cache, nil)
+	if err != nil {
+		t.Fatalf("Error getting values: %v", err)
+	}
+	if _, ok := values["env"]; ok {
+		t.Fatal("Env was injected into the component values instead of the containers")
+	}
+
+	container := values["containers"].([]interface{})[0].(map[interface{}]interface{})
+	expected = []interface{}{
+		map[interface{}]interface{}{"name": "LOG_LEVEL", "value": "debug"},
+		map[interface{}]interface{}{"name": "OVERRIDDEN", "value": "injected"},
+		map[interface{}]interface{}{"name": "DEVSPACE_IMAGE_BACKEND", "value": "myuser/backend:abc"},
+		map[interface{}]interface{}{"name": "DEVSPACE_NAMESPACE", "value": "test"},
+	}
+	if reflect.DeepEqual(container["env"], expected) == false {
+		t.Fatalf("Unexpected container env: %v != %v", container["env"], expected)
+	}
+}
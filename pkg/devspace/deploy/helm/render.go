@@ -0,0 +1,46 @@
+package helm
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+)
+
+// Render renders the chart of the deployment locally with the values it would be deployed with and returns the manifests.
+// If there is a kube client, the templates are rendered with the capabilities of the cluster
+func (d *DeployConfig) Render(cache *generated.CacheConfig) (string, error) {
+	values, _, err := d.GetValues(cache, nil)
+	if err != nil {
+		return "", err
+	}
+
+	releaseNamespace, err := configutil.GetDefaultNamespace(d.config)
+	if err != nil {
+		return "", err
+	}
+	if d.DeploymentConfig.Namespace != nil && *d.DeploymentConfig.Namespace != "" {
+		releaseNamespace = *d.DeploymentConfig.Namespace
+	}
+
+	renderChart, err := helm.LoadChart(d.DeploymentConfig.Helm.Chart)
+	if err != nil {
+		return "", err
+	}
+
+	capabilities, err := d.getCapabilities()
+	if err != nil {
+		return "", err
+	}
+
+	return helm.RenderChart(renderChart, *d.DeploymentConfig.Name, releaseNamespace, values, capabilities)
+}
+
+// getCapabilities returns the capabilities of the cluster or nil if the chart is rendered without a cluster
+func (d *DeployConfig) getCapabilities() (*kubectl.Capabilities, error) {
+	if d.Kube == nil {
+		return nil, nil
+	}
+
+	return kubectl.GetCapabilities(d.Kube)
+}
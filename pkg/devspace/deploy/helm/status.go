@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/devspace/helm"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
 )
 
 // Status gets the status of the deployment
@@ -71,6 +73,48 @@ func (d *DeployConfig) Status() (*deploy.StatusResult, error) {
 	}, nil
 }
 
+// GetRelease returns the deployed helm release of this deployment or nil if the release was not found
+func (d *DeployConfig) GetRelease() (*hapi_release5.Release, error) {
+	if d.Helm == nil {
+		var err error
+
+		// Get HelmClient
+		d.Helm, err = helm.NewClient(d.config, d.TillerNamespace, d.Log, false)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	releases, err := d.Helm.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+	if releases == nil {
+		return nil, nil
+	}
+
+	for _, release := range releases.Releases {
+		if release.GetName() == *d.DeploymentConfig.Name {
+			return release, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetResources returns the kubernetes resources of the deployed helm release
+func (d *DeployConfig) GetResources(cache *generated.CacheConfig) ([]*deploy.Resource, error) {
+	release, err := d.GetRelease()
+	if err != nil {
+		return nil, err
+	}
+	if release == nil {
+		return nil, nil
+	}
+
+	return deploy.GetResources(release.Manifest, release.Namespace)
+}
+
 func (d *DeployConfig) getDeployTarget() string {
 	if d.DeploymentConfig.Helm == nil || d.DeploymentConfig.Helm.Chart == nil {
 		return "N/A"
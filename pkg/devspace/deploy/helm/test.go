@@ -0,0 +1,97 @@
+package helm
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/pkg/errors"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+// shouldRunTests returns true if the helm tests should run after the deployment
+func (d *DeployConfig) shouldRunTests() bool {
+	return d.DeploymentConfig.Helm.RunTests != nil && *d.DeploymentConfig.Helm.RunTests == true
+}
+
+// getRelease returns the deployed release with the given name or nil if it doesn't exist
+func (d *DeployConfig) getRelease(releaseName string) (*hapi_release5.Release, error) {
+	releases, err := d.Helm.ListReleases()
+	if err != nil {
+		return nil, err
+	}
+
+	if releases != nil {
+		for _, release := range releases.Releases {
+			if release.GetName() == releaseName {
+				return release, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// runTests runs the helm test hooks of the release, prints the logs of the test pods and returns an error if a test failed
+func (d *DeployConfig) runTests(release *hapi_release5.Release) error {
+	releaseName := *d.DeploymentConfig.Name
+
+	d.Log.StartWait(fmt.Sprintf("Running helm tests of release %s", releaseName))
+	results, err := d.Helm.RunReleaseTest(releaseName, d.DeploymentConfig.Helm)
+	d.Log.StopWait()
+
+	failed := 0
+	for _, result := range results {
+		switch result.Status {
+		case hapi_release5.TestRun_SUCCESS:
+			d.Log.Done(result.Msg)
+		case hapi_release5.TestRun_FAILURE:
+			d.Log.Fail(result.Msg)
+			failed++
+		default:
+			d.Log.Info(result.Msg)
+		}
+	}
+
+	// Print the logs of the test pods
+	if release != nil && d.Kube != nil {
+		for _, testPod := range getTestPods(release) {
+			logs, logErr := kubectl.Logs(d.Kube, release.Namespace, testPod, "", false, nil)
+			if logErr != nil {
+				d.Log.Warnf("Couldn't retrieve logs of test pod %s: %v", testPod, logErr)
+				continue
+			}
+
+			d.Log.Infof("Logs of test pod %s:\n%s", testPod, strings.TrimSpace(logs))
+		}
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "run helm tests")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d helm tests of release %s failed", failed, len(results), releaseName)
+	}
+
+	d.Log.Donef("Helm tests of release %s passed", releaseName)
+	return nil
+}
+
+// getTestPods returns the names of all test pods defined as hooks in the release
+func getTestPods(release *hapi_release5.Release) []string {
+	testPods := []string{}
+	for _, hook := range release.Hooks {
+		if hook.Kind != "Pod" {
+			continue
+		}
+
+		for _, event := range hook.Events {
+			if event == hapi_release5.Hook_RELEASE_TEST_SUCCESS || event == hapi_release5.Hook_RELEASE_TEST_FAILURE {
+				testPods = append(testPods, hook.Name)
+				break
+			}
+		}
+	}
+
+	return testPods
+}
@@ -0,0 +1,21 @@
+package helm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
+)
+
+func TestGetTestPods(t *testing.T) {
+	release := &hapi_release5.Release{
+		Hooks: []*hapi_release5.Hook{
+			{Name: "pre-install-job", Kind: "Job", Events: []hapi_release5.Hook_Event{hapi_release5.Hook_PRE_INSTALL}},
+			{Name: "test-connection", Kind: "Pod", Events: []hapi_release5.Hook_Event{hapi_release5.Hook_RELEASE_TEST_SUCCESS}},
+			{Name: "test-failure", Kind: "Pod", Events: []hapi_release5.Hook_Event{hapi_release5.Hook_RELEASE_TEST_FAILURE}},
+			{Name: "post-install-pod", Kind: "Pod", Events: []hapi_release5.Hook_Event{hapi_release5.Hook_POST_INSTALL}},
+		},
+	}
+
+	assert.DeepEqual(t, getTestPods(release), []string{"test-connection", "test-failure"})
+}
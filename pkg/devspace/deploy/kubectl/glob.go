@@ -0,0 +1,145 @@
+package kubectl
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/remote"
+)
+
+// kindOrder is the order in which the kinds of globbed manifest files are applied, so that e.g. namespaces and
+// config maps exist before the workloads that use them. Kinds that are not listed are applied last
+var kindOrder = []string{
+	"Namespace",
+	"ResourceQuota",
+	"LimitRange",
+	"PodSecurityPolicy",
+	"PodDisruptionBudget",
+	"Secret",
+	"ConfigMap",
+	"StorageClass",
+	"PersistentVolume",
+	"PersistentVolumeClaim",
+	"ServiceAccount",
+	"CustomResourceDefinition",
+	"ClusterRole",
+	"ClusterRoleBinding",
+	"Role",
+	"RoleBinding",
+	"Service",
+	"DaemonSet",
+	"Pod",
+	"ReplicationController",
+	"ReplicaSet",
+	"Deployment",
+	"HorizontalPodAutoscaler",
+	"StatefulSet",
+	"Job",
+	"CronJob",
+	"Ingress",
+	"APIService",
+}
+
+// kindRegex matches top-level kinds in yaml and kinds in json manifests
+var kindRegex = regexp.MustCompile(`(?m)(?:^kind|"kind")\s*:\s*["']?([A-Za-z0-9]+)`)
+
+// manifestExtensions are the file extensions kubectl reads when applying a directory
+var manifestExtensions = map[string]bool{
+	".yaml": true,
+	".yml":  true,
+	".json": true,
+}
+
+// IsGlob checks if a manifest path contains glob characters
+func IsGlob(manifest string) bool {
+	return strings.ContainsAny(manifest, "*?[")
+}
+
+// ExpandManifests expands manifest paths that contain globs (e.g. k8s/**/*.yaml) into the yaml and json files
+// they match. The files of a glob are sorted by the kinds they contain and then by path, so the order is the same
+// on every run. Urls and paths without globs are returned unchanged
+func ExpandManifests(manifests []string) ([]string, error) {
+	expanded := []string{}
+	for _, manifest := range manifests {
+		if remote.IsURL(manifest) || IsGlob(manifest) == false {
+			expanded = append(expanded, manifest)
+			continue
+		}
+
+		matches, err := doublestar.Glob(manifest)
+		if err != nil {
+			return nil, fmt.Errorf("Error expanding manifest %s: %v", manifest, err)
+		}
+
+		files := []string{}
+		for _, match := range matches {
+			if manifestExtensions[strings.ToLower(filepath.Ext(match))] == false {
+				continue
+			}
+
+			stat, err := os.Stat(match)
+			if err != nil || stat.IsDir() {
+				continue
+			}
+
+			files = append(files, match)
+		}
+		if len(files) == 0 {
+			return nil, fmt.Errorf("Manifest %s doesn't match any yaml or json files", manifest)
+		}
+
+		sorted, err := sortByKind(files)
+		if err != nil {
+			return nil, err
+		}
+
+		expanded = append(expanded, sorted...)
+	}
+
+	return expanded, nil
+}
+
+// sortByKind sorts the files by the position of their first kind in kindOrder and then by path
+func sortByKind(files []string) ([]string, error) {
+	priorities := make(map[string]int, len(files))
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading manifest %s: %v", file, err)
+		}
+
+		priorities[file] = getKindPriority(string(content))
+	}
+
+	sorted := append([]string{}, files...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if priorities[sorted[i]] != priorities[sorted[j]] {
+			return priorities[sorted[i]] < priorities[sorted[j]]
+		}
+
+		return filepath.ToSlash(sorted[i]) < filepath.ToSlash(sorted[j])
+	})
+
+	return sorted, nil
+}
+
+// getKindPriority returns the lowest position in kindOrder of the kinds in the manifest
+func getKindPriority(manifest string) int {
+	priority := len(kindOrder)
+	for _, match := range kindRegex.FindAllStringSubmatch(manifest, -1) {
+		for i, kind := range kindOrder {
+			if kind == match[1] && i < priority {
+				priority = i
+				break
+			}
+		}
+	}
+
+	return priority
+}
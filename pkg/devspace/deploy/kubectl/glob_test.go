@@ -0,0 +1,108 @@
+package kubectl
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestExpandManifests(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+	defer os.Chdir(wdBackup)
+
+	files := map[string]string{
+		"k8s/app/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\n",
+		"k8s/app/service.yml":     "apiVersion: v1\nkind: Service\n",
+		"k8s/config.yaml":         "apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: Namespace\n",
+		"k8s/b-secret.json":       "{\"apiVersion\": \"v1\", \"kind\": \"Secret\"}",
+		"k8s/a-secret.yaml":       "apiVersion: v1\nkind: Secret\n",
+		"k8s/README.md":           "kind: Namespace\n",
+	}
+	for path, content := range files {
+		err = os.MkdirAll(filepath.Dir(path), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = ioutil.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expanded, err := ExpandManifests([]string{"https://example.com/service.yaml", "k8s/**/*", "kube"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, expanded, []string{
+		"https://example.com/service.yaml",
+		"k8s/config.yaml",
+		"k8s/a-secret.yaml",
+		"k8s/b-secret.json",
+		"k8s/app/service.yml",
+		"k8s/app/deployment.yaml",
+		"kube",
+	})
+
+	_, err = ExpandManifests([]string{"other/*.yaml"})
+	assert.ErrorContains(t, err, "doesn't match any yaml or json files")
+}
+
+func TestGetManifestPathsInline(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+	defer os.Chdir(wdBackup)
+
+	inlineManifest := "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"
+	deployConfig := &DeployConfig{
+		Name:      "test",
+		Manifests: []string{"kube"},
+		DeploymentConfig: &latest.DeploymentConfig{
+			Kubectl: &latest.KubectlConfig{
+				InlineManifest: &inlineManifest,
+			},
+		},
+	}
+
+	manifests, err := deployConfig.getManifestPaths()
+	assert.NilError(t, err)
+	assert.Equal(t, len(manifests), 2)
+	assert.Equal(t, manifests[1], "kube")
+
+	content, err := ioutil.ReadFile(manifests[0])
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), inlineManifest)
+
+	if runtime.GOOS != "windows" {
+		stat, err := os.Stat(manifests[0])
+		assert.NilError(t, err)
+		assert.Equal(t, stat.Mode().Perm(), os.FileMode(0600))
+	}
+}
@@ -2,7 +2,10 @@ package kubectl
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
 	"strings"
 
@@ -10,10 +13,12 @@ import (
 	yaml "gopkg.in/yaml.v2"
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl/walk"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/remote"
 	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -21,6 +26,9 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 )
 
+// InlineManifestFolder is the folder inline manifests are written to before they are applied
+const InlineManifestFolder = ".devspace/inline"
+
 // DeployConfig holds the necessary information for kubectl deployment
 type DeployConfig struct {
 	KubeClient kubernetes.Interface // This is not used yet, however the plan is to use it instead of calling kubectl via cmd
@@ -32,6 +40,11 @@ type DeployConfig struct {
 
 	DeploymentConfig *latest.DeploymentConfig
 	Log              log.Logger
+
+	config *latest.Config
+
+	// kubectlNamespace is the namespace kubectl sets on resources without namespace if forceNamespace is enabled
+	kubectlNamespace string
 }
 
 // New creates a new deploy config for kubectl
@@ -39,9 +52,12 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 	if deployConfig.Kubectl == nil {
 		return nil, errors.New("Error creating kubectl deploy config: kubectl is nil")
 	}
-	if deployConfig.Kubectl.Manifests == nil {
+	if deployConfig.Kubectl.Manifests == nil && deployConfig.Kubectl.InlineManifest == nil {
 		return nil, errors.New("No manifests defined for kubectl deploy")
 	}
+	if deployConfig.Kubectl.InlineManifest != nil && deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true {
+		return nil, errors.New("Inline manifests cannot be used with kustomize")
+	}
 
 	context := ""
 	if config.Cluster != nil && config.Cluster.KubeContext != nil {
@@ -61,11 +77,40 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 		cmdPath = *deployConfig.Kubectl.CmdPath
 	}
 
+	// Without --namespace kubectl sets the namespace of the kube context on resources that don't specify one
+	kubectlNamespace := ""
+	if deployConfig.Kubectl.ForceNamespace != nil && *deployConfig.Kubectl.ForceNamespace == true {
+		kubectlConfig := &latest.Config{Cluster: &latest.Cluster{}}
+		if context != "" {
+			kubectlConfig.Cluster.KubeContext = &context
+		}
+
+		kubectlNamespace, err = configutil.GetDefaultNamespace(kubectlConfig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	manifestPaths := []*string{}
+	if deployConfig.Kubectl.Manifests != nil {
+		manifestPaths = *deployConfig.Kubectl.Manifests
+	}
+
 	manifests := []string{}
-	for _, ptrManifest := range *deployConfig.Kubectl.Manifests {
-		manifest := strings.Replace(*ptrManifest, "*", "", -1)
+	for _, ptrManifest := range manifestPaths {
+		if remote.IsURL(*ptrManifest) {
+			if deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true {
+				return nil, fmt.Errorf("Manifest %s: urls cannot be used with kustomize", *ptrManifest)
+			}
+
+			manifests = append(manifests, *ptrManifest)
+			continue
+		}
+
+		// Globs are expanded every time the manifests are applied, so that new files are picked up
+		manifest := *ptrManifest
 		if deployConfig.Kubectl.Kustomize != nil && *deployConfig.Kubectl.Kustomize == true {
-			manifest = strings.TrimSuffix(manifest, "kustomization.yaml")
+			manifest = strings.TrimSuffix(strings.Replace(manifest, "*", "", -1), "kustomization.yaml")
 		}
 
 		manifests = append(manifests, manifest)
@@ -81,6 +126,8 @@ func New(config *latest.Config, kubectl kubernetes.Interface, deployConfig *late
 
 		DeploymentConfig: deployConfig,
 		Log:              log,
+		config:           config,
+		kubectlNamespace: kubectlNamespace,
 	}, nil
 }
 
@@ -105,7 +152,12 @@ func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 	d.Log.StartWait("Deleting manifests with kubectl")
 	defer d.Log.StopWait()
 
-	for _, manifest := range d.Manifests {
+	manifests, err := d.getManifestPaths()
+	if err != nil {
+		return err
+	}
+
+	for _, manifest := range manifests {
 		_, replacedManifest, err := d.getReplacedManifest(manifest, cache, nil)
 		if err != nil {
 			return err
@@ -121,6 +173,7 @@ func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 		cmd.Stderr = d.Log
 
 		err = cmd.Run()
+		audit.Record(audit.ActionDelete, "Manifest", d.Namespace, manifest, err)
 		if err != nil {
 			return err
 		}
@@ -134,11 +187,21 @@ func (d *DeployConfig) Delete(cache *generated.CacheConfig) error {
 func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, builtImages map[string]string) (bool, error) {
 	deployCache := cache.GetDeploymentCache(*d.DeploymentConfig.Name)
 
+	manifests, err := d.getManifestPaths()
+	if err != nil {
+		return false, err
+	}
+
 	// Hash the manifests
 	manifestsHash := ""
-	for _, manifest := range d.Manifests {
+	for _, manifest := range manifests {
+		localManifest, err := remote.GetLocalPath(manifest, d.DeploymentConfig.Kubectl.Remote, d.Log)
+		if err != nil {
+			return false, err
+		}
+
 		// Check if the chart directory has changed
-		hash, err := hash.Directory(manifest)
+		hash, err := hash.Directory(localManifest)
 		if err != nil {
 			return false, fmt.Errorf("Error hashing %s: %v", manifest, err)
 		}
@@ -164,7 +227,7 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 
 	wasDeployed := false
 
-	for _, manifest := range d.Manifests {
+	for _, manifest := range manifests {
 		shouldRedeploy, replacedManifest, err := d.getReplacedManifest(manifest, cache, builtImages)
 		if err != nil {
 			return false, fmt.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
@@ -186,6 +249,7 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 			cmd.Stderr = d.Log
 
 			err = cmd.Run()
+			audit.Record(audit.ActionApply, "Manifest", d.Namespace, manifest, err)
 			if err != nil {
 				return false, fmt.Errorf("%v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", err, manifest)
 			}
@@ -202,7 +266,95 @@ func (d *DeployConfig) Deploy(cache *generated.CacheConfig, forceDeploy bool, bu
 	return wasDeployed, nil
 }
 
+// GetManifests returns all manifests with the image tags from the cache injected, the way they would be applied by kubectl
+func (d *DeployConfig) GetManifests(cache *generated.CacheConfig) (string, error) {
+	manifestPaths, err := d.getManifestPaths()
+	if err != nil {
+		return "", err
+	}
+
+	manifests := []string{}
+	for _, manifest := range manifestPaths {
+		_, replacedManifest, err := d.getReplacedManifest(manifest, cache, nil)
+		if err != nil {
+			return "", errors.Wrapf(err, "render manifest %s", manifest)
+		}
+
+		manifests = append(manifests, replacedManifest)
+	}
+
+	return strings.Join(manifests, "\n---\n"), nil
+}
+
+// getManifestPaths returns the manifests with globs expanded. Kustomize manifests are directories and not expanded.
+// The inline manifest is written to a file and applied first, because it usually holds resources like config maps
+// the other manifests depend on
+func (d *DeployConfig) getManifestPaths() ([]string, error) {
+	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize == true {
+		return d.Manifests, nil
+	}
+
+	manifests, err := ExpandManifests(d.Manifests)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.DeploymentConfig.Kubectl.InlineManifest != nil && strings.TrimSpace(*d.DeploymentConfig.Kubectl.InlineManifest) != "" {
+		inlineManifest, err := d.writeInlineManifest()
+		if err != nil {
+			return nil, err
+		}
+
+		manifests = append([]string{inlineManifest}, manifests...)
+	}
+
+	return manifests, nil
+}
+
+// writeInlineManifest writes the inline manifest of the deployment to the inline manifest folder and returns its path
+func (d *DeployConfig) writeInlineManifest() (string, error) {
+	inlineManifest, err := filepath.Abs(filepath.Join(InlineManifestFolder, d.Name+".yaml"))
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(filepath.Dir(inlineManifest), 0755)
+	if err != nil {
+		return "", err
+	}
+
+	// Inline manifests can contain secrets, so only the user may read them. Chmod also restricts files written
+	// by older versions, because WriteFile keeps the mode of existing files
+	err = ioutil.WriteFile(inlineManifest, []byte(*d.DeploymentConfig.Kubectl.InlineManifest), 0600)
+	if err != nil {
+		return "", errors.Wrap(err, "write inline manifest")
+	}
+
+	err = os.Chmod(inlineManifest, 0600)
+	if err != nil {
+		return "", errors.Wrap(err, "chmod inline manifest")
+	}
+
+	return inlineManifest, nil
+}
+
+// GetResources returns the kubernetes resources of the manifests
+func (d *DeployConfig) GetResources(cache *generated.CacheConfig) ([]*deploy.Resource, error) {
+	manifests, err := d.GetManifests(cache)
+	if err != nil {
+		return nil, err
+	}
+
+	return deploy.GetResources(manifests, d.Namespace)
+}
+
 func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.CacheConfig, builtImages map[string]string) (bool, string, error) {
+	// Manifests from urls are fetched into the remote cache folder
+	manifest, err := remote.GetLocalPath(manifest, d.DeploymentConfig.Kubectl.Remote, d.Log)
+	if err != nil {
+		return false, "", err
+	}
+
 	manifestYamlBytes, err := d.dryRun(manifest)
 	if err != nil {
 		return false, "", err
@@ -212,6 +364,7 @@ func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.Cac
 	splitted := regexp.MustCompile(`(^|\n)apiVersion`).Split(string(manifestYamlBytes), -1)
 	replaceManifests := []string{}
 	shouldRedeploy := false
+	pullOptions := registry.GetPullOptions(d.config, cache)
 
 	for _, resource := range splitted {
 		if resource == "" {
@@ -229,6 +382,13 @@ func (d *DeployConfig) getReplacedManifest(manifest string, cache *generated.Cac
 			shouldRedeploy = replaceManifest(manifestYaml, cache, builtImages) || shouldRedeploy
 		}
 
+		// Set the pull policy and inject the pull secrets of images with images.*.pullPolicy or images.*.injectPullSecret
+		registry.InjectPullOptions(manifestYaml, pullOptions, true)
+
+		if d.forceNamespace() {
+			d.replaceNamespace(manifestYaml, manifest)
+		}
+
 		replacedManifest, err := yaml.Marshal(manifestYaml)
 		if err != nil {
 			return false, "", errors.Wrap(err, "marshal yaml")
@@ -265,7 +425,10 @@ func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
 	if d.Context != "" {
 		args = append(args, "--context", d.Context)
 	}
-	if d.Namespace != "" {
+
+	// kubectl refuses resources with a different namespace than --namespace, so with forceNamespace the namespace
+	// is only replaced afterwards
+	if d.Namespace != "" && d.forceNamespace() == false {
 		args = append(args, "--namespace", d.Namespace)
 	}
 
@@ -284,6 +447,10 @@ func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
 	if err != nil {
 		exitError, ok := err.(*exec.ExitError)
 		if ok {
+			if strings.Contains(string(exitError.Stderr), "does not match the namespace") {
+				return nil, fmt.Errorf("%s\nSet `forceNamespace: true` in the kubectl options of deployment %s to deploy the resources into namespace %s", strings.TrimSpace(string(exitError.Stderr)), d.Name, d.Namespace)
+			}
+
 			return nil, errors.New(string(exitError.Stderr))
 		}
 
@@ -293,6 +460,29 @@ func (d *DeployConfig) dryRun(manifest string) ([]byte, error) {
 	return output, nil
 }
 
+func (d *DeployConfig) forceNamespace() bool {
+	return d.DeploymentConfig.Kubectl.ForceNamespace != nil && *d.DeploymentConfig.Kubectl.ForceNamespace == true
+}
+
+// replaceNamespace sets the namespace of a namespaced resource to the target namespace and warns if the resource
+// had a different namespace hardcoded. Resources without namespace (e.g. cluster-scoped ones) are not changed
+func (d *DeployConfig) replaceNamespace(resource map[interface{}]interface{}, manifest string) {
+	metadata, ok := resource["metadata"].(map[interface{}]interface{})
+	if ok == false {
+		return
+	}
+
+	namespace, ok := metadata["namespace"].(string)
+	if ok == false || namespace == "" || namespace == d.Namespace {
+		return
+	}
+
+	metadata["namespace"] = d.Namespace
+	if namespace != d.kubectlNamespace {
+		d.Log.Warnf("Manifest %s: Replaced namespace %s of %v %v with %s", manifest, namespace, resource["kind"], metadata["name"], d.Namespace)
+	}
+}
+
 func replaceManifest(manifest map[interface{}]interface{}, cache *generated.CacheConfig, builtImages map[string]string) bool {
 	shouldRedeploy := false
 
@@ -305,9 +495,9 @@ func replaceManifest(manifest map[interface{}]interface{}, cache *generated.Cach
 
 			// Search for image name
 			for _, imageCache := range cache.Images {
-				if imageCache.ImageName == image && imageCache.Tag != "" {
+				if imageCache.Tag != "" && registry.IsSameImage(imageCache.ImageName, image) {
 					if builtImages != nil {
-						if _, ok := builtImages[image]; ok {
+						if _, ok := builtImages[imageCache.ImageName]; ok {
 							shouldRedeploy = true
 						}
 					}
@@ -328,8 +518,8 @@ func replaceManifest(manifest map[interface{}]interface{}, cache *generated.Cach
 
 		// Search for image name
 		for _, imageCache := range cache.Images {
-			if imageCache.ImageName == image {
-				return image + ":" + imageCache.Tag, nil
+			if imageCache.Tag != "" && registry.IsSameImage(imageCache.ImageName, image) {
+				return imageCache.GetResolvedImageName() + ":" + imageCache.Tag, nil
 			}
 		}
 
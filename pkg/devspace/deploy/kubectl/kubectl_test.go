@@ -1,12 +1,12 @@
 package kubectl
 
 import (
+	"bytes"
 	"io/ioutil"
 	"os"
+	"strings"
 	"testing"
 
-	//"strings"
-
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/sirupsen/logrus"
 	"gotest.tools/assert"
 )
 
@@ -290,3 +291,42 @@ spec:
 
 	return nil
 }
+
+func TestReplaceNamespace(t *testing.T) {
+	output := &bytes.Buffer{}
+	deployConfig := &DeployConfig{
+		Namespace:        "dev",
+		Log:              log.NewStreamLogger(output, logrus.InfoLevel),
+		kubectlNamespace: "default",
+	}
+
+	// Hardcoded namespaces are replaced with a warning
+	resource := map[interface{}]interface{}{
+		"kind": "Deployment",
+		"metadata": map[interface{}]interface{}{
+			"name":      "api",
+			"namespace": "production",
+		},
+	}
+	deployConfig.replaceNamespace(resource, "kube/api.yaml")
+	assert.Equal(t, resource["metadata"].(map[interface{}]interface{})["namespace"], "dev")
+	assert.Equal(t, strings.Contains(output.String(), "Replaced namespace production of Deployment api with dev"), true, "No warning: "+output.String())
+
+	// The namespace kubectl set on resources without namespace is replaced silently
+	output.Reset()
+	resource["metadata"].(map[interface{}]interface{})["namespace"] = "default"
+	deployConfig.replaceNamespace(resource, "kube/api.yaml")
+	assert.Equal(t, resource["metadata"].(map[interface{}]interface{})["namespace"], "dev")
+	assert.Equal(t, output.String(), "")
+
+	// Resources without namespace are not changed
+	resource = map[interface{}]interface{}{
+		"kind": "ClusterRole",
+		"metadata": map[interface{}]interface{}{
+			"name": "reader",
+		},
+	}
+	deployConfig.replaceNamespace(resource, "kube/rbac.yaml")
+	_, ok := resource["metadata"].(map[interface{}]interface{})["namespace"]
+	assert.Equal(t, ok, false, "Namespace added to cluster-scoped resource")
+}
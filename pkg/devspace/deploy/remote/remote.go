@@ -0,0 +1,147 @@
+package remote
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/hash"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+// CacheFolder is the folder where fetched manifests and values files are stored
+const CacheFolder = ".devspace/remote"
+
+// FetchTimeout is the timeout for fetching a single file
+const FetchTimeout = 30 * time.Second
+
+var (
+	fetchedMutex sync.Mutex
+	fetched      = map[string]string{}
+)
+
+// IsURL checks if a manifest or values file path is a http or https url
+func IsURL(source string) bool {
+	return strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://")
+}
+
+// GetLocalPath returns source unchanged if it is not an url. Otherwise the file is fetched with the headers of the
+// remote config and stored in the cache folder, the returned path points to the cached file. Every url is only fetched
+// once per run. If fetching fails, the previously cached file is used if there is one
+func GetLocalPath(source string, remoteConfig *latest.RemoteConfig, log log.Logger) (string, error) {
+	if IsURL(source) == false {
+		return source, nil
+	}
+
+	fetchedMutex.Lock()
+	defer fetchedMutex.Unlock()
+
+	if localPath, ok := fetched[source]; ok {
+		return localPath, nil
+	}
+
+	localPath, err := getCachePath(source)
+	if err != nil {
+		return "", err
+	}
+
+	err = fetch(source, localPath, remoteConfig)
+	if err != nil {
+		if _, statErr := os.Stat(localPath); statErr != nil {
+			return "", errors.Wrapf(err, "fetch %s", source)
+		}
+
+		log.Warnf("Error fetching %s, using cached file: %v", source, err)
+	}
+
+	fetched[source] = localPath
+	return localPath, nil
+}
+
+// getCachePath returns the path of the cached file for the url. The extension of the url path is kept,
+// because kubectl and helm rely on it
+func getCachePath(source string) (string, error) {
+	parsedURL, err := url.Parse(source)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse url %s", source)
+	}
+
+	extension := path.Ext(parsedURL.Path)
+	if extension == "" {
+		extension = ".yaml"
+	}
+
+	return filepath.Abs(filepath.Join(CacheFolder, hash.String(source)[:16]+extension))
+}
+
+func fetch(source, localPath string, remoteConfig *latest.RemoteConfig) error {
+	req, err := http.NewRequest("GET", source, nil)
+	if err != nil {
+		return err
+	}
+
+	headers, err := GetHeaders(remoteConfig)
+	if err != nil {
+		return err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	client := &http.Client{Timeout: FetchTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server responded with status code %d", resp.StatusCode)
+	}
+
+	err = os.MkdirAll(filepath.Dir(localPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(localPath, body, 0644)
+}
+
+// GetHeaders returns the http headers of the remote config. If tokenVar is set, the token is read from the env var
+// and sent as bearer token in the Authorization header
+func GetHeaders(remoteConfig *latest.RemoteConfig) (map[string]string, error) {
+	headers := map[string]string{}
+	if remoteConfig == nil {
+		return headers, nil
+	}
+
+	if remoteConfig.Headers != nil {
+		for name, value := range *remoteConfig.Headers {
+			headers[name] = value
+		}
+	}
+
+	if remoteConfig.TokenVar != nil && *remoteConfig.TokenVar != "" {
+		token := os.Getenv(*remoteConfig.TokenVar)
+		if token == "" {
+			return nil, fmt.Errorf("Env var %s for the remote token is not set", *remoteConfig.TokenVar)
+		}
+
+		headers["Authorization"] = "Bearer " + token
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,92 @@
+package remote
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+)
+
+func TestGetLocalPath(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	wdBackup, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Error getting current working directory: %v", err)
+	}
+	err = os.Chdir(dir)
+	if err != nil {
+		t.Fatalf("Error changing working directory: %v", err)
+	}
+	defer os.Chdir(wdBackup)
+
+	requests := 0
+	available := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if available == false {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Team") != "backend" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte("kind: Service\n"))
+	}))
+	defer server.Close()
+
+	os.Setenv("REMOTE_TEST_TOKEN", "secret")
+	defer os.Unsetenv("REMOTE_TEST_TOKEN")
+
+	remoteConfig := &latest.RemoteConfig{
+		Headers:  &map[string]string{"X-Team": "backend"},
+		TokenVar: ptr.String("REMOTE_TEST_TOKEN"),
+	}
+
+	localPath, err := GetLocalPath("manifests/service.yaml", remoteConfig, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, localPath, "manifests/service.yaml", "Local path changed")
+
+	source := server.URL + "/base/service.yaml"
+	localPath, err = GetLocalPath(source, remoteConfig, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, filepath.Ext(localPath), ".yaml", "Extension not kept")
+
+	content, err := ioutil.ReadFile(localPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "kind: Service\n", "Wrong content")
+
+	// The url is only fetched once per run
+	_, err = GetLocalPath(source, remoteConfig, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, requests, 1, "Url fetched twice")
+
+	// The cached file is used if the server isn't available
+	delete(fetched, source)
+	available = false
+	cachedPath, err := GetLocalPath(source, remoteConfig, log.Discard)
+	assert.NilError(t, err)
+	assert.Equal(t, cachedPath, localPath, "Cached file not used")
+
+	// Without a cached file fetching errors are returned
+	_, err = GetLocalPath(server.URL+"/other.yaml", remoteConfig, log.Discard)
+	assert.ErrorContains(t, err, "status code 503")
+
+	// A missing token env var is an error
+	available = true
+	_, err = GetLocalPath(server.URL+"/token.yaml", &latest.RemoteConfig{TokenVar: ptr.String("REMOTE_TEST_MISSING")}, log.Discard)
+	assert.ErrorContains(t, err, "REMOTE_TEST_MISSING")
+}
@@ -0,0 +1,79 @@
+package deploy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Resource identifies a single kubernetes resource of a deployment
+type Resource struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+var documentSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// GetResources parses the given multi document yaml and returns the contained resources. Resources without
+// a namespace get the default namespace
+func GetResources(manifests string, defaultNamespace string) ([]*Resource, error) {
+	resources := []*Resource{}
+
+	for _, document := range documentSeparator.Split(manifests, -1) {
+		if strings.TrimSpace(document) == "" {
+			continue
+		}
+
+		object := struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+			Items []interface{} `yaml:"items"`
+		}{}
+
+		err := yaml.Unmarshal([]byte(document), &object)
+		if err != nil {
+			return nil, errors.Wrap(err, "unmarshal manifest")
+		}
+
+		// Lists contain the actual resources in items
+		if strings.HasSuffix(object.Kind, "List") && object.Items != nil {
+			for _, item := range object.Items {
+				out, err := yaml.Marshal(item)
+				if err != nil {
+					return nil, errors.Wrap(err, "marshal list item")
+				}
+
+				itemResources, err := GetResources(string(out), defaultNamespace)
+				if err != nil {
+					return nil, err
+				}
+
+				resources = append(resources, itemResources...)
+			}
+
+			continue
+		}
+		if object.Kind == "" || object.Metadata.Name == "" {
+			continue
+		}
+
+		namespace := object.Metadata.Namespace
+		if namespace == "" {
+			namespace = defaultNamespace
+		}
+
+		resources = append(resources, &Resource{
+			Kind:      object.Kind,
+			Name:      object.Metadata.Name,
+			Namespace: namespace,
+		})
+	}
+
+	return resources, nil
+}
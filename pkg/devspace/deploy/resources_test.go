@@ -0,0 +1,42 @@
+package deploy
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestGetResources(t *testing.T) {
+	manifests := `# Source: chart/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+  namespace: other
+---
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: settings
+`
+
+	resources, err := GetResources(manifests, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.DeepEqual(t, resources, []*Resource{
+		{Kind: "Service", Name: "backend", Namespace: "default"},
+		{Kind: "Deployment", Name: "backend", Namespace: "other"},
+		{Kind: "ConfigMap", Name: "settings", Namespace: "default"},
+	})
+}
@@ -0,0 +1,224 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/component"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
+	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
+	kubectlclient "github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	yaml "gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes"
+)
+
+// GetPurgeDeployments returns the deployments that are deleted by a purge in the order they are deleted (reverse
+// config order) and the names of the protected deployments that are skipped. If deployments is empty, all
+// deployments are selected
+func GetPurgeDeployments(config *latest.Config, deployments []string, forceProtected bool) ([]*latest.DeploymentConfig, []string) {
+	purgeDeployments := []*latest.DeploymentConfig{}
+	protectedDeployments := []string{}
+	if config.Deployments == nil {
+		return purgeDeployments, protectedDeployments
+	}
+
+	for i := len(*config.Deployments) - 1; i >= 0; i-- {
+		deployConfig := (*config.Deployments)[i]
+
+		// Check if we should skip deleting deployment
+		if len(deployments) > 0 {
+			found := false
+			for _, value := range deployments {
+				if value == *deployConfig.Name {
+					found = true
+					break
+				}
+			}
+
+			if found == false {
+				continue
+			}
+		}
+
+		if forceProtected == false && deployConfig.Protected != nil && *deployConfig.Protected {
+			protectedDeployments = append(protectedDeployments, *deployConfig.Name)
+			continue
+		}
+
+		purgeDeployments = append(purgeDeployments, deployConfig)
+	}
+
+	return purgeDeployments, protectedDeployments
+}
+
+// PurgePullSecrets deletes the image pull secrets devspace created in all namespaces of the kube contexts of the purged
+// deployments, unless another deployment that was not purged is still deployed to the same kube context
+func PurgePullSecrets(config *latest.Config, generatedConfig *generated.Config, client kubernetes.Interface, purgeDeployments []*latest.DeploymentConfig, unpatchServiceAccount bool, log log.Logger) {
+	purged := map[string]bool{}
+	for _, deployConfig := range purgeDeployments {
+		purged[*deployConfig.Name] = true
+	}
+
+	clients := kubectlclient.NewDeploymentClients(config, client)
+	remainingContexts := map[string]bool{}
+	if config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			if purged[*deployConfig.Name] {
+				continue
+			}
+
+			kubeContext, err := getDeploymentKubeContext(config, deployConfig)
+			if err != nil {
+				log.Warnf("Unable to determine kube context of deployment %s: %v", *deployConfig.Name, err)
+				return
+			}
+
+			remainingContexts[kubeContext] = true
+		}
+	}
+
+	purgedContexts := map[string]bool{}
+	for _, deployConfig := range purgeDeployments {
+		kubeContext, err := getDeploymentKubeContext(config, deployConfig)
+		if err != nil {
+			log.Warnf("Unable to determine kube context of deployment %s: %v", *deployConfig.Name, err)
+			continue
+		}
+		if remainingContexts[kubeContext] || purgedContexts[kubeContext] || generatedConfig.PullSecrets[kubeContext] == nil {
+			continue
+		}
+		purgedContexts[kubeContext] = true
+
+		_, deploymentClient, err := clients.Get(deployConfig)
+		if err != nil {
+			log.Warnf("Error deleting image pull secrets in kube context %s: %v", kubeContext, err)
+			continue
+		}
+
+		// Pull secrets are created in the namespace of every deployment, not only in the default namespace
+		namespaces := make([]string, 0, len(generatedConfig.PullSecrets[kubeContext]))
+		for namespace := range generatedConfig.PullSecrets[kubeContext] {
+			namespaces = append(namespaces, namespace)
+		}
+		sort.Strings(namespaces)
+
+		for _, namespace := range namespaces {
+			err = registry.DeletePullSecrets(generatedConfig, deploymentClient, kubeContext, namespace, unpatchServiceAccount, log)
+			if err != nil {
+				log.Warnf("Error deleting image pull secrets in namespace %s: %v", namespace, err)
+			}
+		}
+	}
+}
+
+func getDeploymentKubeContext(config *latest.Config, deployConfig *latest.DeploymentConfig) (string, error) {
+	if deployConfig.KubeContext != nil && *deployConfig.KubeContext != "" {
+		return *deployConfig.KubeContext, nil
+	}
+
+	return configutil.GetKubeContext(config)
+}
+
+// GetPurgeResources returns a description of the helm release or of every kubernetes resource that is deleted
+// when the deployment is purged
+func GetPurgeResources(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployConfig *latest.DeploymentConfig, log log.Logger) ([]string, error) {
+	namespace, err := configutil.GetDefaultNamespace(config)
+	if err != nil {
+		return nil, err
+	}
+	if deployConfig.Namespace != nil && *deployConfig.Namespace != "" {
+		namespace = *deployConfig.Namespace
+	}
+
+	var manifestGetter interface {
+		GetManifests(cache *generated.CacheConfig) (string, error)
+	}
+
+	deploymentType := "kubectl"
+	if deployConfig.Component != nil && deployConfig.Component.RenderOnly != nil && *deployConfig.Component.RenderOnly == true {
+		deploymentType = "component"
+		manifestGetter, err = component.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, err
+		}
+	} else if deployConfig.Helm != nil || deployConfig.Component != nil {
+		deploymentType = "helm"
+		if deployConfig.Component != nil {
+			deploymentType = "component"
+		}
+
+		return []string{fmt.Sprintf("release %s (%s deployment %s in namespace %s)", *deployConfig.Name, deploymentType, *deployConfig.Name, namespace)}, nil
+	} else if deployConfig.Kubectl != nil {
+		manifestGetter, err = kubectl.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		return nil, fmt.Errorf("Deployment %s has no deployment method", *deployConfig.Name)
+	}
+
+	manifests, err := manifestGetter.GetManifests(cache)
+	if err != nil {
+		return nil, err
+	}
+
+	resources := []string{}
+	for _, manifest := range strings.Split(manifests, "\n---\n") {
+		resource := struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}{}
+
+		err = yaml.Unmarshal([]byte(manifest), &resource)
+		if err != nil || resource.Kind == "" {
+			continue
+		}
+
+		resourceNamespace := namespace
+		if resource.Metadata.Namespace != "" {
+			resourceNamespace = resource.Metadata.Namespace
+		}
+
+		resources = append(resources, fmt.Sprintf("%s/%s (%s deployment %s in namespace %s)", resource.Kind, resource.Metadata.Name, deploymentType, *deployConfig.Name, resourceNamespace))
+	}
+
+	return resources, nil
+}
+
+// newDeployClient creates the deploy client for the deployment method of the deployment
+func newDeployClient(config *latest.Config, client kubernetes.Interface, deployConfig *latest.DeploymentConfig, log log.Logger) (deploy.Interface, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to create kubectl deploy config: %v", err)
+		}
+
+		return deployClient, nil
+	} else if deployConfig.Helm != nil {
+		deployClient, err := helm.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to create helm deploy config: %v", err)
+		}
+
+		return deployClient, nil
+	} else if deployConfig.Component != nil {
+		deployClient, err := component.New(config, client, deployConfig, log)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to create component deploy config: %v", err)
+		}
+
+		return deployClient, nil
+	}
+
+	return nil, fmt.Errorf("Deployment %s has no deployment method, skipping", *deployConfig.Name)
+}
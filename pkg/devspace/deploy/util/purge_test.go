@@ -0,0 +1,130 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetPurgeDeployments(t *testing.T) {
+	testConfig := &latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name:      ptr.String("database"),
+				Protected: ptr.Bool(true),
+			},
+			&latest.DeploymentConfig{
+				Name: ptr.String("backend"),
+			},
+			&latest.DeploymentConfig{
+				Name: ptr.String("frontend"),
+			},
+		},
+	}
+
+	purgeDeployments, protectedDeployments := GetPurgeDeployments(testConfig, nil, false)
+	if len(purgeDeployments) != 2 || *purgeDeployments[0].Name != "frontend" || *purgeDeployments[1].Name != "backend" {
+		t.Fatalf("Unexpected deployments to purge: %v", purgeDeployments)
+	}
+	if len(protectedDeployments) != 1 || protectedDeployments[0] != "database" {
+		t.Fatalf("Expected database to be protected, got %v", protectedDeployments)
+	}
+
+	purgeDeployments, protectedDeployments = GetPurgeDeployments(testConfig, []string{"database", "backend"}, true)
+	if len(purgeDeployments) != 2 || *purgeDeployments[0].Name != "backend" || *purgeDeployments[1].Name != "database" {
+		t.Fatalf("Unexpected deployments to purge with force protected: %v", purgeDeployments)
+	}
+	if len(protectedDeployments) != 0 {
+		t.Fatalf("Expected no skipped deployments with force protected, got %v", protectedDeployments)
+	}
+}
+
+func TestGetPurgeResources(t *testing.T) {
+	testConfig := &latest.Config{
+		Cluster: &latest.Cluster{
+			Namespace: ptr.String("my-namespace"),
+		},
+	}
+
+	resources, err := GetPurgeResources(testConfig, generated.NewCache(), fake.NewSimpleClientset(), &latest.DeploymentConfig{
+		Name: ptr.String("database"),
+		Helm: &latest.HelmConfig{},
+	}, &log.DiscardLogger{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resources) != 1 || resources[0] != "release database (helm deployment database in namespace my-namespace)" {
+		t.Fatalf("Unexpected resources: %v", resources)
+	}
+
+	_, err = GetPurgeResources(testConfig, generated.NewCache(), fake.NewSimpleClientset(), &latest.DeploymentConfig{
+		Name: ptr.String("no-method"),
+	}, &log.DiscardLogger{})
+	if err == nil {
+		t.Fatalf("Expected error for deployment without deployment method")
+	}
+}
+
+func TestPurgePullSecrets(t *testing.T) {
+	testConfig := &latest.Config{
+		Cluster: &latest.Cluster{
+			KubeContext: ptr.String("test-context"),
+			Namespace:   ptr.String("default-namespace"),
+		},
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name:      ptr.String("backend"),
+				Namespace: ptr.String("backend-namespace"),
+			},
+		},
+	}
+
+	// The pull secret of the deployment is in its own namespace
+	client := fake.NewSimpleClientset()
+	for _, namespace := range []string{"default-namespace", "backend-namespace"} {
+		_, err := client.CoreV1().Secrets(namespace).Create(&k8sv1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "devspace-auth-registry"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := client.CoreV1().ServiceAccounts("default-namespace").Create(&k8sv1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: "default"},
+		ImagePullSecrets: []k8sv1.LocalObjectReference{{Name: "own-secret"}, {Name: "devspace-auth-registry"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generatedConfig := &generated.Config{}
+	generatedConfig.AddPullSecret("test-context", "default-namespace", "devspace-auth-registry")
+	generatedConfig.AddServiceAccountPullSecret("test-context", "default-namespace", "devspace-auth-registry")
+	generatedConfig.AddPullSecret("test-context", "backend-namespace", "devspace-auth-registry")
+
+	PurgePullSecrets(testConfig, generatedConfig, client, *testConfig.Deployments, true, &log.DiscardLogger{})
+
+	for _, namespace := range []string{"default-namespace", "backend-namespace"} {
+		_, err = client.CoreV1().Secrets(namespace).Get("devspace-auth-registry", metav1.GetOptions{})
+		if err == nil {
+			t.Fatalf("Pull secret in namespace %s was not deleted", namespace)
+		}
+	}
+
+	serviceAccount, err := client.CoreV1().ServiceAccounts("default-namespace").Get("default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(serviceAccount.ImagePullSecrets) != 1 || serviceAccount.ImagePullSecrets[0].Name != "own-secret" {
+		t.Fatalf("Unexpected image pull secrets of the service account: %v", serviceAccount.ImagePullSecrets)
+	}
+	if len(generatedConfig.PullSecrets) != 0 {
+		t.Fatalf("Expected no recorded pull secrets, got %v", generatedConfig.PullSecrets)
+	}
+}
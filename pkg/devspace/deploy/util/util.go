@@ -2,8 +2,11 @@ package deploy
 
 import (
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy"
@@ -11,10 +14,19 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/helm"
 	"github.com/devspace-cloud/devspace/pkg/devspace/deploy/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/hook"
+	kubectlclient "github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
 	"k8s.io/client-go/kubernetes"
+	hapi_release5 "k8s.io/helm/pkg/proto/hapi/release"
 )
 
+// releaseGetter is implemented by the deployment methods that deploy a helm release
+type releaseGetter interface {
+	GetRelease() (*hapi_release5.Release, error)
+}
+
 // All deploys all deployments in the config
 func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, isDev, forceDeploy bool, builtImages map[string]string, deployments []string, log log.Logger) error {
 	if config.Deployments != nil && len(*config.Deployments) > 0 {
@@ -24,6 +36,29 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 			return err
 		}
 
+		defaultNamespace, err := configutil.GetDefaultNamespace(config)
+		if err != nil {
+			return err
+		}
+
+		summary := &hook.Summary{
+			Images:      getImageSummaries(cache, builtImages),
+			Deployments: []*hook.DeploymentSummary{},
+		}
+
+		clients := kubectlclient.NewDeploymentClients(config, client)
+
+		// Deploy locks of the namespaces that are deployed to, by kube context and namespace
+		deployLocks := map[string]*kubectlclient.DeployLock{}
+		defer func() {
+			for key, deployLock := range deployLocks {
+				err := deployLock.Release()
+				if err != nil {
+					log.Warnf("Error releasing deploy lock %s: %v", key, err)
+				}
+			}
+		}()
+
 		for _, deployConfig := range *config.Deployments {
 			if len(deployments) > 0 {
 				shouldSkip := true
@@ -46,22 +81,34 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 				method       string
 			)
 
+			// Deployments with an own kube context are deployed with a different config and client
+			deploymentConfig, deploymentClient, err := clients.Get(deployConfig)
+			if err != nil {
+				return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
+			}
+
+			// Wait until no other process deploys into the namespace
+			err = acquireDeployLock(deploymentConfig, deploymentClient, deployConfig, defaultNamespace, deployLocks, log)
+			if err != nil {
+				return fmt.Errorf("Error deploying %s: %v", *deployConfig.Name, err)
+			}
+
 			if deployConfig.Kubectl != nil {
-				deployClient, err = kubectl.New(config, client, deployConfig, log)
+				deployClient, err = kubectl.New(deploymentConfig, deploymentClient, deployConfig, log)
 				if err != nil {
 					return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
 				}
 
 				method = "kubectl"
 			} else if deployConfig.Helm != nil {
-				deployClient, err = helm.New(config, client, deployConfig, log)
+				deployClient, err = helm.New(deploymentConfig, deploymentClient, deployConfig, log)
 				if err != nil {
 					return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
 				}
 
 				method = "helm"
 			} else if deployConfig.Component != nil {
-				deployClient, err = component.New(config, client, deployConfig, log)
+				deployClient, err = component.New(deploymentConfig, deploymentClient, deployConfig, log)
 				if err != nil {
 					return fmt.Errorf("Error deploying devspace: deployment %s error: %v", *deployConfig.Name, err)
 				}
@@ -82,11 +129,36 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 				return fmt.Errorf("Error deploying %s: %v", *deployConfig.Name, err)
 			}
 
+			if deployConfig.Wait != nil && *deployConfig.Wait {
+				err = waitForReady(deployClient, deployConfig, cache, deploymentClient, log)
+				if err != nil {
+					return fmt.Errorf("Error deploying %s: %v", *deployConfig.Name, err)
+				}
+			}
+
 			if wasDeployed {
 				log.Donef("Successfully deployed %s with %s", *deployConfig.Name, method)
+				cache.GetDeploymentCache(*deployConfig.Name).LastDeployed = time.Now().Unix()
+
+				deploymentSummary := &hook.DeploymentSummary{
+					Name:      *deployConfig.Name,
+					Type:      method,
+					Namespace: defaultNamespace,
+				}
+				if deployConfig.Namespace != nil && *deployConfig.Namespace != "" {
+					deploymentSummary.Namespace = *deployConfig.Namespace
+				}
+				if releaseClient, ok := deployClient.(releaseGetter); ok {
+					release, err := releaseClient.GetRelease()
+					if err == nil && release != nil {
+						deploymentSummary.Revision = release.Version
+					}
+				}
+
+				summary.Deployments = append(summary.Deployments, deploymentSummary)
 
 				// Execute after deploment deploy hook
-				err = hook.Execute(config, hook.After, hook.StageDeployments, *deployConfig.Name, log)
+				err = hook.ExecuteWithSummary(config, hook.After, hook.StageDeployments, *deployConfig.Name, summary, log)
 				if err != nil {
 					return err
 				}
@@ -96,7 +168,7 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 		}
 
 		// Execute after deployments deploy hook
-		err = hook.Execute(config, hook.After, hook.StageDeployments, hook.All, log)
+		err = hook.ExecuteWithSummary(config, hook.After, hook.StageDeployments, hook.All, summary, log)
 		if err != nil {
 			return err
 		}
@@ -105,66 +177,130 @@ func All(config *latest.Config, cache *generated.CacheConfig, client kubernetes.
 	return nil
 }
 
-// PurgeDeployments removes all deployments or a set of deployments from the cluster
-func PurgeDeployments(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployments []string, log log.Logger) {
-	if deployments != nil && len(deployments) == 0 {
-		deployments = nil
+// acquireDeployLock acquires the deploy lock of the kube context and namespace of the deployment, if it is not held
+// by this process already
+func acquireDeployLock(deploymentConfig *latest.Config, deploymentClient kubernetes.Interface, deployConfig *latest.DeploymentConfig, defaultNamespace string, deployLocks map[string]*kubectlclient.DeployLock, log log.Logger) error {
+	deployLockSettings := settings.GetDeployLock()
+	if deployLockSettings.Disabled {
+		return nil
 	}
 
-	if config.Deployments != nil {
-		// Reverse them
-		for i := len(*config.Deployments) - 1; i >= 0; i-- {
-			var (
-				err          error
-				deployClient deploy.Interface
-				deployConfig = (*config.Deployments)[i]
-			)
+	namespace := defaultNamespace
+	if deployConfig.Namespace != nil && *deployConfig.Namespace != "" {
+		namespace = *deployConfig.Namespace
+	}
 
-			// Check if we should skip deleting deployment
-			if deployments != nil {
-				found := false
+	kubeContext := ""
+	if deploymentConfig.Cluster != nil && deploymentConfig.Cluster.KubeContext != nil {
+		kubeContext = *deploymentConfig.Cluster.KubeContext
+	}
 
-				for _, value := range deployments {
-					if value == *deployConfig.Name {
-						found = true
-						break
-					}
-				}
+	key := kubeContext + "/" + namespace
+	if _, ok := deployLocks[key]; ok {
+		return nil
+	}
 
-				if found == false {
-					continue
-				}
-			}
+	deployLock, err := kubectlclient.AcquireDeployLock(deploymentClient, namespace, deployLockSettings.GetTimeout(), log)
+	if err != nil {
+		return err
+	}
 
-			// Delete kubectl engine
-			if deployConfig.Kubectl != nil {
-				deployClient, err = kubectl.New(config, client, deployConfig, log)
-				if err != nil {
-					log.Warnf("Unable to create kubectl deploy config: %v", err)
-					continue
-				}
-			} else if deployConfig.Helm != nil {
-				deployClient, err = helm.New(config, client, deployConfig, log)
-				if err != nil {
-					log.Warnf("Unable to create helm deploy config: %v", err)
-					continue
-				}
-			} else if deployConfig.Component != nil {
-				deployClient, err = component.New(config, client, deployConfig, log)
-				if err != nil {
-					log.Warnf("Unable to create component deploy config: %v", err)
-					continue
-				}
-			}
+	deployLocks[key] = deployLock
+	return nil
+}
 
-			log.StartWait("Deleting deployment " + *deployConfig.Name)
-			err = deployClient.Delete(cache)
-			log.StopWait()
-			if err != nil {
-				log.Warnf("Error deleting deployment %s: %v", *deployConfig.Name, err)
-			}
+// waitForReady waits until the deployed resources of the deployment are ready
+func waitForReady(deployClient deploy.Interface, deployConfig *latest.DeploymentConfig, cache *generated.CacheConfig, client kubernetes.Interface, log log.Logger) error {
+	resourceGetter, ok := deployClient.(deploy.ResourceGetter)
+	if ok == false {
+		return nil
+	}
+
+	resources, err := resourceGetter.GetResources(cache)
+	if err != nil {
+		return errors.Wrap(err, "get deployed resources")
+	}
+
+	timeout := deploy.DefaultWaitTimeout
+	if deployConfig.WaitTimeout != nil && *deployConfig.WaitTimeout > 0 {
+		timeout = time.Duration(*deployConfig.WaitTimeout) * time.Second
+	}
+
+	return deploy.WaitForReady(client, resources, timeout, log)
+}
+
+// getImageSummaries returns the summaries of all images known to the cache
+func getImageSummaries(cache *generated.CacheConfig, builtImages map[string]string) []*hook.ImageSummary {
+	imageConfigNames := []string{}
+	for imageConfigName, imageCache := range cache.Images {
+		if imageCache.ImageName != "" && imageCache.Tag != "" {
+			imageConfigNames = append(imageConfigNames, imageConfigName)
+		}
+	}
+	sort.Strings(imageConfigNames)
+
+	images := []*hook.ImageSummary{}
+	for _, imageConfigName := range imageConfigNames {
+		imageCache := cache.Images[imageConfigName]
+		_, built := builtImages[imageCache.ImageName]
+
+		images = append(images, &hook.ImageSummary{
+			Name:  imageConfigName,
+			Image: imageCache.GetResolvedImageName(),
+			Tag:   imageCache.Tag,
+			Built: built,
+		})
+	}
+
+	return images
+}
+
+// PurgeDeployments removes all deployments or a set of deployments from the cluster. Protected deployments are skipped
+func PurgeDeployments(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployments []string, log log.Logger) error {
+	return PurgeDeploymentsWithProtected(config, cache, client, deployments, false, log)
+}
 
-			log.Donef("Successfully deleted deployment %s", *deployConfig.Name)
+// PurgeDeploymentsWithProtected removes all deployments or a set of deployments from the cluster. If forceProtected
+// is false, protected deployments are skipped. Deployments that fail to purge are skipped as well and returned
+// in the error
+func PurgeDeploymentsWithProtected(config *latest.Config, cache *generated.CacheConfig, client kubernetes.Interface, deployments []string, forceProtected bool, log log.Logger) error {
+	purgeDeployments, protectedDeployments := GetPurgeDeployments(config, deployments, forceProtected)
+	for _, name := range protectedDeployments {
+		log.Warnf("Skip deleting protected deployment %s (use --force-protected to delete it)", name)
+	}
+
+	failedDeployments := []string{}
+	clients := kubectlclient.NewDeploymentClients(config, client)
+	for _, deployConfig := range purgeDeployments {
+		deploymentConfig, deploymentClient, err := clients.Get(deployConfig)
+		if err != nil {
+			log.Warnf("Error deleting deployment %s: %v", *deployConfig.Name, err)
+			failedDeployments = append(failedDeployments, *deployConfig.Name)
+			continue
 		}
+
+		deployClient, err := newDeployClient(deploymentConfig, deploymentClient, deployConfig, log)
+		if err != nil {
+			log.Warn(err)
+			failedDeployments = append(failedDeployments, *deployConfig.Name)
+			continue
+		}
+
+		log.StartWait("Deleting deployment " + *deployConfig.Name)
+		err = deployClient.Delete(cache)
+		log.StopWait()
+		if err != nil {
+			log.Warnf("Error deleting deployment %s: %v", *deployConfig.Name, err)
+			failedDeployments = append(failedDeployments, *deployConfig.Name)
+			continue
+		}
+
+		log.Donef("Successfully deleted deployment %s", *deployConfig.Name)
+	}
+
+	if len(failedDeployments) > 0 {
+		return fmt.Errorf("Error deleting deployments %s", strings.Join(failedDeployments, ", "))
 	}
+
+	return nil
 }
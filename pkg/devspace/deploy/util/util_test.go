@@ -502,3 +502,19 @@ spec:
 
 	return nil
 }
+
+func TestPurgeDeploymentsWithoutMethod(t *testing.T) {
+	testConfig := &latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			&latest.DeploymentConfig{
+				Name: ptr.String("no-method"),
+			},
+		},
+	}
+
+	// Should not panic for deployments without a deployment method, but report them as failed
+	err := PurgeDeployments(testConfig, generated.NewCache(), fake.NewSimpleClientset(), nil, &log.DiscardLogger{})
+	if err == nil || err.Error() != "Error deleting deployments no-method" {
+		t.Fatalf("Expected error for deployment no-method, got %v", err)
+	}
+}
@@ -0,0 +1,266 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultWaitTimeout is the time to wait for the resources of a deployment to become ready if no timeout is configured
+const DefaultWaitTimeout = 180 * time.Second
+
+// maxPrintedEvents is the maximum number of events that are printed per pod if a resource does not become ready
+const maxPrintedEvents = 10
+
+// waitInterval is the time between two readiness checks
+var waitInterval = 2 * time.Second
+
+// ResourceGetter is implemented by the deployment methods that can return the kubernetes resources they deployed
+type ResourceGetter interface {
+	GetResources(cache *generated.CacheConfig) ([]*Resource, error)
+}
+
+// WaitForReady waits until all Deployments, StatefulSets and Jobs of the given resources are ready. If a resource
+// is not ready within the timeout or a job fails, the events of its pods are printed and an error is returned
+func WaitForReady(client kubernetes.Interface, resources []*Resource, timeout time.Duration, log log.Logger) error {
+	pending := []*Resource{}
+	for _, resource := range resources {
+		switch resource.Kind {
+		case "Deployment", "StatefulSet", "Job":
+			pending = append(pending, resource)
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+
+	log.StartWait(fmt.Sprintf("Waiting for %d resource(s) to become ready", len(pending)))
+	defer log.StopWait()
+
+	deadline := time.Now().Add(timeout)
+	for {
+		notReady := []*Resource{}
+		for _, resource := range pending {
+			ready, err := isReady(client, resource)
+			if err != nil {
+				log.StopWait()
+				printPodEvents(client, resource, log)
+				return err
+			}
+
+			if ready == false {
+				notReady = append(notReady, resource)
+			}
+		}
+
+		pending = notReady
+		if len(pending) == 0 {
+			return nil
+		}
+
+		if time.Now().After(deadline) {
+			log.StopWait()
+
+			names := []string{}
+			for _, resource := range pending {
+				names = append(names, resource.Kind+"/"+resource.Name)
+				printPodEvents(client, resource, log)
+			}
+
+			return fmt.Errorf("Timeout after %s waiting for %s to become ready", timeout.String(), strings.Join(names, ", "))
+		}
+
+		err := interrupt.Sleep(waitInterval)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// GetReadyCount returns how many of the Deployments, StatefulSets and Jobs of the given resources are ready and how
+// many there are in total. Resources that don't exist or failed count as not ready
+func GetReadyCount(client kubernetes.Interface, resources []*Resource) (int, int) {
+	ready, total := 0, 0
+	for _, resource := range resources {
+		switch resource.Kind {
+		case "Deployment", "StatefulSet", "Job":
+			total++
+
+			isResourceReady, err := isReady(client, resource)
+			if err == nil && isResourceReady {
+				ready++
+			}
+		}
+	}
+
+	return ready, total
+}
+
+// isReady checks if the given resource is ready. An error is returned if the resource cannot become ready anymore
+func isReady(client kubernetes.Interface, resource *Resource) (bool, error) {
+	var err error
+
+	switch resource.Kind {
+	case "Deployment":
+		var deployment *appsv1.Deployment
+		deployment, err = kubectl.GetDeployment(client, resource.Namespace, resource.Name)
+		if err == nil {
+			return isDeploymentReady(deployment)
+		}
+	case "StatefulSet":
+		var statefulSet *appsv1.StatefulSet
+		statefulSet, err = client.AppsV1().StatefulSets(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err == nil {
+			return isStatefulSetReady(statefulSet), nil
+		}
+	case "Job":
+		var job *batchv1.Job
+		job, err = client.BatchV1().Jobs(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err == nil {
+			return isJobReady(job)
+		}
+	default:
+		return true, nil
+	}
+
+	// The resource might not be created yet
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+
+	return false, fmt.Errorf("Error retrieving %s %s: %v", resource.Kind, resource.Name, err)
+}
+
+func isDeploymentReady(deployment *appsv1.Deployment) (bool, error) {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Status == k8sv1.ConditionFalse && condition.Reason == "ProgressDeadlineExceeded" {
+			return false, fmt.Errorf("Deployment %s failed: %s", deployment.Name, condition.Message)
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas >= replicas &&
+		deployment.Status.Replicas == deployment.Status.UpdatedReplicas &&
+		deployment.Status.ReadyReplicas >= replicas, nil
+}
+
+func isStatefulSetReady(statefulSet *appsv1.StatefulSet) bool {
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation || statefulSet.Status.ReadyReplicas < replicas {
+		return false
+	}
+
+	// With the OnDelete strategy pods are not updated automatically
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.OnDeleteStatefulSetStrategyType {
+		return statefulSet.Status.UpdatedReplicas >= replicas
+	}
+
+	return true
+}
+
+func isJobReady(job *batchv1.Job) (bool, error) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == k8sv1.ConditionTrue {
+			return false, fmt.Errorf("Job %s failed: %s", job.Name, condition.Message)
+		}
+	}
+
+	completions := int32(1)
+	if job.Spec.Completions != nil {
+		completions = *job.Spec.Completions
+	}
+
+	return job.Status.Succeeded >= completions, nil
+}
+
+// printPodEvents prints the status and the latest events of the pods of the resource
+func printPodEvents(client kubernetes.Interface, resource *Resource, log log.Logger) {
+	var selector *metav1.LabelSelector
+
+	switch resource.Kind {
+	case "Deployment":
+		deployment, err := kubectl.GetDeployment(client, resource.Namespace, resource.Name)
+		if err == nil {
+			selector = deployment.Spec.Selector
+		}
+	case "StatefulSet":
+		statefulSet, err := client.AppsV1().StatefulSets(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = statefulSet.Spec.Selector
+		}
+	case "Job":
+		job, err := client.BatchV1().Jobs(resource.Namespace).Get(resource.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = job.Spec.Selector
+		}
+	}
+	if selector == nil {
+		log.Warnf("%s %s does not exist", resource.Kind, resource.Name)
+		return
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return
+	}
+
+	pods, err := client.CoreV1().Pods(resource.Namespace).List(metav1.ListOptions{LabelSelector: labelSelector.String()})
+	if err != nil {
+		log.Warnf("Error listing pods of %s %s: %v", resource.Kind, resource.Name, err)
+		return
+	}
+	if len(pods.Items) == 0 {
+		log.Warnf("%s %s has no pods", resource.Kind, resource.Name)
+		return
+	}
+
+	for _, pod := range pods.Items {
+		log.Warnf("Pod %s/%s: %s", pod.Namespace, pod.Name, kubectl.GetPodStatus(&pod))
+
+		events, err := client.CoreV1().Events(pod.Namespace).List(metav1.ListOptions{
+			FieldSelector: "involvedObject.kind=Pod,involvedObject.name=" + pod.Name,
+		})
+		if err != nil {
+			continue
+		}
+
+		podEvents := []k8sv1.Event{}
+		for _, event := range events.Items {
+			if event.InvolvedObject.Name == pod.Name {
+				podEvents = append(podEvents, event)
+			}
+		}
+
+		sort.Slice(podEvents, func(i, j int) bool {
+			return podEvents[i].LastTimestamp.Before(&podEvents[j].LastTimestamp)
+		})
+		if len(podEvents) > maxPrintedEvents {
+			podEvents = podEvents[len(podEvents)-maxPrintedEvents:]
+		}
+
+		for _, event := range podEvents {
+			log.Warnf("  %s %s: %s", event.Type, event.Reason, strings.TrimSpace(event.Message))
+		}
+	}
+}
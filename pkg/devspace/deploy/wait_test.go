@@ -0,0 +1,110 @@
+package deploy
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+func TestWaitForReady(t *testing.T) {
+	waitInterval = time.Millisecond
+	replicas := int32(2)
+
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test", Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, ReadyReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "rolling", Namespace: "test", Generation: 2},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "rolling"}},
+			},
+			Status: appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, ReadyReplicas: 2},
+		},
+		&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "rolling-1", Namespace: "test", Labels: map[string]string{"app": "rolling"}},
+			Status:     k8sv1.PodStatus{Phase: k8sv1.PodPending},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+			Status:     appsv1.StatefulSetStatus{ReadyReplicas: 2, UpdatedReplicas: 2},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "test"},
+			Status:     batchv1.JobStatus{Succeeded: 1},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "test"},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: batchv1.JobFailed, Status: k8sv1.ConditionTrue, Message: "Job has reached the specified backoff limit"},
+			}},
+		},
+	)
+
+	// Ready resources and resources that are not waited for
+	err := WaitForReady(client, []*Resource{
+		{Kind: "Deployment", Name: "ready", Namespace: "test"},
+		{Kind: "StatefulSet", Name: "db", Namespace: "test"},
+		{Kind: "Job", Name: "migrate", Namespace: "test"},
+		{Kind: "Service", Name: "missing", Namespace: "test"},
+	}, time.Second, log.Discard)
+	assert.NilError(t, err)
+
+	// Deployment that is still rolling out
+	err = WaitForReady(client, []*Resource{
+		{Kind: "Deployment", Name: "ready", Namespace: "test"},
+		{Kind: "Deployment", Name: "rolling", Namespace: "test"},
+	}, 10*time.Millisecond, log.Discard)
+	if err == nil || strings.Contains(err.Error(), "Deployment/rolling") == false || strings.Contains(err.Error(), "Deployment/ready") {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// Missing resources are waited for until the timeout
+	err = WaitForReady(client, []*Resource{{Kind: "StatefulSet", Name: "missing", Namespace: "test"}}, 10*time.Millisecond, log.Discard)
+	if err == nil || strings.Contains(err.Error(), "StatefulSet/missing") == false {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	// Failed jobs return immediately
+	err = WaitForReady(client, []*Resource{{Kind: "Job", Name: "broken", Namespace: "test"}}, time.Hour, log.Discard)
+	if err == nil || strings.Contains(err.Error(), "backoff limit") == false {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestGetReadyCount(t *testing.T) {
+	replicas := int32(1)
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test", Generation: 1},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 1, UpdatedReplicas: 1, ReadyReplicas: 1},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "test"},
+			Spec:       appsv1.StatefulSetSpec{Replicas: &replicas},
+		},
+	)
+
+	ready, total := GetReadyCount(client, []*Resource{
+		{Kind: "Deployment", Name: "ready", Namespace: "test"},
+		{Kind: "StatefulSet", Name: "db", Namespace: "test"},
+		{Kind: "Job", Name: "missing", Namespace: "test"},
+		{Kind: "Service", Name: "ready", Namespace: "test"},
+	})
+	assert.Equal(t, ready, 1, "Wrong number of ready resources")
+	assert.Equal(t, total, 3, "Wrong number of resources")
+}
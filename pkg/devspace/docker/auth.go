@@ -102,7 +102,9 @@ func getOfficialServer(ctx context.Context, client client.CommonAPIClient) strin
 	// example a Linux client might be interacting with a Windows daemon, hence
 	// the default registry URL might be Windows specific.
 	serverAddress := registry.IndexServer
-	if info, err := client.Info(ctx); err != nil {
+	if client == nil {
+		// Without a docker client we use the system default
+	} else if info, err := client.Info(ctx); err != nil {
 		// Only report the warning if we're in debug mode to prevent nagging during engine initialization workflows
 		// log.Warnf("Warning: failed to get default registry endpoint from daemon (%v). Using system default: %s", err, serverAddress)
 	} else if info.IndexServerAddress == "" {
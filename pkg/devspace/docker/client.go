@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -104,6 +106,27 @@ func newDockerClientFromMinikube(config *latest.Config) (client.CommonAPIClient,
 	return client.NewClient(host, version, httpclient, nil)
 }
 
+// GetCLIEnvironment returns the environment variables the docker cli needs to connect to the same docker daemon as
+// the client, e.g. the docker daemon of minikube
+func GetCLIEnvironment(cli client.CommonAPIClient) []string {
+	env := []string{}
+	if cli == nil || cli.DaemonHost() == os.Getenv("DOCKER_HOST") || (os.Getenv("DOCKER_HOST") == "" && cli.DaemonHost() == client.DefaultDockerHost) {
+		return env
+	}
+
+	minikubeEnv, err := getMinikubeEnvironment()
+	if err == nil && minikubeEnv["DOCKER_HOST"] == cli.DaemonHost() {
+		for key, value := range minikubeEnv {
+			env = append(env, key+"="+value)
+		}
+
+		sort.Strings(env)
+		return env
+	}
+
+	return append(env, "DOCKER_HOST="+cli.DaemonHost())
+}
+
 func getMinikubeEnvironment() (map[string]string, error) {
 	cmd := exec.Command("minikube", "docker-env", "--shell", "none")
 	out, err := cmd.Output()
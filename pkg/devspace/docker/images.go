@@ -42,3 +42,32 @@ func DeleteImageByFilter(client dockerclient.CommonAPIClient, filter filters.Arg
 
 	return responseItems, nil
 }
+
+// GetImageTags returns the tags of all local images with the given image name
+func GetImageTags(client dockerclient.CommonAPIClient, imageName string) ([]string, error) {
+	imageName = strings.TrimSpace(imageName)
+	summary, err := client.ImageList(context.Background(), types.ImageListOptions{
+		Filters: filters.NewArgs(filters.Arg("reference", imageName)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+	for _, image := range summary {
+		for _, repoTag := range image.RepoTags {
+			if strings.HasPrefix(repoTag, imageName+":") {
+				tags = append(tags, repoTag[len(imageName)+1:])
+			}
+		}
+	}
+
+	return tags, nil
+}
+
+// RemoveImageTag removes a single tag of an image. The image itself is only deleted if it has no other tags
+func RemoveImageTag(client dockerclient.CommonAPIClient, imageName, tag string) ([]types.ImageDeleteResponseItem, error) {
+	return client.ImageRemove(context.Background(), strings.TrimSpace(imageName)+":"+tag, types.ImageRemoveOptions{
+		PruneChildren: true,
+	})
+}
@@ -23,6 +23,25 @@ const DockerfileRepoURL = "https://github.com/devspace-cloud/devspace-templates.
 // DockerfileRepoPath is the path relative to the user folder where the docker file repo is stored
 const DockerfileRepoPath = ".devspace/dockerfileRepo"
 
+// projectFiles maps files that identify the type of a project to the languages the project is most likely written in
+var projectFiles = []struct {
+	pattern   string
+	languages []string
+}{
+	{pattern: "tsconfig.json", languages: []string{"typescript", "javascript"}},
+	{pattern: "package.json", languages: []string{"javascript"}},
+	{pattern: "go.mod", languages: []string{"go"}},
+	{pattern: "Gopkg.toml", languages: []string{"go"}},
+	{pattern: "pom.xml", languages: []string{"java"}},
+	{pattern: "build.gradle", languages: []string{"java"}},
+	{pattern: "requirements.txt", languages: []string{"python"}},
+	{pattern: "Pipfile", languages: []string{"python"}},
+	{pattern: "setup.py", languages: []string{"python"}},
+	{pattern: "Gemfile", languages: []string{"ruby"}},
+	{pattern: "composer.json", languages: []string{"php"}},
+	{pattern: "*.csproj", languages: []string{"c#"}},
+}
+
 // DockerfileGenerator is a type of object that generates a Helm Chart
 type DockerfileGenerator struct {
 	Language  string
@@ -174,6 +193,14 @@ func (cg *DockerfileGenerator) CreateDockerfile(language string) error {
 }
 
 func (cg *DockerfileGenerator) detectLanguage() error {
+	// Project files like package.json are a much better indicator than the file contents
+	for _, language := range getLanguagesFromProjectFiles(cg.LocalPath) {
+		if cg.IsSupportedLanguage(language) {
+			cg.Language = language
+			return nil
+		}
+	}
+
 	contentReadLimit := int64(16 * 1024 * 1024)
 	bytesByLanguage := make(map[string]int64, 0)
 
@@ -266,3 +293,24 @@ func (cg *DockerfileGenerator) detectLanguage() error {
 
 	return nil
 }
+
+// getLanguagesFromProjectFiles returns the languages indicated by the project files (e.g. package.json) found in the given path
+func getLanguagesFromProjectFiles(localPath string) []string {
+	languages := []string{}
+	found := map[string]bool{}
+	for _, projectFile := range projectFiles {
+		matches, err := filepath.Glob(filepath.Join(localPath, projectFile.pattern))
+		if err != nil || len(matches) == 0 {
+			continue
+		}
+
+		for _, language := range projectFile.languages {
+			if found[language] == false {
+				found[language] = true
+				languages = append(languages, language)
+			}
+		}
+	}
+
+	return languages
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
@@ -254,3 +255,31 @@ app.listen(3000, function () {
 	assert.Equal(t, "javascript", detectedLanguage, "Wrong language detected")
 
 }
+
+func TestGetLanguagesFromProjectFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	assert.Equal(t, 0, len(getLanguagesFromProjectFiles(dir)), "Languages detected in empty directory")
+
+	err = fsutil.WriteToFile([]byte("{}"), filepath.Join(dir, "package.json"))
+	if err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+	assert.DeepEqual(t, []string{"javascript"}, getLanguagesFromProjectFiles(dir))
+
+	err = fsutil.WriteToFile([]byte("{}"), filepath.Join(dir, "tsconfig.json"))
+	if err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+	assert.DeepEqual(t, []string{"typescript", "javascript"}, getLanguagesFromProjectFiles(dir))
+
+	err = fsutil.WriteToFile([]byte(""), filepath.Join(dir, "app.csproj"))
+	if err != nil {
+		t.Fatalf("Error writing file: %v", err)
+	}
+	assert.DeepEqual(t, []string{"typescript", "javascript", "c#"}, getLanguagesFromProjectFiles(dir))
+}
@@ -0,0 +1,215 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// The phases of a devspace dev session
+const (
+	PhaseStarting  = "starting"
+	PhaseBuilding  = "building"
+	PhaseDeploying = "deploying"
+	PhaseServices  = "starting-services"
+	PhaseRunning   = "running"
+	PhaseReloading = "reloading"
+	PhaseFailed    = "failed"
+)
+
+// The states of a service
+const (
+	StateRunning      = "running"
+	StateReconnecting = "reconnecting"
+	StateFailed       = "failed"
+)
+
+// Path is the http path of the health endpoint
+const Path = "/healthz"
+
+// Service is the state of a single service such as a port forwarding
+type Service struct {
+	State string `json:"state"`
+	Error string `json:"error,omitempty"`
+}
+
+// Status is the state of the session that is returned by the health endpoint
+type Status struct {
+	Healthy       bool                `json:"healthy"`
+	Reason        string              `json:"reason,omitempty"`
+	Phase         string              `json:"phase"`
+	PhaseSince    time.Time           `json:"phaseSince"`
+	Services      map[string]*Service `json:"services"`
+	LastError     string              `json:"lastError,omitempty"`
+	LastErrorTime *time.Time          `json:"lastErrorTime,omitempty"`
+}
+
+// Reporter tracks the state of a session and serves it via http. All methods of a nil reporter are no-ops,
+// so the reporter can be passed around even if the health endpoint is disabled
+type Reporter struct {
+	// PhaseTimeout is the maximum time the session may stay in a phase other than running before it is
+	// reported as unhealthy. Zero disables the check
+	PhaseTimeout time.Duration
+
+	mutex  sync.Mutex
+	status Status
+	now    func() time.Time
+}
+
+// NewReporter creates a new reporter in the starting phase
+func NewReporter(phaseTimeout time.Duration) *Reporter {
+	return &Reporter{
+		PhaseTimeout: phaseTimeout,
+		status: Status{
+			Phase:      PhaseStarting,
+			PhaseSince: time.Now(),
+			Services:   map[string]*Service{},
+		},
+		now: time.Now,
+	}
+}
+
+// SetPhase changes the phase of the session
+func (r *Reporter) SetPhase(phase string) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.status.Phase != phase {
+		r.status.Phase = phase
+		r.status.PhaseSince = r.now()
+	}
+}
+
+// SetService sets the state of a service. If err is not nil, it is also recorded as last error
+func (r *Reporter) SetService(name, state string, err error) {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	service := &Service{State: state}
+	if err != nil {
+		service.Error = err.Error()
+		r.setError(err)
+	}
+
+	r.status.Services[name] = service
+}
+
+// RemoveServices removes all services, e.g. when they are restarted after a reload
+func (r *Reporter) RemoveServices() {
+	if r == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.status.Services = map[string]*Service{}
+}
+
+// Error records the last error of the session
+func (r *Reporter) Error(err error) {
+	if r == nil || err == nil {
+		return
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.setError(err)
+}
+
+func (r *Reporter) setError(err error) {
+	now := r.now()
+	r.status.LastError = err.Error()
+	r.status.LastErrorTime = &now
+}
+
+// Status returns the current status of the session
+func (r *Reporter) Status() *Status {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	status := r.status
+	status.Services = make(map[string]*Service, len(r.status.Services))
+	for name, service := range r.status.Services {
+		copied := *service
+		status.Services[name] = &copied
+	}
+
+	status.Healthy, status.Reason = r.check()
+	return &status
+}
+
+// check returns if the session is healthy and the reason if it is not
+func (r *Reporter) check() (bool, string) {
+	if r.status.Phase == PhaseFailed {
+		return false, "session failed"
+	}
+
+	if r.PhaseTimeout > 0 && r.status.Phase != PhaseRunning {
+		if duration := r.now().Sub(r.status.PhaseSince); duration > r.PhaseTimeout {
+			return false, fmt.Sprintf("session is %s since %s", r.status.Phase, duration.Round(time.Second).String())
+		}
+	}
+
+	names := make([]string, 0, len(r.status.Services))
+	for name, service := range r.status.Services {
+		if service.State != StateRunning {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return false, fmt.Sprintf("services not running: %v", names)
+	}
+
+	return true, ""
+}
+
+// ServeHTTP implements http.Handler and returns the status as json. The status code is 200 if the session
+// is healthy and 503 otherwise
+func (r *Reporter) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	status := r.Status()
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if status.Healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	w.Write(data)
+}
+
+// Serve starts the health endpoint on localhost with the given port in the background
+func (r *Reporter) Serve(port int) (*http.Server, error) {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(Path, r)
+
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+
+	return server, nil
+}
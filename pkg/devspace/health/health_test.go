@@ -0,0 +1,73 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func getStatus(t *testing.T, reporter *Reporter) (int, *Status) {
+	recorder := httptest.NewRecorder()
+	reporter.ServeHTTP(recorder, httptest.NewRequest("GET", Path, nil))
+
+	status := &Status{}
+	err := json.Unmarshal(recorder.Body.Bytes(), status)
+	if err != nil {
+		t.Fatalf("Error parsing status %s: %v", recorder.Body.String(), err)
+	}
+
+	return recorder.Code, status
+}
+
+func TestReporter(t *testing.T) {
+	now := time.Now()
+	reporter := NewReporter(time.Minute)
+	reporter.now = func() time.Time { return now }
+	reporter.SetPhase(PhaseBuilding)
+
+	code, status := getStatus(t, reporter)
+	if code != http.StatusOK || status.Healthy == false || status.Phase != PhaseBuilding {
+		t.Fatalf("Expected healthy building session, got %d %#v", code, status)
+	}
+
+	// Phase timeout
+	now = now.Add(2 * time.Minute)
+	code, status = getStatus(t, reporter)
+	if code != http.StatusServiceUnavailable || status.Healthy || status.Reason != "session is building since 2m0s" {
+		t.Fatalf("Expected timed out session, got %d %#v", code, status)
+	}
+
+	// Running sessions never time out
+	reporter.SetPhase(PhaseRunning)
+	reporter.SetService("sync", StateRunning, nil)
+	now = now.Add(time.Hour)
+	code, status = getStatus(t, reporter)
+	if code != http.StatusOK || status.Services["sync"].State != StateRunning {
+		t.Fatalf("Expected healthy running session, got %d %#v", code, status)
+	}
+
+	// Failing services
+	reporter.SetService("portforwarding 8080:80", StateReconnecting, errors.New("connection lost"))
+	code, status = getStatus(t, reporter)
+	if code != http.StatusServiceUnavailable || status.LastError != "connection lost" || status.LastErrorTime == nil || status.Reason != "services not running: [portforwarding 8080:80]" {
+		t.Fatalf("Expected unhealthy session, got %d %#v", code, status)
+	}
+
+	reporter.RemoveServices()
+	reporter.SetPhase(PhaseFailed)
+	code, status = getStatus(t, reporter)
+	if code != http.StatusServiceUnavailable || len(status.Services) != 0 || status.LastError != "connection lost" {
+		t.Fatalf("Expected failed session, got %d %#v", code, status)
+	}
+}
+
+func TestNilReporter(t *testing.T) {
+	var reporter *Reporter
+	reporter.SetPhase(PhaseRunning)
+	reporter.SetService("sync", StateRunning, nil)
+	reporter.RemoveServices()
+	reporter.Error(errors.New("ignored"))
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 
 	"k8s.io/helm/pkg/getter"
@@ -18,6 +19,7 @@ import (
 
 	"k8s.io/client-go/kubernetes"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	homedir "github.com/mitchellh/go-homedir"
@@ -34,6 +36,7 @@ type Interface interface {
 	InstallChart(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error)
 	DeleteRelease(releaseName string, purge bool) (*rls.UninstallReleaseResponse, error)
 	ListReleases() (*rls.ListReleasesResponse, error)
+	RunReleaseTest(releaseName string, helmConfig *latest.HelmConfig) ([]*rls.TestReleaseResponse, error)
 }
 
 // Client holds the necessary information for helm
@@ -44,6 +47,9 @@ type Client struct {
 	helm    k8shelm.Interface
 	kubectl kubernetes.Interface
 
+	tunnel     *kube.Tunnel
+	unregister func()
+
 	config *latest.Config
 }
 
@@ -90,7 +96,7 @@ func createNewClient(config *latest.Config, tillerNamespace string, log log.Logg
 	var tunnel *kube.Tunnel
 	var helmClient *k8shelm.Client
 
-	tunnelWaitTime := 2 * 60 * time.Second
+	tunnelWaitTime := getTillerWaitTimeout(config)
 	tunnelCheckInterval := 5 * time.Second
 
 	log.StartWait("Waiting for " + tillerNamespace + "/tiller-deploy to become ready")
@@ -108,7 +114,10 @@ func createNewClient(config *latest.Config, tillerNamespace string, log log.Logg
 			}
 
 			tunnelWaitTime = tunnelWaitTime - tunnelCheckInterval
-			time.Sleep(tunnelCheckInterval)
+			err = interrupt.Sleep(tunnelCheckInterval)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		helmOptions := []k8shelm.Option{
@@ -126,7 +135,10 @@ func createNewClient(config *latest.Config, tillerNamespace string, log log.Logg
 		tunnel.Close()
 
 		tunnelWaitTime = tunnelWaitTime - tunnelCheckInterval
-		time.Sleep(tunnelCheckInterval)
+		err = interrupt.Sleep(tunnelCheckInterval)
+		if err != nil {
+			return nil, err
+		}
 
 		if tunnelWaitTime < 0 {
 			return nil, errors.New("Waiting for tiller timed out")
@@ -135,36 +147,46 @@ func createNewClient(config *latest.Config, tillerNamespace string, log log.Logg
 
 	log.StopWait()
 
-	return create(config, tillerNamespace, helmClient, kubectlClient, log)
-}
+	// The tunnel is used until the client is closed, so we close it on termination as well
+	unregister := interrupt.Register(tunnel.Close)
 
-func create(config *latest.Config, tillerNamespace string, helmClient k8shelm.Interface, kubectlClient kubernetes.Interface, log log.Logger) (*Client, error) {
-	homeDir, err := homedir.Dir()
+	client, err := create(config, tillerNamespace, helmClient, kubectlClient, log)
 	if err != nil {
+		unregister()
+		tunnel.Close()
 		return nil, err
 	}
 
-	helmHomePath := homeDir + "/.helm"
-	repoPath := helmHomePath + "/repository"
-	repoFile := repoPath + "/repositories.yaml"
-	stableRepoCachePathAbs := helmHomePath + "/" + stableRepoCachePath
+	client.tunnel = tunnel
+	client.unregister = unregister
+	return client, nil
+}
 
-	os.MkdirAll(helmHomePath+"/cache", os.ModePerm)
-	os.MkdirAll(repoPath, os.ModePerm)
-	os.MkdirAll(filepath.Dir(stableRepoCachePathAbs), os.ModePerm)
+// Close closes the tunnel to tiller and removes the client from the client cache
+func (client *Client) Close() {
+	helmClientsMutex.Lock()
+	defer helmClientsMutex.Unlock()
 
-	repoFileStat, repoFileNotFound := os.Stat(repoFile)
-	if repoFileNotFound != nil || repoFileStat.Size() == 0 {
-		err = fsutil.WriteToFile([]byte(defaultRepositories), repoFile)
-		if err != nil {
-			return nil, err
-		}
+	if helmClients[client.Namespace] == client {
+		delete(helmClients, client.Namespace)
 	}
 
+	if client.tunnel != nil {
+		client.unregister()
+		client.tunnel.Close()
+		client.tunnel = nil
+	}
+}
+
+func create(config *latest.Config, tillerNamespace string, helmClient k8shelm.Interface, kubectlClient kubernetes.Interface, log log.Logger) (*Client, error) {
+	settings, err := getSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	stableRepoCachePathAbs := settings.Home.String() + "/" + stableRepoCachePath
 	wrapper := &Client{
-		Settings: &helmenvironment.EnvSettings{
-			Home: helmpath.Home(helmHomePath),
-		},
+		Settings:  settings,
 		Namespace: tillerNamespace,
 		helm:      helmClient,
 		kubectl:   kubectlClient,
@@ -182,6 +204,35 @@ func create(config *latest.Config, tillerNamespace string, helmClient k8shelm.In
 	return wrapper, nil
 }
 
+// getSettings returns the helm settings with the helm home in the user's home directory and creates the
+// helm home if it doesn't exist yet
+func getSettings() (*helmenvironment.EnvSettings, error) {
+	homeDir, err := homedir.Dir()
+	if err != nil {
+		return nil, err
+	}
+
+	helmHomePath := homeDir + "/.helm"
+	repoPath := helmHomePath + "/repository"
+	repoFile := repoPath + "/repositories.yaml"
+
+	os.MkdirAll(helmHomePath+"/cache", os.ModePerm)
+	os.MkdirAll(repoPath, os.ModePerm)
+	os.MkdirAll(filepath.Dir(helmHomePath+"/"+stableRepoCachePath), os.ModePerm)
+
+	repoFileStat, repoFileNotFound := os.Stat(repoFile)
+	if repoFileNotFound != nil || repoFileStat.Size() == 0 {
+		err = fsutil.WriteToFile([]byte(defaultRepositories), repoFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &helmenvironment.EnvSettings{
+		Home: helmpath.Home(helmHomePath),
+	}, nil
+}
+
 // UpdateRepos will update the helm repositories
 func (client *Client) UpdateRepos(log log.Logger) error {
 	allRepos, err := repo.LoadRepositoriesFile(client.Settings.Home.RepositoryFile())
@@ -237,7 +288,10 @@ func ReleaseExists(helm helm.Interface, releaseName string) bool {
 
 // DeleteRelease deletes a helm release and optionally purges it
 func (client *Client) DeleteRelease(releaseName string, purge bool) (*rls.UninstallReleaseResponse, error) {
-	return client.helm.DeleteRelease(releaseName, k8shelm.DeletePurge(purge))
+	response, err := client.helm.DeleteRelease(releaseName, k8shelm.DeletePurge(purge))
+	audit.Record(audit.ActionUninstall, "Release", "", releaseName, err)
+
+	return response, err
 }
 
 // ListReleases lists all helm releases
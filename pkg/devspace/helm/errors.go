@@ -0,0 +1,85 @@
+package helm
+
+import (
+	"regexp"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// InvalidObject describes a kubernetes object of a chart that was rejected by the api server. Name is empty if the
+// error does not contain the object name
+type InvalidObject struct {
+	Kind    string
+	Name    string
+	Message string
+}
+
+var grpcErrorRegex = regexp.MustCompile(`rpc error: code = \w+ desc = `)
+
+// invalidObjectRegexes match the errors of rejected objects, the named groups kind, name and message are extracted
+var invalidObjectRegexes = []*regexp.Regexp{
+	// e.g. Deployment.apps "web" is invalid: spec.template.spec.containers[0].image: Required value
+	regexp.MustCompile(`(?P<kind>[A-Za-z]+)(?:\.[a-z0-9.]+)? "(?P<name>[^"]+)" is invalid: (?P<message>.*)`),
+	// e.g. error validating data: ValidationError(Deployment.spec): unknown field "replica" in io.k8s.api.apps.v1.DeploymentSpec
+	regexp.MustCompile(`ValidationError\((?P<kind>[A-Za-z]+)[^)]*\): (?P<message>.*)`),
+	// e.g. no matches for kind "Deployment" in version "apps/v1beta3"
+	regexp.MustCompile(`(?P<message>no matches for kind "(?P<kind>[A-Za-z]+)".*)`),
+}
+
+// CleanError removes the grpc prefixes tiller adds to its errors
+func CleanError(errMessage string) string {
+	return grpcErrorRegex.ReplaceAllString(errMessage, "")
+}
+
+// ParseInvalidObject extracts the kind, name and validation message of the rejected object from a helm error.
+// Returns nil if the error is not caused by an invalid object
+func ParseInvalidObject(errMessage string) *InvalidObject {
+	errMessage = CleanError(errMessage)
+	for _, regex := range invalidObjectRegexes {
+		match := regex.FindStringSubmatch(errMessage)
+		if match == nil {
+			continue
+		}
+
+		invalidObject := &InvalidObject{}
+		for i, name := range regex.SubexpNames() {
+			switch name {
+			case "kind":
+				invalidObject.Kind = match[i]
+			case "name":
+				invalidObject.Name = match[i]
+			case "message":
+				invalidObject.Message = strings.TrimSpace(match[i])
+			}
+		}
+
+		return invalidObject
+	}
+
+	return nil
+}
+
+// FindManifest searches the rendered templates for the manifest of the object and returns the template name and
+// the manifest. If the object name is empty, the first manifest of the object kind is returned
+func FindManifest(templates map[string]string, invalidObject *InvalidObject) (string, string) {
+	for _, templateName := range sortedTemplateNames(templates) {
+		for _, manifest := range strings.Split(templates[templateName], "\n---") {
+			object := struct {
+				Kind     string `yaml:"kind"`
+				Metadata struct {
+					Name string `yaml:"name"`
+				} `yaml:"metadata"`
+			}{}
+			if yaml.Unmarshal([]byte(manifest), &object) != nil {
+				continue
+			}
+
+			if object.Kind == invalidObject.Kind && (invalidObject.Name == "" || object.Metadata.Name == invalidObject.Name) {
+				return templateName, strings.Trim(manifest, "-\n")
+			}
+		}
+	}
+
+	return "", ""
+}
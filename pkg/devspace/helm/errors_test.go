@@ -0,0 +1,37 @@
+package helm
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseInvalidObject(t *testing.T) {
+	invalidObject := ParseInvalidObject(`helm install: rpc error: code = Unknown desc = release web failed: Deployment.apps "web" is invalid: spec.template.spec.containers[0].image: Required value`)
+	assert.DeepEqual(t, invalidObject, &InvalidObject{Kind: "Deployment", Name: "web", Message: "spec.template.spec.containers[0].image: Required value"})
+
+	invalidObject = ParseInvalidObject(`helm upgrade: rpc error: code = Unknown desc = error validating "": error validating data: ValidationError(Service.spec): unknown field "port" in io.k8s.api.core.v1.ServiceSpec`)
+	assert.DeepEqual(t, invalidObject, &InvalidObject{Kind: "Service", Message: `unknown field "port" in io.k8s.api.core.v1.ServiceSpec`})
+
+	invalidObject = ParseInvalidObject(`helm install: rpc error: code = Unknown desc = unable to recognize "": no matches for kind "Deployment" in version "apps/v1beta3"`)
+	assert.DeepEqual(t, invalidObject, &InvalidObject{Kind: "Deployment", Message: `no matches for kind "Deployment" in version "apps/v1beta3"`})
+
+	assert.Assert(t, ParseInvalidObject("helm install: timed out waiting for the condition") == nil)
+}
+
+func TestFindManifest(t *testing.T) {
+	templates := map[string]string{
+		"chart/templates/service.yaml":    "apiVersion: v1\nkind: Service\nmetadata:\n  name: web",
+		"chart/templates/deployment.yaml": "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: db\n---\napiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web",
+	}
+
+	templateName, manifest := FindManifest(templates, &InvalidObject{Kind: "Deployment", Name: "web"})
+	assert.Equal(t, templateName, "chart/templates/deployment.yaml")
+	assert.Equal(t, manifest, "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web")
+
+	templateName, _ = FindManifest(templates, &InvalidObject{Kind: "Service"})
+	assert.Equal(t, templateName, "chart/templates/service.yaml")
+
+	_, manifest = FindManifest(templates, &InvalidObject{Kind: "Ingress"})
+	assert.Equal(t, manifest, "")
+}
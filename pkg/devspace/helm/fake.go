@@ -41,6 +41,11 @@ func (f *FakeClient) ListReleases() (*rls.ListReleasesResponse, error) {
 	return f.helm.ListReleases()
 }
 
+// RunReleaseTest implements interface
+func (f *FakeClient) RunReleaseTest(releaseName string, helmConfig *latest.HelmConfig) ([]*rls.TestReleaseResponse, error) {
+	return runReleaseTest(f.helm, releaseName, helmConfig)
+}
+
 // InstallChart implements interface
 func (f *FakeClient) InstallChart(releaseName string, releaseNamespace string, values *map[interface{}]interface{}, helmConfig *latest.HelmConfig) (*hapi_release5.Release, error) {
 	chart := &chart.Chart{
@@ -7,6 +7,8 @@ import (
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"k8s.io/client-go/kubernetes/fake"
+	k8shelm "k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
 )
 
 //@MoreTest
@@ -62,3 +64,30 @@ func TestFakeInstallChart(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestFakeRunReleaseTest(t *testing.T) {
+	kubeClient := fake.NewSimpleClientset()
+	fakeClient := NewFakeClient(kubeClient, configutil.TestNamespace)
+	fakeClient.helm.(*k8shelm.FakeClient).Responses = map[string]release.TestRun_Status{
+		"PASSED: test-connection": release.TestRun_SUCCESS,
+		"FAILED: test-database":   release.TestRun_FAILURE,
+	}
+
+	results, err := fakeClient.RunReleaseTest("test-release", &latest.HelmConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 test results, got %d", len(results))
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Status == release.TestRun_FAILURE {
+			failed++
+		}
+	}
+	if failed != 1 {
+		t.Fatalf("Expected 1 failed test, got %d", failed)
+	}
+}
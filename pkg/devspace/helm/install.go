@@ -9,6 +9,7 @@ import (
 	"github.com/pkg/errors"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/analyze"
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
@@ -129,6 +130,7 @@ func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPat
 			k8shelm.ReuseValues(false),
 			k8shelm.UpgradeForce(ptr.ReverseBool(helmConfig.Force)),
 		)
+		audit.Record(audit.ActionUpgrade, "Release", releaseNamespace, releaseName, err)
 
 		if err != nil {
 			err = client.analyzeError(fmt.Errorf("helm upgrade: %v", err), releaseNamespace)
@@ -136,6 +138,7 @@ func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPat
 				if rollback {
 					log.Warn("Try to roll back back chart because of previous error")
 					_, rollbackError := client.helm.RollbackRelease(releaseName, k8shelm.RollbackTimeout(180))
+					audit.Record(audit.ActionRollback, "Release", releaseNamespace, releaseName, rollbackError)
 					if rollbackError != nil {
 						return nil, err
 					}
@@ -159,6 +162,7 @@ func (client *Client) InstallChartByPath(releaseName, releaseNamespace, chartPat
 		k8shelm.ReleaseName(releaseName),
 		k8shelm.InstallReuseName(true),
 	)
+	audit.Record(audit.ActionInstall, "Release", releaseNamespace, releaseName, err)
 	if err != nil {
 		err = client.analyzeError(fmt.Errorf("helm install: %v", err), releaseNamespace)
 		if err != nil {
@@ -3,6 +3,7 @@ package helm
 import (
 	"regexp"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 
@@ -67,6 +68,7 @@ func createTillerRBAC(config *latest.Config, kubectlClient kubernetes.Interface,
 						Name: *appNamespace,
 					},
 				})
+				audit.Record(audit.ActionCreate, "Namespace", "", *appNamespace, err)
 				if err != nil {
 					return err
 				}
@@ -89,6 +91,7 @@ func createTillerServiceAccount(kubectlClient kubernetes.Interface, tillerNamesp
 			Namespace: tillerNamespace,
 		},
 	})
+	audit.Record(audit.ActionCreate, "ServiceAccount", tillerNamespace, TillerServiceAccountName, err)
 
 	return err
 }
@@ -0,0 +1,146 @@
+package helm
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/pkg/errors"
+
+	yaml "gopkg.in/yaml.v2"
+	helmchartutil "k8s.io/helm/pkg/chartutil"
+	"k8s.io/helm/pkg/engine"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+	"k8s.io/helm/pkg/renderutil"
+	helmversion "k8s.io/helm/pkg/version"
+)
+
+// LoadChart locates the chart of the chart config, downloads it into the helm home if it is not a local chart and loads it
+func LoadChart(chartConfig *latest.ChartConfig) (*chart.Chart, error) {
+	settings, err := getSettings()
+	if err != nil {
+		return nil, err
+	}
+
+	chartPath, err := locateChartPath(settings, ptr.ReverseString(chartConfig.RepoURL), ptr.ReverseString(chartConfig.Username), ptr.ReverseString(chartConfig.Password), ptr.ReverseString(chartConfig.Name), ptr.ReverseString(chartConfig.Version), false, "", "", "", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "locate chart path")
+	}
+
+	loadedChart, err := helmchartutil.Load(chartPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "load chart")
+	}
+
+	return loadedChart, nil
+}
+
+// RenderTemplates renders the templates of the chart with the given values locally, the same way helm template does.
+// If the cluster capabilities are given, templates can check the api versions and the kubernetes version of the
+// cluster with .Capabilities like they do when tiller renders them. Empty templates and NOTES.txt are omitted
+func RenderTemplates(renderChart *chart.Chart, releaseName, releaseNamespace string, values map[interface{}]interface{}, capabilities *kubectl.Capabilities) (map[string]string, error) {
+	rawValues, err := yaml.Marshal(values)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal values")
+	}
+
+	renderedTemplates, err := render(renderChart, &chart.Config{Raw: string(rawValues)}, helmchartutil.ReleaseOptions{
+		Name:      releaseName,
+		Namespace: releaseNamespace,
+		IsInstall: true,
+	}, getRenderCapabilities(capabilities))
+	if err != nil {
+		return nil, errors.Wrap(err, "render chart")
+	}
+
+	templates := make(map[string]string, len(renderedTemplates))
+	for templateName, content := range renderedTemplates {
+		if path.Base(templateName) == "NOTES.txt" || strings.TrimSpace(content) == "" {
+			continue
+		}
+
+		templates[templateName] = strings.TrimSpace(content)
+	}
+
+	return templates, nil
+}
+
+// RenderChart renders the templates of the chart with the given values locally, the same way helm template does.
+// The manifests are sorted by template name, so the same chart, values and capabilities always result in the same output
+func RenderChart(renderChart *chart.Chart, releaseName, releaseNamespace string, values map[interface{}]interface{}, capabilities *kubectl.Capabilities) (string, error) {
+	templates, err := RenderTemplates(renderChart, releaseName, releaseNamespace, values, capabilities)
+	if err != nil {
+		return "", err
+	}
+
+	manifests := make([]string, 0, len(templates))
+	for _, templateName := range sortedTemplateNames(templates) {
+		manifests = append(manifests, "---\n# Source: "+templateName+"\n"+templates[templateName]+"\n")
+	}
+
+	return strings.Join(manifests, ""), nil
+}
+
+func sortedTemplateNames(templates map[string]string) []string {
+	templateNames := make([]string, 0, len(templates))
+	for templateName := range templates {
+		templateNames = append(templateNames, templateName)
+	}
+	sort.Strings(templateNames)
+
+	return templateNames
+}
+
+// getRenderCapabilities converts the cluster capabilities into the helm capabilities. Without cluster capabilities
+// the defaults of helm template are used
+func getRenderCapabilities(capabilities *kubectl.Capabilities) *helmchartutil.Capabilities {
+	renderCapabilities := &helmchartutil.Capabilities{
+		APIVersions:   helmchartutil.DefaultVersionSet,
+		KubeVersion:   helmchartutil.DefaultKubeVersion,
+		TillerVersion: helmversion.GetVersionProto(),
+	}
+	if capabilities == nil {
+		return renderCapabilities
+	}
+
+	if len(capabilities.APIVersions) > 0 {
+		renderCapabilities.APIVersions = helmchartutil.NewVersionSet(append([]string{"v1"}, capabilities.APIVersions...)...)
+	}
+	if capabilities.KubeVersion != nil {
+		renderCapabilities.KubeVersion = capabilities.KubeVersion
+	}
+
+	return renderCapabilities
+}
+
+// render renders the chart like renderutil.Render, but with the given capabilities
+func render(renderChart *chart.Chart, config *chart.Config, releaseOptions helmchartutil.ReleaseOptions, capabilities *helmchartutil.Capabilities) (map[string]string, error) {
+	requirements, err := helmchartutil.LoadRequirements(renderChart)
+	if err == nil {
+		err = renderutil.CheckDependencies(renderChart, requirements)
+		if err != nil {
+			return nil, err
+		}
+	} else if err != helmchartutil.ErrRequirementsNotFound {
+		return nil, errors.Wrap(err, "load requirements")
+	}
+
+	err = helmchartutil.ProcessRequirementsEnabled(renderChart, config)
+	if err != nil {
+		return nil, err
+	}
+	err = helmchartutil.ProcessRequirementsImportValues(renderChart)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := helmchartutil.ToRenderValuesCaps(renderChart, config, releaseOptions, capabilities)
+	if err != nil {
+		return nil, err
+	}
+
+	return engine.New().Render(renderChart, values)
+}
@@ -0,0 +1,55 @@
+package helm
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/helm/pkg/proto/hapi/chart"
+)
+
+func TestRenderChartCapabilities(t *testing.T) {
+	renderChart := &chart.Chart{
+		Metadata: &chart.Metadata{Name: "test", Version: "0.0.1"},
+		Templates: []*chart.Template{
+			{
+				Name: "templates/deployment.yaml",
+				Data: []byte(`apiVersion: {{ if .Capabilities.APIVersions.Has "apps/v1" }}apps/v1{{ else }}extensions/v1beta1{{ end }}
+kind: Deployment
+metadata:
+  name: {{ .Release.Name }}
+  annotations:
+    kubeVersion: {{ .Capabilities.KubeVersion.GitVersion }}`),
+			},
+		},
+	}
+
+	// Without a cluster the defaults of helm template are used
+	manifests, err := RenderChart(renderChart, "test-release", "test", map[interface{}]interface{}{}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, manifests, `---
+# Source: test/templates/deployment.yaml
+apiVersion: extensions/v1beta1
+kind: Deployment
+metadata:
+  name: test-release
+  annotations:
+    kubeVersion: v1.9.0
+`)
+
+	manifests, err = RenderChart(renderChart, "test-release", "test", map[interface{}]interface{}{}, &kubectl.Capabilities{
+		KubeVersion: &version.Info{Major: "1", Minor: "16", GitVersion: "v1.16.2"},
+		APIVersions: []string{"apps/v1", "networking.k8s.io/v1beta1"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, manifests, `---
+# Source: test/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-release
+  annotations:
+    kubeVersion: v1.16.2
+`)
+}
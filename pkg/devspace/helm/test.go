@@ -0,0 +1,49 @@
+package helm
+
+import (
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	k8shelm "k8s.io/helm/pkg/helm"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// TestTimeout is the default timeout to wait for a single helm test to finish
+const TestTimeout = int64(300)
+
+// RunReleaseTest runs the test hooks of the given release and returns the results of all tests
+func (client *Client) RunReleaseTest(releaseName string, helmConfig *latest.HelmConfig) ([]*rls.TestReleaseResponse, error) {
+	return runReleaseTest(client.helm, releaseName, helmConfig)
+}
+
+func runReleaseTest(helmClient k8shelm.Interface, releaseName string, helmConfig *latest.HelmConfig) ([]*rls.TestReleaseResponse, error) {
+	timeout := TestTimeout
+	if helmConfig != nil && helmConfig.Timeout != nil {
+		timeout = *helmConfig.Timeout
+	}
+
+	resultChan, errChan := helmClient.RunReleaseTest(releaseName, k8shelm.ReleaseTestTimeout(timeout))
+
+	// If tiller can't be reached the result channel is nil and the error is only sent on the error channel,
+	// so we have to wait for both channels
+	results := []*rls.TestReleaseResponse{}
+	for resultChan != nil || errChan != nil {
+		select {
+		case result, ok := <-resultChan:
+			if ok == false {
+				resultChan = nil
+				continue
+			}
+
+			results = append(results, result)
+		case err, ok := <-errChan:
+			if ok == false {
+				errChan = nil
+				continue
+			}
+			if err != nil {
+				return results, err
+			}
+		}
+	}
+
+	return results, nil
+}
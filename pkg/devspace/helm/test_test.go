@@ -0,0 +1,51 @@
+package helm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	k8shelm "k8s.io/helm/pkg/helm"
+	"k8s.io/helm/pkg/proto/hapi/release"
+	rls "k8s.io/helm/pkg/proto/hapi/services"
+)
+
+// unreachableHelmClient behaves like the helm client when tiller can't be reached
+type unreachableHelmClient struct {
+	k8shelm.FakeClient
+}
+
+func (u *unreachableHelmClient) RunReleaseTest(rlsName string, opts ...k8shelm.ReleaseTestOption) (<-chan *rls.TestReleaseResponse, <-chan error) {
+	errc := make(chan error, 1)
+	errc <- errors.New("context deadline exceeded")
+	return nil, errc
+}
+
+func TestRunReleaseTest(t *testing.T) {
+	helmClient := &k8shelm.FakeClient{
+		Responses: map[string]release.TestRun_Status{
+			"PASSED: test-connection": release.TestRun_SUCCESS,
+		},
+	}
+
+	results, err := runReleaseTest(helmClient, "test-release", nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(results), 1)
+	assert.Equal(t, results[0].Msg, "PASSED: test-connection")
+}
+
+func TestRunReleaseTestError(t *testing.T) {
+	done := make(chan error)
+	go func() {
+		_, err := runReleaseTest(&unreachableHelmClient{}, "test-release", nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.Error(t, err, "context deadline exceeded")
+	case <-time.After(time.Second * 10):
+		t.Fatal("Running the release tests didn't return the error of the helm client")
+	}
+}
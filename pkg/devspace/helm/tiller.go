@@ -3,19 +3,35 @@ package helm
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/Masterminds/semver"
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/envutil"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	appsv1 "k8s.io/api/apps/v1"
 	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	helminstaller "k8s.io/helm/cmd/helm/installer"
+	helmversion "k8s.io/helm/pkg/version"
 )
 
 // TillerDeploymentName is the string identifier for the tiller deployment
 const TillerDeploymentName = "tiller-deploy"
+
+// DefaultTillerWaitTimeout is the default time to wait for tiller to become ready
+const DefaultTillerWaitTimeout = 2 * time.Minute
+
+// TillerWaitTimeoutEnv is the environment variable that overrides the tiller wait timeout in seconds
+const TillerWaitTimeoutEnv = "DEVSPACE_TILLER_WAIT_TIMEOUT"
+
 const stableRepoCachePath = "repository/cache/stable-index.yaml"
 const defaultRepositories = `apiVersion: v1
 repositories:
@@ -42,14 +58,17 @@ func ensureTiller(config *latest.Config, kubectlClient kubernetes.Interface, til
 				Name: tillerNamespace,
 			},
 		})
+		audit.Record(audit.ActionCreate, "Namespace", "", tillerNamespace, err)
 		if err != nil {
 			return err
 		}
 	}
 
 	// Create tiller if necessary
-	_, err = kubectlClient.ExtensionsV1beta1().Deployments(tillerNamespace).Get(TillerDeploymentName, metav1.GetOptions{})
-	if err != nil {
+	_, err = kubectl.GetDeployment(kubectlClient, tillerNamespace, TillerDeploymentName)
+	if kubectl.IsUnsupportedClusterError(err) {
+		return err
+	} else if err != nil {
 		// Create tiller server
 		err = createTiller(config, kubectlClient, tillerNamespace, tillerOptions, log)
 		if err != nil {
@@ -66,7 +85,7 @@ func ensureTiller(config *latest.Config, kubectlClient kubernetes.Interface, til
 		}
 	}
 
-	return waitUntilTillerIsStarted(kubectlClient, tillerNamespace, log)
+	return waitUntilTillerIsStarted(config, kubectlClient, tillerNamespace, log)
 }
 
 func getTillerOptions(tillerNamespace string) (tillerOptions *helminstaller.Options) {
@@ -93,7 +112,8 @@ func createTiller(config *latest.Config, kubectlClient kubernetes.Interface, til
 	}
 
 	// Create the deployment
-	err = helminstaller.Install(kubectlClient, tillerOptions)
+	err = installTiller(kubectlClient, tillerOptions)
+	audit.Record(audit.ActionCreate, "Deployment", tillerOptions.Namespace, TillerDeploymentName, err)
 	if err != nil {
 		return err
 	}
@@ -102,43 +122,162 @@ func createTiller(config *latest.Config, kubectlClient kubernetes.Interface, til
 	return nil
 }
 
-func waitUntilTillerIsStarted(kubectlClient kubernetes.Interface, tillerNamespace string, log log.Logger) error {
-	tillerWaitingTime := 2 * 60 * time.Second
+// getTillerWaitTimeout returns the longest tillerTimeout of the helm deployments or the timeout from the environment
+func getTillerWaitTimeout(config *latest.Config) time.Duration {
+	timeout := time.Duration(0)
+	if config != nil && config.Deployments != nil {
+		for _, deployConfig := range *config.Deployments {
+			var tillerTimeout *int64
+			if deployConfig.Helm != nil {
+				tillerTimeout = deployConfig.Helm.TillerTimeout
+			} else if deployConfig.Component != nil && deployConfig.Component.Options != nil {
+				tillerTimeout = deployConfig.Component.Options.TillerTimeout
+			}
+
+			if tillerTimeout != nil && time.Duration(*tillerTimeout)*time.Second > timeout {
+				timeout = time.Duration(*tillerTimeout) * time.Second
+			}
+		}
+	}
+	if timeout > 0 {
+		return timeout
+	}
+
+	return envutil.GetTimeout(TillerWaitTimeoutEnv, DefaultTillerWaitTimeout)
+}
+
+// getTillerPendingReason returns why the tiller pod is not ready yet
+func getTillerPendingReason(kubectlClient kubernetes.Interface, tillerNamespace string) string {
+	pods, err := kubectl.GetPodsFromDeployment(kubectlClient, TillerDeploymentName, tillerNamespace)
+	if err != nil || len(pods.Items) == 0 {
+		return "Waiting for tiller to start"
+	}
+
+	return "Waiting for tiller to start: " + kubectl.GetPodPendingReason(&pods.Items[0])
+}
+
+func waitUntilTillerIsStarted(config *latest.Config, kubectlClient kubernetes.Interface, tillerNamespace string, log log.Logger) error {
+	tillerWaitingTime := getTillerWaitTimeout(config)
 	tillerCheckInterval := 5 * time.Second
 
 	log.StartWait("Waiting for tiller to start")
 	defer log.StopWait()
 
 	for tillerWaitingTime > 0 {
-		tillerDeployment, err := kubectlClient.ExtensionsV1beta1().Deployments(tillerNamespace).Get(TillerDeploymentName, metav1.GetOptions{})
-		if err != nil {
-			continue
+		tillerDeployment, err := kubectl.GetDeployment(kubectlClient, tillerNamespace, TillerDeploymentName)
+		if err == nil {
+			if tillerDeployment.Status.ReadyReplicas == tillerDeployment.Status.Replicas {
+				return nil
+			}
+
+			log.StartWait(getTillerPendingReason(kubectlClient, tillerNamespace))
 		}
-		if tillerDeployment.Status.ReadyReplicas == tillerDeployment.Status.Replicas {
-			return nil
+
+		err = interrupt.Sleep(tillerCheckInterval)
+		if err != nil {
+			return err
 		}
 
-		time.Sleep(tillerCheckInterval)
 		tillerWaitingTime = tillerWaitingTime - tillerCheckInterval
 	}
 
-	return errors.New("Tiller didn't start in time")
+	return fmt.Errorf("Tiller didn't start in time. You can increase the timeout with helm.tillerTimeout or the environment variable %s", TillerWaitTimeoutEnv)
+}
+
+// installTiller creates the tiller deployment and service. The deployment is created with the api version the cluster
+// supports, because the helm installer always uses extensions/v1beta1, which newer clusters do not serve anymore
+func installTiller(kubectlClient kubernetes.Interface, tillerOptions *helminstaller.Options) error {
+	legacyDeployment, err := helminstaller.Deployment(tillerOptions)
+	if err != nil {
+		return err
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = kubectl.ConvertObject(legacyDeployment, deployment)
+	if err != nil {
+		return err
+	}
+
+	// apps/v1 requires a selector, extensions/v1beta1 defaulted it to the template labels
+	if deployment.Spec.Selector == nil {
+		deployment.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: deployment.Spec.Template.Labels,
+		}
+	}
+
+	_, err = kubectl.CreateDeployment(kubectlClient, deployment)
+	if err != nil {
+		return err
+	}
+
+	service := helminstaller.Service(tillerOptions.Namespace)
+	_, err = kubectlClient.CoreV1().Services(service.Namespace).Create(service)
+	return err
 }
 
+// upgradeTiller updates the image of the tiller deployment like helminstaller.Upgrade, but with the deployment api
+// version the cluster supports
 func upgradeTiller(kubectlClient kubernetes.Interface, tillerOptions *helminstaller.Options, log log.Logger) error {
 	log.StartWait("Upgrading tiller")
-	err := helminstaller.Upgrade(kubectlClient, tillerOptions)
-	log.StopWait()
+	defer log.StopWait()
+
+	deployment, err := kubectl.GetDeployment(kubectlClient, tillerOptions.Namespace, TillerDeploymentName)
 	if err != nil {
 		return err
 	}
+	if len(deployment.Spec.Template.Spec.Containers) == 0 {
+		return fmt.Errorf("Tiller deployment %s/%s has no containers", tillerOptions.Namespace, TillerDeploymentName)
+	}
 
-	return nil
+	container := &deployment.Spec.Template.Spec.Containers[0]
+	if isNewerTiller(container.Image) && !tillerOptions.ForceUpgrade {
+		return errors.New("current Tiller version is newer, use --force-upgrade to downgrade")
+	}
+
+	container.Image = tillerOptions.SelectImage()
+	container.ImagePullPolicy = k8sv1.PullIfNotPresent
+	if tillerOptions.UseCanary {
+		container.ImagePullPolicy = k8sv1.PullAlways
+	}
+	deployment.Spec.Template.Spec.ServiceAccountName = tillerOptions.ServiceAccount
+
+	_, err = kubectl.UpdateDeployment(kubectlClient, deployment)
+	if err != nil {
+		return err
+	}
+
+	// Tiller versions before 2.5 did not have a service
+	_, err = kubectlClient.CoreV1().Services(tillerOptions.Namespace).Get(TillerDeploymentName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		service := helminstaller.Service(tillerOptions.Namespace)
+		_, err = kubectlClient.CoreV1().Services(service.Namespace).Create(service)
+	}
+
+	return err
+}
+
+// isNewerTiller returns true if the tiller image has a newer version than the helm client
+func isNewerTiller(image string) bool {
+	split := strings.Split(image, ":")
+	if len(split) < 2 {
+		return false
+	}
+
+	tillerVersion, err := semver.NewVersion(split[len(split)-1])
+	if err != nil {
+		return false
+	}
+	clientVersion, err := semver.NewVersion(helmversion.Version)
+	if err != nil {
+		return false
+	}
+
+	return clientVersion.LessThan(tillerVersion)
 }
 
 // IsTillerDeployed determines if we could connect to a tiller server
 func IsTillerDeployed(config *latest.Config, client kubernetes.Interface, tillerNamespace string) bool {
-	deployment, err := client.ExtensionsV1beta1().Deployments(tillerNamespace).Get(TillerDeploymentName, metav1.GetOptions{})
+	deployment, err := kubectl.GetDeployment(client, tillerNamespace, TillerDeploymentName)
 	if err != nil {
 		return false
 	}
@@ -161,9 +300,10 @@ func IsTillerDeployed(config *latest.Config, client kubernetes.Interface, tiller
 // DeleteTiller clears the tiller server, the service account and role binding
 func DeleteTiller(config *latest.Config, kubectlClient kubernetes.Interface, tillerNamespace string) error {
 	propagationPolicy := metav1.DeletePropagationForeground
+	audit.Record(audit.ActionDelete, "Tiller", tillerNamespace, TillerDeploymentName, nil)
 
 	// Delete deployment
-	kubectlClient.ExtensionsV1beta1().Deployments(tillerNamespace).Delete(TillerDeploymentName, &metav1.DeleteOptions{
+	kubectl.DeleteDeployment(kubectlClient, tillerNamespace, TillerDeploymentName, &metav1.DeleteOptions{
 		PropagationPolicy: &propagationPolicy,
 	})
 
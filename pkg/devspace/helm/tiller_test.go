@@ -10,6 +10,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
 	
@@ -65,8 +66,11 @@ func createTestResources(client kubernetes.Interface) error {
 func TestTillerEnsure(t *testing.T) {
 	config := createFakeConfig()
 
-	// Create the fake client.
+	// Create the fake client of a cluster that serves deployments in extensions/v1beta1
 	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: "extensions/v1beta1", APIResources: []metav1.APIResource{{Name: "deployments"}}},
+	}
 
 	// Inject an event into the fake client.
 	err := createTestResources(client)
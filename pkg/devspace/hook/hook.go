@@ -3,8 +3,13 @@ package hook
 import (
 	"fmt"
 	"io"
+	"os"
+	"runtime"
+	"sort"
 	"strings"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/command"
 	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
@@ -42,11 +47,21 @@ var (
 
 // Execute executes hooks at a specific time
 func Execute(config *latest.Config, when When, stage Stage, which string, log logpkg.Logger) error {
+	return ExecuteWithSummary(config, when, stage, which, nil, log)
+}
+
+// ExecuteWithSummary executes hooks at a specific time and passes the path of a json file containing the summary
+// to the hooks via the DEVSPACE_DEPLOY_SUMMARY environment variable
+func ExecuteWithSummary(config *latest.Config, when When, stage Stage, which string, summary *Summary, log logpkg.Logger) error {
 	if config.Hooks != nil && len(*config.Hooks) > 0 {
 		hooksToExecute := []*latest.HookConfig{}
 
 		// Gather all hooks we should execute
 		for _, hook := range *config.Hooks {
+			if matchesOS(hook) == false {
+				continue
+			}
+
 			if hook.When != nil {
 				if when == Before && hook.When.Before != nil {
 					if stage == StageDeployments && hook.When.Before.Deployments != nil && strings.TrimSpace(*hook.When.Before.Deployments) == strings.TrimSpace(which) {
@@ -64,33 +79,144 @@ func Execute(config *latest.Config, when When, stage Stage, which string, log lo
 			}
 		}
 
+		// Write the summary for the hooks
+		env := []string{}
+		if summary != nil && len(hooksToExecute) > 0 {
+			summaryPath, err := writeSummary(summary)
+			if err != nil {
+				return err
+			}
+			defer os.Remove(summaryPath)
+
+			env = append(env, SummaryEnv+"="+summaryPath)
+		}
+
 		// Execute hooks
 		for _, hook := range hooksToExecute {
-			// Build arguments
-			args := []string{}
-
-			if hook.Args != nil {
-				for _, arg := range *hook.Args {
-					args = append(args, *arg)
-				}
+			err := executeHook(hook, env, log)
+			if err != nil {
+				return err
 			}
+		}
+	}
 
-			cmd := command.NewStreamCommand(*hook.Command, args)
+	return nil
+}
+
+// ExecuteEvent executes the hooks that are registered for the given command event, e.g. before:dev or after:purge.
+// The config variables are passed to the hooks as DEVSPACE_VAR_[NAME] environment variables
+func ExecuteEvent(config *latest.Config, generatedConfig *generated.Config, when When, command string, log logpkg.Logger) error {
+	if config.Hooks == nil || len(*config.Hooks) == 0 {
+		return nil
+	}
 
-			// Determine output writer
-			var writer io.Writer
-			if log == logpkg.GetInstance() {
-				writer = stdout
-			} else {
-				writer = log
+	event := EventName(when, command)
+	hooksToExecute := []*latest.HookConfig{}
+	for _, hook := range *config.Hooks {
+		if hook.Events == nil || matchesOS(hook) == false {
+			continue
+		}
+
+		for _, hookEvent := range *hook.Events {
+			if hookEvent != nil && strings.TrimSpace(*hookEvent) == event {
+				hooksToExecute = append(hooksToExecute, hook)
+				break
 			}
+		}
+	}
+	if len(hooksToExecute) == 0 {
+		return nil
+	}
+
+	env := getVarsEnv(generatedConfig)
+	for _, hook := range hooksToExecute {
+		err := executeHook(hook, env, log)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EventName returns the name of a command event as it is used in the hook config (e.g. before:dev)
+func EventName(when When, command string) string {
+	if when == After {
+		return "after:" + command
+	}
+
+	return "before:" + command
+}
+
+// getVarsEnv returns the variables of the active config as environment variables
+func getVarsEnv(generatedConfig *generated.Config) []string {
+	env := []string{}
+	if generatedConfig == nil || generatedConfig.GetActive() == nil {
+		return env
+	}
+
+	for name, value := range generatedConfig.GetActive().Vars {
+		env = append(env, configutil.VarEnvPrefix+strings.ToUpper(name)+"="+value)
+	}
+
+	sort.Strings(env)
+	return env
+}
+
+// matchesOS returns true if the hook should be executed on the current operating system
+func matchesOS(hook *latest.HookConfig) bool {
+	if hook.OS == nil || len(*hook.OS) == 0 {
+		return true
+	}
+
+	for _, hookOS := range *hook.OS {
+		if hookOS != nil && strings.TrimSpace(*hookOS) == runtime.GOOS {
+			return true
+		}
+	}
+
+	return false
+}
+
+// executeHook runs the hook command and waits for it to finish. Background hooks are only started
+func executeHook(hook *latest.HookConfig, env []string, log logpkg.Logger) error {
+	hookCommand, args, err := configutil.GetOSCommand(hook.Command, hook.Args, hook.Commands)
+	if err != nil {
+		return err
+	}
+
+	var cmd command.Interface
+	if command.IsShellScript(hookCommand) {
+		cmd = command.NewShellCommand(hookCommand, args, env)
+	} else {
+		cmd = command.NewStreamCommandWithEnv(hookCommand, args, env)
+	}
+
+	// Determine output writer
+	var writer io.Writer
+	if log == logpkg.GetInstance() {
+		writer = stdout
+	} else {
+		writer = log
+	}
 
-			log.Infof("Execute hook: %s", ansi.Color(fmt.Sprintf("%s '%s'", *hook.Command, strings.Join(args, "' '")), "white+b"))
+	commandString := ansi.Color(fmt.Sprintf("%s '%s'", hookCommand, strings.Join(args, "' '")), "white+b")
+	if hook.Background != nil && *hook.Background {
+		log.Infof("Execute hook in background: %s", commandString)
+		go func() {
 			err := cmd.Run(writer, writer, nil)
 			if err != nil {
-				return fmt.Errorf("Error executing hook: %v", err)
+				log.Warnf("Error executing background hook %s: %v", hookCommand, err)
 			}
-		}
+		}()
+
+		return nil
+	}
+
+	log.Infof("Execute hook: %s", commandString)
+	err = cmd.Run(writer, writer, nil)
+	if err != nil {
+		return fmt.Errorf("Error executing hook: %v", err)
 	}
 
 	return nil
@@ -1,11 +1,17 @@
 package hook
 
 import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
 	"testing"
 	
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/sirupsen/logrus"
 )
 
 func TestHookWithoutExecution(t *testing.T) {
@@ -86,3 +92,91 @@ func TestHookWithExecution(t *testing.T){
 	}
 
 }
+
+func TestHookWithSummary(t *testing.T) {
+	buff := &bytes.Buffer{}
+	summary := &Summary{
+		Images: []*ImageSummary{
+			{Name: "default", Image: "user/backend", Tag: "abc123", Built: true},
+		},
+		Deployments: []*DeploymentSummary{
+			{Name: "backend", Type: "helm", Namespace: "default", Revision: 2},
+		},
+	}
+
+	err := ExecuteWithSummary(&latest.Config{
+		Hooks: &[]*latest.HookConfig{
+			&latest.HookConfig{
+				When: &latest.HookWhenConfig{
+					After: &latest.HookWhenAtConfig{
+						Deployments: ptr.String(All),
+					},
+				},
+				Command: ptr.String("sh"),
+				Args:    &[]*string{ptr.String("-c"), ptr.String("cat \"$" + SummaryEnv + "\"")},
+			},
+		},
+	}, After, StageDeployments, All, summary, log.NewStreamLogger(buff, logrus.InfoLevel))
+	if err != nil {
+		t.Fatalf("Failed to execute hook with summary: %v", err)
+	}
+
+	output := buff.String()
+	parsed := &Summary{}
+	err = json.Unmarshal([]byte(output[strings.Index(output, "{"):]), parsed)
+	if err != nil {
+		t.Fatalf("Hook didn't receive valid summary json: %v\n%s", err, output)
+	}
+	if len(parsed.Images) != 1 || parsed.Images[0].Tag != "abc123" || len(parsed.Deployments) != 1 || parsed.Deployments[0].Revision != 2 {
+		t.Fatalf("Unexpected summary received by hook: %s", output)
+	}
+}
+
+func TestExecuteEvent(t *testing.T) {
+	buff := &bytes.Buffer{}
+	generatedConfig := &generated.Config{
+		ActiveConfig: "default",
+		Configs: map[string]*generated.CacheConfig{
+			"default": &generated.CacheConfig{
+				Vars: map[string]string{"name": "world"},
+			},
+		},
+	}
+
+	otherOS := "windows"
+	if runtime.GOOS == "windows" {
+		otherOS = "linux"
+	}
+
+	err := ExecuteEvent(&latest.Config{
+		Hooks: &[]*latest.HookConfig{
+			&latest.HookConfig{
+				Command: ptr.String("sh"),
+				Args:    &[]*string{ptr.String("-c"), ptr.String("echo \"before deploy $DEVSPACE_VAR_NAME\"")},
+				Events:  &[]*string{ptr.String("before:dev"), ptr.String("before:deploy")},
+			},
+			&latest.HookConfig{
+				Command: ptr.String("echo"),
+				Args:    &[]*string{ptr.String("after deploy")},
+				Events:  &[]*string{ptr.String("after:deploy")},
+			},
+			&latest.HookConfig{
+				Command: ptr.String("echo"),
+				Args:    &[]*string{ptr.String("other os")},
+				Events:  &[]*string{ptr.String("before:deploy")},
+				OS:      &[]*string{ptr.String(otherOS)},
+			},
+		},
+	}, generatedConfig, Before, "deploy", log.NewStreamLogger(buff, logrus.InfoLevel))
+	if err != nil {
+		t.Fatalf("Failed to execute event hooks: %v", err)
+	}
+
+	output := buff.String()
+	if strings.Contains(output, "before deploy world") == false {
+		t.Fatalf("Expected before:deploy hook to be executed with config vars, got: %s", output)
+	}
+	if strings.Contains(output, "after deploy") || strings.Contains(output, "other os") {
+		t.Fatalf("Unexpected hook executed: %s", output)
+	}
+}
@@ -0,0 +1,56 @@
+package hook
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// SummaryEnv is the name of the environment variable that holds the path to the summary file for after deployment hooks
+const SummaryEnv = "DEVSPACE_DEPLOY_SUMMARY"
+
+// Summary describes the images and deployments of a deploy run and is passed to after deployment hooks as json
+type Summary struct {
+	Images      []*ImageSummary      `json:"images"`
+	Deployments []*DeploymentSummary `json:"deployments"`
+}
+
+// ImageSummary describes a single image
+type ImageSummary struct {
+	Name  string `json:"name"`
+	Image string `json:"image"`
+	Tag   string `json:"tag"`
+	Built bool   `json:"built"`
+}
+
+// DeploymentSummary describes a single applied deployment
+type DeploymentSummary struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Namespace string `json:"namespace"`
+	Revision  int32  `json:"revision,omitempty"`
+}
+
+// writeSummary writes the summary into a temporary file and returns its path
+func writeSummary(summary *Summary) (string, error) {
+	out, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return "", errors.Wrap(err, "marshal summary")
+	}
+
+	file, err := ioutil.TempFile("", "devspace-summary-*.json")
+	if err != nil {
+		return "", errors.Wrap(err, "create summary file")
+	}
+	defer file.Close()
+
+	_, err = file.Write(out)
+	if err != nil {
+		os.Remove(file.Name())
+		return "", errors.Wrap(err, "write summary file")
+	}
+
+	return file.Name(), nil
+}
@@ -0,0 +1,277 @@
+package kubectl
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/client-go/kubernetes"
+)
+
+// The api group versions devspace uses for the resources it creates or reads, newest first
+const (
+	AppsV1GroupVersion            = "apps/v1"
+	ExtensionsV1beta1GroupVersion = "extensions/v1beta1"
+	NetworkingV1beta1GroupVersion = "networking.k8s.io/v1beta1"
+)
+
+// Capabilities holds the api group versions the cluster serves for the resources devspace creates or reads. A group
+// version is empty if the cluster serves the resource in none of the versions devspace supports
+type Capabilities struct {
+	ServerVersion string
+
+	// KubeVersion is the version of the api server or nil if it could not be retrieved
+	KubeVersion *version.Info
+
+	// APIVersions are all group versions the cluster serves, e.g. v1 and apps/v1. Charts that are rendered locally
+	// check them with .Capabilities.APIVersions
+	APIVersions []string
+
+	// DeploymentGroupVersion is apps/v1 or extensions/v1beta1 on clusters older than 1.9
+	DeploymentGroupVersion string
+
+	// IngressGroupVersion is networking.k8s.io/v1beta1 or extensions/v1beta1 on clusters older than 1.14
+	IngressGroupVersion string
+}
+
+// GetDeploymentGroupVersion returns the group version for deployments or an error if the cluster is not supported
+func (c *Capabilities) GetDeploymentGroupVersion() (string, error) {
+	return c.require("deployments", c.DeploymentGroupVersion, AppsV1GroupVersion, ExtensionsV1beta1GroupVersion)
+}
+
+// GetIngressGroupVersion returns the group version for ingresses or an error if the cluster is not supported
+func (c *Capabilities) GetIngressGroupVersion() (string, error) {
+	return c.require("ingresses", c.IngressGroupVersion, NetworkingV1beta1GroupVersion, ExtensionsV1beta1GroupVersion)
+}
+
+func (c *Capabilities) require(resource, groupVersion string, supported ...string) (string, error) {
+	if groupVersion == "" {
+		return "", &UnsupportedClusterError{
+			ServerVersion: c.ServerVersion,
+			Resource:      resource,
+			GroupVersions: supported,
+		}
+	}
+
+	return groupVersion, nil
+}
+
+// UnsupportedClusterError is returned if the cluster serves a resource in none of the api versions devspace supports
+type UnsupportedClusterError struct {
+	ServerVersion string
+	Resource      string
+	GroupVersions []string
+}
+
+func (e *UnsupportedClusterError) Error() string {
+	return fmt.Sprintf("Kubernetes %s is not supported by this version of DevSpace CLI: the cluster serves %s in none of the api versions %s", e.ServerVersion, e.Resource, strings.Join(e.GroupVersions, ", "))
+}
+
+// IsUnsupportedClusterError returns true if the error is an UnsupportedClusterError
+func IsUnsupportedClusterError(err error) bool {
+	_, ok := err.(*UnsupportedClusterError)
+	return ok
+}
+
+var capabilitiesCache = map[kubernetes.Interface]*Capabilities{}
+var capabilitiesCacheMutex sync.Mutex
+
+// GetCapabilities discovers which api group versions the cluster serves. The result is cached per client. If the
+// cluster does not report any api groups, the current group versions are assumed
+func GetCapabilities(client kubernetes.Interface) (*Capabilities, error) {
+	capabilitiesCacheMutex.Lock()
+	defer capabilitiesCacheMutex.Unlock()
+
+	if capabilities, ok := capabilitiesCache[client]; ok {
+		return capabilities, nil
+	}
+
+	capabilities := &Capabilities{
+		ServerVersion: "unknown",
+	}
+
+	serverVersion, err := client.Discovery().ServerVersion()
+	if err == nil {
+		capabilities.ServerVersion = serverVersion.GitVersion
+		capabilities.KubeVersion = serverVersion
+	}
+
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("Error discovering the api groups of the cluster: %v", err)
+	}
+	discoverable := len(groups.Groups) > 0
+	for _, group := range groups.Groups {
+		for _, groupVersion := range group.Versions {
+			capabilities.APIVersions = append(capabilities.APIVersions, groupVersion.GroupVersion)
+		}
+	}
+	sort.Strings(capabilities.APIVersions)
+
+	capabilities.DeploymentGroupVersion = selectGroupVersion(client, discoverable, "deployments", AppsV1GroupVersion, ExtensionsV1beta1GroupVersion)
+	capabilities.IngressGroupVersion = selectGroupVersion(client, discoverable, "ingresses", NetworkingV1beta1GroupVersion, ExtensionsV1beta1GroupVersion)
+
+	capabilitiesCache[client] = capabilities
+	return capabilities, nil
+}
+
+// selectGroupVersion returns the first group version that serves the resource. The first group version is the
+// fallback that is used if the cluster api groups cannot be discovered
+func selectGroupVersion(client kubernetes.Interface, discoverable bool, resource string, groupVersions ...string) string {
+	for _, groupVersion := range groupVersions {
+		resourceList, err := client.Discovery().ServerResourcesForGroupVersion(groupVersion)
+		if err == nil && ResourceExist(groupVersion, resource, []*metav1.APIResourceList{resourceList}) {
+			return groupVersion
+		}
+	}
+
+	if discoverable == false {
+		return groupVersions[0]
+	}
+
+	return ""
+}
+
+// getDeploymentGroupVersion returns the group version the cluster serves deployments in
+func getDeploymentGroupVersion(client kubernetes.Interface) (string, error) {
+	capabilities, err := GetCapabilities(client)
+	if err != nil {
+		return "", err
+	}
+
+	return capabilities.GetDeploymentGroupVersion()
+}
+
+// GetDeployment returns the deployment with the api group version the cluster supports
+func GetDeployment(client kubernetes.Interface, namespace, name string) (*appsv1.Deployment, error) {
+	groupVersion, err := getDeploymentGroupVersion(client)
+	if err != nil {
+		return nil, err
+	}
+	if groupVersion == AppsV1GroupVersion {
+		return client.AppsV1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	}
+
+	legacyDeployment, err := client.ExtensionsV1beta1().Deployments(namespace).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	return deployment, ConvertObject(legacyDeployment, deployment)
+}
+
+// CreateDeployment creates the deployment with the api group version the cluster supports
+func CreateDeployment(client kubernetes.Interface, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	groupVersion, err := getDeploymentGroupVersion(client)
+	if err != nil {
+		return nil, err
+	}
+	if groupVersion == AppsV1GroupVersion {
+		return client.AppsV1().Deployments(deployment.Namespace).Create(deployment)
+	}
+
+	legacyDeployment := &extensionsv1beta1.Deployment{}
+	err = ConvertObject(deployment, legacyDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyDeployment, err = client.ExtensionsV1beta1().Deployments(deployment.Namespace).Create(legacyDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &appsv1.Deployment{}
+	return created, ConvertObject(legacyDeployment, created)
+}
+
+// UpdateDeployment updates the deployment with the api group version the cluster supports
+func UpdateDeployment(client kubernetes.Interface, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	groupVersion, err := getDeploymentGroupVersion(client)
+	if err != nil {
+		return nil, err
+	}
+	if groupVersion == AppsV1GroupVersion {
+		return client.AppsV1().Deployments(deployment.Namespace).Update(deployment)
+	}
+
+	legacyDeployment := &extensionsv1beta1.Deployment{}
+	err = ConvertObject(deployment, legacyDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyDeployment, err = client.ExtensionsV1beta1().Deployments(deployment.Namespace).Update(legacyDeployment)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := &appsv1.Deployment{}
+	return updated, ConvertObject(legacyDeployment, updated)
+}
+
+// DeleteDeployment deletes the deployment with the api group version the cluster supports
+func DeleteDeployment(client kubernetes.Interface, namespace, name string, options *metav1.DeleteOptions) error {
+	groupVersion, err := getDeploymentGroupVersion(client)
+	if err != nil {
+		return err
+	}
+	if groupVersion == AppsV1GroupVersion {
+		return client.AppsV1().Deployments(namespace).Delete(name, options)
+	}
+
+	return client.ExtensionsV1beta1().Deployments(namespace).Delete(name, options)
+}
+
+// ListIngresses lists the ingresses in the namespace with the api group version the cluster supports
+func ListIngresses(client kubernetes.Interface, namespace string) (*networkingv1beta1.IngressList, error) {
+	capabilities, err := GetCapabilities(client)
+	if err != nil {
+		return nil, err
+	}
+
+	groupVersion, err := capabilities.GetIngressGroupVersion()
+	if err != nil {
+		return nil, err
+	}
+	if groupVersion == NetworkingV1beta1GroupVersion {
+		return client.NetworkingV1beta1().Ingresses(namespace).List(metav1.ListOptions{})
+	}
+
+	legacyIngresses, err := client.ExtensionsV1beta1().Ingresses(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ingresses := &networkingv1beta1.IngressList{}
+	return ingresses, ConvertObject(legacyIngresses, ingresses)
+}
+
+// ConvertObject converts an object to the same kind in another api group version. The legacy and current versions of
+// deployments and ingresses share the same fields, only the defaults differ
+func ConvertObject(from interface{}, to interface{}) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, to)
+	if err != nil {
+		return err
+	}
+
+	if object, ok := to.(interface{ GetObjectKind() schema.ObjectKind }); ok {
+		object.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+	}
+
+	return nil
+}
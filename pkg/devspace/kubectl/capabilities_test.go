@@ -0,0 +1,85 @@
+package kubectl
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newCapabilitiesTestClient(resources ...*metav1.APIResourceList) *fake.Clientset {
+	client := fake.NewSimpleClientset()
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = resources
+	return client
+}
+
+func TestGetCapabilities(t *testing.T) {
+	// Current cluster
+	client := newCapabilitiesTestClient(
+		&metav1.APIResourceList{GroupVersion: AppsV1GroupVersion, APIResources: []metav1.APIResource{{Name: "deployments"}}},
+		&metav1.APIResourceList{GroupVersion: NetworkingV1beta1GroupVersion, APIResources: []metav1.APIResource{{Name: "ingresses"}}},
+	)
+	capabilities, err := GetCapabilities(client)
+	assert.NilError(t, err)
+	assert.Equal(t, capabilities.DeploymentGroupVersion, AppsV1GroupVersion)
+	assert.Equal(t, capabilities.IngressGroupVersion, NetworkingV1beta1GroupVersion)
+	assert.DeepEqual(t, capabilities.APIVersions, []string{AppsV1GroupVersion, NetworkingV1beta1GroupVersion})
+
+	// Legacy cluster
+	client = newCapabilitiesTestClient(
+		&metav1.APIResourceList{GroupVersion: ExtensionsV1beta1GroupVersion, APIResources: []metav1.APIResource{{Name: "deployments"}, {Name: "ingresses"}}},
+	)
+	capabilities, err = GetCapabilities(client)
+	assert.NilError(t, err)
+	assert.Equal(t, capabilities.DeploymentGroupVersion, ExtensionsV1beta1GroupVersion)
+	assert.Equal(t, capabilities.IngressGroupVersion, ExtensionsV1beta1GroupVersion)
+
+	// Cluster without supported ingresses
+	client = newCapabilitiesTestClient(
+		&metav1.APIResourceList{GroupVersion: AppsV1GroupVersion, APIResources: []metav1.APIResource{{Name: "deployments"}}},
+	)
+	capabilities, err = GetCapabilities(client)
+	assert.NilError(t, err)
+	_, err = capabilities.GetIngressGroupVersion()
+	assert.Assert(t, IsUnsupportedClusterError(err), "Expected unsupported cluster error, got %v", err)
+	_, err = ListIngresses(client, "test")
+	assert.Assert(t, IsUnsupportedClusterError(err), "Expected unsupported cluster error, got %v", err)
+
+	// Cluster without discovery information
+	capabilities, err = GetCapabilities(fake.NewSimpleClientset())
+	assert.NilError(t, err)
+	assert.Equal(t, capabilities.DeploymentGroupVersion, AppsV1GroupVersion)
+	assert.Equal(t, capabilities.IngressGroupVersion, NetworkingV1beta1GroupVersion)
+}
+
+func TestGetDeploymentLegacy(t *testing.T) {
+	replicas := int32(2)
+	client := newCapabilitiesTestClient(
+		&metav1.APIResourceList{GroupVersion: ExtensionsV1beta1GroupVersion, APIResources: []metav1.APIResource{{Name: "deployments"}}},
+	)
+	_, err := client.ExtensionsV1beta1().Deployments("test").Create(&extensionsv1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "legacy", Namespace: "test"},
+		Spec:       extensionsv1beta1.DeploymentSpec{Replicas: &replicas},
+	})
+	assert.NilError(t, err)
+
+	deployment, err := GetDeployment(client, "test", "legacy")
+	assert.NilError(t, err)
+	assert.Equal(t, deployment.Name, "legacy")
+	assert.Equal(t, *deployment.Spec.Replicas, replicas)
+
+	deployment.Spec.Replicas = nil
+	_, err = UpdateDeployment(client, deployment)
+	assert.NilError(t, err)
+	legacyDeployment, err := client.ExtensionsV1beta1().Deployments("test").Get("legacy", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Assert(t, legacyDeployment.Spec.Replicas == nil)
+
+	err = DeleteDeployment(client, "test", "legacy", &metav1.DeleteOptions{})
+	assert.NilError(t, err)
+	_, err = client.ExtensionsV1beta1().Deployments("test").Get("legacy", metav1.GetOptions{})
+	assert.Assert(t, err != nil, "deployment was not deleted")
+}
@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
 	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
@@ -20,12 +21,12 @@ import (
 func NewClient(devSpaceConfig *latest.Config) (kubernetes.Interface, error) {
 	config, err := loadClientConfig(devSpaceConfig, false)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError(err)
 	}
 
-	restConfig, err := config.ClientConfig()
+	restConfig, err := newRestConfig(config)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError(err)
 	}
 
 	return kubernetes.NewForConfig(restConfig)
@@ -35,12 +36,12 @@ func NewClient(devSpaceConfig *latest.Config) (kubernetes.Interface, error) {
 func NewClientWithContextSwitch(devSpaceConfig *latest.Config, switchContext bool) (kubernetes.Interface, error) {
 	config, err := loadClientConfig(devSpaceConfig, switchContext)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError(err)
 	}
 
-	restConfig, err := config.ClientConfig()
+	restConfig, err := newRestConfig(config)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError(err)
 	}
 
 	return kubernetes.NewForConfig(restConfig)
@@ -106,25 +107,62 @@ func GetRestConfigBySelect(allowPrivate bool, switchContext bool) (*rest.Config,
 func GetRestConfigFromContext(context string) (*rest.Config, error) {
 	clientConfig, err := kubeconfig.LoadConfigFromContext(context)
 	if err != nil {
-		return nil, err
+		return nil, ClassifyError(err)
 	}
 
-	return clientConfig.ClientConfig()
+	setCurrentKubeContext(context)
+	restConfig, err := newRestConfig(clientConfig)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return restConfig, nil
 }
 
 // GetRestConfig loads the rest configuration for kubernetes clients and parses it to *rest.Config
 func GetRestConfig(config *latest.Config) (*rest.Config, error) {
 	clientConfig, err := loadClientConfig(config, false)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	restConfig, err := newRestConfig(clientConfig)
+	if err != nil {
+		return nil, ClassifyError(err)
+	}
+
+	return restConfig, nil
+}
+
+// newRestConfig creates the rest config and applies the api request limits of the global settings. Requests that
+// fail before they reach the api server return a ClusterError that explains the problem
+func newRestConfig(config clientcmd.ClientConfig) (*rest.Config, error) {
+	restConfig, err := config.ClientConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	return clientConfig.ClientConfig()
+	restConfig.Wrap(newClassifyingRoundTripper)
+
+	limits := settings.GetLimits()
+	if limits.APIQPS > 0 {
+		restConfig.QPS = float32(limits.APIQPS)
+	}
+	if limits.APIBurst > 0 {
+		restConfig.Burst = limits.APIBurst
+	}
+
+	return restConfig, nil
 }
 
 func loadClientConfig(config *latest.Config, switchContext bool) (clientcmd.ClientConfig, error) {
 	if config == nil {
-		return kubeconfig.LoadConfig(), nil
+		clientConfig := kubeconfig.LoadConfig()
+		if rawConfig, err := clientConfig.RawConfig(); err == nil {
+			setCurrentKubeContext(rawConfig.CurrentContext)
+		}
+
+		return clientConfig, nil
 	}
 
 	// Load raw config
@@ -152,6 +190,8 @@ func loadClientConfig(config *latest.Config, switchContext bool) (clientcmd.Clie
 		return nil, fmt.Errorf("Error loading kube config, context '%s' doesn't exist", activeContext)
 	}
 
+	setCurrentKubeContext(activeContext)
+
 	// Change context namespace
 	if config.Cluster != nil && config.Cluster.Namespace != nil {
 		kubeConfig.Contexts[activeContext].Namespace = *config.Cluster.Namespace
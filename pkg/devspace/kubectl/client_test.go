@@ -137,6 +137,162 @@ func TestGetNewestRunningPod(t *testing.T) {
 	}
 }
 
+func TestGetNewestRunningPodPrefersReady(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	err := createTestResources(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A newer pod that is running, but not ready
+	notReadyPod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "not-ready-pod",
+			Labels:            map[string]string{"app.kubernetes.io/name": "devspace-app"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: v1.ConditionFalse},
+			},
+			ContainerStatuses: []v1.ContainerStatus{
+				{
+					Name:  "test",
+					State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				},
+			},
+		},
+	}
+	_, err = client.CoreV1().Pods(configutil.TestNamespace).Create(notReadyPod)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod, err := getNewestRunningPod(client, "app.kubernetes.io/name=devspace-app", configutil.TestNamespace, time.Minute, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod == nil || pod.Name != "test-pod" {
+		t.Fatalf("Expected ready pod test-pod, got %v", pod)
+	}
+
+	// Fall back to the pod that is not ready
+	pod, err = getNewestRunningPod(client, "app.kubernetes.io/name=devspace-app", configutil.TestNamespace, time.Minute, 0, func(pod *v1.Pod) bool {
+		return pod.Name == "not-ready-pod"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod == nil || pod.Name != "not-ready-pod" {
+		t.Fatalf("Expected pod not-ready-pod, got %v", pod)
+	}
+
+	// Wait for the newest pod to become ready within the readiness timeout
+	go func() {
+		time.Sleep(time.Second * 2)
+
+		readyPod := notReadyPod.DeepCopy()
+		readyPod.Status.Conditions[0].Status = v1.ConditionTrue
+		client.CoreV1().Pods(configutil.TestNamespace).UpdateStatus(readyPod)
+	}()
+
+	pod, err = getNewestRunningPod(client, "app.kubernetes.io/name=devspace-app", configutil.TestNamespace, time.Minute, time.Second*20, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod == nil || pod.Name != "not-ready-pod" {
+		t.Fatalf("Expected pod not-ready-pod after it became ready, got %v", pod)
+	}
+}
+
+func TestGetNewestRunningPodReadinessTimeout(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	err := createTestResources(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A newer pod that never becomes ready
+	_, err = client.CoreV1().Pods(configutil.TestNamespace).Create(&v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "not-ready-pod",
+			Labels:            map[string]string{"app.kubernetes.io/name": "devspace-app"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(time.Hour)),
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: v1.ConditionFalse},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fall back to the older ready pod after the readiness timeout
+	pod, err := getNewestRunningPod(client, "app.kubernetes.io/name=devspace-app", configutil.TestNamespace, time.Minute, time.Second*3, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod == nil || pod.Name != "test-pod" {
+		t.Fatalf("Expected ready pod test-pod after the readiness timeout, got %v", pod)
+	}
+}
+
+func TestGetNewestRunningPodWithImage(t *testing.T) {
+	config := createTestConfig()
+
+	// Create the fake client.
+	client := fake.NewSimpleClientset()
+	err := createTestResources(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pod, containerName, err := GetNewestRunningPodWithImage(config, client, "", "docker.io/library/nginx", configutil.TestNamespace, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pod.Name != "test-pod" || containerName != "test" {
+		t.Fatalf("Returned pod or container is wrong: %s %s", pod.Name, containerName)
+	}
+
+	_, _, err = GetNewestRunningPodWithImage(config, client, "", "nginx:1.17", configutil.TestNamespace, time.Second*2)
+	if err == nil {
+		t.Fatal("Expected error for image with another tag")
+	}
+}
+
+func TestGetContainerWithImage(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "sidecar", Image: "envoyproxy/envoy:v1.11.0"},
+				{Name: "app", Image: "localhost:5000/myorg/app:abc"},
+			},
+		},
+	}
+
+	testCases := map[string]string{
+		"localhost:5000/myorg/app:abc": "app",
+		"localhost:5000/myorg/app":     "app",
+		"localhost:5000/myorg/app:def": "",
+		"docker.io/envoyproxy/envoy":   "sidecar",
+		"myorg/app":                    "",
+	}
+
+	for image, expected := range testCases {
+		container := GetContainerWithImage(pod, image)
+		if expected == "" && container != nil {
+			t.Fatalf("Unexpected container %s for image %s", container.Name, image)
+		} else if expected != "" && (container == nil || container.Name != expected) {
+			t.Fatalf("Expected container %s for image %s, got %v", expected, image, container)
+		}
+	}
+}
+
 func TestLogs(t *testing.T) {
 	// Create the fake client.
 	client := fake.NewSimpleClientset()
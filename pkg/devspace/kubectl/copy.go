@@ -3,6 +3,7 @@ package kubectl
 import (
 	"archive/tar"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -17,7 +18,7 @@ import (
 
 // CopyFromReader extracts a tar from the reader to a container path
 func CopyFromReader(restConfig *rest.Config, pod *k8sv1.Pod, container, containerPath string, reader io.Reader) error {
-	_, stderr, err := ExecBuffered(restConfig, pod, container, []string{"tar", "xzp", "-C", containerPath + "/."}, reader)
+	_, stderr, err := ExecBuffered(context.Background(), restConfig, pod, container, []string{"tar", "xzp", "-C", containerPath + "/."}, reader)
 	if err != nil {
 		if stderr != nil {
 			return fmt.Errorf("Error executing tar: %s: %v", string(stderr), err)
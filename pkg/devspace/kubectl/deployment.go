@@ -0,0 +1,95 @@
+package kubectl
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ConfigWithKubeContext returns a copy of the config that uses the given kube context
+func ConfigWithKubeContext(config *latest.Config, kubeContext string) *latest.Config {
+	contextConfig := *config
+	contextConfig.Cluster = &latest.Cluster{
+		KubeContext: &kubeContext,
+	}
+	if config.Cluster != nil {
+		contextConfig.Cluster.Namespace = config.Cluster.Namespace
+	}
+
+	return &contextConfig
+}
+
+// GetDeploymentKubeContexts returns the kube contexts of all deployments that are deployed to another kube context
+// than the one of the config
+func GetDeploymentKubeContexts(config *latest.Config) []string {
+	if config.Deployments == nil {
+		return []string{}
+	}
+
+	kubeContexts := []string{}
+	seen := map[string]bool{}
+	for _, deployConfig := range *config.Deployments {
+		if hasOwnKubeContext(config, deployConfig) && seen[*deployConfig.KubeContext] == false {
+			seen[*deployConfig.KubeContext] = true
+			kubeContexts = append(kubeContexts, *deployConfig.KubeContext)
+		}
+	}
+
+	sort.Strings(kubeContexts)
+	return kubeContexts
+}
+
+func hasOwnKubeContext(config *latest.Config, deployConfig *latest.DeploymentConfig) bool {
+	if deployConfig.KubeContext == nil || *deployConfig.KubeContext == "" {
+		return false
+	}
+
+	return config.Cluster == nil || config.Cluster.KubeContext == nil || *config.Cluster.KubeContext != *deployConfig.KubeContext
+}
+
+// DeploymentClients resolves the config and kubernetes client a deployment is deployed with. Deployments without
+// an own kube context use the default client, deployments with the same kube context share a client
+type DeploymentClients struct {
+	config *latest.Config
+	client kubernetes.Interface
+
+	clientsMutex sync.Mutex
+	clients      map[string]kubernetes.Interface
+}
+
+// NewDeploymentClients creates new deployment clients with the default config and client
+func NewDeploymentClients(config *latest.Config, client kubernetes.Interface) *DeploymentClients {
+	return &DeploymentClients{
+		config:  config,
+		client:  client,
+		clients: map[string]kubernetes.Interface{},
+	}
+}
+
+// Get returns the config and client for the deployment. If the deployment has an own kube context, the returned
+// config is a copy of the default config that uses this context
+func (d *DeploymentClients) Get(deployConfig *latest.DeploymentConfig) (*latest.Config, kubernetes.Interface, error) {
+	if hasOwnKubeContext(d.config, deployConfig) == false {
+		return d.config, d.client, nil
+	}
+
+	d.clientsMutex.Lock()
+	defer d.clientsMutex.Unlock()
+
+	kubeContext := *deployConfig.KubeContext
+	contextConfig := ConfigWithKubeContext(d.config, kubeContext)
+	if client, ok := d.clients[kubeContext]; ok {
+		return contextConfig, client, nil
+	}
+
+	client, err := NewClient(contextConfig)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "create client for kube context %s", kubeContext)
+	}
+
+	d.clients[kubeContext] = client
+	return contextConfig, client, nil
+}
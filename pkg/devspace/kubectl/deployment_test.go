@@ -0,0 +1,49 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+)
+
+func TestConfigWithKubeContext(t *testing.T) {
+	config := &latest.Config{
+		Cluster: &latest.Cluster{
+			KubeContext: ptr.String("default"),
+			Namespace:   ptr.String("my-namespace"),
+		},
+	}
+
+	contextConfig := ConfigWithKubeContext(config, "other")
+	assert.Equal(t, *contextConfig.Cluster.KubeContext, "other")
+	assert.Equal(t, *contextConfig.Cluster.Namespace, "my-namespace")
+	assert.Equal(t, *config.Cluster.KubeContext, "default")
+
+	contextConfig = ConfigWithKubeContext(&latest.Config{}, "other")
+	assert.Equal(t, *contextConfig.Cluster.KubeContext, "other")
+	assert.Assert(t, contextConfig.Cluster.Namespace == nil)
+}
+
+func TestGetDeploymentKubeContexts(t *testing.T) {
+	config := &latest.Config{
+		Cluster: &latest.Cluster{
+			KubeContext: ptr.String("default"),
+		},
+		Deployments: &[]*latest.DeploymentConfig{
+			{Name: ptr.String("a")},
+			{Name: ptr.String("b"), KubeContext: ptr.String("default")},
+			{Name: ptr.String("c"), KubeContext: ptr.String("second")},
+			{Name: ptr.String("d"), KubeContext: ptr.String("first")},
+			{Name: ptr.String("e"), KubeContext: ptr.String("second")},
+		},
+	}
+
+	assert.DeepEqual(t, GetDeploymentKubeContexts(config), []string{"first", "second"})
+	assert.DeepEqual(t, GetDeploymentKubeContexts(&latest.Config{}), []string{})
+
+	deploymentConfig, _, err := NewDeploymentClients(config, nil).Get((*config.Deployments)[1])
+	assert.NilError(t, err)
+	assert.Assert(t, deploymentConfig == config)
+}
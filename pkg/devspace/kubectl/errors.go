@@ -0,0 +1,208 @@
+package kubectl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+var (
+	currentKubeContext      string
+	currentKubeContextMutex sync.Mutex
+)
+
+// ClusterError is a kubernetes client error that was extended by a message that explains the problem and
+// suggests how to fix it
+type ClusterError struct {
+	Reason     string
+	Suggestion string
+
+	Err error
+}
+
+// Error implements the error interface
+func (c *ClusterError) Error() string {
+	return fmt.Sprintf("%v\n\n%s. %s", c.Err, c.Reason, c.Suggestion)
+}
+
+// Cause returns the original client error
+func (c *ClusterError) Cause() error {
+	return c.Err
+}
+
+// ClassifyError translates common kubernetes client errors (expired certificates, unknown certificate authorities,
+// unreachable api servers, rejected credentials, missing permissions and missing kube contexts) into a ClusterError.
+// All other errors are returned unchanged. Clients created with NewClient classify the errors of loading the kube
+// config and of all api requests themselves
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*ClusterError); ok {
+		return err
+	}
+
+	clusterErr := classifyError(err)
+	if clusterErr == nil || strings.Contains(err.Error(), clusterErr.Suggestion) {
+		// Status errors of rejected requests already contain the explanation
+		return err
+	}
+
+	return clusterErr
+}
+
+func classifyError(err error) *ClusterError {
+	kubeContext := getCurrentKubeContext()
+	inContext := "the current kube context"
+	contextFlag := ""
+	if kubeContext != "" {
+		inContext = "kube context " + kubeContext
+		contextFlag = " --context " + kubeContext
+	}
+
+	cause := errors.Cause(err)
+	message := err.Error()
+	switch {
+	case strings.Contains(message, "certificate has expired or is not yet valid"):
+		return &ClusterError{
+			Reason:     fmt.Sprintf("The certificate of %s has expired", inContext),
+			Suggestion: "Renew the credentials in your kube config, e.g. run `devspace use space` again for spaces or download a new kube config from your cloud provider",
+			Err:        err,
+		}
+	case strings.Contains(message, "certificate signed by unknown authority"):
+		return &ClusterError{
+			Reason:     fmt.Sprintf("The api server certificate of %s is signed by an unknown certificate authority", inContext),
+			Suggestion: fmt.Sprintf("Make sure the certificate-authority-data of the cluster matches the api server, you can check it with `kubectl config view --raw --minify%s`", contextFlag),
+			Err:        err,
+		}
+	case kerrors.IsUnauthorized(cause) || strings.Contains(message, "the server has asked for the client to provide credentials") || strings.HasSuffix(message, "Unauthorized"):
+		return &ClusterError{
+			Reason:     fmt.Sprintf("The api server rejected the credentials of %s", inContext),
+			Suggestion: "Your token might have expired. Log in again (e.g. with `devspace login` and `devspace use space` for spaces) or refresh the credentials in your kube config",
+			Err:        err,
+		}
+	case kerrors.IsForbidden(cause) || strings.Contains(message, "is forbidden: User"):
+		return &ClusterError{
+			Reason:     fmt.Sprintf("You are not allowed to perform this action in %s", inContext),
+			Suggestion: fmt.Sprintf("Check your permissions with `kubectl auth can-i --list%s` and ask your cluster admin for a role binding", contextFlag),
+			Err:        err,
+		}
+	case clientcmd.IsContextNotFound(cause) || clientcmd.IsEmptyConfig(cause) || strings.Contains(message, "Error loading kube config"):
+		return &ClusterError{
+			Reason:     fmt.Sprintf("Unable to load %s from your kube config", inContext),
+			Suggestion: "List the available contexts with `kubectl config get-contexts` and select one with `devspace use context`",
+			Err:        err,
+		}
+	case isNetworkError(cause, message):
+		return &ClusterError{
+			Reason:     fmt.Sprintf("Unable to reach the api server of %s", inContext),
+			Suggestion: fmt.Sprintf("Make sure the cluster is running and you are connected to the right network or VPN. You can check the connection with `kubectl cluster-info%s`", contextFlag),
+			Err:        err,
+		}
+	}
+
+	return nil
+}
+
+// classifyingRoundTripper classifies the errors of api requests that did not get a response, e.g. because the api
+// server is unreachable or its certificate expired, and explains rejected credentials and missing permissions in
+// the status of the response
+type classifyingRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func newClassifyingRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &classifyingRoundTripper{rt: rt}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (c *classifyingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := c.rt.RoundTrip(req)
+	if err != nil {
+		return resp, ClassifyError(err)
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		classifyStatusResponse(resp)
+	}
+
+	return resp, nil
+}
+
+// classifyStatusResponse adds the explanation to the message of a rejected request's status. The kubernetes client
+// then returns a StatusError that still has the original reason, so kerrors.IsForbidden and the credential refresh
+// of auth plugins keep working. Responses that don't contain a json status are not changed
+func classifyStatusResponse(resp *http.Response) {
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+
+	status := metav1.Status{}
+	if json.Unmarshal(body, &status) != nil || status.Kind != "Status" {
+		return
+	}
+
+	clusterErr := classifyError(&kerrors.StatusError{ErrStatus: status})
+	if clusterErr == nil {
+		return
+	}
+
+	status.Message = clusterErr.Error()
+	body, err = json.Marshal(&status)
+	if err != nil {
+		return
+	}
+
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+	resp.Header.Del("Content-Length")
+}
+
+// WrappedRoundTripper returns the wrapped round tripper, so that client-go can cancel requests
+func (c *classifyingRoundTripper) WrappedRoundTripper() http.RoundTripper {
+	return c.rt
+}
+
+func isNetworkError(cause error, message string) bool {
+	if _, ok := cause.(net.Error); ok {
+		return true
+	}
+
+	for _, networkError := range []string{"connection refused", "no such host", "i/o timeout", "network is unreachable", "TLS handshake timeout", "no route to host"} {
+		if strings.Contains(message, networkError) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setCurrentKubeContext remembers the kube context of the last created client, so that errors can name it
+func setCurrentKubeContext(kubeContext string) {
+	currentKubeContextMutex.Lock()
+	currentKubeContext = kubeContext
+	currentKubeContextMutex.Unlock()
+
+	audit.SetKubeContext(kubeContext)
+}
+
+func getCurrentKubeContext() string {
+	currentKubeContextMutex.Lock()
+	defer currentKubeContextMutex.Unlock()
+
+	return currentKubeContext
+}
@@ -0,0 +1,137 @@
+package kubectl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	setCurrentKubeContext("my-context")
+	defer setCurrentKubeContext("")
+
+	testCases := map[string]struct {
+		err            error
+		expectedReason string
+	}{
+		"Expired certificate": {
+			err:            errors.New(`Get https://1.2.3.4/api: x509: certificate has expired or is not yet valid`),
+			expectedReason: "The certificate of kube context my-context has expired",
+		},
+		"Unknown authority": {
+			err:            errors.New(`Get https://1.2.3.4/api: x509: certificate signed by unknown authority`),
+			expectedReason: "The api server certificate of kube context my-context is signed by an unknown certificate authority",
+		},
+		"Unauthorized": {
+			err:            kerrors.NewUnauthorized("Unauthorized"),
+			expectedReason: "The api server rejected the credentials of kube context my-context",
+		},
+		"Forbidden": {
+			err:            fmt.Errorf("Unable to create namespace: %v", kerrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test", errors.New(`User "dev" cannot create resource "namespaces"`))),
+			expectedReason: "You are not allowed to perform this action in kube context my-context",
+		},
+		"Missing context": {
+			err:            errors.New("Error loading kube config, context 'my-context' doesn't exist"),
+			expectedReason: "Unable to load kube context my-context from your kube config",
+		},
+		"VPN down": {
+			err:            errors.New(`Get https://10.0.0.1/api: dial tcp 10.0.0.1:443: i/o timeout`),
+			expectedReason: "Unable to reach the api server of kube context my-context",
+		},
+	}
+
+	for name, testCase := range testCases {
+		err := ClassifyError(testCase.err)
+		clusterErr, ok := err.(*ClusterError)
+		assert.Assert(t, ok, "Error was not classified in test case %s: %v", name, err)
+		assert.Equal(t, clusterErr.Reason, testCase.expectedReason, "Wrong reason in test case %s", name)
+		assert.Assert(t, strings.Contains(err.Error(), testCase.err.Error()), "Original error is missing in test case %s", name)
+		assert.Assert(t, ClassifyError(err) == err, "Classified error was classified again in test case %s", name)
+	}
+
+	// Other errors are not changed
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test")
+	assert.Equal(t, ClassifyError(notFound), error(notFound))
+	assert.NilError(t, ClassifyError(nil))
+}
+
+type failingRoundTripper struct {
+	err error
+}
+
+func (f *failingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, f.err
+}
+
+type statusRoundTripper struct {
+	status *metav1.Status
+}
+
+func (s *statusRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := json.Marshal(s.status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: int(s.status.Code),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(body)),
+	}, nil
+}
+
+func TestClassifyingRoundTripper(t *testing.T) {
+	setCurrentKubeContext("my-context")
+	defer setCurrentKubeContext("")
+
+	req, err := http.NewRequest("GET", "https://10.0.0.1/api", nil)
+	assert.NilError(t, err)
+
+	_, err = newClassifyingRoundTripper(&failingRoundTripper{err: errors.New("dial tcp 10.0.0.1:443: i/o timeout")}).RoundTrip(req)
+	clusterErr, ok := err.(*ClusterError)
+	assert.Assert(t, ok, "Request error was not classified: %v", err)
+	assert.Equal(t, clusterErr.Reason, "Unable to reach the api server of kube context my-context")
+
+	// Errors the kubernetes client handles itself are not changed
+	_, err = newClassifyingRoundTripper(&failingRoundTripper{err: errors.New("request canceled")}).RoundTrip(req)
+	assert.Error(t, err, "request canceled")
+
+	// Rejected requests keep their status reason and explain the problem in the message
+	forbidden := kerrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test", errors.New(`User "dev" cannot create resource "namespaces"`))
+	forbidden.ErrStatus.Kind = "Status"
+	resp, err := newClassifyingRoundTripper(&statusRoundTripper{status: &forbidden.ErrStatus}).RoundTrip(req)
+	assert.NilError(t, err)
+
+	status := metav1.Status{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	assert.NilError(t, err)
+	assert.Equal(t, status.Reason, metav1.StatusReasonForbidden)
+	assert.Assert(t, strings.HasPrefix(status.Message, forbidden.ErrStatus.Message), "Original message is missing: %s", status.Message)
+	assert.Assert(t, strings.Contains(status.Message, "You are not allowed to perform this action in kube context my-context"), "Explanation is missing: %s", status.Message)
+
+	// Explained status errors are not classified again
+	statusErr := &kerrors.StatusError{ErrStatus: status}
+	assert.Assert(t, kerrors.IsForbidden(statusErr))
+	assert.Equal(t, ClassifyError(statusErr), error(statusErr))
+
+	// Other responses are not changed
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "test")
+	notFound.ErrStatus.Kind = "Status"
+	resp, err = newClassifyingRoundTripper(&statusRoundTripper{status: &notFound.ErrStatus}).RoundTrip(req)
+	assert.NilError(t, err)
+
+	status = metav1.Status{}
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	assert.NilError(t, err)
+	assert.Equal(t, status.Message, notFound.ErrStatus.Message)
+}
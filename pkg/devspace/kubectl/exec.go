@@ -2,14 +2,14 @@ package kubectl
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
-	"os"
 
 	"github.com/devspace-cloud/devspace/pkg/util/terminal"
-	"github.com/pkg/errors"
 	k8sv1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
@@ -74,69 +74,126 @@ func ExecStreamWithTransport(transport http.RoundTripper, upgrader spdy.Upgrader
 	})
 }
 
-// ExecStream executes a command and streams the output to the given streams
-func ExecStream(restConfig *rest.Config, pod *k8sv1.Pod, container string, command []string, tty bool, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
-	client, err := kubernetes.NewForConfig(restConfig)
-	if err != nil {
-		return err
-	}
+// ExecTerminalWithTransport executes a command with a remote tty. In contrast to ExecStreamWithTransport, stdin does
+// not have to be a terminal, which allows to share a single local terminal between several remote terminals
+func ExecTerminalWithTransport(transport http.RoundTripper, upgrader spdy.Upgrader, client kubernetes.Interface, pod *k8sv1.Pod, container string, command []string, stdin io.Reader, stdout io.Writer, sizeQueue remotecommand.TerminalSizeQueue) error {
+	execRequest := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec")
 
-	wrapper, upgradeRoundTripper, err := spdy.RoundTripperFor(restConfig)
+	execRequest.VersionedParams(&k8sapi.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdin:     true,
+		Stdout:    true,
+		TTY:       true,
+	}, legacyscheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutorForTransports(transport, upgrader, "POST", execRequest.URL())
 	if err != nil {
 		return err
 	}
 
-	return ExecStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container, command, tty, stdin, stdout, stderr)
+	return exec.Stream(remotecommand.StreamOptions{
+		Stdin:             stdin,
+		Stdout:            stdout,
+		Tty:               true,
+		TerminalSizeQueue: sizeQueue,
+	})
+}
+
+// ExitCodeError is returned by ExecBuffered if the command was executed but exited with a non-zero exit code
+type ExitCodeError struct {
+	Code   int
+	Stderr []byte
 }
 
-// ExecBuffered executes a command for kubernetes and returns the output and error buffers
-func ExecBuffered(restConfig *rest.Config, pod *k8sv1.Pod, container string, command []string, input io.Reader) ([]byte, []byte, error) {
-	stdoutOutput, err := ioutil.TempFile("", "")
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "create temp file")
-	}
-	defer os.Remove(stdoutOutput.Name())
+// Error implements the error interface
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("command terminated with exit code %d", e.Code)
+}
+
+// contextRoundTripper dials the exec connection with the given context
+type contextRoundTripper struct {
+	ctx          context.Context
+	roundTripper http.RoundTripper
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (c *contextRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return c.roundTripper.RoundTrip(req.WithContext(c.ctx))
+}
 
-	stderrOutput, err := ioutil.TempFile("", "")
+// contextUpgrader closes the exec connection as soon as the given context is done, which stops the running command
+type contextUpgrader struct {
+	ctx      context.Context
+	upgrader spdy.Upgrader
+}
+
+// NewConnection implements the spdy.Upgrader interface
+func (c *contextUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := c.upgrader.NewConnection(resp)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "create temp file")
+		return nil, err
 	}
-	defer os.Remove(stderrOutput.Name())
 
-	kubeExecError := ExecStream(restConfig, pod, container, command, false, input, stdoutOutput, stderrOutput)
-	if kubeExecError != nil {
-		if _, ok := kubeExecError.(kubectlExec.CodeExitError); ok == false {
-			return nil, nil, kubeExecError
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			conn.Close()
+		case <-conn.CloseChan():
 		}
-	}
+	}()
 
-	stdoutOutput.Close()
-	stderrOutput.Close()
+	return conn, nil
+}
 
-	stdoutBuffer := &bytes.Buffer{}
-	stderrBuffer := &bytes.Buffer{}
+// ExecStream executes a command and streams the output to the given streams
+func ExecStream(restConfig *rest.Config, pod *k8sv1.Pod, container string, command []string, tty bool, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	return ExecStreamWithContext(context.Background(), restConfig, pod, container, command, tty, stdin, stdout, stderr)
+}
 
-	// Open stdout
-	stdoutOutput, err = os.Open(stdoutOutput.Name())
+// ExecStreamWithContext executes a command and streams the output to the given streams. The command is stopped
+// and the context error is returned as soon as the context is done
+func ExecStreamWithContext(ctx context.Context, restConfig *rest.Config, pod *k8sv1.Pod, container string, command []string, tty bool, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+	client, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "open stdout file")
+		return err
 	}
 
-	_, err = stdoutBuffer.ReadFrom(stdoutOutput)
+	wrapper, upgradeRoundTripper, err := spdy.RoundTripperFor(restConfig)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 
-	// Open stderr
-	stderrOutput, err = os.Open(stdoutOutput.Name())
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "open stderr file")
+	err = ExecStreamWithTransport(&contextRoundTripper{ctx: ctx, roundTripper: wrapper}, &contextUpgrader{ctx: ctx, upgrader: upgradeRoundTripper}, client, pod, container, command, tty, stdin, stdout, stderr)
+	if ctx.Err() != nil {
+		return ctx.Err()
 	}
 
-	_, err = stderrBuffer.ReadFrom(stdoutOutput)
+	return err
+}
+
+// ExecBuffered executes a command for kubernetes and returns the output and error buffers. If the command exits
+// with a non-zero exit code, the buffers are returned together with an *ExitCodeError. Use ExecStreamWithContext
+// for commands with large output
+func ExecBuffered(ctx context.Context, restConfig *rest.Config, pod *k8sv1.Pod, container string, command []string, input io.Reader) ([]byte, []byte, error) {
+	stdoutBuffer := &bytes.Buffer{}
+	stderrBuffer := &bytes.Buffer{}
+
+	err := ExecStreamWithContext(ctx, restConfig, pod, container, command, false, input, stdoutBuffer, stderrBuffer)
 	if err != nil {
+		if exitError, ok := err.(kubectlExec.CodeExitError); ok {
+			return stdoutBuffer.Bytes(), stderrBuffer.Bytes(), &ExitCodeError{
+				Code:   exitError.Code,
+				Stderr: stderrBuffer.Bytes(),
+			}
+		}
+
 		return nil, nil, err
 	}
 
-	return stdoutBuffer.Bytes(), stderrBuffer.Bytes(), kubeExecError
+	return stdoutBuffer.Bytes(), stderrBuffer.Bytes(), nil
 }
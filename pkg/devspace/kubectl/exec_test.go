@@ -0,0 +1,57 @@
+package kubectl
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/httpstream"
+)
+
+type fakeConnection struct {
+	httpstream.Connection
+	closeChan chan bool
+}
+
+func (f *fakeConnection) Close() error {
+	close(f.closeChan)
+	return nil
+}
+
+func (f *fakeConnection) CloseChan() <-chan bool {
+	return f.closeChan
+}
+
+type fakeUpgrader struct {
+	conn *fakeConnection
+}
+
+func (f *fakeUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	return f.conn, nil
+}
+
+func TestContextUpgraderClosesConnection(t *testing.T) {
+	conn := &fakeConnection{closeChan: make(chan bool)}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	upgrader := &contextUpgrader{ctx: ctx, upgrader: &fakeUpgrader{conn: conn}}
+	_, err := upgrader.NewConnection(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cancel()
+	select {
+	case <-conn.CloseChan():
+	case <-time.After(time.Second * 5):
+		t.Fatal("Connection was not closed after the context was canceled")
+	}
+}
+
+func TestExitCodeError(t *testing.T) {
+	err := &ExitCodeError{Code: 2, Stderr: []byte("not found")}
+	if err.Error() != "command terminated with exit code 2" {
+		t.Fatalf("Unexpected error message: %s", err.Error())
+	}
+}
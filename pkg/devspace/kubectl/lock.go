@@ -0,0 +1,217 @@
+package kubectl
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/randutil"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DeployLockName is the name of the config map that holds the deploy lock of a namespace
+const DeployLockName = "devspace-deploy-lock"
+
+// Annotations of the deploy lock config map
+const (
+	lockHolderAnnotation  = "devspace.cloud/lock-holder"
+	lockRenewedAnnotation = "devspace.cloud/lock-renewed"
+)
+
+// DeployLockLeaseDuration is the time after which a lock that was not renewed is considered abandoned, e.g. because
+// the process that held it was killed
+var DeployLockLeaseDuration = time.Minute
+
+// deployLockRetryInterval is the interval in which a lock that is held by another process is checked again
+var deployLockRetryInterval = 2 * time.Second
+
+// DeployLock is a lock per namespace that prevents that two processes deploy into the same namespace at the same time
+type DeployLock struct {
+	client    kubernetes.Interface
+	namespace string
+	holder    string
+
+	stopRenew chan bool
+	stopOnce  sync.Once
+}
+
+// AcquireDeployLock waits until the deploy lock of the namespace is free and acquires it. The lock is renewed until
+// it is released. Returns an error if the lock could not be acquired within the timeout
+func AcquireDeployLock(client kubernetes.Interface, namespace string, timeout time.Duration, log log.Logger) (*DeployLock, error) {
+	holder, err := getLockHolder()
+	if err != nil {
+		return nil, err
+	}
+
+	lock := &DeployLock{
+		client:    client,
+		namespace: namespace,
+		holder:    holder,
+		stopRenew: make(chan bool),
+	}
+
+	deadline := time.Now().Add(timeout)
+	waiting := false
+	namespaceCreated := false
+	for {
+		otherHolder, err := lock.tryAcquire()
+		if kerrors.IsNotFound(err) && namespaceCreated == false {
+			// The namespace does not exist yet, so we create it to store the lock in it
+			err = lock.createNamespace(log)
+			if err == nil {
+				namespaceCreated = true
+				continue
+			}
+		}
+		if kerrors.IsForbidden(err) {
+			// The user is not allowed to manage config maps or to create the namespace
+			log.Warnf("Deploying into namespace %s without deploy lock: %v", namespace, err)
+			return lock, nil
+		} else if err != nil {
+			return nil, errors.Wrapf(err, "acquire deploy lock in namespace %s", namespace)
+		} else if otherHolder == "" {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("Timed out waiting for the deploy lock in namespace %s, which is held by %s. If no other deployment is running, delete the config map %s", namespace, otherHolder, DeployLockName)
+		}
+		if waiting == false {
+			log.Infof("Waiting for %s to finish deploying into namespace %s", otherHolder, namespace)
+			waiting = true
+		}
+
+		time.Sleep(deployLockRetryInterval)
+	}
+
+	go lock.renew()
+	return lock, nil
+}
+
+// tryAcquire acquires the lock if it is free or abandoned. Returns the current holder if the lock is held by another process
+func (l *DeployLock) tryAcquire() (string, error) {
+	configMaps := l.client.CoreV1().ConfigMaps(l.namespace)
+	configMap, err := configMaps.Get(DeployLockName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		_, err = configMaps.Create(&k8sv1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        DeployLockName,
+				Annotations: l.annotations(),
+			},
+		})
+		if kerrors.IsAlreadyExists(err) {
+			return "another process", nil
+		}
+
+		return "", err
+	} else if err != nil {
+		return "", err
+	}
+
+	currentHolder := configMap.Annotations[lockHolderAnnotation]
+	renewed, err := time.Parse(time.RFC3339, configMap.Annotations[lockRenewedAnnotation])
+	if currentHolder != l.holder && err == nil && time.Since(renewed) < DeployLockLeaseDuration {
+		return currentHolder, nil
+	}
+
+	// Take over the abandoned lock, the resource version makes sure that only one process takes it over
+	configMap.Annotations = l.annotations()
+	_, err = configMaps.Update(configMap)
+	if kerrors.IsConflict(err) {
+		return "another process", nil
+	}
+
+	return "", err
+}
+
+// createNamespace creates the namespace of the lock. A namespace that was created by another process in the meantime
+// is used as well
+func (l *DeployLock) createNamespace(log log.Logger) error {
+	_, err := l.client.CoreV1().Namespaces().Create(&k8sv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: l.namespace,
+		},
+	})
+	if kerrors.IsAlreadyExists(err) {
+		return nil
+	}
+
+	audit.Record(audit.ActionCreate, "Namespace", "", l.namespace, err)
+	if err != nil {
+		return err
+	}
+
+	log.Donef("Create namespace %s", l.namespace)
+	return nil
+}
+
+// renew renews the lock until it is released
+func (l *DeployLock) renew() {
+	for {
+		select {
+		case <-l.stopRenew:
+			return
+		case <-time.After(DeployLockLeaseDuration / 3):
+			configMap, err := l.client.CoreV1().ConfigMaps(l.namespace).Get(DeployLockName, metav1.GetOptions{})
+			if err != nil || configMap.Annotations[lockHolderAnnotation] != l.holder {
+				continue
+			}
+
+			configMap.Annotations = l.annotations()
+			_, _ = l.client.CoreV1().ConfigMaps(l.namespace).Update(configMap)
+		}
+	}
+}
+
+// Release releases the lock, so other processes can deploy into the namespace
+func (l *DeployLock) Release() error {
+	l.stopOnce.Do(func() { close(l.stopRenew) })
+
+	configMap, err := l.client.CoreV1().ConfigMaps(l.namespace).Get(DeployLockName, metav1.GetOptions{})
+	if kerrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	} else if configMap.Annotations[lockHolderAnnotation] != l.holder {
+		// The lock was taken over by another process
+		return nil
+	}
+
+	err = l.client.CoreV1().ConfigMaps(l.namespace).Delete(DeployLockName, &metav1.DeleteOptions{
+		Preconditions: &metav1.Preconditions{UID: &configMap.UID},
+	})
+	if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+		return nil
+	}
+
+	return err
+}
+
+func (l *DeployLock) annotations() map[string]string {
+	return map[string]string{
+		lockHolderAnnotation:  l.holder,
+		lockRenewedAnnotation: time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+// getLockHolder returns a unique name of the current process, e.g. ci-runner-1 (pid 1234, a1b2c3)
+func getLockHolder() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	id, err := randutil.GenerateRandomString(6)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s (pid %d, %s)", hostname, os.Getpid(), id), nil
+}
@@ -0,0 +1,95 @@
+package kubectl
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestDeployLock(t *testing.T) {
+	deployLockRetryInterval = time.Millisecond * 10
+	client := fake.NewSimpleClientset(&k8sv1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test"},
+	})
+
+	lock, err := AcquireDeployLock(client, "test", time.Second, &log.DiscardLogger{})
+	assert.NilError(t, err)
+
+	// A second process has to wait for the lock
+	_, err = AcquireDeployLock(client, "test", time.Millisecond*50, &log.DiscardLogger{})
+	assert.ErrorContains(t, err, "Timed out waiting for the deploy lock")
+
+	err = lock.Release()
+	assert.NilError(t, err)
+	_, err = client.CoreV1().ConfigMaps("test").Get(DeployLockName, metav1.GetOptions{})
+	assert.Assert(t, err != nil, "deploy lock was not deleted")
+
+	// An abandoned lock is taken over
+	_, err = client.CoreV1().ConfigMaps("test").Create(&k8sv1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: DeployLockName,
+			Annotations: map[string]string{
+				lockHolderAnnotation:  "killed-process",
+				lockRenewedAnnotation: time.Now().Add(-DeployLockLeaseDuration * 2).UTC().Format(time.RFC3339),
+			},
+		},
+	})
+	assert.NilError(t, err)
+
+	lock, err = AcquireDeployLock(client, "test", time.Second, &log.DiscardLogger{})
+	assert.NilError(t, err)
+
+	configMap, err := client.CoreV1().ConfigMaps("test").Get(DeployLockName, metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, configMap.Annotations[lockHolderAnnotation], lock.holder)
+	assert.NilError(t, lock.Release())
+}
+
+func TestDeployLockCreatesNamespace(t *testing.T) {
+	client := fake.NewSimpleClientset()
+
+	// Like the api server, the fake client refuses config maps in namespaces that do not exist
+	client.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		_, err := client.Tracker().Get(k8sv1.SchemeGroupVersion.WithResource("namespaces"), "", action.GetNamespace())
+		if err != nil {
+			return true, nil, kerrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, action.GetNamespace())
+		}
+
+		return false, nil, nil
+	})
+
+	lock, err := AcquireDeployLock(client, "test", time.Second, &log.DiscardLogger{})
+	assert.NilError(t, err)
+
+	_, err = client.CoreV1().Namespaces().Get("test", metav1.GetOptions{})
+	assert.NilError(t, err, "namespace was not created")
+	configMap, err := client.CoreV1().ConfigMaps("test").Get(DeployLockName, metav1.GetOptions{})
+	assert.NilError(t, err, "deploy lock was not created")
+	assert.Equal(t, configMap.Annotations[lockHolderAnnotation], lock.holder)
+	assert.NilError(t, lock.Release())
+}
+
+func TestDeployLockForbidden(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("create", "namespaces", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "test", errors.New(`User "dev" cannot create resource "namespaces"`))
+	})
+	client.PrependReactor("create", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(schema.GroupResource{Resource: "namespaces"}, action.GetNamespace())
+	})
+
+	// Without permission to create the namespace we deploy without lock
+	lock, err := AcquireDeployLock(client, "test", time.Second, &log.DiscardLogger{})
+	assert.NilError(t, err)
+	assert.NilError(t, lock.Release())
+}
@@ -1,9 +1,9 @@
 package kubectl
 
 import (
-	"context"
 	"io/ioutil"
 
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
@@ -26,7 +26,7 @@ func Logs(client kubernetes.Interface, namespace, podName, containerName string,
 		return "", errors.New("Request url is empty")
 	}
 
-	reader, err := request.Context(context.Background()).Stream()
+	reader, err := request.Context(interrupt.Context()).Stream()
 	if err != nil {
 		return "", err
 	}
@@ -9,16 +9,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl/minikube"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/docker/distribution/reference"
 	k8sv1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/api/rbac/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/portforward"
 	"k8s.io/client-go/transport/spdy"
 	"k8s.io/kubernetes/pkg/util/node"
@@ -73,6 +76,7 @@ func EnsureDefaultNamespace(config *latest.Config, client kubernetes.Interface,
 					Name: defaultNamespace,
 				},
 			})
+			audit.Record(audit.ActionCreate, "Namespace", "", defaultNamespace, err)
 		}
 	}
 
@@ -125,6 +129,7 @@ func EnsureGoogleCloudClusterRoleBinding(config *latest.Config, client kubernete
 			}
 
 			_, err = client.RbacV1beta1().ClusterRoleBindings().Create(rolebinding)
+			audit.Record(audit.ActionCreate, "ClusterRoleBinding", "", ClusterRoleBindingName, err)
 			if err != nil {
 				return err
 			}
@@ -145,7 +150,114 @@ func GetNewestRunningPod(config *latest.Config, kubectl kubernetes.Interface, la
 		namespace = defaultNamespace
 	}
 
-	waitingInterval := 1 * time.Second
+	pod, err := getNewestRunningPod(kubectl, labelSelector, namespace, maxWaiting, getReadinessTimeout(config), nil)
+	if err != nil {
+		return nil, err
+	} else if pod == nil {
+		return nil, fmt.Errorf("Waiting for pod with selector %s in namespace %s timed out", labelSelector, namespace)
+	}
+
+	return pod, nil
+}
+
+// GetNewestRunningPodWithImage retrieves the newest running pod with a container that runs the given image and
+// returns the pod and the name of the container. If the image has no tag, containers running any tag of the image match.
+// The label selector is optional
+func GetNewestRunningPodWithImage(config *latest.Config, kubectl kubernetes.Interface, labelSelector, image, namespace string, maxWaiting time.Duration) (*k8sv1.Pod, string, error) {
+	if namespace == "" {
+		defaultNamespace, err := configutil.GetDefaultNamespace(config)
+		if err != nil {
+			return nil, "", err
+		}
+
+		namespace = defaultNamespace
+	}
+
+	pod, err := getNewestRunningPod(kubectl, labelSelector, namespace, maxWaiting, getReadinessTimeout(config), func(pod *k8sv1.Pod) bool {
+		return GetContainerWithImage(pod, image) != nil
+	})
+	if err != nil {
+		return nil, "", err
+	} else if pod == nil {
+		return nil, "", fmt.Errorf("Waiting for pod with image %s in namespace %s timed out", image, namespace)
+	}
+
+	return pod, GetContainerWithImage(pod, image).Name, nil
+}
+
+// GetContainerWithImage returns the first container of the pod that runs the given image or nil if there is none.
+// If the image has no tag, containers running any tag of the image match
+func GetContainerWithImage(pod *k8sv1.Pod, image string) *k8sv1.Container {
+	imageRef, err := reference.ParseNormalizedNamed(strings.TrimSpace(image))
+	if err != nil {
+		return nil
+	}
+	imageTagged, hasTag := imageRef.(reference.Tagged)
+
+	for index, container := range pod.Spec.Containers {
+		containerRef, err := reference.ParseNormalizedNamed(container.Image)
+		if err != nil || containerRef.Name() != imageRef.Name() {
+			continue
+		}
+
+		if hasTag {
+			containerTagged, ok := containerRef.(reference.Tagged)
+			if ok == false || containerTagged.Tag() != imageTagged.Tag() {
+				continue
+			}
+		}
+
+		return &pod.Spec.Containers[index]
+	}
+
+	return nil
+}
+
+// getReadinessTimeout returns how long to wait for a running pod to become ready (dev.readinessTimeout)
+func getReadinessTimeout(config *latest.Config) time.Duration {
+	if config == nil || config.Dev == nil || config.Dev.ReadinessTimeout == nil {
+		return 0
+	}
+
+	return time.Duration(*config.Dev.ReadinessTimeout) * time.Second
+}
+
+// IsPodReady returns true if the pod is running and all of its containers are ready
+func IsPodReady(pod *k8sv1.Pod) bool {
+	if GetPodStatus(pod) != "Running" {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == k8sv1.PodReady {
+			return condition.Status == k8sv1.ConditionTrue
+		}
+	}
+
+	if len(pod.Status.ContainerStatuses) == 0 {
+		return false
+	}
+	for _, containerStatus := range pod.Status.ContainerStatuses {
+		if containerStatus.Ready == false {
+			return false
+		}
+	}
+
+	return true
+}
+
+// getNewestRunningPod waits for the newest pod that matches the label selector and the filter to be ready. If the newest
+// pod does not become ready within the readiness timeout, e.g. during a rolling update, the newest ready pod is returned
+// instead and if there is none, the newest pod is returned as soon as it is running. Returns nil if no pod was running
+// within the maximum waiting time
+func getNewestRunningPod(kubectl kubernetes.Interface, labelSelector, namespace string, maxWaiting, readinessTimeout time.Duration, filter func(pod *k8sv1.Pod) bool) (*k8sv1.Pod, error) {
+	var (
+		waitingInterval = 1 * time.Second
+		newestPod       *k8sv1.Pod
+		newestSince     time.Time
+		fallbackPod     *k8sv1.Pod
+	)
+
 	for maxWaiting > 0 {
 		time.Sleep(waitingInterval)
 
@@ -158,24 +270,64 @@ func GetNewestRunningPod(config *latest.Config, kubectl kubernetes.Interface, la
 
 		if podList.Size() > 0 && len(podList.Items) > 0 {
 			// Get Pod with latest creation timestamp
-			var selectedPod *k8sv1.Pod
+			var selectedPod, readyPod *k8sv1.Pod
 
 			for _, pod := range podList.Items {
 				currentPod := pod
+				if filter != nil && filter(&currentPod) == false {
+					continue
+				}
 
 				if selectedPod == nil || currentPod.CreationTimestamp.Time.After(selectedPod.CreationTimestamp.Time) {
 					selectedPod = &currentPod
 				}
+				if IsPodReady(&currentPod) && (readyPod == nil || currentPod.CreationTimestamp.Time.After(readyPod.CreationTimestamp.Time)) {
+					readyPod = &currentPod
+				}
 			}
 
 			if selectedPod != nil {
+				if IsPodReady(selectedPod) {
+					return selectedPod, nil
+				}
+
+				// Give the newest pod the readiness timeout to become ready
+				if newestPod == nil || newestPod.UID != selectedPod.UID {
+					newestSince = time.Now()
+				}
+				newestPod = selectedPod
+
 				podStatus := GetPodStatus(selectedPod)
+				if podStatus == "Error" || podStatus == "Unknown" || podStatus == "ImagePullBackOff" || podStatus == "CrashLoopBackOff" || podStatus == "RunContainerError" || podStatus == "ErrImagePull" || podStatus == "CreateContainerConfigError" || podStatus == "InvalidImageName" {
+					if readyPod != nil {
+						log.Warnf("Newest pod %s cannot start (Status: %s), using pod %s instead", selectedPod.Name, podStatus, readyPod.Name)
+						return readyPod, nil
+					}
 
-				if podStatus == "Running" {
-					return selectedPod, nil
-				} else if podStatus == "Error" || podStatus == "Unknown" || podStatus == "ImagePullBackOff" || podStatus == "CrashLoopBackOff" || podStatus == "RunContainerError" || podStatus == "ErrImagePull" || podStatus == "CreateContainerConfigError" || podStatus == "InvalidImageName" {
 					return nil, fmt.Errorf("Selected Pod(s) cannot start (Status: %s)", podStatus)
 				}
+
+				if time.Since(newestSince) >= readinessTimeout {
+					if readyPod != nil {
+						if readinessTimeout > 0 {
+							log.Warnf("Pod %s did not become ready within %s, using pod %s instead", selectedPod.Name, readinessTimeout.String(), readyPod.Name)
+						}
+
+						return readyPod, nil
+					} else if podStatus == "Running" {
+						if readinessTimeout > 0 {
+							log.Warnf("Pod %s did not become ready within %s, using it anyway", selectedPod.Name, readinessTimeout.String())
+						}
+
+						return selectedPod, nil
+					}
+				}
+
+				if readyPod != nil {
+					fallbackPod = readyPod
+				} else if podStatus == "Running" {
+					fallbackPod = selectedPod
+				}
 			}
 		}
 
@@ -183,7 +335,18 @@ func GetNewestRunningPod(config *latest.Config, kubectl kubernetes.Interface, la
 		maxWaiting -= waitingInterval * 2
 	}
 
-	return nil, fmt.Errorf("Waiting for pod with selector %s in namespace %s timed out", labelSelector, namespace)
+	// Fall back to an older ready pod or the running pod that is not ready yet
+	if fallbackPod != nil {
+		if fallbackPod.UID == newestPod.UID {
+			log.Warnf("Pod %s did not become ready in time, using it anyway", fallbackPod.Name)
+		} else {
+			log.Warnf("Pod %s did not become ready in time, using pod %s instead", newestPod.Name, fallbackPod.Name)
+		}
+
+		return fallbackPod, nil
+	}
+
+	return nil, nil
 }
 
 // GetPodStatus returns the pod status as a string
@@ -260,9 +423,21 @@ func GetPodStatus(pod *k8sv1.Pod) string {
 	return reason
 }
 
+// GetPodPendingReason returns why the pod is not running yet, e.g. the scheduling message "0/3 nodes are available: 3
+// Insufficient cpu." if the pod cannot be scheduled or the pod status otherwise
+func GetPodPendingReason(pod *k8sv1.Pod) string {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == k8sv1.PodScheduled && condition.Status == k8sv1.ConditionFalse && condition.Message != "" {
+			return condition.Message
+		}
+	}
+
+	return GetPodStatus(pod)
+}
+
 // GetPodsFromDeployment retrieves all found pods from a deployment name
 func GetPodsFromDeployment(kubectl kubernetes.Interface, deployment, namespace string) (*k8sv1.PodList, error) {
-	deploy, err := kubectl.ExtensionsV1beta1().Deployments(namespace).Get(deployment, metav1.GetOptions{})
+	deploy, err := GetDeployment(kubectl, namespace, deployment)
 	// Deployment not there
 	if err != nil {
 		return nil, err
@@ -304,6 +479,11 @@ func NewPortForwarder(devSpaceConfig *latest.Config, kubectlClient kubernetes.In
 		return nil, err
 	}
 
+	return NewPortForwarderWithRestConfig(config, kubectlClient, pod, ports, addresses, stopChan, readyChan)
+}
+
+// NewPortForwarderWithRestConfig creates a new port forwarder object for the specified pods, ports and addresses with the given rest config
+func NewPortForwarderWithRestConfig(config *rest.Config, kubectlClient kubernetes.Interface, pod *k8sv1.Pod, ports []string, addresses []string, stopChan chan struct{}, readyChan chan struct{}) (*portforward.PortForwarder, error) {
 	execRequest := kubectlClient.CoreV1().RESTClient().Post().
 		Resource("pods").
 		Name(pod.Name).
@@ -0,0 +1,237 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/kubeconfig"
+	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+)
+
+const (
+	// StatusSuccess is the status of a command that finished successfully
+	StatusSuccess = "success"
+	// StatusFailure is the status of a command that failed
+	StatusFailure = "failure"
+)
+
+// DefaultMessage is the default message template that is used for notifications
+const DefaultMessage = `devspace {{.Command}} {{if eq .Status "success"}}succeeded{{else}}failed{{end}} for config {{.Config}} in {{.Context}}/{{.Namespace}} after {{.Duration}}{{if .Images}} (images: {{join .Images ", "}}){{end}}{{if .Error}}: {{.Error}}{{end}}`
+
+// sendTimeout is the maximum time to wait for a webhook to respond
+const sendTimeout = 10 * time.Second
+
+// Event is the payload that is sent to the notification webhooks. The text field makes the payload compatible
+// with slack incoming webhooks
+type Event struct {
+	Text      string   `json:"text"`
+	Command   string   `json:"command"`
+	Status    string   `json:"status"`
+	Config    string   `json:"config"`
+	Context   string   `json:"context"`
+	Namespace string   `json:"namespace"`
+	Images    []string `json:"images"`
+	Duration  string   `json:"duration"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// Notifier sends the result of a command to the configured notification webhooks
+type Notifier struct {
+	config          *latest.Config
+	generatedConfig *generated.Config
+	command         string
+	log             logpkg.Logger
+
+	start    time.Time
+	sendLock sync.Mutex
+}
+
+// Start creates a new notifier for the given command and makes sure that a failure notification
+// is sent if the command exits with a fatal error
+func Start(config *latest.Config, generatedConfig *generated.Config, command string, log logpkg.Logger) *Notifier {
+	notifier := &Notifier{
+		config:          config,
+		generatedConfig: generatedConfig,
+		command:         command,
+		log:             log,
+		start:           time.Now(),
+	}
+
+	if len(notifier.getNotifications(StatusFailure)) > 0 {
+		logpkg.OnFatal(func(message string) {
+			// We are not allowed to use the default logger within a fatal handler
+			notifier.send(StatusFailure, errors.New(message), logpkg.Discard)
+		})
+	}
+
+	return notifier
+}
+
+// Success sends a success notification and restarts the duration measurement
+func (n *Notifier) Success() {
+	n.send(StatusSuccess, nil, n.log)
+}
+
+// Failure sends a failure notification and restarts the duration measurement
+func (n *Notifier) Failure(err error) {
+	n.send(StatusFailure, err, n.log)
+}
+
+func (n *Notifier) send(status string, err error, log logpkg.Logger) {
+	n.sendLock.Lock()
+	defer n.sendLock.Unlock()
+
+	notifications := n.getNotifications(status)
+	if len(notifications) > 0 {
+		event := n.newEvent(status, err)
+
+		for _, notification := range notifications {
+			sendErr := Send(notification, event)
+			if sendErr != nil {
+				log.Warnf("Error sending notification to %s: %v", *notification.URL, sendErr)
+			}
+		}
+	}
+
+	n.start = time.Now()
+}
+
+// getNotifications returns all notification configs that should be notified for the command and status
+func (n *Notifier) getNotifications(status string) []*latest.NotificationConfig {
+	notifications := []*latest.NotificationConfig{}
+	if n.config == nil || n.config.Notifications == nil {
+		return notifications
+	}
+
+	for _, notification := range *n.config.Notifications {
+		if notification.URL == nil || *notification.URL == "" {
+			continue
+		}
+		if notification.Commands != nil && contains(*notification.Commands, n.command) == false {
+			continue
+		}
+		if notification.On != nil && contains(*notification.On, status) == false {
+			continue
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	return notifications
+}
+
+func (n *Notifier) newEvent(status string, err error) *Event {
+	event := &Event{
+		Command:  n.command,
+		Status:   status,
+		Config:   generated.DefaultConfigName,
+		Images:   []string{},
+		Duration: time.Since(n.start).Round(time.Second).String(),
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+
+	if n.generatedConfig != nil {
+		event.Config = n.generatedConfig.ActiveConfig
+
+		cache := n.generatedConfig.GetActive()
+		for _, imageCache := range cache.Images {
+			if imageCache.ImageName != "" && imageCache.Tag != "" {
+				event.Images = append(event.Images, imageCache.GetResolvedImageName()+":"+imageCache.Tag)
+			}
+		}
+
+		sort.Strings(event.Images)
+	}
+
+	if n.config.Cluster != nil && n.config.Cluster.KubeContext != nil {
+		event.Context = *n.config.Cluster.KubeContext
+	} else if kubeConfig, err := kubeconfig.LoadRawConfig(); err == nil {
+		event.Context = kubeConfig.CurrentContext
+	}
+
+	namespace, nsErr := configutil.GetDefaultNamespace(n.config)
+	if nsErr == nil {
+		event.Namespace = namespace
+	}
+
+	return event
+}
+
+// Send renders the message and the url of the notification with the event and sends the event to the webhook
+func Send(notification *latest.NotificationConfig, event *Event) error {
+	message := DefaultMessage
+	if notification.Message != nil {
+		message = *notification.Message
+	}
+
+	text, err := renderTemplate("message", message, event)
+	if err != nil {
+		return err
+	}
+
+	url, err := renderTemplate("url", *notification.URL, event)
+	if err != nil {
+		return err
+	}
+
+	payload := *event
+	payload.Text = text
+
+	out, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshal event")
+	}
+
+	client := &http.Client{Timeout: sendTimeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(out))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func renderTemplate(name, text string, event *Event) (string, error) {
+	t, err := template.New(name).Funcs(template.FuncMap{
+		"join": strings.Join,
+	}).Parse(text)
+	if err != nil {
+		return "", errors.Wrapf(err, "parse %s template", name)
+	}
+
+	buf := &bytes.Buffer{}
+	err = t.Execute(buf, event)
+	if err != nil {
+		return "", errors.Wrapf(err, "render %s template", name)
+	}
+
+	return buf.String(), nil
+}
+
+func contains(values []*string, value string) bool {
+	for _, v := range values {
+		if v != nil && strings.TrimSpace(*v) == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+)
+
+func TestSend(t *testing.T) {
+	received := &Event{}
+	receivedPath := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedPath = r.URL.RequestURI()
+		err := json.NewDecoder(r.Body).Decode(received)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}))
+	defer server.Close()
+
+	event := &Event{
+		Command:   "deploy",
+		Status:    StatusSuccess,
+		Config:    "default",
+		Context:   "minikube",
+		Namespace: "test",
+		Images:    []string{"user/backend:abc123", "user/frontend:def456"},
+		Duration:  "42s",
+	}
+
+	err := Send(&latest.NotificationConfig{URL: ptr.String(server.URL)}, event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, received.Text, "devspace deploy succeeded for config default in minikube/test after 42s (images: user/backend:abc123, user/frontend:def456)")
+	assert.Equal(t, received.Namespace, "test")
+
+	err = Send(&latest.NotificationConfig{URL: ptr.String(server.URL), Message: ptr.String("{{.Command}} {{.Status}}: {{.Error}}")}, &Event{Command: "purge", Status: StatusFailure, Error: "boom"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, received.Text, "purge failure: boom")
+
+	// The url is rendered with the same event as the message
+	err = Send(&latest.NotificationConfig{URL: ptr.String(server.URL + "/{{.Command}}?status={{.Status}}&error={{urlquery .Error}}")}, &Event{Command: "purge", Status: StatusFailure, Error: "no space left"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, receivedPath, "/purge?status=failure&error=no+space+left")
+
+	err = Send(&latest.NotificationConfig{URL: ptr.String(server.URL + "/{{.Unknown}}")}, event)
+	assert.ErrorContains(t, err, "render url template")
+}
+
+func TestGetNotifications(t *testing.T) {
+	notifier := &Notifier{
+		command: "dev",
+		config: &latest.Config{
+			Notifications: &[]*latest.NotificationConfig{
+				{URL: ptr.String("http://all")},
+				{URL: ptr.String("http://deploy-only"), Commands: &[]*string{ptr.String("deploy")}},
+				{URL: ptr.String("http://failures"), On: &[]*string{ptr.String(StatusFailure)}},
+			},
+		},
+	}
+
+	assert.Equal(t, len(notifier.getNotifications(StatusSuccess)), 1)
+	assert.Equal(t, len(notifier.getNotifications(StatusFailure)), 2)
+}
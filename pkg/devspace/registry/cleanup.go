@@ -0,0 +1,154 @@
+package registry
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/docker"
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/reference"
+	registryclient "github.com/docker/distribution/registry/client"
+	"github.com/docker/distribution/registry/client/auth"
+	"github.com/docker/distribution/registry/client/auth/challenge"
+	"github.com/docker/distribution/registry/client/transport"
+	"github.com/docker/docker/client"
+	"github.com/pkg/errors"
+
+	// Register the manifest types, so that the digests of the tags are resolved for the current manifest formats
+	_ "github.com/docker/distribution/manifest/manifestlist"
+	_ "github.com/docker/distribution/manifest/schema2"
+)
+
+// Repository lists and deletes the tags of an image repository in a remote registry
+type Repository struct {
+	imageName  string
+	repository distribution.Repository
+}
+
+// NewRepository creates a new repository client for the image. The credentials are taken from the docker config
+func NewRepository(dockerClient client.CommonAPIClient, imageName string) (*Repository, error) {
+	named, err := reference.ParseNormalizedNamed(imageName)
+	if err != nil {
+		return nil, err
+	}
+
+	registryURL := reference.Domain(named)
+	if registryURL == "docker.io" {
+		registryURL = "registry-1.docker.io"
+	}
+
+	credentials := &staticCredentials{}
+	if dockerClient != nil {
+		authConfig, err := docker.GetAuthConfig(dockerClient, reference.Domain(named), true)
+		if err == nil {
+			credentials.username = authConfig.Username
+			credentials.password = authConfig.Password
+			credentials.refreshToken = authConfig.IdentityToken
+		}
+	}
+
+	endpoint := "https://" + registryURL
+	if strings.HasPrefix(registryURL, "localhost") || strings.HasPrefix(registryURL, "127.0.0.1") {
+		endpoint = "http://" + registryURL
+	}
+
+	// Get the auth challenges of the registry
+	challengeManager := challenge.NewSimpleManager()
+	resp, err := http.Get(endpoint + "/v2/")
+	if err != nil {
+		return nil, errors.Wrapf(err, "ping registry %s", registryURL)
+	}
+	resp.Body.Close()
+
+	err = challengeManager.AddResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryName := reference.Path(named)
+	authorizer := auth.NewAuthorizer(challengeManager,
+		auth.NewTokenHandler(http.DefaultTransport, credentials, repositoryName, "pull", "push", "delete"),
+		auth.NewBasicHandler(credentials),
+	)
+
+	repository, err := registryclient.NewRepository(reference.TrimNamed(named), endpoint, transport.NewTransport(http.DefaultTransport, authorizer))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		imageName:  imageName,
+		repository: repository,
+	}, nil
+}
+
+// Tags returns all tags of the repository
+func (r *Repository) Tags() ([]string, error) {
+	return r.repository.Tags(context.Background()).All(context.Background())
+}
+
+// DeleteTags deletes the manifests of the tags. Manifests that are also referenced by one of the tags to keep are
+// not deleted, because deleting a manifest deletes all of its tags. Returns the deleted tags
+func (r *Repository) DeleteTags(tags []string, keepTags []string) ([]string, error) {
+	ctx := context.Background()
+	tagService := r.repository.Tags(ctx)
+
+	keepDigests := map[string]bool{}
+	for _, tag := range keepTags {
+		descriptor, err := tagService.Get(ctx, tag)
+		if err != nil {
+			// The tag does not exist in the registry
+			continue
+		}
+
+		keepDigests[descriptor.Digest.String()] = true
+	}
+
+	manifestService, err := r.repository.Manifests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deletedTags := []string{}
+	deletedDigests := map[string]bool{}
+	for _, tag := range tags {
+		descriptor, err := tagService.Get(ctx, tag)
+		if err != nil {
+			return deletedTags, errors.Wrapf(err, "get digest of %s:%s", r.imageName, tag)
+		}
+
+		digest := descriptor.Digest.String()
+		if keepDigests[digest] {
+			continue
+		} else if deletedDigests[digest] == false {
+			err = manifestService.Delete(ctx, descriptor.Digest)
+			if err != nil {
+				return deletedTags, errors.Wrapf(err, "delete %s:%s", r.imageName, tag)
+			}
+
+			deletedDigests[digest] = true
+		}
+
+		deletedTags = append(deletedTags, tag)
+	}
+
+	return deletedTags, nil
+}
+
+type staticCredentials struct {
+	username     string
+	password     string
+	refreshToken string
+}
+
+func (s *staticCredentials) Basic(*url.URL) (string, string) {
+	return s.username, s.password
+}
+
+func (s *staticCredentials) RefreshToken(*url.URL, string) string {
+	return s.refreshToken
+}
+
+func (s *staticCredentials) SetRefreshToken(realm *url.URL, service, token string) {}
@@ -0,0 +1,51 @@
+package registry
+
+import (
+	"os/exec"
+	"regexp"
+
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/pkg/errors"
+)
+
+const credentialHelperPrefix = "docker-credential-"
+
+// credentialHelpers maps well known cloud registries to the credential helper that can issue tokens for them
+var credentialHelpers = []struct {
+	registry *regexp.Regexp
+	helper   string
+}{
+	{registry: regexp.MustCompile(`^[0-9]+\.dkr\.ecr(-fips)?\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`), helper: "ecr-login"},
+	{registry: regexp.MustCompile(`^([a-z]+\.)?gcr\.io$`), helper: "gcr"},
+	{registry: regexp.MustCompile(`^[a-z0-9-]+-docker\.pkg\.dev$`), helper: "gcr"},
+	{registry: regexp.MustCompile(`^[a-z0-9]+\.azurecr\.io$`), helper: "acr-env"},
+}
+
+// GetCredentialHelper returns the name of the credential helper that should be used for the given registry
+// or an empty string if there is no known helper for this registry
+func GetCredentialHelper(registryURL string) string {
+	for _, credentialHelper := range credentialHelpers {
+		if credentialHelper.registry.MatchString(registryURL) {
+			return credentialHelper.helper
+		}
+	}
+
+	return ""
+}
+
+// GetCredentialsFromHelper executes the docker-credential-[helper] binary and returns the credentials for the given registry
+func GetCredentialsFromHelper(helper, registryURL string) (string, string, error) {
+	program := credentialHelperPrefix + helper
+
+	_, err := exec.LookPath(program)
+	if err != nil {
+		return "", "", errors.Errorf("Couldn't find credential helper %s in PATH", program)
+	}
+
+	credentials, err := client.Get(client.NewShellProgramFunc(program), registryURL)
+	if err != nil {
+		return "", "", errors.Wrap(err, program)
+	}
+
+	return credentials.Username, credentials.Secret, nil
+}
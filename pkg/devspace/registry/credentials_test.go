@@ -0,0 +1,32 @@
+package registry
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestGetCredentialHelper(t *testing.T) {
+	testCases := map[string]string{
+		"123456789012.dkr.ecr.eu-west-1.amazonaws.com": "ecr-login",
+		"gcr.io":                      "gcr",
+		"eu.gcr.io":                   "gcr",
+		"europe-west1-docker.pkg.dev": "gcr",
+		"myregistry.azurecr.io":       "acr-env",
+		"dscr.io":                     "",
+		"":                            "",
+		"gcr.io.example.com":          "",
+		"amazonaws.com":               "",
+	}
+
+	for registryURL, expected := range testCases {
+		assert.Equal(t, expected, GetCredentialHelper(registryURL), "Wrong credential helper for registry %s", registryURL)
+	}
+}
+
+func TestGetCredentialsFromHelperNotFound(t *testing.T) {
+	_, _, err := GetCredentialsFromHelper("does-not-exist", "dscr.io")
+	if err == nil {
+		t.Fatal("Expected error for missing credential helper")
+	}
+}
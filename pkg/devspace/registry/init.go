@@ -7,39 +7,56 @@ import (
 	"github.com/docker/docker/client"
 	"github.com/pkg/errors"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
-// CreatePullSecrets creates the image pull secrets
-func CreatePullSecrets(config *latest.Config, dockerClient client.CommonAPIClient, client kubernetes.Interface, log log.Logger) error {
+// CreatePullSecrets creates the image pull secrets and records them in the generated config, so they can be deleted
+// when the deployments are purged
+func CreatePullSecrets(config *latest.Config, generatedConfig *generated.Config, dockerClient client.CommonAPIClient, client kubernetes.Interface, log log.Logger) error {
 	if config.Images != nil {
+		kubeContext, err := configutil.GetKubeContext(config)
+		if err != nil {
+			return errors.Wrap(err, "get kube context")
+		}
+
 		pullSecrets := []string{}
 
 		for _, imageConf := range *config.Images {
 			if imageConf.CreatePullSecret != nil && *imageConf.CreatePullSecret == true {
-				registryURL, err := GetRegistryFromImageName(*imageConf.Image)
+				registryURL, err := GetRegistryFromImageName(GetAliasedImageName(config, *imageConf.Image))
 				if err != nil {
 					return err
 				}
 
+				credentialHelper := GetCredentialHelper(registryURL)
+				if imageConf.CredentialHelper != nil {
+					credentialHelper = *imageConf.CredentialHelper
+				}
+
 				log.StartWait("Creating image pull secret for registry: " + registryURL)
-				err = createPullSecretForRegistry(config, dockerClient, client, registryURL, log)
+				err = createPullSecretForRegistry(config, generatedConfig, kubeContext, dockerClient, client, registryURL, credentialHelper, imageConf.CredentialHelper != nil, log)
 				log.StopWait()
 				if err != nil {
 					return fmt.Errorf("Failed to create pull secret for registry: %v", err)
 				}
 
-				pullSecrets = append(pullSecrets, GetRegistryAuthSecretName(registryURL))
+				// Images with injectPullSecret get the pull secret injected into their workloads during deployment
+				if imageConf.InjectPullSecret == nil || *imageConf.InjectPullSecret == false {
+					pullSecrets = append(pullSecrets, GetRegistryAuthSecretName(registryURL))
+				}
 			}
 		}
 
 		if len(pullSecrets) > 0 {
-			err := addPullSecretsToServiceAccount(config, client, pullSecrets, log)
+			err := addPullSecretsToServiceAccount(config, generatedConfig, kubeContext, client, pullSecrets, log)
 			if err != nil {
 				return errors.Wrap(err, "add pull secrets to service account")
 			}
@@ -49,7 +66,31 @@ func CreatePullSecrets(config *latest.Config, dockerClient client.CommonAPIClien
 	return nil
 }
 
-func addPullSecretsToServiceAccount(config *latest.Config, client kubernetes.Interface, pullSecrets []string, log log.Logger) error {
+// CreateDeploymentPullSecrets creates the image pull secrets in the kube contexts of deployments that are not
+// deployed to the default kube context
+func CreateDeploymentPullSecrets(config *latest.Config, generatedConfig *generated.Config, dockerClient client.CommonAPIClient, log log.Logger) error {
+	for _, kubeContext := range kubectl.GetDeploymentKubeContexts(config) {
+		contextConfig := kubectl.ConfigWithKubeContext(config, kubeContext)
+		contextClient, err := kubectl.NewClient(contextConfig)
+		if err != nil {
+			return errors.Wrapf(err, "create client for kube context %s", kubeContext)
+		}
+
+		err = kubectl.EnsureDefaultNamespace(contextConfig, contextClient, log)
+		if err != nil {
+			return errors.Wrapf(err, "create namespace in kube context %s", kubeContext)
+		}
+
+		err = CreatePullSecrets(contextConfig, generatedConfig, dockerClient, contextClient, log)
+		if err != nil {
+			return errors.Wrapf(err, "kube context %s", kubeContext)
+		}
+	}
+
+	return nil
+}
+
+func addPullSecretsToServiceAccount(config *latest.Config, generatedConfig *generated.Config, kubeContext string, client kubernetes.Interface, pullSecrets []string, log log.Logger) error {
 	// Add secrets to default service account in default namespace
 	namespace, err := configutil.GetDefaultNamespace(config)
 	if err != nil {
@@ -64,7 +105,7 @@ func addPullSecretsToServiceAccount(config *latest.Config, client kubernetes.Int
 	}
 
 	// Check if all pull secrets are there
-	changed := false
+	added := []string{}
 	for _, newPullSecret := range pullSecrets {
 		found := false
 
@@ -76,23 +117,29 @@ func addPullSecretsToServiceAccount(config *latest.Config, client kubernetes.Int
 		}
 
 		if found == false {
-			changed = true
+			added = append(added, newPullSecret)
 			serviceaccount.ImagePullSecrets = append(serviceaccount.ImagePullSecrets, v1.LocalObjectReference{Name: newPullSecret})
 		}
 	}
 
 	// Should we update the service account?
-	if changed {
+	if len(added) > 0 {
 		_, err := client.CoreV1().ServiceAccounts(namespace).Update(serviceaccount)
+		audit.Record(audit.ActionUpdate, "ServiceAccount", namespace, serviceaccount.Name, err)
 		if err != nil {
 			return errors.Wrap(err, "update service account")
 		}
+
+		// Only the secrets devspace added are removed from the service account again on purge
+		for _, pullSecret := range added {
+			generatedConfig.AddServiceAccountPullSecret(kubeContext, namespace, pullSecret)
+		}
 	}
 
 	return nil
 }
 
-func createPullSecretForRegistry(config *latest.Config, dockerClient client.CommonAPIClient, client kubernetes.Interface, registryURL string, log log.Logger) error {
+func createPullSecretForRegistry(config *latest.Config, generatedConfig *generated.Config, kubeContext string, dockerClient client.CommonAPIClient, client kubernetes.Interface, registryURL, credentialHelper string, credentialHelperConfigured bool, log log.Logger) error {
 	defaultNamespace, err := configutil.GetDefaultNamespace(config)
 	if err != nil {
 		return err
@@ -107,6 +154,20 @@ func createPullSecretForRegistry(config *latest.Config, dockerClient client.Comm
 		}
 	}
 
+	// Ask the credential helper if the docker config has no credentials for this registry
+	if (username == "" || password == "") && credentialHelper != "" {
+		username, password, err = GetCredentialsFromHelper(credentialHelper, registryURL)
+		if err != nil {
+			if credentialHelperConfigured {
+				return errors.Wrap(err, "get credentials from helper")
+			}
+
+			// The helper was only detected from the registry url, so a missing helper shouldn't stop the deployment
+			log.Warnf("Skip creating pull secret for registry %s: %v", registryURL, err)
+			return nil
+		}
+	}
+
 	if config.Deployments != nil && username != "" && password != "" {
 		for _, deployConfig := range *config.Deployments {
 			email := "noreply@devspace.cloud"
@@ -120,6 +181,8 @@ func createPullSecretForRegistry(config *latest.Config, dockerClient client.Comm
 			if err != nil {
 				return err
 			}
+
+			generatedConfig.AddPullSecret(kubeContext, namespace, GetRegistryAuthSecretName(registryURL))
 		}
 	}
 
@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
@@ -40,6 +41,9 @@ func (t testLogger) Info(args ...interface{}) {
 func (t testLogger) Infof(format string, args ...interface{}) {
 	logOutput = logOutput + "\nInfo " + fmt.Sprintf(format, args...)
 }
+func (t testLogger) Warnf(format string, args ...interface{}) {
+	logOutput = logOutput + "\nWarn " + fmt.Sprintf(format, args...)
+}
 func (t testLogger) StartWait(message string) {
 	logOutput = logOutput + "\nStartWait " + message
 }
@@ -89,6 +93,37 @@ Error Couldn't find service account 'default' in namespace 'testNS': serviceacco
 StartWait Creating image pull secret for registry: 
 StopWait`,
 		},
+		createPullSecretTestCase{
+			name:            "Detected credential helper is missing",
+			namespace:       "testNS",
+			serviceAccounts: []string{"default"},
+			imagesInConfig: map[string]*latest.ImageConfig{
+				"testimage": &latest.ImageConfig{
+					CreatePullSecret: ptr.Bool(true),
+					Image:            ptr.String("gcr.io/devspace-test/testimage"),
+				},
+			},
+			expectedLog: `
+StartWait Creating image pull secret for registry: gcr.io
+Warn Skip creating pull secret for registry gcr.io: Couldn't find credential helper docker-credential-gcr in PATH
+StopWait`,
+		},
+		createPullSecretTestCase{
+			name:            "Configured credential helper is missing",
+			namespace:       "testNS",
+			serviceAccounts: []string{"default"},
+			imagesInConfig: map[string]*latest.ImageConfig{
+				"testimage": &latest.ImageConfig{
+					CreatePullSecret: ptr.Bool(true),
+					Image:            ptr.String("gcr.io/devspace-test/testimage"),
+					CredentialHelper: ptr.String("does-not-exist"),
+				},
+			},
+			expectedLog: `
+StartWait Creating image pull secret for registry: gcr.io
+StopWait`,
+			expectedErr: "Failed to create pull secret for registry: get credentials from helper: Couldn't find credential helper docker-credential-does-not-exist in PATH",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -120,7 +155,7 @@ StopWait`,
 		logOutput = ""
 
 		//Unfortunately we can't fake dockerClients yet.
-		err = CreatePullSecrets(testConfig, nil, kubeClient, &testLogger{})
+		err = CreatePullSecrets(testConfig, &generated.Config{}, nil, kubeClient, &testLogger{})
 
 		if testCase.expectedErr == "" {
 			assert.NilError(t, err, "Error creating pull secrets in testCase %s", testCase.name)
@@ -147,3 +182,25 @@ StopWait`,
 		}`, string(resultSecret.Data[k8sv1.DockerConfigJsonKey]), "Saved secret has wrong data")*/
 
 }
+
+func TestAddPullSecretsToServiceAccount(t *testing.T) {
+	namespace := "testNS"
+	kubeClient := fake.NewSimpleClientset(&k8sv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: namespace,
+		},
+		ImagePullSecrets: []k8sv1.LocalObjectReference{{Name: "devspace-auth-docker"}},
+	})
+	generatedConfig := &generated.Config{}
+
+	err := addPullSecretsToServiceAccount(&latest.Config{Cluster: &latest.Cluster{Namespace: &namespace}}, generatedConfig, "my-context", kubeClient, []string{"devspace-auth-docker", "devspace-auth-gcr-io"}, &testLogger{})
+	assert.NilError(t, err)
+
+	serviceAccount, err := kubeClient.CoreV1().ServiceAccounts(namespace).Get("default", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, serviceAccount.ImagePullSecrets, []k8sv1.LocalObjectReference{{Name: "devspace-auth-docker"}, {Name: "devspace-auth-gcr-io"}})
+
+	// The secret that was already in the service account must stay there on purge
+	assert.DeepEqual(t, generatedConfig.GetPullSecrets("my-context", namespace).ServiceAccount, []string{"devspace-auth-gcr-io"})
+}
@@ -0,0 +1,237 @@
+package registry
+
+import (
+	"sort"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+)
+
+// devVariant is the image cache variant of images built with images.*.dev (see helper.DevVariant)
+const devVariant = "dev"
+
+// PullOptions holds the image pull policy and the image pull secret that are injected into the workloads
+// that use an image
+type PullOptions struct {
+	ImageNames []string
+	PullPolicy string
+	PullSecret string
+}
+
+// GetPullOptions returns the pull options of all images that define a pull policy or inject their pull secret.
+// images.*.dev.pullPolicy overrides images.*.pullPolicy if the image was last built for devspace dev
+func GetPullOptions(config *latest.Config, cache *generated.CacheConfig) []*PullOptions {
+	pullOptions := []*PullOptions{}
+	if config == nil || config.Images == nil {
+		return pullOptions
+	}
+
+	imageConfigNames := make([]string, 0, len(*config.Images))
+	for imageConfigName := range *config.Images {
+		imageConfigNames = append(imageConfigNames, imageConfigName)
+	}
+	sort.Strings(imageConfigNames)
+
+	for _, imageConfigName := range imageConfigNames {
+		imageConf := (*config.Images)[imageConfigName]
+		if imageConf.Image == nil {
+			continue
+		}
+
+		var imageCache *generated.ImageCache
+		if cache != nil {
+			imageCache = cache.Images[imageConfigName]
+		}
+
+		options := &PullOptions{
+			ImageNames: []string{*imageConf.Image, GetAliasedImageName(config, *imageConf.Image)},
+		}
+		if imageCache != nil && imageCache.ImageName != "" {
+			options.ImageNames = append(options.ImageNames, imageCache.GetResolvedImageName())
+		}
+
+		if imageConf.PullPolicy != nil {
+			options.PullPolicy = *imageConf.PullPolicy
+		}
+		if imageCache != nil && imageCache.Variant == devVariant && imageConf.Dev != nil && imageConf.Dev.PullPolicy != nil {
+			options.PullPolicy = *imageConf.Dev.PullPolicy
+		}
+
+		if imageConf.CreatePullSecret != nil && *imageConf.CreatePullSecret && imageConf.InjectPullSecret != nil && *imageConf.InjectPullSecret {
+			registryURL, err := GetRegistryFromImageName(GetAliasedImageName(config, *imageConf.Image))
+			if err == nil {
+				options.PullSecret = GetRegistryAuthSecretName(registryURL)
+			}
+		}
+
+		if options.PullPolicy != "" || options.PullSecret != "" {
+			pullOptions = append(pullOptions, options)
+		}
+	}
+
+	return pullOptions
+}
+
+// InjectPullOptions searches obj for pod specs, i.e. maps with a containers or initContainers list, and sets the
+// imagePullPolicy of every container whose image has pull options. If injectPullSecrets is true, the pull secrets of
+// the matched images are added to the imagePullSecrets of the pod spec. The pull secrets of all matched images are
+// returned
+func InjectPullOptions(obj interface{}, pullOptions []*PullOptions, injectPullSecrets bool) []string {
+	pullSecrets := []string{}
+	if len(pullOptions) == 0 {
+		return pullSecrets
+	}
+
+	switch value := obj.(type) {
+	case map[interface{}]interface{}:
+		podSpecSecrets := []string{}
+		for _, key := range []string{"initContainers", "containers"} {
+			containers, ok := value[key].([]interface{})
+			if ok == false {
+				continue
+			}
+
+			for _, container := range containers {
+				containerValues, ok := container.(map[interface{}]interface{})
+				if ok == false {
+					continue
+				}
+
+				image, ok := containerValues["image"].(string)
+				if ok == false {
+					continue
+				}
+
+				options := getPullOptionsForImage(image, pullOptions)
+				if options == nil {
+					continue
+				}
+
+				if options.PullPolicy != "" {
+					containerValues["imagePullPolicy"] = options.PullPolicy
+				}
+				if options.PullSecret != "" {
+					podSpecSecrets = appendUnique(podSpecSecrets, options.PullSecret)
+				}
+			}
+		}
+
+		if injectPullSecrets && len(podSpecSecrets) > 0 {
+			value["imagePullSecrets"] = MergePullSecrets(value["imagePullSecrets"], podSpecSecrets, true)
+		}
+		for _, secret := range podSpecSecrets {
+			pullSecrets = appendUnique(pullSecrets, secret)
+		}
+
+		for _, child := range value {
+			for _, secret := range InjectPullOptions(child, pullOptions, injectPullSecrets) {
+				pullSecrets = appendUnique(pullSecrets, secret)
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			for _, secret := range InjectPullOptions(child, pullOptions, injectPullSecrets) {
+				pullSecrets = appendUnique(pullSecrets, secret)
+			}
+		}
+	}
+
+	sort.Strings(pullSecrets)
+	return pullSecrets
+}
+
+// GetReferencedPullSecrets returns the pull secrets of all images with pull options that are referenced by a string
+// value anywhere in obj
+func GetReferencedPullSecrets(obj interface{}, pullOptions []*PullOptions) []string {
+	pullSecrets := []string{}
+
+	switch value := obj.(type) {
+	case string:
+		options := getPullOptionsForImage(value, pullOptions)
+		if options != nil && options.PullSecret != "" {
+			pullSecrets = append(pullSecrets, options.PullSecret)
+		}
+	case map[interface{}]interface{}:
+		for _, child := range value {
+			for _, secret := range GetReferencedPullSecrets(child, pullOptions) {
+				pullSecrets = appendUnique(pullSecrets, secret)
+			}
+		}
+	case []interface{}:
+		for _, child := range value {
+			for _, secret := range GetReferencedPullSecrets(child, pullOptions) {
+				pullSecrets = appendUnique(pullSecrets, secret)
+			}
+		}
+	}
+
+	sort.Strings(pullSecrets)
+	return pullSecrets
+}
+
+// MergePullSecrets adds the pull secrets that are missing to an existing pull secret list. If references is true,
+// the list holds secret references ({name: secret}) as in imagePullSecrets of a pod spec, otherwise plain names.
+// An existing value that is not a list is returned unchanged
+func MergePullSecrets(existing interface{}, pullSecrets []string, references bool) interface{} {
+	existingSecrets, ok := existing.([]interface{})
+	if existing != nil && ok == false {
+		// We don't know how to merge this, so we leave it as it is
+		return existing
+	}
+
+	defined := map[string]bool{}
+	for _, secret := range existingSecrets {
+		switch secretValue := secret.(type) {
+		case string:
+			defined[secretValue] = true
+		case map[interface{}]interface{}:
+			if name, ok := secretValue["name"].(string); ok {
+				defined[name] = true
+			}
+		}
+	}
+
+	merged := make([]interface{}, 0, len(existingSecrets)+len(pullSecrets))
+	merged = append(merged, existingSecrets...)
+	for _, secret := range pullSecrets {
+		if defined[secret] {
+			continue
+		}
+
+		defined[secret] = true
+		if references {
+			merged = append(merged, map[interface{}]interface{}{"name": secret})
+		} else {
+			merged = append(merged, secret)
+		}
+	}
+
+	return merged
+}
+
+func getPullOptionsForImage(image string, pullOptions []*PullOptions) *PullOptions {
+	strippedImage, err := GetStrippedDockerImageName(image)
+	if err != nil {
+		return nil
+	}
+
+	for _, options := range pullOptions {
+		for _, imageName := range options.ImageNames {
+			if IsSameImage(imageName, strippedImage) {
+				return options
+			}
+		}
+	}
+
+	return nil
+}
+
+func appendUnique(list []string, value string) []string {
+	for _, existing := range list {
+		if existing == value {
+			return list
+		}
+	}
+
+	return append(list, value)
+}
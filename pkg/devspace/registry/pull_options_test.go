@@ -0,0 +1,100 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	yaml "gopkg.in/yaml.v2"
+	"gotest.tools/assert"
+)
+
+func TestGetPullOptions(t *testing.T) {
+	config := &latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"app": &latest.ImageConfig{
+				Image:            ptr.String("registry.example.com/app"),
+				CreatePullSecret: ptr.Bool(true),
+				InjectPullSecret: ptr.Bool(true),
+				PullPolicy:       ptr.String("IfNotPresent"),
+				Dev: &latest.ImageDevConfig{
+					PullPolicy: ptr.String("Always"),
+				},
+			},
+			"sidecar": &latest.ImageConfig{
+				Image:            ptr.String("registry.example.com/sidecar"),
+				CreatePullSecret: ptr.Bool(true),
+			},
+		},
+	}
+
+	pullOptions := GetPullOptions(config, &generated.CacheConfig{Images: map[string]*generated.ImageCache{}})
+	assert.Equal(t, len(pullOptions), 1, "Wrong number of pull options")
+	assert.Equal(t, pullOptions[0].PullPolicy, "IfNotPresent", "Wrong pull policy")
+	assert.Equal(t, pullOptions[0].PullSecret, GetRegistryAuthSecretName("registry.example.com"), "Wrong pull secret")
+
+	cache := &generated.CacheConfig{
+		Images: map[string]*generated.ImageCache{
+			"app": &generated.ImageCache{ImageName: "registry.example.com/app", Tag: "abc", Variant: devVariant},
+		},
+	}
+	pullOptions = GetPullOptions(config, cache)
+	assert.Equal(t, pullOptions[0].PullPolicy, "Always", "Dev pull policy not used for dev variant")
+}
+
+func TestInjectPullOptions(t *testing.T) {
+	pullOptions := []*PullOptions{
+		&PullOptions{
+			ImageNames: []string{"registry.example.com/app"},
+			PullPolicy: "Always",
+			PullSecret: "devspace-auth-registry-example-com",
+		},
+	}
+
+	manifest := map[interface{}]interface{}{}
+	err := yaml.Unmarshal([]byte(`
+kind: Deployment
+spec:
+  template:
+    spec:
+      imagePullSecrets:
+      - name: existing
+      containers:
+      - name: app
+        image: registry.example.com/app:abc
+      - name: nginx
+        image: nginx
+`), &manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pullSecrets := InjectPullOptions(manifest, pullOptions, true)
+	assert.DeepEqual(t, pullSecrets, []string{"devspace-auth-registry-example-com"})
+
+	podSpec := manifest["spec"].(map[interface{}]interface{})["template"].(map[interface{}]interface{})["spec"].(map[interface{}]interface{})
+	containers := podSpec["containers"].([]interface{})
+	assert.Equal(t, containers[0].(map[interface{}]interface{})["imagePullPolicy"], "Always", "Pull policy not set")
+	_, ok := containers[1].(map[interface{}]interface{})["imagePullPolicy"]
+	assert.Equal(t, ok, false, "Pull policy set for unrelated image")
+	assert.DeepEqual(t, podSpec["imagePullSecrets"], []interface{}{
+		map[interface{}]interface{}{"name": "existing"},
+		map[interface{}]interface{}{"name": "devspace-auth-registry-example-com"},
+	})
+
+	// Injecting twice must not duplicate the secret
+	InjectPullOptions(manifest, pullOptions, true)
+	assert.Equal(t, len(podSpec["imagePullSecrets"].([]interface{})), 2, "Pull secret injected twice")
+}
+
+func TestMergePullSecrets(t *testing.T) {
+	merged := MergePullSecrets([]interface{}{"existing"}, []string{"existing", "new"}, false)
+	assert.DeepEqual(t, merged, []interface{}{"existing", "new"})
+
+	merged = MergePullSecrets(nil, []string{"new"}, true)
+	assert.DeepEqual(t, merged, []interface{}{map[interface{}]interface{}{"name": "new"}})
+
+	merged = MergePullSecrets("invalid", []string{"new"}, false)
+	assert.Equal(t, merged, "invalid", "Invalid pull secret list was overridden")
+}
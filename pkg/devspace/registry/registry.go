@@ -6,10 +6,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/audit"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"k8s.io/client-go/kubernetes"
 
 	k8sv1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -56,6 +59,7 @@ func CreatePullSecret(kubectl kubernetes.Interface, namespace, registryURL, user
 	_, err := kubectl.CoreV1().Secrets(namespace).Get(pullSecretName, metav1.GetOptions{})
 	if err != nil {
 		_, err = kubectl.CoreV1().Secrets(namespace).Create(registryPullSecret)
+		audit.Record(audit.ActionCreate, "Secret", namespace, pullSecretName, err)
 		if err != nil {
 			return fmt.Errorf("Unable to create image pull secret: %s", err.Error())
 		}
@@ -63,6 +67,7 @@ func CreatePullSecret(kubectl kubernetes.Interface, namespace, registryURL, user
 		log.Donef("Created image pull secret %s/%s", namespace, pullSecretName)
 	} else {
 		_, err = kubectl.CoreV1().Secrets(namespace).Update(registryPullSecret)
+		audit.Record(audit.ActionUpdate, "Secret", namespace, pullSecretName, err)
 		if err != nil {
 			return fmt.Errorf("Unable to update image pull secret: %s", err.Error())
 		}
@@ -72,6 +77,66 @@ func CreatePullSecret(kubectl kubernetes.Interface, namespace, registryURL, user
 	return nil
 }
 
+// DeletePullSecrets deletes the image pull secrets devspace created in the namespace and forgets them in the generated config.
+// If unpatchServiceAccount is true, the secrets devspace added to the default service account are removed from it as well
+func DeletePullSecrets(generatedConfig *generated.Config, kubectl kubernetes.Interface, kubeContext, namespace string, unpatchServiceAccount bool, log log.Logger) error {
+	pullSecrets := generatedConfig.GetPullSecrets(kubeContext, namespace)
+	if pullSecrets == nil {
+		return nil
+	}
+
+	if unpatchServiceAccount && len(pullSecrets.ServiceAccount) > 0 {
+		serviceAccount, err := kubectl.CoreV1().ServiceAccounts(namespace).Get("default", metav1.GetOptions{})
+		if err != nil && kerrors.IsNotFound(err) == false {
+			return fmt.Errorf("Unable to get service account default: %v", err)
+		} else if err == nil {
+			imagePullSecrets := []k8sv1.LocalObjectReference{}
+			for _, imagePullSecret := range serviceAccount.ImagePullSecrets {
+				if contains(pullSecrets.ServiceAccount, imagePullSecret.Name) == false {
+					imagePullSecrets = append(imagePullSecrets, imagePullSecret)
+				}
+			}
+
+			if len(imagePullSecrets) != len(serviceAccount.ImagePullSecrets) {
+				serviceAccount.ImagePullSecrets = imagePullSecrets
+
+				_, err = kubectl.CoreV1().ServiceAccounts(namespace).Update(serviceAccount)
+				audit.Record(audit.ActionUpdate, "ServiceAccount", namespace, serviceAccount.Name, err)
+				if err != nil {
+					return fmt.Errorf("Unable to update service account default: %v", err)
+				}
+			}
+		}
+	}
+
+	for _, pullSecretName := range pullSecrets.Secrets {
+		err := kubectl.CoreV1().Secrets(namespace).Delete(pullSecretName, &metav1.DeleteOptions{})
+		if err != nil && kerrors.IsNotFound(err) {
+			continue
+		}
+
+		audit.Record(audit.ActionDelete, "Secret", namespace, pullSecretName, err)
+		if err != nil {
+			return fmt.Errorf("Unable to delete image pull secret %s: %v", pullSecretName, err)
+		}
+
+		log.Donef("Deleted image pull secret %s/%s", namespace, pullSecretName)
+	}
+
+	generatedConfig.RemovePullSecrets(kubeContext, namespace)
+	return nil
+}
+
+func contains(haystack []string, needle string) bool {
+	for _, value := range haystack {
+		if value == needle {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GetRegistryAuthSecretName returns the name of the image pull secret for a registry
 func GetRegistryAuthSecretName(registryURL string) string {
 	if registryURL == "" {
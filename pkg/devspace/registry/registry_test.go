@@ -8,6 +8,7 @@ import (
 	k8sv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	
 	"gotest.tools/assert"
@@ -48,3 +49,38 @@ func TestCreatePullSecret(t *testing.T) {
 			}
 		}`, string(resultSecret.Data[k8sv1.DockerConfigJsonKey]), "Saved secret has wrong data")
 }
+
+func TestDeletePullSecrets(t *testing.T) {
+	namespace := "myns"
+	kubeClient := fake.NewSimpleClientset(&k8sv1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default",
+			Namespace: namespace,
+		},
+		ImagePullSecrets: []k8sv1.LocalObjectReference{{Name: "devspace-auth-docker"}, {Name: "other-secret"}},
+	})
+
+	err := CreatePullSecret(kubeClient, namespace, "", "someuser", "password", "someuser@example.com", log.Discard)
+	assert.NilError(t, err)
+
+	generatedConfig := &generated.Config{}
+	generatedConfig.AddPullSecret("my-context", namespace, "devspace-auth-docker")
+	generatedConfig.AddPullSecret("my-context", namespace, "devspace-auth-deleted")
+	generatedConfig.AddServiceAccountPullSecret("my-context", namespace, "devspace-auth-docker")
+
+	// Secrets of other namespaces are not touched
+	err = DeletePullSecrets(generatedConfig, kubeClient, "my-context", "other", true, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, generatedConfig.GetPullSecrets("my-context", namespace) != nil)
+
+	err = DeletePullSecrets(generatedConfig, kubeClient, "my-context", namespace, true, log.Discard)
+	assert.NilError(t, err)
+	assert.Assert(t, generatedConfig.PullSecrets == nil || len(generatedConfig.PullSecrets) == 0)
+
+	_, err = kubeClient.CoreV1().Secrets(namespace).Get("devspace-auth-docker", metav1.GetOptions{})
+	assert.Assert(t, err != nil, "Pull secret was not deleted")
+
+	serviceAccount, err := kubeClient.CoreV1().ServiceAccounts(namespace).Get("default", metav1.GetOptions{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, serviceAccount.ImagePullSecrets, []k8sv1.LocalObjectReference{{Name: "other-secret"}})
+}
@@ -3,6 +3,7 @@ package registry
 import (
 	"strings"
 
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/docker/distribution/reference"
 	dockerregistry "github.com/docker/docker/registry"
 )
@@ -48,3 +49,75 @@ func GetStrippedDockerImageName(imageName string) (string, error) {
 
 	return reference.TrimNamed(ref).Name(), nil
 }
+
+// GetAliasedImageName returns the image name with the longest matching prefix of the registry aliases of the config
+// replaced. Image names and prefixes are normalized before comparing them, so docker.io/myorg matches myorg/app and
+// index.docker.io/myorg/app. If no alias matches or the image name is invalid, the image name is returned unchanged
+func GetAliasedImageName(config *latest.Config, imageName string) string {
+	if config == nil || config.RegistryAliases == nil || len(*config.RegistryAliases) == 0 {
+		return imageName
+	}
+
+	ref, err := reference.ParseNormalizedNamed(strings.TrimSpace(imageName))
+	if err != nil {
+		return imageName
+	}
+
+	name := ref.Name()
+	suffix := strings.TrimPrefix(reference.FamiliarString(ref), reference.FamiliarName(ref))
+
+	longestPrefix := ""
+	replacement := ""
+	for prefix, alias := range *config.RegistryAliases {
+		normalizedPrefix := normalizeImagePrefix(prefix)
+		if name != normalizedPrefix && strings.HasPrefix(name, normalizedPrefix+"/") == false {
+			continue
+		}
+
+		if len(normalizedPrefix) > len(longestPrefix) {
+			longestPrefix = normalizedPrefix
+			replacement = strings.TrimSuffix(strings.TrimSpace(alias), "/")
+		}
+	}
+	if longestPrefix == "" {
+		return imageName
+	}
+
+	return replacement + strings.TrimPrefix(name, longestPrefix) + suffix
+}
+
+// IsSameImage checks if two image names without tags refer to the same image, e.g. nginx and docker.io/library/nginx
+func IsSameImage(first, second string) bool {
+	if first == second {
+		return true
+	}
+
+	firstImage, err := GetStrippedDockerImageName(first)
+	if err != nil {
+		return false
+	}
+	secondImage, err := GetStrippedDockerImageName(second)
+	if err != nil {
+		return false
+	}
+
+	return firstImage == secondImage
+}
+
+// normalizeImagePrefix adds the default registry to an image name prefix the same way docker does for image names
+func normalizeImagePrefix(prefix string) string {
+	prefix = strings.TrimSuffix(strings.TrimSpace(prefix), "/")
+
+	domain := prefix
+	if index := strings.Index(prefix, "/"); index != -1 {
+		domain = prefix[:index]
+	}
+
+	if domain == "index.docker.io" {
+		return "docker.io" + strings.TrimPrefix(prefix, domain)
+	} else if domain != "localhost" && strings.ContainsAny(domain, ".:") == false {
+		return "docker.io/" + prefix
+	}
+
+	return prefix
+}
@@ -2,7 +2,8 @@ package registry
 
 import (
 	"testing"
-	
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"gotest.tools/assert"
 )
 
@@ -27,3 +28,37 @@ func TestGetRegistryFromImageName(t *testing.T){
 		t.Fatalf("No Error calling GetRegistryFromImageName with empty image name")
 	}
 }
+
+func TestGetAliasedImageName(t *testing.T) {
+	config := &latest.Config{
+		RegistryAliases: &map[string]string{
+			"docker.io/myorg":        "localhost:5000/myorg",
+			"docker.io/myorg/legacy": "localhost:5000/old/",
+			"gcr.io/project":         "registry.local/project",
+		},
+	}
+
+	testCases := map[string]string{
+		"docker.io/myorg/app":          "localhost:5000/myorg/app",
+		"myorg/app":                    "localhost:5000/myorg/app",
+		"index.docker.io/myorg/app:v1": "localhost:5000/myorg/app:v1",
+		"myorg/legacy":                 "localhost:5000/old",
+		"myorg/legacy/worker":          "localhost:5000/old/worker",
+		"gcr.io/project/api":           "registry.local/project/api",
+		"myorganization/app":           "myorganization/app",
+		"nginx":                        "nginx",
+		"not a valid image name":       "not a valid image name",
+	}
+
+	for imageName, expected := range testCases {
+		assert.Equal(t, GetAliasedImageName(config, imageName), expected, "Wrong aliased image name for %s", imageName)
+	}
+
+	assert.Equal(t, GetAliasedImageName(&latest.Config{}, "myorg/app"), "myorg/app", "Image name changed without aliases")
+}
+
+func TestIsSameImage(t *testing.T) {
+	assert.Equal(t, IsSameImage("nginx", "docker.io/library/nginx"), true, "Official image not normalized")
+	assert.Equal(t, IsSameImage("docker.io/myorg/app", "myorg/app"), true, "Docker hub image not normalized")
+	assert.Equal(t, IsSameImage("gcr.io/myorg/app", "myorg/app"), false, "Different registries match")
+}
@@ -0,0 +1,158 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+// runCommand executes the given command and returns its stdout
+var runCommand = func(name string, args ...string) ([]byte, error) {
+	_, err := exec.LookPath(name)
+	if err != nil {
+		return nil, errors.Errorf("Couldn't find %s in PATH", name)
+	}
+
+	out, err := exec.Command(name, args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			return nil, errors.Errorf("%s: %s", name, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return nil, errors.Wrap(err, name)
+	}
+
+	return out, nil
+}
+
+// vaultBackend reads secrets from the HashiCorp Vault HTTP API (vault:kv/path#key). The address and token are
+// read from VAULT_ADDR and VAULT_TOKEN (or ~/.vault-token) like the vault cli does
+type vaultBackend struct{}
+
+func (v *vaultBackend) Resolve(path string) (string, error) {
+	splitted := strings.SplitN(path, "#", 2)
+	if len(splitted) != 2 || splitted[1] == "" {
+		return "", fmt.Errorf("Please specify the key of the secret (e.g. vault:kv/path#key)")
+	}
+
+	address := os.Getenv("VAULT_ADDR")
+	if address == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+
+	token, err := getVaultToken()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest("GET", strings.TrimRight(address, "/")+"/v1/"+strings.TrimLeft(splitted[0], "/"), nil)
+	if err != nil {
+		return "", err
+	}
+
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded with status code %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	response := struct {
+		Data map[string]interface{} `json:"data"`
+	}{}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return "", errors.Wrap(err, "parse vault response")
+	}
+
+	data := response.Data
+
+	// KV version 2 secrets engines nest the secret in data.data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = nested
+		}
+	}
+
+	value, ok := data[splitted[1]]
+	if !ok {
+		return "", fmt.Errorf("Key %s not found in vault secret %s", splitted[1], splitted[0])
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
+
+func getVaultToken() (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+
+	home, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := ioutil.ReadFile(filepath.Join(home, ".vault-token"))
+	if err != nil {
+		return "", fmt.Errorf("VAULT_TOKEN is not set and ~/.vault-token doesn't exist. Please run `vault login`")
+	}
+
+	return strings.TrimSpace(string(token)), nil
+}
+
+// awsSSMBackend reads parameters from the AWS Systems Manager Parameter Store with the aws cli (awsssm:/param)
+type awsSSMBackend struct{}
+
+func (a *awsSSMBackend) Resolve(path string) (string, error) {
+	out, err := runCommand("aws", "ssm", "get-parameter", "--name", path, "--with-decryption", "--query", "Parameter.Value", "--output", "text")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
+
+// gcpSecretManagerBackend reads secrets from the GCP Secret Manager with the gcloud cli
+// (gcpsm:projects/[project]/secrets/[secret] or gcpsm:projects/[project]/secrets/[secret]/versions/[version])
+type gcpSecretManagerBackend struct{}
+
+func (g *gcpSecretManagerBackend) Resolve(path string) (string, error) {
+	splitted := strings.Split(strings.Trim(path, "/"), "/")
+	if (len(splitted) != 4 && len(splitted) != 6) || splitted[0] != "projects" || splitted[2] != "secrets" || (len(splitted) == 6 && splitted[4] != "versions") {
+		return "", fmt.Errorf("Invalid secret name %s, expected projects/[project]/secrets/[secret]/versions/[version]", path)
+	}
+
+	version := "latest"
+	if len(splitted) == 6 {
+		version = splitted[5]
+	}
+
+	out, err := runCommand("gcloud", "secrets", "versions", "access", version, "--secret", splitted[3], "--project", splitted[1])
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
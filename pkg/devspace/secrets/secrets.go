@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Backend resolves references to secrets that are stored in an external secret manager
+type Backend interface {
+	// Resolve returns the secret value for the given path (the reference without the backend prefix)
+	Resolve(path string) (string, error)
+}
+
+var (
+	backends     = map[string]Backend{}
+	backendsLock sync.RWMutex
+
+	// cache holds the already resolved secrets for the lifetime of the process. Secret values are never persisted
+	cache     = map[string]string{}
+	cacheLock sync.Mutex
+)
+
+func init() {
+	Register("vault", &vaultBackend{})
+	Register("awsssm", &awsSSMBackend{})
+	Register("gcpsm", &gcpSecretManagerBackend{})
+}
+
+// Register registers a backend for references with the given prefix (e.g. vault for vault:kv/path#key)
+func Register(prefix string, backend Backend) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	backends[prefix] = backend
+}
+
+// IsReference checks if the given value references a secret of a registered backend
+func IsReference(value string) bool {
+	_, _, err := getBackend(value)
+	return err == nil
+}
+
+// Resolve resolves the given reference (e.g. vault:kv/path#key) with the matching backend. Resolved secrets are cached
+func Resolve(reference string) (string, error) {
+	reference = strings.TrimSpace(reference)
+
+	cacheLock.Lock()
+	defer cacheLock.Unlock()
+
+	if value, ok := cache[reference]; ok {
+		return value, nil
+	}
+
+	backend, path, err := getBackend(reference)
+	if err != nil {
+		return "", err
+	}
+
+	value, err := backend.Resolve(path)
+	if err != nil {
+		return "", fmt.Errorf("Error resolving secret %s: %v", reference, err)
+	}
+
+	cache[reference] = value
+	return value, nil
+}
+
+func getBackend(reference string) (Backend, string, error) {
+	splitted := strings.SplitN(strings.TrimSpace(reference), ":", 2)
+	if len(splitted) != 2 || splitted[1] == "" {
+		return nil, "", fmt.Errorf("Invalid secret reference %s, expected [backend]:[path]", reference)
+	}
+
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	backend, ok := backends[splitted[0]]
+	if !ok {
+		return nil, "", fmt.Errorf("Unknown secret backend %s, supported backends are: %s", splitted[0], strings.Join(getBackendNames(), ", "))
+	}
+
+	return backend, splitted[1], nil
+}
+
+func getBackendNames() []string {
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,144 @@
+package secrets
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+)
+
+type fakeBackend struct {
+	calls int
+}
+
+func (f *fakeBackend) Resolve(path string) (string, error) {
+	f.calls++
+	return "secret-" + path, nil
+}
+
+func TestResolve(t *testing.T) {
+	backend := &fakeBackend{}
+	Register("fake", backend)
+
+	if IsReference("fake:my/path") == false {
+		t.Fatal("Expected fake:my/path to be a reference")
+	}
+	if IsReference("unknown:my/path") || IsReference("fake:") || IsReference("name") {
+		t.Fatal("Unexpected reference")
+	}
+
+	for i := 0; i < 2; i++ {
+		value, err := Resolve("fake:my/path")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != "secret-my/path" {
+			t.Fatalf("Unexpected value %s", value)
+		}
+	}
+	if backend.calls != 1 {
+		t.Fatalf("Expected secret to be cached, but backend was called %d times", backend.calls)
+	}
+
+	_, err := Resolve("unknown:my/path")
+	if err == nil {
+		t.Fatal("Expected error for unknown backend")
+	}
+}
+
+func TestVaultBackend(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/kv/data/app":
+			fmt.Fprint(w, `{"data":{"data":{"password":"v2-secret"},"metadata":{"version":1}}}`)
+		case "/v1/secret/app":
+			fmt.Fprint(w, `{"data":{"password":"v1-secret"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	defer os.Setenv("VAULT_ADDR", os.Getenv("VAULT_ADDR"))
+	defer os.Setenv("VAULT_TOKEN", os.Getenv("VAULT_TOKEN"))
+	os.Setenv("VAULT_ADDR", server.URL)
+	os.Setenv("VAULT_TOKEN", "token")
+
+	backend := &vaultBackend{}
+	value, err := backend.Resolve("kv/data/app#password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "v2-secret" {
+		t.Fatalf("Unexpected value %s", value)
+	}
+
+	value, err = backend.Resolve("secret/app#password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "v1-secret" {
+		t.Fatalf("Unexpected value %s", value)
+	}
+
+	_, err = backend.Resolve("secret/app#missing")
+	if err == nil {
+		t.Fatal("Expected error for missing key")
+	}
+	_, err = backend.Resolve("secret/app")
+	if err == nil {
+		t.Fatal("Expected error for reference without key")
+	}
+	_, err = backend.Resolve("secret/other#password")
+	if err == nil {
+		t.Fatal("Expected error for missing secret")
+	}
+}
+
+func TestCommandBackends(t *testing.T) {
+	var executed string
+	defer func(old func(string, ...string) ([]byte, error)) { runCommand = old }(runCommand)
+	runCommand = func(name string, args ...string) ([]byte, error) {
+		executed = name + " " + strings.Join(args, " ")
+		return []byte("value\n"), nil
+	}
+
+	value, err := (&awsSSMBackend{}).Resolve("/app/password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "value" {
+		t.Fatalf("Unexpected value %s", value)
+	}
+	if executed != "aws ssm get-parameter --name /app/password --with-decryption --query Parameter.Value --output text" {
+		t.Fatalf("Unexpected command %s", executed)
+	}
+
+	_, err = (&gcpSecretManagerBackend{}).Resolve("projects/my-project/secrets/password/versions/3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if executed != "gcloud secrets versions access 3 --secret password --project my-project" {
+		t.Fatalf("Unexpected command %s", executed)
+	}
+
+	_, err = (&gcpSecretManagerBackend{}).Resolve("projects/my-project/secrets/password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if executed != "gcloud secrets versions access latest --secret password --project my-project" {
+		t.Fatalf("Unexpected command %s", executed)
+	}
+
+	_, err = (&gcpSecretManagerBackend{}).Resolve("my-project/password")
+	if err == nil {
+		t.Fatal("Expected error for invalid secret name")
+	}
+}
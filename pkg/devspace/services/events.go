@@ -0,0 +1,416 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// eventRetryDelay is the time to wait before the event watch is reestablished
+const eventRetryDelay = 2 * time.Second
+
+// releaseLabels are the labels helm charts use to mark the release a resource belongs to
+var releaseLabels = []string{"release", "app.kubernetes.io/instance"}
+
+// EventFilter decides if an event belongs to a resource that is managed by devspace. A resource is managed by
+// devspace if it matches one of the configured label selectors or if it belongs to a release of a deployment
+type EventFilter struct {
+	client         kubernetes.Interface
+	labelSelectors []map[string]string
+	deployments    map[string]bool
+
+	labelsCache     map[string]map[string]string
+	labelsCacheLock sync.Mutex
+}
+
+// NewEventFilter creates a new event filter from the selectors and deployments of the config
+func NewEventFilter(config *latest.Config, client kubernetes.Interface) *EventFilter {
+	filter := &EventFilter{
+		client:         client,
+		labelSelectors: []map[string]string{},
+		deployments:    map[string]bool{},
+		labelsCache:    map[string]map[string]string{},
+	}
+
+	if config == nil {
+		return filter
+	}
+
+	if config.Deployments != nil {
+		for _, deployment := range *config.Deployments {
+			if deployment.Name != nil {
+				filter.deployments[*deployment.Name] = true
+			}
+		}
+	}
+
+	if config.Dev != nil {
+		if config.Dev.Selectors != nil {
+			for _, selector := range *config.Dev.Selectors {
+				filter.addLabelSelector(selector.LabelSelector)
+			}
+		}
+		if config.Dev.Ports != nil {
+			for _, port := range *config.Dev.Ports {
+				filter.addLabelSelector(port.LabelSelector)
+			}
+		}
+		if config.Dev.Sync != nil {
+			for _, syncConfig := range *config.Dev.Sync {
+				filter.addLabelSelector(syncConfig.LabelSelector)
+			}
+		}
+		if config.Dev.Terminal != nil {
+			filter.addLabelSelector(config.Dev.Terminal.LabelSelector)
+		}
+	}
+
+	return filter
+}
+
+func (f *EventFilter) addLabelSelector(labelSelector *map[string]*string) {
+	if labelSelector == nil || len(*labelSelector) == 0 {
+		return
+	}
+
+	selector := map[string]string{}
+	for key, value := range *labelSelector {
+		if value != nil {
+			selector[key] = *value
+		}
+	}
+
+	f.labelSelectors = append(f.labelSelectors, selector)
+}
+
+// Matches returns true if the involved object of the event is managed by devspace
+func (f *EventFilter) Matches(event *v1.Event) bool {
+	objectLabels, err := f.getLabels(&event.InvolvedObject)
+	if err != nil {
+		return false
+	}
+
+	for _, label := range releaseLabels {
+		if f.deployments[objectLabels[label]] {
+			return true
+		}
+	}
+
+	for _, selector := range f.labelSelectors {
+		matches := true
+		for key, value := range selector {
+			if objectLabels[key] != value {
+				matches = false
+				break
+			}
+		}
+
+		if matches {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getLabels returns the labels of the referenced object and caches them by the object uid
+func (f *EventFilter) getLabels(ref *v1.ObjectReference) (map[string]string, error) {
+	f.labelsCacheLock.Lock()
+	defer f.labelsCacheLock.Unlock()
+
+	if objectLabels, ok := f.labelsCache[string(ref.UID)]; ok && ref.UID != "" {
+		return objectLabels, nil
+	}
+
+	objectLabels, err := getObjectLabels(f.client, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if ref.UID != "" {
+		f.labelsCache[string(ref.UID)] = objectLabels
+	}
+
+	return objectLabels, nil
+}
+
+func getObjectLabels(client kubernetes.Interface, ref *v1.ObjectReference) (map[string]string, error) {
+	var (
+		objectMeta *metav1.ObjectMeta
+		err        error
+	)
+
+	switch ref.Kind {
+	case "Pod":
+		object, getErr := client.CoreV1().Pods(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if getErr == nil {
+			objectMeta = &object.ObjectMeta
+		}
+		err = getErr
+	case "Service":
+		object, getErr := client.CoreV1().Services(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if getErr == nil {
+			objectMeta = &object.ObjectMeta
+		}
+		err = getErr
+	case "PersistentVolumeClaim":
+		object, getErr := client.CoreV1().PersistentVolumeClaims(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if getErr == nil {
+			objectMeta = &object.ObjectMeta
+		}
+		err = getErr
+	case "Deployment":
+		object, getErr := kubectl.GetDeployment(client, ref.Namespace, ref.Name)
+		if getErr == nil {
+			objectMeta = &object.ObjectMeta
+		}
+		err = getErr
+	case "ReplicaSet":
+		object, getErr := client.AppsV1().ReplicaSets(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if getErr == nil {
+			objectMeta = &object.ObjectMeta
+		}
+		err = getErr
+	case "StatefulSet":
+		object, getErr := client.AppsV1().StatefulSets(ref.Namespace).Get(ref.Name, metav1.GetOptions{})
+		if getErr == nil {
+			objectMeta = &object.ObjectMeta
+		}
+		err = getErr
+	default:
+		// This is a bad guess, but works for most resources
+		resource, _ := meta.UnsafeGuessKindToResource(ref.GroupVersionKind())
+		out, getErr := client.CoreV1().RESTClient().Get().AbsPath(makeURLSegments(resource.Group, resource.Version, resource.Resource, ref.Namespace, ref.Name)...).DoRaw()
+		if getErr == nil {
+			object := struct {
+				Metadata metav1.ObjectMeta `json:"metadata"`
+			}{}
+
+			getErr = json.Unmarshal(out, &object)
+			objectMeta = &object.Metadata
+		}
+		err = getErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return objectMeta.Labels, nil
+}
+
+func makeURLSegments(group, version, resource, namespace, name string) []string {
+	url := []string{}
+	if len(group) == 0 {
+		url = append(url, "api")
+	} else {
+		url = append(url, "apis", group)
+	}
+	url = append(url, version)
+
+	if len(namespace) > 0 {
+		url = append(url, "namespaces", namespace)
+	}
+
+	return append(url, resource, name)
+}
+
+// GetEvents returns the current events of devspace managed resources in the namespace sorted by time
+func GetEvents(client kubernetes.Interface, filter *EventFilter, namespace string, warningsOnly bool) ([]*v1.Event, error) {
+	eventList, err := client.CoreV1().Events(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list events")
+	}
+
+	events := []*v1.Event{}
+	for i := range eventList.Items {
+		event := &eventList.Items[i]
+		if warningsOnly && event.Type != v1.EventTypeWarning {
+			continue
+		}
+		if filter.Matches(event) == false {
+			continue
+		}
+
+		events = append(events, event)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return getEventTime(events[i]).Before(getEventTime(events[j]))
+	})
+
+	return events, nil
+}
+
+// PrintEvent prints the event to the log. Warnings are printed as warnings, all other events as info
+func PrintEvent(event *v1.Event, log log.Logger) {
+	message := FormatEvent(event)
+	if event.Type == v1.EventTypeWarning {
+		log.Warn(message)
+	} else {
+		log.Info(message)
+	}
+}
+
+// FormatEvent returns a single line describing the event
+func FormatEvent(event *v1.Event) string {
+	message := fmt.Sprintf("%s %s/%s: %s", event.Reason, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Message)
+	if event.Count > 1 {
+		message += fmt.Sprintf(" (%dx)", event.Count)
+	}
+
+	return message
+}
+
+func getEventTime(event *v1.Event) time.Time {
+	if event.LastTimestamp.IsZero() == false {
+		return event.LastTimestamp.Time
+	}
+	if event.EventTime.IsZero() == false {
+		return event.EventTime.Time
+	}
+
+	return event.CreationTimestamp.Time
+}
+
+// EventLogger follows the events of devspace managed resources and prints them to the log
+type EventLogger struct {
+	client       kubernetes.Interface
+	filter       *EventFilter
+	namespace    string
+	warningsOnly bool
+	log          log.Logger
+
+	errChan  chan error
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+// StartEventLogging starts printing new events of devspace managed resources in the namespace. If warningsOnly is
+// true, only events of type Warning (e.g. FailedScheduling or Unhealthy) are printed
+func StartEventLogging(config *latest.Config, client kubernetes.Interface, namespace string, warningsOnly bool, log log.Logger) (*EventLogger, error) {
+	eventLogger := &EventLogger{
+		client:       client,
+		filter:       NewEventFilter(config, client),
+		namespace:    namespace,
+		warningsOnly: warningsOnly,
+		log:          log,
+		errChan:      make(chan error, 1),
+		stopChan:     make(chan struct{}),
+	}
+
+	// Only print events that occur from now on
+	eventList, err := client.CoreV1().Events(namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list events")
+	}
+
+	go eventLogger.run(eventList.ResourceVersion)
+	return eventLogger, nil
+}
+
+// Errors returns the errors of the event watch. The event logger keeps retrying after an error, so the channel only
+// needs to be read if the events are essential, e.g. when following the events
+func (e *EventLogger) Errors() <-chan error {
+	return e.errChan
+}
+
+// Close stops the event logging
+func (e *EventLogger) Close() {
+	e.stopOnce.Do(func() {
+		close(e.stopChan)
+	})
+}
+
+// run watches the events and reestablishes the watch until the event logger is closed
+func (e *EventLogger) run(resourceVersion string) {
+	for {
+		if resourceVersion == "" {
+			eventList, err := e.client.CoreV1().Events(e.namespace).List(metav1.ListOptions{})
+			if err == nil {
+				resourceVersion = eventList.ResourceVersion
+			} else {
+				e.log.Debugf("Error listing events: %v", err)
+			}
+		}
+		if resourceVersion != "" {
+			resourceVersion = e.watch(resourceVersion)
+		}
+
+		select {
+		case <-e.stopChan:
+			return
+		case <-time.After(eventRetryDelay):
+		}
+	}
+}
+
+// watch prints the events until the watch is closed and returns the last seen resource version
+func (e *EventLogger) watch(resourceVersion string) string {
+	watcher, err := e.client.CoreV1().Events(e.namespace).Watch(metav1.ListOptions{ResourceVersion: resourceVersion})
+	if err != nil {
+		e.log.Debugf("Error watching events: %v", err)
+		e.reportError(err)
+		return resourceVersion
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-e.stopChan:
+			return resourceVersion
+		case watchEvent, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
+
+			if watchEvent.Type == watch.Error {
+				// The resource version is too old, so we list the events again without printing them
+				if status, ok := watchEvent.Object.(*metav1.Status); ok && status.Code == 410 {
+					return ""
+				}
+
+				err := kerrors.FromObject(watchEvent.Object)
+				e.log.Debugf("Error watching events: %v", err)
+				e.reportError(err)
+				return resourceVersion
+			}
+
+			event, ok := watchEvent.Object.(*v1.Event)
+			if !ok {
+				continue
+			}
+
+			resourceVersion = event.ResourceVersion
+			if watchEvent.Type == watch.Deleted {
+				continue
+			}
+			if e.warningsOnly && event.Type != v1.EventTypeWarning {
+				continue
+			}
+			if e.filter.Matches(event) {
+				PrintEvent(event, e.log)
+			}
+		}
+	}
+}
+
+// reportError passes the error to the reader of the error channel without blocking the event logger
+func (e *EventLogger) reportError(err error) {
+	select {
+	case e.errChan <- err:
+	default:
+	}
+}
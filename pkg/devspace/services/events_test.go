@@ -0,0 +1,120 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetEvents(t *testing.T) {
+	config := &latest.Config{
+		Deployments: &[]*latest.DeploymentConfig{
+			{Name: ptr.String("backend")},
+		},
+		Dev: &latest.DevConfig{
+			Selectors: &[]*latest.SelectorConfig{
+				{
+					Name:          ptr.String("frontend"),
+					LabelSelector: &map[string]*string{"app": ptr.String("frontend")},
+				},
+			},
+		},
+	}
+
+	now := time.Now()
+	client := fake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "backend", Namespace: "default", UID: "1", Labels: map[string]string{"release": "backend"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "frontend", Namespace: "default", UID: "2", Labels: map[string]string{"app": "frontend", "tier": "web"}}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "3", Labels: map[string]string{"app": "other"}}},
+		newTestEvent("backend-unhealthy", "backend", v1.EventTypeWarning, "Unhealthy", now.Add(-time.Minute)),
+		newTestEvent("frontend-scheduling", "frontend", v1.EventTypeWarning, "FailedScheduling", now.Add(-2*time.Minute)),
+		newTestEvent("frontend-pulled", "frontend", v1.EventTypeNormal, "Pulled", now),
+		newTestEvent("other-unhealthy", "other", v1.EventTypeWarning, "Unhealthy", now),
+		newTestEvent("deleted-unhealthy", "deleted", v1.EventTypeWarning, "Unhealthy", now),
+	)
+
+	events, err := GetEvents(client, NewEventFilter(config, client), "default", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"frontend-scheduling", "backend-unhealthy", "frontend-pulled"}
+	if len(events) != len(expected) {
+		t.Fatalf("Expected %d events, got %d", len(expected), len(events))
+	}
+	for i, name := range expected {
+		if events[i].Name != name {
+			t.Fatalf("Expected event %d to be %s, got %s", i, name, events[i].Name)
+		}
+	}
+
+	events, err = GetEvents(client, NewEventFilter(config, client), "default", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 warning events, got %d", len(events))
+	}
+}
+
+func TestEventLoggerErrors(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &v1.EventList{ListMeta: metav1.ListMeta{ResourceVersion: "1"}}, nil
+	})
+	client.PrependWatchReactor("events", func(action k8stesting.Action) (bool, watch.Interface, error) {
+		return true, nil, kerrors.NewForbidden(schema.GroupResource{Resource: "events"}, "", nil)
+	})
+
+	eventLogger, err := StartEventLogging(&latest.Config{}, client, "default", false, log.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eventLogger.Close()
+
+	select {
+	case err := <-eventLogger.Errors():
+		if kerrors.IsForbidden(err) == false {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second * 5):
+		t.Fatal("Watch error was not reported")
+	}
+}
+
+func TestFormatEvent(t *testing.T) {
+	event := newTestEvent("test", "backend", v1.EventTypeWarning, "Unhealthy", time.Now())
+	event.Count = 3
+
+	message := FormatEvent(event)
+	if message != "Unhealthy Pod/backend: test message (3x)" {
+		t.Fatalf("Unexpected message: %s", message)
+	}
+}
+
+func newTestEvent(name, podName, eventType, reason string, timestamp time.Time) *v1.Event {
+	return &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: v1.ObjectReference{
+			Kind:      "Pod",
+			Name:      podName,
+			Namespace: "default",
+		},
+		Type:          eventType,
+		Reason:        reason,
+		Message:       "test message",
+		Count:         1,
+		LastTimestamp: metav1.NewTime(timestamp),
+	}
+}
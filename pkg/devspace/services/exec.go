@@ -0,0 +1,85 @@
+package services
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	logpkg "github.com/devspace-cloud/devspace/pkg/util/log"
+
+	"github.com/mgutz/ansi"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+	kubectlExec "k8s.io/client-go/util/exec"
+)
+
+// ExecResult is the result of a command that was executed in a single container
+type ExecResult struct {
+	Pod       string
+	Container string
+
+	// ExitCode is the exit code of the command or -1 if the command could not be executed
+	ExitCode int
+	Err      error
+}
+
+// ExecInAllContainers executes the command concurrently in every running pod that matches the parameters. Every line
+// of output is prefixed with the pod name. The returned results are in the order of the selected pods
+func ExecInAllContainers(config *latest.Config, client kubernetes.Interface, selectorParameter *targetselector.SelectorParameter, command []string, stdout io.Writer, stderr io.Writer, log logpkg.Logger) ([]*ExecResult, error) {
+	targetSelector, err := targetselector.NewTargetSelector(config, selectorParameter, false)
+	if err != nil {
+		return nil, err
+	}
+
+	containers, err := targetSelector.GetAllContainers(client)
+	if err != nil {
+		return nil, err
+	}
+	if len(containers) == 0 {
+		return nil, errors.New("Couldn't find any running pod that matches the selection")
+	}
+
+	restConfig, err := kubectl.GetRestConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Executing command in %d containers", len(containers))
+
+	results := make([]*ExecResult, len(containers))
+	waitGroup := sync.WaitGroup{}
+	for index, podContainer := range containers {
+		waitGroup.Add(1)
+
+		go func(index int, podContainer *targetselector.PodContainer) {
+			defer waitGroup.Done()
+
+			prefix := ansi.Color(fmt.Sprintf("[%s:%s] ", podContainer.Pod.Name, podContainer.Container.Name), "white+b")
+			stdoutWriter := logpkg.NewPrefixWriter(prefix, stdout)
+			stderrWriter := logpkg.NewPrefixWriter(prefix, stderr)
+
+			execErr := kubectl.ExecStream(restConfig, podContainer.Pod, podContainer.Container.Name, command, false, nil, stdoutWriter, stderrWriter)
+			stdoutWriter.Flush()
+			stderrWriter.Flush()
+
+			results[index] = &ExecResult{
+				Pod:       podContainer.Pod.Name,
+				Container: podContainer.Container.Name,
+			}
+			if execErr != nil {
+				if exitError, ok := execErr.(kubectlExec.CodeExitError); ok {
+					results[index].ExitCode = exitError.Code
+				} else {
+					results[index].ExitCode = -1
+					results[index].Err = execErr
+				}
+			}
+		}(index, podContainer)
+	}
+
+	waitGroup.Wait()
+	return results, nil
+}
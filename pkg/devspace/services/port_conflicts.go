@@ -0,0 +1,165 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+)
+
+// PortClaim is a local port that is claimed by a port mapping of a config
+type PortClaim struct {
+	// Owner is the config the port mapping belongs to, e.g. devspace.yaml or dependency my-dependency
+	Owner string
+	// Path is the path of the port mapping within the config, e.g. dev.ports[0].forward[1]
+	Path string
+
+	LocalPort   int
+	RemotePort  int
+	BindAddress *string
+}
+
+// String returns a human readable description of the claim
+func (p *PortClaim) String() string {
+	address := "127.0.0.1"
+	if p.BindAddress != nil {
+		address = *p.BindAddress
+	}
+
+	return fmt.Sprintf("%s %s (%s:%d -> %d)", p.Owner, p.Path, address, p.LocalPort, p.RemotePort)
+}
+
+// PortConflict is a local port that is claimed by more than one port mapping
+type PortConflict struct {
+	LocalPort int
+	Claims    []*PortClaim
+}
+
+// String returns a human readable description of the conflict
+func (p *PortConflict) String() string {
+	claims := make([]string, 0, len(p.Claims))
+	for _, claim := range p.Claims {
+		claims = append(claims, claim.String())
+	}
+
+	return fmt.Sprintf("Local port %d is used by: %s", p.LocalPort, strings.Join(claims, ", "))
+}
+
+// GetPortClaims returns the local ports that the port forwarding of the config would use
+func GetPortClaims(owner string, config *latest.Config) []*PortClaim {
+	claims := []*PortClaim{}
+	if config == nil || config.Dev == nil || config.Dev.Ports == nil {
+		return claims
+	}
+
+	for portConfigIndex, portForwarding := range *config.Dev.Ports {
+		if portForwarding.PortMappings == nil {
+			continue
+		}
+
+		for index, portMapping := range *portForwarding.PortMappings {
+			if portMapping.LocalPort == nil {
+				continue
+			}
+
+			remotePort := *portMapping.LocalPort
+			if portMapping.RemotePort != nil {
+				remotePort = *portMapping.RemotePort
+			}
+
+			claims = append(claims, &PortClaim{
+				Owner:       owner,
+				Path:        fmt.Sprintf("dev.ports[%d].forward[%d]", portConfigIndex, index),
+				LocalPort:   *portMapping.LocalPort,
+				RemotePort:  remotePort,
+				BindAddress: portMapping.BindAddress,
+			})
+		}
+	}
+
+	return claims
+}
+
+// FindPortConflicts returns all local ports that are claimed more than once on overlapping bind addresses,
+// sorted by port
+func FindPortConflicts(claims []*PortClaim) []*PortConflict {
+	conflicts := map[int]*PortConflict{}
+
+	for i, claim := range claims {
+		for _, other := range claims[i+1:] {
+			if claim.LocalPort != other.LocalPort || BindAddressesOverlap(claim.BindAddress, other.BindAddress) == false {
+				continue
+			}
+
+			conflict, ok := conflicts[claim.LocalPort]
+			if !ok {
+				conflict = &PortConflict{LocalPort: claim.LocalPort}
+				conflicts[claim.LocalPort] = conflict
+			}
+
+			conflict.addClaim(claim)
+			conflict.addClaim(other)
+		}
+	}
+
+	retConflicts := make([]*PortConflict, 0, len(conflicts))
+	for _, conflict := range conflicts {
+		retConflicts = append(retConflicts, conflict)
+	}
+
+	sort.Slice(retConflicts, func(i, j int) bool {
+		return retConflicts[i].LocalPort < retConflicts[j].LocalPort
+	})
+
+	return retConflicts
+}
+
+func (p *PortConflict) addClaim(claim *PortClaim) {
+	for _, existing := range p.Claims {
+		if existing == claim {
+			return
+		}
+	}
+
+	p.Claims = append(p.Claims, claim)
+}
+
+// BindAddressesOverlap checks if two local ports with the given bind addresses would conflict
+func BindAddressesOverlap(first, second *string) bool {
+	normalize := func(address *string) string {
+		if address == nil || *address == "localhost" {
+			return "127.0.0.1"
+		}
+		if ip := net.ParseIP(*address); ip != nil && ip.IsUnspecified() {
+			return ""
+		}
+
+		return *address
+	}
+
+	firstAddress, secondAddress := normalize(first), normalize(second)
+	return firstAddress == "" || secondAddress == "" || firstAddress == secondAddress
+}
+
+// IsLocalPortInUse checks if the local port of a port mapping accepts connections, e.g. because the port
+// forwarding of a running devspace dev is active
+func IsLocalPortInUse(localPort int, bindAddress *string) bool {
+	address := "127.0.0.1"
+	if bindAddress != nil {
+		if ip := net.ParseIP(*bindAddress); ip == nil || ip.IsUnspecified() == false {
+			address = *bindAddress
+		}
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(address, strconv.Itoa(localPort)), 500*time.Millisecond)
+	if err != nil {
+		return false
+	}
+
+	conn.Close()
+	return true
+}
@@ -4,21 +4,45 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/tools/portforward"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/health"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 )
 
-// StartPortForwarding starts the port forwarding functionality
-func StartPortForwarding(config *latest.Config, client kubernetes.Interface, log log.Logger) ([]*portforward.PortForwarder, error) {
+// portForwardHealthInterval is the interval in which the target pod of a port forwarding is checked
+const portForwardHealthInterval = 5 * time.Second
+
+// PortForwarder forwards the ports of a single port forwarding config and reestablishes
+// the forwarding to a new pod if the target pod is restarted or the connection is lost
+type PortForwarder struct {
+	config    *latest.Config
+	client    kubernetes.Interface
+	selector  *targetselector.TargetSelector
+	ports     []string
+	addresses []string
+	health    *health.Reporter
+	log       log.Logger
+
+	stopChan   chan struct{}
+	stopOnce   sync.Once
+	unregister func()
+}
+
+// StartPortForwarding starts the port forwarding functionality. The state of the port forwardings is reported
+// to the health reporter, which may be nil
+func StartPortForwarding(config *latest.Config, client kubernetes.Interface, healthReporter *health.Reporter, log log.Logger) ([]*PortForwarder, error) {
 	if config.Dev.Ports != nil {
-		portforwarder := make([]*portforward.PortForwarder, 0, len(*config.Dev.Ports))
+		portforwarder := make([]*PortForwarder, 0, len(*config.Dev.Ports))
 
 		for portConfigIndex, portForwarding := range *config.Dev.Ports {
 			selector, err := targetselector.NewTargetSelector(config, &targetselector.SelectorParameter{
@@ -26,68 +50,266 @@ func StartPortForwarding(config *latest.Config, client kubernetes.Interface, log
 					Selector:      portForwarding.Selector,
 					Namespace:     portForwarding.Namespace,
 					LabelSelector: portForwarding.LabelSelector,
+					ImageName:     portForwarding.ImageName,
 				},
 			}, false)
 			if err != nil {
 				return nil, fmt.Errorf("Error creating target selector: %v", err)
 			}
 
-			log.StartWait("Port-Forwarding: Waiting for pods...")
-			pod, err := selector.GetPod(client)
-			log.StopWait()
-			if err != nil {
-				return nil, fmt.Errorf("Error starting port-forwarding: Unable to list devspace pods: %s", err.Error())
-			} else if pod != nil {
-				ports := make([]string, len(*portForwarding.PortMappings))
-				addresses := make([]string, len(*portForwarding.PortMappings))
-
-				for index, value := range *portForwarding.PortMappings {
-					if value.LocalPort == nil {
-						return nil, fmt.Errorf("port is not defined in portmapping %d:%d", portConfigIndex, index)
-					}
-
-					localPort := strconv.Itoa(*value.LocalPort)
-					remotePort := localPort
-					if value.RemotePort != nil {
-						remotePort = strconv.Itoa(*value.RemotePort)
-					}
-
-					ports[index] = localPort + ":" + remotePort
-					if value.BindAddress == nil {
-						addresses[index] = "127.0.0.1"
-					} else {
-						addresses[index] = *value.BindAddress
-					}
+			for index, value := range *portForwarding.PortMappings {
+				if value.LocalPort == nil {
+					return nil, fmt.Errorf("port is not defined in portmapping %d:%d", portConfigIndex, index)
 				}
+			}
 
-				readyChan := make(chan struct{})
+			pf, err := startPortForwarder(config, client, selector, *portForwarding.PortMappings, healthReporter, log)
+			if err != nil {
+				return nil, err
+			} else if pf != nil {
+				portforwarder = append(portforwarder, pf)
+			}
+		}
 
-				pf, err := kubectl.NewPortForwarder(config, client, pod, ports, addresses, make(chan struct{}), readyChan)
-				if err != nil {
-					return nil, fmt.Errorf("Error starting port forwarding: %v", err)
-				}
+		return portforwarder, nil
+	}
+
+	return nil, nil
+}
+
+// StartPortForwardingFromCmd forwards the port mappings to the pod that is selected with the command parameters.
+// The config may be nil
+func StartPortForwardingFromCmd(config *latest.Config, client kubernetes.Interface, cmdParameter targetselector.CmdParameter, portMappings []*latest.PortMapping, log log.Logger) (*PortForwarder, error) {
+	selector, err := targetselector.NewTargetSelector(config, &targetselector.SelectorParameter{
+		CmdParameter: cmdParameter,
+	}, true)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating target selector: %v", err)
+	}
+
+	pf, err := startPortForwarder(config, client, selector, portMappings, nil, log)
+	if err != nil {
+		return nil, err
+	} else if pf == nil {
+		return nil, fmt.Errorf("Error starting port-forwarding: No pod found")
+	}
+
+	return pf, nil
+}
+
+// ParsePortMappings parses port mappings in the format localPort[:remotePort] (e.g. 8080:80)
+func ParsePortMappings(mappings []string, bindAddress string) ([]*latest.PortMapping, error) {
+	portMappings := make([]*latest.PortMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		ports := strings.Split(mapping, ":")
+		if len(ports) > 2 {
+			return nil, fmt.Errorf("Invalid port mapping %s, expected localPort[:remotePort]", mapping)
+		}
+
+		portMapping := &latest.PortMapping{}
+		for index, port := range ports {
+			portNumber, err := strconv.Atoi(port)
+			if err != nil || portNumber <= 0 || portNumber > 65535 {
+				return nil, fmt.Errorf("Invalid port %s in port mapping %s", port, mapping)
+			}
+
+			if index == 0 {
+				portMapping.LocalPort = &portNumber
+			} else {
+				portMapping.RemotePort = &portNumber
+			}
+		}
+		if bindAddress != "" {
+			portMapping.BindAddress = &bindAddress
+		}
+
+		portMappings = append(portMappings, portMapping)
+	}
+
+	return portMappings, nil
+}
+
+// startPortForwarder starts forwarding the port mappings to the selected pod. Returns nil if no pod was found
+func startPortForwarder(config *latest.Config, client kubernetes.Interface, selector *targetselector.TargetSelector, portMappings []*latest.PortMapping, healthReporter *health.Reporter, log log.Logger) (*PortForwarder, error) {
+	log.StartWait("Port-Forwarding: Waiting for pods...")
+	pod, err := selector.GetPod(client)
+	log.StopWait()
+	if err != nil {
+		return nil, fmt.Errorf("Error starting port-forwarding: Unable to list devspace pods: %s", err.Error())
+	} else if pod == nil {
+		return nil, nil
+	}
+
+	ports := make([]string, len(portMappings))
+	addresses := make([]string, len(portMappings))
+
+	for index, value := range portMappings {
+		localPort := strconv.Itoa(*value.LocalPort)
+		remotePort := localPort
+		if value.RemotePort != nil {
+			remotePort = strconv.Itoa(*value.RemotePort)
+		}
+
+		ports[index] = localPort + ":" + remotePort
+		if value.BindAddress == nil {
+			addresses[index] = "127.0.0.1"
+		} else {
+			addresses[index] = *value.BindAddress
+		}
+	}
+
+	pf := &PortForwarder{
+		config:    config,
+		client:    client,
+		selector:  selector,
+		ports:     ports,
+		addresses: addresses,
+		health:    healthReporter,
+		log:       log,
+		stopChan:  make(chan struct{}),
+	}
 
-				go func() {
-					err := pf.ForwardPorts()
-					if err != nil {
-						log.Errorf("Error forwarding ports: %v", err)
-					}
-				}()
-
-				// Wait till forwarding is ready
-				select {
-				case <-readyChan:
-					log.Donef("Port forwarding started on %s", strings.Join(ports, ", "))
-
-					portforwarder = append(portforwarder, pf)
-				case <-time.After(20 * time.Second):
-					return nil, fmt.Errorf("Timeout waiting for port forwarding to start")
+	// Stop the port forwarding on termination
+	pf.unregister = interrupt.Register(pf.Close)
+
+	readyChan := make(chan struct{})
+	go pf.run(pod, readyChan)
+
+	// Wait till forwarding is ready
+	select {
+	case <-readyChan:
+		log.Donef("Port forwarding started on %s", strings.Join(ports, ", "))
+		return pf, nil
+	case <-time.After(20 * time.Second):
+		pf.Close()
+		return nil, fmt.Errorf("Timeout waiting for port forwarding to start")
+	}
+}
+
+// serviceName returns the name of the port forwarding for the health reporter
+func (p *PortForwarder) serviceName() string {
+	return "portforwarding " + strings.Join(p.ports, ", ")
+}
+
+// Close stops the port forwarding
+func (p *PortForwarder) Close() {
+	p.stopOnce.Do(func() {
+		close(p.stopChan)
+		if p.unregister != nil {
+			p.unregister()
+		}
+	})
+}
+
+// run forwards the ports to the given pod and reconnects to a newly selected pod until the port forwarder is closed
+func (p *PortForwarder) run(pod *v1.Pod, ready chan struct{}) {
+	connected := false
+
+	for {
+		if pod != nil {
+			err := p.forward(pod, func() {
+				p.health.SetService(p.serviceName(), health.StateRunning, nil)
+				if connected {
+					p.log.Donef("Port-Forwarding: Reconnected to pod %s/%s on %s", pod.Namespace, pod.Name, strings.Join(p.ports, ", "))
+					return
 				}
+
+				connected = true
+				close(ready)
+			})
+			if p.isStopped() {
+				return
 			}
+
+			p.health.SetService(p.serviceName(), health.StateReconnecting, err)
+
+			p.log.Infof("Port-Forwarding: Connection to pod %s/%s lost (%v), reconnecting...", pod.Namespace, pod.Name, err)
 		}
 
-		return portforwarder, nil
+		interrupt.Sleep(portForwardRetryDelay)
+		if p.isStopped() {
+			return
+		}
+
+		// Select the pod again, because the old one might have been replaced
+		newPod, err := p.selector.GetPod(p.client)
+		if err != nil || newPod == nil {
+			p.log.Infof("Port-Forwarding: Couldn't find a running pod (%v), retrying...", err)
+			pod = nil
+			continue
+		}
+
+		pod = newPod
 	}
+}
 
-	return nil, nil
+// forward forwards the ports to the pod until the connection is lost, the pod is replaced or the port forwarder is closed
+func (p *PortForwarder) forward(pod *v1.Pod, onReady func()) error {
+	stopChan := make(chan struct{})
+	readyChan := make(chan struct{})
+
+	pf, err := kubectl.NewPortForwarder(p.config, p.client, pod, p.ports, p.addresses, stopChan, readyChan)
+	if err != nil {
+		return err
+	}
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- pf.ForwardPorts()
+	}()
+
+	ticker := time.NewTicker(portForwardHealthInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-readyChan:
+			onReady()
+			readyChan = nil
+		case err := <-errChan:
+			if err == nil {
+				err = fmt.Errorf("connection closed")
+			}
+
+			return err
+		case <-p.stopChan:
+			close(stopChan)
+			return <-errChan
+		case <-ticker.C:
+			err := checkPortForwardPod(p.client, pod)
+			if err != nil {
+				close(stopChan)
+				<-errChan
+				return err
+			}
+		}
+	}
+}
+
+func (p *PortForwarder) isStopped() bool {
+	select {
+	case <-p.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+// checkPortForwardPod returns an error if the given pod was deleted, replaced or is not running anymore
+func checkPortForwardPod(client kubernetes.Interface, pod *v1.Pod) error {
+	currentPod, err := client.CoreV1().Pods(pod.Namespace).Get(pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("pod %s/%s not found: %v", pod.Namespace, pod.Name, err)
+	}
+	if currentPod.UID != pod.UID {
+		return fmt.Errorf("pod %s/%s was replaced", pod.Namespace, pod.Name)
+	}
+	if currentPod.DeletionTimestamp != nil {
+		return fmt.Errorf("pod %s/%s is terminating", pod.Namespace, pod.Name)
+	}
+	if currentPod.Status.Phase != v1.PodRunning {
+		return fmt.Errorf("pod %s/%s has status %s", pod.Namespace, pod.Name, kubectl.GetPodStatus(currentPod))
+	}
+
+	return nil
 }
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCheckPortForwardPod(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "1"},
+		Status:     v1.PodStatus{Phase: v1.PodRunning},
+	}
+
+	client := fake.NewSimpleClientset(pod)
+	err := checkPortForwardPod(client, pod)
+	if err != nil {
+		t.Fatalf("Unexpected error for running pod: %v", err)
+	}
+
+	// Replaced pod with the same name
+	replacedPod := pod.DeepCopy()
+	replacedPod.UID = "2"
+	client = fake.NewSimpleClientset(replacedPod)
+	err = checkPortForwardPod(client, pod)
+	if err == nil {
+		t.Fatal("Expected error for replaced pod")
+	}
+
+	// Pod that is not running anymore
+	failedPod := pod.DeepCopy()
+	failedPod.Status.Phase = v1.PodFailed
+	client = fake.NewSimpleClientset(failedPod)
+	err = checkPortForwardPod(client, pod)
+	if err == nil {
+		t.Fatal("Expected error for failed pod")
+	}
+
+	// Deleted pod
+	client = fake.NewSimpleClientset()
+	err = checkPortForwardPod(client, pod)
+	if err == nil {
+		t.Fatal("Expected error for deleted pod")
+	}
+}
+
+func TestFindPortConflicts(t *testing.T) {
+	config := &latest.Config{
+		Dev: &latest.DevConfig{
+			Ports: &[]*latest.PortForwardingConfig{
+				&latest.PortForwardingConfig{
+					PortMappings: &[]*latest.PortMapping{
+						&latest.PortMapping{LocalPort: ptr.Int(8080), RemotePort: ptr.Int(80)},
+						&latest.PortMapping{LocalPort: ptr.Int(9090), BindAddress: ptr.String("192.168.0.10")},
+					},
+				},
+				&latest.PortForwardingConfig{
+					PortMappings: &[]*latest.PortMapping{
+						&latest.PortMapping{LocalPort: ptr.Int(9090)},
+						&latest.PortMapping{LocalPort: ptr.Int(3000)},
+					},
+				},
+			},
+		},
+	}
+	dependencyConfig := &latest.Config{
+		Dev: &latest.DevConfig{
+			Ports: &[]*latest.PortForwardingConfig{
+				&latest.PortForwardingConfig{
+					PortMappings: &[]*latest.PortMapping{
+						&latest.PortMapping{LocalPort: ptr.Int(8080), BindAddress: ptr.String("0.0.0.0")},
+					},
+				},
+			},
+		},
+	}
+
+	claims := GetPortClaims("devspace.yaml", config)
+	if len(claims) != 4 {
+		t.Fatalf("Expected 4 claims, got %d", len(claims))
+	}
+
+	// The same port on different addresses is no conflict
+	conflicts := FindPortConflicts(claims)
+	if len(conflicts) != 0 {
+		t.Fatalf("Unexpected conflicts %v", conflicts)
+	}
+
+	claims = append(claims, GetPortClaims("dependency dep", dependencyConfig)...)
+	conflicts = FindPortConflicts(claims)
+	if len(conflicts) != 1 {
+		t.Fatalf("Expected 1 conflict, got %d", len(conflicts))
+	}
+
+	expected := "Local port 8080 is used by: devspace.yaml dev.ports[0].forward[0] (127.0.0.1:8080 -> 80), dependency dep dev.ports[0].forward[0] (0.0.0.0:8080 -> 8080)"
+	if conflicts[0].String() != expected {
+		t.Fatalf("Expected %s, got %s", expected, conflicts[0].String())
+	}
+}
+
+func TestParsePortMappings(t *testing.T) {
+	portMappings, err := ParsePortMappings([]string{"8080:80", "3000"}, "0.0.0.0")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(portMappings) != 2 {
+		t.Fatalf("Expected 2 port mappings, got %d", len(portMappings))
+	}
+	if *portMappings[0].LocalPort != 8080 || *portMappings[0].RemotePort != 80 || *portMappings[0].BindAddress != "0.0.0.0" {
+		t.Fatalf("Unexpected port mapping %d:%d", *portMappings[0].LocalPort, *portMappings[0].RemotePort)
+	}
+	if *portMappings[1].LocalPort != 3000 || portMappings[1].RemotePort != nil {
+		t.Fatal("Expected port mapping without remote port")
+	}
+
+	for _, invalid := range []string{"8080:80:90", "http", "0", "70000:80"} {
+		_, err = ParsePortMappings([]string{invalid}, "")
+		if err == nil {
+			t.Fatalf("Expected error for port mapping %s", invalid)
+		}
+	}
+}
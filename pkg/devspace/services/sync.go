@@ -2,7 +2,9 @@ package services
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,14 +13,20 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	gosync "sync"
+	"time"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
+	"github.com/devspace-cloud/devspace/pkg/devspace/settings"
 	"github.com/devspace-cloud/devspace/pkg/devspace/sync"
 	"github.com/devspace-cloud/devspace/pkg/devspace/upgrade"
+	"github.com/devspace-cloud/devspace/pkg/util/command"
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/pkg/errors"
@@ -33,14 +41,34 @@ const SyncHelperBaseURL = "https://github.com/devspace-cloud/devspace/releases"
 // SyncHelperTempFolder is the local folder where we store the sync helper
 const SyncHelperTempFolder = "sync"
 
+// syncHelperVersionTimeout is the time after which the sync helper version check in the container is aborted
+const syncHelperVersionTimeout = 30 * time.Second
+
+// SyncCompareByHash compares files that exist locally and in the container by their content during the initial sync
+const SyncCompareByHash = "hash"
+
 // SyncBinaryRegEx is the regexp that finds the correct download link for the sync helper binary
 var SyncBinaryRegEx = regexp.MustCompile(`href="(\/devspace-cloud\/devspace\/releases\/download\/[^\/]*\/sync)"`)
 
 // SyncHelperContainerPath is the path of the sync helper in the container
 const SyncHelperContainerPath = "/tmp/sync"
 
-// StartSyncFromCmd starts a new sync from command
-func StartSyncFromCmd(config *latest.Config, cmdParameter targetselector.CmdParameter, localPath, containerPath string, exclude []string, verbose bool, log log.Logger) error {
+// syncWorkers is shared by all syncs of the process to limit the change sets that are applied at the same time
+var syncWorkers sync.WorkerPool
+var syncWorkersOnce gosync.Once
+
+func getSyncWorkers() sync.WorkerPool {
+	syncWorkersOnce.Do(func() {
+		syncWorkers = sync.NewWorkerPool(settings.GetLimits().MaxSyncWorkers)
+	})
+
+	return syncWorkers
+}
+
+// StartSyncFromCmd starts a new sync from command. The config may be nil, because the sync target is selected with the
+// command parameters. If noWatch is true, the function returns after the initial sync instead of watching for changes
+func StartSyncFromCmd(config *latest.Config, cmdParameter targetselector.CmdParameter, localPath, containerPath string, exclude []string, verbose, noWatch bool, log log.Logger) error {
+
 	restConfig, err := kubectl.GetRestConfig(config)
 	if err != nil {
 		return errors.Wrap(err, "get kubernetes rest config")
@@ -78,9 +106,12 @@ func StartSyncFromCmd(config *latest.Config, cmdParameter targetselector.CmdPara
 	if len(exclude) > 0 {
 		syncConfig.ExcludePaths = &exclude
 	}
+	if noWatch {
+		syncConfig.WaitInitialSync = ptr.Bool(true)
+	}
 
 	log.StartWait("Starting sync...")
-	syncClient, err := startSync(restConfig, pod, container.Name, syncConfig, verbose, syncDone, log)
+	syncClient, err := startSync(restConfig, pod, container.Name, syncConfig, verbose, syncDone, nil, log)
 	log.StopWait()
 	if err != nil {
 		return errors.Wrap(err, "start sync")
@@ -93,6 +124,22 @@ func StartSyncFromCmd(config *latest.Config, cmdParameter targetselector.CmdPara
 
 	log.Donef("Sync started on %s <-> %s (Pod: %s/%s)", syncClient.LocalPath, containerPath, pod.Namespace, pod.Name)
 
+	if noWatch {
+		log.StartWait("Sync: waiting for intial sync to complete")
+		defer log.StopWait()
+
+		for _, initialSyncDone := range []chan bool{syncClient.Options.UpstreamInitialSyncDone, syncClient.Options.DownstreamInitialSyncDone} {
+			select {
+			case <-initialSyncDone:
+			case <-syncDone:
+				return errors.New("Sync stopped during initial sync")
+			}
+		}
+
+		syncClient.Stop(nil)
+		return nil
+	}
+
 	// Wait till sync is finished
 	<-syncDone
 
@@ -122,6 +169,7 @@ func StartSync(config *latest.Config, verboseSync bool, log log.Logger) ([]*sync
 				Selector:      syncConfig.Selector,
 				Namespace:     syncConfig.Namespace,
 				LabelSelector: syncConfig.LabelSelector,
+				ImageName:     syncConfig.ImageName,
 				ContainerName: syncConfig.ContainerName,
 			},
 		}, false)
@@ -137,7 +185,7 @@ func StartSync(config *latest.Config, verboseSync bool, log log.Logger) ([]*sync
 		}
 
 		log.StartWait("Starting sync...")
-		syncClient, err := startSync(restConfig, pod, container.Name, syncConfig, verboseSync, nil, nil)
+		syncClient, err := startSync(restConfig, pod, container.Name, syncConfig, verboseSync, nil, nil, nil)
 		log.StopWait()
 		if err != nil {
 			return nil, errors.Wrap(err, "start sync")
@@ -168,7 +216,47 @@ func StartSync(config *latest.Config, verboseSync bool, log log.Logger) ([]*sync
 	return syncClients, nil
 }
 
-func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfig *latest.SyncConfig, verbose bool, syncDone chan bool, customLog log.Logger) (*sync.Sync, error) {
+// StartBuildContextSync mirrors the local build context into the container path of a build pod. Files are only
+// uploaded and the function returns as soon as the initial sync is done. The returned channel is closed when the
+// sync stops, errors are passed to the error handler instead of exiting the process
+func StartBuildContextSync(config *latest.Config, pod *v1.Pod, container, contextPath, containerPath string, exclude []string, errorHandler func(err error)) (*sync.Sync, chan bool, error) {
+	restConfig, err := kubectl.GetRestConfig(config)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "get kubernetes rest config")
+	}
+
+	syncDone := make(chan bool)
+	syncConfig := &latest.SyncConfig{
+		LocalSubPath:  &contextPath,
+		ContainerPath: &containerPath,
+		ExcludePaths:  &exclude,
+
+		// Nothing is downloaded, files in the build pod are overwritten by the local context
+		DownloadExcludePaths: &[]string{"*"},
+		WaitInitialSync:      ptr.Bool(true),
+	}
+
+	syncClient, err := startSync(restConfig, pod, container, syncConfig, false, syncDone, errorHandler, nil)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "start sync")
+	}
+
+	err = syncClient.Start()
+	if err != nil {
+		syncClient.Stop(nil)
+		return nil, nil, errors.Wrap(err, "start sync")
+	}
+
+	select {
+	case <-syncClient.Options.UpstreamInitialSyncDone:
+	case <-syncDone:
+		return nil, nil, errors.New("sync stopped during initial sync")
+	}
+
+	return syncClient, syncDone, nil
+}
+
+func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfig *latest.SyncConfig, verbose bool, syncDone chan bool, errorHandler func(err error), customLog log.Logger) (*sync.Sync, error) {
 	err := injectSync(kubeconfig, pod, container)
 	if err != nil {
 		return nil, err
@@ -184,10 +272,18 @@ func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfi
 		containerPath = *syncConfig.ContainerPath
 	}
 
+	limits := settings.GetLimits()
 	options := &sync.Options{
-		Verbose:  verbose,
-		SyncDone: syncDone,
-		Log:      customLog,
+		Verbose:          verbose,
+		SyncDone:         syncDone,
+		CoalesceInterval: settings.Milliseconds(limits.SyncCoalesceInterval, 0),
+		Workers:          getSyncWorkers(),
+		ErrorHandler:     errorHandler,
+		LogFields: map[string]interface{}{
+			"pod":       pod.Name,
+			"container": containerPath,
+		},
+		Log: customLog,
 	}
 
 	if syncConfig.ExcludePaths != nil {
@@ -202,6 +298,18 @@ func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfi
 		options.UploadExcludePaths = *syncConfig.UploadExcludePaths
 	}
 
+	if syncConfig.ExcludeFromGitignore != nil && *syncConfig.ExcludeFromGitignore == true {
+		options.ExcludeFromGitignore = true
+	}
+
+	if syncConfig.CompareBy != nil && *syncConfig.CompareBy == SyncCompareByHash {
+		options.CompareByHash = true
+	}
+
+	if syncConfig.ConvertLineEndings != nil && *syncConfig.ConvertLineEndings == true {
+		options.ConvertLineEndings = true
+	}
+
 	if syncConfig.WaitInitialSync != nil && *syncConfig.WaitInitialSync == true {
 		options.UpstreamInitialSyncDone = make(chan bool)
 		options.DownstreamInitialSyncDone = make(chan bool)
@@ -217,11 +325,75 @@ func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfi
 		}
 	}
 
+	if syncConfig.OnUpload != nil {
+		onUpload := []func(log log.Logger){}
+		if syncConfig.OnUpload.ExecRemote != nil {
+			onUpload = append(onUpload, getExecRemoteFunc(kubeconfig, pod, container, syncConfig.OnUpload.ExecRemote))
+		}
+		if syncConfig.OnUpload.RestartContainer != nil {
+			onUpload = append(onUpload, getRestartContainerFunc(kubeconfig, pod, container, syncConfig.OnUpload.RestartContainer))
+		}
+		if len(onUpload) > 0 {
+			options.OnUpload = func(log log.Logger) {
+				for _, fn := range onUpload {
+					fn(log)
+				}
+			}
+		}
+	}
+	if syncConfig.OnDownload != nil && syncConfig.OnDownload.ExecLocal != nil {
+		options.OnDownload = getExecLocalFunc(syncConfig.OnDownload.ExecLocal)
+	}
+
+	if syncConfig.PollingInterval != nil {
+		options.PollingInterval = time.Duration(*syncConfig.PollingInterval) * time.Millisecond
+	}
+	if syncConfig.DebounceInterval != nil {
+		options.DebounceInterval = time.Duration(*syncConfig.DebounceInterval) * time.Millisecond
+	}
+
+	portForward := syncConfig.Transport != nil && *syncConfig.Transport == SyncTransportPortForward
+	if options.SyncDone == nil {
+		// We need to know when the sync is stopped to stop the port forwarding and the termination handler
+		options.SyncDone = make(chan bool)
+	}
+
 	syncClient, err := sync.NewSync(localPath, options)
 	if err != nil {
 		return nil, errors.Wrap(err, "create sync")
 	}
 
+	// Stop the sync and thereby the sync processes in the container on termination
+	unregister := interrupt.Register(func() { syncClient.Stop(nil) })
+	go func() {
+		<-options.SyncDone
+		unregister()
+	}()
+
+	upstreamArgs := []string{SyncHelperContainerPath, "--upstream"}
+	if owner := getFileOwner(syncConfig, pod, container); owner != "" {
+		upstreamArgs = append(upstreamArgs, "--owner", owner)
+	}
+	upstreamArgs = append(upstreamArgs, containerPath)
+	downstreamArgs := []string{SyncHelperContainerPath, "--downstream"}
+	for _, exclude := range options.ExcludePaths {
+		downstreamArgs = append(downstreamArgs, "--exclude", exclude)
+	}
+	for _, exclude := range options.DownloadExcludePaths {
+		downstreamArgs = append(downstreamArgs, "--exclude", exclude)
+	}
+	downstreamArgs = append(downstreamArgs, containerPath)
+
+	if portForward {
+		err = startPortForwardSync(syncClient, kubeconfig, pod, container, upstreamArgs, downstreamArgs)
+		if err != nil {
+			syncClient.Stop(nil)
+			return nil, err
+		}
+
+		return syncClient, nil
+	}
+
 	// Start upstream
 	upStdinReader, upStdinWriter, err := os.Pipe()
 	if err != nil {
@@ -232,7 +404,7 @@ func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfi
 		return nil, errors.Wrap(err, "create pipe")
 	}
 
-	go startStream(syncClient, kubeconfig, pod, container, []string{SyncHelperContainerPath, "--upstream", containerPath}, upStdinReader, upStdoutWriter)
+	go startStream(syncClient, kubeconfig, pod, container, upstreamArgs, upStdinReader, upStdoutWriter)
 
 	err = syncClient.InitUpstream(upStdoutReader, upStdinWriter)
 	if err != nil {
@@ -240,15 +412,6 @@ func startSync(kubeconfig *rest.Config, pod *v1.Pod, container string, syncConfi
 	}
 
 	// Start downstream
-	downstreamArgs := []string{SyncHelperContainerPath, "--downstream"}
-	for _, exclude := range options.ExcludePaths {
-		downstreamArgs = append(downstreamArgs, "--exclude", exclude)
-	}
-	for _, exclude := range options.DownloadExcludePaths {
-		downstreamArgs = append(downstreamArgs, "--exclude", exclude)
-	}
-	downstreamArgs = append(downstreamArgs, containerPath)
-
 	downStdinReader, downStdinWriter, err := os.Pipe()
 	if err != nil {
 		return nil, errors.Wrap(err, "create pipe")
@@ -298,7 +461,9 @@ func injectSync(kubeconfig *rest.Config, pod *v1.Pod, container string) error {
 	}
 
 	// Check if sync is already in pod
-	stdout, _, err := kubectl.ExecBuffered(kubeconfig, pod, container, []string{"/tmp/sync", "--version"}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), syncHelperVersionTimeout)
+	stdout, _, err := kubectl.ExecBuffered(ctx, kubeconfig, pod, container, []string{"/tmp/sync", "--version"}, nil)
+	cancel()
 	if err != nil || version != string(stdout) {
 		homedir, err := homedir.Dir()
 		if err != nil {
@@ -453,3 +618,140 @@ func injectSyncHelper(kubeconfig *rest.Config, pod *v1.Pod, container string, fi
 
 	return <-errChan
 }
+
+// getFileOwner returns the owner (UID[:GID]) of the files the upstream creates in the container. If no uid is
+// configured, the runAsUser and runAsGroup of the container or pod security context are used. Returns an empty
+// string if the files should be owned by the user of the sync helper
+func getFileOwner(syncConfig *latest.SyncConfig, pod *v1.Pod, container string) string {
+	uid, gid := syncConfig.UID, syncConfig.GID
+	if uid == nil {
+		if pod.Spec.SecurityContext != nil {
+			uid, gid = pod.Spec.SecurityContext.RunAsUser, pod.Spec.SecurityContext.RunAsGroup
+		}
+
+		for _, podContainer := range pod.Spec.Containers {
+			if podContainer.Name != container || podContainer.SecurityContext == nil {
+				continue
+			}
+
+			if podContainer.SecurityContext.RunAsUser != nil {
+				uid = podContainer.SecurityContext.RunAsUser
+			}
+			if podContainer.SecurityContext.RunAsGroup != nil {
+				gid = podContainer.SecurityContext.RunAsGroup
+			}
+		}
+
+		// Files are owned by root anyways if the container runs as root
+		if uid == nil || (*uid == 0 && (gid == nil || *gid == 0)) {
+			return ""
+		}
+	}
+
+	if gid == nil {
+		return fmt.Sprintf("%d", *uid)
+	}
+
+	return fmt.Sprintf("%d:%d", *uid, *gid)
+}
+
+// restartContainerTimeout is the maximum time the restart command may run in the container
+const restartContainerTimeout = time.Second * 30
+
+// getRestartContainerFunc returns the function that restarts the process in the container after changes were
+// uploaded. Errors are only logged, because a failed restart should not stop the sync
+func getRestartContainerFunc(kubeconfig *rest.Config, pod *v1.Pod, container string, restartConfig *latest.RestartContainerConfig) func(log log.Logger) {
+	command := getRestartContainerCommand(restartConfig)
+	return func(log log.Logger) {
+		ctx, cancel := context.WithTimeout(context.Background(), restartContainerTimeout)
+		defer cancel()
+
+		_, stderr, err := kubectl.ExecBuffered(ctx, kubeconfig, pod, container, command, nil)
+		if err != nil {
+			log.Warnf("Error restarting container %s/%s with %s: %v %s", pod.Name, container, strings.Join(command, " "), err, strings.TrimSpace(string(stderr)))
+			return
+		}
+
+		log.Infof("Restarted container %s/%s with %s", pod.Name, container, strings.Join(command, " "))
+	}
+}
+
+// getRestartContainerCommand returns the command that restarts the process in the container. A signal is sent to
+// the main process of the container (pid 1)
+func getRestartContainerCommand(restartConfig *latest.RestartContainerConfig) []string {
+	if restartConfig.Command != nil && len(*restartConfig.Command) > 0 {
+		return *restartConfig.Command
+	}
+
+	signal := "HUP"
+	if restartConfig.Signal != nil && *restartConfig.Signal != "" {
+		signal = strings.TrimPrefix(strings.ToUpper(*restartConfig.Signal), "SIG")
+	}
+
+	return []string{"kill", "-" + signal, "1"}
+}
+
+// syncExecTimeout is the maximum time a command that is executed after a sync batch may run in the container
+const syncExecTimeout = time.Minute * 10
+
+// getExecRemoteFunc returns the function that executes the configured command in the container after changes
+// were uploaded. Errors are only logged, because a failed command should not stop the sync
+func getExecRemoteFunc(kubeconfig *rest.Config, pod *v1.Pod, container string, execConfig *latest.SyncExecConfig) func(log log.Logger) {
+	command := getExecRemoteCommand(execConfig)
+	return func(log log.Logger) {
+		ctx, cancel := context.WithTimeout(context.Background(), syncExecTimeout)
+		defer cancel()
+
+		stdout, stderr, err := kubectl.ExecBuffered(ctx, kubeconfig, pod, container, command, nil)
+		if err != nil {
+			log.Warnf("Error executing %s in container %s/%s: %v\n%s%s", strings.Join(command, " "), pod.Name, container, err, string(stdout), string(stderr))
+			return
+		}
+
+		log.Infof("Executed %s in container %s/%s", strings.Join(command, " "), pod.Name, container)
+	}
+}
+
+// getExecRemoteCommand returns the command that is executed in the container. A command without args is
+// executed by the container shell, so that it can be a script such as "npm install && npm run build"
+func getExecRemoteCommand(execConfig *latest.SyncExecConfig) []string {
+	if execConfig.Args == nil {
+		return []string{"sh", "-c", *execConfig.Command}
+	}
+
+	command := []string{*execConfig.Command}
+	for _, arg := range *execConfig.Args {
+		command = append(command, *arg)
+	}
+
+	return command
+}
+
+// getExecLocalFunc returns the function that executes the configured command locally after changes
+// were downloaded. Errors are only logged, because a failed command should not stop the sync
+func getExecLocalFunc(execConfig *latest.SyncExecConfig) func(log log.Logger) {
+	args := []string{}
+	if execConfig.Args != nil {
+		for _, arg := range *execConfig.Args {
+			args = append(args, *arg)
+		}
+	}
+
+	return func(log log.Logger) {
+		var cmd command.Interface
+		if command.IsShellScript(*execConfig.Command) {
+			cmd = command.NewShellCommand(*execConfig.Command, args, nil)
+		} else {
+			cmd = command.NewStreamCommand(*execConfig.Command, args)
+		}
+
+		output := &bytes.Buffer{}
+		err := cmd.Run(output, output, nil)
+		if err != nil {
+			log.Warnf("Error executing %s locally: %v\n%s", strings.Join(append([]string{*execConfig.Command}, args...), " "), err, output.String())
+			return
+		}
+
+		log.Infof("Executed %s locally", strings.Join(append([]string{*execConfig.Command}, args...), " "))
+	}
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	gosync "sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/devspace/sync"
+	"github.com/devspace-cloud/devspace/sync/util"
+
+	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// SyncTransportExec transfers the sync data over the stdin and stdout of an exec stream
+const SyncTransportExec = "exec"
+
+// SyncTransportPortForward transfers the sync data over a forwarded port
+const SyncTransportPortForward = "portforward"
+
+// portForwardMaxRetries is the amount of times we try to reestablish a lost port forwarding in a row
+const portForwardMaxRetries = 10
+
+// portForwardRetryDelay is the time to wait between port forwarding retries
+const portForwardRetryDelay = 2 * time.Second
+
+// portForwardReadyTimeout is the maximum time to wait for the sync helper and the port forwarding to get ready
+const portForwardReadyTimeout = 30 * time.Second
+
+func startPortForwardSync(syncClient *sync.Sync, kubeconfig *rest.Config, pod *v1.Pod, container string, upstreamArgs, downstreamArgs []string) error {
+	client, err := kubernetes.NewForConfig(kubeconfig)
+	if err != nil {
+		return errors.Wrap(err, "create kubernetes client")
+	}
+
+	upstreamAddress, err := startPortForwardStream(syncClient, kubeconfig, client, pod, container, upstreamArgs)
+	if err != nil {
+		return errors.Wrap(err, "start upstream")
+	}
+
+	err = syncClient.InitUpstreamWithAddress(upstreamAddress)
+	if err != nil {
+		return errors.Wrap(err, "init upstream")
+	}
+
+	downstreamAddress, err := startPortForwardStream(syncClient, kubeconfig, client, pod, container, downstreamArgs)
+	if err != nil {
+		return errors.Wrap(err, "start downstream")
+	}
+
+	err = syncClient.InitDownstreamWithAddress(downstreamAddress)
+	if err != nil {
+		return errors.Wrap(err, "init downstream")
+	}
+
+	return nil
+}
+
+// startPortForwardStream starts the sync helper with the given arguments listening on a port within the pod
+// and forwards this port to the same local port. It returns the local address to connect to
+func startPortForwardStream(syncClient *sync.Sync, kubeconfig *rest.Config, client kubernetes.Interface, pod *v1.Pod, container string, args []string) (string, error) {
+	port, err := getFreePort()
+	if err != nil {
+		return "", errors.Wrap(err, "find free port")
+	}
+
+	// The path has to be the last argument
+	address := fmt.Sprintf("127.0.0.1:%d", port)
+	command := append([]string{}, args[:len(args)-1]...)
+	command = append(command, "--listen", address, args[len(args)-1])
+
+	err = startListeningSyncHelper(syncClient, kubeconfig, pod, container, command)
+	if err != nil {
+		return "", err
+	}
+
+	ready := make(chan struct{})
+	go forwardSyncPort(syncClient, kubeconfig, client, pod, port, ready)
+
+	select {
+	case <-ready:
+		return address, nil
+	case <-time.After(portForwardReadyTimeout):
+		return "", errors.New("Timeout waiting for port forwarding to start")
+	}
+}
+
+// startListeningSyncHelper starts the sync helper and waits until it accepts connections
+func startListeningSyncHelper(syncClient *sync.Sync, kubeconfig *rest.Config, pod *v1.Pod, container string, command []string) error {
+	stdoutReader, stdoutWriter := io.Pipe()
+	stderr := &bytes.Buffer{}
+	execDone := make(chan error, 1)
+
+	go func() {
+		err := kubectl.ExecStream(kubeconfig, pod, container, command, false, nil, stdoutWriter, stderr)
+		stdoutWriter.Close()
+		execDone <- err
+	}()
+
+	readyChan := make(chan struct{})
+	go func() {
+		scanner := bufio.NewScanner(stdoutReader)
+		for scanner.Scan() {
+			if strings.TrimSpace(scanner.Text()) == util.ListenReadyMessage {
+				close(readyChan)
+				break
+			}
+		}
+
+		// Make sure the helper never blocks on writing
+		io.Copy(ioutil.Discard, stdoutReader)
+	}()
+
+	select {
+	case <-readyChan:
+		// The sync helper keeps running if the exec stream is lost, so we only log this
+		go func() {
+			err := <-execDone
+			if err != nil {
+				syncClient.Options.Log.Infof("Sync - exec stream to sync helper %s in pod %s/%s closed: %v", strings.Join(command, " "), pod.Namespace, pod.Name, err)
+			}
+		}()
+
+		return nil
+	case err := <-execDone:
+		return fmt.Errorf("Sync helper exited unexpectedly: %s %v", stderr.String(), err)
+	case <-time.After(portForwardReadyTimeout):
+		return errors.New("Timeout waiting for sync helper to start")
+	}
+}
+
+// forwardSyncPort forwards the given port until the sync is stopped and reestablishes the port forwarding if it is lost
+func forwardSyncPort(syncClient *sync.Sync, kubeconfig *rest.Config, client kubernetes.Interface, pod *v1.Pod, port int, ready chan struct{}) {
+	var (
+		ports     = []string{fmt.Sprintf("%d:%d", port, port)}
+		readyOnce gosync.Once
+		retries   = 0
+	)
+
+	for {
+		stopChan := make(chan struct{})
+		readyChan := make(chan struct{})
+		forwardDone := make(chan struct{})
+
+		pf, err := kubectl.NewPortForwarderWithRestConfig(kubeconfig, client, pod, ports, []string{"127.0.0.1"}, stopChan, readyChan)
+		if err == nil {
+			go func() {
+				select {
+				case <-readyChan:
+					readyOnce.Do(func() { close(ready) })
+				case <-forwardDone:
+				}
+			}()
+			go func() {
+				select {
+				case <-syncClient.Options.SyncDone:
+					close(stopChan)
+				case <-forwardDone:
+				}
+			}()
+
+			err = pf.ForwardPorts()
+			close(forwardDone)
+
+			// Only count failures in a row
+			select {
+			case <-readyChan:
+				retries = 0
+			default:
+			}
+		}
+
+		select {
+		case <-syncClient.Options.SyncDone:
+			return
+		default:
+		}
+
+		retries++
+		if retries > portForwardMaxRetries {
+			syncClient.Stop(fmt.Errorf("Sync - lost port forwarding to pod %s/%s: %v", pod.Namespace, pod.Name, err))
+			return
+		}
+
+		syncClient.Options.Log.Infof("Sync - port forwarding to pod %s/%s lost (%v), reconnecting...", pod.Namespace, pod.Name, err)
+		time.Sleep(portForwardRetryDelay)
+	}
+}
+
+func getFreePort() (int, error) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer lis.Close()
+
+	return lis.Addr().(*net.TCPAddr).Port, nil
+}
@@ -1,7 +1,12 @@
 package services
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	v1 "k8s.io/api/core/v1"
 )
 
 func TestDownloadSyncHelper(t *testing.T) {
@@ -10,3 +15,124 @@ func TestDownloadSyncHelper(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+func TestGetFileOwner(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			SecurityContext: &v1.PodSecurityContext{
+				RunAsUser:  ptr.Int64(1000),
+				RunAsGroup: ptr.Int64(2000),
+			},
+			Containers: []v1.Container{
+				{
+					Name: "api",
+					SecurityContext: &v1.SecurityContext{
+						RunAsUser: ptr.Int64(1001),
+					},
+				},
+				{
+					Name: "db",
+				},
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		syncConfig *latest.SyncConfig
+		pod        *v1.Pod
+		container  string
+		expected   string
+	}{
+		"container security context": {
+			syncConfig: &latest.SyncConfig{},
+			pod:        pod,
+			container:  "api",
+			expected:   "1001:2000",
+		},
+		"pod security context": {
+			syncConfig: &latest.SyncConfig{},
+			pod:        pod,
+			container:  "db",
+			expected:   "1000:2000",
+		},
+		"configured owner": {
+			syncConfig: &latest.SyncConfig{UID: ptr.Int64(5), GID: ptr.Int64(6)},
+			pod:        pod,
+			container:  "api",
+			expected:   "5:6",
+		},
+		"configured user": {
+			syncConfig: &latest.SyncConfig{UID: ptr.Int64(5)},
+			pod:        &v1.Pod{},
+			container:  "api",
+			expected:   "5",
+		},
+		"no security context": {
+			syncConfig: &latest.SyncConfig{},
+			pod:        &v1.Pod{},
+			container:  "api",
+			expected:   "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		owner := getFileOwner(testCase.syncConfig, testCase.pod, testCase.container)
+		if owner != testCase.expected {
+			t.Fatalf("Test case %s: expected owner %s, got %s", name, testCase.expected, owner)
+		}
+	}
+}
+
+func TestGetRestartContainerCommand(t *testing.T) {
+	testCases := map[string]struct {
+		restartConfig *latest.RestartContainerConfig
+		expected      []string
+	}{
+		"default signal": {
+			restartConfig: &latest.RestartContainerConfig{},
+			expected:      []string{"kill", "-HUP", "1"},
+		},
+		"signal": {
+			restartConfig: &latest.RestartContainerConfig{Signal: ptr.String("SIGUSR2")},
+			expected:      []string{"kill", "-USR2", "1"},
+		},
+		"lowercase signal": {
+			restartConfig: &latest.RestartContainerConfig{Signal: ptr.String("sigterm")},
+			expected:      []string{"kill", "-TERM", "1"},
+		},
+		"command": {
+			restartConfig: &latest.RestartContainerConfig{Command: &[]string{"supervisorctl", "restart", "app"}},
+			expected:      []string{"supervisorctl", "restart", "app"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		command := getRestartContainerCommand(testCase.restartConfig)
+		if strings.Join(command, " ") != strings.Join(testCase.expected, " ") {
+			t.Fatalf("Test case %s: expected command %v, got %v", name, testCase.expected, command)
+		}
+	}
+}
+
+func TestGetExecRemoteCommand(t *testing.T) {
+	testCases := map[string]struct {
+		execConfig *latest.SyncExecConfig
+		expected   []string
+	}{
+		"script": {
+			execConfig: &latest.SyncExecConfig{Command: ptr.String("npm install && npm run build")},
+			expected:   []string{"sh", "-c", "npm install && npm run build"},
+		},
+		"command with args": {
+			execConfig: &latest.SyncExecConfig{Command: ptr.String("npm"), Args: &[]*string{ptr.String("install")}},
+			expected:   []string{"npm", "install"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		command := getExecRemoteCommand(testCase.execConfig)
+		if strings.Join(command, " ") != strings.Join(testCase.expected, " ") {
+			t.Fatalf("Test case %s: expected command %v, got %v", name, testCase.expected, command)
+		}
+	}
+}
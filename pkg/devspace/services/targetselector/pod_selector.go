@@ -1,11 +1,16 @@
 package targetselector
 
 import (
+	"fmt"
+	"sort"
+	"time"
+
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
 	"github.com/devspace-cloud/devspace/pkg/util/survey"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -105,3 +110,74 @@ func SelectPod(client kubernetes.Interface, namespace string, labelSelector *str
 
 	return nil, nil
 }
+
+// SelectMatchingPod lets the user select one of the running pods that match the label selector. The options show
+// the name, node, status and age of every pod, the newest pod is listed first. If only one pod is running, it is
+// returned without asking and if no pod is running, nil is returned
+func SelectMatchingPod(client kubernetes.Interface, namespace string, labelSelector string, question *string) (*v1.Pod, error) {
+	if question == nil {
+		question = ptr.String(DefaultPodQuestion)
+	}
+
+	podList, err := client.CoreV1().Pods(namespace).List(metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []*v1.Pod{}
+	for i := range podList.Items {
+		if kubectl.GetPodStatus(&podList.Items[i]) == "Running" {
+			pods = append(pods, &podList.Items[i])
+		}
+	}
+	if len(pods) == 0 {
+		return nil, nil
+	} else if len(pods) == 1 {
+		return pods[0], nil
+	}
+
+	sort.SliceStable(pods, func(i, j int) bool {
+		return pods[j].CreationTimestamp.Before(&pods[i].CreationTimestamp)
+	})
+
+	options := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		options = append(options, formatPodOption(pod, pods))
+	}
+
+	answer := survey.Question(&survey.QuestionOptions{
+		Question: *question,
+		Options:  options,
+	})
+	for i, option := range options {
+		if option == answer || pods[i].Name == answer {
+			return pods[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("Couldn't find selected pod %s", answer)
+}
+
+// formatPodOption returns the picker option of a pod with the name padded to the longest pod name
+func formatPodOption(pod *v1.Pod, pods []*v1.Pod) string {
+	nameLength := 0
+	for _, p := range pods {
+		if len(p.Name) > nameLength {
+			nameLength = len(p.Name)
+		}
+	}
+
+	node := pod.Spec.NodeName
+	if node == "" {
+		node = "-"
+	}
+
+	age := "-"
+	if pod.CreationTimestamp.IsZero() == false {
+		age = duration.HumanDuration(time.Since(pod.CreationTimestamp.Time))
+	}
+
+	return fmt.Sprintf("%-*s  node: %s  status: %s  age: %s", nameLength, pod.Name, node, kubectl.GetPodStatus(pod), age)
+}
@@ -1,7 +1,11 @@
 package targetselector
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/survey"
 
 	"k8s.io/client-go/kubernetes/fake"
 	k8sv1 "k8s.io/api/core/v1"
@@ -391,3 +395,54 @@ func TestPodSelectionOneRunningOneNotRunningPodsNoLabel(t *testing.T) {
 	assert.Equal(t, returnedPod.Name, "UnMatchingPod", "SelectPod returned deleted pod")
 	
 }*/
+
+func TestSelectMatchingPod(t *testing.T) {
+	namespace := "test"
+	kubeClient := fake.NewSimpleClientset()
+	labels := map[string]string{"app": "api"}
+
+	_, err := kubeClient.CoreV1().Pods(namespace).Create(&k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:            labels,
+			Name:              "api-old",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+		Spec:   k8sv1.PodSpec{NodeName: "node-1"},
+		Status: k8sv1.PodStatus{Reason: "Running"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+
+	// Only one running pod is returned without asking
+	pod, err := SelectMatchingPod(kubeClient, namespace, "app=api", nil)
+	if err != nil {
+		t.Fatalf("Error selecting pod: %v", err)
+	}
+	assert.Equal(t, pod.Name, "api-old", "Wrong pod returned")
+
+	_, err = kubeClient.CoreV1().Pods(namespace).Create(&k8sv1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:            labels,
+			Name:              "api-new",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Minute)),
+		},
+		Status: k8sv1.PodStatus{Reason: "Running"},
+	})
+	if err != nil {
+		t.Fatalf("Error creating pod: %v", err)
+	}
+
+	// The options show node, status and age with the newest pod first
+	oldPod, _ := kubeClient.CoreV1().Pods(namespace).Get("api-old", metav1.GetOptions{})
+	newPod, _ := kubeClient.CoreV1().Pods(namespace).Get("api-new", metav1.GetOptions{})
+	option := formatPodOption(oldPod, []*k8sv1.Pod{newPod, oldPod})
+	assert.Equal(t, strings.HasPrefix(option, "api-old  node: node-1  status: Running  age: 120m"), true, "Wrong option "+option)
+
+	survey.SetNextAnswer("api-old")
+	pod, err = SelectMatchingPod(kubeClient, namespace, "app=api", nil)
+	if err != nil {
+		t.Fatalf("Error selecting pod: %v", err)
+	}
+	assert.Equal(t, pod.Name, "api-old", "Picked pod not returned")
+}
@@ -0,0 +1,133 @@
+package targetselector
+
+import (
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// rememberedSelection is a pod and container that the user picked interactively
+type rememberedSelection struct {
+	podName       string
+	generateName  string
+	containerName string
+}
+
+// rememberedSelections holds the interactive choices of this process by selection key, so that
+// reconnecting terminals, logs and syncs during the same run don't ask again
+var rememberedSelections = map[string]*rememberedSelection{}
+var rememberedSelectionsMutex sync.Mutex
+
+// selectionKey identifies the selection parameters a choice was made for
+func (t *TargetSelector) selectionKey() string {
+	key := t.namespace + "/"
+	if t.labelSelector != nil {
+		key += *t.labelSelector
+	}
+	key += "/"
+	if t.image != nil {
+		key += *t.image
+	}
+
+	return key
+}
+
+func (t *TargetSelector) rememberPod(pod *v1.Pod) {
+	rememberedSelectionsMutex.Lock()
+	defer rememberedSelectionsMutex.Unlock()
+
+	rememberedSelections[t.selectionKey()] = &rememberedSelection{
+		podName:      pod.Name,
+		generateName: pod.GenerateName,
+	}
+}
+
+func (t *TargetSelector) rememberContainer(pod *v1.Pod, containerName string) {
+	rememberedSelectionsMutex.Lock()
+	defer rememberedSelectionsMutex.Unlock()
+
+	selection, ok := rememberedSelections[t.selectionKey()]
+	if ok == false || selection.podName != pod.Name {
+		selection = &rememberedSelection{
+			podName:      pod.Name,
+			generateName: pod.GenerateName,
+		}
+		rememberedSelections[t.selectionKey()] = selection
+	}
+
+	selection.containerName = containerName
+}
+
+func (t *TargetSelector) getRememberedSelection() *rememberedSelection {
+	rememberedSelectionsMutex.Lock()
+	defer rememberedSelectionsMutex.Unlock()
+
+	return rememberedSelections[t.selectionKey()]
+}
+
+// getRememberedPod returns the remembered pod if it is still running. If the pod was replaced, the newest
+// running pod that was created by the same controller is returned instead
+func (t *TargetSelector) getRememberedPod(client kubernetes.Interface) *v1.Pod {
+	selection := t.getRememberedSelection()
+	if selection == nil {
+		return nil
+	}
+
+	pod, err := client.CoreV1().Pods(t.namespace).Get(selection.podName, metav1.GetOptions{})
+	if err == nil && kubectl.GetPodStatus(pod) == "Running" {
+		return pod
+	}
+	if selection.generateName == "" {
+		return nil
+	}
+
+	podList, err := client.CoreV1().Pods(t.namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return nil
+	}
+
+	var newestPod *v1.Pod
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.GenerateName != selection.generateName || kubectl.GetPodStatus(pod) != "Running" {
+			continue
+		}
+		if newestPod == nil || newestPod.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			newestPod = pod
+		}
+	}
+	if newestPod != nil {
+		t.rememberPodKeepContainer(newestPod)
+	}
+
+	return newestPod
+}
+
+// rememberPodKeepContainer updates the remembered pod without losing the remembered container
+func (t *TargetSelector) rememberPodKeepContainer(pod *v1.Pod) {
+	rememberedSelectionsMutex.Lock()
+	defer rememberedSelectionsMutex.Unlock()
+
+	if selection, ok := rememberedSelections[t.selectionKey()]; ok {
+		selection.podName = pod.Name
+	}
+}
+
+// getRememberedContainer returns the remembered container if the pod has it
+func (t *TargetSelector) getRememberedContainer(pod *v1.Pod) *v1.Container {
+	selection := t.getRememberedSelection()
+	if selection == nil || selection.containerName == "" {
+		return nil
+	}
+
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == selection.containerName {
+			return &pod.Spec.Containers[i]
+		}
+	}
+
+	return nil
+}
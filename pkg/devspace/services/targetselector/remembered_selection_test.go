@@ -0,0 +1,90 @@
+package targetselector
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"gotest.tools/assert"
+)
+
+func TestRememberedSelection(t *testing.T) {
+	rememberedSelections = map[string]*rememberedSelection{}
+
+	namespace := "test"
+	config := latest.Config{
+		Cluster: &latest.Cluster{
+			Namespace: &namespace,
+		},
+	}
+	targetSelector, err := NewTargetSelector(&config, &SelectorParameter{}, true)
+	if err != nil {
+		t.Fatalf("Error creating targetSelector: %v", err)
+	}
+
+	kubeClient := fake.NewSimpleClientset()
+	createPod := func(name string) *k8sv1.Pod {
+		pod, err := kubeClient.CoreV1().Pods(namespace).Create(&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:         name,
+				GenerateName: "app-",
+			},
+			Status: k8sv1.PodStatus{
+				Reason: "Running",
+			},
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{
+					{Name: "app"},
+					{Name: "sidecar"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating pod: %v", err)
+		}
+
+		return pod
+	}
+
+	// Nothing picked yet
+	assert.Equal(t, true, targetSelector.getRememberedPod(kubeClient) == nil, "Unexpected remembered pod")
+
+	pod := createPod("app-1")
+	targetSelector.rememberPod(pod)
+	targetSelector.rememberContainer(pod, "sidecar")
+
+	rememberedPod := targetSelector.getRememberedPod(kubeClient)
+	assert.Equal(t, false, rememberedPod == nil, "Remembered pod is nil")
+	assert.Equal(t, "app-1", rememberedPod.Name, "Wrong pod remembered")
+
+	// Other selections are not affected
+	otherLabelSelector := "app=other"
+	otherSelector, err := NewTargetSelector(&config, &SelectorParameter{
+		CmdParameter: CmdParameter{
+			LabelSelector: &otherLabelSelector,
+		},
+	}, true)
+	if err != nil {
+		t.Fatalf("Error creating targetSelector: %v", err)
+	}
+	assert.Equal(t, true, otherSelector.getRememberedPod(kubeClient) == nil, "Unexpected remembered pod for other selection")
+
+	// The pod is replaced by a pod of the same controller
+	err = kubeClient.CoreV1().Pods(namespace).Delete("app-1", &metav1.DeleteOptions{})
+	if err != nil {
+		t.Fatalf("Error deleting pod: %v", err)
+	}
+	createPod("app-2")
+
+	rememberedPod = targetSelector.getRememberedPod(kubeClient)
+	assert.Equal(t, false, rememberedPod == nil, "Remembered pod is nil after pod was replaced")
+	assert.Equal(t, "app-2", rememberedPod.Name, "Wrong pod after pod was replaced")
+
+	container := targetSelector.getRememberedContainer(rememberedPod)
+	assert.Equal(t, false, container == nil, "Remembered container is nil")
+	assert.Equal(t, "sidecar", container.Name, "Wrong container remembered")
+}
@@ -1,10 +1,13 @@
 package targetselector
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/configutil"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/registry"
 )
 
 // SelectorParameter holds the information from the config and the command overrides
@@ -29,24 +32,57 @@ type ConfigParameter struct {
 	LabelSelector *map[string]*string
 	Namespace     *string
 	ContainerName *string
+	ImageName     *string
+}
+
+// getSelectors returns the selectors named in the command and in the config or nil if no selector is named. The
+// selector of the command takes precedence over the config parameters, the selector of the config does not
+func (t *SelectorParameter) getSelectors(config *latest.Config) (*latest.SelectorConfig, *latest.SelectorConfig, error) {
+	var cmdSelector, configSelector *latest.SelectorConfig
+
+	for _, selector := range []struct {
+		name   *string
+		config **latest.SelectorConfig
+	}{
+		{name: t.CmdParameter.Selector, config: &cmdSelector},
+		{name: t.ConfigParameter.Selector, config: &configSelector},
+	} {
+		if selector.name == nil {
+			continue
+		}
+		if config == nil {
+			return nil, nil, fmt.Errorf("Cannot use selector %s without a devspace.yaml", *selector.name)
+		}
+
+		selectorConfig, err := configutil.GetSelector(config, *selector.name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		*selector.config = selectorConfig
+	}
+
+	return cmdSelector, configSelector, nil
 }
 
 // GetNamespace retrieves the target namespace
 func (t *SelectorParameter) GetNamespace(config *latest.Config) (string, error) {
+	cmdSelector, configSelector, err := t.getSelectors(config)
+	if err != nil {
+		return "", err
+	}
+
 	if t.CmdParameter.Namespace != nil {
 		return *t.CmdParameter.Namespace, nil
 	}
+	if cmdSelector != nil && cmdSelector.Namespace != nil {
+		return *cmdSelector.Namespace, nil
+	}
 	if t.ConfigParameter.Namespace != nil {
 		return *t.ConfigParameter.Namespace, nil
 	}
-	if t.ConfigParameter.Selector != nil {
-		selector, err := configutil.GetSelector(config, *t.ConfigParameter.Selector)
-		if err != nil {
-			return "", err
-		}
-		if selector.Namespace != nil {
-			return *selector.Namespace, nil
-		}
+	if configSelector != nil && configSelector.Namespace != nil {
+		return *configSelector.Namespace, nil
 	}
 
 	// Get default namespace
@@ -60,26 +96,29 @@ func (t *SelectorParameter) GetNamespace(config *latest.Config) (string, error)
 
 // GetLabelSelector retrieves the label selector of the target
 func (t *SelectorParameter) GetLabelSelector(config *latest.Config) (*string, error) {
+	cmdSelector, configSelector, err := t.getSelectors(config)
+	if err != nil {
+		return nil, err
+	}
+
 	if t.CmdParameter.LabelSelector != nil {
 		return t.CmdParameter.LabelSelector, nil
 	}
+	if cmdSelector != nil && cmdSelector.LabelSelector != nil {
+		labelSelector := labelSelectorMapToString(*cmdSelector.LabelSelector)
+		return &labelSelector, nil
+	}
 	if t.ConfigParameter.LabelSelector != nil {
 		labelSelector := labelSelectorMapToString(*t.ConfigParameter.LabelSelector)
 		return &labelSelector, nil
 	}
-	if t.ConfigParameter.Selector != nil {
-		selector, err := configutil.GetSelector(config, *t.ConfigParameter.Selector)
-		if err != nil {
-			return nil, err
-		}
-		if selector.LabelSelector != nil {
-			labelSelector := labelSelectorMapToString(*selector.LabelSelector)
-			return &labelSelector, nil
-		}
+	if configSelector != nil && configSelector.LabelSelector != nil {
+		labelSelector := labelSelectorMapToString(*configSelector.LabelSelector)
+		return &labelSelector, nil
 	}
 
-	// We get the first selector if it exists
-	if config != nil {
+	// We get the first selector if it exists, pods are selected by their image instead if an image name is set
+	if config != nil && t.ConfigParameter.ImageName == nil {
 		if config.Dev != nil && config.Dev.Selectors != nil {
 			selectors := *config.Dev.Selectors
 			if len(selectors) == 1 && selectors[0].LabelSelector != nil {
@@ -101,23 +140,72 @@ func labelSelectorMapToString(m map[string]*string) string {
 	return strings.Join(labels, ",")
 }
 
-// GetPodName retrieves the pod name from the parameters
-func (t *SelectorParameter) GetPodName() *string {
+// GetImage retrieves the image a container of the target pod has to run. The image name of the config is the name of
+// an image in the images section, the tag is the tag of the last build of this image
+func (t *SelectorParameter) GetImage(config *latest.Config) (*string, error) {
+	if t.ConfigParameter.ImageName == nil {
+		return nil, nil
+	}
+
+	imageName := *t.ConfigParameter.ImageName
+	if config == nil || config.Images == nil || (*config.Images)[imageName] == nil || (*config.Images)[imageName].Image == nil {
+		return nil, fmt.Errorf("Image %s is not defined in images", imageName)
+	}
+
+	// Use the image without tag if the image was not built yet
+	image := registry.GetAliasedImageName(config, *(*config.Images)[imageName].Image)
+
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if imageCache, ok := generatedConfig.GetActive().Images[imageName]; ok && imageCache.ImageName != "" && imageCache.Tag != "" {
+		image = imageCache.GetResolvedImageName() + ":" + imageCache.Tag
+	}
+
+	return &image, nil
+}
+
+// GetPodName retrieves the pod name from the parameters. A pod name selects the pod directly without resolving
+// the label selector or image
+func (t *SelectorParameter) GetPodName(config *latest.Config) (*string, error) {
+	cmdSelector, configSelector, err := t.getSelectors(config)
+	if err != nil {
+		return nil, err
+	}
+
 	if t.CmdParameter.PodName != nil {
-		return t.CmdParameter.PodName
+		return t.CmdParameter.PodName, nil
+	}
+	if cmdSelector != nil && cmdSelector.PodName != nil {
+		return cmdSelector.PodName, nil
+	}
+	if configSelector != nil && configSelector.PodName != nil {
+		return configSelector.PodName, nil
 	}
 
-	return nil
+	return nil, nil
 }
 
 // GetContainerName retrieves the container name from the parameters
-func (t *SelectorParameter) GetContainerName() *string {
+func (t *SelectorParameter) GetContainerName(config *latest.Config) (*string, error) {
+	cmdSelector, configSelector, err := t.getSelectors(config)
+	if err != nil {
+		return nil, err
+	}
+
 	if t.CmdParameter.ContainerName != nil {
-		return t.CmdParameter.ContainerName
+		return t.CmdParameter.ContainerName, nil
+	}
+	if cmdSelector != nil && cmdSelector.ContainerName != nil {
+		return cmdSelector.ContainerName, nil
 	}
 	if t.ConfigParameter.ContainerName != nil {
-		return t.ConfigParameter.ContainerName
+		return t.ConfigParameter.ContainerName, nil
+	}
+	if configSelector != nil && configSelector.ContainerName != nil {
+		return configSelector.ContainerName, nil
 	}
 
-	return nil
+	return nil, nil
 }
@@ -1,7 +1,109 @@
 package targetselector
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+
+	"gotest.tools/assert"
+)
 
 func TestSelectorParameter(t *testing.T) {
 	//Neccessary code coverage already reached
 }
+
+func TestGetImage(t *testing.T) {
+	config := &latest.Config{
+		Images: &map[string]*latest.ImageConfig{
+			"backend":  &latest.ImageConfig{Image: ptr.String("myorg/backend")},
+			"frontend": &latest.ImageConfig{Image: ptr.String("myorg/frontend")},
+		},
+		RegistryAliases: &map[string]string{"myorg": "localhost:5000/myorg"},
+	}
+
+	generatedConfig := &generated.Config{
+		ActiveConfig: generated.DefaultConfigName,
+		Configs: map[string]*generated.CacheConfig{
+			generated.DefaultConfigName: &generated.CacheConfig{
+				Images: map[string]*generated.ImageCache{
+					"backend": &generated.ImageCache{
+						ImageName:         "myorg/backend",
+						ResolvedImageName: "localhost:5000/myorg/backend",
+						Tag:               "abc",
+					},
+				},
+			},
+		},
+	}
+	generated.SetTestConfig(generatedConfig)
+
+	sp := &SelectorParameter{}
+	image, err := sp.GetImage(config)
+	assert.NilError(t, err)
+	assert.Assert(t, image == nil, "Image without image name")
+
+	// Built image
+	sp.ConfigParameter.ImageName = ptr.String("backend")
+	image, err = sp.GetImage(config)
+	assert.NilError(t, err)
+	assert.Equal(t, *image, "localhost:5000/myorg/backend:abc")
+
+	// Image that was not built yet
+	sp.ConfigParameter.ImageName = ptr.String("frontend")
+	image, err = sp.GetImage(config)
+	assert.NilError(t, err)
+	assert.Equal(t, *image, "localhost:5000/myorg/frontend")
+
+	// Unknown image
+	sp.ConfigParameter.ImageName = ptr.String("unknown")
+	_, err = sp.GetImage(config)
+	assert.Error(t, err, "Image unknown is not defined in images")
+}
+
+func TestGetPodName(t *testing.T) {
+	config := &latest.Config{
+		Dev: &latest.DevConfig{
+			Selectors: &[]*latest.SelectorConfig{
+				{Name: ptr.String("web"), Namespace: ptr.String("web-namespace"), PodName: ptr.String("web-0"), ContainerName: ptr.String("nginx")},
+				{Name: ptr.String("db"), PodName: ptr.String("db-0")},
+			},
+		},
+	}
+
+	sp := &SelectorParameter{}
+	podName, err := sp.GetPodName(config)
+	assert.NilError(t, err)
+	assert.Assert(t, podName == nil, "Pod name without selector")
+
+	// Pod name and container of the config selector
+	sp.ConfigParameter.Selector = ptr.String("web")
+	podName, err = sp.GetPodName(config)
+	assert.NilError(t, err)
+	assert.Equal(t, *podName, "web-0")
+	containerName, err := sp.GetContainerName(config)
+	assert.NilError(t, err)
+	assert.Equal(t, *containerName, "nginx")
+
+	// The selector of the command takes precedence over the selector of the config
+	sp.CmdParameter.Selector = ptr.String("db")
+	podName, err = sp.GetPodName(config)
+	assert.NilError(t, err)
+	assert.Equal(t, *podName, "db-0")
+	namespace, err := sp.GetNamespace(config)
+	assert.NilError(t, err)
+	assert.Equal(t, namespace, "web-namespace")
+
+	// The pod name of the command takes precedence over the selectors
+	sp.CmdParameter.PodName = ptr.String("other")
+	podName, err = sp.GetPodName(config)
+	assert.NilError(t, err)
+	assert.Equal(t, *podName, "other")
+
+	// Unknown selector and selector without config
+	_, err = (&SelectorParameter{CmdParameter: CmdParameter{Selector: ptr.String("unknown")}}).GetPodName(config)
+	assert.Error(t, err, "Unable to find selector: unknown")
+	_, err = (&SelectorParameter{CmdParameter: CmdParameter{Selector: ptr.String("web")}}).GetPodName(nil)
+	assert.Error(t, err, "Cannot use selector web without a devspace.yaml")
+}
@@ -29,7 +29,11 @@ type TargetSelector struct {
 	namespace string
 	pick      bool
 
+	// pickMultiple is true if the user is asked to pick a pod when multiple pods match the label selector
+	pickMultiple bool
+
 	labelSelector *string
+	image         *string
 	podName       *string
 	containerName *string
 
@@ -51,12 +55,30 @@ func NewTargetSelector(config *latest.Config, sp *SelectorParameter, allowPick b
 		return nil, err
 	}
 
+	// Get image
+	image, err := sp.GetImage(config)
+	if err != nil {
+		return nil, err
+	}
+
+	podName, err := sp.GetPodName(config)
+	if err != nil {
+		return nil, err
+	}
+
+	containerName, err := sp.GetContainerName(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return &TargetSelector{
 		namespace:     namespace,
 		labelSelector: labelSelector,
-		podName:       sp.GetPodName(),
-		containerName: sp.GetContainerName(),
+		image:         image,
+		podName:       podName,
+		containerName: containerName,
 		pick:          allowPick && sp.CmdParameter.Pick != nil && *sp.CmdParameter.Pick == true,
+		pickMultiple:  allowPick && sp.CmdParameter.Pick == nil,
 
 		allowPick: allowPick,
 		config:    config,
@@ -76,19 +98,59 @@ func (t *TargetSelector) GetPod(client kubernetes.Interface) (*v1.Pod, error) {
 			return nil, fmt.Errorf("Couldn't get pod %s, because pod has status: %s which is not Running", pod.Name, podStatus)
 		}
 
+		return pod, nil
+	} else if t.pick == false && t.image != nil {
+		labelSelector := ""
+		if t.labelSelector != nil {
+			labelSelector = *t.labelSelector
+		}
+
+		pod, containerName, err := kubectl.GetNewestRunningPodWithImage(t.config, client, labelSelector, *t.image, t.namespace, time.Second*120)
+		if err != nil {
+			return nil, err
+		}
+
+		// Select the container that runs the image
+		if t.containerName == nil {
+			t.containerName = &containerName
+		}
+
 		return pod, nil
 	} else if t.pick == false && t.labelSelector != nil {
+		if t.pickMultiple {
+			// Reuse the pod that was picked before during this run
+			if pod := t.getRememberedPod(client); pod != nil {
+				return pod, nil
+			}
+		}
+
 		pod, err := kubectl.GetNewestRunningPod(t.config, client, *t.labelSelector, t.namespace, time.Second*120)
 		if err != nil {
 			return nil, err
 		}
 
+		// Ask which pod to use if there are multiple pods running
+		if t.pickMultiple {
+			pickedPod, err := SelectMatchingPod(client, t.namespace, *t.labelSelector, t.PodQuestion)
+			if err != nil {
+				return nil, err
+			} else if pickedPod != nil {
+				t.rememberPod(pickedPod)
+				return pickedPod, nil
+			}
+		}
+
 		return pod, nil
 	}
 
 	// Don't allow pick
 	if t.allowPick == false {
-		return nil, errors.New("Couldn't find a running pod, because no labelselector or pod name was specified")
+		return nil, errors.New("Couldn't find a running pod, because no labelselector, image or pod name was specified")
+	}
+
+	// Reuse the pod that was picked before during this run
+	if pod := t.getRememberedPod(client); pod != nil {
+		return pod, nil
 	}
 
 	// Ask for pod
@@ -96,6 +158,9 @@ func (t *TargetSelector) GetPod(client kubernetes.Interface) (*v1.Pod, error) {
 	if err != nil {
 		return nil, err
 	}
+	if pod != nil {
+		t.rememberPod(pod)
+	}
 
 	return pod, nil
 }
@@ -130,6 +195,11 @@ func (t *TargetSelector) GetContainer(client kubernetes.Interface) (*v1.Pod, *v1
 			return nil, nil, fmt.Errorf("Couldn't select a container in pod %s, because no container name was specified", pod.Name)
 		}
 
+		// Reuse the container that was picked before during this run
+		if container := t.getRememberedContainer(pod); container != nil {
+			return pod, container, nil
+		}
+
 		options := []string{}
 		for _, container := range pod.Spec.Containers {
 			options = append(options, container.Name)
@@ -145,6 +215,7 @@ func (t *TargetSelector) GetContainer(client kubernetes.Interface) (*v1.Pod, *v1
 		})
 		for _, container := range pod.Spec.Containers {
 			if container.Name == containerName {
+				t.rememberContainer(pod, containerName)
 				return pod, &container, nil
 			}
 		}
@@ -152,3 +223,59 @@ func (t *TargetSelector) GetContainer(client kubernetes.Interface) (*v1.Pod, *v1
 
 	return pod, nil, nil
 }
+
+// PodContainer is a container within a pod
+type PodContainer struct {
+	Pod       *v1.Pod
+	Container *v1.Container
+}
+
+// GetAllContainers returns a container of every running pod that matches the label selector or runs the image. If a
+// container name is set, pods without this container are skipped
+func (t *TargetSelector) GetAllContainers(client kubernetes.Interface) ([]*PodContainer, error) {
+	if t.labelSelector == nil && t.image == nil {
+		return nil, errors.New("Couldn't select pods, because no labelselector or image was specified")
+	}
+
+	labelSelector := ""
+	if t.labelSelector != nil {
+		labelSelector = *t.labelSelector
+	}
+
+	podList, err := client.CoreV1().Pods(t.namespace).List(metav1.ListOptions{LabelSelector: labelSelector})
+	if err != nil {
+		return nil, err
+	}
+
+	containers := []*PodContainer{}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if kubectl.GetPodStatus(pod) != "Running" || len(pod.Spec.Containers) == 0 {
+			continue
+		}
+
+		var container *v1.Container
+		if t.containerName != nil {
+			for j := range pod.Spec.Containers {
+				if pod.Spec.Containers[j].Name == *t.containerName {
+					container = &pod.Spec.Containers[j]
+					break
+				}
+			}
+		} else if t.image != nil {
+			container = kubectl.GetContainerWithImage(pod, *t.image)
+		} else {
+			container = &pod.Spec.Containers[0]
+		}
+		if container == nil {
+			continue
+		}
+
+		containers = append(containers, &PodContainer{
+			Pod:       pod,
+			Container: container,
+		})
+	}
+
+	return containers, nil
+}
@@ -224,3 +224,84 @@ func TestTargetSelector(t *testing.T) {
 	assert.Equal(t, true, returnedPod == nil, "returned Pod is not nil")
 	assert.Equal(t, true, returnedContainer == nil, "returned container is not nil")
 }
+
+func TestGetAllContainers(t *testing.T) {
+	namespace := "test"
+	labelSelector := "app=worker"
+	config := latest.Config{
+		Cluster: &latest.Cluster{
+			Namespace: &namespace,
+		},
+	}
+
+	kubeClient := fake.NewSimpleClientset()
+	for _, pod := range []struct {
+		name   string
+		app    string
+		status string
+	}{
+		{name: "worker-1", app: "worker", status: "Running"},
+		{name: "worker-2", app: "worker", status: "Running"},
+		{name: "worker-3", app: "worker", status: "Stopped"},
+		{name: "other", app: "other", status: "Running"},
+	} {
+		_, err := kubeClient.CoreV1().Pods(namespace).Create(&k8sv1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   pod.name,
+				Labels: map[string]string{"app": pod.app},
+			},
+			Status: k8sv1.PodStatus{
+				Reason: pod.status,
+			},
+			Spec: k8sv1.PodSpec{
+				Containers: []k8sv1.Container{
+					{Name: "sidecar"},
+					{Name: "worker"},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error creating pod: %v", err)
+		}
+	}
+
+	// Without container name the first container is selected
+	targetSelector, err := NewTargetSelector(&config, &SelectorParameter{
+		CmdParameter: CmdParameter{
+			LabelSelector: &labelSelector,
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("Error creating targetSelector: %v", err)
+	}
+
+	containers, err := targetSelector.GetAllContainers(kubeClient)
+	if err != nil {
+		t.Fatalf("Error getting containers: %v", err)
+	}
+	assert.Equal(t, 2, len(containers), "Wrong number of containers returned")
+	for _, podContainer := range containers {
+		assert.Equal(t, "sidecar", podContainer.Container.Name, "Wrong container returned")
+	}
+
+	// With container name
+	containerName := "worker"
+	targetSelector, err = NewTargetSelector(&config, &SelectorParameter{
+		CmdParameter: CmdParameter{
+			LabelSelector: &labelSelector,
+			ContainerName: &containerName,
+		},
+	}, false)
+	if err != nil {
+		t.Fatalf("Error creating targetSelector: %v", err)
+	}
+
+	containers, err = targetSelector.GetAllContainers(kubeClient)
+	if err != nil {
+		t.Fatalf("Error getting containers: %v", err)
+	}
+	assert.Equal(t, 2, len(containers), "Wrong number of containers returned")
+	for _, podContainer := range containers {
+		assert.Equal(t, "worker", podContainer.Container.Name, "Wrong container returned")
+	}
+}
@@ -2,82 +2,331 @@ package services
 
 import (
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
 	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
 	"github.com/devspace-cloud/devspace/pkg/devspace/services/targetselector"
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	"github.com/devspace-cloud/devspace/pkg/util/terminal"
 
 	"github.com/mgutz/ansi"
+	v1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	kubectlExec "k8s.io/client-go/util/exec"
 )
 
+// defaultTerminalMaxRetries is the amount of times in a row a restarted terminal tries to select a running pod
+const defaultTerminalMaxRetries = 10
+
+// defaultTerminalRetryDelay is the time to wait before a restarted terminal selects the pod again
+const defaultTerminalRetryDelay = 2 * time.Second
+
 // StartTerminal opens a new terminal
 func StartTerminal(config *latest.Config, client kubernetes.Interface, cmdParameter targetselector.CmdParameter, args []string, interrupt chan error, log log.Logger) error {
-	command := getCommand(config, args)
+	var terminalConfig *latest.Terminal
+	if config != nil && config.Dev != nil {
+		terminalConfig = config.Dev.Terminal
+	}
+
+	state := newTerminalStateTracker(config, terminalConfig, args)
+	target, err := selectTerminalTarget(config, client, terminalConfig, cmdParameter, args, state)
+	if err != nil {
+		return err
+	}
+	defer target.upgradeRoundTripper.Close()
+
+	var stdout io.Writer = os.Stdout
+	if state != nil {
+		stdout = state.writer(os.Stdout)
+		defer saveTerminalState(state, log)
+	}
+
+	log.Infof("Opening shell to pod:container %s:%s", ansi.Color(target.pod.Name, "white+b"), ansi.Color(target.container, "white+b"))
+
+	go func() {
+		terminalErr := runTerminal(client, target, getTerminalReconnectPolicy(terminalConfig), func(target *terminalTarget) error {
+			return kubectl.ExecStreamWithTransport(target.wrapper, target.upgradeRoundTripper, client, target.pod, target.container, target.command, true, os.Stdin, stdout, os.Stderr)
+		}, func() (*terminalTarget, error) {
+			return selectTerminalTarget(config, client, terminalConfig, cmdParameter, args, state)
+		}, log)
+		if terminalErr != nil {
+			if _, ok := terminalErr.(kubectlExec.CodeExitError); ok == false {
+				interrupt <- fmt.Errorf("Unable to start terminal session: %v", terminalErr)
+				return
+			}
+		}
+
+		interrupt <- nil
+	}()
+
+	return <-interrupt
+}
+
+// StartTerminals opens all terminals in dev.terminals and shares the local terminal between them. The
+// terminals are switched with the keys of the terminal multiplexer
+func StartTerminals(config *latest.Config, client kubernetes.Interface, interrupt chan error, log log.Logger) error {
+	terminals := []*latest.Terminal{}
+	for _, terminalConfig := range *config.Dev.Terminals {
+		if terminalConfig.Disabled == nil || *terminalConfig.Disabled == false {
+			terminals = append(terminals, terminalConfig)
+		}
+	}
+	if len(terminals) == 0 {
+		return <-interrupt
+	}
+
+	tty := terminal.SetupTTY(os.Stdin, os.Stdout)
+	if tty.Raw == false {
+		return fmt.Errorf("Multiple terminals (dev.terminals) require an interactive terminal, use --terminal=false to start without terminals")
+	}
+
+	targets := make([]*terminalTarget, 0, len(terminals))
+	states := make([]*terminalStateTracker, 0, len(terminals))
+	names := make([]string, 0, len(terminals))
+	for index, terminalConfig := range terminals {
+		state := newTerminalStateTracker(config, terminalConfig, nil)
+		target, err := selectTerminalTarget(config, client, terminalConfig, targetselector.CmdParameter{}, nil, state)
+		if err != nil {
+			return err
+		}
+		defer target.upgradeRoundTripper.Close()
+		if state != nil {
+			defer saveTerminalState(state, log)
+		}
+
+		name := fmt.Sprintf("%s:%s", target.pod.Name, target.container)
+		if terminalConfig.Name != nil {
+			name = *terminalConfig.Name
+		}
+
+		log.Infof("Opening shell %d (%s) to pod:container %s:%s", index+1, name, ansi.Color(target.pod.Name, "white+b"), ansi.Color(target.container, "white+b"))
+		targets = append(targets, target)
+		states = append(states, state)
+		names = append(names, name)
+	}
+
+	log.Infof("Switch between the terminals with %s", terminal.Help)
+
+	multiplexer := terminal.NewMultiplexer(names, tty.Out)
+	done := make(chan error, len(targets))
+
+	return tty.Safe(func() error {
+		for index, target := range targets {
+			go func(index int, name string, target *terminalTarget) {
+				var stdout io.Writer = multiplexer.Stdout(index)
+				if states[index] != nil {
+					stdout = states[index].writer(stdout)
+				}
+
+				terminalErr := runTerminal(client, target, getTerminalReconnectPolicy(terminals[index]), func(target *terminalTarget) error {
+					return kubectl.ExecTerminalWithTransport(target.wrapper, target.upgradeRoundTripper, client, target.pod, target.container, target.command, multiplexer.Stdin(index), stdout, tty.MonitorSize(tty.GetSize()))
+				}, func() (*terminalTarget, error) {
+					return selectTerminalTarget(config, client, terminals[index], targetselector.CmdParameter{}, nil, states[index])
+				}, log)
+				if terminalErr != nil {
+					if _, ok := terminalErr.(kubectlExec.CodeExitError); ok == false {
+						done <- fmt.Errorf("Unable to start terminal session %s: %v", name, terminalErr)
+						return
+					}
+				}
+
+				if multiplexer.Close(index) == false {
+					done <- nil
+				}
+			}(index, names[index], target)
+		}
+
+		go multiplexer.Run(tty.In)
+
+		select {
+		case err := <-interrupt:
+			return err
+		case err := <-done:
+			return err
+		}
+	})
+}
+
+// saveTerminalState saves the state of the terminal in the generated config. Errors are only logged, because the
+// state is not required to open the terminal again
+func saveTerminalState(state *terminalStateTracker, log log.Logger) {
+	err := state.save()
+	if err != nil {
+		log.Warnf("Error saving terminal state: %v", err)
+	}
+}
+
+// terminalReconnectPolicy defines if and how a terminal is reopened after its pod or container was restarted
+type terminalReconnectPolicy struct {
+	enabled    bool
+	maxRetries int
+	retryDelay time.Duration
+}
+
+func getTerminalReconnectPolicy(terminalConfig *latest.Terminal) *terminalReconnectPolicy {
+	policy := &terminalReconnectPolicy{
+		enabled:    true,
+		maxRetries: defaultTerminalMaxRetries,
+		retryDelay: defaultTerminalRetryDelay,
+	}
+	if terminalConfig == nil || terminalConfig.Reconnect == nil {
+		return policy
+	}
 
+	if terminalConfig.Reconnect.Disabled != nil && *terminalConfig.Reconnect.Disabled == true {
+		policy.enabled = false
+	}
+	if terminalConfig.Reconnect.MaxRetries != nil {
+		policy.maxRetries = *terminalConfig.Reconnect.MaxRetries
+	}
+	if terminalConfig.Reconnect.RetryDelay != nil {
+		policy.retryDelay = time.Duration(*terminalConfig.Reconnect.RetryDelay) * time.Second
+	}
+
+	return policy
+}
+
+// runTerminal runs the terminal to the target until it is closed. If the terminal was closed because the pod was deleted
+// or replaced or the container was restarted, the container is selected again and the terminal is reopened to it
+func runTerminal(client kubernetes.Interface, target *terminalTarget, policy *terminalReconnectPolicy, run func(target *terminalTarget) error, reselect func() (*terminalTarget, error), log log.Logger) error {
+	// The transport of the first target is closed by the caller
+	initialTarget := target
+	defer func() {
+		if target != initialTarget {
+			target.upgradeRoundTripper.Close()
+		}
+	}()
+
+	for {
+		err := run(target)
+		if policy.enabled == false || targetRestarted(client, target) == false {
+			return err
+		}
+
+		log.Infof("Terminal: Pod %s/%s was restarted, reconnecting...", target.pod.Namespace, target.pod.Name)
+
+		newTarget, err := reselectTerminalTarget(policy, reselect, log)
+		if err != nil {
+			return err
+		}
+
+		if target != initialTarget {
+			target.upgradeRoundTripper.Close()
+		}
+
+		target = newTarget
+		log.Donef("Terminal: Reconnected to pod:container %s:%s", ansi.Color(target.pod.Name, "white+b"), ansi.Color(target.container, "white+b"))
+	}
+}
+
+// reselectTerminalTarget selects the terminal target again and retries according to the policy if there is no running pod
+func reselectTerminalTarget(policy *terminalReconnectPolicy, reselect func() (*terminalTarget, error), log log.Logger) (*terminalTarget, error) {
+	for retries := 1; ; retries++ {
+		time.Sleep(policy.retryDelay)
+
+		target, err := reselect()
+		if err == nil {
+			return target, nil
+		} else if retries >= policy.maxRetries {
+			return nil, fmt.Errorf("Unable to reconnect terminal after %d retries: %v", retries, err)
+		}
+
+		log.Infof("Terminal: Couldn't find a running pod (%v), retrying...", err)
+	}
+}
+
+// targetRestarted checks if the pod of the target was deleted or replaced or the container of the target was restarted
+func targetRestarted(client kubernetes.Interface, target *terminalTarget) bool {
+	pod, err := client.CoreV1().Pods(target.pod.Namespace).Get(target.pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return kerrors.IsNotFound(err)
+	}
+	if pod.DeletionTimestamp != nil || pod.UID != target.pod.UID {
+		return true
+	}
+
+	return getRestartCount(pod, target.container) != getRestartCount(target.pod, target.container)
+}
+
+func getRestartCount(pod *v1.Pod, container string) int32 {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == container {
+			return status.RestartCount
+		}
+	}
+
+	return 0
+}
+
+// terminalTarget is the container a terminal is opened to
+type terminalTarget struct {
+	pod       *v1.Pod
+	container string
+	command   []string
+
+	wrapper             http.RoundTripper
+	upgradeRoundTripper *kubectl.UpgraderWrapper
+}
+
+// selectTerminalTarget selects the container for the terminal config and creates the transport for the terminal. If
+// the state is not nil, the default shell is started with the working directory and last command of the state
+func selectTerminalTarget(config *latest.Config, client kubernetes.Interface, terminalConfig *latest.Terminal, cmdParameter targetselector.CmdParameter, args []string, state *terminalStateTracker) (*terminalTarget, error) {
 	selectorParameter := &targetselector.SelectorParameter{
 		CmdParameter: cmdParameter,
 	}
 
-	if config != nil && config.Dev != nil && config.Dev.Terminal != nil {
+	if terminalConfig != nil {
 		selectorParameter.ConfigParameter = targetselector.ConfigParameter{
-			Selector:      config.Dev.Terminal.Selector,
-			Namespace:     config.Dev.Terminal.Namespace,
-			LabelSelector: config.Dev.Terminal.LabelSelector,
-			ContainerName: config.Dev.Terminal.ContainerName,
+			Selector:      terminalConfig.Selector,
+			Namespace:     terminalConfig.Namespace,
+			LabelSelector: terminalConfig.LabelSelector,
+			ContainerName: terminalConfig.ContainerName,
 		}
 	}
 
 	targetSelector, err := targetselector.NewTargetSelector(config, selectorParameter, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	targetSelector.PodQuestion = ptr.String("Which pod do you want to open the terminal for?")
 
 	pod, container, err := targetSelector.GetContainer(client)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	kubeconfig, err := kubectl.GetRestConfig(config)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	wrapper, upgradeRoundTripper, err := kubectl.GetUpgraderWrapper(kubeconfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	log.Infof("Opening shell to pod:container %s:%s", ansi.Color(pod.Name, "white+b"), ansi.Color(container.Name, "white+b"))
-
-	go func() {
-		terminalErr := kubectl.ExecStreamWithTransport(wrapper, upgradeRoundTripper, client, pod, container.Name, command, true, os.Stdin, os.Stdout, os.Stderr)
-		if terminalErr != nil {
-			if _, ok := terminalErr.(kubectlExec.CodeExitError); ok == false {
-				interrupt <- fmt.Errorf("Unable to start terminal session: %v", terminalErr)
-				return
-			}
-		}
-
-		interrupt <- nil
-	}()
-
-	err = <-interrupt
-	upgradeRoundTripper.Close()
-	return err
+	return &terminalTarget{
+		pod:                 pod,
+		container:           container.Name,
+		command:             getCommand(terminalConfig, args, state),
+		wrapper:             wrapper,
+		upgradeRoundTripper: upgradeRoundTripper,
+	}, nil
 }
 
-func getCommand(config *latest.Config, args []string) []string {
+func getCommand(terminalConfig *latest.Terminal, args []string, state *terminalStateTracker) []string {
 	var command []string
 
-	if config != nil && config.Dev != nil && config.Dev.Terminal != nil && config.Dev.Terminal.Command != nil && len(*config.Dev.Terminal.Command) > 0 {
-		for _, cmd := range *config.Dev.Terminal.Command {
+	if terminalConfig != nil && terminalConfig.Command != nil && len(*terminalConfig.Command) > 0 {
+		for _, cmd := range *terminalConfig.Command {
 			command = append(command, *cmd)
 		}
 	}
@@ -85,7 +334,9 @@ func getCommand(config *latest.Config, args []string) []string {
 	if len(args) > 0 {
 		command = args
 	} else {
-		if len(command) == 0 {
+		if len(command) == 0 && state != nil {
+			command = state.command()
+		} else if len(command) == 0 {
 			command = []string{
 				"sh",
 				"-c",
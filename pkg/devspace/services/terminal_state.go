@@ -0,0 +1,200 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/generated"
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+)
+
+// defaultTerminalStateKey is the key the state of an unnamed terminal is saved with
+const defaultTerminalStateKey = "default"
+
+// terminalStateSequence starts the escape sequence the default shell prints before every prompt to report its
+// working directory and last command. The sequence ends with BEL and is removed from the terminal output
+var terminalStateSequence = []byte("\x1b]devspace-state;")
+
+// maxTerminalStateSequence is the maximum length of a state sequence, longer sequences are printed unchanged
+const maxTerminalStateSequence = 16 * 1024
+
+// terminalStateScript starts bash (or sh) in the working directory $1 and adds the command $2 to the bash history.
+// Before every prompt, bash prints the terminal state sequence with the base64 encoded working directory and last command
+const terminalStateScript = `cd "$1" 2>/dev/null; export DEVSPACE_LAST_COMMAND="$2"; export PROMPT_COMMAND='if [ -n "$DEVSPACE_LAST_COMMAND" ]; then history -s "$DEVSPACE_LAST_COMMAND"; unset DEVSPACE_LAST_COMMAND; fi; printf "\033]devspace-state;%s;%s\007" "$(pwd | base64 | tr -d "\n")" "$(HISTTIMEFORMAT= history 1 | sed "s/^ *[0-9]* *//" | base64 | tr -d "\n")"'; command -v bash >/dev/null 2>&1 && exec bash || exec sh`
+
+// terminalStateTracker keeps track of the working directory and the last command of a terminal, so they can be
+// restored when the terminal is reopened after the pod was restarted or dev mode was reloaded
+type terminalStateTracker struct {
+	key string
+
+	stateMutex sync.Mutex
+	state      generated.TerminalState
+}
+
+// newTerminalStateTracker returns the tracker for the terminal or nil if the state should not be restored, i.e. if
+// a command instead of the default shell is started
+func newTerminalStateTracker(config *latest.Config, terminalConfig *latest.Terminal, args []string) *terminalStateTracker {
+	if config == nil || len(args) > 0 {
+		return nil
+	}
+
+	key := defaultTerminalStateKey
+	if terminalConfig != nil {
+		if terminalConfig.RestoreState != nil && *terminalConfig.RestoreState == false {
+			return nil
+		}
+		if terminalConfig.Command != nil && len(*terminalConfig.Command) > 0 {
+			return nil
+		}
+		if terminalConfig.Name != nil {
+			key = *terminalConfig.Name
+		}
+	}
+
+	tracker := &terminalStateTracker{
+		key: key,
+	}
+
+	generatedConfig, err := generated.LoadConfig()
+	if err == nil && generatedConfig.Terminals != nil && generatedConfig.Terminals[key] != nil {
+		tracker.state = *generatedConfig.Terminals[key]
+	}
+
+	return tracker
+}
+
+// get returns the current state of the terminal
+func (t *terminalStateTracker) get() generated.TerminalState {
+	t.stateMutex.Lock()
+	defer t.stateMutex.Unlock()
+
+	return t.state
+}
+
+// update sets the working directory and the last command. An empty command does not replace the last command,
+// because a new shell has no history yet
+func (t *terminalStateTracker) update(workDir, command string) {
+	t.stateMutex.Lock()
+	defer t.stateMutex.Unlock()
+
+	if workDir != "" {
+		t.state.WorkDir = workDir
+	}
+	if command != "" {
+		t.state.LastCommand = command
+	}
+}
+
+// save saves the state in the generated config if it changed
+func (t *terminalStateTracker) save() error {
+	state := t.get()
+
+	generatedConfig, err := generated.LoadConfig()
+	if err != nil {
+		return err
+	}
+	if generatedConfig.Terminals == nil {
+		generatedConfig.Terminals = map[string]*generated.TerminalState{}
+	} else if saved := generatedConfig.Terminals[t.key]; saved != nil && *saved == state {
+		return nil
+	}
+
+	generatedConfig.Terminals[t.key] = &state
+	return generated.SaveConfig(generatedConfig)
+}
+
+// command returns the command that starts the default shell with the restored state
+func (t *terminalStateTracker) command() []string {
+	state := t.get()
+	return []string{"sh", "-c", terminalStateScript, "devspace", state.WorkDir, state.LastCommand}
+}
+
+// writer returns a writer that removes the state sequences from the terminal output and updates the state
+func (t *terminalStateTracker) writer(out io.Writer) io.Writer {
+	return &terminalStateWriter{
+		out:     out,
+		tracker: t,
+	}
+}
+
+// terminalStateWriter removes the terminal state sequences from the output. Sequences may be split across writes,
+// so a possibly incomplete sequence at the end of a write is held back until the next write
+type terminalStateWriter struct {
+	out     io.Writer
+	tracker *terminalStateTracker
+
+	pending []byte
+}
+
+func (w *terminalStateWriter) Write(p []byte) (int, error) {
+	data := append(w.pending, p...)
+	w.pending = nil
+
+	for {
+		index := bytes.Index(data, terminalStateSequence)
+		if index == -1 {
+			keep := partialPrefixLength(data, terminalStateSequence)
+			w.pending = append([]byte{}, data[len(data)-keep:]...)
+			return len(p), w.write(data[:len(data)-keep])
+		}
+
+		err := w.write(data[:index])
+		if err != nil {
+			return len(p), err
+		}
+
+		data = data[index:]
+		end := bytes.IndexByte(data, '\a')
+		if end == -1 {
+			if len(data) > maxTerminalStateSequence {
+				return len(p), w.write(data)
+			}
+
+			w.pending = append([]byte{}, data...)
+			return len(p), nil
+		}
+
+		w.tracker.update(parseTerminalState(data[len(terminalStateSequence):end]))
+		data = data[end+1:]
+	}
+}
+
+func (w *terminalStateWriter) write(data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	_, err := w.out.Write(data)
+	return err
+}
+
+// parseTerminalState parses the payload of a state sequence (base64 working directory;base64 last command)
+func parseTerminalState(payload []byte) (string, string) {
+	parts := bytes.SplitN(payload, []byte(";"), 2)
+
+	workDir, _ := base64.StdEncoding.DecodeString(string(parts[0]))
+	command := []byte{}
+	if len(parts) == 2 {
+		command, _ = base64.StdEncoding.DecodeString(string(parts[1]))
+	}
+
+	return string(bytes.TrimSpace(workDir)), string(bytes.TrimSpace(command))
+}
+
+// partialPrefixLength returns the length of the longest suffix of data that is a prefix of sequence
+func partialPrefixLength(data, sequence []byte) int {
+	max := len(sequence) - 1
+	if len(data) < max {
+		max = len(data)
+	}
+
+	for length := max; length > 0; length-- {
+		if bytes.HasPrefix(sequence, data[len(data)-length:]) {
+			return length
+		}
+	}
+
+	return 0
+}
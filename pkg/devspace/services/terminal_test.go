@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/versions/latest"
+	"github.com/devspace-cloud/devspace/pkg/devspace/kubectl"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/pkg/util/ptr"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTargetRestarted(t *testing.T) {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", UID: "1"},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{{Name: "app", RestartCount: 1}},
+		},
+	}
+	target := &terminalTarget{pod: pod, container: "app"}
+
+	if targetRestarted(fake.NewSimpleClientset(pod), target) {
+		t.Fatal("Unchanged pod detected as restarted")
+	}
+	if targetRestarted(fake.NewSimpleClientset(), target) == false {
+		t.Fatal("Deleted pod not detected as restarted")
+	}
+
+	replacedPod := pod.DeepCopy()
+	replacedPod.UID = "2"
+	if targetRestarted(fake.NewSimpleClientset(replacedPod), target) == false {
+		t.Fatal("Replaced pod not detected as restarted")
+	}
+
+	restartedPod := pod.DeepCopy()
+	restartedPod.Status.ContainerStatuses[0].RestartCount = 2
+	if targetRestarted(fake.NewSimpleClientset(restartedPod), target) == false {
+		t.Fatal("Restarted container not detected as restarted")
+	}
+}
+
+func TestRunTerminal(t *testing.T) {
+	oldPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: "default", UID: "1"}}
+	newPod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: "default", UID: "2"}}
+	client := fake.NewSimpleClientset(newPod)
+
+	newTarget := func() *terminalTarget {
+		return &terminalTarget{pod: newPod, container: "app", upgradeRoundTripper: &kubectl.UpgraderWrapper{}}
+	}
+	policy := getTerminalReconnectPolicy(&latest.Terminal{
+		Reconnect: &latest.TerminalReconnect{
+			MaxRetries: ptr.Int(2),
+			RetryDelay: ptr.Int(0),
+		},
+	})
+
+	// The terminal is reopened to the new pod after the old pod was deleted
+	runs := []string{}
+	reselects := 0
+	err := runTerminal(client, &terminalTarget{pod: oldPod, container: "app"}, policy, func(target *terminalTarget) error {
+		runs = append(runs, target.pod.Name)
+		return nil
+	}, func() (*terminalTarget, error) {
+		reselects++
+		if reselects == 1 {
+			return nil, errors.New("no running pod")
+		}
+
+		return newTarget(), nil
+	}, log.Discard)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(runs) != 2 || runs[0] != "old" || runs[1] != "new" || reselects != 2 {
+		t.Fatalf("Unexpected runs %v with %d reselects", runs, reselects)
+	}
+
+	// Reconnecting gives up after max retries
+	err = runTerminal(client, &terminalTarget{pod: oldPod, container: "app"}, policy, func(target *terminalTarget) error {
+		return nil
+	}, func() (*terminalTarget, error) {
+		return nil, errors.New("no running pod")
+	}, log.Discard)
+	if err == nil {
+		t.Fatal("No error after max retries")
+	}
+
+	// Disabled reconnect returns the terminal error
+	policy.enabled = false
+	terminalErr := errors.New("terminal closed")
+	err = runTerminal(client, &terminalTarget{pod: oldPod, container: "app"}, policy, func(target *terminalTarget) error {
+		return terminalErr
+	}, nil, log.Discard)
+	if err != terminalErr {
+		t.Fatalf("Unexpected error %v", err)
+	}
+}
+
+func TestTerminalStateWriter(t *testing.T) {
+	tracker := &terminalStateTracker{key: defaultTerminalStateKey}
+	out := &bytes.Buffer{}
+	writer := tracker.writer(out)
+
+	sequence := "\x1b]devspace-state;" + base64.StdEncoding.EncodeToString([]byte("/app/src")) + ";" + base64.StdEncoding.EncodeToString([]byte("npm test")) + "\a"
+	output := "$ ls\r\nmain.go\r\n" + sequence + "\x1b[32m$\x1b[0m "
+
+	// Write the output in small chunks, so the sequence is split across writes
+	for i := 0; i < len(output); i += 5 {
+		end := i + 5
+		if end > len(output) {
+			end = len(output)
+		}
+
+		_, err := writer.Write([]byte(output[i:end]))
+		if err != nil {
+			t.Fatalf("Error writing output: %v", err)
+		}
+	}
+
+	if out.String() != "$ ls\r\nmain.go\r\n\x1b[32m$\x1b[0m " {
+		t.Fatalf("State sequence was not removed from the output: %q", out.String())
+	}
+
+	state := tracker.get()
+	if state.WorkDir != "/app/src" || state.LastCommand != "npm test" {
+		t.Fatalf("Wrong terminal state: %#v", state)
+	}
+
+	// A new shell without history does not reset the last command
+	_, _ = writer.Write([]byte("\x1b]devspace-state;" + base64.StdEncoding.EncodeToString([]byte("/app")) + ";\a"))
+	state = tracker.get()
+	if state.WorkDir != "/app" || state.LastCommand != "npm test" {
+		t.Fatalf("Wrong terminal state after new shell: %#v", state)
+	}
+
+	command := getCommand(nil, nil, tracker)
+	if len(command) != 6 || command[4] != "/app" || command[5] != "npm test" {
+		t.Fatalf("Wrong command for restored terminal: %v", command)
+	}
+}
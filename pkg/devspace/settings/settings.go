@@ -0,0 +1,223 @@
+package settings
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// SettingsPath is the path of the global settings file relative to the home directory
+var SettingsPath = constants.DefaultHomeDevSpaceFolder + "/settings.yaml"
+
+// Settings holds the global settings of the devspace cli that apply to all projects
+type Settings struct {
+	Limits  *Limits  `yaml:"limits,omitempty"`
+	Audit   *Audit   `yaml:"audit,omitempty"`
+	History *History `yaml:"history,omitempty"`
+
+	DeployLock *DeployLock `yaml:"deployLock,omitempty"`
+}
+
+// DeployLock configures the lock that prevents that two processes deploy into the same namespace at the same time
+type DeployLock struct {
+	// Disabled turns the deploy lock off
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Timeout is the time in seconds to wait for the lock if another process is deploying (Default: 600)
+	Timeout int `yaml:"timeout,omitempty"`
+}
+
+// DefaultDeployLockTimeout is the time to wait for the deploy lock if deployLock.timeout is not set
+const DefaultDeployLockTimeout = 10 * time.Minute
+
+// GetDeployLock returns the deploy lock settings. If the settings cannot be loaded, the default settings are returned
+func GetDeployLock() *DeployLock {
+	settings, err := Get()
+	if err != nil || settings.DeployLock == nil {
+		return &DeployLock{}
+	}
+
+	return settings.DeployLock
+}
+
+// GetTimeout returns the time to wait for the deploy lock
+func (d *DeployLock) GetTimeout() time.Duration {
+	if d.Timeout <= 0 {
+		return DefaultDeployLockTimeout
+	}
+
+	return time.Duration(d.Timeout) * time.Second
+}
+
+// History configures which previous versions of the project files are kept for devspace rollback-state
+type History struct {
+	// MaxVersions is the number of versions that are kept per file (Default: 10)
+	MaxVersions int `yaml:"maxVersions,omitempty"`
+
+	// Config keeps the versions of devspace.yaml that are saved by commands like devspace add as well
+	Config bool `yaml:"config,omitempty"`
+}
+
+// Audit configures the log of all actions that change the cluster
+type Audit struct {
+	// Disabled turns the audit log off
+	Disabled bool `yaml:"disabled,omitempty"`
+
+	// Webhook is an optional url every audit log entry is posted to as json
+	Webhook string `yaml:"webhook,omitempty"`
+}
+
+// Limits restrict the resources the devspace cli itself uses. A zero value means no limit or the default
+type Limits struct {
+	// MaxConcurrentBuilds is the maximum number of images that are built in parallel by one process
+	MaxConcurrentBuilds int `yaml:"maxConcurrentBuilds,omitempty"`
+
+	// MaxSyncWorkers is the maximum number of change sets that all syncs upload or download at the same time
+	MaxSyncWorkers int `yaml:"maxSyncWorkers,omitempty"`
+
+	// SyncCoalesceInterval is the time in milliseconds the sync waits for further local changes before uploading them
+	SyncCoalesceInterval int `yaml:"syncCoalesceInterval,omitempty"`
+
+	// WatchPollInterval is the time in milliseconds between two checks of the auto reload file watcher
+	WatchPollInterval int `yaml:"watchPollInterval,omitempty"`
+
+	// APIQPS is the maximum number of kubernetes api requests per second
+	APIQPS int `yaml:"apiQPS,omitempty"`
+
+	// APIBurst is the maximum burst of kubernetes api requests
+	APIBurst int `yaml:"apiBurst,omitempty"`
+}
+
+// limitFields maps the names of the limits to their fields
+var limitFields = map[string]func(l *Limits) *int{
+	"maxConcurrentBuilds":  func(l *Limits) *int { return &l.MaxConcurrentBuilds },
+	"maxSyncWorkers":       func(l *Limits) *int { return &l.MaxSyncWorkers },
+	"syncCoalesceInterval": func(l *Limits) *int { return &l.SyncCoalesceInterval },
+	"watchPollInterval":    func(l *Limits) *int { return &l.WatchPollInterval },
+	"apiQPS":               func(l *Limits) *int { return &l.APIQPS },
+	"apiBurst":             func(l *Limits) *int { return &l.APIBurst },
+}
+
+var loadedSettings *Settings
+var loadSettingsOnce sync.Once
+var loadSettingsErr error
+
+// Get returns the global settings. The settings are loaded only once
+func Get() (*Settings, error) {
+	loadSettingsOnce.Do(func() {
+		loadedSettings, loadSettingsErr = load()
+	})
+
+	return loadedSettings, loadSettingsErr
+}
+
+// GetLimits returns the configured limits. If the settings cannot be loaded, no limits are returned
+func GetLimits() *Limits {
+	settings, err := Get()
+	if err != nil || settings.Limits == nil {
+		return &Limits{}
+	}
+
+	return settings.Limits
+}
+
+func load() (*Settings, error) {
+	settingsPath, err := getSettingsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	settings := &Settings{}
+	data, err := ioutil.ReadFile(settingsPath)
+	if os.IsNotExist(err) {
+		return settings, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	err = yaml.Unmarshal(data, settings)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", settingsPath)
+	}
+
+	return settings, nil
+}
+
+// Save saves the global settings
+func Save(settings *Settings) error {
+	settingsPath, err := getSettingsPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(settings)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(settingsPath), 0755)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(settingsPath, data, 0644)
+}
+
+func getSettingsPath() (string, error) {
+	homedir, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(homedir, SettingsPath), nil
+}
+
+// LimitNames returns the sorted names of all limits
+func LimitNames() []string {
+	names := make([]string, 0, len(limitFields))
+	for name := range limitFields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// SetLimit sets the limit with the given name. A value of 0 removes the limit
+func (s *Settings) SetLimit(name, value string) error {
+	field, ok := limitFields[name]
+	if ok == false {
+		return fmt.Errorf("Unknown limit %s, supported limits are: %v", name, LimitNames())
+	}
+
+	intValue, err := strconv.Atoi(value)
+	if err != nil || intValue < 0 {
+		return fmt.Errorf("Invalid value %s for limit %s: expected a positive number", value, name)
+	}
+
+	if s.Limits == nil {
+		s.Limits = &Limits{}
+	}
+
+	*field(s.Limits) = intValue
+	return nil
+}
+
+// Milliseconds converts a limit in milliseconds to a duration and returns the default if the limit is not set
+func Milliseconds(value int, defaultValue time.Duration) time.Duration {
+	if value <= 0 {
+		return defaultValue
+	}
+
+	return time.Duration(value) * time.Millisecond
+}
@@ -0,0 +1,50 @@
+package settings
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetLimit(t *testing.T) {
+	settings := &Settings{}
+
+	err := settings.SetLimit("maxConcurrentBuilds", "2")
+	if err != nil {
+		t.Fatalf("Error setting limit: %v", err)
+	}
+	err = settings.SetLimit("apiQPS", "10")
+	if err != nil {
+		t.Fatalf("Error setting limit: %v", err)
+	}
+	if settings.Limits.MaxConcurrentBuilds != 2 || settings.Limits.APIQPS != 10 {
+		t.Fatalf("Unexpected limits %#v", settings.Limits)
+	}
+
+	err = settings.SetLimit("maxConcurrentBuilds", "0")
+	if err != nil {
+		t.Fatalf("Error removing limit: %v", err)
+	}
+	if settings.Limits.MaxConcurrentBuilds != 0 {
+		t.Fatalf("Expected limit to be removed, got %d", settings.Limits.MaxConcurrentBuilds)
+	}
+
+	for _, args := range [][]string{{"unknown", "1"}, {"maxSyncWorkers", "-1"}, {"maxSyncWorkers", "many"}} {
+		err = settings.SetLimit(args[0], args[1])
+		if err == nil {
+			t.Fatalf("Expected error for %v", args)
+		}
+	}
+
+	if len(LimitNames()) != len(limitFields) {
+		t.Fatalf("Unexpected limit names %v", LimitNames())
+	}
+}
+
+func TestMilliseconds(t *testing.T) {
+	if Milliseconds(0, time.Second) != time.Second {
+		t.Fatalf("Expected default for unset limit")
+	}
+	if Milliseconds(1500, time.Second) != 1500*time.Millisecond {
+		t.Fatalf("Unexpected duration %v", Milliseconds(1500, time.Second))
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 	"github.com/juju/ratelimit"
 	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/devspace-cloud/devspace/sync/remote"
 	"github.com/devspace-cloud/devspace/sync/util"
@@ -21,11 +24,18 @@ type downstream struct {
 
 	reader io.ReadCloser
 	writer io.WriteCloser
+	conn   io.Closer
 	client remote.DownstreamClient
 }
 
 const downloadFilesBufferSize = 64
 
+// hashFilesBatchSize is the amount of paths the remote side hashes per request
+const hashFilesBatchSize = 100
+
+// watchProtocolVersion is the first sync protocol version that streams changes from file system events
+const watchProtocolVersion = 2
+
 // newDownstream creates a new downstream handler with the given parameters
 func newDownstream(reader io.ReadCloser, writer io.WriteCloser, sync *Sync) (*downstream, error) {
 	var (
@@ -47,13 +57,30 @@ func newDownstream(reader io.ReadCloser, writer io.WriteCloser, sync *Sync) (*do
 		return nil, errors.Wrap(err, "new client connection")
 	}
 
+	d := newDownstreamFromConn(conn, sync)
+	d.reader = reader
+	d.writer = writer
+	return d, nil
+}
+
+// newDownstreamWithAddress creates a new downstream handler that connects to the given tcp address
+func newDownstreamWithAddress(address string, sync *Sync) (*downstream, error) {
+	conn, err := util.NewAddressClientConnection(address, sync.limitConn)
+	if err != nil {
+		return nil, errors.Wrap(err, "new client connection")
+	}
+
+	d := newDownstreamFromConn(conn, sync)
+	d.conn = conn
+	return d, nil
+}
+
+func newDownstreamFromConn(conn *grpc.ClientConn, sync *Sync) *downstream {
 	return &downstream{
 		interrupt: make(chan bool, 1),
 		sync:      sync,
-		reader:    reader,
-		writer:    writer,
 		client:    remote.NewDownstreamClient(conn),
-	}, nil
+	}
 }
 
 func (d *downstream) populateFileMap() error {
@@ -74,6 +101,59 @@ func (d *downstream) populateFileMap() error {
 	return nil
 }
 
+// filterUnchangedFiles compares the content digests of the given local files with the remote ones and
+// removes all files that already exist with the same content on the remote side
+func (d *downstream) filterUnchangedFiles(changes []*FileInformation) ([]*FileInformation, error) {
+	remoteHashes := make(map[string]string)
+	paths := make([]string, 0, hashFilesBatchSize)
+
+	d.sync.fileIndex.fileMapMutex.Lock()
+	existing := make([]string, 0, len(changes))
+	for _, change := range changes {
+		if change.IsDirectory == false && d.sync.fileIndex.fileMap[change.Name] != nil {
+			existing = append(existing, change.Name)
+		}
+	}
+	d.sync.fileIndex.fileMapMutex.Unlock()
+
+	for i, path := range existing {
+		paths = append(paths, path)
+		if len(paths) < hashFilesBatchSize && i < len(existing)-1 {
+			continue
+		}
+
+		hashes, err := d.client.Hashes(context.Background(), &remote.Paths{Paths: paths})
+		if err != nil {
+			if status.Code(err) == codes.Unimplemented {
+				d.sync.log.Warn("Sync helper in container does not support content hash comparison, falling back to modification time comparison")
+				return changes, nil
+			}
+
+			return nil, errors.Wrap(err, "retrieve hashes")
+		}
+
+		for _, hash := range hashes.Hashes {
+			remoteHashes[hash.Path] = hash.Hash
+		}
+
+		paths = make([]string, 0, hashFilesBatchSize)
+	}
+
+	filtered := make([]*FileInformation, 0, len(changes))
+	for _, change := range changes {
+		if remoteHash, ok := remoteHashes[change.Name]; ok {
+			localHash, err := util.HashFile(filepath.Join(d.sync.LocalPath, change.Name))
+			if err == nil && localHash == remoteHash {
+				continue
+			}
+		}
+
+		filtered = append(filtered, change)
+	}
+
+	return filtered, nil
+}
+
 func (d *downstream) collectChanges() ([]*remote.Change, error) {
 	changes := make([]*remote.Change, 0, 128)
 
@@ -104,6 +184,76 @@ func (d *downstream) collectChanges() ([]*remote.Change, error) {
 }
 
 func (d *downstream) mainLoop() error {
+	version, err := d.client.Version(context.Background(), &remote.Empty{})
+	if err != nil && status.Code(err) != codes.Unimplemented {
+		return errors.Wrap(err, "get protocol version")
+	}
+
+	// Older sync helpers can only be polled for changes
+	if err == nil && version.Version >= watchProtocolVersion {
+		return d.watchLoop()
+	}
+
+	return d.pollLoop()
+}
+
+// watchLoop receives the changes the remote side collected from file system events and applies every
+// batch at once
+func (d *downstream) watchLoop() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		select {
+		case <-d.interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	watchClient, err := d.client.WatchChanges(ctx, &remote.WatchOptions{
+		DebounceMillis: int64(d.sync.Options.DebounceInterval / time.Millisecond),
+	})
+	if err != nil {
+		return errors.Wrap(err, "start watching changes")
+	}
+
+	changes := make([]*remote.Change, 0, 128)
+	for {
+		changeChunk, err := watchClient.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			} else if status.Code(err) == codes.FailedPrecondition {
+				d.sync.log.Warnf("Downstream - Cannot watch for changes in container (%s), falling back to polling", status.Convert(err).Message())
+				return d.pollLoop()
+			} else if err == io.EOF {
+				return errors.New("remote closed the change stream")
+			}
+
+			return errors.Wrap(err, "recv change")
+		}
+
+		for _, change := range changeChunk.Changes {
+			if d.shouldKeep(change) {
+				changes = append(changes, change)
+			}
+		}
+
+		if changeChunk.LastChunk {
+			err = d.applyChangesAndNotify(changes)
+			if err != nil {
+				return err
+			}
+
+			changes = make([]*remote.Change, 0, 128)
+		}
+	}
+}
+
+// pollLoop checks the remote side for changes in the polling interval and applies them as soon as the
+// amount of changes does not change anymore
+func (d *downstream) pollLoop() error {
 	lastAmountChanges := int64(0)
 
 	for {
@@ -120,16 +270,16 @@ func (d *downstream) mainLoop() error {
 				return errors.Wrap(err, "collect changes")
 			}
 
-			err = d.applyChanges(changes)
+			err = d.applyChangesAndNotify(changes)
 			if err != nil {
-				return errors.Wrap(err, "apply changes")
+				return err
 			}
 		}
 
 		select {
 		case <-d.interrupt:
 			return nil
-		case <-time.After(1700 * time.Millisecond):
+		case <-time.After(d.sync.Options.PollingInterval):
 			break
 		}
 
@@ -137,6 +287,21 @@ func (d *downstream) mainLoop() error {
 	}
 }
 
+// applyChangesAndNotify applies a change set and calls the OnDownload handler once afterwards,
+// so that commands triggered by downloads run once per change set and not once per file
+func (d *downstream) applyChangesAndNotify(changes []*remote.Change) error {
+	err := d.applyChanges(changes)
+	if err != nil {
+		return errors.Wrap(err, "apply changes")
+	}
+
+	if len(changes) > 0 && d.sync.Options.OnDownload != nil {
+		d.sync.Options.OnDownload(d.sync.log)
+	}
+
+	return nil
+}
+
 func (d *downstream) shouldKeep(change *remote.Change) bool {
 	d.sync.fileIndex.fileMapMutex.Lock()
 	defer d.sync.fileIndex.fileMapMutex.Unlock()
@@ -167,6 +332,9 @@ func (d *downstream) applyChanges(changes []*remote.Change) error {
 		return nil
 	}
 
+	d.sync.Options.Workers.acquire()
+	defer d.sync.Options.Workers.release()
+
 	// determine what to delete and what to download
 	for _, change := range changes {
 		if change.ChangeType == remote.ChangeType_DELETE {
@@ -179,6 +347,11 @@ func (d *downstream) applyChanges(changes []*remote.Change) error {
 	// Remove all files and folders that should be deleted first and we ignore errors
 	d.remove(remove)
 
+	// Skip downloads that would overwrite each other on case insensitive file systems
+	d.sync.fileIndex.fileMapMutex.Lock()
+	download = d.sync.platform.filterCaseCollisions(download, d.sync.fileIndex.fileMap, d.sync.log)
+	d.sync.fileIndex.fileMapMutex.Unlock()
+
 	// Extract downloaded archive
 	if len(download) > 0 {
 		reader, writer, err := os.Pipe()
@@ -75,12 +75,12 @@ func shouldUpload(relativePath string, stat os.FileInfo, s *Sync, isInitial bool
 
 		if isInitial {
 			// File is older locally than remote so don't update remote
-			if stat.ModTime().Unix() <= s.fileIndex.fileMap[relativePath].Mtime {
+			if s.platform.mtimeNewer(stat.ModTime().Unix(), s.fileIndex.fileMap[relativePath].Mtime) == false {
 				return false
 			}
 		} else {
 			// File did not change or was changed by downstream
-			if stat.ModTime().Unix() == s.fileIndex.fileMap[relativePath].Mtime && stat.Size() == s.fileIndex.fileMap[relativePath].Size {
+			if s.platform.mtimeEqual(stat.ModTime().Unix(), s.fileIndex.fileMap[relativePath].Mtime) && stat.Size() == s.fileIndex.fileMap[relativePath].Size {
 				return false
 			}
 		}
@@ -96,14 +96,14 @@ func shouldDownload(change *remote.Change, s *Sync) bool {
 		// Don't override folders that exist in the filemap
 		if change.IsDir == false {
 			// Redownload file if mtime is newer than saved one
-			if change.MtimeUnix > s.fileIndex.fileMap[change.Path].Mtime {
+			if s.platform.mtimeNewer(change.MtimeUnix, s.fileIndex.fileMap[change.Path].Mtime) {
 				return true
 			}
 
 			// Redownload file if size changed && file is not older than the one in the fileMap
 			// the mTime check is necessary, because otherwise we would override older local files that
 			// are not overridden initially
-			if change.MtimeUnix == s.fileIndex.fileMap[change.Path].Mtime && change.Size != s.fileIndex.fileMap[change.Path].Size {
+			if s.platform.mtimeEqual(change.MtimeUnix, s.fileIndex.fileMap[change.Path].Mtime) && change.Size != s.fileIndex.fileMap[change.Path].remoteSize() {
 				return true
 			}
 		}
@@ -158,7 +158,7 @@ func shouldRemoveLocal(absFilepath string, fileInformation *FileInformation, s *
 			// We don't delete the file if it has changed in the map since we collected changes
 			if fileInformation.Mtime == s.fileIndex.fileMap[fileInformation.Name].Mtime && fileInformation.Size == s.fileIndex.fileMap[fileInformation.Name].Size {
 				// We don't delete the file if it has changed on the filesystem meanwhile
-				if stat.ModTime().Unix() <= fileInformation.Mtime {
+				if s.platform.mtimeNewer(stat.ModTime().Unix(), fileInformation.Mtime) == false {
 					return true
 				}
 
@@ -12,10 +12,23 @@ type FileInformation struct {
 	Mtime     int64
 	MtimeNano int64
 
+	// RemoteSize is the size of the file in the container if it differs from the local size, because the
+	// line endings were converted during the transfer
+	RemoteSize int64
+
 	IsSymbolicLink bool
 	IsDirectory    bool
 }
 
+// remoteSize returns the size of the file in the container
+func (f *FileInformation) remoteSize() int64 {
+	if f.RemoteSize != 0 {
+		return f.RemoteSize
+	}
+
+	return f.Size
+}
+
 // Sys implements interface
 func (f *FileInformation) Sys() interface{} {
 	return f
@@ -0,0 +1,106 @@
+package sync
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// gitignoreFile is the name of the files the exclude paths are read from if ExcludeFromGitignore is enabled
+const gitignoreFile = ".gitignore"
+
+// GetGitignorePaths reads the .gitignore file in localPath and all nested .gitignore files and returns their
+// patterns as exclude paths relative to localPath. Folders that are excluded by excludePaths or the top-level
+// .gitignore are not searched for nested .gitignore files
+func GetGitignorePaths(localPath string, excludePaths []string) ([]string, error) {
+	gitignorePaths, err := readGitignore(localPath, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ignoreMatcher, err := CompilePaths(append(append([]string{}, excludePaths...), gitignorePaths...))
+	if err != nil {
+		return nil, err
+	}
+
+	err = filepath.Walk(localPath, func(fullPath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() == false || fullPath == localPath {
+			return nil
+		}
+
+		relativePath := getRelativeFromFullPath(fullPath, localPath)
+		if info.Name() == ".git" || (ignoreMatcher != nil && (ignoreMatcher.MatchesPath(relativePath) || ignoreMatcher.MatchesPath(relativePath+"/"))) {
+			return filepath.SkipDir
+		}
+
+		paths, err := readGitignore(fullPath, relativePath)
+		if err != nil {
+			return err
+		}
+
+		gitignorePaths = append(gitignorePaths, paths...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return gitignorePaths, nil
+}
+
+// readGitignore reads the .gitignore file in dir and converts its patterns to exclude paths relative to the sync
+// root, where relativeDir is the path of dir relative to the sync root (empty for the sync root)
+func readGitignore(dir, relativeDir string) ([]string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, gitignoreFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "read %s", filepath.Join(dir, gitignoreFile))
+	}
+
+	paths := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		pattern := strings.TrimRight(line, "\r")
+		if strings.TrimSpace(pattern) == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+
+		paths = append(paths, rebasePattern(pattern, relativeDir))
+	}
+
+	return paths, nil
+}
+
+// rebasePattern converts a .gitignore pattern of a nested folder to a pattern relative to the sync root
+func rebasePattern(pattern, relativeDir string) string {
+	if relativeDir == "" {
+		return pattern
+	}
+
+	negate := ""
+	if strings.HasPrefix(pattern, "!") {
+		negate = "!"
+		pattern = pattern[1:]
+	}
+
+	// Patterns without a slash (except a trailing one) match in all subfolders of the .gitignore folder
+	if strings.Contains(strings.TrimSuffix(pattern, "/"), "/") == false {
+		return negate + path.Join(relativeDir, "**", pattern) + trailingSlash(pattern)
+	}
+
+	return negate + path.Join(relativeDir, strings.TrimPrefix(pattern, "/")) + trailingSlash(pattern)
+}
+
+func trailingSlash(pattern string) string {
+	if strings.HasSuffix(pattern, "/") {
+		return "/"
+	}
+
+	return ""
+}
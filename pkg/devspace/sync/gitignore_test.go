@@ -0,0 +1,72 @@
+package sync
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGetGitignorePaths(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testGitignore")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		".gitignore":                        "# dependencies\nnode_modules/\n\n*.log\r\n",
+		"frontend/.gitignore":               "dist/\n/build\n!keep.log\n",
+		"node_modules/.gitignore":           "ignored\n",
+		"frontend/src/app.js":               "",
+		"frontend/nested/dist/app.js":       "",
+		"frontend/nested/src/.gitignore":    "",
+		"backend/internal/cache/.gitignore": "*.tmp\n",
+	}
+	for name, content := range files {
+		err = os.MkdirAll(filepath.Join(dir, filepath.Dir(name)), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	paths, err := GetGitignorePaths(dir, []string{"/backend/internal/"})
+	if err != nil {
+		t.Fatalf("Error reading .gitignore files: %v", err)
+	}
+
+	expected := []string{"node_modules/", "*.log", "/frontend/**/dist/", "/frontend/build", "!/frontend/**/keep.log"}
+	if len(paths) != len(expected) {
+		t.Fatalf("Expected paths %v, got %v", expected, paths)
+	}
+	for index, path := range expected {
+		if paths[index] != path {
+			t.Fatalf("Expected paths %v, got %v", expected, paths)
+		}
+	}
+
+	ignoreMatcher, err := CompilePaths(paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matches := map[string]bool{
+		"/node_modules/package":        true,
+		"/frontend/nested/dist/app.js": true,
+		"/frontend/build":              true,
+		"/frontend/error.log":          true,
+		"/frontend/keep.log":           false,
+		"/frontend/src/app.js":         false,
+		"/build":                       false,
+	}
+	for path, expectedMatch := range matches {
+		if ignoreMatcher.MatchesPath(path) != expectedMatch {
+			t.Fatalf("Expected %s to match %v", path, expectedMatch)
+		}
+	}
+}
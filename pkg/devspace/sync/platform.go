@@ -0,0 +1,137 @@
+package sync
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/sync/remote"
+)
+
+// textDetectionBytes is the amount of bytes that are checked for a NUL byte to decide if a file is a text file
+const textDetectionBytes = 8000
+
+// platform abstracts the differences between the local file system and the linux file system in the container,
+// such as path separators, case insensitivity, timestamp resolution and line endings
+type platform struct {
+	// caseInsensitive is true if paths that only differ in case point to the same local file
+	caseInsensitive bool
+
+	// mtimeTolerance is the amount of seconds two modification times may differ and still be treated as equal,
+	// because some file systems (e.g. FAT) only store timestamps with a resolution of two seconds
+	mtimeTolerance int64
+
+	// convertLineEndings converts CRLF line endings of text files to LF on upload and back on download
+	convertLineEndings bool
+}
+
+// newPlatform creates a platform for the operating system the sync runs on
+func newPlatform(options *Options) *platform {
+	p := &platform{
+		caseInsensitive: runtime.GOOS == "windows" || runtime.GOOS == "darwin",
+	}
+	if runtime.GOOS == "windows" {
+		p.mtimeTolerance = 1
+	}
+	if options != nil {
+		p.convertLineEndings = options.ConvertLineEndings
+	}
+
+	return p
+}
+
+// localPath converts the slash separated relative path of a synced file to a path on the local file system
+func (p *platform) localPath(basePath, relativePath string) string {
+	return filepath.Join(basePath, filepath.FromSlash(relativePath))
+}
+
+// mtimeNewer checks if the modification time a is newer than b
+func (p *platform) mtimeNewer(a, b int64) bool {
+	return a > b+p.mtimeTolerance
+}
+
+// mtimeEqual checks if the modification times a and b are equal
+func (p *platform) mtimeEqual(a, b int64) bool {
+	return p.mtimeNewer(a, b) == false && p.mtimeNewer(b, a) == false
+}
+
+// pathKey returns the key under which the local file system stores the relative path
+func (p *platform) pathKey(relativePath string) string {
+	if p.caseInsensitive {
+		return strings.ToLower(relativePath)
+	}
+
+	return relativePath
+}
+
+// filterCaseCollisions removes the downloads from changes that would overwrite a different download or an already
+// synced file on a case insensitive file system. fileMap needs to be locked before this function is called
+func (p *platform) filterCaseCollisions(changes []*remote.Change, fileMap map[string]*FileInformation, log log.Logger) []*remote.Change {
+	if p.caseInsensitive == false {
+		return changes
+	}
+
+	existing := make(map[string]string, len(fileMap))
+	for name := range fileMap {
+		existing[p.pathKey(name)] = name
+	}
+
+	seen := make(map[string]string, len(changes))
+	filtered := make([]*remote.Change, 0, len(changes))
+	for _, change := range changes {
+		key := p.pathKey(change.Path)
+		if other, ok := seen[key]; ok && other != change.Path {
+			log.Warnf("Downstream - Skip %s because it collides with %s on this case insensitive file system", change.Path, other)
+			continue
+		}
+		if other, ok := existing[key]; ok && other != change.Path {
+			log.Warnf("Downstream - Skip %s because it collides with the local file %s on this case insensitive file system", change.Path, other)
+			continue
+		}
+
+		seen[key] = change.Path
+		filtered = append(filtered, change)
+	}
+
+	return filtered
+}
+
+// toRemoteContent converts the content of a local file before it is uploaded. The second return value is false if
+// the content was not changed
+func (p *platform) toRemoteContent(content []byte) ([]byte, bool) {
+	if p == nil || p.convertLineEndings == false || isText(content) == false || bytes.Contains(content, []byte("\r\n")) == false {
+		return content, false
+	}
+
+	return bytes.Replace(content, []byte("\r\n"), []byte("\n"), -1), true
+}
+
+// toLocalContent converts the content of a downloaded file before it is written locally. The second return value is
+// false if the content was not changed
+func (p *platform) toLocalContent(content []byte) ([]byte, bool) {
+	if p == nil || p.convertLineEndings == false || isText(content) == false || bytes.Contains(content, []byte("\n")) == false {
+		return content, false
+	}
+
+	converted := make([]byte, 0, len(content)+bytes.Count(content, []byte("\n")))
+	for i, b := range content {
+		if b == '\n' && (i == 0 || content[i-1] != '\r') {
+			converted = append(converted, '\r')
+		}
+
+		converted = append(converted, b)
+	}
+
+	return converted, len(converted) != len(content)
+}
+
+// isText checks if the content looks like text, which is the case if it doesn't contain a NUL byte in its beginning
+func isText(content []byte) bool {
+	if len(content) > textDetectionBytes {
+		content = content[:textDetectionBytes]
+	}
+
+	return bytes.IndexByte(content, 0) == -1
+}
@@ -0,0 +1,62 @@
+package sync
+
+import (
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/devspace-cloud/devspace/sync/remote"
+	"gotest.tools/assert"
+)
+
+func TestLineEndingConversion(t *testing.T) {
+	p := &platform{convertLineEndings: true}
+
+	converted, changed := p.toRemoteContent([]byte("a\r\nb\r\n"))
+	assert.Equal(t, changed, true)
+	assert.Equal(t, string(converted), "a\nb\n")
+
+	converted, changed = p.toLocalContent([]byte("a\nb\r\nc"))
+	assert.Equal(t, changed, true)
+	assert.Equal(t, string(converted), "a\r\nb\r\nc")
+
+	_, changed = p.toLocalContent([]byte("a\r\nb"))
+	assert.Equal(t, changed, false)
+
+	_, changed = p.toRemoteContent([]byte("bin\x00ary\r\n"))
+	assert.Equal(t, changed, false, "Binary files should not be converted")
+
+	_, changed = (&platform{}).toRemoteContent([]byte("a\r\n"))
+	assert.Equal(t, changed, false, "Conversion should be disabled by default")
+}
+
+func TestMtimeTolerance(t *testing.T) {
+	p := &platform{mtimeTolerance: 1}
+	assert.Equal(t, p.mtimeEqual(10, 11), true)
+	assert.Equal(t, p.mtimeNewer(11, 10), false)
+	assert.Equal(t, p.mtimeNewer(12, 10), true)
+
+	p = &platform{}
+	assert.Equal(t, p.mtimeEqual(10, 11), false)
+	assert.Equal(t, p.mtimeNewer(11, 10), true)
+}
+
+func TestFilterCaseCollisions(t *testing.T) {
+	changes := []*remote.Change{
+		{Path: "/Readme.md"},
+		{Path: "/README.md"},
+		{Path: "/src/Main.go"},
+		{Path: "/other.go"},
+	}
+	fileMap := map[string]*FileInformation{
+		"/src/main.go": {Name: "/src/main.go"},
+		"/other.go":    {Name: "/other.go"},
+	}
+
+	filtered := (&platform{caseInsensitive: true}).filterCaseCollisions(changes, fileMap, log.Discard)
+	assert.Equal(t, len(filtered), 2)
+	assert.Equal(t, filtered[0].Path, "/Readme.md")
+	assert.Equal(t, filtered[1].Path, "/other.go")
+
+	filtered = (&platform{}).filterCaseCollisions(changes, fileMap, log.Discard)
+	assert.Equal(t, len(filtered), 4)
+}
@@ -0,0 +1,167 @@
+package sync
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var syncStopped = regexp.MustCompile(`^\[Sync\] Sync stopped$`)
+var downstreamChanges = regexp.MustCompile(`^\[Downstream\] Successfully processed (\d+) change\(s\)$`)
+var upstreamChanges = regexp.MustCompile(`^\[Upstream\] Successfully processed (\d+) change\(s\)$`)
+
+// Status is the status of a single sync that is parsed from the sync log
+type Status struct {
+	Status    string
+	Pod       string
+	Local     string
+	Container string
+
+	LastActivity     string
+	LastActivityTime string
+	Error            string
+
+	TotalChanges int
+}
+
+// ReadStatus reads the sync log and returns the status of every sync that wrote to it
+func ReadStatus(syncLogPath string) ([]*Status, error) {
+	data, err := ioutil.ReadFile(syncLogPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseStatus(data)
+}
+
+// ParseStatus parses the entries of the sync log and returns the status of every sync in the order the syncs
+// wrote their first entry. Entries that don't belong to a sync (without pod, local and container) are ignored
+func ParseStatus(data []byte) ([]*Status, error) {
+	statusList := []*Status{}
+	statusMap := map[string]*Status{}
+
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+
+		jsonMap := make(map[string]string)
+		err := json.Unmarshal([]byte(line), &jsonMap)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse sync log entry %s", line)
+		}
+		if jsonMap["container"] == "" || jsonMap["local"] == "" || jsonMap["pod"] == "" || jsonMap["level"] == "" || jsonMap["time"] == "" || jsonMap["msg"] == "" {
+			continue
+		}
+
+		identifier := jsonMap["pod"] + ":" + jsonMap["local"] + ":" + jsonMap["container"]
+		status, ok := statusMap[identifier]
+		if ok == false {
+			status = &Status{
+				Pod:       jsonMap["pod"],
+				Local:     jsonMap["local"],
+				Container: jsonMap["container"],
+			}
+
+			statusMap[identifier] = status
+			statusList = append(statusList, status)
+		}
+
+		status.update(jsonMap["level"], jsonMap["msg"], jsonMap["time"])
+	}
+
+	return statusList, nil
+}
+
+func (s *Status) update(level, message, timestamp string) {
+	if level == "error" {
+		s.Status = "Error"
+		s.Error = message
+		s.LastActivityTime = timestamp
+	} else if matches := downstreamChanges.FindStringSubmatch(message); len(matches) == 2 {
+		s.LastActivity = "Downloaded " + matches[1] + " changes"
+		s.LastActivityTime = timestamp
+
+		changes, _ := strconv.Atoi(matches[1])
+		s.TotalChanges += changes
+	} else if matches := upstreamChanges.FindStringSubmatch(message); len(matches) == 2 {
+		s.LastActivity = "Uploaded " + matches[1] + " changes"
+		s.LastActivityTime = timestamp
+
+		changes, _ := strconv.Atoi(matches[1])
+		s.TotalChanges += changes
+	} else if syncStopped.MatchString(message) {
+		s.Status = "Stopped"
+		s.LastActivity = "Sync stopped"
+		s.LastActivityTime = timestamp
+	}
+}
+
+// GetStatus returns the status of the sync, i.e. Active, Stopped or Error
+func (s *Status) GetStatus() string {
+	if s.Status == "" {
+		return "Active"
+	}
+
+	return s.Status
+}
+
+// GetLatestActivity returns the latest activity or error of the sync and how long ago it happened
+func (s *Status) GetLatestActivity() string {
+	latestActivity := s.LastActivity
+	if s.Error != "" {
+		latestActivity = s.Error
+	}
+
+	parsedTime, err := time.Parse(time.RFC3339, s.LastActivityTime)
+	if err != nil {
+		parsedTime = time.Now()
+	}
+
+	return latestActivity + " (" + intToTimeString(int(time.Now().Unix()-parsedTime.Unix())) + " ago)"
+}
+
+func intToTimeString(timeDifference int) string {
+	days := math.Floor(float64(timeDifference) / (60.0 * 60.0 * 24.0))
+	if days > 0 {
+		if days == 1 {
+			return "1d"
+		}
+
+		return strconv.Itoa(int(days)) + "d"
+	}
+
+	hours := math.Floor(float64(timeDifference) / (60.0 * 60.0))
+	if hours > 0 {
+		if hours == 1 {
+			return "1h"
+		}
+
+		return strconv.Itoa(int(hours)) + "h"
+	}
+
+	minutes := math.Floor(float64(timeDifference) / 60.0)
+	if minutes > 0 {
+		if minutes == 1 {
+			return "1m"
+		}
+
+		return strconv.Itoa(int(minutes)) + "m"
+	}
+
+	if timeDifference > 0 {
+		if timeDifference == 1 {
+			return "1s"
+		}
+
+		return strconv.Itoa(timeDifference) + "s"
+	}
+
+	return "0s"
+}
@@ -0,0 +1,34 @@
+package sync
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestParseStatus(t *testing.T) {
+	data := `{"level":"info","msg":"[Sync] Start syncing","time":"2019-10-01T10:00:00Z"}
+{"container":"/app","level":"info","local":"/project","msg":"[Sync] Start syncing","pod":"app-0","time":"2019-10-01T10:00:00Z"}
+{"container":"/app","level":"info","local":"/project","msg":"[Upstream] Successfully processed 3 change(s)","pod":"app-0","time":"2019-10-01T10:01:00Z"}
+{"container":"/data","level":"info","local":"/data","msg":"[Downstream] Successfully processed 2 change(s)","pod":"app-0","time":"2019-10-01T10:02:00Z"}
+{"container":"/data","level":"error","local":"/data","msg":"[Sync] Fatal sync error","pod":"app-0","time":"2019-10-01T10:03:00Z"}
+{"container":"/app","level":"info","local":"/project","msg":"[Sync] Sync stopped","pod":"app-0","time":"2019-10-01T10:04:00Z"}
+`
+
+	statusList, err := ParseStatus([]byte(data))
+	assert.NilError(t, err)
+	assert.Equal(t, len(statusList), 2, "Wrong number of syncs")
+
+	assert.Equal(t, statusList[0].Local, "/project")
+	assert.Equal(t, statusList[0].GetStatus(), "Stopped")
+	assert.Equal(t, statusList[0].TotalChanges, 3)
+	assert.Equal(t, statusList[0].LastActivityTime, "2019-10-01T10:04:00Z")
+
+	assert.Equal(t, statusList[1].Container, "/data")
+	assert.Equal(t, statusList[1].GetStatus(), "Error")
+	assert.Equal(t, statusList[1].Error, "[Sync] Fatal sync error")
+	assert.Equal(t, statusList[1].TotalChanges, 2)
+
+	_, err = ParseStatus([]byte("no json\n"))
+	assert.ErrorContains(t, err, "parse sync log entry")
+}
@@ -19,23 +19,74 @@ import (
 )
 
 var initialUpstreamBatchSize = 1000
+
+// defaultPollingInterval is the interval in which the remote container is checked for changes
+const defaultPollingInterval = 1700 * time.Millisecond
+
+// defaultDebounceInterval is the time the remote side waits for further file events before it sends the changes
+const defaultDebounceInterval = 300 * time.Millisecond
+
+// defaultCoalesceInterval is the time the upstream waits for further local changes before it uploads them
+const defaultCoalesceInterval = 600 * time.Millisecond
+
 var syncLog log.Logger
 
+// syncLogToFile is true if syncLog writes to the sync log file, in this case every sync adds its fields to the entries
+var syncLogToFile bool
+
 // Options holds the sync options
 type Options struct {
 	ExcludePaths         []string
 	DownloadExcludePaths []string
 	UploadExcludePaths   []string
 
+	// ExcludeFromGitignore adds the patterns of the .gitignore files in the local path to the exclude paths
+	ExcludeFromGitignore bool
+
+	// CompareByHash compares files that exist locally and remotely by their content during the initial sync
+	// instead of by their modification time
+	CompareByHash bool
+
+	// ConvertLineEndings converts CRLF line endings of local text files to LF on upload and back on download
+	ConvertLineEndings bool
+
 	UpstreamLimit   int64
 	DownstreamLimit int64
 	Verbose         bool
 
+	// PollingInterval is the interval in which the remote container is checked for changes, if the
+	// sync helper in the container does not support watching for changes
+	PollingInterval time.Duration
+
+	// DebounceInterval is the time the sync helper in the container waits for further file events
+	// before it sends the collected changes
+	DebounceInterval time.Duration
+
+	// CoalesceInterval is the time the upstream waits for further local changes before it uploads them
+	CoalesceInterval time.Duration
+
+	// Workers limits the number of change sets that are applied at the same time, nil means no limit
+	Workers WorkerPool
+
+	// OnUpload is called with the sync log after local changes were uploaded to the container
+	OnUpload func(log log.Logger)
+
+	// OnDownload is called with the sync log after remote changes were downloaded to the local directory
+	OnDownload func(log log.Logger)
+
 	// These channels can be used to listen for certain sync events
 	DownstreamInitialSyncDone chan bool
 	UpstreamInitialSyncDone   chan bool
 	SyncDone                  chan bool
 
+	// ErrorHandler is called with the error if the sync stops because of an error. If it is not set, the process
+	// exits with a fatal error
+	ErrorHandler func(err error)
+
+	// LogFields are added to every entry of the sync log (e.g. pod and container), the local path is always added.
+	// They are ignored if Log is set
+	LogFields map[string]interface{}
+
 	Log log.Logger
 }
 
@@ -45,6 +96,7 @@ type Sync struct {
 	Options   *Options
 
 	fileIndex *fileIndex
+	platform  *platform
 
 	ignoreMatcher         gitignore.IgnoreParser
 	downloadIgnoreMatcher gitignore.IgnoreParser
@@ -80,6 +132,25 @@ func NewSync(localPath string, options *Options) (*Sync, error) {
 		options.ExcludePaths = make([]string, 0, 2)
 	}
 
+	if options.PollingInterval <= 0 {
+		options.PollingInterval = defaultPollingInterval
+	}
+	if options.DebounceInterval <= 0 {
+		options.DebounceInterval = defaultDebounceInterval
+	}
+	if options.CoalesceInterval <= 0 {
+		options.CoalesceInterval = defaultCoalesceInterval
+	}
+
+	if options.ExcludeFromGitignore {
+		gitignorePaths, err := GetGitignorePaths(absoluteLocalPath, options.ExcludePaths)
+		if err != nil {
+			return nil, errors.Wrap(err, "read .gitignore")
+		}
+
+		options.ExcludePaths = append(options.ExcludePaths, gitignorePaths...)
+	}
+
 	// We exclude the sync log to prevent an endless loop in upstream
 	options.ExcludePaths = append(options.ExcludePaths, ".devspace/")
 
@@ -96,9 +167,17 @@ func NewSync(localPath string, options *Options) (*Sync, error) {
 
 		syncLog = log.GetFileLogger("sync")
 		syncLog.SetLevel(logrus.InfoLevel)
+		syncLogToFile = true
 	}
-	if options.Log == nil {
+	if options.Log == nil && syncLogToFile == false {
 		options.Log = syncLog
+	} else if options.Log == nil {
+		fields := map[string]interface{}{"local": absoluteLocalPath}
+		for key, value := range options.LogFields {
+			fields[key] = value
+		}
+
+		options.Log = log.GetFileLoggerWithFields("sync", fields)
 	}
 
 	// Create sync structure
@@ -107,6 +186,7 @@ func NewSync(localPath string, options *Options) (*Sync, error) {
 		Options:   options,
 
 		fileIndex: newFileIndex(),
+		platform:  newPlatform(options),
 		log:       options.Log,
 	}
 
@@ -137,6 +217,28 @@ func (s *Sync) InitUpstream(reader io.ReadCloser, writer io.WriteCloser) error {
 	return nil
 }
 
+// InitUpstreamWithAddress inits the upstream with a tcp connection to the given address (e.g. a forwarded port)
+func (s *Sync) InitUpstreamWithAddress(address string) error {
+	upstream, err := newUpstreamWithAddress(address, s)
+	if err != nil {
+		return errors.Wrap(err, "new upstream")
+	}
+
+	s.upstream = upstream
+	return nil
+}
+
+// InitDownstreamWithAddress inits the downstream with a tcp connection to the given address (e.g. a forwarded port)
+func (s *Sync) InitDownstreamWithAddress(address string) error {
+	downstream, err := newDownstreamWithAddress(address, s)
+	if err != nil {
+		return errors.Wrap(err, "new downstream")
+	}
+
+	s.downstream = downstream
+	return nil
+}
+
 // InitDownstream inits the downstream
 func (s *Sync) InitDownstream(reader io.ReadCloser, writer io.WriteCloser) error {
 	downstream, err := newDownstream(reader, writer, s)
@@ -212,6 +314,10 @@ func (s *Sync) startUpstream() {
 	// Set up a watchpoint listening for events within a directory tree rooted at specified directory
 	err := notify.Watch(s.LocalPath+"/...", s.upstream.events, notify.All)
 	if err != nil {
+		if isWatchLimitError(err) {
+			err = errors.Errorf("the file watcher for %s has run out of inotify watches (%v). Please increase the limit with 'sudo sysctl fs.inotify.max_user_watches=524288' or sync a smaller directory via localSubPath", s.LocalPath, err)
+		}
+
 		s.Stop(err)
 		return
 	}
@@ -261,6 +367,13 @@ func (s *Sync) initialSync() error {
 		return errors.Wrap(err, "diff server client")
 	}
 
+	if s.Options.CompareByHash && len(localChanges) > 0 {
+		localChanges, err = s.downstream.filterUnchangedFiles(localChanges)
+		if err != nil {
+			return errors.Wrap(err, "compare hashes")
+		}
+	}
+
 	// Upstream initial sync
 	go func() {
 		s.sendChangesToUpstream(localChanges)
@@ -428,6 +541,11 @@ func (s *Sync) sendChangesToUpstream(changes []*FileInformation) {
 	}
 }
 
+// UpstreamIdle returns true if all local changes the sync noticed so far are uploaded
+func (s *Sync) UpstreamIdle() bool {
+	return s.upstream == nil || s.upstream.idle()
+}
+
 // Stop stops the sync process
 func (s *Sync) Stop(fatalError error) {
 	s.stopOnce.Do(func() {
@@ -444,6 +562,9 @@ func (s *Sync) Stop(fatalError error) {
 				// Closing the reader is hanging on windows so we skip that
 				// s.upstream.reader.Close()
 			}
+			if s.upstream.conn != nil {
+				s.upstream.conn.Close()
+			}
 		}
 
 		if s.downstream != nil && s.downstream.interrupt != nil {
@@ -455,6 +576,9 @@ func (s *Sync) Stop(fatalError error) {
 				// Closing the reader is hanging on windows so we skip that
 				// s.downstream.reader.Close()
 			}
+			if s.downstream.conn != nil {
+				s.downstream.conn.Close()
+			}
 		}
 
 		s.log.Infof("Sync stopped")
@@ -464,6 +588,10 @@ func (s *Sync) Stop(fatalError error) {
 
 		if fatalError != nil {
 			s.Error(fatalError)
+			if s.Options.ErrorHandler != nil {
+				s.Options.ErrorHandler(fatalError)
+				return
+			}
 
 			// This needs to be rethought because we do not always kill the application here, would be better to have an error channel
 			// or runtime error here
@@ -116,7 +116,7 @@ func TestInitialSync(t *testing.T) {
 	defer upServerWriter.Close()
 
 	go func() {
-		err := server.StartUpstreamServer(remote, upServerReader, upClientWriter, false)
+		err := server.StartUpstreamServer(remote, nil, upServerReader, upClientWriter, false)
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -145,6 +145,102 @@ func TestInitialSync(t *testing.T) {
 	checkFilesAndFolders(t, filesToCheck, foldersToCheck, local, remote, 10*time.Second)
 }
 
+func TestInitialSyncCompareByHash(t *testing.T) {
+	remote, local, outside := initTestDirs(t)
+	defer os.RemoveAll(remote)
+	defer os.RemoveAll(local)
+	defer os.RemoveAll(outside)
+
+	// Remote files are older than the local ones, as it would be after a fresh clone
+	remoteMtime := time.Now().Add(-time.Hour)
+	for name, content := range map[string]string{"same.txt": "same", "changed.txt": "old"} {
+		err := ioutil.WriteFile(filepath.Join(remote, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Chtimes(filepath.Join(remote, name), remoteMtime, remoteMtime)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range map[string]string{"same.txt": "same", "changed.txt": "new"} {
+		err := ioutil.WriteFile(filepath.Join(local, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	syncClient, err := createTestSyncClient(local, testCaseList{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syncClient.Stop(nil)
+
+	syncClient.Options.CompareByHash = true
+
+	// Start the downstream server
+	downClientReader, downClientWriter, _ := os.Pipe()
+	downServerReader, downServerWriter, _ := os.Pipe()
+	defer downClientReader.Close()
+	defer downClientWriter.Close()
+	defer downServerReader.Close()
+	defer downServerWriter.Close()
+
+	go server.StartDownstreamServer(remote, []string{}, downServerReader, downClientWriter, false)
+
+	err = syncClient.InitDownstream(downClientReader, downServerWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Start upstream server
+	upClientReader, upClientWriter, _ := os.Pipe()
+	upServerReader, upServerWriter, _ := os.Pipe()
+	defer upClientReader.Close()
+	defer upClientWriter.Close()
+	defer upServerReader.Close()
+	defer upServerWriter.Close()
+
+	go server.StartUpstreamServer(remote, nil, upServerReader, upClientWriter, false)
+
+	err = syncClient.InitUpstream(upClientReader, upServerWriter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go syncClient.startUpstream()
+
+	err = syncClient.initialSync()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait till the changed file was uploaded
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		data, err := ioutil.ReadFile(filepath.Join(remote, "changed.txt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) == "new" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected changed.txt to be uploaded, remote content is %s", string(data))
+		}
+
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	stat, err := os.Stat(filepath.Join(remote, "same.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stat.ModTime().Unix() != remoteMtime.Unix() {
+		t.Fatal("Expected same.txt not to be uploaded, because the content is equal")
+	}
+}
+
 func TestNormalSync(t *testing.T) {
 	remote, local, outside := initTestDirs(t)
 	defer os.RemoveAll(remote)
@@ -196,7 +292,7 @@ func TestNormalSync(t *testing.T) {
 	defer upServerWriter.Close()
 
 	go func() {
-		err := server.StartUpstreamServer(remote, upServerReader, upClientWriter, false)
+		err := server.StartUpstreamServer(remote, nil, upServerReader, upClientWriter, false)
 		if err != nil {
 			t.Fatal(err)
 		}
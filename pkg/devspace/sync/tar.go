@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"time"
 
 	"github.com/pkg/errors"
@@ -53,13 +54,13 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string, config *Sync) (bo
 	}
 
 	relativePath := getRelativeFromFullPath("/"+header.Name, prefix)
-	outFileName := path.Join(destPath, relativePath)
-	baseName := path.Dir(outFileName)
+	outFileName := config.platform.localPath(destPath, relativePath)
+	baseName := filepath.Dir(outFileName)
 
 	// Check if newer file is there and then don't override?
 	stat, err := os.Stat(outFileName)
 	if err == nil {
-		if stat.ModTime().Unix() > header.FileInfo().ModTime().Unix() {
+		if config.platform.mtimeNewer(stat.ModTime().Unix(), header.FileInfo().ModTime().Unix()) {
 			// Update filemap otherwise we download and download again
 			config.fileIndex.fileMap[relativePath] = &FileInformation{
 				Name:        relativePath,
@@ -105,7 +106,24 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string, config *Sync) (bo
 
 	defer outFile.Close()
 
-	if _, err := io.Copy(outFile, tarReader); err != nil {
+	size := header.FileInfo().Size()
+	remoteSize := int64(0)
+	if config.platform.convertLineEndings {
+		content, err := ioutil.ReadAll(tarReader)
+		if err != nil {
+			return false, errors.Wrap(err, "read file from tar")
+		}
+
+		if converted, changed := config.platform.toLocalContent(content); changed {
+			content = converted
+			size = int64(len(converted))
+			remoteSize = header.FileInfo().Size()
+		}
+
+		if _, err := outFile.Write(content); err != nil {
+			return false, errors.Wrap(err, "write file")
+		}
+	} else if _, err := io.Copy(outFile, tarReader); err != nil {
 		return false, errors.Wrap(err, "copy file to reader")
 	}
 
@@ -129,7 +147,8 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string, config *Sync) (bo
 	config.fileIndex.fileMap[relativePath] = &FileInformation{
 		Name:        relativePath,
 		Mtime:       header.ModTime.Unix(),
-		Size:        header.FileInfo().Size(),
+		Size:        size,
+		RemoteSize:  remoteSize,
 		IsDirectory: false,
 	}
 
@@ -138,11 +157,17 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string, config *Sync) (bo
 
 // RecursiveTar runs recursively over the given path and basepath and tars the found files and folders
 func RecursiveTar(basePath, relativePath string, writtenFiles map[string]*FileInformation, tw *tar.Writer, ignoreMatcher gitignore.IgnoreParser) error {
+	return recursiveTar(basePath, relativePath, writtenFiles, tw, ignoreMatcher, nil)
+}
+
+// recursiveTar tars the files and folders like RecursiveTar and converts their content for the remote side if a
+// platform is given
+func recursiveTar(basePath, relativePath string, writtenFiles map[string]*FileInformation, tw *tar.Writer, ignoreMatcher gitignore.IgnoreParser, p *platform) error {
 	if writtenFiles == nil {
 		writtenFiles = make(map[string]*FileInformation)
 	}
 
-	absFilepath := path.Join(basePath, relativePath)
+	absFilepath := filepath.Join(basePath, filepath.FromSlash(relativePath))
 	if writtenFiles[relativePath] != nil {
 		return nil
 	}
@@ -162,15 +187,15 @@ func RecursiveTar(basePath, relativePath string, writtenFiles map[string]*FileIn
 	fileInformation := createFileInformationFromStat(relativePath, stat)
 	if stat.IsDir() {
 		// Recursively tar folder
-		return tarFolder(basePath, fileInformation, writtenFiles, stat, tw, ignoreMatcher)
+		return tarFolder(basePath, fileInformation, writtenFiles, stat, tw, ignoreMatcher, p)
 	}
 
-	return tarFile(basePath, fileInformation, writtenFiles, stat, tw)
+	return tarFile(basePath, fileInformation, writtenFiles, stat, tw, p)
 }
 
-func tarFolder(basePath string, fileInformation *FileInformation, writtenFiles map[string]*FileInformation, stat os.FileInfo, tw *tar.Writer, ignoreMatcher gitignore.IgnoreParser) error {
-	filepath := path.Join(basePath, fileInformation.Name)
-	files, err := ioutil.ReadDir(filepath)
+func tarFolder(basePath string, fileInformation *FileInformation, writtenFiles map[string]*FileInformation, stat os.FileInfo, tw *tar.Writer, ignoreMatcher gitignore.IgnoreParser, p *platform) error {
+	folderPath := filepath.Join(basePath, filepath.FromSlash(fileInformation.Name))
+	files, err := ioutil.ReadDir(folderPath)
 	if err != nil {
 		// config.Logf("[Upstream] Couldn't read dir %s: %s\n", filepath, err.Error())
 		return nil
@@ -178,7 +203,7 @@ func tarFolder(basePath string, fileInformation *FileInformation, writtenFiles m
 
 	if len(files) == 0 && fileInformation.Name != "" {
 		// Case empty directory
-		hdr, _ := tar.FileInfoHeader(stat, filepath)
+		hdr, _ := tar.FileInfoHeader(stat, folderPath)
 		hdr.Name = fileInformation.Name
 		if err := tw.WriteHeader(hdr); err != nil {
 			return errors.Wrap(err, "tar write header")
@@ -188,7 +213,7 @@ func tarFolder(basePath string, fileInformation *FileInformation, writtenFiles m
 	}
 
 	for _, f := range files {
-		if err := RecursiveTar(basePath, path.Join(fileInformation.Name, f.Name()), writtenFiles, tw, ignoreMatcher); err != nil {
+		if err := recursiveTar(basePath, path.Join(fileInformation.Name, f.Name()), writtenFiles, tw, ignoreMatcher, p); err != nil {
 			return errors.Wrap(err, "recursive tar "+f.Name())
 		}
 	}
@@ -196,11 +221,11 @@ func tarFolder(basePath string, fileInformation *FileInformation, writtenFiles m
 	return nil
 }
 
-func tarFile(basePath string, fileInformation *FileInformation, writtenFiles map[string]*FileInformation, stat os.FileInfo, tw *tar.Writer) error {
-	filepath := path.Join(basePath, fileInformation.Name)
+func tarFile(basePath string, fileInformation *FileInformation, writtenFiles map[string]*FileInformation, stat os.FileInfo, tw *tar.Writer, p *platform) error {
+	filePath := filepath.Join(basePath, filepath.FromSlash(fileInformation.Name))
 
 	// Case regular file
-	f, err := os.Open(filepath)
+	f, err := os.Open(filePath)
 	if err != nil {
 		// We ignore open file and just treat it as okay
 		// return errors.Wrap(err, "open file")
@@ -209,13 +234,39 @@ func tarFile(basePath string, fileInformation *FileInformation, writtenFiles map
 
 	defer f.Close()
 
-	hdr, err := tar.FileInfoHeader(stat, filepath)
+	hdr, err := tar.FileInfoHeader(stat, filePath)
 	if err != nil {
 		return errors.Wrap(err, "create tar file info header")
 	}
 	hdr.Name = fileInformation.Name
 	hdr.ModTime = time.Unix(fileInformation.Mtime, 0)
 
+	if p != nil && p.convertLineEndings {
+		content, err := ioutil.ReadAll(f)
+		if err != nil {
+			return errors.Wrap(err, "read file")
+		}
+
+		// The file could have changed since we called stat
+		fileInformation.Size = int64(len(content))
+		if converted, changed := p.toRemoteContent(content); changed {
+			content = converted
+			fileInformation.RemoteSize = int64(len(converted))
+		}
+
+		hdr.Size = int64(len(content))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return errors.Wrap(err, "tar write header")
+		}
+
+		if _, err := tw.Write(content); err != nil {
+			return errors.Wrap(err, "tar write file")
+		}
+
+		writtenFiles[fileInformation.Name] = fileInformation
+		return nil
+	}
+
 	if err := tw.WriteHeader(hdr); err != nil {
 		return errors.Wrap(err, "tar write header")
 	}
@@ -10,11 +10,13 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/juju/ratelimit"
 	"github.com/pkg/errors"
 	gitignore "github.com/sabhiram/go-gitignore"
+	"google.golang.org/grpc"
 
 	"github.com/devspace-cloud/devspace/sync/remote"
 	"github.com/devspace-cloud/devspace/sync/util"
@@ -22,6 +24,9 @@ import (
 )
 
 type upstream struct {
+	// busy is 1 while local changes are collected and uploaded
+	busy int32
+
 	events    chan notify.EventInfo
 	symlinks  map[string]*Symlink
 	interrupt chan bool
@@ -29,6 +34,7 @@ type upstream struct {
 
 	reader io.ReadCloser
 	writer io.WriteCloser
+	conn   io.Closer
 	client remote.UpstreamClient
 }
 
@@ -55,16 +61,33 @@ func newUpstream(reader io.ReadCloser, writer io.WriteCloser, sync *Sync) (*upst
 		return nil, errors.Wrap(err, "new client connection")
 	}
 
+	u := newUpstreamFromConn(conn, sync)
+	u.reader = reader
+	u.writer = writer
+	return u, nil
+}
+
+// newUpstreamWithAddress creates a new upstream handler that connects to the given tcp address
+func newUpstreamWithAddress(address string, sync *Sync) (*upstream, error) {
+	conn, err := util.NewAddressClientConnection(address, sync.limitConn)
+	if err != nil {
+		return nil, errors.Wrap(err, "new client connection")
+	}
+
+	u := newUpstreamFromConn(conn, sync)
+	u.conn = conn
+	return u, nil
+}
+
+func newUpstreamFromConn(conn *grpc.ClientConn, sync *Sync) *upstream {
 	return &upstream{
 		events:    make(chan notify.EventInfo, 3000), // High buffer size so we don't miss any fsevents if there are a lot of changes
 		symlinks:  make(map[string]*Symlink),
 		interrupt: make(chan bool, 1),
 		sync:      sync,
 
-		reader: reader,
-		writer: writer,
 		client: remote.NewUpstreamClient(conn),
-	}, nil
+	}
 }
 
 func (u *upstream) mainLoop() error {
@@ -83,6 +106,7 @@ func (u *upstream) mainLoop() error {
 					return nil
 				}
 
+				atomic.StoreInt32(&u.busy, 1)
 				events := make([]notify.EventInfo, 0, 10)
 				events = append(events, event)
 
@@ -104,11 +128,14 @@ func (u *upstream) mainLoop() error {
 				}
 
 				changes = append(changes, fileInformations...)
-			case <-time.After(time.Millisecond * 600):
+				if len(changes) == 0 {
+					atomic.StoreInt32(&u.busy, 0)
+				}
+			case <-time.After(u.sync.Options.CoalesceInterval):
 				break
 			}
 
-			// We gather changes till there are no more changes for 1 second
+			// We gather changes till there are no more changes within the coalesce interval
 			if changeAmount == len(changes) && changeAmount > 0 {
 				break
 			}
@@ -120,9 +147,20 @@ func (u *upstream) mainLoop() error {
 		if err != nil {
 			return errors.Wrap(err, "apply changes")
 		}
+
+		if u.sync.Options.OnUpload != nil {
+			u.sync.Options.OnUpload(u.sync.log)
+		}
+
+		atomic.StoreInt32(&u.busy, 0)
 	}
 }
 
+// idle returns true if there are no local changes that wait to be uploaded
+func (u *upstream) idle() bool {
+	return atomic.LoadInt32(&u.busy) == 0 && len(u.events) == 0
+}
+
 func (u *upstream) getfileInformationFromEvent(events []notify.EventInfo) ([]*FileInformation, error) {
 	u.sync.fileIndex.fileMapMutex.Lock()
 	defer u.sync.fileIndex.fileMapMutex.Unlock()
@@ -275,6 +313,9 @@ func (u *upstream) RemoveSymlinks(absPath string) {
 }
 
 func (u *upstream) applyChanges(changes []*FileInformation) error {
+	u.sync.Options.Workers.acquire()
+	defer u.sync.Options.Workers.release()
+
 	var creates []*FileInformation
 	var removes []*FileInformation
 
@@ -377,7 +418,7 @@ func (u *upstream) compress(writer io.WriteCloser, files []*FileInformation, ign
 	writtenFiles := make(map[string]*FileInformation)
 	for _, file := range files {
 		if writtenFiles[file.Name] == nil {
-			err := RecursiveTar(u.sync.LocalPath, file.Name, writtenFiles, tarWriter, ignoreMatcher)
+			err := recursiveTar(u.sync.LocalPath, file.Name, writtenFiles, tarWriter, ignoreMatcher, u.sync.platform)
 			if err != nil {
 				return errors.Wrap(err, "recursive tar")
 			}
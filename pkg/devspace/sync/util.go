@@ -1,13 +1,17 @@
 package sync
 
 import (
+	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"strings"
+	"syscall"
 
 	"github.com/devspace-cloud/devspace/pkg/util/log"
 	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
 	gitignore "github.com/sabhiram/go-gitignore"
 )
 
@@ -29,6 +33,23 @@ func CompilePaths(excludePaths []string) (gitignore.IgnoreParser, error) {
 	return nil, nil
 }
 
+// isWatchLimitError checks if the given error was caused by exceeding the inotify watch limit
+func isWatchLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	cause := errors.Cause(err)
+	if syscallErr, ok := cause.(*os.SyscallError); ok {
+		cause = syscallErr.Err
+	}
+	if cause == syscall.ENOSPC {
+		return true
+	}
+
+	return strings.Contains(err.Error(), syscall.ENOSPC.Error())
+}
+
 func cleanupSyncLogs() error {
 	syncLogName := log.Logdir + "sync.log"
 	_, err := os.Stat(syncLogName)
@@ -56,3 +77,41 @@ func cleanupSyncLogs() error {
 
 	return os.Remove(syncLogName)
 }
+
+// limitConn applies the configured bandwidth limits to a tcp connection
+func (s *Sync) limitConn(conn net.Conn) net.Conn {
+	if s.Options.DownstreamLimit <= 0 && s.Options.UpstreamLimit <= 0 {
+		return conn
+	}
+
+	limited := &limitedConn{
+		Conn:   conn,
+		reader: conn,
+		writer: conn,
+	}
+	if s.Options.DownstreamLimit > 0 {
+		limited.reader = ratelimit.Reader(conn, ratelimit.NewBucketWithRate(float64(s.Options.DownstreamLimit), s.Options.DownstreamLimit))
+	}
+	if s.Options.UpstreamLimit > 0 {
+		limited.writer = ratelimit.Writer(conn, ratelimit.NewBucketWithRate(float64(s.Options.UpstreamLimit), s.Options.UpstreamLimit))
+	}
+
+	return limited
+}
+
+type limitedConn struct {
+	net.Conn
+
+	reader io.Reader
+	writer io.Writer
+}
+
+// Read implements interface
+func (c *limitedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
+
+// Write implements interface
+func (c *limitedConn) Write(b []byte) (int, error) {
+	return c.writer.Write(b)
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"syscall"
 	"testing"
 	"time"
 
@@ -196,3 +197,15 @@ Outer:
 		t.Fatal("unexpected")
 	}
 }
+
+func TestIsWatchLimitError(t *testing.T) {
+	if isWatchLimitError(nil) {
+		t.Fatal("Expected nil not to be a watch limit error")
+	}
+	if isWatchLimitError(errors.New("permission denied")) {
+		t.Fatal("Expected permission denied not to be a watch limit error")
+	}
+	if !isWatchLimitError(os.NewSyscallError("inotify_add_watch", syscall.ENOSPC)) {
+		t.Fatal("Expected ENOSPC to be a watch limit error")
+	}
+}
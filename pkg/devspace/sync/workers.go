@@ -0,0 +1,29 @@
+package sync
+
+// WorkerPool limits the number of change sets that are uploaded or downloaded at the same time by all syncs
+// that share the pool
+type WorkerPool chan bool
+
+// NewWorkerPool creates a new worker pool with the given size. If size is zero or less, nil is returned
+// and the syncs are not limited
+func NewWorkerPool(size int) WorkerPool {
+	if size <= 0 {
+		return nil
+	}
+
+	return make(WorkerPool, size)
+}
+
+// acquire blocks until a worker is free. A nil pool never blocks
+func (w WorkerPool) acquire() {
+	if w != nil {
+		w <- true
+	}
+}
+
+// release frees a worker that was acquired before
+func (w WorkerPool) release() {
+	if w != nil {
+		<-w
+	}
+}
@@ -0,0 +1,186 @@
+package workspace
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/devspace-cloud/devspace/pkg/util/interrupt"
+	"github.com/devspace-cloud/devspace/pkg/util/log"
+	"github.com/mgutz/ansi"
+)
+
+// prefixColors are used to distinguish the output of the projects
+var prefixColors = []string{"cyan+b", "magenta+b", "yellow+b", "green+b", "blue+b", "red+b"}
+
+// Run executes the devspace command with the given args in all projects of the workspace concurrently and prefixes
+// the output of each project with its name. If a project fails or an interrupt is received, all projects are stopped
+func Run(config *Config, args []string, stdout io.Writer, log log.Logger) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	// On termination all projects are stopped and the termination handler waits until they are done
+	interrupted := make(chan bool)
+	finished := make(chan bool)
+	defer close(finished)
+
+	unregister := interrupt.Register(func() {
+		select {
+		case interrupted <- true:
+			<-finished
+		case <-finished:
+		}
+	})
+	defer unregister()
+
+	var outputMutex sync.Mutex
+	processes := []*exec.Cmd{}
+	errChan := make(chan error, len(config.Projects))
+
+	stopping := false
+	stop := func() {
+		if stopping {
+			return
+		}
+
+		stopping = true
+		for _, process := range processes {
+			// Sending an interrupt is not supported on windows
+			if err := process.Process.Signal(os.Interrupt); err != nil {
+				process.Process.Kill()
+			}
+		}
+	}
+
+	maxNameLength := 0
+	for _, project := range config.Projects {
+		if len(project.Name) > maxNameLength {
+			maxNameLength = len(project.Name)
+		}
+	}
+
+	for index, project := range config.Projects {
+		prefix := ansi.Color(fmt.Sprintf("[%s]", project.Name)+strings.Repeat(" ", maxNameLength-len(project.Name)), prefixColors[index%len(prefixColors)]) + " "
+		writer := NewPrefixWriter(stdout, prefix, &outputMutex)
+
+		cmd := exec.Command(executable, append(append([]string{}, args...), project.Args...)...)
+		cmd.Dir = project.Path
+		cmd.Stdout = writer
+		cmd.Stderr = writer
+
+		err = cmd.Start()
+		if err != nil {
+			stop()
+			return fmt.Errorf("Error starting project %s: %v", project.Name, err)
+		}
+
+		log.Infof("Started devspace %s in project %s (%s)", strings.Join(args, " "), project.Name, project.Path)
+		processes = append(processes, cmd)
+
+		go func(name string, cmd *exec.Cmd, writer *PrefixWriter) {
+			err := cmd.Wait()
+			writer.Flush()
+			if err != nil {
+				errChan <- fmt.Errorf("Project %s: %v", name, err)
+				return
+			}
+
+			errChan <- nil
+		}(project.Name, cmd, writer)
+	}
+
+	failed := []string{}
+	for remaining := len(processes); remaining > 0; {
+		select {
+		case <-interrupted:
+			if stopping == false {
+				log.Info("Stopping all projects...")
+			}
+
+			stop()
+		case err := <-errChan:
+			remaining--
+			if err != nil && stopping == false {
+				log.Error(err)
+				failed = append(failed, err.Error())
+
+				if remaining > 0 {
+					log.Info("Stopping all projects...")
+				}
+				stop()
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+
+	return nil
+}
+
+// PrefixWriter writes every line with the given prefix to the underlying writer. Lines of different writers that
+// share the mutex are not interleaved
+type PrefixWriter struct {
+	writer io.Writer
+	prefix string
+	mutex  *sync.Mutex
+
+	buffer bytes.Buffer
+}
+
+// NewPrefixWriter creates a new prefix writer
+func NewPrefixWriter(writer io.Writer, prefix string, mutex *sync.Mutex) *PrefixWriter {
+	return &PrefixWriter{
+		writer: writer,
+		prefix: prefix,
+		mutex:  mutex,
+	}
+}
+
+// Write implements io.Writer and writes all complete lines
+func (p *PrefixWriter) Write(data []byte) (int, error) {
+	p.buffer.Write(data)
+
+	output := []byte{}
+	for {
+		index := bytes.IndexByte(p.buffer.Bytes(), '\n')
+		if index == -1 {
+			break
+		}
+
+		output = append(output, p.prefix...)
+		output = append(output, p.buffer.Next(index+1)...)
+	}
+
+	if len(output) > 0 {
+		p.mutex.Lock()
+		defer p.mutex.Unlock()
+
+		_, err := p.writer.Write(output)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush writes the remaining incomplete line
+func (p *PrefixWriter) Flush() {
+	if p.buffer.Len() == 0 {
+		return
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.writer.Write([]byte(p.prefix + p.buffer.String() + "\n"))
+	p.buffer.Reset()
+}
@@ -0,0 +1,89 @@
+package workspace
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+	"github.com/pkg/errors"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// ConfigPath is the path of the workspace config
+const ConfigPath = "devspace-workspace.yaml"
+
+// Config defines the projects of a workspace
+type Config struct {
+	Projects []*Project `yaml:"projects"`
+}
+
+// Project is a DevSpace project within the workspace
+type Project struct {
+	Name string   `yaml:"name,omitempty"`
+	Path string   `yaml:"path"`
+	Args []string `yaml:"args,omitempty"`
+}
+
+// LoadConfig loads and validates the workspace config from the given path. Relative project paths are
+// resolved against the directory of the workspace config
+func LoadConfig(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{}
+	err = yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parse %s", path)
+	}
+
+	baseDir, err := filepath.Abs(filepath.Dir(path))
+	if err != nil {
+		return nil, err
+	}
+
+	err = config.prepare(baseDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "validate %s", path)
+	}
+
+	return config, nil
+}
+
+// prepare validates the config, resolves the project paths and sets the default project names
+func (c *Config) prepare(baseDir string) error {
+	if len(c.Projects) == 0 {
+		return fmt.Errorf("projects: at least one project is required")
+	}
+
+	names := map[string]bool{}
+	for index, project := range c.Projects {
+		if project.Path == "" {
+			return fmt.Errorf("projects[%d].path is required", index)
+		}
+
+		if filepath.IsAbs(project.Path) == false {
+			project.Path = filepath.Join(baseDir, filepath.FromSlash(project.Path))
+		}
+		if project.Name == "" {
+			project.Name = filepath.Base(project.Path)
+		}
+		if names[project.Name] {
+			return fmt.Errorf("projects[%d]: duplicate project name %s", index, project.Name)
+		}
+		names[project.Name] = true
+
+		_, err := os.Stat(filepath.Join(project.Path, constants.DefaultConfigPath))
+		if err != nil {
+			_, configsErr := os.Stat(filepath.Join(project.Path, constants.DefaultConfigsPath))
+			if configsErr != nil {
+				return fmt.Errorf("projects[%d]: no %s found in %s", index, constants.DefaultConfigPath, project.Path)
+			}
+		}
+	}
+
+	return nil
+}
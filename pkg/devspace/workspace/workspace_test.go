@@ -0,0 +1,89 @@
+package workspace
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/devspace-cloud/devspace/pkg/devspace/config/constants"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testWorkspace")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	for _, project := range []string{"backend", "frontend"} {
+		err = os.MkdirAll(filepath.Join(dir, project), 0755)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = ioutil.WriteFile(filepath.Join(dir, project, constants.DefaultConfigPath), []byte("version: v1beta2\n"), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	configPath := filepath.Join(dir, ConfigPath)
+	err = ioutil.WriteFile(configPath, []byte(`projects:
+- path: backend
+- name: web
+  path: ./frontend
+  args: ["--portforwarding=false"]
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("Error loading workspace config: %v", err)
+	}
+	if len(config.Projects) != 2 || config.Projects[0].Name != "backend" || config.Projects[1].Name != "web" {
+		t.Fatalf("Unexpected projects: %#v", config.Projects)
+	}
+	if config.Projects[1].Path != filepath.Join(dir, "frontend") || len(config.Projects[1].Args) != 1 {
+		t.Fatalf("Unexpected project %#v", config.Projects[1])
+	}
+
+	invalidConfigs := []string{
+		"projects: []",
+		"projects:\n- name: missing-path",
+		"projects:\n- path: not-existing",
+		"projects:\n- path: backend\n- path: ./backend",
+		"projects:\n- path: backend\n  unknown: true",
+	}
+	for _, invalidConfig := range invalidConfigs {
+		err = ioutil.WriteFile(configPath, []byte(invalidConfig), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = LoadConfig(configPath)
+		if err == nil {
+			t.Fatalf("Expected error for workspace config %s", invalidConfig)
+		}
+	}
+}
+
+func TestPrefixWriter(t *testing.T) {
+	output := &bytes.Buffer{}
+	writer := NewPrefixWriter(output, "[app] ", &sync.Mutex{})
+
+	writer.Write([]byte("first line\nsecond "))
+	writer.Write([]byte("line\nincomplete"))
+	if output.String() != "[app] first line\n[app] second line\n" {
+		t.Fatalf("Unexpected output %q", output.String())
+	}
+
+	writer.Flush()
+	if output.String() != "[app] first line\n[app] second line\n[app] incomplete\n" {
+		t.Fatalf("Unexpected output after flush %q", output.String())
+	}
+}
@@ -2,8 +2,11 @@ package command
 
 import (
 	"io"
+	"os"
 	"os/exec"
+	"strings"
 
+	"github.com/devspace-cloud/devspace/pkg/util/shell"
 	goansi "github.com/k0kubun/go-ansi"
 )
 
@@ -37,6 +40,18 @@ func NewStreamCommand(command string, args []string) *StreamCommand {
 	}
 }
 
+// NewStreamCommandWithEnv creates a new stream command that has the given environment variables set additionally
+func NewStreamCommandWithEnv(command string, args []string, env []string) *StreamCommand {
+	cmd := exec.Command(command, args...)
+	if len(env) > 0 {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	return &StreamCommand{
+		cmd: cmd,
+	}
+}
+
 // Run runs a stream command
 func (s *StreamCommand) Run(stdout io.Writer, stderr io.Writer, stdin io.Reader) error {
 	if stdout == nil {
@@ -57,3 +72,57 @@ func (s *StreamCommand) Run(stdout io.Writer, stderr io.Writer, stdin io.Reader)
 
 	return s.cmd.Run()
 }
+
+// ShellCommand is a command that is executed by the built-in shell interpreter
+type ShellCommand struct {
+	script string
+	args   []string
+	env    []string
+}
+
+// NewShellCommand creates a new shell command. The args are appended to the script and the env is set additionally
+func NewShellCommand(script string, args []string, env []string) *ShellCommand {
+	return &ShellCommand{
+		script: script,
+		args:   args,
+		env:    env,
+	}
+}
+
+// IsShellScript returns true if the command is not an existing file or executable but a script that
+// has to be interpreted (e.g. echo $TAG && cp a b)
+func IsShellScript(command string) bool {
+	if strings.ContainsAny(command, " \t\n'\"$;&|<>") == false {
+		return false
+	}
+
+	_, err := os.Stat(command)
+	if err == nil {
+		return false
+	}
+
+	_, err = exec.LookPath(command)
+	return err != nil
+}
+
+// Run runs the shell command
+func (s *ShellCommand) Run(stdout io.Writer, stderr io.Writer, stdin io.Reader) error {
+	script := s.script
+	for _, arg := range s.args {
+		script += " " + shell.Quote(arg)
+	}
+
+	if stdout == nil {
+		stdout = defaultStdout
+	}
+	if stderr == nil {
+		stderr = defaultStderr
+	}
+
+	return shell.Run(script, &shell.Options{
+		Env:    s.env,
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
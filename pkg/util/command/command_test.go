@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bytes"
 	"testing"
 )
 
@@ -17,3 +18,21 @@ func TestCommand(t *testing.T) {
 		t.Fatalf("StreamCommand unexpectedly returned error: %v", err)
 	}
 }
+
+func TestShellCommand(t *testing.T) {
+	if IsShellScript("echo") || IsShellScript("./scripts/build.sh") {
+		t.Fatalf("Expected plain commands not to be shell scripts")
+	}
+	if IsShellScript("echo $TAG && echo done") == false {
+		t.Fatalf("Expected script to be a shell script")
+	}
+
+	stdout := &bytes.Buffer{}
+	err := NewShellCommand("echo $TAG &&", []string{"echo", "it's done"}, []string{"TAG=v1"}).Run(stdout, stdout, nil)
+	if err != nil {
+		t.Fatalf("ShellCommand unexpectedly returned error: %v", err)
+	}
+	if stdout.String() != "v1\nit's done\n" {
+		t.Fatalf("Unexpected output of ShellCommand: %q", stdout.String())
+	}
+}
@@ -1,6 +1,23 @@
 package envutil
 
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
 //SetEnvVar sets an environment variable
 func SetEnvVar(name string, value string) error {
 	return setEnv(name, value)
 }
+
+// GetTimeout returns the timeout in seconds of the environment variable or the default if the variable is not set or
+// not a positive number
+func GetTimeout(name string, defaultTimeout time.Duration) time.Duration {
+	seconds, err := strconv.Atoi(os.Getenv(name))
+	if err != nil || seconds <= 0 {
+		return defaultTimeout
+	}
+
+	return time.Duration(seconds) * time.Second
+}
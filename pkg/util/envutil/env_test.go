@@ -5,6 +5,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/devspace-cloud/devspace/pkg/util/randutil"
 	
@@ -59,3 +60,19 @@ func includes(arr []string, needle string) bool{
 	}
 	return false
 }
+
+func TestGetTimeout(t *testing.T) {
+	const envName = "DEVSPACE_TEST_TIMEOUT"
+	defer os.Unsetenv(envName)
+
+	assert.Equal(t, time.Minute, GetTimeout(envName, time.Minute), "Default not returned for unset variable")
+
+	os.Setenv(envName, "300")
+	assert.Equal(t, 5*time.Minute, GetTimeout(envName, time.Minute), "Timeout of variable not returned")
+
+	os.Setenv(envName, "-1")
+	assert.Equal(t, time.Minute, GetTimeout(envName, time.Minute), "Default not returned for negative timeout")
+
+	os.Setenv(envName, "abc")
+	assert.Equal(t, time.Minute, GetTimeout(envName, time.Minute), "Default not returned for invalid timeout")
+}
@@ -38,6 +38,24 @@ func (gr *Repository) GetHash() (string, error) {
 	return head.Hash().String(), nil
 }
 
+// GetBranch retrieves the name of the currently checked out branch
+func (gr *Repository) GetBranch() (string, error) {
+	repo, err := git.PlainOpen(gr.LocalPath)
+	if err != nil {
+		return "", errors.Wrap(err, "git open")
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", errors.Wrap(err, "get head")
+	}
+	if head.Name().IsBranch() == false {
+		return "", errors.New("HEAD is not pointing to a branch")
+	}
+
+	return head.Name().Short(), nil
+}
+
 // GetRemote retrieves the remote origin
 func (gr *Repository) GetRemote() (string, error) {
 	_, err := os.Stat(gr.LocalPath + "/.git")
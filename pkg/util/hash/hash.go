@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -189,6 +190,96 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
+// File calculates the sha256 hash of the content of a file
+func File(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// DirectoryTree calculates a merkle hash of a directory. The hash of a directory is built from the names, permissions
+// and hashes of its entries, so it only changes if a file that is not excluded by the patterns changes, no matter
+// where the directory is located
+func DirectoryTree(srcPath string, excludePatterns []string) (string, error) {
+	pm, err := fileutils.NewPatternMatcher(excludePatterns)
+	if err != nil {
+		return "", err
+	}
+
+	stat, err := os.Stat(srcPath)
+	if err != nil {
+		return "", err
+	}
+	if !stat.IsDir() {
+		return "", fmt.Errorf("Path %s is not a directory", srcPath)
+	}
+
+	return hashTree(srcPath, "", pm)
+}
+
+func hashTree(srcPath, relPath string, pm *fileutils.PatternMatcher) (string, error) {
+	files, err := ioutil.ReadDir(filepath.Join(srcPath, relPath))
+	if err != nil {
+		return "", fmt.Errorf("Error reading directory %s: %v", filepath.Join(srcPath, relPath), err)
+	}
+
+	hash := sha256.New()
+	for _, f := range files {
+		relFilePath := filepath.Join(relPath, f.Name())
+		skip, err := pm.Matches(relFilePath)
+		if err != nil {
+			return "", fmt.Errorf("Error matching %s: %v", relFilePath, err)
+		}
+
+		// Excluded directories are only hashed if an exclusion pattern (e.g. !dir/file) could match a file within
+		if skip && (!f.IsDir() || !hasExclusionWithin(pm, relFilePath)) {
+			continue
+		}
+
+		entryHash := ""
+		if f.IsDir() {
+			entryHash, err = hashTree(srcPath, relFilePath, pm)
+		} else if f.Mode()&os.ModeSymlink != 0 {
+			var target string
+			target, err = os.Readlink(filepath.Join(srcPath, relFilePath))
+			entryHash = String(target)
+		} else {
+			entryHash, err = File(filepath.Join(srcPath, relFilePath))
+		}
+		if err != nil {
+			return "", err
+		}
+
+		io.WriteString(hash, f.Name()+";"+strconv.FormatUint(uint64(f.Mode()&(os.ModePerm|os.ModeDir|os.ModeSymlink)), 8)+";"+entryHash+"\n")
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+func hasExclusionWithin(pm *fileutils.PatternMatcher, dir string) bool {
+	if !pm.Exclusions() {
+		return false
+	}
+
+	dirSlash := dir + string(filepath.Separator)
+	for _, pattern := range pm.Patterns() {
+		if pattern.Exclusion() && strings.HasPrefix(pattern.String()+string(filepath.Separator), dirSlash) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // String hashes a given string
 func String(s string) string {
 	hash := sha256.New()
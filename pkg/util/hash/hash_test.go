@@ -3,6 +3,7 @@ package hash
 import(
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 	
 	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
@@ -101,3 +102,44 @@ func TestHashDirectoryExcludes(t *testing.T) {
 	}
 	
 }
+
+func TestHashDirectoryTree(t *testing.T) {
+	dirA, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dirA)
+
+	dirB, err := ioutil.TempDir("", "test")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dirB)
+
+	for _, dir := range []string{dirA, dirB} {
+		fsutil.WriteToFile([]byte("included"), filepath.Join(dir, "includedFile"))
+		fsutil.WriteToFile([]byte("excluded"), filepath.Join(dir, "excludedFile"))
+		fsutil.WriteToFile([]byte("excluded"), filepath.Join(dir, "excludedDir", "someFile"))
+		fsutil.WriteToFile([]byte("included"), filepath.Join(dir, "excludedDir", "includedFile"))
+	}
+
+	excludes := []string{"excludedFile", "excludedDir", "!excludedDir/includedFile"}
+	hashA, err := DirectoryTree(dirA, excludes)
+	assert.NilError(t, err, "Error creating hash of directory")
+	hashB, err := DirectoryTree(dirB, excludes)
+	assert.NilError(t, err, "Error creating hash of directory")
+	assert.Equal(t, hashA, hashB, "Hash depends on the location of the directory")
+
+	// Changes of excluded files don't change the hash
+	fsutil.WriteToFile([]byte("changed"), filepath.Join(dirB, "excludedFile"))
+	fsutil.WriteToFile([]byte("changed"), filepath.Join(dirB, "excludedDir", "someFile"))
+	hashB, err = DirectoryTree(dirB, excludes)
+	assert.NilError(t, err, "Error creating hash of directory")
+	assert.Equal(t, hashA, hashB, "Hash changed after changing excluded files")
+
+	// Changes of included files change the hash
+	fsutil.WriteToFile([]byte("changed"), filepath.Join(dirB, "excludedDir", "includedFile"))
+	hashB, err = DirectoryTree(dirB, excludes)
+	assert.NilError(t, err, "Error creating hash of directory")
+	assert.Assert(t, hashA != hashB, "Hash didn't change after changing an included file")
+}
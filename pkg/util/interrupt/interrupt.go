@@ -0,0 +1,124 @@
+package interrupt
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// GracePeriod is the maximum time the registered cleanup functions may take after a termination signal
+var GracePeriod = 10 * time.Second
+
+// exit is called after the cleanup functions are done and can be replaced in tests
+var exit = os.Exit
+
+var (
+	rootContext, cancel = context.WithCancel(context.Background())
+
+	cleanups      = map[int]func(){}
+	nextCleanupID = 0
+	terminating   = false
+	mutex         sync.Mutex
+
+	startOnce sync.Once
+)
+
+// Context returns the root context of the process that is canceled as soon as a termination signal is received.
+// Long running operations should stop waiting when the context is done
+func Context() context.Context {
+	return rootContext
+}
+
+// Start installs the handler for SIGINT and SIGTERM. When a signal is received, the root context is canceled
+// and all registered cleanup functions are executed concurrently. The process exits after all cleanup
+// functions are done or the grace period is over. Further signals during the cleanup are ignored
+func Start() {
+	startOnce.Do(func() {
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+		go func() {
+			<-signals
+			Terminate()
+		}()
+	})
+}
+
+// Register registers a cleanup function, e.g. to delete remote resources, that is executed on termination.
+// The returned function unregisters the cleanup function again. If the process is already terminating, the
+// cleanup function is not registered
+func Register(cleanup func()) func() {
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	if terminating {
+		return func() {}
+	}
+
+	id := nextCleanupID
+	nextCleanupID++
+	cleanups[id] = cleanup
+
+	return func() {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		delete(cleanups, id)
+	}
+}
+
+// Terminate cancels the root context, executes all registered cleanup functions within the grace period
+// and exits the process
+func Terminate() {
+	mutex.Lock()
+	if terminating {
+		mutex.Unlock()
+		return
+	}
+
+	terminating = true
+	pending := make([]func(), 0, len(cleanups))
+	for _, cleanup := range cleanups {
+		pending = append(pending, cleanup)
+	}
+	cleanups = map[int]func(){}
+	mutex.Unlock()
+
+	cancel()
+
+	done := make(chan bool)
+	go func() {
+		waitGroup := sync.WaitGroup{}
+		for _, cleanup := range pending {
+			waitGroup.Add(1)
+			go func(cleanup func()) {
+				defer waitGroup.Done()
+				cleanup()
+			}(cleanup)
+		}
+
+		waitGroup.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(GracePeriod):
+	}
+
+	exit(1)
+}
+
+// Sleep waits for the given duration and returns the error of the root context if the process is terminated
+// in the meantime. It is used in polling loops to stop waiting on termination
+func Sleep(duration time.Duration) error {
+	select {
+	case <-rootContext.Done():
+		return rootContext.Err()
+	case <-time.After(duration):
+		return nil
+	}
+}
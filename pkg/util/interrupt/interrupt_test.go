@@ -0,0 +1,65 @@
+package interrupt
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTerminate(t *testing.T) {
+	exitCode := -1
+	exit = func(code int) { exitCode = code }
+	GracePeriod = 100 * time.Millisecond
+
+	if err := Sleep(time.Millisecond); err != nil {
+		t.Fatalf("Unexpected error before termination: %v", err)
+	}
+
+	called := map[string]bool{}
+	calledMutex := sync.Mutex{}
+	register := func(name string, duration time.Duration) func() {
+		return Register(func() {
+			time.Sleep(duration)
+
+			calledMutex.Lock()
+			defer calledMutex.Unlock()
+			called[name] = true
+		})
+	}
+
+	register("first", 0)
+	unregister := register("unregistered", 0)
+	register("slow", time.Second)
+	unregister()
+
+	start := time.Now()
+	Terminate()
+	if time.Since(start) >= time.Second {
+		t.Fatalf("Expected termination to stop waiting after the grace period")
+	}
+	if exitCode != 1 {
+		t.Fatalf("Expected exit code 1, got %d", exitCode)
+	}
+
+	calledMutex.Lock()
+	if called["first"] == false || called["unregistered"] {
+		t.Fatalf("Unexpected cleanup functions called: %v", called)
+	}
+	calledMutex.Unlock()
+
+	select {
+	case <-Context().Done():
+	default:
+		t.Fatalf("Expected root context to be canceled")
+	}
+	if err := Sleep(time.Hour); err == nil {
+		t.Fatalf("Expected sleep to return immediately after termination")
+	}
+
+	// Terminating again does nothing
+	exitCode = -1
+	Terminate()
+	if exitCode != -1 {
+		t.Fatalf("Expected second termination to be ignored")
+	}
+}
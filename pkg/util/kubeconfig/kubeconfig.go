@@ -1,6 +1,9 @@
 package kubeconfig
 
 import (
+	"fmt"
+	"sort"
+
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
@@ -39,3 +42,38 @@ func LoadRawConfig() (*api.Config, error) {
 func SaveConfig(config *api.Config) error {
 	return clientcmd.ModifyConfig(clientcmd.NewDefaultClientConfigLoadingRules(), *config, false)
 }
+
+// GetContextNames returns the sorted names of all contexts within the kube config
+func GetContextNames(config *api.Config) []string {
+	contextNames := make([]string, 0, len(config.Contexts))
+	for contextName := range config.Contexts {
+		contextNames = append(contextNames, contextName)
+	}
+
+	sort.Strings(contextNames)
+	return contextNames
+}
+
+// MergeConfig adds the contexts of the imported kube config together with the clusters and users they reference to
+// the given kube config. Existing entries with the same name are replaced. It returns the names of the added contexts
+func MergeConfig(config *api.Config, imported *api.Config) ([]string, error) {
+	contextNames := GetContextNames(imported)
+	for _, contextName := range contextNames {
+		context := imported.Contexts[contextName]
+
+		cluster, ok := imported.Clusters[context.Cluster]
+		if !ok {
+			return nil, fmt.Errorf("Context %s references cluster %s, which does not exist", contextName, context.Cluster)
+		}
+		authInfo, ok := imported.AuthInfos[context.AuthInfo]
+		if !ok {
+			return nil, fmt.Errorf("Context %s references user %s, which does not exist", contextName, context.AuthInfo)
+		}
+
+		config.Clusters[context.Cluster] = cluster
+		config.AuthInfos[context.AuthInfo] = authInfo
+		config.Contexts[contextName] = context
+	}
+
+	return contextNames, nil
+}
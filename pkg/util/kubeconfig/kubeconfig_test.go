@@ -182,3 +182,41 @@ func TestSaveLoadKubeConfig(t *testing.T) {
 	assert.Equal(t, string(testConfigAsJSON), string(kubeConfigAsJSON), "Readed Config doesn't match written config")
 
 }
+
+func TestGetContextNames(t *testing.T) {
+	config := &api.Config{
+		Contexts: map[string]*api.Context{
+			"minikube":       &api.Context{},
+			"docker-desktop": &api.Context{},
+			"gke_prod":       &api.Context{},
+		},
+	}
+
+	assert.Equal(t, strings.Join(GetContextNames(config), ","), "docker-desktop,gke_prod,minikube")
+}
+
+func TestMergeConfig(t *testing.T) {
+	config := api.NewConfig()
+	config.Contexts["minikube"] = &api.Context{Cluster: "minikube", AuthInfo: "minikube"}
+
+	imported := api.NewConfig()
+	imported.Clusters["devspace-space"] = &api.Cluster{Server: "https://space.example.com"}
+	imported.AuthInfos["devspace-space"] = &api.AuthInfo{Token: "token"}
+	imported.Contexts["devspace-space"] = &api.Context{Cluster: "devspace-space", AuthInfo: "devspace-space", Namespace: "space"}
+
+	contextNames, err := MergeConfig(config, imported)
+	if err != nil {
+		t.Fatalf("Error merging config: %v", err)
+	}
+
+	assert.Equal(t, strings.Join(contextNames, ","), "devspace-space")
+	assert.Equal(t, strings.Join(GetContextNames(config), ","), "devspace-space,minikube")
+	assert.Equal(t, config.Clusters["devspace-space"].Server, "https://space.example.com")
+	assert.Equal(t, config.AuthInfos["devspace-space"].Token, "token")
+
+	imported.Contexts["broken"] = &api.Context{Cluster: "missing", AuthInfo: "devspace-space"}
+	_, err = MergeConfig(config, imported)
+	if err == nil {
+		t.Fatalf("No error merging config with missing cluster")
+	}
+}
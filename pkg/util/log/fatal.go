@@ -0,0 +1,28 @@
+package log
+
+import (
+	"strings"
+	"sync"
+)
+
+var fatalHandlers = []func(message string){}
+var fatalHandlersMutex sync.Mutex
+
+// OnFatal registers a handler that is called with the error message right before the process
+// exits because of a fatal error. Handlers must not use the default logger
+func OnFatal(handler func(message string)) {
+	fatalHandlersMutex.Lock()
+	defer fatalHandlersMutex.Unlock()
+
+	fatalHandlers = append(fatalHandlers, handler)
+}
+
+func runFatalHandlers(message string) {
+	fatalHandlersMutex.Lock()
+	handlers := fatalHandlers
+	fatalHandlersMutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(strings.TrimSpace(message))
+	}
+}
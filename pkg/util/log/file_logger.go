@@ -15,6 +15,7 @@ var runtimeErrorHandlersOverriden bool
 
 type fileLogger struct {
 	logger *logrus.Logger
+	fields logrus.Fields
 }
 
 // GetFileLogger returns a logger instance for the specified filename
@@ -29,7 +30,7 @@ func GetFileLogger(filename string) Logger {
 
 		os.MkdirAll(Logdir, os.ModePerm)
 
-		logFile, err := os.OpenFile(Logdir+filename+".log", os.O_APPEND|os.O_CREATE|os.O_RDWR, os.ModePerm)
+		logFile, err := openRotatingFile(GetLogFilePath(filename))
 
 		if err != nil {
 			newLogger.Warnf("Unable to open " + filename + " log file. Will log to stdout.")
@@ -43,6 +44,14 @@ func GetFileLogger(filename string) Logger {
 	return logs[filename]
 }
 
+// GetFileLoggerWithFields returns a logger for the specified filename that adds the fields to every log entry
+func GetFileLoggerWithFields(filename string, fields map[string]interface{}) Logger {
+	return &fileLogger{
+		logger: GetFileLogger(filename).(*fileLogger).logger,
+		fields: fields,
+	}
+}
+
 // OverrideRuntimeErrorHandler overrides the standard runtime error handler that logs to stdout
 // with a file logger that logs all runtime.HandleErrors to errors.log
 func OverrideRuntimeErrorHandler() {
@@ -59,68 +68,72 @@ func OverrideRuntimeErrorHandler() {
 	}
 }
 
+func (f *fileLogger) entry() *logrus.Entry {
+	return f.logger.WithFields(f.fields)
+}
+
 func (f *fileLogger) Debug(args ...interface{}) {
-	f.logger.Debug(args...)
+	f.entry().Debug(args...)
 }
 
 func (f *fileLogger) Debugf(format string, args ...interface{}) {
-	f.logger.Debugf(format, args...)
+	f.entry().Debugf(format, args...)
 }
 
 func (f *fileLogger) Info(args ...interface{}) {
-	f.logger.Info(args...)
+	f.entry().Info(args...)
 }
 
 func (f *fileLogger) Infof(format string, args ...interface{}) {
-	f.logger.Infof(format, args...)
+	f.entry().Infof(format, args...)
 }
 
 func (f *fileLogger) Warn(args ...interface{}) {
-	f.logger.Warn(args...)
+	f.entry().Warn(args...)
 }
 
 func (f *fileLogger) Warnf(format string, args ...interface{}) {
-	f.logger.Warnf(format, args...)
+	f.entry().Warnf(format, args...)
 }
 
 func (f *fileLogger) Error(args ...interface{}) {
-	f.logger.Error(args...)
+	f.entry().Error(args...)
 }
 
 func (f *fileLogger) Errorf(format string, args ...interface{}) {
-	f.logger.Errorf(format, args...)
+	f.entry().Errorf(format, args...)
 }
 
 func (f *fileLogger) Fatal(args ...interface{}) {
-	f.logger.Fatal(args...)
+	f.entry().Fatal(args...)
 }
 
 func (f *fileLogger) Fatalf(format string, args ...interface{}) {
-	f.logger.Fatalf(format, args...)
+	f.entry().Fatalf(format, args...)
 }
 
 func (f *fileLogger) Panic(args ...interface{}) {
-	f.logger.Panic(args...)
+	f.entry().Panic(args...)
 }
 
 func (f *fileLogger) Panicf(format string, args ...interface{}) {
-	f.logger.Panicf(format, args...)
+	f.entry().Panicf(format, args...)
 }
 
 func (f *fileLogger) Done(args ...interface{}) {
-	f.logger.Info(args...)
+	f.entry().Info(args...)
 }
 
 func (f *fileLogger) Donef(format string, args ...interface{}) {
-	f.logger.Infof(format, args...)
+	f.entry().Infof(format, args...)
 }
 
 func (f *fileLogger) Fail(args ...interface{}) {
-	f.logger.Error(args...)
+	f.entry().Error(args...)
 }
 
 func (f *fileLogger) Failf(format string, args ...interface{}) {
-	f.logger.Errorf(format, args...)
+	f.entry().Errorf(format, args...)
 }
 
 func (f *fileLogger) Print(level logrus.Level, args ...interface{}) {
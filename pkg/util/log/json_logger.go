@@ -0,0 +1,378 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devspace-cloud/devspace/pkg/util/analytics"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// LogFormatText prints log messages as colored text
+	LogFormatText = "text"
+	// LogFormatJSON prints every log message as a json object on a single line
+	LogFormatJSON = "json"
+)
+
+var ansiEscapeRegex = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+
+// logFormat and logModule are the log format and module set with SetLogFormat
+var logFormat, logModule string
+
+// SetLogFormat sets the format of the default logger (text or json). Every json entry contains the module, e.g.
+// the command that is executed
+func SetLogFormat(format, module string) error {
+	switch format {
+	case "", LogFormatText:
+		logFormat, logModule = LogFormatText, module
+		return nil
+	case LogFormatJSON:
+		logFormat, logModule = LogFormatJSON, module
+
+		// Keep stdout free for structured tables
+		stream := io.Writer(os.Stdout)
+		if IsStructuredOutput() {
+			stream = os.Stderr
+		}
+
+		SetInstance(NewJSONLogger(stream, logrus.DebugLevel, module))
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported log format %s, supported formats are %s and %s", format, LogFormatText, LogFormatJSON)
+}
+
+// GetStderrInstance returns a logger that writes to stderr in the log format, for messages that must not be mixed
+// into the output of a command, e.g. a kube config that is printed to stdout
+func GetStderrInstance() Logger {
+	if logFormat == LogFormatJSON {
+		return NewJSONLogger(os.Stderr, logrus.InfoLevel, logModule)
+	}
+
+	return NewStreamLogger(os.Stderr, logrus.InfoLevel)
+}
+
+// JSONLogger logs every message as a json object with level, timestamp, module and message to a stream
+type JSONLogger struct {
+	logMutex sync.Mutex
+	level    logrus.Level
+	module   string
+
+	stream io.Writer
+	buffer []byte
+
+	fileLogger Logger
+}
+
+type jsonEntry struct {
+	Level     string `json:"level"`
+	Timestamp string `json:"timestamp"`
+	Module    string `json:"module,omitempty"`
+	Message   string `json:"message"`
+}
+
+// NewJSONLogger creates a new json logger
+func NewJSONLogger(stream io.Writer, level logrus.Level, module string) *JSONLogger {
+	return &JSONLogger{
+		level:  level,
+		module: module,
+
+		stream: stream,
+	}
+}
+
+func (s *JSONLogger) writeMessage(fnType logFunctionType, message string) {
+	fnInformation := fnStringTypeInformationMap[fnType]
+
+	if s.level >= fnInformation.logLevel {
+		s.writeEntry(fnInformation.logLevel, message)
+		s.writeMessageToFileLogger(fnType, message)
+	}
+}
+
+// writeMessageToFileLogger writes the message to the log file of the command. Fatal and panic messages are written
+// by fatal and Panic, because the file logger exits or panics after writing them
+func (s *JSONLogger) writeMessageToFileLogger(fnType logFunctionType, message string) {
+	if s.fileLogger == nil {
+		return
+	}
+
+	message = strings.TrimSpace(message)
+	switch fnType {
+	case doneFn:
+		s.fileLogger.Done(message)
+	case infoFn:
+		s.fileLogger.Info(message)
+	case debugFn:
+		s.fileLogger.Debug(message)
+	case warnFn:
+		s.fileLogger.Warn(message)
+	case failFn:
+		s.fileLogger.Fail(message)
+	case errorFn:
+		s.fileLogger.Error(message)
+	}
+}
+
+func (s *JSONLogger) writeEntry(level logrus.Level, message string) {
+	out, err := json.Marshal(&jsonEntry{
+		Level:     level.String(),
+		Timestamp: time.Now().Format(time.RFC3339),
+		Module:    s.module,
+		Message:   strings.TrimSpace(ansiEscapeRegex.ReplaceAllString(message, "")),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	_, err = s.stream.Write(append(out, '\n'))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// StartWait prints the wait message as info entry
+func (s *JSONLogger) StartWait(message string) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(infoFn, message)
+}
+
+// StopWait implements interface
+func (s *JSONLogger) StopWait() {
+
+}
+
+// Debug implements interface
+func (s *JSONLogger) Debug(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(debugFn, fmt.Sprintln(args...))
+}
+
+// Debugf implements interface
+func (s *JSONLogger) Debugf(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(debugFn, fmt.Sprintf(format, args...))
+}
+
+// Info implements interface
+func (s *JSONLogger) Info(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(infoFn, fmt.Sprintln(args...))
+}
+
+// Infof implements interface
+func (s *JSONLogger) Infof(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(infoFn, fmt.Sprintf(format, args...))
+}
+
+// Warn implements interface
+func (s *JSONLogger) Warn(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(warnFn, fmt.Sprintln(args...))
+}
+
+// Warnf implements interface
+func (s *JSONLogger) Warnf(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(warnFn, fmt.Sprintf(format, args...))
+}
+
+// Error implements interface
+func (s *JSONLogger) Error(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(errorFn, fmt.Sprintln(args...))
+}
+
+// Errorf implements interface
+func (s *JSONLogger) Errorf(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(errorFn, fmt.Sprintf(format, args...))
+}
+
+// Fatal implements interface
+func (s *JSONLogger) Fatal(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.fatal(fmt.Sprintln(args...))
+}
+
+// Fatalf implements interface
+func (s *JSONLogger) Fatalf(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.fatal(fmt.Sprintf(format, args...))
+}
+
+func (s *JSONLogger) fatal(msg string) {
+	s.writeMessage(fatalFn, msg)
+	runFatalHandlers(msg)
+
+	analytics, err := analytics.GetAnalytics()
+	if err == nil {
+		analytics.SendCommandEvent(errors.New(msg))
+	}
+
+	if s.fileLogger != nil {
+		s.fileLogger.Fatal(strings.TrimSpace(msg))
+	}
+
+	os.Exit(1)
+}
+
+// Panic implements interface
+func (s *JSONLogger) Panic(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.panic(fmt.Sprintln(args...))
+}
+
+// Panicf implements interface
+func (s *JSONLogger) Panicf(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.panic(fmt.Sprintf(format, args...))
+}
+
+func (s *JSONLogger) panic(msg string) {
+	s.writeMessage(panicFn, msg)
+	if s.fileLogger != nil {
+		s.fileLogger.Panic(strings.TrimSpace(msg))
+	}
+
+	panic(msg)
+}
+
+// Done implements interface
+func (s *JSONLogger) Done(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(doneFn, fmt.Sprintln(args...))
+}
+
+// Donef implements interface
+func (s *JSONLogger) Donef(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(doneFn, fmt.Sprintf(format, args...))
+}
+
+// Fail implements interface
+func (s *JSONLogger) Fail(args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(failFn, fmt.Sprintln(args...))
+}
+
+// Failf implements interface
+func (s *JSONLogger) Failf(format string, args ...interface{}) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.writeMessage(failFn, fmt.Sprintf(format, args...))
+}
+
+// Print implements interface
+func (s *JSONLogger) Print(level logrus.Level, args ...interface{}) {
+	switch level {
+	case logrus.InfoLevel:
+		s.Info(args...)
+	case logrus.DebugLevel:
+		s.Debug(args...)
+	case logrus.WarnLevel:
+		s.Warn(args...)
+	case logrus.ErrorLevel:
+		s.Error(args...)
+	case logrus.PanicLevel:
+		s.Panic(args...)
+	case logrus.FatalLevel:
+		s.Fatal(args...)
+	}
+}
+
+// Printf implements interface
+func (s *JSONLogger) Printf(level logrus.Level, format string, args ...interface{}) {
+	switch level {
+	case logrus.InfoLevel:
+		s.Infof(format, args...)
+	case logrus.DebugLevel:
+		s.Debugf(format, args...)
+	case logrus.WarnLevel:
+		s.Warnf(format, args...)
+	case logrus.ErrorLevel:
+		s.Errorf(format, args...)
+	case logrus.PanicLevel:
+		s.Panicf(format, args...)
+	case logrus.FatalLevel:
+		s.Fatalf(format, args...)
+	}
+}
+
+// SetLevel implements interface
+func (s *JSONLogger) SetLevel(level logrus.Level) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.level = level
+}
+
+// Write splits the message into lines and logs every complete line as info entry, e.g. the output of an image build
+func (s *JSONLogger) Write(message []byte) (int, error) {
+	s.logMutex.Lock()
+	defer s.logMutex.Unlock()
+
+	s.buffer = append(s.buffer, message...)
+	for {
+		index := bytes.IndexByte(s.buffer, '\n')
+		if index == -1 {
+			break
+		}
+
+		line := strings.TrimRight(string(s.buffer[:index]), "\r")
+		s.buffer = s.buffer[index+1:]
+		if strings.TrimSpace(line) != "" && s.level >= logrus.InfoLevel {
+			s.writeEntry(logrus.InfoLevel, line)
+		}
+	}
+
+	return len(message), nil
+}
+
+// WriteString implements interface
+func (s *JSONLogger) WriteString(message string) {
+	s.Write([]byte(message))
+}
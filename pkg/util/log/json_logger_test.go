@@ -0,0 +1,114 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mgutz/ansi"
+	"github.com/sirupsen/logrus"
+)
+
+func TestJSONLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger := NewJSONLogger(out, logrus.InfoLevel, "deploy")
+
+	logger.Debugf("Not printed")
+	logger.Infof("Deploying %s", "backend")
+	logger.Warn("Run", ansi.Color("devspace cleanup images", "white+b"))
+	logger.Donef("Deployed backend")
+	logger.Write([]byte("[backend] Step 1/2\n[backend] Step"))
+	logger.Write([]byte(" 2/2\r\n\n"))
+
+	expected := []jsonEntry{
+		{Level: "info", Module: "deploy", Message: "Deploying backend"},
+		{Level: "warning", Module: "deploy", Message: "Run devspace cleanup images"},
+		{Level: "info", Module: "deploy", Message: "Deployed backend"},
+		{Level: "info", Module: "deploy", Message: "[backend] Step 1/2"},
+		{Level: "info", Module: "deploy", Message: "[backend] Step 2/2"},
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(expected) {
+		t.Fatalf("Unexpected number of entries: expected %d, got %d:\n%s", len(expected), len(lines), out.String())
+	}
+
+	for i, line := range lines {
+		entry := jsonEntry{}
+		err := json.Unmarshal([]byte(line), &entry)
+		if err != nil {
+			t.Fatalf("Entry %s is not valid json: %v", line, err)
+		}
+		if entry.Timestamp == "" {
+			t.Fatalf("Entry %s has no timestamp", line)
+		}
+
+		entry.Timestamp = ""
+		if entry != expected[i] {
+			t.Fatalf("Unexpected entry %d: expected %#v, got %#v", i, expected[i], entry)
+		}
+	}
+}
+
+func TestSetLogFormat(t *testing.T) {
+	defer SetInstance(defaultLog)
+
+	err := SetLogFormat("xml", "deploy")
+	if err == nil {
+		t.Fatal("Expected error for unsupported log format")
+	}
+
+	err = SetLogFormat(LogFormatJSON, "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := GetInstance().(*JSONLogger); ok == false {
+		t.Fatalf("Expected json logger, got %T", GetInstance())
+	}
+	if _, ok := GetStderrInstance().(*JSONLogger); ok == false {
+		t.Fatalf("Expected json logger for stderr, got %T", GetStderrInstance())
+	}
+
+	err = SetLogFormat(LogFormatText, "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := GetStderrInstance().(*JSONLogger); ok {
+		t.Fatal("Expected text logger for stderr")
+	}
+}
+
+func TestJSONLoggerFileLogging(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldLogdir := Logdir
+	Logdir = dir
+	defer func() { Logdir = oldLogdir }()
+	defer SetInstance(defaultLog)
+
+	stream := &bytes.Buffer{}
+	SetInstance(NewJSONLogger(stream, logrus.InfoLevel, "deploy"))
+	StartFileLogging("json-deploy")
+
+	Infof("Deployed %s", "test")
+	Debug("Not logged")
+
+	if strings.Contains(stream.String(), "Deployed test") == false {
+		t.Fatalf("Expected message on stream, got %s", stream.String())
+	}
+
+	out, err := ioutil.ReadFile(GetLogFilePath("json-deploy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "Deployed test") == false || strings.Contains(string(out), "Not logged") {
+		t.Fatalf("Unexpected log file content %s", string(out))
+	}
+}
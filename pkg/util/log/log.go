@@ -26,6 +26,10 @@ func StopWait() {
 
 // PrintLogo prints the devspace logo
 func PrintLogo() {
+	if _, ok := defaultLog.(*JSONLogger); ok {
+		return
+	}
+
 	logo := `
      ____              ____                       
     |  _ \  _____   __/ ___| _ __   __ _  ___ ___ 
@@ -132,11 +136,13 @@ func SetLevel(level logrus.Level) {
 	defaultLog.SetLevel(level)
 }
 
-// StartFileLogging logs the output of the global logger to the file default.log
-func StartFileLogging() {
-	defaultLogStdout, ok := defaultLog.(*stdoutLogger)
-	if ok {
-		defaultLogStdout.fileLogger = GetFileLogger("default")
+// StartFileLogging logs the output of the global logger to the log file of the command, e.g. dev.log
+func StartFileLogging(command string) {
+	switch logger := defaultLog.(type) {
+	case *stdoutLogger:
+		logger.fileLogger = GetFileLogger(command)
+	case *JSONLogger:
+		logger.fileLogger = GetFileLogger(command)
 	}
 
 	OverrideRuntimeErrorHandler()
@@ -167,8 +173,14 @@ func WriteString(message string) {
 	defaultLog.WriteString(message)
 }
 
-// PrintTable prints a table with header columns and string values
+// PrintTable prints a table with header columns and string values. If an output format is set, the rows are printed
+// as json or yaml instead
 func PrintTable(s Logger, header []string, values [][]string) {
+	if IsStructuredOutput() {
+		printStructuredTable(structuredOutput, header, values)
+		return
+	}
+
 	columnLengths := make([]int, len(header))
 
 	for k, v := range header {
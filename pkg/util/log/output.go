@@ -0,0 +1,111 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// OutputJSON prints tables as json
+	OutputJSON = "json"
+	// OutputYAML prints tables as yaml
+	OutputYAML = "yaml"
+)
+
+// outputFormat is the format tables are printed in. If empty, tables are printed for humans
+var outputFormat string
+
+// structuredOutput is the stream structured tables are printed to
+var structuredOutput io.Writer = os.Stdout
+
+// SetOutputFormat sets the format tables are printed in (json or yaml). All other log messages are written to
+// stderr in these formats, so stdout only contains the structured data
+func SetOutputFormat(format string) error {
+	switch format {
+	case "":
+		outputFormat = ""
+		return nil
+	case OutputJSON, OutputYAML:
+		outputFormat = format
+		SetInstance(NewStreamLogger(os.Stderr, logrus.InfoLevel))
+		return nil
+	}
+
+	return fmt.Errorf("Unsupported output format %s, supported formats are %s and %s", format, OutputJSON, OutputYAML)
+}
+
+// IsStructuredOutput returns true if tables are printed as json or yaml
+func IsStructuredOutput() bool {
+	return outputFormat != ""
+}
+
+// PrintNoEntries prints an empty list if tables are printed as json or yaml. Commands call this instead of
+// PrintTable if they have nothing to list
+func PrintNoEntries() {
+	if IsStructuredOutput() {
+		printStructuredTable(structuredOutput, []string{}, [][]string{})
+	}
+}
+
+// printStructuredTable prints the rows of a table as a list of objects whose keys are the header columns
+func printStructuredTable(writer io.Writer, header []string, values [][]string) {
+	keys := make([]string, len(header))
+	for i, column := range header {
+		keys[i] = columnKey(column)
+	}
+
+	rows := make([]map[string]string, 0, len(values))
+	for _, value := range values {
+		row := map[string]string{}
+		for i, key := range keys {
+			if i < len(value) {
+				row[key] = value[i]
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	var out []byte
+	var err error
+	if outputFormat == OutputYAML {
+		out, err = yaml.Marshal(rows)
+	} else {
+		out, err = json.MarshalIndent(rows, "", "  ")
+		out = append(out, '\n')
+	}
+	if err != nil {
+		Fatalf("Error printing %s: %v", outputFormat, err)
+	}
+
+	writer.Write(out)
+}
+
+// columnKey converts a table column name into a camel case key, e.g. "Label Selector" into "labelSelector"
+func columnKey(column string) string {
+	words := strings.FieldsFunc(column, func(r rune) bool {
+		return unicode.IsLetter(r) == false && unicode.IsDigit(r) == false
+	})
+
+	key := ""
+	for i, word := range words {
+		if i == 0 {
+			if strings.ToUpper(word) == word {
+				key += strings.ToLower(word)
+			} else {
+				key += strings.ToLower(word[:1]) + word[1:]
+			}
+		} else {
+			key += strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+
+	return key
+}
@@ -0,0 +1,48 @@
+package log
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColumnKey(t *testing.T) {
+	keys := map[string]string{
+		"Name":                 "name",
+		"ID":                   "id",
+		"SpaceID":              "spaceID",
+		"Label Selector":       "labelSelector",
+		"Is logged in":         "isLoggedIn",
+		"Ports (Local:Remote)": "portsLocalRemote",
+	}
+
+	for column, expected := range keys {
+		if key := columnKey(column); key != expected {
+			t.Fatalf("Unexpected key for %s: expected %s, got %s", column, expected, key)
+		}
+	}
+}
+
+func TestPrintStructuredTable(t *testing.T) {
+	defer func() { outputFormat = "" }()
+
+	out := &bytes.Buffer{}
+	outputFormat = OutputJSON
+	printStructuredTable(out, []string{"Name", "Local Path"}, [][]string{{"default", "./"}})
+	if out.String() != "[\n  {\n    \"localPath\": \"./\",\n    \"name\": \"default\"\n  }\n]\n" {
+		t.Fatalf("Unexpected json output %q", out.String())
+	}
+
+	out.Reset()
+	outputFormat = OutputYAML
+	printStructuredTable(out, []string{"Name", "Local Path"}, [][]string{{"default", "./"}})
+	if out.String() != "- localPath: ./\n  name: default\n" {
+		t.Fatalf("Unexpected yaml output %q", out.String())
+	}
+
+	out.Reset()
+	outputFormat = OutputJSON
+	printStructuredTable(out, []string{"Name"}, [][]string{})
+	if out.String() != "[]\n" {
+		t.Fatalf("Unexpected empty output %q", out.String())
+	}
+}
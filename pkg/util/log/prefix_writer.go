@@ -0,0 +1,68 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// PrefixWriter writes complete lines prefixed with the given prefix to the underlying writer. This keeps the output
+// of parallel builds or commands readable, because lines of different writers are never mixed
+type PrefixWriter struct {
+	prefix string
+	out    io.Writer
+
+	buffer     bytes.Buffer
+	bufferLock sync.Mutex
+}
+
+// NewPrefixWriter creates a new prefix writer that writes to out
+func NewPrefixWriter(prefix string, out io.Writer) *PrefixWriter {
+	return &PrefixWriter{
+		prefix: prefix,
+		out:    out,
+	}
+}
+
+// Write implements the io.Writer interface
+func (p *PrefixWriter) Write(message []byte) (int, error) {
+	p.bufferLock.Lock()
+	defer p.bufferLock.Unlock()
+
+	p.buffer.Write(message)
+
+	for {
+		index := bytes.IndexAny(p.buffer.Bytes(), "\r\n")
+		if index == -1 {
+			break
+		}
+
+		line := p.buffer.Next(index + 1)
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		_, err := p.out.Write(append([]byte(p.prefix), append(bytes.TrimRight(line, "\r\n"), '\n')...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return len(message), nil
+}
+
+// Flush writes the remaining incomplete line
+func (p *PrefixWriter) Flush() error {
+	p.bufferLock.Lock()
+	defer p.bufferLock.Unlock()
+
+	if len(bytes.TrimSpace(p.buffer.Bytes())) > 0 {
+		_, err := p.out.Write(append([]byte(p.prefix), append(p.buffer.Bytes(), '\n')...))
+		if err != nil {
+			return err
+		}
+	}
+
+	p.buffer.Reset()
+	return nil
+}
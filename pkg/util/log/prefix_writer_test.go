@@ -0,0 +1,23 @@
+package log
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	out := &bytes.Buffer{}
+	writer := NewPrefixWriter("[api] ", out)
+
+	writer.Write([]byte("Step 1/2 : FROM alpine\nStep 2/2"))
+	writer.Write([]byte(" : RUN echo\r\n\nSuccessfully built"))
+	if out.String() != "[api] Step 1/2 : FROM alpine\n[api] Step 2/2 : RUN echo\n" {
+		t.Fatalf("Unexpected output %q", out.String())
+	}
+
+	writer.Flush()
+	if !strings.HasSuffix(out.String(), "[api] Successfully built\n") {
+		t.Fatalf("Unexpected output after flush %q", out.String())
+	}
+}
@@ -0,0 +1,161 @@
+package log
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxLogFileSize is the size in bytes after which a log file is rotated
+	maxLogFileSize = 10 * 1024 * 1024
+	// maxLogFileAge is the age after which a log file is rotated when it is opened
+	maxLogFileAge = 7 * 24 * time.Hour
+	// maxLogFileBackups is the number of rotated files that are kept for every log file, e.g. dev.log.1 to dev.log.3
+	maxLogFileBackups = 3
+)
+
+// rotatingFile is a log file that is rotated when it grows larger than maxLogFileSize. When it is opened, files
+// that were started more than maxLogFileAge ago are rotated as well, so every log starts fresh after a week. The start
+// of a log file is stored next to it in path.start, because the modification time changes with every write
+type rotatingFile struct {
+	mutex sync.Mutex
+	path  string
+
+	file *os.File
+	size int64
+}
+
+// openRotatingFile opens the log file and rotates it first if it is too large or too old
+func openRotatingFile(path string) (*rotatingFile, error) {
+	r := &rotatingFile{
+		path: path,
+	}
+
+	stat, err := os.Stat(path)
+	if err == nil && (stat.Size() >= maxLogFileSize || time.Since(logFileStart(path, stat)) > maxLogFileAge) {
+		err = rotateLogFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = r.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	_, err := os.Stat(r.path)
+	if os.IsNotExist(err) {
+		err = ioutil.WriteFile(startLogFile(r.path), []byte(time.Now().Format(time.RFC3339)), 0666)
+		if err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_CREATE|os.O_RDWR, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = stat.Size()
+	return nil
+}
+
+// Write writes to the log file and rotates it if it grew too large
+func (r *rotatingFile) Write(message []byte) (int, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.size+int64(len(message)) > maxLogFileSize && r.size > 0 {
+		r.file.Close()
+
+		err := rotateLogFile(r.path)
+		if err != nil {
+			return 0, err
+		}
+
+		err = r.open()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(message)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotateLogFile renames path to path.1, path.1 to path.2 and so on. The oldest backup is deleted
+func rotateLogFile(path string) error {
+	os.Remove(backupLogFile(path, maxLogFileBackups))
+	for i := maxLogFileBackups - 1; i > 0; i-- {
+		err := os.Rename(backupLogFile(path, i), backupLogFile(path, i+1))
+		if err != nil && os.IsNotExist(err) == false {
+			return err
+		}
+	}
+
+	return os.Rename(path, backupLogFile(path, 1))
+}
+
+func backupLogFile(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
+
+func startLogFile(path string) string {
+	return path + ".start"
+}
+
+// logFileStart returns the time the log file was started. Log files written before the start was stored fall back
+// to the modification time
+func logFileStart(path string, stat os.FileInfo) time.Time {
+	out, err := ioutil.ReadFile(startLogFile(path))
+	if err != nil {
+		return stat.ModTime()
+	}
+
+	start, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return stat.ModTime()
+	}
+
+	return start
+}
+
+// GetLogFilePath returns the path of the log file with the given name, e.g. dev for .devspace/logs/dev.log
+func GetLogFilePath(name string) string {
+	return filepath.Join(Logdir, name+".log")
+}
+
+// GetLogFiles returns the existing files of the log with the given name, starting with the oldest rotated file and
+// ending with the current log file
+func GetLogFiles(name string) []string {
+	path := GetLogFilePath(name)
+	files := []string{}
+	for i := maxLogFileBackups; i > 0; i-- {
+		if _, err := os.Stat(backupLogFile(path, i)); err == nil {
+			files = append(files, backupLogFile(path, i))
+		}
+	}
+	if _, err := os.Stat(path); err == nil {
+		files = append(files, path)
+	}
+
+	return files
+}
@@ -0,0 +1,108 @@
+package log
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldLogdir := Logdir
+	Logdir = dir
+	defer func() { Logdir = oldLogdir }()
+
+	path := GetLogFilePath("dev")
+	if path != filepath.Join(dir, "dev.log") {
+		t.Fatalf("Unexpected log file path %s", path)
+	}
+
+	// Old log files are rotated when they are opened, even if they were written recently
+	err = ioutil.WriteFile(path, []byte("old\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldTime := time.Now().Add(-maxLogFileAge - time.Hour)
+	err = ioutil.WriteFile(startLogFile(path), []byte(oldTime.Format(time.RFC3339)), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := openRotatingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write([]byte("new\n"))
+
+	// The start of the new log file is stored
+	stat, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(logFileStart(path, stat)) > time.Minute {
+		t.Fatalf("Unexpected start of the new log file %v", logFileStart(path, stat))
+	}
+
+	// Large log files are rotated while writing
+	file.Write([]byte(strings.Repeat("x", maxLogFileSize) + "\n"))
+	file.Write([]byte("newest\n"))
+	file.file.Close()
+
+	files := GetLogFiles("dev")
+	expected := []string{path + ".3", path + ".2", path + ".1", path}
+	if strings.Join(files, ",") != strings.Join(expected, ",") {
+		t.Fatalf("Unexpected log files: expected %v, got %v", expected, files)
+	}
+
+	for file, content := range map[string]string{path + ".3": "old\n", path + ".2": "new\n", path: "newest\n"} {
+		out, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(out) != content {
+			t.Fatalf("Unexpected content of %s: %s", file, string(out))
+		}
+	}
+}
+
+func TestRotatingFileWithoutStart(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// Log files without a stored start are aged by their modification time
+	path := filepath.Join(dir, "dev.log")
+	err = ioutil.WriteFile(path, []byte("old\n"), 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldTime := time.Now().Add(-maxLogFileAge - time.Hour)
+	err = os.Chtimes(path, oldTime, oldTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := openRotatingFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.file.Close()
+
+	out, err := ioutil.ReadFile(backupLogFile(path, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(out) != "old\n" {
+		t.Fatalf("Unexpected content of %s: %s", backupLogFile(path, 1), string(out))
+	}
+}
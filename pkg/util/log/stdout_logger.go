@@ -258,6 +258,7 @@ func (s *stdoutLogger) Fatal(args ...interface{}) {
 	msg := fmt.Sprintln(args...)
 
 	s.writeMessage(fatalFn, msg)
+	runFatalHandlers(msg)
 	s.writeMessageToFileLogger(fatalFn, args...)
 
 	if s.fileLogger == nil {
@@ -277,6 +278,7 @@ func (s *stdoutLogger) Fatalf(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
 
 	s.writeMessage(fatalFn, msg+"\n")
+	runFatalHandlers(msg)
 	s.writeMessageToFileLoggerf(fatalFn, format, args...)
 
 	if s.fileLogger == nil {
@@ -0,0 +1,256 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devspace-cloud/devspace/pkg/util/fsutil"
+	homedir "github.com/mitchellh/go-homedir"
+)
+
+// builtin is a command that is executed by the interpreter itself and returns the exit status
+type builtin func(r *runner, args []string, stdout, stderr io.Writer) int
+
+// builtins are available on every platform and take precedence over executables in the PATH
+var builtins map[string]builtin
+
+func init() {
+	builtins = map[string]builtin{
+		"true":   func(r *runner, args []string, stdout, stderr io.Writer) int { return 0 },
+		"false":  func(r *runner, args []string, stdout, stderr io.Writer) int { return 1 },
+		"echo":   builtinEcho,
+		"exit":   builtinExit,
+		"cd":     builtinCd,
+		"export": builtinExport,
+		"cp":     builtinCp,
+		"mv":     builtinMv,
+		"rm":     builtinRm,
+		"mkdir":  builtinMkdir,
+	}
+}
+
+// parseFlags splits the single character flags (e.g. -rf) from the arguments
+func parseFlags(args []string, allowed string) (map[rune]bool, []string, error) {
+	flags := map[rune]bool{}
+	for index, arg := range args {
+		if arg == "--" {
+			return flags, args[index+1:], nil
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			return flags, args[index:], nil
+		}
+
+		for _, flag := range arg[1:] {
+			if strings.ContainsRune(allowed, flag) == false {
+				return nil, nil, fmt.Errorf("unsupported flag -%c", flag)
+			}
+
+			flags[flag] = true
+		}
+	}
+
+	return flags, []string{}, nil
+}
+
+func builtinEcho(r *runner, args []string, stdout, stderr io.Writer) int {
+	newline := "\n"
+	if len(args) > 0 && args[0] == "-n" {
+		newline = ""
+		args = args[1:]
+	}
+
+	fmt.Fprint(stdout, strings.Join(args, " ")+newline)
+	return 0
+}
+
+func builtinExit(r *runner, args []string, stdout, stderr io.Writer) int {
+	status := r.lastStatus
+	if len(args) > 0 {
+		var err error
+		status, err = strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintf(stderr, "exit: invalid exit code %s\n", args[0])
+			status = 2
+		}
+	}
+
+	r.exited = true
+	return status
+}
+
+func builtinCd(r *runner, args []string, stdout, stderr io.Writer) int {
+	dir := ""
+	if len(args) > 0 {
+		dir = r.absPath(args[0])
+	} else {
+		home, err := homedir.Dir()
+		if err != nil {
+			fmt.Fprintf(stderr, "cd: %v\n", err)
+			return 1
+		}
+
+		dir = home
+	}
+
+	stat, err := os.Stat(dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "cd: %v\n", err)
+		return 1
+	} else if stat.IsDir() == false {
+		fmt.Fprintf(stderr, "cd: %s is not a directory\n", args[0])
+		return 1
+	}
+
+	r.dir = dir
+	return 0
+}
+
+func builtinExport(r *runner, args []string, stdout, stderr io.Writer) int {
+	for _, arg := range args {
+		splitted := strings.SplitN(arg, "=", 2)
+		if isName(splitted[0]) == false {
+			fmt.Fprintf(stderr, "export: invalid name %s\n", splitted[0])
+			return 1
+		}
+
+		if len(splitted) == 2 {
+			r.setVar(splitted[0], splitted[1])
+		}
+	}
+
+	return 0
+}
+
+func builtinCp(r *runner, args []string, stdout, stderr io.Writer) int {
+	flags, args, err := parseFlags(args, "rRf")
+	if err != nil || len(args) < 2 {
+		fmt.Fprintln(stderr, "usage: cp [-r] source... target")
+		return 2
+	}
+
+	target := r.absPath(args[len(args)-1])
+	targetStat, err := os.Stat(target)
+	targetIsDir := err == nil && targetStat.IsDir()
+	if len(args) > 2 && targetIsDir == false {
+		fmt.Fprintf(stderr, "cp: %s is not a directory\n", args[len(args)-1])
+		return 1
+	}
+
+	for _, source := range args[:len(args)-1] {
+		sourcePath := r.absPath(source)
+		stat, err := os.Stat(sourcePath)
+		if err != nil {
+			fmt.Fprintf(stderr, "cp: %v\n", err)
+			return 1
+		}
+		if stat.IsDir() && flags['r'] == false && flags['R'] == false {
+			fmt.Fprintf(stderr, "cp: %s is a directory (use -r)\n", source)
+			return 1
+		}
+
+		targetPath := target
+		if targetIsDir {
+			targetPath = filepath.Join(target, filepath.Base(sourcePath))
+		}
+
+		err = fsutil.Copy(sourcePath, targetPath, true)
+		if err != nil {
+			fmt.Fprintf(stderr, "cp: %v\n", err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func builtinMv(r *runner, args []string, stdout, stderr io.Writer) int {
+	_, args, err := parseFlags(args, "f")
+	if err != nil || len(args) < 2 {
+		fmt.Fprintln(stderr, "usage: mv source... target")
+		return 2
+	}
+
+	target := r.absPath(args[len(args)-1])
+	targetStat, err := os.Stat(target)
+	targetIsDir := err == nil && targetStat.IsDir()
+
+	for _, source := range args[:len(args)-1] {
+		sourcePath := r.absPath(source)
+		targetPath := target
+		if targetIsDir {
+			targetPath = filepath.Join(target, filepath.Base(sourcePath))
+		}
+
+		err = os.Rename(sourcePath, targetPath)
+		if err != nil {
+			fmt.Fprintf(stderr, "mv: %v\n", err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func builtinRm(r *runner, args []string, stdout, stderr io.Writer) int {
+	flags, args, err := parseFlags(args, "rRf")
+	if err != nil {
+		fmt.Fprintln(stderr, "usage: rm [-rf] path...")
+		return 2
+	}
+
+	for _, path := range args {
+		absPath := r.absPath(path)
+		stat, err := os.Lstat(absPath)
+		if err != nil {
+			if flags['f'] && os.IsNotExist(err) {
+				continue
+			}
+
+			fmt.Fprintf(stderr, "rm: %v\n", err)
+			return 1
+		}
+
+		if stat.IsDir() {
+			if flags['r'] == false && flags['R'] == false {
+				fmt.Fprintf(stderr, "rm: %s is a directory (use -r)\n", path)
+				return 1
+			}
+
+			err = os.RemoveAll(absPath)
+		} else {
+			err = os.Remove(absPath)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "rm: %v\n", err)
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func builtinMkdir(r *runner, args []string, stdout, stderr io.Writer) int {
+	flags, args, err := parseFlags(args, "p")
+	if err != nil || len(args) == 0 {
+		fmt.Fprintln(stderr, "usage: mkdir [-p] path...")
+		return 2
+	}
+
+	for _, path := range args {
+		if flags['p'] {
+			err = os.MkdirAll(r.absPath(path), 0755)
+		} else {
+			err = os.Mkdir(r.absPath(path), 0755)
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "mkdir: %v\n", err)
+			return 1
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,557 @@
+package shell
+
+import (
+	"fmt"
+	"strings"
+)
+
+// wordPart is a literal string or a parameter expansion within a word
+type wordPart struct {
+	Literal string
+	Quoted  bool
+
+	// Param is the name of the expanded parameter, empty for literals
+	Param      string
+	Default    string
+	HasDefault bool
+
+	// DefaultIfEmpty is true for ${NAME:-default}, which uses the default for empty values as well. ${NAME-default}
+	// only uses it if the parameter is unset
+	DefaultIfEmpty bool
+}
+
+type word []wordPart
+
+type assignment struct {
+	Name  string
+	Value word
+}
+
+type redirect struct {
+	FD     int
+	Op     string
+	Target word
+}
+
+type simpleCommand struct {
+	Assignments []*assignment
+	Args        []word
+	Redirects   []*redirect
+}
+
+type pipeline []*simpleCommand
+
+type andOr struct {
+	Pipelines []pipeline
+	Ops       []string
+}
+
+type script []*andOr
+
+// token types of the lexer
+const (
+	tokenWord = iota
+	tokenOperator
+	tokenRedirect
+
+	// tokenUnsupported is syntax the interpreter doesn't support, the error is returned when the parser reaches it
+	tokenUnsupported
+)
+
+// reservedWords start compound commands and function definitions, which the interpreter doesn't support
+var reservedWords = map[string]bool{
+	"if": true, "then": true, "else": true, "elif": true, "fi": true,
+	"for": true, "while": true, "until": true, "do": true, "done": true,
+	"case": true, "esac": true, "in": true, "select": true, "function": true,
+	"{": true, "}": true, "!": true, "[[": true, "]]": true,
+}
+
+type token struct {
+	Type int
+	Word word
+
+	// Op is the operator (&&, ||, |, ;, newline), the redirect operator (>, >>, <, >&) or the error of unsupported
+	// syntax
+	Op string
+	FD int
+}
+
+// Parse parses the script into an executable representation
+func parse(src string) (script, error) {
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	return p.parseScript()
+}
+
+type parser struct {
+	tokens []*token
+	pos    int
+}
+
+func (p *parser) peek() *token {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+
+	return nil
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	next := p.peek()
+	if next == nil || next.Type != tokenOperator {
+		return false
+	}
+
+	for _, op := range ops {
+		if next.Op == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) parseScript() (script, error) {
+	s := script{}
+	for {
+		for p.isOperator(";", "\n") {
+			p.pos++
+		}
+		if p.peek() == nil {
+			return s, nil
+		}
+
+		list, err := p.parseAndOr()
+		if err != nil {
+			return nil, err
+		}
+
+		s = append(s, list)
+		if p.peek() != nil && p.isOperator(";", "\n") == false {
+			return nil, fmt.Errorf("unexpected %s", p.peek().Op)
+		}
+	}
+}
+
+func (p *parser) parseAndOr() (*andOr, error) {
+	first, err := p.parsePipeline()
+	if err != nil {
+		return nil, err
+	}
+
+	list := &andOr{Pipelines: []pipeline{first}}
+	for p.isOperator("&&", "||") {
+		op := p.peek().Op
+		p.pos++
+
+		// A newline is allowed after && and ||
+		for p.isOperator("\n") {
+			p.pos++
+		}
+
+		next, err := p.parsePipeline()
+		if err != nil {
+			return nil, err
+		}
+
+		list.Ops = append(list.Ops, op)
+		list.Pipelines = append(list.Pipelines, next)
+	}
+
+	return list, nil
+}
+
+func (p *parser) parsePipeline() (pipeline, error) {
+	first, err := p.parseSimpleCommand()
+	if err != nil {
+		return nil, err
+	}
+
+	pipe := pipeline{first}
+	for p.isOperator("|") {
+		p.pos++
+		for p.isOperator("\n") {
+			p.pos++
+		}
+
+		next, err := p.parseSimpleCommand()
+		if err != nil {
+			return nil, err
+		}
+
+		pipe = append(pipe, next)
+	}
+
+	return pipe, nil
+}
+
+func (p *parser) parseSimpleCommand() (*simpleCommand, error) {
+	cmd := &simpleCommand{}
+	for {
+		next := p.peek()
+		if next == nil || next.Type == tokenOperator {
+			break
+		} else if next.Type == tokenUnsupported {
+			return nil, fmt.Errorf("%s", next.Op)
+		}
+
+		p.pos++
+		if next.Type == tokenRedirect {
+			target := p.peek()
+			if target == nil || target.Type != tokenWord {
+				return nil, fmt.Errorf("missing target of redirect %s", next.Op)
+			}
+
+			p.pos++
+			cmd.Redirects = append(cmd.Redirects, &redirect{FD: next.FD, Op: next.Op, Target: target.Word})
+			continue
+		}
+
+		if len(cmd.Args) == 0 {
+			if assign := parseAssignment(next.Word); assign != nil {
+				cmd.Assignments = append(cmd.Assignments, assign)
+				continue
+			}
+			if isReservedWord(next.Word) {
+				return nil, fmt.Errorf("%s is not supported, compound commands (if, for, while, case, { ... }) and functions need a script file", next.Word[0].Literal)
+			}
+		}
+
+		cmd.Args = append(cmd.Args, next.Word)
+	}
+
+	if len(cmd.Args) == 0 && len(cmd.Assignments) == 0 && len(cmd.Redirects) == 0 {
+		next := p.peek()
+		if next == nil {
+			return nil, fmt.Errorf("unexpected end of script")
+		}
+
+		return nil, fmt.Errorf("unexpected %s", next.Op)
+	}
+
+	return cmd, nil
+}
+
+// isReservedWord checks whether the unquoted word is a reserved word of the shell grammar
+func isReservedWord(w word) bool {
+	return len(w) == 1 && w[0].Quoted == false && w[0].Param == "" && reservedWords[w[0].Literal]
+}
+
+// parseAssignment returns the assignment if the word has the form NAME=value
+func parseAssignment(w word) *assignment {
+	if len(w) == 0 || w[0].Quoted || w[0].Param != "" {
+		return nil
+	}
+
+	index := strings.Index(w[0].Literal, "=")
+	if index <= 0 || isName(w[0].Literal[:index]) == false {
+		return nil
+	}
+
+	value := word{}
+	if index+1 < len(w[0].Literal) {
+		value = append(value, wordPart{Literal: w[0].Literal[index+1:]})
+	}
+
+	return &assignment{
+		Name:  w[0].Literal[:index],
+		Value: append(value, w[1:]...),
+	}
+}
+
+func isName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, c := range name {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+type lexer struct {
+	src    []rune
+	pos    int
+	tokens []*token
+}
+
+func lex(src string) ([]*token, error) {
+	l := &lexer{src: []rune(src)}
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			l.pos++
+		case c == '\\' && l.next(1) == '\n':
+			l.pos += 2
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.pos++
+			}
+		case c == '\n' || c == ';':
+			l.addOperator(string(c), 1)
+		case c == '&' && l.next(1) == '&':
+			l.addOperator("&&", 2)
+		case c == '|' && l.next(1) == '|':
+			l.addOperator("||", 2)
+		case c == '|':
+			l.addOperator("|", 1)
+		case c == '&':
+			l.addUnsupported("background commands (&) are not supported")
+		case c == '(' || c == ')' || c == '`':
+			l.addUnsupported("subshells and command substitution are not supported")
+		case c == '>' || c == '<' || (c >= '0' && c <= '9' && (l.next(1) == '>' || l.next(1) == '<')):
+			l.lexRedirect()
+		default:
+			w, err := l.lexWord()
+			if err != nil {
+				return nil, err
+			}
+
+			l.tokens = append(l.tokens, &token{Type: tokenWord, Word: w})
+		}
+	}
+
+	return l.tokens, nil
+}
+
+func (l *lexer) next(offset int) rune {
+	if l.pos+offset < len(l.src) {
+		return l.src[l.pos+offset]
+	}
+
+	return 0
+}
+
+func (l *lexer) addOperator(op string, length int) {
+	l.tokens = append(l.tokens, &token{Type: tokenOperator, Op: op})
+	l.pos += length
+}
+
+func (l *lexer) addUnsupported(message string) {
+	l.tokens = append(l.tokens, &token{Type: tokenUnsupported, Op: message})
+	l.pos++
+}
+
+func (l *lexer) lexRedirect() {
+	fd := -1
+	if c := l.src[l.pos]; c >= '0' && c <= '9' {
+		fd = int(c - '0')
+		l.pos++
+	}
+
+	op := string(l.src[l.pos])
+	l.pos++
+	if op == ">" && l.next(0) == '>' {
+		op = ">>"
+		l.pos++
+	} else if op == ">" && l.next(0) == '&' {
+		op = ">&"
+		l.pos++
+	}
+
+	if fd == -1 {
+		fd = 1
+		if op == "<" {
+			fd = 0
+		}
+	}
+
+	l.tokens = append(l.tokens, &token{Type: tokenRedirect, Op: op, FD: fd})
+}
+
+func (l *lexer) lexWord() (word, error) {
+	w := word{}
+	literal := []rune{}
+	flush := func(quoted bool) {
+		if len(literal) > 0 {
+			w = append(w, wordPart{Literal: string(literal), Quoted: quoted})
+			literal = []rune{}
+		}
+	}
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if strings.ContainsRune(" \t\r\n;&|<>()`", c) {
+			break
+		}
+
+		switch c {
+		case '\\':
+			if l.next(1) == '\n' {
+				l.pos += 2
+				continue
+			}
+			if l.pos+1 < len(l.src) {
+				literal = append(literal, l.src[l.pos+1])
+			}
+			l.pos += 2
+		case '\'':
+			flush(false)
+			end := l.indexFrom(l.pos+1, '\'')
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated single quote")
+			}
+
+			// Keep empty strings as word
+			w = append(w, wordPart{Literal: string(l.src[l.pos+1 : end]), Quoted: true})
+			l.pos = end + 1
+		case '"':
+			flush(false)
+			parts, err := l.lexDoubleQuoted()
+			if err != nil {
+				return nil, err
+			}
+
+			w = append(w, parts...)
+		case '$':
+			part, ok, err := l.lexParam()
+			if err != nil {
+				return nil, err
+			}
+			if ok == false {
+				literal = append(literal, c)
+				l.pos++
+				continue
+			}
+
+			flush(false)
+			w = append(w, part)
+		default:
+			literal = append(literal, c)
+			l.pos++
+		}
+	}
+
+	flush(false)
+	return w, nil
+}
+
+func (l *lexer) lexDoubleQuoted() ([]wordPart, error) {
+	parts := []wordPart{{Literal: "", Quoted: true}}
+	literal := []rune{}
+	l.pos++
+
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.pos++
+			if len(literal) > 0 || len(parts) == 1 {
+				parts = append(parts, wordPart{Literal: string(literal), Quoted: true})
+			}
+
+			return parts[1:], nil
+		case c == '\\' && strings.ContainsRune("$\"\\\n", l.next(1)):
+			if l.next(1) != '\n' {
+				literal = append(literal, l.next(1))
+			}
+			l.pos += 2
+		case c == '`':
+			return nil, fmt.Errorf("command substitution is not supported")
+		case c == '$':
+			part, ok, err := l.lexParam()
+			if err != nil {
+				return nil, err
+			}
+			if ok == false {
+				literal = append(literal, c)
+				l.pos++
+				continue
+			}
+
+			if len(literal) > 0 {
+				parts = append(parts, wordPart{Literal: string(literal), Quoted: true})
+				literal = []rune{}
+			}
+
+			part.Quoted = true
+			parts = append(parts, part)
+		default:
+			literal = append(literal, c)
+			l.pos++
+		}
+	}
+
+	return nil, fmt.Errorf("unterminated double quote")
+}
+
+// lexParam lexes $NAME, ${NAME}, ${NAME:-default}, ${NAME-default} and the special parameters $?, $@ and $0-$9.
+// If the $ does not start a parameter expansion, false is returned
+func (l *lexer) lexParam() (wordPart, bool, error) {
+	next := l.next(1)
+	switch {
+	case next == '{':
+		end := l.indexFrom(l.pos+2, '}')
+		if end == -1 {
+			return wordPart{}, false, fmt.Errorf("unterminated ${")
+		}
+
+		expr := string(l.src[l.pos+2 : end])
+		l.pos = end + 1
+
+		nameEnd := 0
+		if len(expr) > 0 && isSpecialParam(expr[:1]) {
+			nameEnd = 1
+		} else {
+			for nameEnd < len(expr) && isName(expr[:nameEnd+1]) {
+				nameEnd++
+			}
+		}
+
+		part := wordPart{Param: expr[:nameEnd]}
+		operator := expr[nameEnd:]
+		switch {
+		case part.Param == "":
+			return wordPart{}, false, fmt.Errorf("unsupported parameter expansion ${%s}", expr)
+		case operator == "":
+		case strings.HasPrefix(operator, ":-"):
+			part.Default, part.HasDefault, part.DefaultIfEmpty = operator[2:], true, true
+		case strings.HasPrefix(operator, "-"):
+			part.Default, part.HasDefault = operator[1:], true
+		default:
+			return wordPart{}, false, fmt.Errorf("unsupported parameter expansion ${%s}, only ${NAME:-default} and ${NAME-default} are supported", expr)
+		}
+
+		return part, true, nil
+	case next == '(':
+		return wordPart{}, false, fmt.Errorf("command substitution is not supported")
+	case next == '?' || next == '@' || (next >= '0' && next <= '9'):
+		l.pos += 2
+		return wordPart{Param: string(next)}, true, nil
+	case next == '_' || (next >= 'a' && next <= 'z') || (next >= 'A' && next <= 'Z'):
+		start := l.pos + 1
+		end := start
+		for end < len(l.src) && isName(string(l.src[start:end+1])) {
+			end++
+		}
+
+		l.pos = end
+		return wordPart{Param: string(l.src[start:end])}, true, nil
+	}
+
+	return wordPart{}, false, nil
+}
+
+func isSpecialParam(name string) bool {
+	return len(name) == 1 && strings.ContainsAny(name, "?@0123456789")
+}
+
+func (l *lexer) indexFrom(start int, c rune) int {
+	for i := start; i < len(l.src); i++ {
+		if l.src[i] == c {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,474 @@
+package shell
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Options holds the options for running a script
+type Options struct {
+	// Dir is the working directory of the script (Default: current working directory)
+	Dir string
+
+	// Env holds additional environment variables in the form KEY=value
+	Env []string
+
+	// Args are the positional parameters ($1, $2, ... and $@) of the script
+	Args []string
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// ExitError is returned if the script exits with a non zero exit code
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// Run parses the script and executes it with the built-in interpreter. The interpreter supports the POSIX shell
+// syntax that is commonly used in hooks and build commands (&&, ||, ;, |, redirects, quoting and variable
+// expansion) and a set of portable builtins, so that scripts behave the same on Windows, macOS and Linux
+func Run(src string, options *Options) error {
+	parsed, err := parse(src)
+	if err != nil {
+		return errors.Wrap(err, "parse script")
+	}
+
+	r, err := newRunner(options)
+	if err != nil {
+		return err
+	}
+
+	status := r.runScript(parsed)
+	if status != 0 {
+		return &ExitError{Code: status}
+	}
+
+	return nil
+}
+
+// runner holds the state of a running script
+type runner struct {
+	dir  string
+	env  map[string]string
+	args []string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+
+	lastStatus int
+	exited     bool
+}
+
+func newRunner(options *Options) (*runner, error) {
+	r := &runner{
+		dir:    options.Dir,
+		env:    map[string]string{},
+		args:   options.Args,
+		stdin:  options.Stdin,
+		stdout: options.Stdout,
+		stderr: options.Stderr,
+	}
+
+	if r.dir == "" {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+
+		r.dir = dir
+	}
+	if r.stdout == nil {
+		r.stdout = os.Stdout
+	}
+	if r.stderr == nil {
+		r.stderr = os.Stderr
+	}
+
+	for _, env := range append(os.Environ(), options.Env...) {
+		splitted := strings.SplitN(env, "=", 2)
+		if len(splitted) == 2 {
+			r.setVar(splitted[0], splitted[1])
+		}
+	}
+
+	return r, nil
+}
+
+// clone returns a copy of the runner that is used for the commands of a pipeline
+func (r *runner) clone() *runner {
+	clone := *r
+	clone.env = make(map[string]string, len(r.env))
+	for key, value := range r.env {
+		clone.env[key] = value
+	}
+
+	return &clone
+}
+
+// Environment variables are case insensitive on windows
+func (r *runner) normalizeName(name string) string {
+	if runtime.GOOS == "windows" {
+		return strings.ToUpper(name)
+	}
+
+	return name
+}
+
+func (r *runner) setVar(name, value string) {
+	r.env[r.normalizeName(name)] = value
+}
+
+func (r *runner) lookupVar(name string) (string, bool) {
+	value, ok := r.env[r.normalizeName(name)]
+	return value, ok
+}
+
+func (r *runner) environ() []string {
+	env := make([]string, 0, len(r.env))
+	for key, value := range r.env {
+		env = append(env, key+"="+value)
+	}
+
+	sort.Strings(env)
+	return env
+}
+
+func (r *runner) runScript(s script) int {
+	for _, list := range s {
+		r.lastStatus = r.runAndOr(list)
+		if r.exited {
+			break
+		}
+	}
+
+	return r.lastStatus
+}
+
+func (r *runner) runAndOr(list *andOr) int {
+	status := r.runPipeline(list.Pipelines[0])
+	for index, op := range list.Ops {
+		if r.exited {
+			break
+		}
+
+		if (op == "&&" && status == 0) || (op == "||" && status != 0) {
+			status = r.runPipeline(list.Pipelines[index+1])
+		}
+	}
+
+	return status
+}
+
+func (r *runner) runPipeline(pipe pipeline) int {
+	if len(pipe) == 1 {
+		r.lastStatus = r.runSimpleCommand(pipe[0])
+		return r.lastStatus
+	}
+
+	// Every command of a pipeline runs concurrently in its own copy of the shell state
+	statuses := make([]int, len(pipe))
+	waitGroup := sync.WaitGroup{}
+	stdin := r.stdin
+
+	for index, cmd := range pipe {
+		stage := r.clone()
+		stage.stdin = stdin
+
+		var pipeReader *io.PipeReader
+		var pipeWriter *io.PipeWriter
+		if index < len(pipe)-1 {
+			pipeReader, pipeWriter = io.Pipe()
+			stage.stdout = pipeWriter
+			stdin = pipeReader
+		}
+
+		waitGroup.Add(1)
+		go func(index int, cmd *simpleCommand, stage *runner, reader io.Reader, writer *io.PipeWriter) {
+			defer waitGroup.Done()
+
+			statuses[index] = stage.runSimpleCommand(cmd)
+			if writer != nil {
+				writer.Close()
+			}
+			if pipeReader, ok := reader.(*io.PipeReader); ok {
+				pipeReader.Close()
+			}
+		}(index, cmd, stage, stage.stdin, pipeWriter)
+	}
+
+	waitGroup.Wait()
+	r.lastStatus = statuses[len(statuses)-1]
+	return r.lastStatus
+}
+
+func (r *runner) runSimpleCommand(cmd *simpleCommand) int {
+	args := []string{}
+	for _, w := range cmd.Args {
+		args = append(args, r.expandWord(w)...)
+	}
+
+	assignments := []string{}
+	for _, assign := range cmd.Assignments {
+		value := r.expandString(assign.Value)
+		if len(args) == 0 {
+			r.setVar(assign.Name, value)
+		} else {
+			assignments = append(assignments, assign.Name+"="+value)
+		}
+	}
+
+	stdin, stdout, stderr, closeFiles, err := r.openRedirects(cmd.Redirects)
+	defer closeFiles()
+	if err != nil {
+		fmt.Fprintln(r.stderr, err)
+		return 1
+	}
+	if len(args) == 0 {
+		return 0
+	}
+
+	if builtin, ok := builtins[args[0]]; ok {
+		return builtin(r, args[1:], stdout, stderr)
+	}
+
+	return r.runExternal(args, assignments, stdin, stdout, stderr)
+}
+
+func (r *runner) runExternal(args []string, assignments []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	path, err := r.lookPath(args[0])
+	if err != nil {
+		fmt.Fprintf(stderr, "%s: command not found\n", args[0])
+		return 127
+	}
+
+	cmd := exec.Command(path, args[1:]...)
+	cmd.Dir = r.dir
+	cmd.Env = append(r.environ(), assignments...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			if exitCode := exitError.ExitCode(); exitCode > 0 {
+				return exitCode
+			}
+
+			return 1
+		}
+
+		fmt.Fprintf(stderr, "%s: %v\n", args[0], err)
+		return 126
+	}
+
+	return 0
+}
+
+// lookPath resolves commands with a path relative to the working directory of the script and
+// searches all other commands in the PATH of the script
+func (r *runner) lookPath(name string) (string, error) {
+	if strings.ContainsAny(name, `/\`) {
+		if filepath.IsAbs(name) == false {
+			name = filepath.Join(r.dir, name)
+		}
+
+		return exec.LookPath(name)
+	}
+
+	if path, ok := r.lookupVar("PATH"); ok {
+		for _, dir := range filepath.SplitList(path) {
+			if dir == "" {
+				dir = r.dir
+			}
+
+			found, err := exec.LookPath(filepath.Join(dir, name))
+			if err == nil {
+				return found, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("%s not found", name)
+}
+
+func (r *runner) openRedirects(redirects []*redirect) (io.Reader, io.Writer, io.Writer, func(), error) {
+	stdin, stdout, stderr := r.stdin, r.stdout, r.stderr
+	files := []*os.File{}
+	closeFiles := func() {
+		for _, file := range files {
+			file.Close()
+		}
+	}
+
+	for _, redirect := range redirects {
+		target := r.expandString(redirect.Target)
+
+		var file *os.File
+		var err error
+		switch redirect.Op {
+		case ">&":
+			if target == "1" && redirect.FD == 2 {
+				stderr = stdout
+				continue
+			} else if target == "2" && redirect.FD == 1 {
+				stdout = stderr
+				continue
+			}
+
+			return nil, nil, nil, closeFiles, fmt.Errorf("unsupported redirect >&%s", target)
+		case "<":
+			file, err = os.Open(r.absPath(target))
+		case ">":
+			file, err = os.Create(r.absPath(target))
+		case ">>":
+			file, err = os.OpenFile(r.absPath(target), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0666)
+		}
+		if err != nil {
+			return nil, nil, nil, closeFiles, err
+		}
+
+		files = append(files, file)
+		switch redirect.FD {
+		case 0:
+			stdin = file
+		case 1:
+			stdout = file
+		case 2:
+			stderr = file
+		default:
+			return nil, nil, nil, closeFiles, fmt.Errorf("unsupported file descriptor %d", redirect.FD)
+		}
+	}
+
+	return stdin, stdout, stderr, closeFiles, nil
+}
+
+// expandWord expands the parameters within the word and splits the results of unquoted expansions into fields at
+// spaces, tabs and newlines. Unquoted words that expand to an empty string are removed and "$@" expands to one word
+// per positional parameter
+func (r *runner) expandWord(w word) []string {
+	if len(w) == 1 && w[0].Param == "@" && w[0].Quoted {
+		return append([]string{}, r.args...)
+	}
+
+	fields := []string{}
+	field := ""
+	hasField := false
+	endField := func() {
+		if hasField {
+			fields = append(fields, field)
+		}
+
+		field = ""
+		hasField = false
+	}
+
+	for _, part := range w {
+		if part.Param == "" || part.Quoted {
+			field += r.expandPart(part)
+			hasField = hasField || part.Quoted || field != ""
+			continue
+		}
+
+		value := r.expandPart(part)
+		if value != "" && isFieldSeparator(rune(value[0])) {
+			endField()
+		}
+		for index, splitted := range strings.FieldsFunc(value, isFieldSeparator) {
+			if index > 0 {
+				endField()
+			}
+
+			field += splitted
+			hasField = true
+		}
+		if value != "" && isFieldSeparator(rune(value[len(value)-1])) {
+			endField()
+		}
+	}
+
+	endField()
+	return fields
+}
+
+// expandString expands the parameters within the word without splitting it, e.g. for the value of an assignment
+func (r *runner) expandString(w word) string {
+	value := ""
+	for _, part := range w {
+		value += r.expandPart(part)
+	}
+
+	return value
+}
+
+func (r *runner) expandPart(part wordPart) string {
+	if part.Param == "" {
+		return part.Literal
+	}
+
+	value, ok := r.lookupParam(part.Param)
+	if part.HasDefault && (ok == false || (part.DefaultIfEmpty && value == "")) {
+		return part.Default
+	}
+
+	return value
+}
+
+func isFieldSeparator(c rune) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func (r *runner) lookupParam(name string) (string, bool) {
+	switch {
+	case name == "?":
+		return strconv.Itoa(r.lastStatus), true
+	case name == "@":
+		return strings.Join(r.args, " "), true
+	case name == "0":
+		return "sh", true
+	case len(name) == 1 && name[0] >= '1' && name[0] <= '9':
+		index := int(name[0] - '1')
+		if index < len(r.args) {
+			return r.args[index], true
+		}
+
+		return "", false
+	}
+
+	return r.lookupVar(name)
+}
+
+func (r *runner) absPath(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+
+	return filepath.Join(r.dir, filepath.FromSlash(path))
+}
+
+// Quote returns the argument quoted, so that the interpreter parses it as a single word without expansions
+func Quote(arg string) string {
+	if arg != "" && strings.ContainsAny(arg, " \t\r\n'\"\\$;&|<>()`#*?~") == false {
+		return arg
+	}
+
+	return "'" + strings.Replace(arg, "'", `'"'"'`, -1) + "'"
+}
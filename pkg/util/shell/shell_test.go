@@ -0,0 +1,392 @@
+package shell
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func runScript(t *testing.T, dir string, script string, args ...string) (string, error) {
+	stdout := &bytes.Buffer{}
+	err := Run(script, &Options{
+		Dir:    dir,
+		Env:    []string{"TAG=v1.0", "EMPTY="},
+		Args:   args,
+		Stdout: stdout,
+		Stderr: stdout,
+	})
+
+	return stdout.String(), err
+}
+
+func TestRun(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testShell")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := map[string]string{
+		`echo ${TAG} && echo "tag: $TAG"`:                         "v1.0\ntag: v1.0\n",
+		`echo 'no $TAG' "a  b" c\ d`:                              "no $TAG a  b c d\n",
+		`echo a$EMPTY b "" ${UNSET:-default}`:                     "a b  default\n",
+		`false && echo no || echo yes; echo $?`:                   "yes\n0\n",
+		`NAME=world; export GREETING=hello; echo $GREETING $NAME`: "hello world\n",
+		`echo first $1, all $@`:                                   "first one, all one two\n",
+		"echo multi \\\n  line # comment":                         "multi line\n",
+		`true; exit 0; echo not printed`:                          "",
+	}
+
+	for script, expected := range tests {
+		output, err := runScript(t, dir, script, "one", "two")
+		if err != nil {
+			t.Fatalf("Error running %s: %v", script, err)
+		}
+		if output != expected {
+			t.Fatalf("Unexpected output of %s: expected %q, got %q", script, expected, output)
+		}
+	}
+
+	// Builtins and redirects
+	_, err = runScript(t, dir, `mkdir -p a/b && echo content > a/b/file.txt && echo more >> a/b/file.txt && cp -r a c && mv c/b/file.txt c/moved.txt && rm -rf a`)
+	if err != nil {
+		t.Fatalf("Error running file builtins: %v", err)
+	}
+
+	content, err := ioutil.ReadFile(filepath.Join(dir, "c", "moved.txt"))
+	if err != nil || string(content) != "content\nmore\n" {
+		t.Fatalf("Unexpected file content %q: %v", string(content), err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a")); os.IsNotExist(err) == false {
+		t.Fatalf("Expected folder a to be removed")
+	}
+
+	output, err := runScript(t, dir, `cd c && echo changed > file.txt && cd .. && cp c/file.txt copied.txt`)
+	if err != nil {
+		t.Fatalf("Error running cd: %v", err)
+	}
+	if content, _ := ioutil.ReadFile(filepath.Join(dir, "copied.txt")); string(content) != "changed\n" {
+		t.Fatalf("Unexpected content after cd %q", string(content))
+	}
+
+	// Exit codes
+	_, err = runScript(t, dir, `echo failing; exit 3`)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 3 {
+		t.Fatalf("Expected exit code 3, got %v", err)
+	}
+
+	output, err = runScript(t, dir, `not-existing-command-xyz`)
+	if exitErr, ok := err.(*ExitError); !ok || exitErr.Code != 127 || strings.Contains(output, "command not found") == false {
+		t.Fatalf("Expected command not found, got %v: %s", err, output)
+	}
+
+	// Syntax errors
+	for _, script := range []string{`echo "unterminated`, `echo $(date)`, `sleep 1 &`, `&& echo`, `echo |`, `echo ${TAG:=default}`, `echo ${#TAG}`} {
+		_, err = runScript(t, dir, script)
+		if err == nil || strings.HasPrefix(err.Error(), "parse script") == false {
+			t.Fatalf("Expected parse error for %s, got %v", script, err)
+		}
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	if _, err := os.Stat("/bin/cat"); err != nil {
+		t.Skip("cat is not available")
+	}
+
+	output, err := runScript(t, "", `echo piped | cat | cat`)
+	if err != nil {
+		t.Fatalf("Error running pipeline: %v", err)
+	}
+	if output != "piped\n" {
+		t.Fatalf("Unexpected pipeline output %q", output)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	for _, arg := range []string{"simple", "with space", "it's", "$TAG", ""} {
+		output, err := runScript(t, "", "echo "+Quote(arg))
+		if err != nil {
+			t.Fatalf("Error running quoted arg %s: %v", arg, err)
+		}
+		if output != arg+"\n" {
+			t.Fatalf("Expected %q, got %q", arg+"\n", output)
+		}
+	}
+}
+
+type scriptTestCase struct {
+	script string
+
+	expectedOutput string
+	expectedCode   int
+}
+
+func runScriptTestCases(t *testing.T, dir string, testCases map[string]*scriptTestCase) {
+	for testName, testCase := range testCases {
+		output, err := runScript(t, dir, testCase.script)
+		code := 0
+		if exitErr, ok := err.(*ExitError); ok {
+			code = exitErr.Code
+		} else if err != nil {
+			t.Fatalf("Unexpected error in testCase %s: %v", testName, err)
+		}
+
+		if code != testCase.expectedCode {
+			t.Fatalf("Unexpected exit code in testCase %s: expected %d, got %d", testName, testCase.expectedCode, code)
+		}
+		if output != testCase.expectedOutput {
+			t.Fatalf("Unexpected output in testCase %s: expected %q, got %q", testName, testCase.expectedOutput, output)
+		}
+	}
+}
+
+func TestQuotingAndEscapes(t *testing.T) {
+	runScriptTestCases(t, "", map[string]*scriptTestCase{
+		"Quotes within quotes": {
+			script:         `echo "it's" 'say "hi"' "a'b'c"`,
+			expectedOutput: "it's say \"hi\" a'b'c\n",
+		},
+		"Empty quoted words are kept": {
+			script:         `echo '' "" x`,
+			expectedOutput: "  x\n",
+		},
+		"Adjacent quoted and unquoted parts form one word": {
+			script:         `echo a"b"'c'd "  spaced  "`,
+			expectedOutput: "abcd   spaced  \n",
+		},
+		"Expansion only outside single quotes": {
+			script:         `echo "$TAG"'$TAG'$TAG "${TAG}s"`,
+			expectedOutput: "v1.0$TAGv1.0 v1.0s\n",
+		},
+		"Escapes within double quotes": {
+			script:         `echo "\$TAG \"q\" \\ \n"`,
+			expectedOutput: "$TAG \"q\" \\ \\n\n",
+		},
+		"No escapes within single quotes": {
+			script:         `echo 'single \n \\ $TAG'`,
+			expectedOutput: "single \\n \\\\ $TAG\n",
+		},
+		"Unquoted escapes": {
+			script:         `echo a\$b \"q\" back\\slash \'s\' semi\;colon`,
+			expectedOutput: "a$b \"q\" back\\slash 's' semi;colon\n",
+		},
+		"Trailing dollar is literal": {
+			script:         `echo cost: 5$ "$"`,
+			expectedOutput: "cost: 5$ $\n",
+		},
+	})
+}
+
+func TestAndOr(t *testing.T) {
+	runScriptTestCases(t, "", map[string]*scriptTestCase{
+		"Failing command skips &&": {
+			script:         `false && echo no; echo $?`,
+			expectedOutput: "1\n",
+		},
+		"Failing command runs ||": {
+			script:         `false && echo a || echo b`,
+			expectedOutput: "b\n",
+		},
+		"Operators are left associative": {
+			script:         `true || false && echo yes`,
+			expectedOutput: "yes\n",
+		},
+		"Status of the last executed command": {
+			script:         `false || false && echo no; echo $?`,
+			expectedOutput: "1\n",
+		},
+		"Status is available after ||": {
+			script:         `true && false || echo recovered $?`,
+			expectedOutput: "recovered 1\n",
+		},
+		"Failing command not found in &&": {
+			script:         `not-existing-command-xyz && echo no`,
+			expectedOutput: "not-existing-command-xyz: command not found\n",
+			expectedCode:   127,
+		},
+		"Failing last command is the exit code": {
+			script:         `echo first && false`,
+			expectedOutput: "first\n",
+			expectedCode:   1,
+		},
+		"Exit stops the list": {
+			script:       `exit 2 || echo no; echo no`,
+			expectedCode: 2,
+		},
+		"Newline after operator": {
+			script:         "false ||\n  echo continued",
+			expectedOutput: "continued\n",
+		},
+	})
+}
+
+func TestRedirects(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testShell")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	_, createErr := os.Create(filepath.Join(dir, "missing", "out.txt"))
+	if createErr == nil {
+		t.Fatal("Expected error creating file in missing folder")
+	}
+
+	runScriptTestCases(t, dir, map[string]*scriptTestCase{
+		"Redirect and append": {
+			script:         `echo one > out.txt && echo two >>out.txt && echo three>>out.txt`,
+			expectedOutput: "",
+		},
+		"Redirect target with variable and quotes": {
+			script:         `FILE=var; echo content > "$FILE file.txt"`,
+			expectedOutput: "",
+		},
+		"Redirect stderr to file": {
+			script:         `not-existing-command-xyz 2> err.txt || echo failed`,
+			expectedOutput: "failed\n",
+		},
+		"Redirect stderr to stdout file": {
+			script:       `not-existing-command-xyz > both.txt 2>&1`,
+			expectedCode: 127,
+		},
+		"Redirect stdout to stderr": {
+			script:         `echo to-stderr 1>&2 2> ignored.txt`,
+			expectedOutput: "to-stderr\n",
+		},
+		"Redirect into missing folder fails": {
+			script:         `echo content > missing/out.txt && echo no`,
+			expectedOutput: createErr.Error() + "\n",
+			expectedCode:   1,
+		},
+		"Unsupported redirect": {
+			script:         `echo content >&3 || echo failed`,
+			expectedOutput: "unsupported redirect >&3\nfailed\n",
+		},
+	})
+
+	expectedFiles := map[string]string{
+		"out.txt":      "one\ntwo\nthree\n",
+		"var file.txt": "content\n",
+		"err.txt":      "not-existing-command-xyz: command not found\n",
+		"both.txt":     "not-existing-command-xyz: command not found\n",
+		"ignored.txt":  "",
+	}
+	for file, expected := range expectedFiles {
+		content, err := ioutil.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatalf("Error reading %s: %v", file, err)
+		}
+		if string(content) != expected {
+			t.Fatalf("Unexpected content of %s: expected %q, got %q", file, expected, string(content))
+		}
+	}
+}
+
+func TestUnsetVariablesInWindowsPaths(t *testing.T) {
+	runScriptTestCases(t, "", map[string]*scriptTestCase{
+		"Unset variable in double quotes": {
+			script:         `echo "${UNSET}\bin" "C:\Users\\${UNSET}\AppData" "$UNSET\\server\share"`,
+			expectedOutput: "\\bin C:\\Users\\\\AppData \\server\\share\n",
+		},
+		"Unset variable between single quotes": {
+			script:         `echo 'C:\'$UNSET'\Temp'`,
+			expectedOutput: "C:\\\\Temp\n",
+		},
+		"Unquoted unset variables are removed": {
+			script:         `echo ${UNSET}\bin $UNSET "$UNSET" end`,
+			expectedOutput: "bin  end\n",
+		},
+		"Default values with backslashes": {
+			script:         `echo "${UNSET:-C:\Program Files\App}" "${EMPTY:-D:\data}"`,
+			expectedOutput: "C:\\Program Files\\App D:\\data\n",
+		},
+		"Set variable followed by a path": {
+			script:         `echo "${TAG}\dir" "$TAG\dir"`,
+			expectedOutput: "v1.0\\dir v1.0\\dir\n",
+		},
+		"Unset variable as command": {
+			script:         `$UNSET echo runs`,
+			expectedOutput: "runs\n",
+		},
+	})
+}
+
+func TestFieldSplitting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "testShell")
+	if err != nil {
+		t.Fatalf("Error creating temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	runScriptTestCases(t, dir, map[string]*scriptTestCase{
+		"Unquoted expansion is split": {
+			script:         `X="a   b"; echo $X; echo "$X"`,
+			expectedOutput: "a b\na   b\n",
+		},
+		"Separators at the edges end the field": {
+			script:         `X=" a	b "; echo [$X]; echo [${UNSET:-a  b}]`,
+			expectedOutput: "[ a b ]\n[a b]\n",
+		},
+		"Assignments are not split": {
+			script:         `X="a  b"; Y=$X; echo "$Y"`,
+			expectedOutput: "a  b\n",
+		},
+		"Expansion with only separators is removed": {
+			script:         `X="   "; echo a $X b`,
+			expectedOutput: "a b\n",
+		},
+		"Split fields are separate arguments": {
+			script:         `DIRS="one two"; mkdir $DIRS && mkdir "$DIRS"`,
+			expectedOutput: "",
+		},
+	})
+
+	for _, folder := range []string{"one", "two", "one two"} {
+		if stat, err := os.Stat(filepath.Join(dir, folder)); err != nil || stat.IsDir() == false {
+			t.Fatalf("Expected folder %s: %v", folder, err)
+		}
+	}
+}
+
+func TestDefaultValues(t *testing.T) {
+	runScriptTestCases(t, "", map[string]*scriptTestCase{
+		"Default for unset variables": {
+			script:         `echo ${UNSET-unset} ${UNSET:-unset}`,
+			expectedOutput: "unset unset\n",
+		},
+		"Default for empty variables": {
+			script:         `echo "[${EMPTY-default}]" "[${EMPTY:-default}]"`,
+			expectedOutput: "[] [default]\n",
+		},
+		"No default for set variables": {
+			script:         `echo ${TAG-default} ${TAG:-default}`,
+			expectedOutput: "v1.0 v1.0\n",
+		},
+		"Default of positional parameters": {
+			script:         `echo ${1-none} ${3-none}`,
+			expectedOutput: "none none\n",
+		},
+	})
+}
+
+func TestReservedWords(t *testing.T) {
+	for _, script := range []string{`if true; then echo y; fi`, `for i in a b; do echo $i; done`, `while false; do echo; done`, `case a in a) echo;; esac`, `{ echo a; }`, `! false`, `true && if true; then echo; fi`} {
+		_, err := runScript(t, "", script)
+		if err == nil || strings.Contains(err.Error(), "is not supported, compound commands") == false {
+			t.Fatalf("Expected error for reserved word in %s, got %v", script, err)
+		}
+	}
+
+	// Reserved words are only recognized as command
+	output, err := runScript(t, "", `echo if then fi "for" && "true"`)
+	if err != nil {
+		t.Fatalf("Error running reserved words as arguments: %v", err)
+	}
+	if output != "if then fi for\n" {
+		t.Fatalf("Unexpected output %q", output)
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/devspace-cloud/devspace/pkg/util/log"
 	surveypkg "gopkg.in/AlecAivazis/survey.v1"
 )
 
@@ -25,6 +29,20 @@ var DefaultValidationRegexPattern = regexp.MustCompile("^.*$")
 
 var nextAnswers []*string
 
+// noInput disables all questions, e.g. in CI where nobody can answer them
+var noInput bool
+
+// SetNoInput disables or enables asking questions
+func SetNoInput(disabled bool) {
+	noInput = disabled
+}
+
+// IsNoInput returns true if questions cannot be asked. Callers should fall back to a default or fail with a
+// descriptive error instead of asking
+func IsNoInput() bool {
+	return noInput
+}
+
 // SetNextAnswer will set the next answer for the question function
 // THIS SHOULD BE ONLY USED FOR UNIT TESTS
 func SetNextAnswer(answer string) {
@@ -41,9 +59,11 @@ func Question(params *QuestionOptions) string {
 
 	if params.Options != nil {
 		prompt = &surveypkg.Select{
-			Message: params.Question + "\n",
-			Options: params.Options,
-			Default: params.DefaultValue,
+			Message:  params.Question + "\n",
+			Options:  params.Options,
+			Default:  params.DefaultValue,
+			PageSize: selectPageSize,
+			FilterFn: FuzzyFilter,
 		}
 	} else if params.IsPassword {
 		prompt = &surveypkg.Password{
@@ -105,6 +125,9 @@ func Question(params *QuestionOptions) string {
 		nextAnswers = nextAnswers[1:]
 		return answer
 	}
+	if noInput {
+		log.Fatalf("Cannot ask question '%s', because input is disabled with --no-input", params.Question)
+	}
 
 	err := surveypkg.Ask(question, &answers)
 	if err != nil {
@@ -114,3 +137,58 @@ func Question(params *QuestionOptions) string {
 
 	return answers.Question
 }
+
+// selectPageSize is the number of options a select prompt shows at once
+const selectPageSize = 10
+
+// FuzzyFilter returns the options that contain the characters of the filter in the same order (case insensitive).
+// Options that contain the filter as a whole are returned first, then options whose matched characters are closer
+// together
+func FuzzyFilter(filter string, options []string) []string {
+	type match struct {
+		option string
+		score  int
+	}
+
+	filter = strings.ToLower(filter)
+	matches := []match{}
+	for _, option := range options {
+		score, ok := fuzzyScore(filter, strings.ToLower(option))
+		if ok {
+			matches = append(matches, match{option: option, score: score})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score < matches[j].score
+	})
+
+	filtered := make([]string, 0, len(matches))
+	for _, match := range matches {
+		filtered = append(filtered, match.option)
+	}
+
+	return filtered
+}
+
+// fuzzyScore returns how well the option matches the filter, lower is better
+func fuzzyScore(filter, option string) (int, bool) {
+	if index := strings.Index(option, filter); index != -1 {
+		return index, true
+	}
+
+	// The score of a subsequence match is always worse than the one of a substring match
+	score := len(option)
+	last := -1
+	for _, r := range filter {
+		index := strings.IndexRune(option[last+1:], r)
+		if index == -1 {
+			return 0, false
+		}
+
+		score += index
+		last += index + utf8.RuneLen(r)
+	}
+
+	return score, true
+}
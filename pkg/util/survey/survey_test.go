@@ -55,3 +55,12 @@ func TestSurvey(t *testing.T) {
 		}
 	}
 }
+
+func TestFuzzyFilter(t *testing.T) {
+	options := []string{"redis-master", "backend-deployment", "devspace-default", "database", "frontend"}
+
+	assert.DeepEqual(t, FuzzyFilter("end", options), []string{"backend-deployment", "frontend"})
+	assert.DeepEqual(t, FuzzyFilter("dpl", options), []string{"backend-deployment", "devspace-default"})
+	assert.DeepEqual(t, FuzzyFilter("DB", options), []string{"database"})
+	assert.DeepEqual(t, FuzzyFilter("xyz", options), []string{})
+}
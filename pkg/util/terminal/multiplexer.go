@@ -0,0 +1,223 @@
+package terminal
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// PrefixKey is the key (Ctrl+A) that has to be pressed before a command key of the multiplexer
+const PrefixKey byte = 0x01
+
+// maxReplayBuffer is the amount of output of a terminal that is kept to redraw it when switching to it
+const maxReplayBuffer = 16 * 1024
+
+// Help describes the keys of the multiplexer
+const Help = "Ctrl+A 1-9: switch to terminal, Ctrl+A n/p: next/previous terminal, Ctrl+A Ctrl+A: send Ctrl+A"
+
+// Multiplexer shares a single local terminal between several remote terminals. Only the output of the
+// active terminal is printed and the input is forwarded only to the active terminal. The active terminal
+// is switched with the prefix key followed by a command key
+type Multiplexer struct {
+	out      io.Writer
+	sessions []*session
+
+	active int
+	mutex  sync.Mutex
+}
+
+type session struct {
+	name   string
+	index  int
+	closed bool
+
+	stdinReader *io.PipeReader
+	stdinWriter *io.PipeWriter
+
+	buffer []byte
+	mux    *Multiplexer
+}
+
+// NewMultiplexer creates a new multiplexer for terminals with the given names, the first terminal is active
+func NewMultiplexer(names []string, out io.Writer) *Multiplexer {
+	m := &Multiplexer{
+		out:      out,
+		sessions: make([]*session, 0, len(names)),
+	}
+
+	for index, name := range names {
+		reader, writer := io.Pipe()
+		m.sessions = append(m.sessions, &session{
+			name:        name,
+			index:       index,
+			stdinReader: reader,
+			stdinWriter: writer,
+			mux:         m,
+		})
+	}
+
+	return m
+}
+
+// Stdin returns the input of the terminal with the given index
+func (m *Multiplexer) Stdin(index int) io.Reader {
+	return m.sessions[index].stdinReader
+}
+
+// Stdout returns the writer for the output of the terminal with the given index
+func (m *Multiplexer) Stdout(index int) io.Writer {
+	return m.sessions[index]
+}
+
+// Active returns the index of the active terminal
+func (m *Multiplexer) Active() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.active
+}
+
+// Write writes the output of the session to the local terminal if it is active and remembers it for redrawing
+func (s *session) Write(data []byte) (int, error) {
+	s.mux.mutex.Lock()
+	defer s.mux.mutex.Unlock()
+
+	s.buffer = append(s.buffer, data...)
+	if len(s.buffer) > maxReplayBuffer {
+		s.buffer = s.buffer[len(s.buffer)-maxReplayBuffer:]
+	}
+
+	if s.mux.active == s.index {
+		return s.mux.out.Write(data)
+	}
+
+	return len(data), nil
+}
+
+// Close marks the terminal with the given index as closed and switches to the next open terminal. It returns
+// false if all terminals are closed
+func (m *Multiplexer) Close(index int) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	s := m.sessions[index]
+	if s.closed == false {
+		s.closed = true
+		s.stdinReader.Close()
+	}
+
+	if m.active == index {
+		for offset := 1; offset < len(m.sessions); offset++ {
+			next := (index + offset) % len(m.sessions)
+			if m.sessions[next].closed == false {
+				fmt.Fprintf(m.out, "\r\n[devspace] Terminal %s was closed\r\n", s.name)
+				m.switchTo(next)
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for _, s := range m.sessions {
+		if s.closed == false {
+			return true
+		}
+	}
+
+	return false
+}
+
+// switchTo activates the terminal with the given index and redraws its output. The caller must hold the mutex
+func (m *Multiplexer) switchTo(index int) {
+	if index < 0 || index >= len(m.sessions) || index == m.active || m.sessions[index].closed {
+		return
+	}
+
+	m.active = index
+	s := m.sessions[index]
+	fmt.Fprintf(m.out, "\r\n[devspace] Switched to terminal %d (%s)\r\n", index+1, s.name)
+	m.out.Write(s.buffer)
+}
+
+// step activates the next (or previous) open terminal. The caller must hold the mutex
+func (m *Multiplexer) step(direction int) {
+	for offset := 1; offset < len(m.sessions); offset++ {
+		next := (m.active + direction*offset + len(m.sessions)) % len(m.sessions)
+		if m.sessions[next].closed == false {
+			m.switchTo(next)
+			return
+		}
+	}
+}
+
+// Run reads the local input and forwards it to the active terminal until the input is closed
+func (m *Multiplexer) Run(in io.Reader) error {
+	defer func() {
+		for _, s := range m.sessions {
+			s.stdinWriter.Close()
+		}
+	}()
+
+	prefix := false
+	buf := make([]byte, 1024)
+	for {
+		n, err := in.Read(buf)
+
+		// Input before a command key has to be forwarded to the terminal that was active before
+		forward := make([]byte, 0, n)
+		for _, b := range buf[:n] {
+			if prefix {
+				prefix = false
+				if b != PrefixKey {
+					m.forward(forward)
+					forward = forward[:0]
+					m.command(b)
+					continue
+				}
+			} else if b == PrefixKey {
+				prefix = true
+				continue
+			}
+
+			forward = append(forward, b)
+		}
+		m.forward(forward)
+
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// forward writes the input to the active terminal. Input for closed terminals is dropped
+func (m *Multiplexer) forward(input []byte) {
+	if len(input) == 0 {
+		return
+	}
+
+	m.mutex.Lock()
+	active := m.sessions[m.active]
+	m.mutex.Unlock()
+
+	active.stdinWriter.Write(input)
+}
+
+// command executes the command key that was pressed after the prefix key
+func (m *Multiplexer) command(key byte) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	switch {
+	case key >= '1' && key <= '9':
+		m.switchTo(int(key - '1'))
+	case key == 'n':
+		m.step(1)
+	case key == 'p':
+		m.step(-1)
+	}
+}
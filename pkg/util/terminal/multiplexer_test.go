@@ -0,0 +1,77 @@
+package terminal
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type syncBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (s *syncBuffer) Write(data []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buffer.Write(data)
+}
+
+func (s *syncBuffer) String() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.buffer.String()
+}
+
+func TestMultiplexer(t *testing.T) {
+	out := &syncBuffer{}
+	multiplexer := NewMultiplexer([]string{"api", "web"}, out)
+
+	// Collect the input of the terminals
+	inputs := make([]string, 2)
+	waitGroup := sync.WaitGroup{}
+	for index := range inputs {
+		waitGroup.Add(1)
+		go func(index int) {
+			defer waitGroup.Done()
+
+			data, _ := ioutil.ReadAll(multiplexer.Stdin(index))
+			inputs[index] = string(data)
+		}(index)
+	}
+
+	multiplexer.Stdout(0).Write([]byte("api output\n"))
+	multiplexer.Stdout(1).Write([]byte("web output\n"))
+	if out.String() != "api output\n" {
+		t.Fatalf("Expected only the output of the active terminal, got %q", out.String())
+	}
+
+	input := "ls\n" + string(PrefixKey) + "2pwd\n" + string(PrefixKey) + string(PrefixKey) + string(PrefixKey) + "p" + "exit\n"
+	err := multiplexer.Run(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Error running multiplexer: %v", err)
+	}
+
+	waitGroup.Wait()
+	if inputs[0] != "ls\nexit\n" || inputs[1] != "pwd\n"+string(PrefixKey) {
+		t.Fatalf("Unexpected terminal input %q", inputs)
+	}
+	if strings.Contains(out.String(), "Switched to terminal 2 (web)\r\nweb output\n") == false {
+		t.Fatalf("Expected output of web to be replayed, got %q", out.String())
+	}
+	if multiplexer.Active() != 0 {
+		t.Fatalf("Expected terminal 1 to be active, got %d", multiplexer.Active()+1)
+	}
+
+	// Closing the active terminal switches to the next one
+	if multiplexer.Close(0) == false || multiplexer.Active() != 1 {
+		t.Fatalf("Expected terminal 2 to be active after closing terminal 1")
+	}
+	if multiplexer.Close(1) {
+		t.Fatalf("Expected all terminals to be closed")
+	}
+}
@@ -135,6 +135,7 @@ func (m *ChangeAmount) GetAmount() int64 {
 
 type ChangeChunk struct {
 	Changes              []*Change `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+	LastChunk            bool      `protobuf:"varint,2,opt,name=LastChunk,proto3" json:"LastChunk,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -172,6 +173,13 @@ func (m *ChangeChunk) GetChanges() []*Change {
 	return nil
 }
 
+func (m *ChangeChunk) GetLastChunk() bool {
+	if m != nil {
+		return m.LastChunk
+	}
+	return false
+}
+
 type Change struct {
 	ChangeType           ChangeType `protobuf:"varint,1,opt,name=ChangeType,proto3,enum=remote.ChangeType" json:"ChangeType,omitempty"`
 	Path                 string     `protobuf:"bytes,2,opt,name=Path,proto3" json:"Path,omitempty"`
@@ -360,6 +368,170 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
+type FileHash struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=Path,proto3" json:"Path,omitempty"`
+	Hash                 string   `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileHash) Reset()         { *m = FileHash{} }
+func (m *FileHash) String() string { return proto.CompactTextString(m) }
+func (*FileHash) ProtoMessage()    {}
+func (*FileHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{7}
+}
+
+func (m *FileHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileHash.Unmarshal(m, b)
+}
+func (m *FileHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileHash.Marshal(b, m, deterministic)
+}
+func (m *FileHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileHash.Merge(m, src)
+}
+func (m *FileHash) XXX_Size() int {
+	return xxx_messageInfo_FileHash.Size(m)
+}
+func (m *FileHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileHash proto.InternalMessageInfo
+
+func (m *FileHash) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileHash) GetHash() string {
+	if m != nil {
+		return m.Hash
+	}
+	return ""
+}
+
+type FileHashes struct {
+	Hashes               []*FileHash `protobuf:"bytes,1,rep,name=Hashes,proto3" json:"Hashes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *FileHashes) Reset()         { *m = FileHashes{} }
+func (m *FileHashes) String() string { return proto.CompactTextString(m) }
+func (*FileHashes) ProtoMessage()    {}
+func (*FileHashes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{8}
+}
+
+func (m *FileHashes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileHashes.Unmarshal(m, b)
+}
+func (m *FileHashes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileHashes.Marshal(b, m, deterministic)
+}
+func (m *FileHashes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileHashes.Merge(m, src)
+}
+func (m *FileHashes) XXX_Size() int {
+	return xxx_messageInfo_FileHashes.Size(m)
+}
+func (m *FileHashes) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileHashes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileHashes proto.InternalMessageInfo
+
+func (m *FileHashes) GetHashes() []*FileHash {
+	if m != nil {
+		return m.Hashes
+	}
+	return nil
+}
+
+type WatchOptions struct {
+	DebounceMillis       int64    `protobuf:"varint,1,opt,name=DebounceMillis,proto3" json:"DebounceMillis,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchOptions) Reset()         { *m = WatchOptions{} }
+func (m *WatchOptions) String() string { return proto.CompactTextString(m) }
+func (*WatchOptions) ProtoMessage()    {}
+func (*WatchOptions) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{9}
+}
+
+func (m *WatchOptions) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchOptions.Unmarshal(m, b)
+}
+func (m *WatchOptions) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchOptions.Marshal(b, m, deterministic)
+}
+func (m *WatchOptions) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchOptions.Merge(m, src)
+}
+func (m *WatchOptions) XXX_Size() int {
+	return xxx_messageInfo_WatchOptions.Size(m)
+}
+func (m *WatchOptions) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchOptions.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchOptions proto.InternalMessageInfo
+
+func (m *WatchOptions) GetDebounceMillis() int64 {
+	if m != nil {
+		return m.DebounceMillis
+	}
+	return 0
+}
+
+type VersionInfo struct {
+	Version              int64    `protobuf:"varint,1,opt,name=Version,proto3" json:"Version,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VersionInfo) Reset()         { *m = VersionInfo{} }
+func (m *VersionInfo) String() string { return proto.CompactTextString(m) }
+func (*VersionInfo) ProtoMessage()    {}
+func (*VersionInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_eefc82927d57d89b, []int{10}
+}
+
+func (m *VersionInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VersionInfo.Unmarshal(m, b)
+}
+func (m *VersionInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VersionInfo.Marshal(b, m, deterministic)
+}
+func (m *VersionInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VersionInfo.Merge(m, src)
+}
+func (m *VersionInfo) XXX_Size() int {
+	return xxx_messageInfo_VersionInfo.Size(m)
+}
+func (m *VersionInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_VersionInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VersionInfo proto.InternalMessageInfo
+
+func (m *VersionInfo) GetVersion() int64 {
+	if m != nil {
+		return m.Version
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("remote.ChangeType", ChangeType_name, ChangeType_value)
 	proto.RegisterType((*Watch)(nil), "remote.Watch")
@@ -369,38 +541,51 @@ func init() {
 	proto.RegisterType((*Paths)(nil), "remote.Paths")
 	proto.RegisterType((*Chunk)(nil), "remote.Chunk")
 	proto.RegisterType((*Empty)(nil), "remote.Empty")
+	proto.RegisterType((*FileHash)(nil), "remote.FileHash")
+	proto.RegisterType((*FileHashes)(nil), "remote.FileHashes")
+	proto.RegisterType((*WatchOptions)(nil), "remote.WatchOptions")
+	proto.RegisterType((*VersionInfo)(nil), "remote.VersionInfo")
 }
 
 func init() { proto.RegisterFile("remote.proto", fileDescriptor_eefc82927d57d89b) }
 
 var fileDescriptor_eefc82927d57d89b = []byte{
-	// 416 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x5d, 0x6b, 0xdb, 0x30,
-	0x14, 0x8d, 0xea, 0xda, 0x8e, 0x6f, 0x93, 0x52, 0xb4, 0x32, 0x44, 0xd8, 0xc0, 0x13, 0x65, 0x98,
-	0x3e, 0x84, 0xce, 0xa3, 0xec, 0xb9, 0x38, 0x66, 0x1b, 0x6c, 0x65, 0x68, 0x0d, 0x7b, 0x76, 0x53,
-	0x31, 0x9b, 0xc4, 0x92, 0xb1, 0xe5, 0x2d, 0xd9, 0x0f, 0xda, 0xcf, 0xd8, 0x6f, 0x1b, 0x92, 0xec,
-	0x24, 0x1e, 0xec, 0xed, 0x9c, 0x7b, 0xcf, 0xfd, 0x3a, 0x5c, 0x98, 0xd4, 0xbc, 0x94, 0x8a, 0xcf,
-	0xab, 0x5a, 0x2a, 0x89, 0x3d, 0xcb, 0xe8, 0x2d, 0xb8, 0xdf, 0x32, 0xb5, 0xca, 0x31, 0x86, 0xd3,
-	0x2f, 0x99, 0xca, 0x09, 0x0a, 0x51, 0x14, 0x30, 0x83, 0x31, 0x01, 0x3f, 0xdd, 0xae, 0x36, 0xed,
-	0x13, 0x27, 0x27, 0xa1, 0x13, 0x05, 0xac, 0xa7, 0xf4, 0x35, 0x4c, 0x92, 0x3c, 0x13, 0xdf, 0xf9,
-	0x5d, 0x29, 0x5b, 0xa1, 0xf0, 0x73, 0xf0, 0x2c, 0x32, 0xf5, 0x0e, 0xeb, 0x18, 0x7d, 0x07, 0x67,
-	0x56, 0x97, 0xe4, 0xad, 0x58, 0xe3, 0x08, 0xfc, 0x95, 0xa1, 0x0d, 0x41, 0xa1, 0x13, 0x9d, 0xc5,
-	0xe7, 0xf3, 0x6e, 0x2b, 0xab, 0x62, 0x7d, 0x9a, 0xfe, 0x41, 0xe0, 0xd9, 0x18, 0x8e, 0x01, 0x2c,
-	0x7a, 0xd8, 0x55, 0xdc, 0xf4, 0x3f, 0x8f, 0xf1, 0xb0, 0x4e, 0x67, 0xd8, 0x91, 0x6a, 0x7f, 0xcd,
-	0xc9, 0xd1, 0x35, 0x2f, 0x20, 0xf8, 0xac, 0x8a, 0x92, 0x2f, 0x45, 0xb1, 0x25, 0x8e, 0x59, 0xf3,
-	0x10, 0xc0, 0x57, 0x30, 0xdd, 0x93, 0xfb, 0x4c, 0x48, 0x72, 0x6a, 0x14, 0xc3, 0xa0, 0xee, 0xfb,
-	0xb5, 0xf8, 0xc5, 0x89, 0x6b, 0x92, 0x06, 0xe3, 0x4b, 0x70, 0x3f, 0x36, 0x8b, 0xa2, 0x26, 0x5e,
-	0x88, 0xa2, 0x31, 0xb3, 0x84, 0xbe, 0x04, 0x57, 0x4f, 0x6d, 0x74, 0xda, 0x00, 0x73, 0x71, 0xc0,
-	0x2c, 0xa1, 0xaf, 0xc0, 0xb5, 0x96, 0x10, 0xf0, 0x13, 0x29, 0x14, 0xef, 0xac, 0x9b, 0xb0, 0x9e,
-	0x52, 0x1f, 0xdc, 0xb4, 0xac, 0xd4, 0xee, 0xfa, 0xea, 0xd8, 0x00, 0x0c, 0xe0, 0x25, 0x1f, 0xee,
-	0xee, 0xdf, 0xa7, 0x17, 0x23, 0x8d, 0x17, 0xe9, 0xa7, 0xf4, 0x21, 0xbd, 0x40, 0xf1, 0x6f, 0x04,
-	0xb0, 0x90, 0x3f, 0x45, 0xa3, 0x6a, 0x9e, 0x95, 0x78, 0x0e, 0x63, 0xcd, 0x36, 0x32, 0x7b, 0xc2,
-	0xd3, 0xde, 0x2d, 0x33, 0x7b, 0x36, 0x3d, 0x98, 0xd7, 0x8a, 0x35, 0x1d, 0x45, 0xe8, 0x06, 0xe1,
-	0x37, 0xe0, 0xdb, 0x21, 0xcd, 0x41, 0x6e, 0xc6, 0xcf, 0x9e, 0x0d, 0xbd, 0xee, 0x8a, 0x6e, 0x10,
-	0xbe, 0xed, 0x9f, 0xa0, 0x49, 0xcc, 0x13, 0xfc, 0x53, 0x77, 0x39, 0xac, 0xeb, 0x3e, 0x62, 0x14,
-	0x3f, 0xc2, 0x78, 0x59, 0x75, 0x5b, 0x5e, 0x83, 0xb7, 0xac, 0x86, 0x3b, 0x9a, 0xfe, 0xb3, 0x61,
-	0x2f, 0xbd, 0xa3, 0xd6, 0x32, 0x5e, 0xca, 0x1f, 0xfc, 0xbf, 0xf7, 0xec, 0xb5, 0x8f, 0x9e, 0xf9,
-	0xf2, 0xb7, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x29, 0xa8, 0x8d, 0xf9, 0xf5, 0x02, 0x00, 0x00,
+	// 549 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x54, 0x41, 0x6f, 0xda, 0x4c,
+	0x10, 0xc5, 0x10, 0x1b, 0x98, 0x00, 0x42, 0xf3, 0xa1, 0x4f, 0x16, 0x4a, 0x25, 0xba, 0x8a, 0x52,
+	0x2b, 0x52, 0x69, 0xea, 0x2a, 0xb9, 0xf5, 0x10, 0x19, 0xb7, 0x89, 0x94, 0xa4, 0x95, 0x1b, 0xda,
+	0xb3, 0x21, 0xdb, 0x62, 0x15, 0x7b, 0x2d, 0x76, 0x69, 0x93, 0xfe, 0xb8, 0xde, 0xfa, 0xbf, 0xaa,
+	0x5d, 0xef, 0x1a, 0x8c, 0xca, 0x6d, 0xde, 0xcc, 0x1b, 0xef, 0xcc, 0x9b, 0x27, 0x43, 0x67, 0x45,
+	0x53, 0x26, 0xe8, 0x38, 0x5f, 0x31, 0xc1, 0xd0, 0x29, 0x10, 0x39, 0x07, 0xfb, 0x4b, 0x2c, 0xe6,
+	0x0b, 0x44, 0x38, 0xf8, 0x18, 0x8b, 0x85, 0x6b, 0x8d, 0x2c, 0xaf, 0x1d, 0xa9, 0x18, 0x5d, 0x68,
+	0x86, 0x8f, 0xf3, 0xe5, 0xfa, 0x81, 0xba, 0xf5, 0x51, 0xc3, 0x6b, 0x47, 0x06, 0x92, 0x13, 0xe8,
+	0x04, 0x8b, 0x38, 0xfb, 0x46, 0x2f, 0x53, 0xb6, 0xce, 0x04, 0xfe, 0x0f, 0x4e, 0x11, 0xa9, 0xfe,
+	0x46, 0xa4, 0x11, 0x99, 0xc2, 0x61, 0xc1, 0x0b, 0x16, 0xeb, 0xec, 0x3b, 0x7a, 0xd0, 0x9c, 0x2b,
+	0xc8, 0x5d, 0x6b, 0xd4, 0xf0, 0x0e, 0xfd, 0xde, 0x58, 0x4f, 0x55, 0xb0, 0x22, 0x53, 0xc6, 0x23,
+	0x68, 0xdf, 0xc4, 0x5c, 0xa8, 0x36, 0xb7, 0x3e, 0xb2, 0xbc, 0x56, 0xb4, 0x49, 0x90, 0xdf, 0x16,
+	0x38, 0x45, 0x07, 0xfa, 0x00, 0x45, 0x74, 0xff, 0x94, 0x53, 0xf5, 0x7a, 0xcf, 0xc7, 0xea, 0x57,
+	0x65, 0x25, 0xda, 0x62, 0x95, 0xbb, 0xd6, 0xb7, 0x76, 0x3d, 0x82, 0xf6, 0xad, 0x48, 0x52, 0x3a,
+	0xcd, 0x92, 0x47, 0xb7, 0xa1, 0x96, 0xd8, 0x24, 0xf0, 0x18, 0xba, 0x25, 0xb8, 0x8b, 0x33, 0xe6,
+	0x1e, 0x28, 0x46, 0x35, 0x29, 0xbf, 0xfb, 0x29, 0xf9, 0x45, 0x5d, 0x5b, 0x15, 0x55, 0x8c, 0x03,
+	0xb0, 0xaf, 0xf9, 0x24, 0x59, 0xb9, 0x8e, 0x5a, 0xa2, 0x00, 0xe4, 0x19, 0xd8, 0xf2, 0x55, 0x8e,
+	0x03, 0x1d, 0x28, 0x3d, 0xda, 0x51, 0x01, 0xc8, 0x73, 0xb0, 0x0b, 0xc1, 0x5c, 0x68, 0x06, 0x2c,
+	0x13, 0x54, 0x0b, 0xdb, 0x89, 0x0c, 0x24, 0x4d, 0xb0, 0xc3, 0x34, 0x17, 0x4f, 0xc4, 0x87, 0xd6,
+	0xbb, 0x64, 0x49, 0xaf, 0x62, 0xfe, 0xef, 0x23, 0x22, 0x1c, 0xc8, 0x9a, 0x59, 0x56, 0xc6, 0xe4,
+	0x02, 0xc0, 0xf4, 0x50, 0x8e, 0x1e, 0x38, 0x45, 0xa4, 0x8f, 0xd2, 0x37, 0xf2, 0x19, 0x4e, 0xa4,
+	0xeb, 0xe4, 0x02, 0x3a, 0xca, 0x2d, 0x1f, 0x72, 0x91, 0xb0, 0x8c, 0xe3, 0x09, 0xf4, 0x26, 0x74,
+	0xc6, 0xd6, 0xd9, 0x9c, 0xde, 0x26, 0xcb, 0x65, 0xc2, 0xf5, 0xf9, 0x77, 0xb2, 0xe4, 0x05, 0x1c,
+	0x7e, 0xa6, 0x2b, 0x9e, 0xb0, 0xec, 0x3a, 0xfb, 0xca, 0xe4, 0x56, 0x1a, 0x6a, 0xbe, 0x81, 0xa7,
+	0xc7, 0xdb, 0xd7, 0x44, 0x00, 0x27, 0xb8, 0xba, 0xbc, 0x7b, 0x1f, 0xf6, 0x6b, 0x32, 0x9e, 0x84,
+	0x37, 0xe1, 0x7d, 0xd8, 0xb7, 0xfc, 0x3f, 0x75, 0x80, 0x09, 0xfb, 0x99, 0x71, 0xb1, 0xa2, 0x71,
+	0x8a, 0x63, 0x68, 0x49, 0xb4, 0x64, 0xf1, 0x03, 0x76, 0xcd, 0xec, 0x4a, 0xc8, 0x61, 0x77, 0xe3,
+	0x04, 0xe9, 0x9b, 0x9a, 0x67, 0x9d, 0x59, 0xf8, 0x1a, 0x9a, 0x81, 0xb6, 0x59, 0x59, 0x57, 0x5a,
+	0x0e, 0xff, 0xab, 0x1a, 0x47, 0x37, 0x9d, 0x59, 0x78, 0x6e, 0xfc, 0xce, 0x03, 0xe5, 0xf7, 0x9d,
+	0xbe, 0x41, 0xb5, 0x4f, 0x9b, 0xbf, 0x86, 0x2f, 0x8d, 0xb2, 0xbb, 0x73, 0xe1, 0xae, 0xc4, 0x94,
+	0x93, 0x1a, 0xbe, 0x2a, 0x75, 0xd9, 0x3b, 0xd8, 0x96, 0x8c, 0xa4, 0x86, 0x6f, 0xf5, 0x3d, 0xcc,
+	0x3a, 0xe5, 0x1c, 0xdb, 0x57, 0xda, 0xbb, 0x95, 0x3f, 0x83, 0xd6, 0x34, 0xd7, 0x22, 0x9e, 0x82,
+	0x33, 0xcd, 0xab, 0x12, 0x2a, 0xe2, 0xb0, 0x3a, 0x89, 0x94, 0x50, 0x72, 0x23, 0x9a, 0xb2, 0x1f,
+	0x74, 0xaf, 0xdc, 0x25, 0x77, 0xe6, 0xa8, 0xff, 0xcd, 0x9b, 0xbf, 0x03, 0x00, 0x8a, 0x22, 0x98,
+	0x3c, 0x7f, 0x04, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -418,6 +603,9 @@ type DownstreamClient interface {
 	Download(ctx context.Context, opts ...grpc.CallOption) (Downstream_DownloadClient, error)
 	Changes(ctx context.Context, in *Empty, opts ...grpc.CallOption) (Downstream_ChangesClient, error)
 	ChangesCount(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ChangeAmount, error)
+	Hashes(ctx context.Context, in *Paths, opts ...grpc.CallOption) (*FileHashes, error)
+	Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionInfo, error)
+	WatchChanges(ctx context.Context, in *WatchOptions, opts ...grpc.CallOption) (Downstream_WatchChangesClient, error)
 }
 
 type downstreamClient struct {
@@ -500,11 +688,64 @@ func (c *downstreamClient) ChangesCount(ctx context.Context, in *Empty, opts ...
 	return out, nil
 }
 
+func (c *downstreamClient) Hashes(ctx context.Context, in *Paths, opts ...grpc.CallOption) (*FileHashes, error) {
+	out := new(FileHashes)
+	err := c.cc.Invoke(ctx, "/remote.Downstream/Hashes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downstreamClient) Version(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*VersionInfo, error) {
+	out := new(VersionInfo)
+	err := c.cc.Invoke(ctx, "/remote.Downstream/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *downstreamClient) WatchChanges(ctx context.Context, in *WatchOptions, opts ...grpc.CallOption) (Downstream_WatchChangesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Downstream_serviceDesc.Streams[2], "/remote.Downstream/WatchChanges", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &downstreamWatchChangesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Downstream_WatchChangesClient interface {
+	Recv() (*ChangeChunk, error)
+	grpc.ClientStream
+}
+
+type downstreamWatchChangesClient struct {
+	grpc.ClientStream
+}
+
+func (x *downstreamWatchChangesClient) Recv() (*ChangeChunk, error) {
+	m := new(ChangeChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DownstreamServer is the server API for Downstream service.
 type DownstreamServer interface {
 	Download(Downstream_DownloadServer) error
 	Changes(*Empty, Downstream_ChangesServer) error
 	ChangesCount(context.Context, *Empty) (*ChangeAmount, error)
+	Hashes(context.Context, *Paths) (*FileHashes, error)
+	Version(context.Context, *Empty) (*VersionInfo, error)
+	WatchChanges(*WatchOptions, Downstream_WatchChangesServer) error
 }
 
 func RegisterDownstreamServer(s *grpc.Server, srv DownstreamServer) {
@@ -576,6 +817,63 @@ func _Downstream_ChangesCount_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Downstream_Hashes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Paths)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownstreamServer).Hashes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Downstream/Hashes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownstreamServer).Hashes(ctx, req.(*Paths))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downstream_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DownstreamServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.Downstream/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DownstreamServer).Version(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Downstream_WatchChanges_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOptions)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DownstreamServer).WatchChanges(m, &downstreamWatchChangesServer{stream})
+}
+
+type Downstream_WatchChangesServer interface {
+	Send(*ChangeChunk) error
+	grpc.ServerStream
+}
+
+type downstreamWatchChangesServer struct {
+	grpc.ServerStream
+}
+
+func (x *downstreamWatchChangesServer) Send(m *ChangeChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Downstream_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "remote.Downstream",
 	HandlerType: (*DownstreamServer)(nil),
@@ -584,6 +882,14 @@ var _Downstream_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ChangesCount",
 			Handler:    _Downstream_ChangesCount_Handler,
 		},
+		{
+			MethodName: "Hashes",
+			Handler:    _Downstream_Hashes_Handler,
+		},
+		{
+			MethodName: "Version",
+			Handler:    _Downstream_Version_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -597,6 +903,11 @@ var _Downstream_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Downstream_Changes_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchChanges",
+			Handler:       _Downstream_WatchChanges_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "remote.proto",
 }
@@ -0,0 +1,5 @@
+package remote
+
+// ProtocolVersion is the version of the sync protocol that is implemented by the sync helper. Sync helpers that
+// do not implement the Version rpc yet speak version 1, which only supports polling for changes
+const ProtocolVersion = 2
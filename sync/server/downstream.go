@@ -6,38 +6,47 @@ import (
 	"context"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/devspace-cloud/devspace/sync/remote"
 	"github.com/devspace-cloud/devspace/sync/util"
 	"github.com/pkg/errors"
+	"github.com/rjeczalik/notify"
 	gitignore "github.com/sabhiram/go-gitignore"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
+// defaultDebounceInterval is the time the downstream server waits for further file events before it sends
+// the collected changes to a watching client
+const defaultDebounceInterval = 300 * time.Millisecond
+
+// maxDebounceIntervals limits how many debounce intervals a batch is delayed if the watched files change continuously
+const maxDebounceIntervals = 10
+
+// watch starts watching the given path, tests replace it to simulate watch errors
+var watch = notify.Watch
+
 // StartDownstreamServer starts a new downstream server with the given reader and writer
 func StartDownstreamServer(remotePath string, excludePaths []string, reader io.Reader, writer io.Writer, exitOnClose bool) error {
 	pipe := util.NewStdStreamJoint(reader, writer, exitOnClose)
 	lis := util.NewStdinListener()
 	done := make(chan error)
 
-	// Compile ignore paths
-	ignoreMatcher, err := compilePaths(excludePaths)
+	s, err := newDownstreamServer(remotePath, excludePaths)
 	if err != nil {
-		return errors.Wrap(err, "compile paths")
+		return err
 	}
 
 	go func() {
-		s := grpc.NewServer()
-
-		remote.RegisterDownstreamServer(s, &Downstream{
-			RemotePath:    remotePath,
-			ignoreMatcher: ignoreMatcher,
-		})
-		reflection.Register(s)
-
 		done <- s.Serve(lis)
 	}()
 
@@ -45,6 +54,35 @@ func StartDownstreamServer(remotePath string, excludePaths []string, reader io.R
 	return <-done
 }
 
+// ServeDownstreamServer starts a new downstream server that accepts connections on the given listener. The server is stopped
+// if no client was connected for the given idle timeout
+func ServeDownstreamServer(remotePath string, excludePaths []string, lis net.Listener, idleTimeout time.Duration) error {
+	s, err := newDownstreamServer(remotePath, excludePaths, util.KeepaliveServerOption())
+	if err != nil {
+		return err
+	}
+
+	return s.Serve(util.NewIdleListener(lis, idleTimeout, s.Stop))
+}
+
+func newDownstreamServer(remotePath string, excludePaths []string, options ...grpc.ServerOption) (*grpc.Server, error) {
+	// Compile ignore paths
+	ignoreMatcher, err := compilePaths(excludePaths)
+	if err != nil {
+		return nil, errors.Wrap(err, "compile paths")
+	}
+
+	s := grpc.NewServer(options...)
+
+	remote.RegisterDownstreamServer(s, &Downstream{
+		RemotePath:    remotePath,
+		ignoreMatcher: ignoreMatcher,
+	})
+	reflection.Register(s)
+
+	return s, nil
+}
+
 // Downstream is the implementation for the downstream server
 type Downstream struct {
 	// RemotePath is the path to watch for changes
@@ -54,7 +92,8 @@ type Downstream struct {
 	ignoreMatcher gitignore.IgnoreParser
 
 	// watchedFiles is a memory map of the previous state of the changes function
-	watchedFiles map[string]*remote.Change
+	watchedFiles      map[string]*remote.Change
+	watchedFilesMutex sync.Mutex
 }
 
 // Download sends the file at the temp download location to the client
@@ -137,6 +176,9 @@ func (d *Downstream) compress(writer io.WriteCloser, files []string) error {
 
 // ChangesCount returns the amount of changes on the remote side
 func (d *Downstream) ChangesCount(context.Context, *remote.Empty) (*remote.ChangeAmount, error) {
+	d.watchedFilesMutex.Lock()
+	defer d.watchedFilesMutex.Unlock()
+
 	newState := make(map[string]*remote.Change)
 
 	// Walk through the dir
@@ -154,6 +196,9 @@ func (d *Downstream) ChangesCount(context.Context, *remote.Empty) (*remote.Chang
 
 // Changes retrieves all changes from the watchpath
 func (d *Downstream) Changes(empty *remote.Empty, stream remote.Downstream_ChangesServer) error {
+	d.watchedFilesMutex.Lock()
+	defer d.watchedFilesMutex.Unlock()
+
 	newState := make(map[string]*remote.Change)
 
 	// Walk through the dir
@@ -168,6 +213,138 @@ func (d *Downstream) Changes(empty *remote.Empty, stream remote.Downstream_Chang
 	return nil
 }
 
+// Version returns the sync protocol version of the server
+func (d *Downstream) Version(context.Context, *remote.Empty) (*remote.VersionInfo, error) {
+	return &remote.VersionInfo{
+		Version: remote.ProtocolVersion,
+	}, nil
+}
+
+// WatchChanges watches the remote path for file system events and streams the changes to the client. Events are
+// collected until no further event occurred for the debounce interval, so that a build that touches thousands
+// of files results in a single batch. The last chunk of every batch is marked with LastChunk
+func (d *Downstream) WatchChanges(options *remote.WatchOptions, stream remote.Downstream_WatchChangesServer) error {
+	debounceInterval := defaultDebounceInterval
+	if options.DebounceMillis > 0 {
+		debounceInterval = time.Duration(options.DebounceMillis) * time.Millisecond
+	}
+
+	events := make(chan notify.EventInfo, 1000)
+	err := watch(filepath.Join(d.RemotePath, "..."), events, notify.All)
+	if err != nil {
+		if isWatchLimitError(err) {
+			return status.Errorf(codes.FailedPrecondition, "the file watcher for %s has run out of inotify watches (%v). Please increase the limit on the node with 'sudo sysctl fs.inotify.max_user_watches=524288' or sync a smaller directory via containerPath", d.RemotePath, err)
+		}
+
+		return status.Errorf(codes.FailedPrecondition, "watch %s: %v", d.RemotePath, err)
+	}
+	defer notify.Stop(events)
+
+	// Send everything that changed since the client retrieved the changes the last time
+	err = d.sendBatch(stream)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			if d.ignoreMatcher != nil && d.ignoreMatcher.MatchesPath(event.Path()) {
+				continue
+			}
+		}
+
+		// Wait until the file system is quiet again
+		deadline := time.After(debounceInterval * maxDebounceIntervals)
+	Debounce:
+		for {
+			select {
+			case <-stream.Context().Done():
+				return nil
+			case <-events:
+				continue
+			case <-deadline:
+				break Debounce
+			case <-time.After(debounceInterval):
+				break Debounce
+			}
+		}
+
+		err = d.sendBatch(stream)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// isWatchLimitError checks if the given error was caused by exceeding the inotify watch limit
+func isWatchLimitError(err error) bool {
+	cause := errors.Cause(err)
+	if syscallErr, ok := cause.(*os.SyscallError); ok {
+		cause = syscallErr.Err
+	}
+	if cause == syscall.ENOSPC {
+		return true
+	}
+
+	return strings.Contains(err.Error(), syscall.ENOSPC.Error())
+}
+
+// sendBatch sends all changes since the last state as a single batch
+func (d *Downstream) sendBatch(stream remote.Downstream_WatchChangesServer) error {
+	d.watchedFilesMutex.Lock()
+	defer d.watchedFilesMutex.Unlock()
+
+	newState := make(map[string]*remote.Change)
+	walkDir(d.RemotePath, d.ignoreMatcher, newState)
+
+	changeAmount, err := streamChanges(d.RemotePath, d.watchedFiles, newState, stream)
+	if err != nil {
+		return errors.Wrap(err, "stream changes")
+	}
+
+	d.watchedFiles = newState
+	if changeAmount == 0 {
+		return nil
+	}
+
+	return stream.Send(&remote.ChangeChunk{LastChunk: true})
+}
+
+// Hashes returns the content digests of the given files. Files that do not exist, are excluded or are
+// directories are left out of the response
+func (d *Downstream) Hashes(ctx context.Context, paths *remote.Paths) (*remote.FileHashes, error) {
+	hashes := make([]*remote.FileHash, 0, len(paths.Paths))
+	for _, relativePath := range paths.Paths {
+		absolutePath := filepath.Join(d.RemotePath, relativePath)
+		if d.ignoreMatcher != nil && d.ignoreMatcher.MatchesPath(absolutePath) {
+			continue
+		}
+
+		stat, err := os.Stat(absolutePath)
+		if err != nil || stat.IsDir() {
+			continue
+		}
+
+		hash, err := util.HashFile(absolutePath)
+		if err != nil {
+			// File is suddenly not readable anymore, the client will fall back to its default comparison
+			continue
+		}
+
+		hashes = append(hashes, &remote.FileHash{
+			Path: relativePath,
+			Hash: hash,
+		})
+	}
+
+	return &remote.FileHashes{
+		Hashes: hashes,
+	}, nil
+}
+
 func streamChanges(basePath string, oldState map[string]*remote.Change, newState map[string]*remote.Change, stream remote.Downstream_ChangesServer) (int64, error) {
 	changeAmount := int64(0)
 	if oldState == nil {
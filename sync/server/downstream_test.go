@@ -5,12 +5,19 @@ import (
 	"io"
 	"io/ioutil"
 	"log"
+	"net"
 	"os"
 	"path/filepath"
+	"strings"
+	"syscall"
 	"testing"
+	"time"
 
 	"github.com/devspace-cloud/devspace/sync/remote"
 	"github.com/devspace-cloud/devspace/sync/util"
+	"github.com/rjeczalik/notify"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestDownstreamServer(t *testing.T) {
@@ -127,7 +134,7 @@ func TestDownstreamServer(t *testing.T) {
 	w.Close()
 	log.Println("Downloaded complete file")
 
-	err = untarAll(r, toDir, "")
+	err = untarAll(r, toDir, "", nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -201,3 +208,218 @@ func getAllChanges(changesClient remote.Downstream_ChangesClient) ([]*remote.Cha
 
 	return changes, nil
 }
+
+func TestServeDownstreamServer(t *testing.T) {
+	fromDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fromDir)
+
+	err = createFiles(fromDir, fileStructure)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serverDone := make(chan error)
+	go func() {
+		serverDone <- ServeDownstreamServer(fromDir, []string{}, lis, time.Second)
+	}()
+
+	conn, err := util.NewAddressClientConnection(lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	amount, err := remote.NewDownstreamClient(conn).ChangesCount(context.Background(), &remote.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if amount.Amount == 0 {
+		t.Fatal("Expected changes from the tcp downstream server")
+	}
+
+	// The server should stop after the client is gone for the idle timeout
+	conn.Close()
+	select {
+	case err := <-serverDone:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Downstream server didn't stop after idle timeout")
+	}
+}
+
+func TestDownstreamHashes(t *testing.T) {
+	fromDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fromDir)
+
+	err = createFiles(fromDir, fileStructure)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go ServeDownstreamServer(fromDir, []string{"test-123"}, lis, time.Second)
+
+	conn, err := util.NewAddressClientConnection(lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	hashes, err := remote.NewDownstreamClient(conn).Hashes(context.Background(), &remote.Paths{
+		Paths: []string{"/test.txt", "/dir1/dir1-child/test", "/dir1/dir1-child/test-123", "/dir1", "/notexisting"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hashes.Hashes) != 2 {
+		t.Fatalf("Expected 2 hashes, got %d", len(hashes.Hashes))
+	}
+
+	for _, hash := range hashes.Hashes {
+		expected, err := util.HashFile(filepath.Join(fromDir, hash.Path))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash.Hash != expected {
+			t.Fatalf("Unexpected hash for %s: expected %s, got %s", hash.Path, expected, hash.Hash)
+		}
+	}
+}
+
+func TestDownstreamWatchChanges(t *testing.T) {
+	fromDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fromDir)
+
+	err = createFiles(fromDir, fileStructure)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go ServeDownstreamServer(fromDir, []string{"test-123"}, lis, time.Second)
+
+	conn, err := util.NewAddressClientConnection(lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := remote.NewDownstreamClient(conn)
+	version, err := client.Version(context.Background(), &remote.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version.Version != remote.ProtocolVersion {
+		t.Fatalf("Unexpected protocol version, expected %d, got %d", remote.ProtocolVersion, version.Version)
+	}
+
+	// Retrieve the initial state
+	changesClient, err := client.Changes(context.Background(), &remote.Empty{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = getAllChanges(changesClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watchClient, err := client.WatchChanges(ctx, &remote.WatchOptions{DebounceMillis: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wait until the server watches the directory
+	time.Sleep(time.Second)
+
+	// Excluded files should not show up in the batch
+	for _, name := range []string{"a", "b", "c", "d", "e", "test-123"} {
+		err = ioutil.WriteFile(filepath.Join(fromDir, name), []byte(name), 0666)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes := []*remote.Change{}
+	for {
+		changeChunk, err := watchClient.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		changes = append(changes, changeChunk.Changes...)
+		if changeChunk.LastChunk {
+			break
+		}
+	}
+
+	if len(changes) != 5 {
+		t.Fatalf("Expected 5 changes in a single batch, got %d", len(changes))
+	}
+}
+
+func TestDownstreamWatchLimit(t *testing.T) {
+	fromDir, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fromDir)
+
+	// Simulate a node that has run out of inotify watches
+	defer func() { watch = notify.Watch }()
+	watch = func(path string, c chan<- notify.EventInfo, events ...notify.Event) error {
+		return syscall.ENOSPC
+	}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go ServeDownstreamServer(fromDir, []string{}, lis, time.Second)
+
+	conn, err := util.NewAddressClientConnection(lis.Addr().String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	client := remote.NewDownstreamClient(conn)
+	watchClient, err := client.WatchChanges(context.Background(), &remote.WatchOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = watchClient.Recv()
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("Expected a FailedPrecondition error, got %v", err)
+	}
+	if !strings.Contains(status.Convert(err).Message(), "fs.inotify.max_user_watches") {
+		t.Fatalf("Expected the error to explain how to increase the watch limit, got %s", status.Convert(err).Message())
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -15,13 +16,41 @@ import (
 	"github.com/pkg/errors"
 )
 
+// FileOwner is the user and group id of a file. An id of -1 keeps the id of the user that creates the file
+type FileOwner struct {
+	UID int
+	GID int
+}
+
+// ParseFileOwner parses an owner in the format UID[:GID]
+func ParseFileOwner(owner string) (*FileOwner, error) {
+	splitted := strings.SplitN(owner, ":", 2)
+	uid, err := strconv.Atoi(splitted[0])
+	if err != nil {
+		return nil, fmt.Errorf("Invalid owner %s, expected UID[:GID]", owner)
+	}
+
+	gid := -1
+	if len(splitted) == 2 {
+		gid, err = strconv.Atoi(splitted[1])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid owner %s, expected UID[:GID]", owner)
+		}
+	}
+
+	return &FileOwner{
+		UID: uid,
+		GID: gid,
+	}, nil
+}
+
 type fileInformation struct {
 	Name  string
 	Size  int64
 	Mtime time.Time
 }
 
-func untarAll(reader io.Reader, destPath, prefix string) error {
+func untarAll(reader io.Reader, destPath, prefix string, owner *FileOwner) error {
 	gzr, err := gzip.NewReader(reader)
 	if err != nil {
 		return fmt.Errorf("Error decompressing: %v", err)
@@ -31,7 +60,7 @@ func untarAll(reader io.Reader, destPath, prefix string) error {
 	tarReader := tar.NewReader(gzr)
 
 	for {
-		shouldContinue, err := untarNext(tarReader, destPath, prefix)
+		shouldContinue, err := untarNext(tarReader, destPath, prefix, owner)
 		if err != nil {
 			return errors.Wrap(err, "untarNext")
 		} else if shouldContinue == false {
@@ -40,7 +69,7 @@ func untarAll(reader io.Reader, destPath, prefix string) error {
 	}
 }
 
-func untarNext(tarReader *tar.Reader, destPath, prefix string) (bool, error) {
+func untarNext(tarReader *tar.Reader, destPath, prefix string, owner *FileOwner) (bool, error) {
 	header, err := tarReader.Next()
 	if err != nil {
 		if err != io.EOF {
@@ -57,12 +86,12 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string) (bool, error) {
 	// Check if newer file is there and then don't override?
 	stat, _ := os.Stat(outFileName)
 
-	if err := os.MkdirAll(baseName, 0755); err != nil {
+	if err := mkdirAll(baseName, owner); err != nil {
 		return false, errors.Wrap(err, "mkdir all "+baseName)
 	}
 
 	if header.FileInfo().IsDir() {
-		if err := os.MkdirAll(outFileName, 0755); err != nil {
+		if err := mkdirAll(outFileName, owner); err != nil {
 			return false, errors.Wrap(err, "mkdir all "+outFileName)
 		}
 
@@ -90,7 +119,10 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string) (bool, error) {
 	}
 
 	// Set old permissions and owner and group
-	if stat != nil {
+	if stat == nil && owner != nil {
+		// Errors are ignored, because only root is allowed to change the owner
+		_ = os.Lchown(outFileName, owner.UID, owner.GID)
+	} else if stat != nil {
 		// Set old permissions correctly
 		_ = os.Chmod(outFileName, stat.Mode())
 
@@ -106,6 +138,36 @@ func untarNext(tarReader *tar.Reader, destPath, prefix string) (bool, error) {
 	return true, nil
 }
 
+// mkdirAll creates the folder and its missing parents. If owner is set, the created folders are owned by it
+func mkdirAll(dirPath string, owner *FileOwner) error {
+	if owner == nil {
+		return os.MkdirAll(dirPath, 0755)
+	}
+
+	// Find the folders that don't exist yet
+	missing := []string{}
+	for current := dirPath; ; current = path.Dir(current) {
+		if _, err := os.Stat(current); err == nil {
+			break
+		}
+
+		missing = append(missing, current)
+		if current == path.Dir(current) {
+			break
+		}
+	}
+
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return err
+	}
+
+	for _, created := range missing {
+		_ = os.Lchown(created, owner.UID, owner.GID)
+	}
+
+	return nil
+}
+
 func recursiveTar(basePath, relativePath string, writtenFiles map[string]bool, tw *tar.Writer, skipFolderContents bool) error {
 	absFilepath := path.Join(basePath, relativePath)
 	if _, ok := writtenFiles[relativePath]; ok {
@@ -3,8 +3,10 @@ package server
 import (
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/devspace-cloud/devspace/sync/remote"
 	"github.com/devspace-cloud/devspace/sync/util"
@@ -13,30 +15,47 @@ import (
 	"google.golang.org/grpc/reflection"
 )
 
-// StartUpstreamServer starts a new upstream server with the given reader and writer
-func StartUpstreamServer(uploadPath string, reader io.Reader, writer io.Writer, exitOnClose bool) error {
+// StartUpstreamServer starts a new upstream server with the given reader and writer. If owner is set, uploaded files
+// and folders that do not exist yet are owned by it
+func StartUpstreamServer(uploadPath string, owner *FileOwner, reader io.Reader, writer io.Writer, exitOnClose bool) error {
 	pipe := util.NewStdStreamJoint(reader, writer, exitOnClose)
 	lis := util.NewStdinListener()
 	done := make(chan error)
 
 	go func() {
-		s := grpc.NewServer()
-
-		remote.RegisterUpstreamServer(s, &Upstream{
-			UploadPath: uploadPath,
-		})
-		reflection.Register(s)
-
-		done <- s.Serve(lis)
+		done <- newUpstreamServer(uploadPath, owner).Serve(lis)
 	}()
 
 	lis.Ready(pipe)
 	return <-done
 }
 
+// ServeUpstreamServer starts a new upstream server that accepts connections on the given listener. The server is stopped
+// if no client was connected for the given idle timeout
+func ServeUpstreamServer(uploadPath string, owner *FileOwner, lis net.Listener, idleTimeout time.Duration) error {
+	s := newUpstreamServer(uploadPath, owner, util.KeepaliveServerOption())
+	return s.Serve(util.NewIdleListener(lis, idleTimeout, s.Stop))
+}
+
+func newUpstreamServer(uploadPath string, owner *FileOwner, options ...grpc.ServerOption) *grpc.Server {
+	s := grpc.NewServer(options...)
+
+	remote.RegisterUpstreamServer(s, &Upstream{
+		UploadPath: uploadPath,
+		Owner:      owner,
+	})
+	reflection.Register(s)
+
+	return s
+}
+
 // Upstream is the implementation for the upstream server
 type Upstream struct {
 	UploadPath string
+
+	// Owner owns the files and folders that are created by an upload, e.g. the user the container runs its
+	// process as. If nil, they are owned by the user of the sync helper
+	Owner *FileOwner
 }
 
 // Remove implements the server
@@ -76,7 +95,7 @@ func (u *Upstream) Upload(stream remote.Upstream_UploadServer) error {
 		writerErrChan <- u.writeTar(writer, stream)
 	}()
 
-	err = untarAll(reader, u.UploadPath, "")
+	err = untarAll(reader, u.UploadPath, "", u.Owner)
 	if err != nil {
 		return errors.Wrap(err, "untar all")
 	}
@@ -3,10 +3,13 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/devspace-cloud/devspace/sync/server"
+	"github.com/devspace-cloud/devspace/sync/util"
 )
 
 type arrayFlags []string
@@ -20,8 +23,11 @@ func (i *arrayFlags) Set(value string) error {
 	return nil
 }
 
+// listenIdleTimeout is the time after which a server started with --listen exits if no client is connected
+const listenIdleTimeout = 5 * time.Minute
+
 func printUsage() {
-	fmt.Fprintf(os.Stderr, "Usage: sync [--version] [--upstream] [--downstream] [--exclude] PATH\n")
+	fmt.Fprintf(os.Stderr, "Usage: sync [--version] [--upstream] [--downstream] [--exclude] [--listen] [--owner] PATH\n")
 	os.Exit(1)
 }
 
@@ -34,6 +40,8 @@ func main() {
 		isDownstream = flag.Bool("downstream", false, "Starts the downstream service")
 		isUpstream   = flag.Bool("upstream", false, "Starts the upstream service")
 		showVersion  = flag.Bool("version", false, "Shows the version")
+		listen       = flag.String("listen", "", "Listens on the given tcp address instead of using stdin and stdout")
+		owner        = flag.String("owner", "", "Owner (UID[:GID]) of the files and folders the upstream creates")
 	)
 
 	flag.Var(&excludePaths, "exclude", "The exclude paths for downstream watching")
@@ -54,6 +62,17 @@ func main() {
 		printUsage()
 	}
 
+	var fileOwner *server.FileOwner
+	if *owner != "" {
+		parsedOwner, err := server.ParseFileOwner(*owner)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+
+		fileOwner = parsedOwner
+	}
+
 	// we have to resolve the real local path, because the watcher gives us the real path always
 	realLocalPath, err := filepath.EvalSymlinks(args[0])
 	if err != nil {
@@ -67,14 +86,37 @@ func main() {
 		os.Exit(1)
 	}
 
-	if *isDownstream {
+	if *listen != "" {
+		if *isDownstream == false && *isUpstream == false {
+			printUsage()
+		}
+
+		lis, err := net.Listen("tcp", *listen)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+
+		// Tell the client that we are ready to accept connections
+		fmt.Fprintf(os.Stdout, "%s\n", util.ListenReadyMessage)
+
+		if *isDownstream {
+			err = server.ServeDownstreamServer(absolutePath, excludePaths, lis, listenIdleTimeout)
+		} else {
+			err = server.ServeUpstreamServer(absolutePath, fileOwner, lis, listenIdleTimeout)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v", err)
+			os.Exit(1)
+		}
+	} else if *isDownstream {
 		err := server.StartDownstreamServer(absolutePath, excludePaths, os.Stdin, os.Stdout, true)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%v", err)
 			os.Exit(1)
 		}
 	} else if *isUpstream {
-		err := server.StartUpstreamServer(absolutePath, os.Stdin, os.Stdout, true)
+		err := server.StartUpstreamServer(absolutePath, fileOwner, os.Stdin, os.Stdout, true)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%v", err)
 			os.Exit(1)
@@ -6,8 +6,23 @@ import (
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
 )
 
+// ListenReadyMessage is printed by the sync helper as soon as it accepts tcp connections
+const ListenReadyMessage = "Listening for connections"
+
+// KeepaliveInterval is the interval in which clients connected over tcp send keepalive pings
+const KeepaliveInterval = 10 * time.Second
+
+// KeepaliveServerOption returns the server option that allows clients to send keepalive pings every KeepaliveInterval
+func KeepaliveServerOption() grpc.ServerOption {
+	return grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+		MinTime:             KeepaliveInterval / 2,
+		PermitWithoutStream: true,
+	})
+}
+
 // NewClientConnection creates a new client connection for the given reader and writer
 func NewClientConnection(reader io.Reader, writer io.Writer) (*grpc.ClientConn, error) {
 	pipe := NewStdStreamJoint(reader, writer, false)
@@ -17,3 +32,27 @@ func NewClientConnection(reader io.Reader, writer io.Writer) (*grpc.ClientConn,
 		return pipe, nil
 	}))
 }
+
+// NewAddressClientConnection creates a new client connection to the given tcp address. Keepalive pings are sent
+// every KeepaliveInterval and calls wait until the connection is (re)established. If wrapConn is not nil, the
+// tcp connection is wrapped with it
+func NewAddressClientConnection(address string, wrapConn func(net.Conn) net.Conn) (*grpc.ClientConn, error) {
+	return grpc.Dial(address, grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                KeepaliveInterval,
+			Timeout:             KeepaliveInterval,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithDialer(func(addr string, timeout time.Duration) (net.Conn, error) {
+			conn, err := net.DialTimeout("tcp", addr, timeout)
+			if err != nil {
+				return nil, err
+			}
+			if wrapConn != nil {
+				return wrapConn(conn), nil
+			}
+
+			return conn, nil
+		}))
+}
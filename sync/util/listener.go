@@ -2,6 +2,8 @@ package util
 
 import (
 	"net"
+	"sync"
+	"time"
 )
 
 // NewStdinListener creates a new stdin listener
@@ -35,3 +37,66 @@ func (lis *StdinListener) Close() error {
 func (lis *StdinListener) Addr() net.Addr {
 	return NewStdinAddr("listener")
 }
+
+// NewIdleListener wraps the given listener and calls onIdle if there was no open connection for the given timeout
+func NewIdleListener(lis net.Listener, timeout time.Duration, onIdle func()) *IdleListener {
+	idleListener := &IdleListener{
+		Listener: lis,
+		timeout:  timeout,
+		onIdle:   onIdle,
+	}
+
+	idleListener.timer = time.AfterFunc(timeout, onIdle)
+	return idleListener
+}
+
+// IdleListener is a listener that detects if no client is connected anymore
+type IdleListener struct {
+	net.Listener
+
+	timeout time.Duration
+	onIdle  func()
+
+	timerMutex  sync.Mutex
+	timer       *time.Timer
+	activeConns int
+}
+
+// Accept implements interface
+func (lis *IdleListener) Accept() (net.Conn, error) {
+	conn, err := lis.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	lis.timerMutex.Lock()
+	defer lis.timerMutex.Unlock()
+
+	lis.activeConns++
+	lis.timer.Stop()
+
+	return &idleConn{Conn: conn, listener: lis}, nil
+}
+
+func (lis *IdleListener) connClosed() {
+	lis.timerMutex.Lock()
+	defer lis.timerMutex.Unlock()
+
+	lis.activeConns--
+	if lis.activeConns == 0 {
+		lis.timer.Reset(lis.timeout)
+	}
+}
+
+type idleConn struct {
+	net.Conn
+
+	listener  *IdleListener
+	closeOnce sync.Once
+}
+
+// Close implements interface
+func (c *idleConn) Close() error {
+	c.closeOnce.Do(c.listener.connClosed)
+	return c.Conn.Close()
+}